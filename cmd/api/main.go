@@ -1,18 +1,27 @@
 package main
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"log"
 	"net/http"
 	"os"
+	"strconv"
 	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/cors"
 	"github.com/joho/godotenv"
+	"github.com/june20516/orbithall/internal/auth"
 	"github.com/june20516/orbithall/internal/database"
 	"github.com/june20516/orbithall/internal/handlers"
+	"github.com/june20516/orbithall/internal/httputil"
+	"github.com/june20516/orbithall/internal/ipcrypt"
+	"github.com/june20516/orbithall/internal/jobs"
+	"github.com/june20516/orbithall/internal/models"
+	"github.com/june20516/orbithall/internal/notify"
 	"github.com/june20516/orbithall/internal/ratelimit"
 	httpSwagger "github.com/swaggo/http-swagger/v2"
 	"golang.org/x/time/rate"
@@ -44,6 +53,11 @@ import (
 // @name Authorization
 // @description JWT 토큰 ("Bearer " 접두사 포함)
 
+// @securityDefinitions.apikey SecretKeyAuth
+// @in header
+// @name X-Orbithall-API-Key
+// @description 서버 API 접근용 비밀 키 (orb_secret_, 브라우저 요청 거부)
+
 func main() {
 	if err := run(); err != nil {
 		log.Fatal(err)
@@ -78,19 +92,163 @@ func run() error {
 
 	log.Println("Database connected successfully")
 
+	// ============================================
+	// IP 주소 암호화 키 설정
+	// ============================================
+	ipKeyring, err := ipcrypt.LoadFromEnv()
+	if err != nil {
+		return fmt.Errorf("failed to load ip encryption keys: %w", err)
+	}
+	database.SetIPKeyring(ipKeyring)
+
+	// ============================================
+	// 신뢰하는 프록시 설정
+	// ============================================
+	// 직접 연결된 주소가 TRUSTED_PROXIES 대역에 속할 때만 Forwarded, X-Forwarded-For, X-Real-IP 헤더로 클라이언트 IP를 판단
+	// 설정하지 않으면 헤더를 무시하고 직접 연결된 주소를 클라이언트 IP로 사용 (헤더 조작으로 IP를 위장할 수 없음)
+	trustedProxies, err := httputil.ParseTrustedProxies(os.Getenv("TRUSTED_PROXIES"))
+	if err != nil {
+		return fmt.Errorf("invalid TRUSTED_PROXIES: %w", err)
+	}
+	httputil.SetTrustedProxies(trustedProxies)
+
+	// ============================================
+	// 사이트 캐시 설정
+	// ============================================
+	// API 키로 조회한 사이트 정보를 최대 SITE_CACHE_SIZE개까지 메모리에 캐시 (기본 10000개)
+	siteCacheSize := database.DefaultSiteCacheSize
+	if value := os.Getenv("SITE_CACHE_SIZE"); value != "" {
+		siteCacheSize, err = strconv.Atoi(value)
+		if err != nil || siteCacheSize < 1 {
+			return fmt.Errorf("invalid SITE_CACHE_SIZE: %q", value)
+		}
+	}
+	database.SetSiteCache(database.NewMemorySiteCache(siteCacheSize))
+
+	// ============================================
+	// 사용자 알림 설정
+	// ============================================
+	// SMTP_ADDR을 설정하면 소유권 이전 등 사용자 알림을 메일로 전송하고, 설정하지 않으면 서버 로그에 기록
+	notifier, err := notify.LoadFromEnv()
+	if err != nil {
+		return fmt.Errorf("failed to configure notifications: %w", err)
+	}
+
+	// ============================================
+	// 로그인 ID 공급자 설정
+	// ============================================
+	// Google은 항상 사용하며, OIDC_ISSUER_URL을 설정하면 OIDC 공급자(Keycloak 등)로도 로그인할 수 있음
+	identityProviders, err := auth.LoadProvidersFromEnv()
+	if err != nil {
+		return fmt.Errorf("failed to configure identity providers: %w", err)
+	}
+	for _, provider := range identityProviders {
+		log.Printf("Identity provider enabled: %s", provider.Name())
+	}
+
+	// ============================================
+	// JWT 서명 키 설정
+	// ============================================
+	// JWT_SIGNING_KEYS의 첫 번째 키로 access token을 서명하고, 나머지 키와 JWT_SECRET은 이전에 서명한 토큰 검증에만 사용
+	// 공개 키는 /.well-known/jwks.json으로 공개하여 다른 서비스도 토큰을 검증할 수 있음
+	signingKeys, err := auth.LoadSigningKeysFromEnv()
+	if err != nil {
+		return fmt.Errorf("failed to load jwt signing keys: %w", err)
+	}
+	auth.SetSigningKeys(signingKeys)
+	if signingKeys.ActiveKeyID() != "" {
+		log.Printf("JWT signing key: %s", signingKeys.ActiveKeyID())
+	} else {
+		log.Println("[WARN] JWT_SIGNING_KEYS not set, signing access tokens with JWT_SECRET (HS256)")
+	}
+
 	// ============================================
 	// 핸들러 초기화
 	// ============================================
 	commentHandler := handlers.NewCommentHandler(db)
 	authHandler := handlers.NewAuthHandler(db)
+	authHandler.SetProviders(identityProviders...)
 	adminHandler := handlers.NewAdminHandler(db)
+	adminHandler.SetNotifier(notifier)
+	serverHandler := handlers.NewServerHandler(db)
+
+	// ============================================
+	// 백그라운드 작업
+	// ============================================
+	// 댓글 수 보정: posts.comment_count를 실제 댓글 수와 주기적으로 맞춤 (기본 1시간, 0이면 비활성화)
+	reconcileInterval := time.Hour
+	if value := os.Getenv("COMMENT_COUNT_RECONCILE_INTERVAL"); value != "" {
+		reconcileInterval, err = time.ParseDuration(value)
+		if err != nil {
+			return fmt.Errorf("invalid COMMENT_COUNT_RECONCILE_INTERVAL: %w", err)
+		}
+	}
+	jobCtx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+	if reconcileInterval > 0 {
+		go jobs.RunPeriodically(jobCtx, "reconcile-comment-counts", reconcileInterval, jobs.ReconcileCommentCounts(db))
+	}
+
+	// IP 주소 암호화: 평문으로 남은 IP와 이전 키로 암호화된 IP를 활성 키로 암호화 (기본 1시간, 0이면 비활성화)
+	ipEncryptionInterval := time.Hour
+	if value := os.Getenv("IP_ENCRYPTION_MIGRATE_INTERVAL"); value != "" {
+		ipEncryptionInterval, err = time.ParseDuration(value)
+		if err != nil {
+			return fmt.Errorf("invalid IP_ENCRYPTION_MIGRATE_INTERVAL: %w", err)
+		}
+	}
+	if ipEncryptionInterval > 0 {
+		go jobs.RunPeriodically(jobCtx, "encrypt-comment-ip-addresses", ipEncryptionInterval, jobs.EncryptCommentIPAddresses(db))
+	}
+
+	// 사이트 캐시 무효화: 다른 인스턴스에서 변경된 사이트/API 키의 캐시 항목을 NOTIFY로 전달받아 제거
+	go func() {
+		if err := database.ListenSiteCacheInvalidation(jobCtx, databaseURL); err != nil {
+			log.Printf("[ERROR] Site cache invalidation listener stopped: %v", err)
+		}
+	}()
+
+	// 개인정보 보관 기간 적용: 사이트별 보관 기간이 지난 IP/User-Agent와 삭제된 댓글 내용, 만료된 로그인 세션 삭제 (기본 24시간, 0이면 비활성화)
+	retentionInterval := 24 * time.Hour
+	if value := os.Getenv("RETENTION_INTERVAL"); value != "" {
+		retentionInterval, err = time.ParseDuration(value)
+		if err != nil {
+			return fmt.Errorf("invalid RETENTION_INTERVAL: %w", err)
+		}
+	}
+	if retentionInterval > 0 {
+		go jobs.RunPeriodically(jobCtx, "apply-retention-policies", retentionInterval, jobs.ApplyRetentionPolicies(db))
+	}
 
 	// ============================================
 	// Rate Limiter 초기화
 	// ============================================
-	// 댓글 작성 제한: 10 req/min, burst 5
-	// rate.Every()를 사용하여 분당 10개 = 6초당 1개로 설정
-	createCommentLimiter := ratelimit.NewRateLimiter(rate.Every(time.Minute/10), 5)
+	// 제한 상태 저장소: RATE_LIMIT_STORE=postgres면 모든 인스턴스가 DB의 제한 상태를 공유 (기본 memory: 인스턴스별 메모리)
+	// 공개 API 제한은 사이트별 설정(없으면 models.DefaultRateLimits)을 사이트와 IP 조합별로 적용 (세션 단위 제한을 켠 사이트는 세션별 제한도 함께 적용)
+	var rateLimitStore ratelimit.Store
+	switch store := os.Getenv("RATE_LIMIT_STORE"); store {
+	case "", "memory":
+		// 최대 100000개 대상까지 저장하고, 10분 이상 요청이 없던 대상은 1분마다 정리
+		// (rate.Every(time.Minute/10), 5는 GetLimiter의 기본값이며, 라우트별 제한은 아래 Rule로 적용)
+		memoryLimiter := ratelimit.NewRateLimiter(rate.Every(time.Minute/10), 5)
+		memoryLimiter.StartJanitor(time.Minute)
+		defer memoryLimiter.Stop()
+		rateLimitStore = memoryLimiter
+	case "postgres":
+		rateLimitStore = ratelimit.NewPostgresStore(db)
+	default:
+		return fmt.Errorf("invalid RATE_LIMIT_STORE: %q (must be memory or postgres)", store)
+	}
+	siteRateLimit := func(action string) func(http.Handler) http.Handler {
+		return ratelimit.RateLimitMiddleware(rateLimitStore, handlers.SiteRateLimit(action), handlers.SiteSessionRateLimit(action))
+	}
+
+	// Google 로그인 제한: IP별 10 req/min, burst 5
+	authRateLimit := ratelimit.RateLimitMiddleware(rateLimitStore, ratelimit.ByIP("auth", ratelimit.PerMinute(10, 5)))
+	// Admin API 제한: 관리자별 300 req/min, burst 60
+	adminRateLimit := ratelimit.RateLimitMiddleware(rateLimitStore, handlers.AdminRateLimit(ratelimit.PerMinute(300, 60)))
+	// 서버 API 제한: 비밀 키별 300 req/min, burst 60
+	serverRateLimit := ratelimit.RateLimitMiddleware(rateLimitStore, handlers.ServerRateLimit(ratelimit.PerMinute(300, 60)))
 
 	// ============================================
 	// 라우터 설정
@@ -102,6 +260,8 @@ func run() error {
 	// ============================================
 	// 미들웨어 등록
 	// ============================================
+	// ClientIPMiddleware: 클라이언트 IP를 한 번 결정하여 Rate Limiting, 댓글 기록, 로깅이 같은 IP를 사용하도록 함
+	r.Use(httputil.ClientIPMiddleware)
 	// Logger: 모든 HTTP 요청을 로깅 (개발 시 디버깅 용이)
 	r.Use(middleware.Logger)
 	// Recoverer: panic 발생 시 서버가 죽지 않도록 복구
@@ -119,9 +279,9 @@ func run() error {
 		// 허용할 HTTP 메서드
 		AllowedMethods: []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
 		// 허용할 요청 헤더
-		AllowedHeaders: []string{"Accept", "Authorization", "Content-Type", "X-Orbithall-API-Key", "Origin"},
+		AllowedHeaders: []string{"Accept", "Authorization", "Content-Type", "X-Orbithall-API-Key", "X-Orbithall-Session", "Origin"},
 		// 노출할 응답 헤더
-		ExposedHeaders: []string{"Link"},
+		ExposedHeaders: []string{"Link", "RateLimit-Limit", "RateLimit-Remaining", "RateLimit-Reset", "Retry-After"},
 		// 쿠키 및 인증 정보 전송 허용
 		AllowCredentials: false, // "*" origin 사용 시 false 필수
 		// preflight 요청 캐시 시간 (초)
@@ -162,10 +322,30 @@ func run() error {
 		w.Write([]byte(`{"status":"ok","service":"orbithall","database":"active_query_success"}`))
 	})
 
+	// 사이트 캐시 통계 엔드포인트 (서버 시작 이후 캐시 히트/미스 횟수, 현재 항목 수)
+	// 운영 정보이므로 OPS_TOKEN으로 보호 (설정하지 않으면 비활성화)
+	r.With(handlers.OpsTokenMiddleware(os.Getenv("OPS_TOKEN"))).Get("/health/cache", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{
+			"status":     "ok",
+			"service":    "orbithall",
+			"site_cache": database.GetSiteCacheStats(),
+		})
+	})
+
+	// JWT 공개 키 목록 (다른 서비스의 access token 검증용)
+	r.Get("/.well-known/jwks.json", authHandler.JWKS)
+
 	// Auth 라우트 그룹 (/auth 접두사, 인증 불필요)
 	r.Route("/auth", func(r chi.Router) {
 		// Google OAuth 검증 및 JWT 발급
-		r.Post("/google/verify", authHandler.GoogleVerify)
+		r.With(authRateLimit).Post("/google/verify", authHandler.GoogleVerify)
+		// 그 외 ID 공급자(OIDC) 검증 및 JWT 발급
+		r.With(authRateLimit).Post("/{provider}/verify", authHandler.ProviderVerify)
+		// refresh token으로 세션 갱신 (access token 재발급)
+		r.With(authRateLimit).Post("/refresh", authHandler.Refresh)
+		// refresh token의 세션 로그아웃
+		r.With(authRateLimit).Post("/logout", authHandler.Logout)
 	})
 
 	// API 라우트 그룹 (/api 접두사)
@@ -173,22 +353,43 @@ func run() error {
 		// 인증 미들웨어 적용 (모든 API 요청은 API 키 필요)
 		r.Use(handlers.AuthMiddleware(db))
 
-		// 댓글 CRUD 엔드포인트
-		// 댓글 작성: Rate Limiting 적용 (10 req/min, burst 5)
-		r.With(ratelimit.RateLimitMiddleware(createCommentLimiter)).Post("/posts/{slug}/comments", commentHandler.CreateComment)
-		r.Get("/posts/{slug}/comments", commentHandler.ListComments)
-		r.Put("/comments/{id}", commentHandler.UpdateComment)
-		r.Delete("/comments/{id}", commentHandler.DeleteComment)
+		// 댓글 CRUD 엔드포인트 (작업별 사이트 Rate Limiting 적용)
+		r.With(siteRateLimit(models.RateLimitActionCreate)).Post("/posts/{slug}/comments", commentHandler.CreateComment)
+		r.With(siteRateLimit(models.RateLimitActionList)).Get("/posts/{slug}/comments", commentHandler.ListComments)
+		r.With(siteRateLimit(models.RateLimitActionList)).Get("/posts/counts", commentHandler.GetCommentCounts)
+		r.With(siteRateLimit(models.RateLimitActionList)).Post("/posts/counts", commentHandler.BatchCommentCounts)
+		r.With(siteRateLimit(models.RateLimitActionUpdate)).Put("/comments/{id}", commentHandler.UpdateComment)
+		r.With(siteRateLimit(models.RateLimitActionDelete)).Delete("/comments/{id}", commentHandler.DeleteComment)
+	})
+
+	// 서버 API 라우트 그룹 (/server 접두사, 비밀 키 인증 필요)
+	// 각 엔드포인트는 키에 필요한 scope가 있어야 호출 가능
+	r.Route("/server", func(r chi.Router) {
+		r.Use(handlers.SecretKeyAuthMiddleware(db))
+		r.Use(serverRateLimit)
+
+		r.With(handlers.RequireScope(models.ScopeCommentsReadPrivate)).Get("/posts/{slug}/comments", serverHandler.ListPostComments)
+		r.With(handlers.RequireScope(models.ScopeCommentsModerate)).Delete("/comments/{id}", serverHandler.DeleteComment)
+		r.With(handlers.RequireScope(models.ScopeCommentsModerate)).Post("/comments/{id}/restore", serverHandler.RestoreComment)
+		r.With(handlers.RequireScope(models.ScopePostsWrite)).Put("/posts/{slug}", serverHandler.UpdatePost)
+		r.With(handlers.RequireScope(models.ScopePostsWrite)).Post("/posts/{slug}/lock", serverHandler.LockPost)
+		r.With(handlers.RequireScope(models.ScopePostsWrite)).Post("/posts/{slug}/unlock", serverHandler.UnlockPost)
 	})
 
 	// Admin 라우트 그룹 (/admin 접두사, JWT 인증 필요)
 	r.Route("/admin", func(r chi.Router) {
 		// JWT 인증 미들웨어 적용 (모든 Admin 요청은 JWT 토큰 필요)
 		r.Use(handlers.JWTAuthMiddleware(db))
+		r.Use(adminRateLimit)
 
 		// 프로필 조회
 		r.Get("/profile", adminHandler.GetProfile)
 
+		// 로그인 세션 관리
+		r.Get("/sessions", adminHandler.ListSessions)
+		r.Delete("/sessions", adminHandler.RevokeAllSessions)
+		r.Delete("/sessions/{sessionId}", adminHandler.RevokeSession)
+
 		// 사이트 관리
 		r.Get("/sites", adminHandler.ListSites)
 		r.Post("/sites", adminHandler.CreateSite)
@@ -196,10 +397,54 @@ func run() error {
 		r.Put("/sites/{id}", adminHandler.UpdateSite)
 		r.Delete("/sites/{id}", adminHandler.DeleteSite)
 
+		// 도메인 소유권 검증
+		r.Get("/sites/{id}/verification", adminHandler.GetSiteVerification)
+		r.Post("/sites/{id}/verification", adminHandler.VerifySiteDomain)
+
+		// 사이트 멤버 관리
+		r.Get("/sites/{id}/members", adminHandler.ListSiteMembers)
+		r.Put("/sites/{id}/members/{userId}", adminHandler.UpdateSiteMemberRole)
+		r.Delete("/sites/{id}/members/{userId}", adminHandler.RemoveSiteMember)
+
+		// 사이트 멤버 초대
+		r.Get("/sites/{id}/invitations", adminHandler.ListSiteInvitations)
+		r.Post("/sites/{id}/invitations", adminHandler.CreateSiteInvitation)
+		r.Delete("/sites/{id}/invitations/{invitationId}", adminHandler.RevokeSiteInvitation)
+		r.Post("/invitations/accept", adminHandler.AcceptInvitation)
+
+		// 사이트 소유권 이전
+		r.Post("/sites/{id}/transfer", adminHandler.RequestSiteTransfer)
+		r.Delete("/sites/{id}/transfer", adminHandler.CancelSiteTransfer)
+		r.Get("/transfers", adminHandler.ListOwnershipTransfers)
+		r.Post("/transfers/{transferId}/accept", adminHandler.AcceptOwnershipTransfer)
+		r.Post("/transfers/{transferId}/decline", adminHandler.DeclineOwnershipTransfer)
+
+		// 사이트 API 키 관리
+		r.Get("/sites/{id}/api-keys", adminHandler.ListSiteAPIKeys)
+		r.Post("/sites/{id}/api-keys", adminHandler.CreateSiteAPIKey)
+		r.Post("/sites/{id}/api-keys/{keyId}/rotate", adminHandler.RotateSiteAPIKey)
+		r.Delete("/sites/{id}/api-keys/{keyId}", adminHandler.RevokeSiteAPIKey)
+
 		// 사이트 통계 및 컨텐츠 조회 (016)
 		r.Get("/sites/{id}/stats", adminHandler.GetSiteStats)
 		r.Get("/sites/{id}/posts", adminHandler.ListSitePosts)
+		r.Get("/sites/{id}/posts/{postId}", adminHandler.GetSitePost)
+		r.Put("/sites/{id}/posts/{postId}", adminHandler.UpdateSitePost)
+		r.Post("/sites/{id}/posts/{postId}/lock", adminHandler.LockSitePost)
+		r.Post("/sites/{id}/posts/{postId}/unlock", adminHandler.UnlockSitePost)
+		r.Post("/sites/{id}/posts/{postId}/aliases", adminHandler.AddSitePostAlias)
+		r.Delete("/sites/{id}/posts/{postId}/aliases/{slug}", adminHandler.DeleteSitePostAlias)
+		r.Post("/sites/{id}/posts/{postId}/merge", adminHandler.MergeSitePost)
+		r.Post("/sites/{id}/comment-counts/reconcile", adminHandler.ReconcileSiteCommentCounts)
+		r.Delete("/sites/{id}/sandbox", adminHandler.WipeSiteSandbox)
+		r.Post("/sites/{id}/comments/{commentId}/restore", adminHandler.RestoreSiteComment)
+		r.Post("/sites/{id}/erasure", adminHandler.EraseSiteComments)
+		r.Get("/sites/{id}/audit", adminHandler.ListSiteAuditLog)
 		r.Get("/posts/{slug}/comments", adminHandler.GetPostComments)
+
+		// 외부 플랫폼 댓글 가져오기
+		r.Post("/sites/{id}/import/disqus", adminHandler.ImportDisqus)
+		r.Post("/sites/{id}/import/wordpress", adminHandler.ImportWordPress)
 	})
 
 	// ============================================
@@ -0,0 +1,133 @@
+// import는 외부 댓글 플랫폼의 내보내기 파일을 Orbithall 사이트로 가져오는 명령줄 도구입니다
+//
+// 사용 예시:
+//
+//	go run ./cmd/import -format disqus -site-id 1 -file export.xml
+//	go run ./cmd/import -format disqus -site-id 1 -file export.xml -slug-pattern '/posts/(?P<slug>[^/]+)' -dry-run
+//	go run ./cmd/import -format wordpress -site-id 1 -file wordpress.xml -include-spam -include-trash
+//
+// DATABASE_URL 환경변수가 필요합니다 (-dry-run일 때는 데이터베이스에 연결하지 않음)
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"github.com/joho/godotenv"
+	"github.com/june20516/orbithall/internal/database"
+	"github.com/june20516/orbithall/internal/importer"
+	"github.com/june20516/orbithall/internal/ipcrypt"
+)
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// parsers는 -format 값별 내보내기 파일 파서입니다
+var parsers = map[string]func(r io.Reader, wxrOpts importer.WXROptions) ([]*importer.Thread, error){
+	importer.SourceDisqus: func(r io.Reader, _ importer.WXROptions) ([]*importer.Thread, error) {
+		return importer.ParseDisqus(r)
+	},
+	importer.SourceWordPress: importer.ParseWordPress,
+}
+
+// run은 명령줄 인자를 해석하여 가져오기를 실행하고 결과 보고서를 JSON으로 출력합니다
+// 테스트 가능하도록 main()에서 분리되었습니다
+func run(args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("import", flag.ContinueOnError)
+	format := flags.String("format", importer.SourceDisqus, "내보내기 파일 형식 (disqus, wordpress)")
+	siteID := flags.Int64("site-id", 0, "댓글을 가져올 사이트 ID (필수)")
+	filePath := flags.String("file", "", "내보내기 파일 경로 (필수)")
+	slugPattern := flags.String("slug-pattern", "", "URL에서 slug를 추출할 정규식 (기본값: URL 경로의 마지막 세그먼트)")
+	dryRun := flags.Bool("dry-run", false, "저장하지 않고 매핑 결과만 출력")
+	includeSpam := flags.Bool("include-spam", false, "스팸 댓글을 삭제된 댓글로 가져오기 (wordpress)")
+	includeTrash := flags.Bool("include-trash", false, "휴지통의 댓글을 삭제된 댓글로 가져오기 (wordpress)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	// 인자 검증
+	parse, ok := parsers[*format]
+	if !ok {
+		return fmt.Errorf("unsupported format: %s", *format)
+	}
+	if *siteID <= 0 {
+		return fmt.Errorf("-site-id is required")
+	}
+	if *filePath == "" {
+		return fmt.Errorf("-file is required")
+	}
+
+	slugRule := importer.SlugRule(importer.LastPathSegment)
+	if *slugPattern != "" {
+		rule, err := importer.NewPatternSlugRule(*slugPattern)
+		if err != nil {
+			return err
+		}
+		slugRule = rule
+	}
+
+	// 내보내기 파일 파싱
+	file, err := os.Open(*filePath)
+	if err != nil {
+		return fmt.Errorf("failed to open file: %w", err)
+	}
+	defer file.Close()
+
+	threads, err := parse(file, importer.WXROptions{
+		IncludeSpam:  *includeSpam,
+		IncludeTrash: *includeTrash,
+	})
+	if err != nil {
+		return err
+	}
+
+	// 데이터베이스 연결 (dry-run이면 생략)
+	var db database.DBTX
+	if !*dryRun {
+		if os.Getenv("ENV") != "production" {
+			_ = godotenv.Load() // 에러 무시 (.env 파일 없어도 OK)
+		}
+
+		databaseURL := os.Getenv("DATABASE_URL")
+		if databaseURL == "" {
+			return fmt.Errorf("DATABASE_URL environment variable is required")
+		}
+
+		conn, err := database.New(databaseURL)
+		if err != nil {
+			return fmt.Errorf("failed to connect to database: %w", err)
+		}
+		defer database.Close(conn)
+		db = conn
+
+		// 가져온 댓글의 IP 주소 암호화 키 설정
+		ipKeyring, err := ipcrypt.LoadFromEnv()
+		if err != nil {
+			return fmt.Errorf("failed to load ip encryption keys: %w", err)
+		}
+		database.SetIPKeyring(ipKeyring)
+	}
+
+	// 가져오기
+	report, err := importer.Import(context.Background(), db, *siteID, threads, importer.Options{
+		Source:   *format,
+		SlugRule: slugRule,
+		DryRun:   *dryRun,
+	})
+	if err != nil {
+		return fmt.Errorf("import failed: %w", err)
+	}
+
+	// 결과 보고서 출력
+	encoder := json.NewEncoder(stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(report)
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/june20516/orbithall/internal/importer"
+)
+
+// TestRun_MissingFlags_ReturnsError는 필수 인자가 없을 때 에러를 반환하는지 테스트합니다
+func TestRun_MissingFlags_ReturnsError(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{"site-id 누락", []string{"-file", "export.xml"}},
+		{"file 누락", []string{"-site-id", "1"}},
+		{"지원하지 않는 형식", []string{"-format", "unknown", "-site-id", "1", "-file", "export.xml"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// When: run() 호출
+			err := run(tt.args, &bytes.Buffer{})
+
+			// Then: 에러 반환
+			if err == nil {
+				t.Fatal("expected error, got nil")
+			}
+		})
+	}
+}
+
+// TestRun_DryRun_PrintsReport는 dry-run 시 DB 연결 없이 보고서를 출력하는지 테스트합니다
+func TestRun_DryRun_PrintsReport(t *testing.T) {
+	// Given: Disqus 내보내기 파일
+	path := filepath.Join(t.TempDir(), "export.xml")
+	content := `<disqus xmlns:dsq="http://disqus.com/disqus-internals">
+  <thread dsq:id="1"><link>https://blog.example.com/posts/hello</link><title>Hello</title></thread>
+  <post dsq:id="10"><message>Hi</message><createdAt>2020-01-01T00:00:00Z</createdAt><thread dsq:id="1"/></post>
+</disqus>`
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	// When: dry-run 실행
+	var out bytes.Buffer
+	err := run([]string{"-site-id", "1", "-file", path, "-dry-run"}, &out)
+
+	// Then: 보고서 출력
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	var report importer.Report
+	if err := json.Unmarshal(out.Bytes(), &report); err != nil {
+		t.Fatalf("failed to parse report: %v", err)
+	}
+	if report.ThreadsImported != 1 || report.CommentsImported != 1 {
+		t.Errorf("unexpected report: %+v", report)
+	}
+}
+
+// TestRun_WordPressDryRun_SkipsSpamByDefault는 WordPress 형식에서 스팸 댓글을 기본적으로 건너뛰는지 테스트합니다
+func TestRun_WordPressDryRun_SkipsSpamByDefault(t *testing.T) {
+	// Given: 승인된 댓글과 스팸 댓글이 있는 WXR 파일
+	path := filepath.Join(t.TempDir(), "wordpress.xml")
+	content := `<rss xmlns:wp="http://wordpress.org/export/1.2/"><channel><item>
+  <title>Hello</title><link>https://blog.example.com/hello/</link><wp:post_name>hello</wp:post_name>
+  <wp:comment><wp:comment_id>1</wp:comment_id><wp:comment_date_gmt>2020-01-01 00:00:00</wp:comment_date_gmt><wp:comment_content>Hi</wp:comment_content><wp:comment_approved>1</wp:comment_approved></wp:comment>
+  <wp:comment><wp:comment_id>2</wp:comment_id><wp:comment_date_gmt>2020-01-01 00:00:00</wp:comment_date_gmt><wp:comment_content>Spam</wp:comment_content><wp:comment_approved>spam</wp:comment_approved></wp:comment>
+</item></channel></rss>`
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		args     []string
+		expected int
+	}{
+		{"기본값은 스팸 제외", nil, 1},
+		{"-include-spam 지정 시 포함", []string{"-include-spam"}, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// When: dry-run 실행
+			var out bytes.Buffer
+			args := append([]string{"-format", "wordpress", "-site-id", "1", "-file", path, "-dry-run"}, tt.args...)
+			if err := run(args, &out); err != nil {
+				t.Fatalf("expected no error, got: %v", err)
+			}
+
+			// Then: 가져올 댓글 수 확인
+			var report importer.Report
+			if err := json.Unmarshal(out.Bytes(), &report); err != nil {
+				t.Fatalf("failed to parse report: %v", err)
+			}
+			if report.CommentsImported != tt.expected {
+				t.Errorf("expected comments_imported=%d, got %d", tt.expected, report.CommentsImported)
+			}
+		})
+	}
+}
@@ -2,24 +2,22 @@ package auth
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"os"
 
 	"google.golang.org/api/idtoken"
 )
 
-var (
-	// ErrInvalidIDToken은 Google ID Token이 유효하지 않을 때 반환됩니다
-	ErrInvalidIDToken = errors.New("invalid google id token")
-)
+// GoogleProviderName은 Google ID 공급자 이름입니다
+const GoogleProviderName = "google"
 
 // GoogleIDTokenPayload는 Google ID Token에서 추출한 사용자 정보입니다
 type GoogleIDTokenPayload struct {
-	GoogleID string
-	Email    string
-	Name     string
-	Picture  string
+	GoogleID      string
+	Email         string
+	EmailVerified bool
+	Name          string
+	Picture       string
 }
 
 // VerifyGoogleIDToken은 Google ID Token을 검증하고 사용자 정보를 추출합니다
@@ -44,13 +42,45 @@ func VerifyGoogleIDToken(ctx context.Context, idToken string) (*GoogleIDTokenPay
 	}
 
 	email, _ := payload.Claims["email"].(string)
+	emailVerified, _ := payload.Claims["email_verified"].(bool)
 	name, _ := payload.Claims["name"].(string)
 	picture, _ := payload.Claims["picture"].(string)
 
 	return &GoogleIDTokenPayload{
-		GoogleID: googleID,
-		Email:    email,
-		Name:     name,
-		Picture:  picture,
+		GoogleID:      googleID,
+		Email:         email,
+		EmailVerified: emailVerified,
+		Name:          name,
+		Picture:       picture,
+	}, nil
+}
+
+// GoogleProvider는 Google ID Token을 검증하는 IdentityProvider입니다
+type GoogleProvider struct{}
+
+// NewGoogleProvider는 GoogleProvider를 생성합니다
+func NewGoogleProvider() *GoogleProvider {
+	return &GoogleProvider{}
+}
+
+// Name은 공급자 이름(google)을 반환합니다
+func (p *GoogleProvider) Name() string {
+	return GoogleProviderName
+}
+
+// Verify는 VerifyGoogleIDToken으로 ID Token을 검증합니다
+func (p *GoogleProvider) Verify(ctx context.Context, idToken string) (*Identity, error) {
+	payload, err := VerifyGoogleIDToken(ctx, idToken)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Identity{
+		Provider:      GoogleProviderName,
+		Subject:       payload.GoogleID,
+		Email:         payload.Email,
+		EmailVerified: payload.EmailVerified,
+		Name:          payload.Name,
+		Picture:       payload.Picture,
 	}, nil
 }
@@ -0,0 +1,207 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// jwksMinRefreshInterval은 모르는 kid로 서명 키를 다시 조회하는 최소 간격입니다
+// 잘못된 kid를 담은 토큰으로 공급자에 요청이 몰리지 않도록 제한합니다
+const jwksMinRefreshInterval = time.Minute
+
+// errUnknownSigningKey는 JWKS에 토큰의 kid에 해당하는 키가 없을 때 반환됩니다
+var errUnknownSigningKey = errors.New("unknown signing key")
+
+// jwksCache는 OIDC 공급자의 서명 키(JWKS)를 조회하고 캐시합니다
+// 캐시가 만료되었거나 모르는 kid가 오면(키 교체) 다시 조회하며,
+// 다시 조회하지 못하면 이전에 조회한 키를 계속 사용합니다
+type jwksCache struct {
+	client *http.Client
+	ttl    time.Duration
+
+	// discover는 url이 비어 있을 때 JWKS URL을 조회합니다 (discovery 문서)
+	discover func(ctx context.Context) (string, error)
+
+	mu        sync.Mutex
+	url       string
+	keys      map[string]crypto.PublicKey
+	fetchedAt time.Time
+}
+
+// key는 kid에 해당하는 공개 키를 반환합니다
+// kid가 비어 있으면 JWKS에 키가 하나뿐일 때만 그 키를 반환합니다
+func (c *jwksCache) key(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	if c.keys != nil && now.Sub(c.fetchedAt) < c.ttl {
+		if key, ok := c.lookup(kid); ok {
+			return key, nil
+		}
+		if now.Sub(c.fetchedAt) < jwksMinRefreshInterval {
+			return nil, errUnknownSigningKey
+		}
+	}
+
+	if err := c.refresh(ctx); err != nil {
+		// 조회에 실패해도 이전에 조회한 키가 있으면 사용
+		if key, ok := c.lookup(kid); ok {
+			return key, nil
+		}
+		return nil, fmt.Errorf("%w: %v", ErrProviderUnavailable, err)
+	}
+
+	if key, ok := c.lookup(kid); ok {
+		return key, nil
+	}
+	return nil, errUnknownSigningKey
+}
+
+// lookup은 캐시된 키에서 kid에 해당하는 키를 찾습니다
+func (c *jwksCache) lookup(kid string) (crypto.PublicKey, bool) {
+	if kid == "" {
+		if len(c.keys) != 1 {
+			return nil, false
+		}
+		for _, key := range c.keys {
+			return key, true
+		}
+	}
+	key, ok := c.keys[kid]
+	return key, ok
+}
+
+// refresh는 JWKS를 조회해 캐시를 교체합니다 (c.mu를 잡은 상태에서 호출)
+func (c *jwksCache) refresh(ctx context.Context) error {
+	if c.url == "" {
+		url, err := c.discover(ctx)
+		if err != nil {
+			return err
+		}
+		c.url = url
+	}
+
+	var set JSONWebKeySet
+	if err := getJSON(ctx, c.client, c.url, &set); err != nil {
+		return fmt.Errorf("failed to get jwks: %w", err)
+	}
+
+	keys := make(map[string]crypto.PublicKey, len(set.Keys))
+	for _, jwk := range set.Keys {
+		// 서명용이 아닌 키와 지원하지 않는 키는 무시
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		key, err := jwk.publicKey()
+		if err != nil {
+			continue
+		}
+		keys[jwk.Kid] = key
+	}
+	if len(keys) == 0 {
+		return errors.New("jwks has no usable signing keys")
+	}
+
+	c.keys = keys
+	c.fetchedAt = time.Now()
+	return nil
+}
+
+// JSONWebKeySet은 공개 키 목록(JWKS)입니다 (RFC 7517)
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
+
+// JSONWebKey는 JWKS의 공개 키 하나입니다 (RFC 7517, RFC 8037)
+type JSONWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid,omitempty"`
+	Use string `json:"use,omitempty"`
+	Alg string `json:"alg,omitempty"`
+
+	// RSA
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+
+	// EC, OKP(Ed25519)
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+}
+
+// publicKey는 JWK를 RSA, ECDSA 또는 Ed25519 공개 키로 변환합니다
+func (k JSONWebKey) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rsa modulus: %w", err)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rsa exponent: %w", err)
+		}
+		exponent := new(big.Int).SetBytes(e)
+		if len(n) < 256 || !exponent.IsInt64() || exponent.Int64() < 3 || exponent.Int64() > 1<<31-1 {
+			// 2048비트 미만의 키와 비정상적인 지수는 허용하지 않음
+			return nil, errors.New("unsupported rsa key")
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exponent.Int64())}, nil
+
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ec x: %w", err)
+		}
+		y, err := base64.RawURLEncoding.DecodeString(k.Y)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ec y: %w", err)
+		}
+		size := (curve.Params().BitSize + 7) / 8
+		if len(x) != size || len(y) != size {
+			return nil, errors.New("invalid ec point length")
+		}
+		// 비압축 점 형식(0x04 || X || Y)으로 파싱해 곡선 위의 점인지 검증
+		point := append(append([]byte{4}, x...), y...)
+		return ecdsa.ParseUncompressedPublicKey(curve, point)
+
+	case "OKP":
+		if k.Crv != "Ed25519" {
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, fmt.Errorf("invalid okp x: %w", err)
+		}
+		if len(x) != ed25519.PublicKeySize {
+			return nil, errors.New("invalid ed25519 key length")
+		}
+		return ed25519.PublicKey(x), nil
+
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+	}
+}
@@ -4,7 +4,6 @@ import (
 	"errors"
 	"fmt"
 	"os"
-	"strconv"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -18,51 +17,113 @@ var (
 	ErrExpiredToken = errors.New("expired token")
 )
 
+// DefaultAccessTokenTTL은 JWT_ACCESS_TOKEN_TTL을 설정하지 않았을 때 access token(JWT)의 유효 기간입니다
+const DefaultAccessTokenTTL = 15 * time.Minute
+
+// DefaultRefreshTokenTTL은 JWT_REFRESH_TOKEN_TTL을 설정하지 않았을 때 refresh token의 유효 기간입니다
+// 세션을 갱신할 때마다 다시 이 기간만큼 연장됩니다 (SessionMaxLifetime까지)
+const DefaultRefreshTokenTTL = 30 * 24 * time.Hour
+
+// DefaultSessionMaxLifetime은 JWT_SESSION_MAX_LIFETIME을 설정하지 않았을 때 로그인 세션의 최대 유지 기간입니다
+// 로그인한 시각부터 계산하며, 갱신해도 이 기간을 넘겨 연장되지 않습니다
+const DefaultSessionMaxLifetime = 90 * 24 * time.Hour
+
+// DefaultTokenIssuer는 JWT_ISSUER를 설정하지 않았을 때 access token의 발급자(iss)입니다
+const DefaultTokenIssuer = "orbithall"
+
+// DefaultTokenAudience는 JWT_AUDIENCE를 설정하지 않았을 때 access token의 대상(aud)입니다
+// access token은 관리 API(/admin, /auth) 인증에만 사용합니다
+const DefaultTokenAudience = "orbithall-admin"
+
 // CustomClaims는 JWT 토큰에 포함될 사용자 정의 클레임입니다
 type CustomClaims struct {
 	UserID int64  `json:"user_id"`
 	Email  string `json:"email"`
+
+	// SessionID는 토큰을 발급한 로그인 세션 ID입니다 (세션이 로그아웃되면 토큰도 거부됨)
+	SessionID int64 `json:"sid"`
 	jwt.RegisteredClaims
 }
 
-// GenerateJWT는 사용자 ID와 이메일을 포함하는 JWT를 생성합니다
-// JWT_SECRET과 JWT_EXPIRATION_HOURS 환경변수를 사용합니다
-func GenerateJWT(userID int64, email string) (string, error) {
-	// JWT_SECRET 검증
-	jwtSecret := os.Getenv("JWT_SECRET")
-	if jwtSecret == "" {
-		return "", fmt.Errorf("JWT_SECRET environment variable is required")
-	}
-	if len(jwtSecret) < 32 {
-		return "", fmt.Errorf("JWT_SECRET must be at least 32 characters long")
+// AccessTokenTTL은 access token(JWT)의 유효 기간을 반환합니다
+// JWT_ACCESS_TOKEN_TTL 환경변수(Go duration)를 사용하며, 없거나 잘못된 값이면 기본값(15분)을 사용합니다
+func AccessTokenTTL() time.Duration {
+	return durationFromEnv("JWT_ACCESS_TOKEN_TTL", DefaultAccessTokenTTL)
+}
+
+// RefreshTokenTTL은 refresh token의 유효 기간을 반환합니다
+// JWT_REFRESH_TOKEN_TTL 환경변수(Go duration)를 사용하며, 없거나 잘못된 값이면 기본값(30일)을 사용합니다
+func RefreshTokenTTL() time.Duration {
+	return durationFromEnv("JWT_REFRESH_TOKEN_TTL", DefaultRefreshTokenTTL)
+}
+
+// SessionMaxLifetime은 로그인 세션의 최대 유지 기간을 반환합니다
+// JWT_SESSION_MAX_LIFETIME 환경변수(Go duration)를 사용하며, 없거나 잘못된 값이면 기본값(90일)을 사용합니다
+// refresh token이 탈취되더라도 세션은 로그인한 시각부터 이 기간이 지나면 만료됩니다
+func SessionMaxLifetime() time.Duration {
+	return durationFromEnv("JWT_SESSION_MAX_LIFETIME", DefaultSessionMaxLifetime)
+}
+
+// TokenIssuer는 access token의 발급자(iss)를 반환합니다
+// JWT_ISSUER 환경변수를 사용하며, 없으면 기본값(orbithall)을 사용합니다
+func TokenIssuer() string {
+	return stringFromEnv("JWT_ISSUER", DefaultTokenIssuer)
+}
+
+// TokenAudience는 access token의 대상(aud)을 반환합니다
+// JWT_AUDIENCE 환경변수를 사용하며, 없으면 기본값(orbithall-admin)을 사용합니다
+// JWKS로 토큰을 검증하는 다른 서비스는 iss와 aud로 Orbithall 관리 토큰인지 구분할 수 있습니다
+func TokenAudience() string {
+	return stringFromEnv("JWT_AUDIENCE", DefaultTokenAudience)
+}
+
+// stringFromEnv는 환경변수 값을 읽습니다 (없으면 기본값)
+func stringFromEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
 	}
+	return defaultValue
+}
 
-	// JWT_EXPIRATION_HOURS 읽기 (기본값: 168시간 = 7일)
-	expirationHours := 168
-	if expirationStr := os.Getenv("JWT_EXPIRATION_HOURS"); expirationStr != "" {
-		if parsed, err := strconv.Atoi(expirationStr); err == nil {
-			expirationHours = parsed
+// durationFromEnv는 환경변수의 Go duration 값을 읽습니다 (없거나 0 이하이면 기본값)
+func durationFromEnv(key string, defaultValue time.Duration) time.Duration {
+	if value := os.Getenv(key); value != "" {
+		if parsed, err := time.ParseDuration(value); err == nil && parsed > 0 {
+			return parsed
 		}
 	}
+	return defaultValue
+}
+
+// GenerateJWT는 사용자 ID, 이메일, 로그인 세션 ID를 포함하는 access token(JWT)을 생성합니다
+// 활성 서명 키로 서명하며(SetSigningKeys, 설정되지 않았으면 JWT_SECRET), 유효 기간은 AccessTokenTTL입니다
+// 발급자(iss)와 대상(aud)은 TokenIssuer, TokenAudience입니다
+func GenerateJWT(userID int64, email string, sessionID int64) (string, error) {
+	// 서명 키 조회
+	keys, err := currentSigningKeys()
+	if err != nil {
+		return "", err
+	}
 
 	// 만료 시간 계산
-	expiresAt := time.Now().Add(time.Duration(expirationHours) * time.Hour)
+	now := time.Now()
+	expiresAt := now.Add(AccessTokenTTL())
 
 	// Claims 생성
 	claims := &CustomClaims{
-		UserID: userID,
-		Email:  email,
+		UserID:    userID,
+		Email:     email,
+		SessionID: sessionID,
 		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    TokenIssuer(),
+			Audience:  jwt.ClaimStrings{TokenAudience()},
 			ExpiresAt: jwt.NewNumericDate(expiresAt),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			IssuedAt:  jwt.NewNumericDate(now),
 		},
 	}
 
-	// JWT 토큰 생성 (HS256 알고리즘)
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-
 	// 서명하여 문자열로 변환
-	tokenString, err := token.SignedString([]byte(jwtSecret))
+	tokenString, err := keys.sign(claims)
 	if err != nil {
 		return "", fmt.Errorf("failed to sign token: %w", err)
 	}
@@ -71,26 +132,26 @@ func GenerateJWT(userID int64, email string) (string, error) {
 }
 
 // ValidateJWT는 JWT 토큰을 검증하고 claims를 반환합니다
+// 활성 키뿐 아니라 검증용으로 남겨 둔 이전 키로 서명한 토큰도 만료 전까지 허용합니다
+// 발급자(iss)와 대상(aud)이 TokenIssuer, TokenAudience와 다른 토큰은 같은 키로 서명했더라도 거부합니다
 func ValidateJWT(tokenString string) (*CustomClaims, error) {
 	// 빈 토큰 체크
 	if tokenString == "" {
 		return nil, ErrInvalidToken
 	}
 
-	// JWT_SECRET 가져오기
-	jwtSecret := os.Getenv("JWT_SECRET")
-	if jwtSecret == "" {
-		return nil, fmt.Errorf("JWT_SECRET environment variable is required")
+	// 서명 키 조회
+	keys, err := currentSigningKeys()
+	if err != nil {
+		return nil, err
 	}
 
-	// 토큰 파싱 및 검증
-	token, err := jwt.ParseWithClaims(tokenString, &CustomClaims{}, func(token *jwt.Token) (interface{}, error) {
-		// HMAC 서명 방식인지 확인
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-		return []byte(jwtSecret), nil
-	})
+	// 토큰 파싱 및 검증 (kid와 서명 방식으로 검증 키 선택)
+	token, err := jwt.ParseWithClaims(tokenString, &CustomClaims{}, keys.verificationKey,
+		jwt.WithValidMethods(jwtSigningMethods),
+		jwt.WithIssuer(TokenIssuer()),
+		jwt.WithAudience(TokenAudience()),
+	)
 
 	if err != nil {
 		// 만료된 토큰 체크
@@ -100,9 +161,9 @@ func ValidateJWT(tokenString string) (*CustomClaims, error) {
 		return nil, ErrInvalidToken
 	}
 
-	// Claims 추출
+	// Claims 추출 (세션 ID가 없는 이전 형식의 토큰은 로그아웃할 수 없으므로 거부)
 	claims, ok := token.Claims.(*CustomClaims)
-	if !ok || !token.Valid {
+	if !ok || !token.Valid || claims.SessionID == 0 {
 		return nil, ErrInvalidToken
 	}
 
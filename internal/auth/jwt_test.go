@@ -4,12 +4,13 @@ import (
 	"os"
 	"testing"
 	"time"
+
+	"github.com/golang-jwt/jwt/v5"
 )
 
 func init() {
 	// 테스트용 환경변수 설정
 	os.Setenv("JWT_SECRET", "test-secret-key-at-least-32-characters-long-for-security")
-	os.Setenv("JWT_EXPIRATION_HOURS", "168")
 }
 
 // TestGenerateJWT는 JWT 생성을 테스트합니다
@@ -20,7 +21,7 @@ func TestGenerateJWT(t *testing.T) {
 		email := "test@example.com"
 
 		// When: JWT 생성
-		token, err := GenerateJWT(userID, email)
+		token, err := GenerateJWT(userID, email, 1)
 
 		// Then: 토큰 생성 성공
 		if err != nil {
@@ -51,7 +52,7 @@ func TestGenerateJWT(t *testing.T) {
 		defer os.Setenv("JWT_SECRET", original)
 
 		// When: JWT 생성 시도
-		_, err := GenerateJWT(1, "test@example.com")
+		_, err := GenerateJWT(1, "test@example.com", 1)
 
 		// Then: 에러 반환
 		if err == nil {
@@ -66,7 +67,7 @@ func TestGenerateJWT(t *testing.T) {
 		defer os.Setenv("JWT_SECRET", original)
 
 		// When: JWT 생성 시도
-		_, err := GenerateJWT(1, "test@example.com")
+		_, err := GenerateJWT(1, "test@example.com", 1)
 
 		// Then: 에러 반환
 		if err == nil {
@@ -81,7 +82,7 @@ func TestValidateJWT(t *testing.T) {
 		// Given: 생성된 JWT
 		userID := int64(456)
 		email := "validate@example.com"
-		token, err := GenerateJWT(userID, email)
+		token, err := GenerateJWT(userID, email, 1)
 		if err != nil {
 			t.Fatalf("failed to generate token: %v", err)
 		}
@@ -102,6 +103,32 @@ func TestValidateJWT(t *testing.T) {
 		if claims.Email != email {
 			t.Errorf("expected email=%s, got %s", email, claims.Email)
 		}
+		if claims.SessionID != 1 {
+			t.Errorf("expected sid=1, got %d", claims.SessionID)
+		}
+	})
+
+	t.Run("세션 ID가 없는 토큰 검증 실패", func(t *testing.T) {
+		// Given: 세션 ID 없이 서명된 이전 형식의 토큰
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, &CustomClaims{
+			UserID: 1,
+			Email:  "legacy@example.com",
+			RegisteredClaims: jwt.RegisteredClaims{
+				ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+			},
+		})
+		tokenString, err := token.SignedString([]byte(os.Getenv("JWT_SECRET")))
+		if err != nil {
+			t.Fatalf("failed to sign token: %v", err)
+		}
+
+		// When: JWT 검증
+		_, err = ValidateJWT(tokenString)
+
+		// Then: ErrInvalidToken 반환
+		if err != ErrInvalidToken {
+			t.Errorf("expected ErrInvalidToken, got: %v", err)
+		}
 	})
 
 	t.Run("잘못된 토큰 검증 실패", func(t *testing.T) {
@@ -138,7 +165,7 @@ func TestValidateJWT(t *testing.T) {
 
 	t.Run("잘못된 서명 검증 실패", func(t *testing.T) {
 		// Given: 유효한 JWT 생성
-		token, err := GenerateJWT(789, "wrong@example.com")
+		token, err := GenerateJWT(789, "wrong@example.com", 1)
 		if err != nil {
 			t.Fatalf("failed to generate token: %v", err)
 		}
@@ -161,6 +188,84 @@ func TestValidateJWT(t *testing.T) {
 	})
 }
 
+// TestValidateJWT_IssuerAudience는 발급자(iss)와 대상(aud) 검증을 테스트합니다
+func TestValidateJWT_IssuerAudience(t *testing.T) {
+	// signClaims는 같은 키(JWT_SECRET)로 주어진 발급자와 대상의 토큰을 서명합니다
+	signClaims := func(t *testing.T, issuer, audience string) string {
+		t.Helper()
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, &CustomClaims{
+			UserID:    1,
+			Email:     "aud@example.com",
+			SessionID: 1,
+			RegisteredClaims: jwt.RegisteredClaims{
+				Issuer:    issuer,
+				Audience:  jwt.ClaimStrings{audience},
+				ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+			},
+		})
+		tokenString, err := token.SignedString([]byte(os.Getenv("JWT_SECRET")))
+		if err != nil {
+			t.Fatalf("failed to sign token: %v", err)
+		}
+		return tokenString
+	}
+
+	t.Run("발급한 토큰에 iss와 aud 포함", func(t *testing.T) {
+		t.Setenv("JWT_ISSUER", "https://comments.example.com")
+		t.Setenv("JWT_AUDIENCE", "comments-admin")
+
+		token, err := GenerateJWT(1, "aud@example.com", 1)
+		if err != nil {
+			t.Fatalf("failed to generate token: %v", err)
+		}
+		claims, err := ValidateJWT(token)
+		if err != nil {
+			t.Fatalf("failed to validate token: %v", err)
+		}
+
+		if claims.Issuer != "https://comments.example.com" || len(claims.Audience) != 1 || claims.Audience[0] != "comments-admin" {
+			t.Errorf("expected configured iss and aud, got %q %v", claims.Issuer, claims.Audience)
+		}
+	})
+
+	t.Run("다른 대상(aud)의 토큰 거부", func(t *testing.T) {
+		// Given: 같은 키로 서명했지만 다른 서비스용 대상
+		token := signClaims(t, DefaultTokenIssuer, "other-service")
+
+		// When
+		_, err := ValidateJWT(token)
+
+		// Then
+		if err != ErrInvalidToken {
+			t.Errorf("expected ErrInvalidToken, got: %v", err)
+		}
+	})
+
+	t.Run("다른 발급자(iss)의 토큰 거부", func(t *testing.T) {
+		token := signClaims(t, "other-issuer", DefaultTokenAudience)
+
+		if _, err := ValidateJWT(token); err != ErrInvalidToken {
+			t.Errorf("expected ErrInvalidToken, got: %v", err)
+		}
+	})
+
+	t.Run("iss와 aud가 없는 토큰 거부", func(t *testing.T) {
+		token := signClaims(t, "", "")
+
+		if _, err := ValidateJWT(token); err != ErrInvalidToken {
+			t.Errorf("expected ErrInvalidToken, got: %v", err)
+		}
+	})
+
+	t.Run("기본 발급자와 대상의 토큰 허용", func(t *testing.T) {
+		token := signClaims(t, DefaultTokenIssuer, DefaultTokenAudience)
+
+		if _, err := ValidateJWT(token); err != nil {
+			t.Errorf("expected no error, got: %v", err)
+		}
+	})
+}
+
 // TestValidateJWT_Expiration은 만료된 토큰 검증을 테스트합니다
 // 주의: 실제 만료된 토큰을 생성하는 것은 시간이 오래 걸리므로
 // 이 테스트는 스킵하거나 mock을 사용해야 합니다
@@ -168,7 +273,7 @@ func TestValidateJWT_Expiration(t *testing.T) {
 	t.Skip("만료 테스트는 시간이 오래 걸리므로 스킵 (통합 테스트에서 처리)")
 
 	// 참고: 만료 테스트를 실제로 수행하려면:
-	// 1. JWT_EXPIRATION_HOURS를 매우 작은 값(예: -1)으로 설정
+	// 1. JWT_ACCESS_TOKEN_TTL을 매우 작은 값(예: 1s)으로 설정
 	// 2. 토큰 생성
 	// 3. time.Sleep()로 대기
 	// 4. 검증 시 ErrExpiredToken 확인
@@ -182,7 +287,7 @@ func TestCustomClaims(t *testing.T) {
 		email := "claims@example.com"
 
 		// When: JWT 생성 및 검증
-		token, err := GenerateJWT(userID, email)
+		token, err := GenerateJWT(userID, email, 1)
 		if err != nil {
 			t.Fatalf("failed to generate token: %v", err)
 		}
@@ -204,3 +309,39 @@ func TestCustomClaims(t *testing.T) {
 		}
 	})
 }
+
+// TestTokenTTL은 access token과 refresh token 유효 기간 설정을 테스트합니다
+func TestTokenTTL(t *testing.T) {
+	t.Run("설정이 없거나 잘못되면 기본값", func(t *testing.T) {
+		t.Setenv("JWT_ACCESS_TOKEN_TTL", "")
+		t.Setenv("JWT_REFRESH_TOKEN_TTL", "invalid")
+		t.Setenv("JWT_SESSION_MAX_LIFETIME", "-1h")
+
+		if ttl := AccessTokenTTL(); ttl != DefaultAccessTokenTTL {
+			t.Errorf("expected %v, got %v", DefaultAccessTokenTTL, ttl)
+		}
+		if ttl := RefreshTokenTTL(); ttl != DefaultRefreshTokenTTL {
+			t.Errorf("expected %v, got %v", DefaultRefreshTokenTTL, ttl)
+		}
+		if lifetime := SessionMaxLifetime(); lifetime != DefaultSessionMaxLifetime {
+			t.Errorf("expected %v, got %v", DefaultSessionMaxLifetime, lifetime)
+		}
+	})
+
+	t.Run("설정한 유효 기간으로 access token 만료 시각 계산", func(t *testing.T) {
+		t.Setenv("JWT_ACCESS_TOKEN_TTL", "5m")
+
+		token, err := GenerateJWT(1, "ttl@example.com", 1)
+		if err != nil {
+			t.Fatalf("failed to generate token: %v", err)
+		}
+		claims, err := ValidateJWT(token)
+		if err != nil {
+			t.Fatalf("failed to validate token: %v", err)
+		}
+
+		if remaining := time.Until(claims.ExpiresAt.Time); remaining > 5*time.Minute || remaining < 4*time.Minute {
+			t.Errorf("expected expiration in about 5 minutes, got %v", remaining)
+		}
+	})
+}
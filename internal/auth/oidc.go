@@ -0,0 +1,227 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// DefaultOIDCProviderName은 OIDCConfig.Name을 지정하지 않았을 때 사용하는 공급자 이름입니다
+const DefaultOIDCProviderName = "oidc"
+
+// oidcSigningMethods는 OIDC ID Token 서명으로 허용하는 알고리즘입니다 (비대칭 키만 허용)
+var oidcSigningMethods = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512", "EdDSA"}
+
+// oidcClockSkew는 ID Token 시각 클레임(exp, iat, nbf) 검증 시 허용하는 서버 간 시계 오차입니다
+const oidcClockSkew = time.Minute
+
+// OIDCConfig는 OIDC 공급자 설정입니다
+type OIDCConfig struct {
+	// Name은 공급자 이름입니다 (기본값 oidc, 소문자/숫자/-/_ 최대 50자)
+	Name string
+
+	// IssuerURL은 발급자 URL입니다 (ID Token의 iss 클레임과 일치해야 함)
+	IssuerURL string
+
+	// ClientID는 ID Token의 aud로 허용할 클라이언트 ID입니다
+	ClientID string
+
+	// JWKSURL은 서명 키(JWKS) URL입니다
+	// 비어 있으면 IssuerURL의 /.well-known/openid-configuration에서 jwks_uri를 조회합니다
+	JWKSURL string
+
+	// HTTPClient는 discovery 문서와 JWKS 조회에 사용할 클라이언트입니다 (기본값: 10초 타임아웃)
+	HTTPClient *http.Client
+
+	// KeyCacheTTL은 조회한 서명 키를 캐시하는 기간입니다 (기본값 1시간)
+	KeyCacheTTL time.Duration
+}
+
+// OIDCProvider는 OpenID Connect 발급자가 서명한 ID Token을 검증하는 IdentityProvider입니다
+// Keycloak, Authentik, Dex 등 OIDC를 지원하는 공급자를 사용할 수 있습니다
+type OIDCProvider struct {
+	name     string
+	issuer   string
+	clientID string
+	keys     *jwksCache
+}
+
+// NewOIDCProvider는 설정을 검증하고 OIDCProvider를 생성합니다
+// 서명 키는 처음 검증할 때 조회합니다
+func NewOIDCProvider(cfg OIDCConfig) (*OIDCProvider, error) {
+	name := cfg.Name
+	if name == "" {
+		name = DefaultOIDCProviderName
+	}
+	if !providerNamePattern.MatchString(name) {
+		return nil, fmt.Errorf("invalid provider name %q", name)
+	}
+	if err := validateHTTPURL(cfg.IssuerURL); err != nil {
+		return nil, fmt.Errorf("invalid issuer url: %w", err)
+	}
+	if cfg.ClientID == "" {
+		return nil, errors.New("client id is required")
+	}
+	if cfg.JWKSURL != "" {
+		if err := validateHTTPURL(cfg.JWKSURL); err != nil {
+			return nil, fmt.Errorf("invalid jwks url: %w", err)
+		}
+	}
+
+	client := cfg.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	ttl := cfg.KeyCacheTTL
+	if ttl <= 0 {
+		ttl = time.Hour
+	}
+
+	issuer := cfg.IssuerURL
+	keys := &jwksCache{
+		client: client,
+		url:    cfg.JWKSURL,
+		ttl:    ttl,
+	}
+	if keys.url == "" {
+		keys.discover = func(ctx context.Context) (string, error) {
+			return discoverJWKSURL(ctx, client, issuer)
+		}
+	}
+
+	return &OIDCProvider{
+		name:     name,
+		issuer:   issuer,
+		clientID: cfg.ClientID,
+		keys:     keys,
+	}, nil
+}
+
+// Name은 공급자 이름을 반환합니다
+func (p *OIDCProvider) Name() string {
+	return p.name
+}
+
+// Verify는 ID Token의 서명(JWKS), iss, aud, exp를 검증하고 계정 정보를 반환합니다
+// 토큰이 유효하지 않으면 ErrInvalidIDToken, 서명 키를 가져오지 못하면 ErrProviderUnavailable을 반환합니다
+func (p *OIDCProvider) Verify(ctx context.Context, idToken string) (*Identity, error) {
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(idToken, claims, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		return p.keys.key(ctx, kid)
+	},
+		jwt.WithValidMethods(oidcSigningMethods),
+		jwt.WithIssuer(p.issuer),
+		jwt.WithAudience(p.clientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(oidcClockSkew),
+	)
+	if err != nil {
+		if errors.Is(err, ErrProviderUnavailable) {
+			return nil, err
+		}
+		return nil, ErrInvalidIDToken
+	}
+
+	subject, _ := claims["sub"].(string)
+	if subject == "" {
+		return nil, ErrInvalidIDToken
+	}
+
+	// aud에 여러 클라이언트가 있으면 azp(토큰을 요청한 클라이언트)가 이 클라이언트여야 함
+	if azp, ok := claims["azp"].(string); ok && azp != "" && azp != p.clientID {
+		return nil, ErrInvalidIDToken
+	}
+
+	email, _ := claims["email"].(string)
+	name, _ := claims["name"].(string)
+	if name == "" {
+		name, _ = claims["preferred_username"].(string)
+	}
+	picture, _ := claims["picture"].(string)
+
+	return &Identity{
+		Provider:      p.name,
+		Subject:       subject,
+		Email:         email,
+		EmailVerified: claimBool(claims["email_verified"]),
+		Name:          name,
+		Picture:       picture,
+	}, nil
+}
+
+// claimBool은 불리언 클레임을 읽습니다 (일부 공급자는 "true" 문자열로 발급)
+func claimBool(value interface{}) bool {
+	switch v := value.(type) {
+	case bool:
+		return v
+	case string:
+		return strings.EqualFold(v, "true")
+	default:
+		return false
+	}
+}
+
+// discoverJWKSURL은 발급자의 discovery 문서(/.well-known/openid-configuration)에서 jwks_uri를 조회합니다
+// discovery 문서의 issuer가 설정한 발급자와 다르면 에러를 반환합니다
+func discoverJWKSURL(ctx context.Context, client *http.Client, issuer string) (string, error) {
+	discoveryURL := strings.TrimSuffix(issuer, "/") + "/.well-known/openid-configuration"
+
+	var doc struct {
+		Issuer  string `json:"issuer"`
+		JWKSURI string `json:"jwks_uri"`
+	}
+	if err := getJSON(ctx, client, discoveryURL, &doc); err != nil {
+		return "", fmt.Errorf("failed to get discovery document: %w", err)
+	}
+	if doc.Issuer != issuer {
+		return "", fmt.Errorf("discovery issuer %q does not match %q", doc.Issuer, issuer)
+	}
+	if err := validateHTTPURL(doc.JWKSURI); err != nil {
+		return "", fmt.Errorf("invalid jwks_uri in discovery document: %w", err)
+	}
+
+	return doc.JWKSURI, nil
+}
+
+// getJSON은 url을 GET으로 조회해 JSON 응답을 v로 디코딩합니다
+func getJSON(ctx context.Context, client *http.Client, url string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %d from %s", resp.StatusCode, url)
+	}
+
+	// 응답 크기 제한 (1MB)
+	return json.NewDecoder(http.MaxBytesReader(nil, resp.Body, 1<<20)).Decode(v)
+}
+
+// validateHTTPURL은 http(s) 절대 URL인지 확인합니다
+func validateHTTPURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return err
+	}
+	if (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return fmt.Errorf("%q must be an absolute http(s) url", raw)
+	}
+	return nil
+}
@@ -0,0 +1,350 @@
+package auth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// mockIssuer는 테스트용 OIDC 발급자입니다 (discovery 문서와 JWKS 제공)
+type mockIssuer struct {
+	server    *httptest.Server
+	jwksHits  atomic.Int32
+	mu        sync.Mutex
+	jwks      []map[string]string
+	available atomic.Bool
+}
+
+// newMockIssuer는 mockIssuer를 시작합니다
+func newMockIssuer(t *testing.T) *mockIssuer {
+	t.Helper()
+	m := &mockIssuer{}
+	m.available.Store(true)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{
+			"issuer":   m.server.URL,
+			"jwks_uri": m.server.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		m.jwksHits.Add(1)
+		if !m.available.Load() {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		json.NewEncoder(w).Encode(map[string]interface{}{"keys": m.jwks})
+	})
+	m.server = httptest.NewServer(mux)
+	t.Cleanup(m.server.Close)
+	return m
+}
+
+// setKeys는 JWKS로 제공할 키를 교체합니다
+func (m *mockIssuer) setKeys(keys ...map[string]string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.jwks = keys
+}
+
+// rsaJWK는 RSA 공개 키를 JWK로 변환합니다
+func rsaJWK(kid string, key *rsa.PublicKey) map[string]string {
+	return map[string]string{
+		"kty": "RSA",
+		"kid": kid,
+		"use": "sig",
+		"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+		"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+	}
+}
+
+// ecJWK는 P-256 공개 키를 JWK로 변환합니다
+func ecJWK(kid string, key *ecdsa.PublicKey) map[string]string {
+	point, _ := key.Bytes()
+	return map[string]string{
+		"kty": "EC",
+		"kid": kid,
+		"crv": "P-256",
+		"x":   base64.RawURLEncoding.EncodeToString(point[1:33]),
+		"y":   base64.RawURLEncoding.EncodeToString(point[33:]),
+	}
+}
+
+// signToken은 claims로 ID Token을 서명합니다
+func signToken(t *testing.T, method jwt.SigningMethod, key interface{}, kid string, claims jwt.MapClaims) string {
+	t.Helper()
+	token := jwt.NewWithClaims(method, claims)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+	return signed
+}
+
+// TestOIDCProvider_Verify는 mock 발급자로 OIDC ID Token 검증을 테스트합니다
+func TestOIDCProvider_Verify(t *testing.T) {
+	ctx := context.Background()
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate rsa key: %v", err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate ec key: %v", err)
+	}
+
+	issuer := newMockIssuer(t)
+	issuer.setKeys(rsaJWK("rsa-1", &rsaKey.PublicKey), ecJWK("ec-1", &ecKey.PublicKey))
+
+	// claims는 유효한 기본 클레임을 반환합니다
+	claims := func() jwt.MapClaims {
+		return jwt.MapClaims{
+			"iss":            issuer.server.URL,
+			"aud":            "orbithall",
+			"sub":            "user-123",
+			"email":          "admin@example.com",
+			"email_verified": true,
+			"name":           "Admin",
+			"iat":            time.Now().Unix(),
+			"exp":            time.Now().Add(time.Hour).Unix(),
+		}
+	}
+
+	newProvider := func(t *testing.T) *OIDCProvider {
+		provider, err := NewOIDCProvider(OIDCConfig{Name: "keycloak", IssuerURL: issuer.server.URL, ClientID: "orbithall"})
+		if err != nil {
+			t.Fatalf("failed to create provider: %v", err)
+		}
+		return provider
+	}
+
+	t.Run("유효한 RS256/ES256 토큰 검증 성공", func(t *testing.T) {
+		// Given: discovery로 JWKS를 조회하는 공급자
+		provider := newProvider(t)
+
+		for _, token := range []string{
+			signToken(t, jwt.SigningMethodRS256, rsaKey, "rsa-1", claims()),
+			signToken(t, jwt.SigningMethodES256, ecKey, "ec-1", claims()),
+		} {
+			// When
+			identity, err := provider.Verify(ctx, token)
+
+			// Then
+			if err != nil {
+				t.Fatalf("expected no error, got: %v", err)
+			}
+			if identity.Provider != "keycloak" || identity.Subject != "user-123" || identity.Email != "admin@example.com" || !identity.EmailVerified || identity.Name != "Admin" {
+				t.Errorf("unexpected identity: %+v", identity)
+			}
+		}
+	})
+
+	t.Run("서명 키는 캐시되어 다시 조회하지 않음", func(t *testing.T) {
+		provider := newProvider(t)
+		token := signToken(t, jwt.SigningMethodRS256, rsaKey, "rsa-1", claims())
+		before := issuer.jwksHits.Load()
+
+		for i := 0; i < 3; i++ {
+			if _, err := provider.Verify(ctx, token); err != nil {
+				t.Fatalf("expected no error, got: %v", err)
+			}
+		}
+
+		if hits := issuer.jwksHits.Load() - before; hits != 1 {
+			t.Errorf("expected 1 jwks request, got %d", hits)
+		}
+	})
+
+	t.Run("유효하지 않은 토큰은 ErrInvalidIDToken", func(t *testing.T) {
+		provider := newProvider(t)
+
+		otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		if err != nil {
+			t.Fatalf("failed to generate rsa key: %v", err)
+		}
+		mutate := func(change func(jwt.MapClaims)) jwt.MapClaims {
+			c := claims()
+			change(c)
+			return c
+		}
+
+		tests := []struct {
+			name  string
+			token string
+		}{
+			{"다른 aud", signToken(t, jwt.SigningMethodRS256, rsaKey, "rsa-1", mutate(func(c jwt.MapClaims) { c["aud"] = "other-client" }))},
+			{"다른 iss", signToken(t, jwt.SigningMethodRS256, rsaKey, "rsa-1", mutate(func(c jwt.MapClaims) { c["iss"] = "https://evil.example.com" }))},
+			{"만료됨", signToken(t, jwt.SigningMethodRS256, rsaKey, "rsa-1", mutate(func(c jwt.MapClaims) { c["exp"] = time.Now().Add(-time.Hour).Unix() }))},
+			{"exp 없음", signToken(t, jwt.SigningMethodRS256, rsaKey, "rsa-1", mutate(func(c jwt.MapClaims) { delete(c, "exp") }))},
+			{"sub 없음", signToken(t, jwt.SigningMethodRS256, rsaKey, "rsa-1", mutate(func(c jwt.MapClaims) { delete(c, "sub") }))},
+			{"다른 azp", signToken(t, jwt.SigningMethodRS256, rsaKey, "rsa-1", mutate(func(c jwt.MapClaims) { c["azp"] = "other-client" }))},
+			{"다른 키로 서명", signToken(t, jwt.SigningMethodRS256, otherKey, "rsa-1", claims())},
+			{"HS256 서명", signToken(t, jwt.SigningMethodHS256, []byte("shared-secret-shared-secret-shared"), "rsa-1", claims())},
+			{"형식 오류", "not-a-jwt"},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				if _, err := provider.Verify(ctx, tt.token); err != ErrInvalidIDToken {
+					t.Errorf("expected ErrInvalidIDToken, got: %v", err)
+				}
+			})
+		}
+	})
+
+	t.Run("키 교체 시 모르는 kid로 JWKS를 다시 조회", func(t *testing.T) {
+		// Given: 기존 키를 캐시한 공급자
+		provider := newProvider(t)
+		if _, err := provider.Verify(ctx, signToken(t, jwt.SigningMethodRS256, rsaKey, "rsa-1", claims())); err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+
+		rotated, err := rsa.GenerateKey(rand.Reader, 2048)
+		if err != nil {
+			t.Fatalf("failed to generate rsa key: %v", err)
+		}
+		issuer.setKeys(rsaJWK("rsa-1", &rsaKey.PublicKey), rsaJWK("rsa-2", &rotated.PublicKey))
+		defer issuer.setKeys(rsaJWK("rsa-1", &rsaKey.PublicKey), ecJWK("ec-1", &ecKey.PublicKey))
+		token := signToken(t, jwt.SigningMethodRS256, rotated, "rsa-2", claims())
+
+		// When/Then: 최소 간격 안에서는 다시 조회하지 않음
+		if _, err := provider.Verify(ctx, token); err != ErrInvalidIDToken {
+			t.Fatalf("expected ErrInvalidIDToken within refresh interval, got: %v", err)
+		}
+
+		// When/Then: 최소 간격이 지나면 다시 조회해 새 키로 검증
+		provider.keys.fetchedAt = time.Now().Add(-2 * jwksMinRefreshInterval)
+		if _, err := provider.Verify(ctx, token); err != nil {
+			t.Errorf("expected rotated key to verify, got: %v", err)
+		}
+	})
+
+	t.Run("JWKS를 가져오지 못하면 ErrProviderUnavailable", func(t *testing.T) {
+		provider := newProvider(t)
+		token := signToken(t, jwt.SigningMethodRS256, rsaKey, "rsa-1", claims())
+
+		issuer.available.Store(false)
+		defer issuer.available.Store(true)
+
+		_, err := provider.Verify(ctx, token)
+		if !errors.Is(err, ErrProviderUnavailable) {
+			t.Fatalf("expected ErrProviderUnavailable, got: %v", err)
+		}
+
+		// 캐시가 만료되어도 이전에 조회한 키는 계속 사용
+		issuer.available.Store(true)
+		if _, err := provider.Verify(ctx, token); err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		issuer.available.Store(false)
+		provider.keys.fetchedAt = time.Now().Add(-2 * time.Hour)
+		if _, err := provider.Verify(ctx, token); err != nil {
+			t.Errorf("expected stale key to be used, got: %v", err)
+		}
+	})
+
+	t.Run("JWKS URL을 직접 지정하고 email_verified 문자열 허용", func(t *testing.T) {
+		provider, err := NewOIDCProvider(OIDCConfig{IssuerURL: issuer.server.URL, ClientID: "orbithall", JWKSURL: issuer.server.URL + "/jwks"})
+		if err != nil {
+			t.Fatalf("failed to create provider: %v", err)
+		}
+		c := claims()
+		c["email_verified"] = "true"
+		delete(c, "name")
+		c["preferred_username"] = "admin"
+
+		identity, err := provider.Verify(ctx, signToken(t, jwt.SigningMethodRS256, rsaKey, "rsa-1", c))
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if identity.Provider != DefaultOIDCProviderName || !identity.EmailVerified || identity.Name != "admin" {
+			t.Errorf("unexpected identity: %+v", identity)
+		}
+	})
+}
+
+// TestNewOIDCProvider는 OIDC 공급자 설정 검증을 테스트합니다
+func TestNewOIDCProvider(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  OIDCConfig
+	}{
+		{"발급자 URL 없음", OIDCConfig{ClientID: "orbithall"}},
+		{"발급자 URL 형식 오류", OIDCConfig{IssuerURL: "keycloak.example.com", ClientID: "orbithall"}},
+		{"클라이언트 ID 없음", OIDCConfig{IssuerURL: "https://keycloak.example.com"}},
+		{"잘못된 공급자 이름", OIDCConfig{Name: "Key Cloak", IssuerURL: "https://keycloak.example.com", ClientID: "orbithall"}},
+		{"JWKS URL 형식 오류", OIDCConfig{IssuerURL: "https://keycloak.example.com", ClientID: "orbithall", JWKSURL: "ftp://keys"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewOIDCProvider(tt.cfg); err == nil {
+				t.Error("expected error, got nil")
+			}
+		})
+	}
+}
+
+// TestLoadProvidersFromEnv는 환경변수로 ID 공급자를 설정하는 것을 테스트합니다
+func TestLoadProvidersFromEnv(t *testing.T) {
+	t.Run("OIDC 설정이 없으면 Google만 사용", func(t *testing.T) {
+		t.Setenv("OIDC_ISSUER_URL", "")
+
+		providers, err := LoadProvidersFromEnv()
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if len(providers) != 1 || providers[0].Name() != GoogleProviderName {
+			t.Errorf("expected only google provider, got %d providers", len(providers))
+		}
+	})
+
+	t.Run("OIDC 공급자 추가", func(t *testing.T) {
+		t.Setenv("OIDC_ISSUER_URL", "https://keycloak.example.com/realms/orbithall")
+		t.Setenv("OIDC_CLIENT_ID", "orbithall")
+		t.Setenv("OIDC_PROVIDER_NAME", "keycloak")
+
+		providers, err := LoadProvidersFromEnv()
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if len(providers) != 2 || providers[1].Name() != "keycloak" {
+			t.Errorf("expected google and keycloak providers, got %d providers", len(providers))
+		}
+	})
+
+	t.Run("OIDC_CLIENT_ID가 없거나 이름이 google이면 에러", func(t *testing.T) {
+		t.Setenv("OIDC_ISSUER_URL", "https://keycloak.example.com/realms/orbithall")
+		t.Setenv("OIDC_CLIENT_ID", "")
+		if _, err := LoadProvidersFromEnv(); err == nil {
+			t.Error("expected error when OIDC_CLIENT_ID is not set, got nil")
+		}
+
+		t.Setenv("OIDC_CLIENT_ID", "orbithall")
+		t.Setenv("OIDC_PROVIDER_NAME", GoogleProviderName)
+		if _, err := LoadProvidersFromEnv(); err == nil {
+			t.Error("expected error for reserved provider name, got nil")
+		}
+	})
+}
@@ -0,0 +1,84 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+)
+
+var (
+	// ErrInvalidIDToken은 ID 공급자가 발급한 ID Token이 유효하지 않을 때 반환됩니다
+	ErrInvalidIDToken = errors.New("invalid id token")
+
+	// ErrProviderUnavailable은 ID Token 검증에 필요한 공급자 정보(discovery 문서, JWKS)를 가져오지 못했을 때 반환됩니다
+	ErrProviderUnavailable = errors.New("identity provider unavailable")
+)
+
+// Identity는 ID 공급자가 검증한 사용자 계정 정보입니다
+type Identity struct {
+	// Provider는 ID 공급자 이름입니다 (IdentityProvider.Name)
+	Provider string
+
+	// Subject는 공급자 계정의 고유 식별자입니다 (sub 클레임)
+	Subject string
+
+	Email string
+
+	// EmailVerified는 공급자가 이메일 소유를 확인했는지 여부입니다 (email_verified 클레임)
+	EmailVerified bool
+
+	Name    string
+	Picture string
+}
+
+// IdentityProvider는 ID Token을 검증하는 외부 ID 공급자입니다 (Google, OIDC 등)
+type IdentityProvider interface {
+	// Name은 공급자 이름입니다 (로그인 경로 /auth/{name}/verify와 user_identities.provider에 사용)
+	Name() string
+
+	// Verify는 ID Token을 검증하고 계정 정보를 반환합니다
+	// 토큰이 유효하지 않으면 ErrInvalidIDToken을 반환합니다
+	Verify(ctx context.Context, idToken string) (*Identity, error)
+}
+
+// providerNamePattern은 공급자 이름 형식입니다 (URL 경로와 DB에 그대로 사용)
+var providerNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,49}$`)
+
+// LoadProvidersFromEnv는 환경변수로 로그인에 사용할 ID 공급자 목록을 생성합니다
+// Google은 항상 포함되며 (GOOGLE_CLIENT_ID는 검증 시 확인), OIDC_ISSUER_URL이 설정되면 OIDC 공급자를 추가합니다
+//
+// 환경변수:
+//   - OIDC_ISSUER_URL: OIDC 발급자 URL (예: https://keycloak.example.com/realms/orbithall)
+//   - OIDC_CLIENT_ID: ID Token의 aud로 허용할 클라이언트 ID (OIDC_ISSUER_URL 설정 시 필수)
+//   - OIDC_JWKS_URL: 서명 키(JWKS) URL (선택, 없으면 발급자의 discovery 문서에서 조회)
+//   - OIDC_PROVIDER_NAME: 공급자 이름 (선택, 기본값 oidc)
+func LoadProvidersFromEnv() ([]IdentityProvider, error) {
+	providers := []IdentityProvider{NewGoogleProvider()}
+
+	issuer := os.Getenv("OIDC_ISSUER_URL")
+	if issuer == "" {
+		return providers, nil
+	}
+
+	clientID := os.Getenv("OIDC_CLIENT_ID")
+	if clientID == "" {
+		return nil, errors.New("OIDC_CLIENT_ID is required when OIDC_ISSUER_URL is set")
+	}
+
+	provider, err := NewOIDCProvider(OIDCConfig{
+		Name:      os.Getenv("OIDC_PROVIDER_NAME"),
+		IssuerURL: issuer,
+		ClientID:  clientID,
+		JWKSURL:   os.Getenv("OIDC_JWKS_URL"),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("invalid oidc provider config: %w", err)
+	}
+	if provider.Name() == GoogleProviderName {
+		return nil, fmt.Errorf("OIDC_PROVIDER_NAME %q is reserved", GoogleProviderName)
+	}
+
+	return append(providers, provider), nil
+}
@@ -0,0 +1,299 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"sync/atomic"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// minHMACSecretLength는 JWT_SECRET(HS256 비밀 키)의 최소 길이입니다
+const minHMACSecretLength = 32
+
+// jwtSigningMethods는 access token(JWT) 서명으로 허용하는 알고리즘입니다
+var jwtSigningMethods = []string{"EdDSA", "RS256", "HS256"}
+
+// signingKeyIDPattern은 서명 키 ID(kid) 형식입니다 (JWT 헤더와 JWKS에 그대로 사용)
+var signingKeyIDPattern = regexp.MustCompile(`^[A-Za-z0-9._-]{1,64}$`)
+
+// signingKeys는 access token 서명과 검증에 사용하는 키 묶음입니다
+// 서버 시작 시 SetSigningKeys로 설정하며, 설정되지 않으면 JWT_SECRET 환경변수로 HS256 키 묶음을 만듭니다
+var signingKeys atomic.Pointer[SigningKeys]
+
+// SigningKeys는 access token(JWT) 서명과 검증에 사용하는 키 묶음입니다
+// 키 교체를 위해 여러 키를 kid로 구분하여 보관하며, 새로 서명할 때는 활성 키만 사용하고
+// 나머지 키는 이전에 서명한 토큰이 만료될 때까지 검증에만 사용합니다
+// 비대칭 키의 공개 키는 JWKS로 공개되어 다른 서비스도 비밀 키 없이 토큰을 검증할 수 있습니다
+type SigningKeys struct {
+	activeID string
+	keys     map[string]*signingKey
+
+	// hmacSecret은 kid 없이 HS256으로 서명한 토큰에 사용하는 비밀 키입니다 (JWT_SECRET)
+	hmacSecret []byte
+}
+
+// signingKey는 kid 하나의 서명 방식과 키입니다
+type signingKey struct {
+	method jwt.SigningMethod
+	public crypto.PublicKey
+
+	// private은 서명에 사용하는 개인 키입니다 (검증 전용 키는 nil)
+	private crypto.Signer
+}
+
+// NewSigningKeys는 서명 키(kid → 키)와 HMAC 비밀 키로 키 묶음을 생성합니다
+// keys의 값은 Ed25519(EdDSA) 또는 RSA(RS256, 2048비트 이상)의 개인 키나 공개 키이며, 공개 키는 검증에만 사용합니다
+// activeID는 새로 서명할 때 사용할 개인 키의 kid이며, 비어 있으면 hmacSecret으로 서명합니다 (HS256)
+// hmacSecret이 있으면 kid 없이 HS256으로 서명한 토큰도 검증합니다
+func NewSigningKeys(activeID string, keys map[string]interface{}, hmacSecret []byte) (*SigningKeys, error) {
+	if len(hmacSecret) > 0 && len(hmacSecret) < minHMACSecretLength {
+		return nil, fmt.Errorf("hmac secret must be at least %d bytes", minHMACSecretLength)
+	}
+	if activeID == "" && len(hmacSecret) == 0 {
+		return nil, errors.New("an active signing key or hmac secret is required")
+	}
+
+	parsed := make(map[string]*signingKey, len(keys))
+	for id, key := range keys {
+		if !signingKeyIDPattern.MatchString(id) {
+			return nil, fmt.Errorf("invalid key id %q", id)
+		}
+		signing, err := newSigningKey(key)
+		if err != nil {
+			return nil, fmt.Errorf("invalid key %q: %w", id, err)
+		}
+		parsed[id] = signing
+	}
+
+	if activeID != "" {
+		active, ok := parsed[activeID]
+		if !ok {
+			return nil, fmt.Errorf("active key %q not found", activeID)
+		}
+		if active.private == nil {
+			return nil, fmt.Errorf("active key %q must be a private key", activeID)
+		}
+	}
+
+	return &SigningKeys{activeID: activeID, keys: parsed, hmacSecret: hmacSecret}, nil
+}
+
+// newSigningKey는 키 종류에 맞는 서명 방식을 정합니다
+func newSigningKey(key interface{}) (*signingKey, error) {
+	switch k := key.(type) {
+	case ed25519.PrivateKey:
+		return &signingKey{method: jwt.SigningMethodEdDSA, public: k.Public(), private: k}, nil
+	case ed25519.PublicKey:
+		return &signingKey{method: jwt.SigningMethodEdDSA, public: k}, nil
+	case *rsa.PrivateKey:
+		if k.N.BitLen() < 2048 {
+			return nil, errors.New("rsa key must be at least 2048 bits")
+		}
+		return &signingKey{method: jwt.SigningMethodRS256, public: k.Public(), private: k}, nil
+	case *rsa.PublicKey:
+		if k.N.BitLen() < 2048 {
+			return nil, errors.New("rsa key must be at least 2048 bits")
+		}
+		return &signingKey{method: jwt.SigningMethodRS256, public: k}, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %T (expected ed25519 or rsa)", key)
+	}
+}
+
+// LoadSigningKeysFromEnv는 환경변수에서 서명 키 묶음을 생성합니다
+//
+// 환경변수:
+//   - JWT_SIGNING_KEYS: "kid:base64 DER 키" 목록 (쉼표 구분, 첫 번째가 활성 키)
+//     개인 키는 PKCS#8, 검증 전용 공개 키는 PKIX(SubjectPublicKeyInfo) 형식입니다
+//   - JWT_SECRET: HS256 비밀 키 (32자 이상). JWT_SIGNING_KEYS가 없으면 서명에 사용하고,
+//     있으면 이전에 HS256으로 서명한 토큰 검증에만 사용합니다
+//
+// 둘 다 없으면 production에서는 에러를, 그 외 환경에서는 서버를 시작할 때마다 새로 만드는 개발용 키를 반환합니다
+func LoadSigningKeysFromEnv() (*SigningKeys, error) {
+	keysSpec := os.Getenv("JWT_SIGNING_KEYS")
+	secret := os.Getenv("JWT_SECRET")
+
+	if keysSpec == "" {
+		if secret != "" {
+			return secretSigningKeys(secret)
+		}
+		if os.Getenv("ENV") == "production" {
+			return nil, fmt.Errorf("JWT_SIGNING_KEYS or JWT_SECRET environment variable is required")
+		}
+		log.Println("[WARN] JWT_SIGNING_KEYS not set, using ephemeral development signing key (tokens are invalidated on restart)")
+		return DevelopmentSigningKeys(), nil
+	}
+	if secret != "" && len(secret) < minHMACSecretLength {
+		return nil, fmt.Errorf("JWT_SECRET must be at least %d characters long", minHMACSecretLength)
+	}
+
+	// 서명 키 파싱
+	keys := make(map[string]interface{})
+	var activeID string
+	for _, entry := range strings.Split(keysSpec, ",") {
+		id, encoded, ok := strings.Cut(strings.TrimSpace(entry), ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid JWT_SIGNING_KEYS entry %q (expected kid:base64key)", entry)
+		}
+		der, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("invalid base64 key for %q: %w", id, err)
+		}
+		key, err := parseDERKey(der)
+		if err != nil {
+			return nil, fmt.Errorf("invalid key for %q: %w", id, err)
+		}
+		if _, exists := keys[id]; exists {
+			return nil, fmt.Errorf("duplicate key id %q", id)
+		}
+		keys[id] = key
+		if activeID == "" {
+			activeID = id
+		}
+	}
+
+	return NewSigningKeys(activeID, keys, []byte(secret))
+}
+
+// parseDERKey는 PKCS#8 개인 키 또는 PKIX 공개 키를 파싱합니다
+func parseDERKey(der []byte) (interface{}, error) {
+	if key, err := x509.ParsePKCS8PrivateKey(der); err == nil {
+		return key, nil
+	}
+	key, err := x509.ParsePKIXPublicKey(der)
+	if err != nil {
+		return nil, errors.New("expected a PKCS#8 private key or PKIX public key")
+	}
+	return key, nil
+}
+
+// secretSigningKeys는 JWT_SECRET으로 HS256 서명 키 묶음을 만듭니다
+func secretSigningKeys(secret string) (*SigningKeys, error) {
+	if len(secret) < minHMACSecretLength {
+		return nil, fmt.Errorf("JWT_SECRET must be at least %d characters long", minHMACSecretLength)
+	}
+	return NewSigningKeys("", nil, []byte(secret))
+}
+
+// DevelopmentSigningKeys는 개발/테스트용 Ed25519 서명 키 묶음을 새로 만듭니다
+// 호출할 때마다 키가 바뀌므로 여러 인스턴스나 재시작 후에는 이전 토큰을 검증할 수 없습니다
+func DevelopmentSigningKeys() *SigningKeys {
+	_, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		// crypto/rand 실패는 시스템 레벨 문제
+		panic("failed to generate development signing key: " + err.Error())
+	}
+
+	keys, err := NewSigningKeys("dev", map[string]interface{}{"dev": private}, nil)
+	if err != nil {
+		panic(err) // 고정 값이므로 발생하지 않음
+	}
+	return keys
+}
+
+// SetSigningKeys는 access token 서명과 검증에 사용할 키 묶음을 설정합니다
+func SetSigningKeys(keys *SigningKeys) {
+	signingKeys.Store(keys)
+}
+
+// currentSigningKeys는 설정된 키 묶음을 반환합니다
+// 설정되지 않았으면 JWT_SECRET 환경변수로 HS256 키 묶음을 만듭니다
+func currentSigningKeys() (*SigningKeys, error) {
+	if keys := signingKeys.Load(); keys != nil {
+		return keys, nil
+	}
+
+	secret := os.Getenv("JWT_SECRET")
+	if secret == "" {
+		return nil, fmt.Errorf("JWT_SECRET environment variable is required")
+	}
+	return secretSigningKeys(secret)
+}
+
+// ActiveKeyID는 새로 서명할 때 사용하는 키의 kid를 반환합니다 (HS256으로 서명하면 빈 문자열)
+func (k *SigningKeys) ActiveKeyID() string {
+	return k.activeID
+}
+
+// sign은 활성 키로 claims를 서명합니다 (비대칭 키는 kid 헤더 포함)
+func (k *SigningKeys) sign(claims jwt.Claims) (string, error) {
+	if k.activeID == "" {
+		return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(k.hmacSecret)
+	}
+
+	active := k.keys[k.activeID]
+	token := jwt.NewWithClaims(active.method, claims)
+	token.Header["kid"] = k.activeID
+	return token.SignedString(active.private)
+}
+
+// verificationKey는 토큰의 kid와 서명 방식에 맞는 검증 키를 반환합니다 (jwt.Keyfunc)
+func (k *SigningKeys) verificationKey(token *jwt.Token) (interface{}, error) {
+	// HS256 토큰은 JWT_SECRET이 있을 때만 허용
+	if _, ok := token.Method.(*jwt.SigningMethodHMAC); ok {
+		if len(k.hmacSecret) == 0 {
+			return nil, errors.New("hmac signed tokens are not accepted")
+		}
+		return k.hmacSecret, nil
+	}
+
+	kid, _ := token.Header["kid"].(string)
+	key, ok := k.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown signing key %q", kid)
+	}
+	// kid의 키와 다른 알고리즘으로 서명한 토큰은 거부
+	if token.Method.Alg() != key.method.Alg() {
+		return nil, fmt.Errorf("unexpected signing method %q for key %q", token.Method.Alg(), kid)
+	}
+	return key.public, nil
+}
+
+// JWKS는 검증에 사용하는 공개 키 목록을 반환합니다 (HS256 비밀 키는 포함하지 않음)
+func (k *SigningKeys) JWKS() JSONWebKeySet {
+	ids := make([]string, 0, len(k.keys))
+	for id := range k.keys {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	set := JSONWebKeySet{Keys: make([]JSONWebKey, 0, len(ids))}
+	for _, id := range ids {
+		key := k.keys[id]
+		jwk := JSONWebKey{Kid: id, Use: "sig", Alg: key.method.Alg()}
+		switch public := key.public.(type) {
+		case ed25519.PublicKey:
+			jwk.Kty = "OKP"
+			jwk.Crv = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(public)
+		case *rsa.PublicKey:
+			jwk.Kty = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(public.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes())
+		}
+		set.Keys = append(set.Keys, jwk)
+	}
+	return set
+}
+
+// PublicSigningKeys는 access token을 검증할 수 있는 공개 키 목록(JWKS)을 반환합니다
+func PublicSigningKeys() (JSONWebKeySet, error) {
+	keys, err := currentSigningKeys()
+	if err != nil {
+		return JSONWebKeySet{}, err
+	}
+	return keys.JWKS(), nil
+}
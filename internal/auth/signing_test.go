@@ -0,0 +1,267 @@
+package auth
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// useSigningKeys는 테스트 동안 서명 키 묶음을 설정하고 끝나면 JWT_SECRET 기반으로 되돌립니다
+func useSigningKeys(t *testing.T, keys *SigningKeys) {
+	t.Helper()
+	SetSigningKeys(keys)
+	t.Cleanup(func() { SetSigningKeys(nil) })
+}
+
+// newEd25519Key는 테스트용 Ed25519 개인 키를 생성합니다
+func newEd25519Key(t *testing.T) ed25519.PrivateKey {
+	t.Helper()
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate ed25519 key: %v", err)
+	}
+	return key
+}
+
+// TestSigningKeys는 비대칭 키 서명과 키 교체를 테스트합니다
+func TestSigningKeys(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("Failed to generate rsa key: %v", err)
+	}
+	oldKey := newEd25519Key(t)
+	newKey := newEd25519Key(t)
+
+	t.Run("EdDSA/RS256 서명에 kid 헤더 포함", func(t *testing.T) {
+		for _, tt := range []struct {
+			kid string
+			key interface{}
+			alg string
+		}{
+			{"ed-1", oldKey, "EdDSA"},
+			{"rsa-1", rsaKey, "RS256"},
+		} {
+			keys, err := NewSigningKeys(tt.kid, map[string]interface{}{tt.kid: tt.key}, nil)
+			if err != nil {
+				t.Fatalf("Failed to create signing keys: %v", err)
+			}
+			useSigningKeys(t, keys)
+
+			tokenString, err := GenerateJWT(1, "user@example.com", 1)
+			if err != nil {
+				t.Fatalf("Failed to generate JWT: %v", err)
+			}
+			token, _, err := jwt.NewParser().ParseUnverified(tokenString, &CustomClaims{})
+			if err != nil {
+				t.Fatalf("Failed to parse token: %v", err)
+			}
+			if token.Header["kid"] != tt.kid || token.Method.Alg() != tt.alg {
+				t.Errorf("Expected kid %s and alg %s, got %v", tt.kid, tt.alg, token.Header)
+			}
+			if _, err := ValidateJWT(tokenString); err != nil {
+				t.Errorf("Expected %s token to validate, got %v", tt.alg, err)
+			}
+		}
+	})
+
+	t.Run("교체 후에도 이전 키로 서명한 토큰은 검증", func(t *testing.T) {
+		// Given: 이전 키로 서명한 토큰
+		before, _ := NewSigningKeys("old", map[string]interface{}{"old": oldKey}, nil)
+		useSigningKeys(t, before)
+		oldToken, err := GenerateJWT(1, "user@example.com", 1)
+		if err != nil {
+			t.Fatalf("Failed to generate JWT: %v", err)
+		}
+
+		// When: 새 키를 활성화하고 이전 키는 공개 키만 남김
+		after, err := NewSigningKeys("new", map[string]interface{}{"new": newKey, "old": oldKey.Public()}, nil)
+		if err != nil {
+			t.Fatalf("Failed to create signing keys: %v", err)
+		}
+		useSigningKeys(t, after)
+
+		// Then: 이전 토큰과 새 토큰 모두 검증
+		if _, err := ValidateJWT(oldToken); err != nil {
+			t.Errorf("Expected old token to validate, got %v", err)
+		}
+		newToken, _ := GenerateJWT(1, "user@example.com", 1)
+		if _, err := ValidateJWT(newToken); err != nil {
+			t.Errorf("Expected new token to validate, got %v", err)
+		}
+
+		// 이전 키를 제거하면 이전 토큰은 거부
+		removed, _ := NewSigningKeys("new", map[string]interface{}{"new": newKey}, nil)
+		useSigningKeys(t, removed)
+		if _, err := ValidateJWT(oldToken); err != ErrInvalidToken {
+			t.Errorf("Expected ErrInvalidToken after key removal, got %v", err)
+		}
+	})
+
+	t.Run("HS256 토큰은 JWT_SECRET이 있을 때만 검증", func(t *testing.T) {
+		secret := []byte("test-secret-key-at-least-32-characters-long-for-security")
+		claims := &CustomClaims{UserID: 1, SessionID: 1, RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    TokenIssuer(),
+			Audience:  jwt.ClaimStrings{TokenAudience()},
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		}}
+		hmacToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(secret)
+		if err != nil {
+			t.Fatalf("Failed to sign token: %v", err)
+		}
+
+		withSecret, _ := NewSigningKeys("new", map[string]interface{}{"new": newKey}, secret)
+		useSigningKeys(t, withSecret)
+		if _, err := ValidateJWT(hmacToken); err != nil {
+			t.Errorf("Expected hs256 token to validate, got %v", err)
+		}
+
+		withoutSecret, _ := NewSigningKeys("new", map[string]interface{}{"new": newKey}, nil)
+		useSigningKeys(t, withoutSecret)
+		if _, err := ValidateJWT(hmacToken); err != ErrInvalidToken {
+			t.Errorf("Expected ErrInvalidToken without secret, got %v", err)
+		}
+	})
+
+	t.Run("kid의 키와 다른 알고리즘이면 거부", func(t *testing.T) {
+		keys, _ := NewSigningKeys("rsa", map[string]interface{}{"rsa": rsaKey, "ed": newKey}, nil)
+		useSigningKeys(t, keys)
+
+		// ed 키로 서명했지만 kid는 rsa
+		claims := &CustomClaims{UserID: 1, SessionID: 1, RegisteredClaims: jwt.RegisteredClaims{ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour))}}
+		token := jwt.NewWithClaims(jwt.SigningMethodEdDSA, claims)
+		token.Header["kid"] = "rsa"
+		tokenString, _ := token.SignedString(newKey)
+
+		if _, err := ValidateJWT(tokenString); err != ErrInvalidToken {
+			t.Errorf("Expected ErrInvalidToken, got %v", err)
+		}
+	})
+
+	t.Run("JWKS는 공개 키만 포함하고 그대로 검증에 사용 가능", func(t *testing.T) {
+		keys, _ := NewSigningKeys("ed", map[string]interface{}{"ed": newKey, "rsa": &rsaKey.PublicKey}, []byte("test-secret-key-at-least-32-characters-long-for-security"))
+
+		set := keys.JWKS()
+		if len(set.Keys) != 2 {
+			t.Fatalf("Expected 2 keys, got %+v", set.Keys)
+		}
+		for _, jwk := range set.Keys {
+			public, err := jwk.publicKey()
+			if err != nil {
+				t.Fatalf("Failed to parse published key %s: %v", jwk.Kid, err)
+			}
+			switch jwk.Kid {
+			case "ed":
+				if jwk.Alg != "EdDSA" || !newKey.Public().(ed25519.PublicKey).Equal(public) {
+					t.Errorf("Unexpected ed25519 jwk: %+v", jwk)
+				}
+			case "rsa":
+				if jwk.Alg != "RS256" || !rsaKey.PublicKey.Equal(public) {
+					t.Errorf("Unexpected rsa jwk: %+v", jwk)
+				}
+			default:
+				t.Errorf("Unexpected kid %q", jwk.Kid)
+			}
+		}
+	})
+}
+
+// TestNewSigningKeys는 잘못된 키 설정을 거부하는지 테스트합니다
+func TestNewSigningKeys(t *testing.T) {
+	key := newEd25519Key(t)
+	smallRSA, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatalf("Failed to generate rsa key: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		activeID string
+		keys     map[string]interface{}
+		secret   []byte
+	}{
+		{"키와 비밀 키가 모두 없음", "", nil, nil},
+		{"활성 키 없음", "missing", map[string]interface{}{"ed": key}, nil},
+		{"활성 키가 공개 키", "ed", map[string]interface{}{"ed": key.Public()}, nil},
+		{"잘못된 kid", "bad kid", map[string]interface{}{"bad kid": key}, nil},
+		{"2048비트 미만 RSA 키", "rsa", map[string]interface{}{"rsa": smallRSA}, nil},
+		{"짧은 비밀 키", "", nil, []byte("short-key")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewSigningKeys(tt.activeID, tt.keys, tt.secret); err == nil {
+				t.Error("expected error, got nil")
+			}
+		})
+	}
+}
+
+// TestLoadSigningKeysFromEnv는 환경변수 서명 키 설정을 테스트합니다
+func TestLoadSigningKeysFromEnv(t *testing.T) {
+	key := newEd25519Key(t)
+	privateDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatalf("Failed to marshal private key: %v", err)
+	}
+	publicDER, err := x509.MarshalPKIXPublicKey(newEd25519Key(t).Public())
+	if err != nil {
+		t.Fatalf("Failed to marshal public key: %v", err)
+	}
+
+	t.Run("첫 번째 키가 활성 키, 공개 키는 검증 전용", func(t *testing.T) {
+		t.Setenv("JWT_SIGNING_KEYS", "2025-02:"+base64.StdEncoding.EncodeToString(privateDER)+", 2025-01:"+base64.StdEncoding.EncodeToString(publicDER))
+		t.Setenv("JWT_SECRET", "")
+
+		keys, err := LoadSigningKeysFromEnv()
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if keys.ActiveKeyID() != "2025-02" || len(keys.JWKS().Keys) != 2 {
+			t.Errorf("Unexpected keys: active %q, jwks %+v", keys.ActiveKeyID(), keys.JWKS())
+		}
+	})
+
+	t.Run("JWT_SECRET만 있으면 HS256으로 서명", func(t *testing.T) {
+		t.Setenv("JWT_SIGNING_KEYS", "")
+		t.Setenv("JWT_SECRET", "test-secret-key-at-least-32-characters-long-for-security")
+
+		keys, err := LoadSigningKeysFromEnv()
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if keys.ActiveKeyID() != "" || len(keys.JWKS().Keys) != 0 {
+			t.Errorf("Expected hs256 signing keys, got active %q", keys.ActiveKeyID())
+		}
+	})
+
+	t.Run("설정이 없으면 production에서 에러", func(t *testing.T) {
+		t.Setenv("JWT_SIGNING_KEYS", "")
+		t.Setenv("JWT_SECRET", "")
+		t.Setenv("ENV", "production")
+
+		if _, err := LoadSigningKeysFromEnv(); err == nil {
+			t.Error("expected error, got nil")
+		}
+	})
+
+	t.Run("잘못된 형식이면 에러", func(t *testing.T) {
+		t.Setenv("JWT_SECRET", "")
+		for _, spec := range []string{
+			"no-separator",
+			"kid:not-base64!",
+			"kid:" + base64.StdEncoding.EncodeToString([]byte("not a key")),
+			"public:" + base64.StdEncoding.EncodeToString(publicDER),
+		} {
+			t.Setenv("JWT_SIGNING_KEYS", spec)
+			if _, err := LoadSigningKeysFromEnv(); err == nil {
+				t.Errorf("expected error for %q, got nil", spec)
+			}
+		}
+	})
+}
@@ -0,0 +1,263 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/june20516/orbithall/internal/models"
+	"github.com/lib/pq"
+)
+
+// apiKeyColumns는 API 키 조회 시 사용하는 컬럼 목록입니다 (scanSiteAPIKey와 순서 일치)
+const apiKeyColumns = `id, site_id, label, api_key, key_hash, key_prefix, key_last4, allowed_origins, scopes, expires_at, revoked_at, last_used_at, created_at`
+
+// scanSiteAPIKey는 apiKeyColumns 순서로 조회한 행을 SiteAPIKey로 변환합니다
+func scanSiteAPIKey(row rowScanner) (*models.SiteAPIKey, error) {
+	var key models.SiteAPIKey
+	var apiKey, keyHash, keyPrefix, keyLast4 sql.NullString
+	var allowedOrigins, scopes pq.StringArray
+	var expiresAt, revokedAt, lastUsedAt sql.NullTime
+	err := row.Scan(
+		&key.ID,
+		&key.SiteID,
+		&key.Label,
+		&apiKey,
+		&keyHash,
+		&keyPrefix,
+		&keyLast4,
+		&allowedOrigins,
+		&scopes,
+		&expiresAt,
+		&revokedAt,
+		&lastUsedAt,
+		&key.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// 비밀 키는 원문 없이 해시와 표시용 prefix, 마지막 4자만 저장됨
+	if keyHash.Valid {
+		key.Secret = true
+		key.KeyHash = keyHash.String
+		key.KeyPrefix = keyPrefix.String
+		key.KeyLast4 = keyLast4.String
+	} else {
+		key.Key = apiKey.String
+		key.KeyHash = models.HashAPIKey(key.Key)
+		key.TestMode = models.IsTestAPIKey(key.Key)
+	}
+	key.AllowedOrigins = []string(allowedOrigins)
+	if key.AllowedOrigins == nil {
+		key.AllowedOrigins = []string{}
+	}
+	key.Scopes = []string(scopes)
+	if key.Scopes == nil {
+		key.Scopes = []string{}
+	}
+	if expiresAt.Valid {
+		key.ExpiresAt = &expiresAt.Time
+	}
+	if revokedAt.Valid {
+		key.RevokedAt = &revokedAt.Time
+	}
+	if lastUsedAt.Valid {
+		key.LastUsedAt = &lastUsedAt.Time
+	}
+
+	return &key, nil
+}
+
+// CreateSiteAPIKey는 사이트에 새 API 키를 발급합니다
+// key.Key가 비어있으면 key.Secret이면 orb_secret_, key.TestMode면 orb_test_, 아니면 orb_live_ prefix로 자동 생성하며,
+// 생성된 ID와 시각을 key에 채웁니다
+// 비밀 키는 원문 대신 SHA-256 해시와 표시용 prefix, 마지막 4자만 저장하므로 key.Key는 이 호출 이후 다시 조회할 수 없습니다
+// scope는 비밀 키에만 저장됩니다
+// 조회되지 않던 키로 캐시된 네거티브 항목은 모든 인스턴스에서 무효화됩니다
+func CreateSiteAPIKey(ctx context.Context, db DBTX, key *models.SiteAPIKey) error {
+	if key.Key == "" {
+		prefix := models.APIKeyPrefixLive
+		switch {
+		case key.Secret:
+			prefix = models.APIKeyPrefixSecret
+		case key.TestMode:
+			prefix = models.APIKeyPrefixTest
+		}
+		key.Key = models.GenerateAPIKey(prefix)
+	}
+	key.TestMode = models.IsTestAPIKey(key.Key)
+	key.Secret = models.IsSecretAPIKey(key.Key)
+	key.KeyHash = models.HashAPIKey(key.Key)
+
+	// 비밀 키는 원문을 저장하지 않음 (공개 키는 원문만 저장)
+	var apiKey, keyHash, keyPrefix, keyLast4 any
+	if key.Secret {
+		key.KeyPrefix = models.APIKeyPrefixSecret
+		key.KeyLast4 = key.Key[len(key.Key)-4:]
+		keyHash, keyPrefix, keyLast4 = key.KeyHash, key.KeyPrefix, key.KeyLast4
+	} else {
+		apiKey = key.Key
+	}
+
+	// 허용 Origin이 없으면 NULL로 저장 (사이트의 cors_origins 사용)
+	var allowedOrigins any
+	if len(key.AllowedOrigins) > 0 {
+		allowedOrigins = pq.Array(key.AllowedOrigins)
+	} else {
+		key.AllowedOrigins = []string{}
+	}
+
+	// 공개 키는 scope를 NULL로 저장
+	var scopes any
+	if key.Secret {
+		if key.Scopes == nil {
+			key.Scopes = []string{}
+		}
+		scopes = pq.Array(key.Scopes)
+	} else {
+		key.Scopes = []string{}
+	}
+
+	query := `
+		INSERT INTO site_api_keys (site_id, label, api_key, key_hash, key_prefix, key_last4, allowed_origins, scopes, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING id, created_at
+	`
+
+	err := db.QueryRowContext(ctx, query,
+		key.SiteID,
+		key.Label,
+		apiKey,
+		keyHash,
+		keyPrefix,
+		keyLast4,
+		allowedOrigins,
+		scopes,
+		key.ExpiresAt,
+	).Scan(&key.ID, &key.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create site api key: %w", err)
+	}
+
+	return notifySiteChanged(ctx, db, key.SiteID)
+}
+
+// ListSiteAPIKeys는 사이트의 API 키 목록을 조회합니다
+// 폐기되거나 만료된 키도 포함하며, 발급 순서대로 정렬됩니다
+func ListSiteAPIKeys(ctx context.Context, db DBTX, siteID int64) ([]models.SiteAPIKey, error) {
+	query := `
+		SELECT ` + apiKeyColumns + `
+		FROM site_api_keys
+		WHERE site_id = $1
+		ORDER BY created_at, id
+	`
+
+	rows, err := db.QueryContext(ctx, query, siteID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list site api keys: %w", err)
+	}
+	defer rows.Close()
+
+	keys := []models.SiteAPIKey{}
+	for rows.Next() {
+		key, err := scanSiteAPIKey(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan site api key: %w", err)
+		}
+		keys = append(keys, *key)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating site api keys: %w", err)
+	}
+
+	return keys, nil
+}
+
+// RotateSiteAPIKey는 API 키를 같은 라벨, 허용 Origin, 종류(라이브/테스트/비밀), scope를 가진 새 키로 교체합니다
+// 이전 키는 유예 기간(gracePeriod) 동안 계속 사용할 수 있고, 이후 만료됩니다
+// gracePeriod가 0이면 이전 키는 즉시 만료됩니다
+// 키가 없으면 sql.ErrNoRows, 이미 폐기되거나 만료된 키면 ErrAPIKeyInactive를 반환합니다
+func RotateSiteAPIKey(ctx context.Context, db DBTX, siteID, keyID int64, gracePeriod time.Duration) (newKey, oldKey *models.SiteAPIKey, err error) {
+	err = RunInTx(ctx, db, func(tx DBTX) error {
+		// 1. 이전 키를 잠그고 조회 (동시 교체 방지)
+		var err error
+		oldKey, err = scanSiteAPIKey(tx.QueryRowContext(ctx, `
+			SELECT `+apiKeyColumns+`
+			FROM site_api_keys
+			WHERE id = $1 AND site_id = $2
+			FOR UPDATE
+		`, keyID, siteID))
+		if err == sql.ErrNoRows {
+			return sql.ErrNoRows
+		}
+		if err != nil {
+			return fmt.Errorf("failed to lock site api key: %w", err)
+		}
+		if !oldKey.IsUsable(time.Now()) {
+			return ErrAPIKeyInactive
+		}
+
+		// 2. 새 키 발급 (라벨, 허용 Origin, 종류, scope 유지)
+		newKey = &models.SiteAPIKey{
+			SiteID:         siteID,
+			Label:          oldKey.Label,
+			AllowedOrigins: oldKey.AllowedOrigins,
+			TestMode:       oldKey.TestMode,
+			Secret:         oldKey.Secret,
+			Scopes:         oldKey.Scopes,
+		}
+		if err := CreateSiteAPIKey(ctx, tx, newKey); err != nil {
+			return err
+		}
+
+		// 3. 이전 키 만료 시각을 유예 기간 종료 시각으로 설정 (기존 만료 시각이 더 빠르면 유지)
+		query := `
+			UPDATE site_api_keys
+			SET expires_at = LEAST(COALESCE(expires_at, 'infinity'), NOW() + make_interval(secs => $2))
+			WHERE id = $1
+			RETURNING ` + apiKeyColumns
+		oldKey, err = scanSiteAPIKey(tx.QueryRowContext(ctx, query, keyID, gracePeriod.Seconds()))
+		if err != nil {
+			return fmt.Errorf("failed to expire site api key: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// 캐시된 이전 키는 새 만료 시각을 반영하도록 커밋 후 이 인스턴스에서 다시 제거
+	// (다른 인스턴스는 새 키 발급 시의 알림으로 무효화됨)
+	InvalidateAPIKeyHash(oldKey.KeyHash)
+
+	return newKey, oldKey, nil
+}
+
+// RevokeSiteAPIKey는 API 키를 즉시 폐기합니다
+// 캐시된 키는 모든 인스턴스에서 무효화되어 다음 요청부터 거부됩니다
+// 키가 없거나 이미 폐기되었으면 sql.ErrNoRows를 반환합니다
+func RevokeSiteAPIKey(ctx context.Context, db DBTX, siteID, keyID int64) (*models.SiteAPIKey, error) {
+	query := `
+		UPDATE site_api_keys
+		SET revoked_at = NOW()
+		WHERE id = $1 AND site_id = $2 AND revoked_at IS NULL
+		RETURNING ` + apiKeyColumns
+
+	key, err := scanSiteAPIKey(db.QueryRowContext(ctx, query, keyID, siteID))
+	if err == sql.ErrNoRows {
+		return nil, sql.ErrNoRows
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to revoke site api key: %w", err)
+	}
+
+	if err := notifySiteChanged(ctx, db, siteID); err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
@@ -0,0 +1,259 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/june20516/orbithall/internal/models"
+	"github.com/june20516/orbithall/internal/testhelpers"
+)
+
+// TestSiteAPIKeys는 API 키 발급, 교체, 폐기와 키별 인증을 테스트합니다
+func TestSiteAPIKeys(t *testing.T) {
+	db := testhelpers.SetupTestDB(t)
+	defer Close(db)
+
+	t.Run("추가 키 발급 후 두 키 모두 인증, 키별 허용 Origin 적용", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		// Given: 기본 키가 있는 사이트에 허용 Origin이 지정된 키 추가
+		site := testhelpers.CreateTestSite(ctx, t, tx, "Test Site", "keys.com", []string{"https://keys.com"}, true)
+		key := &models.SiteAPIKey{
+			SiteID:         site.ID,
+			Label:          "staging",
+			AllowedOrigins: []string{"https://staging.keys.com"},
+		}
+		if err := CreateSiteAPIKey(ctx, tx, key); err != nil {
+			t.Fatalf("failed to create api key: %v", err)
+		}
+		defer InvalidateAPIKey(site.APIKey)
+		defer InvalidateAPIKey(key.Key)
+
+		// When: 두 키로 사이트 조회
+		bySiteKey, err1 := GetSiteByAPIKey(ctx, tx, site.APIKey)
+		byNewKey, err2 := GetSiteByAPIKey(ctx, tx, key.Key)
+
+		// Then: 같은 사이트, 새 키는 키의 허용 Origin 사용
+		if err1 != nil || err2 != nil {
+			t.Fatalf("expected no error, got: %v, %v", err1, err2)
+		}
+		if bySiteKey.ID != site.ID || byNewKey.ID != site.ID {
+			t.Errorf("expected site %d, got %d and %d", site.ID, bySiteKey.ID, byNewKey.ID)
+		}
+		if len(bySiteKey.CORSOrigins) != 1 || bySiteKey.CORSOrigins[0] != "https://keys.com" {
+			t.Errorf("expected site origins, got %v", bySiteKey.CORSOrigins)
+		}
+		if len(byNewKey.CORSOrigins) != 1 || byNewKey.CORSOrigins[0] != "https://staging.keys.com" {
+			t.Errorf("expected key origins, got %v", byNewKey.CORSOrigins)
+		}
+
+		// 마지막 사용 시각 기록
+		keys, err := ListSiteAPIKeys(ctx, tx, site.ID)
+		if err != nil || len(keys) != 2 {
+			t.Fatalf("expected 2 keys, got %d (err=%v)", len(keys), err)
+		}
+		for _, k := range keys {
+			if k.LastUsedAt == nil {
+				t.Errorf("expected last_used_at for key %d", k.ID)
+			}
+		}
+	})
+
+	t.Run("폐기 즉시 캐시에서도 거부", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		// Given: 캐시된 키
+		site := testhelpers.CreateTestSite(ctx, t, tx, "Test Site", "revoke.com", []string{"https://revoke.com"}, true)
+		defer InvalidateAPIKey(site.APIKey)
+		if _, err := GetSiteByAPIKey(ctx, tx, site.APIKey); err != nil {
+			t.Fatalf("failed to get site: %v", err)
+		}
+		keys, _ := ListSiteAPIKeys(ctx, tx, site.ID)
+
+		// When: 키 폐기
+		revoked, err := RevokeSiteAPIKey(ctx, tx, site.ID, keys[0].ID)
+
+		// Then: 다음 조회부터 거부, 다시 폐기하면 sql.ErrNoRows
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if revoked.RevokedAt == nil {
+			t.Error("expected revoked_at to be set")
+		}
+		if _, err := GetSiteByAPIKey(ctx, tx, site.APIKey); err == nil {
+			t.Error("expected revoked key to be rejected")
+		}
+		if _, err := RevokeSiteAPIKey(ctx, tx, site.ID, keys[0].ID); err != sql.ErrNoRows {
+			t.Errorf("expected sql.ErrNoRows, got: %v", err)
+		}
+	})
+
+	t.Run("교체 시 유예 기간 동안 두 키 모두 동작", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		// Given: 기본 키
+		site := testhelpers.CreateTestSite(ctx, t, tx, "Test Site", "rotate.com", []string{"https://rotate.com"}, true)
+		keys, _ := ListSiteAPIKeys(ctx, tx, site.ID)
+
+		// When: 1시간 유예로 교체
+		newKey, oldKey, err := RotateSiteAPIKey(ctx, tx, site.ID, keys[0].ID, time.Hour)
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		defer InvalidateAPIKey(newKey.Key)
+		defer InvalidateAPIKey(oldKey.Key)
+
+		// Then: 새 키는 같은 라벨, 이전 키는 약 1시간 후 만료
+		if newKey.Label != "default" || newKey.Key == oldKey.Key {
+			t.Errorf("unexpected new key: %+v", newKey)
+		}
+		if oldKey.ExpiresAt == nil || time.Until(*oldKey.ExpiresAt) > time.Hour || time.Until(*oldKey.ExpiresAt) < 50*time.Minute {
+			t.Errorf("expected old key to expire in about an hour, got %v", oldKey.ExpiresAt)
+		}
+		for _, k := range []string{newKey.Key, oldKey.Key} {
+			if _, err := GetSiteByAPIKey(ctx, tx, k); err != nil {
+				t.Errorf("expected key to work during grace period: %v", err)
+			}
+		}
+	})
+
+	t.Run("유예 기간 0이면 이전 키 즉시 만료, 만료된 키는 다시 교체 불가", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		// Given: 캐시된 기본 키
+		site := testhelpers.CreateTestSite(ctx, t, tx, "Test Site", "rotate-now.com", []string{"https://rotate-now.com"}, true)
+		defer InvalidateAPIKey(site.APIKey)
+		if _, err := GetSiteByAPIKey(ctx, tx, site.APIKey); err != nil {
+			t.Fatalf("failed to get site: %v", err)
+		}
+		keys, _ := ListSiteAPIKeys(ctx, tx, site.ID)
+
+		// When: 유예 없이 교체
+		newKey, _, err := RotateSiteAPIKey(ctx, tx, site.ID, keys[0].ID, 0)
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		defer InvalidateAPIKey(newKey.Key)
+
+		// Then: 이전 키 거부, 다시 교체하면 ErrAPIKeyInactive
+		if _, err := GetSiteByAPIKey(ctx, tx, site.APIKey); err == nil {
+			t.Error("expected old key to be rejected")
+		}
+		if _, _, err := RotateSiteAPIKey(ctx, tx, site.ID, keys[0].ID, time.Hour); !errors.Is(err, ErrAPIKeyInactive) {
+			t.Errorf("expected ErrAPIKeyInactive, got: %v", err)
+		}
+	})
+
+	t.Run("다른 사이트의 키는 교체/폐기 불가", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		// Given: 서로 다른 사이트
+		site1 := testhelpers.CreateTestSite(ctx, t, tx, "Site 1", "keys1.com", []string{"https://keys1.com"}, true)
+		site2 := testhelpers.CreateTestSite(ctx, t, tx, "Site 2", "keys2.com", []string{"https://keys2.com"}, true)
+		keys, _ := ListSiteAPIKeys(ctx, tx, site1.ID)
+
+		// When: site2로 site1의 키 교체/폐기 시도
+		_, _, rotateErr := RotateSiteAPIKey(ctx, tx, site2.ID, keys[0].ID, time.Hour)
+		_, revokeErr := RevokeSiteAPIKey(ctx, tx, site2.ID, keys[0].ID)
+
+		// Then: sql.ErrNoRows
+		if rotateErr != sql.ErrNoRows || revokeErr != sql.ErrNoRows {
+			t.Errorf("expected sql.ErrNoRows, got: %v, %v", rotateErr, revokeErr)
+		}
+	})
+
+	t.Run("테스트 모드 키는 orb_test_ prefix, 교체 후에도 유지, 조회한 사이트는 TestMode", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		// Given: 테스트 모드 키 발급
+		site := testhelpers.CreateTestSite(ctx, t, tx, "Test Site", "testmode.com", []string{"https://testmode.com"}, true)
+		key := &models.SiteAPIKey{SiteID: site.ID, Label: "sandbox", TestMode: true}
+		if err := CreateSiteAPIKey(ctx, tx, key); err != nil {
+			t.Fatalf("failed to create api key: %v", err)
+		}
+		if !models.IsTestAPIKey(key.Key) {
+			t.Fatalf("expected orb_test_ key, got %s", key.Key)
+		}
+
+		// When: 키 교체 후 새 키와 기본 키로 사이트 조회
+		newKey, _, err := RotateSiteAPIKey(ctx, tx, site.ID, key.ID, 0)
+		if err != nil {
+			t.Fatalf("failed to rotate api key: %v", err)
+		}
+		defer InvalidateAPIKey(newKey.Key)
+		defer InvalidateAPIKey(site.APIKey)
+		testSite, err1 := GetSiteByAPIKey(ctx, tx, newKey.Key)
+		liveSite, err2 := GetSiteByAPIKey(ctx, tx, site.APIKey)
+
+		// Then: 새 키도 테스트 모드, 테스트 모드 키로 조회한 사이트만 TestMode
+		if err1 != nil || err2 != nil {
+			t.Fatalf("expected no error, got: %v, %v", err1, err2)
+		}
+		if !newKey.TestMode || !models.IsTestAPIKey(newKey.Key) {
+			t.Errorf("expected rotated key to stay in test mode, got %s", newKey.Key)
+		}
+		if !testSite.TestMode || liveSite.TestMode {
+			t.Errorf("expected test_mode true/false, got %v/%v", testSite.TestMode, liveSite.TestMode)
+		}
+	})
+
+	t.Run("비밀 키는 orb_secret_ prefix, 교체 후에도 스코프 유지, 조회한 사이트에 키 ID와 스코프", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		// Given: 스코프가 지정된 비밀 키 발급
+		site := testhelpers.CreateTestSite(ctx, t, tx, "Test Site", "secret.com", []string{"https://secret.com"}, true)
+		key := &models.SiteAPIKey{SiteID: site.ID, Label: "backend", Secret: true, Scopes: []string{models.ScopeCommentsModerate}}
+		if err := CreateSiteAPIKey(ctx, tx, key); err != nil {
+			t.Fatalf("failed to create api key: %v", err)
+		}
+		if !models.IsSecretAPIKey(key.Key) {
+			t.Fatalf("expected orb_secret_ key, got %s", key.Key)
+		}
+
+		// 비밀 키는 원문 대신 해시와 마지막 4자만 저장
+		var storedKey sql.NullString
+		var storedHash, storedLast4 string
+		err := tx.QueryRowContext(ctx, `SELECT api_key, key_hash, key_last4 FROM site_api_keys WHERE id = $1`, key.ID).Scan(&storedKey, &storedHash, &storedLast4)
+		if err != nil {
+			t.Fatalf("failed to query stored key: %v", err)
+		}
+		if storedKey.Valid || storedHash != models.HashAPIKey(key.Key) || storedLast4 != key.Key[len(key.Key)-4:] {
+			t.Errorf("expected only hash and last 4 chars stored, got %v %s %s", storedKey, storedHash, storedLast4)
+		}
+		keys, err := ListSiteAPIKeys(ctx, tx, site.ID)
+		if err != nil {
+			t.Fatalf("failed to list api keys: %v", err)
+		}
+		if listed := keys[len(keys)-1]; !listed.Secret || listed.Key != "" || listed.Masked().Key != models.APIKeyPrefixSecret+"****"+storedLast4 {
+			t.Errorf("expected listed secret key without plaintext, got %+v", listed)
+		}
+
+		// When: 키 교체 후 새 키로 사이트 조회
+		newKey, _, err := RotateSiteAPIKey(ctx, tx, site.ID, key.ID, 0)
+		if err != nil {
+			t.Fatalf("failed to rotate api key: %v", err)
+		}
+		defer InvalidateAPIKey(newKey.Key)
+		secretSite, err := GetSiteByAPIKey(ctx, tx, newKey.Key)
+
+		// Then: 새 키도 비밀 키, 조회한 사이트는 새 키의 ID와 스코프를 가짐
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if !newKey.Secret || !models.IsSecretAPIKey(newKey.Key) {
+			t.Errorf("expected rotated key to stay secret, got %s", newKey.Key)
+		}
+		if secretSite.APIKeyID != newKey.ID || !secretSite.HasScope(models.ScopeCommentsModerate) || secretSite.HasScope(models.ScopePostsWrite) {
+			t.Errorf("expected key id %d with comments:moderate only, got %d %v", newKey.ID, secretSite.APIKeyID, secretSite.APIKeyScopes)
+		}
+	})
+}
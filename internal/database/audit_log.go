@@ -0,0 +1,119 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/june20516/orbithall/internal/models"
+)
+
+// CreateAuditLog는 관리자 작업을 감사 로그에 기록합니다
+// 요청 IP는 댓글 IP와 같은 키로 암호화하여 저장하며, 생성된 ID와 시각을 entry에 채웁니다
+// 기록이 작업과 함께 커밋/롤백되도록 작업과 같은 트랜잭션(tx)으로 호출합니다
+func CreateAuditLog(ctx context.Context, db DBTX, entry *models.AuditLogEntry) error {
+	ip, err := protectIPAddress(entry.IPAddress)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO audit_log (site_id, actor_user_id, actor_api_key_id, action, target_type, target_id, before_state, after_state, ip_address_encrypted)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING id, created_at
+	`
+
+	err = db.QueryRowContext(ctx, query,
+		entry.SiteID,
+		entry.ActorUserID,
+		entry.ActorAPIKeyID,
+		entry.Action,
+		entry.TargetType,
+		entry.TargetID,
+		nullIfEmptyJSON(entry.Before),
+		nullIfEmptyJSON(entry.After),
+		ip.encrypted,
+	).Scan(&entry.ID, &entry.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create audit log: %w", err)
+	}
+
+	return nil
+}
+
+// ListAuditLog는 사이트의 감사 로그를 최신순으로 조회합니다
+// 작업한 관리자의 이메일(또는 비밀 키의 라벨)을 함께 조회하고, 요청 IP를 복호화합니다
+// 전체 개수(total)도 함께 반환합니다
+func ListAuditLog(ctx context.Context, db DBTX, siteID int64, limit, offset int) ([]models.AuditLogEntry, int, error) {
+	// 1단계: 전체 개수 조회
+	var total int
+	err := db.QueryRowContext(ctx, `
+		SELECT COUNT(*)
+		FROM audit_log
+		WHERE site_id = $1
+	`, siteID).Scan(&total)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count audit log: %w", err)
+	}
+
+	// 2단계: 페이지 조회
+	rows, err := db.QueryContext(ctx, `
+		SELECT a.id, a.site_id, a.actor_user_id, COALESCE(u.email, ''), a.actor_api_key_id, COALESCE(k.label, ''),
+		       a.action, a.target_type, a.target_id, a.before_state, a.after_state, a.ip_address_encrypted, a.created_at
+		FROM audit_log a
+		LEFT JOIN users u ON u.id = a.actor_user_id
+		LEFT JOIN site_api_keys k ON k.id = a.actor_api_key_id
+		WHERE a.site_id = $1
+		ORDER BY a.created_at DESC, a.id DESC
+		LIMIT $2 OFFSET $3
+	`, siteID, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list audit log: %w", err)
+	}
+	defer rows.Close()
+
+	entries := []models.AuditLogEntry{}
+	for rows.Next() {
+		var entry models.AuditLogEntry
+		var before, after []byte
+		var ipEncrypted sql.NullString
+		err := rows.Scan(
+			&entry.ID,
+			&entry.SiteID,
+			&entry.ActorUserID,
+			&entry.ActorEmail,
+			&entry.ActorAPIKeyID,
+			&entry.ActorAPIKeyLabel,
+			&entry.Action,
+			&entry.TargetType,
+			&entry.TargetID,
+			&before,
+			&after,
+			&ipEncrypted,
+			&entry.CreatedAt,
+		)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan audit log: %w", err)
+		}
+		entry.Before = before
+		entry.After = after
+		if entry.IPAddress, err = DecryptIPAddress(ipEncrypted.String); err != nil {
+			return nil, 0, fmt.Errorf("audit log %d: %w", entry.ID, err)
+		}
+		entries = append(entries, entry)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating audit log: %w", err)
+	}
+
+	return entries, total, nil
+}
+
+// nullIfEmptyJSON은 비어있는 JSON 값을 NULL로 저장하기 위해 nil로 변환합니다
+func nullIfEmptyJSON(value []byte) any {
+	if len(value) == 0 {
+		return nil
+	}
+	return string(value)
+}
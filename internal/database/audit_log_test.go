@@ -0,0 +1,142 @@
+package database
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/june20516/orbithall/internal/models"
+	"github.com/june20516/orbithall/internal/testhelpers"
+)
+
+// TestAuditLog는 감사 로그 기록과 조회를 테스트합니다
+func TestAuditLog(t *testing.T) {
+	db := testhelpers.SetupTestDB(t)
+	defer Close(db)
+
+	t.Run("기록 후 최신순 조회, 관리자 이메일과 IP 포함", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		// Given: 관리자와 사이트, 감사 로그 3건
+		user := &models.User{Email: "audit@example.com", Name: "Auditor"}
+		if err := CreateUser(ctx, tx, user); err != nil {
+			t.Fatalf("failed to create user: %v", err)
+		}
+		site := testhelpers.CreateTestSite(ctx, t, tx, "Test Site", "audit.com", []string{"http://localhost:3000"}, true)
+
+		for _, action := range []string{models.AuditActionPostLock, models.AuditActionPostUnlock, models.AuditActionPostUpdate} {
+			entry := &models.AuditLogEntry{
+				SiteID:      site.ID,
+				ActorUserID: &user.ID,
+				Action:      action,
+				TargetType:  models.AuditTargetPost,
+				TargetID:    42,
+				Before:      json.RawMessage(`{"is_locked":false}`),
+				IPAddress:   "203.0.113.7",
+			}
+			if err := CreateAuditLog(ctx, tx, entry); err != nil {
+				t.Fatalf("failed to create audit log: %v", err)
+			}
+			if entry.ID == 0 {
+				t.Fatal("expected entry ID to be set")
+			}
+		}
+
+		// When: 첫 페이지(2건) 조회
+		entries, total, err := ListAuditLog(ctx, tx, site.ID, 2, 0)
+
+		// Then: 전체 3건, 최신 기록부터 반환
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if total != 3 {
+			t.Errorf("expected total=3, got %d", total)
+		}
+		if len(entries) != 2 {
+			t.Fatalf("expected 2 entries, got %d", len(entries))
+		}
+		if entries[0].Action != models.AuditActionPostUpdate {
+			t.Errorf("expected latest action %s, got %s", models.AuditActionPostUpdate, entries[0].Action)
+		}
+		if entries[0].ActorEmail != "audit@example.com" {
+			t.Errorf("expected actor email, got %q", entries[0].ActorEmail)
+		}
+		if entries[0].IPAddress != "203.0.113.7" {
+			t.Errorf("expected decrypted IP, got %q", entries[0].IPAddress)
+		}
+		if len(entries[0].After) != 0 {
+			t.Errorf("expected empty after state, got %s", entries[0].After)
+		}
+
+		var before map[string]bool
+		if err := json.Unmarshal(entries[0].Before, &before); err != nil || before["is_locked"] {
+			t.Errorf("unexpected before state: %s (err=%v)", entries[0].Before, err)
+		}
+
+		// 다음 페이지
+		entries, _, err = ListAuditLog(ctx, tx, site.ID, 2, 2)
+		if err != nil || len(entries) != 1 || entries[0].Action != models.AuditActionPostLock {
+			t.Errorf("unexpected second page: %+v (err=%v)", entries, err)
+		}
+	})
+
+	t.Run("IP는 평문으로 저장하지 않음", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		// Given: 감사 로그 기록
+		site := testhelpers.CreateTestSite(ctx, t, tx, "Test Site", "audit-ip.com", []string{"http://localhost:3000"}, true)
+		entry := &models.AuditLogEntry{
+			SiteID:     site.ID,
+			Action:     models.AuditActionSiteUpdate,
+			TargetType: models.AuditTargetSite,
+			TargetID:   site.ID,
+			IPAddress:  "203.0.113.7",
+		}
+		if err := CreateAuditLog(ctx, tx, entry); err != nil {
+			t.Fatalf("failed to create audit log: %v", err)
+		}
+
+		// When: 저장된 값 조회
+		var stored string
+		if err := tx.QueryRowContext(ctx, `SELECT ip_address_encrypted FROM audit_log WHERE id = $1`, entry.ID).Scan(&stored); err != nil {
+			t.Fatalf("failed to query audit log: %v", err)
+		}
+
+		// Then: 암호문으로 저장
+		if stored == "" || stored == "203.0.113.7" {
+			t.Errorf("expected encrypted IP, got %q", stored)
+		}
+	})
+
+	t.Run("사이트 삭제 후에도 기록 유지", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		// Given: 사이트 삭제 기록
+		site := testhelpers.CreateTestSite(ctx, t, tx, "Test Site", "audit-delete.com", []string{"http://localhost:3000"}, true)
+		entry := &models.AuditLogEntry{
+			SiteID:     site.ID,
+			Action:     models.AuditActionSiteDelete,
+			TargetType: models.AuditTargetSite,
+			TargetID:   site.ID,
+		}
+		if err := CreateAuditLog(ctx, tx, entry); err != nil {
+			t.Fatalf("failed to create audit log: %v", err)
+		}
+
+		// When: 사이트 삭제
+		if err := DeleteSite(ctx, tx, site.ID); err != nil {
+			t.Fatalf("failed to delete site: %v", err)
+		}
+
+		// Then: 감사 로그는 남아있음
+		entries, total, err := ListAuditLog(ctx, tx, site.ID, 10, 0)
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if total != 1 || len(entries) != 1 || entries[0].ActorUserID != nil {
+			t.Errorf("unexpected entries: %+v", entries)
+		}
+	})
+}
@@ -3,91 +3,218 @@ package database
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
-	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/june20516/orbithall/internal/models"
 	"github.com/lib/pq"
 )
 
-// cacheEntry는 캐시된 사이트 정보와 만료 시간을 저장합니다
-type cacheEntry struct {
-	site      *models.Site
-	expiresAt time.Time
+// SiteCache는 API 키로 조회한 사이트 정보를 저장하는 캐시입니다
+// 조회되지 않는 키(폐기/만료된 키, 비활성화된 사이트, 존재하지 않는 키)는 site가 nil인 항목으로 저장합니다 (네거티브 캐시)
+// 항목은 API 키 원문 대신 키의 SHA-256 해시(models.HashAPIKey)로 저장합니다 (원문을 저장하지 않는 비밀 키도 해시로 무효화할 수 있도록)
+// 구현체는 여러 고루틴에서 동시에 호출해도 안전해야 합니다
+type SiteCache interface {
+	// Get은 API 키의 캐시 항목을 반환합니다
+	// 항목이 없거나 만료되었으면 ok가 false이고, 네거티브 항목이면 site가 nil입니다
+	Get(apiKey string) (site *models.Site, ok bool)
+
+	// Set은 API 키의 캐시 항목을 ttl 동안 저장합니다 (site가 nil이면 네거티브 항목)
+	Set(apiKey string, site *models.Site, ttl time.Duration)
+
+	// Delete는 API 키의 캐시 항목을 제거합니다
+	Delete(apiKey string)
+
+	// DeleteSite는 사이트의 모든 키 항목과 네거티브 항목을 제거합니다
+	// 새로 발급되거나 다시 활성화된 키가 네거티브 항목 때문에 거부되지 않도록 네거티브 항목도 함께 제거합니다
+	DeleteSite(siteID int64)
+
+	// Clear는 모든 항목을 제거합니다
+	Clear()
+
+	// Len은 저장된 항목 수를 반환합니다
+	Len() int
+}
+
+const (
+	// cacheTTL은 캐시 항목의 유효 시간입니다 (1분)
+	cacheTTL = 1 * time.Minute
+
+	// negativeCacheTTL은 네거티브 항목의 유효 시간입니다 (30초)
+	negativeCacheTTL = 30 * time.Second
+
+	// DefaultSiteCacheSize는 SetSiteCache로 캐시를 설정하지 않았을 때 사용하는 메모리 캐시의 최대 항목 수입니다
+	DefaultSiteCacheSize = 10000
+)
+
+// siteCache는 GetSiteByAPIKey가 사용하는 캐시입니다
+// 서버 시작 시 SetSiteCache로 설정하며, 설정되지 않으면 DefaultSiteCacheSize 크기의 메모리 캐시를 사용합니다
+var siteCache atomic.Pointer[SiteCache]
+
+// SetSiteCache는 GetSiteByAPIKey가 사용할 캐시를 설정합니다
+func SetSiteCache(cache SiteCache) {
+	siteCache.Store(&cache)
 }
 
-// isExpired는 캐시 항목이 만료되었는지 확인합니다
-func (e *cacheEntry) isExpired() bool {
-	return time.Now().After(e.expiresAt)
+// currentSiteCache는 설정된 캐시를 반환합니다 (없으면 메모리 캐시)
+func currentSiteCache() SiteCache {
+	if cache := siteCache.Load(); cache != nil {
+		return *cache
+	}
+	var cache SiteCache = NewMemorySiteCache(DefaultSiteCacheSize)
+	siteCache.CompareAndSwap(nil, &cache)
+	return *siteCache.Load()
+}
+
+// SiteCacheStats는 사이트 캐시의 조회 통계입니다
+type SiteCacheStats struct {
+	Hits         uint64 `json:"hits"`          // 캐시에서 사이트를 반환한 횟수
+	NegativeHits uint64 `json:"negative_hits"` // 네거티브 항목으로 DB 조회 없이 거부한 횟수
+	Misses       uint64 `json:"misses"`        // DB를 조회한 횟수
+	Size         int    `json:"size"`          // 현재 저장된 항목 수
 }
 
-// SiteCache는 사이트 정보를 캐싱하는 thread-safe 캐시입니다
-var siteCache sync.Map
+// 사이트 캐시 조회 카운터 (서버 시작 이후 누적)
+var (
+	siteCacheHits         atomic.Uint64
+	siteCacheNegativeHits atomic.Uint64
+	siteCacheMisses       atomic.Uint64
+)
 
-// cacheTTL은 캐시 항목의 유효 시간입니다 (1분)
-const cacheTTL = 1 * time.Minute
+// GetSiteCacheStats는 서버 시작 이후의 사이트 캐시 조회 통계를 반환합니다
+func GetSiteCacheStats() SiteCacheStats {
+	return SiteCacheStats{
+		Hits:         siteCacheHits.Load(),
+		NegativeHits: siteCacheNegativeHits.Load(),
+		Misses:       siteCacheMisses.Load(),
+		Size:         currentSiteCache().Len(),
+	}
+}
 
 // GetSiteByAPIKey는 API 키로 사이트 정보를 조회합니다
 // 캐시에 있고 만료되지 않았으면 캐시에서 반환하고, 없거나 만료되었으면 DB에서 조회합니다
+// 조회되지 않는 키는 네거티브 항목으로 캐시하여 같은 키의 반복 요청이 DB를 조회하지 않도록 하며, ErrSiteNotFound를 반환합니다
+// 키에 허용 Origin이 지정되어 있으면 반환되는 사이트의 CORSOrigins는 키의 허용 Origin입니다
+// 테스트 모드 키(orb_test_)로 조회하면 반환되는 사이트의 TestMode가 true이며,
+// 반환되는 사이트의 APIKeyID와 APIKeyScopes는 조회에 사용한 키의 값입니다
 func GetSiteByAPIKey(ctx context.Context, db DBTX, apiKey string) (*models.Site, error) {
+	cache := currentSiteCache()
+	keyHash := models.HashAPIKey(apiKey)
+
 	// 캐시 조회
-	if cached, ok := siteCache.Load(apiKey); ok {
-		entry := cached.(*cacheEntry)
-		// TTL 확인
-		if !entry.isExpired() {
-			return entry.site, nil
+	if site, ok := cache.Get(keyHash); ok {
+		if site == nil {
+			siteCacheNegativeHits.Add(1)
+			return nil, ErrSiteNotFound
 		}
-		// 만료된 항목 삭제
-		siteCache.Delete(apiKey)
+		siteCacheHits.Add(1)
+		return site, nil
 	}
 
 	// 캐시 미스: DB에서 조회
-	site, err := getSiteFromDB(ctx, db, apiKey)
+	siteCacheMisses.Add(1)
+	site, keyExpiresAt, err := getSiteFromDB(ctx, db, apiKey)
+	if errors.Is(err, ErrSiteNotFound) {
+		cache.Set(keyHash, nil, negativeCacheTTL)
+		return nil, err
+	}
 	if err != nil {
 		return nil, err
 	}
 
-	// 캐시에 저장
-	siteCache.Store(apiKey, &cacheEntry{
-		site:      site,
-		expiresAt: time.Now().Add(cacheTTL),
-	})
+	// 캐시에 저장 (키가 TTL보다 먼저 만료되면 키 만료 시각까지만 캐시)
+	ttl := cacheTTL
+	if keyExpiresAt.Valid && time.Until(keyExpiresAt.Time) < ttl {
+		ttl = time.Until(keyExpiresAt.Time)
+	}
+	cache.Set(keyHash, site, ttl)
 
 	return site, nil
 }
 
+// InvalidateAPIKey는 이 인스턴스의 캐시에서 API 키의 항목을 즉시 제거합니다
+func InvalidateAPIKey(apiKey string) {
+	InvalidateAPIKeyHash(models.HashAPIKey(apiKey))
+}
+
+// InvalidateAPIKeyHash는 이 인스턴스의 캐시에서 키 해시(models.HashAPIKey)의 항목을 즉시 제거합니다
+// 원문을 저장하지 않는 비밀 키는 이 함수로 무효화합니다
+func InvalidateAPIKeyHash(keyHash string) {
+	currentSiteCache().Delete(keyHash)
+}
+
+// InvalidateSite는 이 인스턴스의 캐시에서 사이트의 모든 키 항목과 네거티브 항목을 즉시 제거합니다
+func InvalidateSite(siteID int64) {
+	currentSiteCache().DeleteSite(siteID)
+}
+
 // getSiteFromDB는 데이터베이스에서 API 키로 사이트 정보를 조회합니다
-func getSiteFromDB(ctx context.Context, db DBTX, apiKey string) (*models.Site, error) {
+// 폐기되거나 만료된 키, 비활성화된 사이트는 조회되지 않으며, 조회 시 키의 마지막 사용 시각을 갱신합니다
+// 비밀 키는 원문이 저장되지 않으므로 키의 SHA-256 해시로 조회합니다
+// 키의 만료 시각도 함께 반환합니다
+func getSiteFromDB(ctx context.Context, db DBTX, apiKey string) (*models.Site, sql.NullTime, error) {
+	keyColumn, keyValue := "k.api_key", apiKey
+	if models.IsSecretAPIKey(apiKey) {
+		keyColumn, keyValue = "k.key_hash", models.HashAPIKey(apiKey)
+	}
+
 	query := `
-		SELECT id, name, domain, api_key, cors_origins, is_active, created_at, updated_at
-		FROM sites
-		WHERE api_key = $1 AND is_active = true
+		UPDATE site_api_keys k
+		SET last_used_at = NOW()
+		FROM sites s
+		WHERE ` + keyColumn + ` = $1
+		  AND s.id = k.site_id
+		  AND s.is_active = true
+		  AND k.revoked_at IS NULL
+		  AND (k.expires_at IS NULL OR k.expires_at > NOW())
+		RETURNING s.id, s.name, s.domain, k.id, k.scopes, s.cors_origins, k.allowed_origins, k.expires_at, s.is_active, s.is_verified, s.auto_close_days,
+		          s.personal_data_retention_days, s.deleted_content_retention_days, s.rate_limits, s.created_at, s.updated_at
 	`
 
 	var site models.Site
-	var corsOrigins pq.StringArray
+	var corsOrigins, allowedOrigins, scopes pq.StringArray
+	var keyExpiresAt sql.NullTime
+	var rateLimits []byte
 
-	err := db.QueryRowContext(ctx, query, apiKey).Scan(
+	err := db.QueryRowContext(ctx, query, keyValue).Scan(
 		&site.ID,
 		&site.Name,
 		&site.Domain,
-		&site.APIKey,
+		&site.APIKeyID,
+		&scopes,
 		&corsOrigins,
+		&allowedOrigins,
+		&keyExpiresAt,
 		&site.IsActive,
+		&site.IsVerified,
+		&site.AutoCloseDays,
+		&site.PersonalDataRetentionDays,
+		&site.DeletedContentRetentionDays,
+		&rateLimits,
 		&site.CreatedAt,
 		&site.UpdatedAt,
 	)
 
 	if err == sql.ErrNoRows {
-		return nil, fmt.Errorf("site not found or inactive")
+		return nil, keyExpiresAt, ErrSiteNotFound
 	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to query site: %w", err)
+		return nil, keyExpiresAt, fmt.Errorf("failed to query site: %w", err)
 	}
 
-	// PostgreSQL TEXT[] 타입을 []string으로 변환
+	// PostgreSQL TEXT[] 타입을 []string으로 변환 (키의 허용 Origin이 있으면 우선)
 	site.CORSOrigins = []string(corsOrigins)
+	if len(allowedOrigins) > 0 {
+		site.CORSOrigins = []string(allowedOrigins)
+	}
+	site.APIKey = apiKey
+	site.TestMode = models.IsTestAPIKey(site.APIKey)
+	site.APIKeyScopes = []string(scopes)
+	if err := unmarshalRateLimits(rateLimits, &site.RateLimits); err != nil {
+		return nil, keyExpiresAt, err
+	}
 
-	return &site, nil
+	return &site, keyExpiresAt, nil
 }
@@ -0,0 +1,78 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// siteCacheChannel은 사이트 캐시 무효화를 다른 인스턴스에 전파하는 PostgreSQL NOTIFY 채널입니다
+// 페이로드는 변경된 사이트의 ID입니다
+const siteCacheChannel = "orbithall_site_cache"
+
+// notifySiteChanged는 사이트 정보나 사이트의 API 키가 변경되었음을 알립니다
+// 이 인스턴스의 캐시에서 사이트의 항목을 즉시 제거하고, NOTIFY로 ListenSiteCacheInvalidation을 실행 중인 모든 인스턴스에 전파합니다
+// NOTIFY는 커밋 시점에 전달되므로, 커밋 전에 다른 요청이 이전 상태를 다시 캐시하더라도 알림을 받은 뒤 다시 제거됩니다
+// db가 트랜잭션이고 롤백되면 알림은 전달되지 않습니다
+func notifySiteChanged(ctx context.Context, db DBTX, siteID int64) error {
+	InvalidateSite(siteID)
+
+	if _, err := db.ExecContext(ctx, "SELECT pg_notify($1, $2)", siteCacheChannel, strconv.FormatInt(siteID, 10)); err != nil {
+		return fmt.Errorf("failed to notify site change: %w", err)
+	}
+
+	return nil
+}
+
+// ListenSiteCacheInvalidation은 사이트 변경 알림을 받아 이 인스턴스의 사이트 캐시를 무효화합니다
+// ctx가 취소될 때까지 실행됩니다 (블로킹)
+// 연결이 끊겼다가 다시 연결되면 그 사이의 알림을 놓쳤을 수 있으므로 캐시 전체를 비웁니다
+func ListenSiteCacheInvalidation(ctx context.Context, databaseURL string) error {
+	listener := pq.NewListener(databaseURL, time.Second, time.Minute, func(event pq.ListenerEventType, err error) {
+		if err != nil {
+			log.Printf("[ERROR] Site cache listener: %v", err)
+		}
+	})
+	defer listener.Close()
+
+	if err := listener.Listen(siteCacheChannel); err != nil {
+		return fmt.Errorf("failed to listen for site cache invalidation: %w", err)
+	}
+
+	// 알림이 없는 동안 연결 상태를 주기적으로 확인 (끊겼으면 pq가 재연결)
+	ticker := time.NewTicker(90 * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case notification := <-listener.Notify:
+			handleSiteCacheNotification(notification)
+		case <-ticker.C:
+			go listener.Ping()
+		}
+	}
+}
+
+// handleSiteCacheNotification은 사이트 변경 알림 하나를 처리합니다
+// notification이 nil이면(재연결) 또는 페이로드를 해석할 수 없으면 캐시 전체를 비웁니다
+func handleSiteCacheNotification(notification *pq.Notification) {
+	if notification == nil {
+		currentSiteCache().Clear()
+		return
+	}
+
+	siteID, err := strconv.ParseInt(notification.Extra, 10, 64)
+	if err != nil {
+		log.Printf("[ERROR] Site cache listener: invalid payload %q", notification.Extra)
+		currentSiteCache().Clear()
+		return
+	}
+
+	InvalidateSite(siteID)
+}
@@ -0,0 +1,158 @@
+package database
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"github.com/june20516/orbithall/internal/models"
+)
+
+// cacheEntry는 캐시된 사이트 정보와 만료 시간을 저장합니다
+// site가 nil이면 조회되지 않는 키의 네거티브 항목입니다
+type cacheEntry struct {
+	apiKey    string
+	site      *models.Site
+	expiresAt time.Time
+}
+
+// isExpired는 캐시 항목이 만료되었는지 확인합니다
+func (e *cacheEntry) isExpired() bool {
+	return time.Now().After(e.expiresAt)
+}
+
+// MemorySiteCache는 최대 항목 수가 제한된 인스턴스 내 메모리 캐시입니다 (SiteCache 구현)
+// 가득 차면 가장 오래 사용되지 않은 항목부터 제거하며(LRU), 만료된 항목은 조회 시 제거합니다
+type MemorySiteCache struct {
+	mu       sync.Mutex
+	capacity int
+
+	// order는 최근 사용 순서입니다 (앞쪽이 가장 최근)
+	order *list.List
+	// entries는 API 키를 키로, order의 원소(*cacheEntry)를 값으로 저장합니다
+	entries map[string]*list.Element
+	// siteKeys는 사이트별로 캐시된 API 키 목록입니다 (DeleteSite용)
+	siteKeys map[int64]map[string]struct{}
+	// negativeKeys는 네거티브 항목의 API 키 목록입니다
+	negativeKeys map[string]struct{}
+}
+
+// NewMemorySiteCache는 최대 capacity개의 항목을 저장하는 메모리 캐시를 생성합니다
+// capacity가 1보다 작으면 1로 설정합니다
+func NewMemorySiteCache(capacity int) *MemorySiteCache {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &MemorySiteCache{
+		capacity:     capacity,
+		order:        list.New(),
+		entries:      make(map[string]*list.Element),
+		siteKeys:     make(map[int64]map[string]struct{}),
+		negativeKeys: make(map[string]struct{}),
+	}
+}
+
+// Get은 API 키의 캐시 항목을 반환하고 최근 사용으로 표시합니다
+func (c *MemorySiteCache) Get(apiKey string) (*models.Site, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[apiKey]
+	if !ok {
+		return nil, false
+	}
+
+	entry := element.Value.(*cacheEntry)
+	if entry.isExpired() {
+		c.removeElement(element)
+		return nil, false
+	}
+
+	c.order.MoveToFront(element)
+	return entry.site, true
+}
+
+// Set은 API 키의 캐시 항목을 ttl 동안 저장합니다
+// 최대 항목 수를 넘으면 가장 오래 사용되지 않은 항목을 제거합니다
+func (c *MemorySiteCache) Set(apiKey string, site *models.Site, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// 기존 항목은 교체 (사이트 인덱스도 새 항목 기준으로 갱신)
+	if element, ok := c.entries[apiKey]; ok {
+		c.removeElement(element)
+	}
+
+	entry := &cacheEntry{apiKey: apiKey, site: site, expiresAt: time.Now().Add(ttl)}
+	c.entries[apiKey] = c.order.PushFront(entry)
+	if site == nil {
+		c.negativeKeys[apiKey] = struct{}{}
+	} else {
+		if c.siteKeys[site.ID] == nil {
+			c.siteKeys[site.ID] = make(map[string]struct{})
+		}
+		c.siteKeys[site.ID][apiKey] = struct{}{}
+	}
+
+	for c.order.Len() > c.capacity {
+		c.removeElement(c.order.Back())
+	}
+}
+
+// Delete는 API 키의 캐시 항목을 제거합니다
+func (c *MemorySiteCache) Delete(apiKey string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, ok := c.entries[apiKey]; ok {
+		c.removeElement(element)
+	}
+}
+
+// DeleteSite는 사이트의 모든 키 항목과 네거티브 항목을 제거합니다
+func (c *MemorySiteCache) DeleteSite(siteID int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for apiKey := range c.siteKeys[siteID] {
+		c.removeElement(c.entries[apiKey])
+	}
+	for apiKey := range c.negativeKeys {
+		c.removeElement(c.entries[apiKey])
+	}
+}
+
+// Clear는 모든 항목을 제거합니다
+func (c *MemorySiteCache) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.order.Init()
+	c.entries = make(map[string]*list.Element)
+	c.siteKeys = make(map[int64]map[string]struct{})
+	c.negativeKeys = make(map[string]struct{})
+}
+
+// Len은 저장된 항목 수를 반환합니다 (만료되었지만 아직 제거되지 않은 항목 포함)
+func (c *MemorySiteCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.order.Len()
+}
+
+// removeElement는 항목을 목록과 모든 인덱스에서 제거합니다 (c.mu를 잡은 상태에서 호출)
+func (c *MemorySiteCache) removeElement(element *list.Element) {
+	entry := c.order.Remove(element).(*cacheEntry)
+	delete(c.entries, entry.apiKey)
+
+	if entry.site == nil {
+		delete(c.negativeKeys, entry.apiKey)
+		return
+	}
+	keys := c.siteKeys[entry.site.ID]
+	delete(keys, entry.apiKey)
+	if len(keys) == 0 {
+		delete(c.siteKeys, entry.site.ID)
+	}
+}
@@ -1,6 +1,7 @@
 package database
 
 import (
+	"errors"
 	"testing"
 	"time"
 
@@ -51,10 +52,7 @@ func TestGetSiteByAPIKey_CacheHit_NoDBQuery(t *testing.T) {
 		UpdatedAt:   time.Now(),
 	}
 
-	siteCache.Store(apiKey, &cacheEntry{
-		site:      cachedSite,
-		expiresAt: time.Now().Add(1 * time.Minute),
-	})
+	currentSiteCache().Set(models.HashAPIKey(apiKey), cachedSite, 1*time.Minute)
 
 	// When: GetSiteByAPIKey 호출 (DB는 nil이어도 작동해야 함)
 	site, err := GetSiteByAPIKey(ctx, tx, apiKey)
@@ -68,7 +66,7 @@ func TestGetSiteByAPIKey_CacheHit_NoDBQuery(t *testing.T) {
 	}
 
 	// Cleanup
-	siteCache.Delete(apiKey)
+	InvalidateAPIKey(apiKey)
 }
 
 // TestGetSiteByAPIKey_ExpiredCache_QueriesDB는 만료된 캐시는 DB 재조회하는지 테스트합니다
@@ -88,10 +86,7 @@ func TestGetSiteByAPIKey_ExpiredCache_QueriesDB(t *testing.T) {
 		IsActive: true,
 	}
 
-	siteCache.Store(apiKey, &cacheEntry{
-		site:      expiredSite,
-		expiresAt: time.Now().Add(-1 * time.Minute), // 이미 만료됨
-	})
+	currentSiteCache().Set(models.HashAPIKey(apiKey), expiredSite, -1*time.Minute) // 이미 만료됨
 
 	// When: GetSiteByAPIKey 호출
 	_, err := GetSiteByAPIKey(ctx, tx, apiKey)
@@ -102,10 +97,11 @@ func TestGetSiteByAPIKey_ExpiredCache_QueriesDB(t *testing.T) {
 		t.Logf("expected behavior: expired cache removed, DB query failed - %v", err)
 	}
 
-	// 캐시에서 삭제되었는지 확인
-	if _, ok := siteCache.Load(apiKey); ok {
+	// 만료된 항목이 반환되지 않았는지 확인 (DB에 없으면 네거티브 항목으로 교체됨)
+	if site, _ := currentSiteCache().Get(models.HashAPIKey(apiKey)); site != nil {
 		t.Error("expected expired cache to be removed")
 	}
+	InvalidateAPIKey(apiKey)
 }
 
 // TestCacheEntry_isExpired는 캐시 만료 판단 로직을 테스트합니다
@@ -148,10 +144,7 @@ func TestGetSiteByAPIKey_Concurrency(t *testing.T) {
 		IsActive: true,
 	}
 
-	siteCache.Store(apiKey, &cacheEntry{
-		site:      testSite,
-		expiresAt: time.Now().Add(1 * time.Minute),
-	})
+	currentSiteCache().Set(models.HashAPIKey(apiKey), testSite, 1*time.Minute)
 
 	// When: 여러 goroutine에서 동시 접근
 	done := make(chan bool)
@@ -171,5 +164,150 @@ func TestGetSiteByAPIKey_Concurrency(t *testing.T) {
 	}
 
 	// Cleanup
-	siteCache.Delete(apiKey)
+	InvalidateAPIKey(apiKey)
+}
+
+// TestGetSiteByAPIKey_NegativeCache는 조회되지 않는 키가 네거티브 캐시되고, 사이트 변경 시 무효화되는지 테스트합니다
+func TestGetSiteByAPIKey_NegativeCache(t *testing.T) {
+	db := setupTestDB(t)
+	defer Close(db)
+
+	ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+	defer cleanup()
+
+	// Given: 비활성화된 사이트의 키
+	site := testhelpers.CreateTestSite(ctx, t, tx, "Test Site", "negative.com", []string{"https://negative.com"}, false)
+	defer InvalidateAPIKey(site.APIKey)
+
+	// When: 같은 키로 두 번 조회
+	before := GetSiteCacheStats()
+	_, err1 := GetSiteByAPIKey(ctx, tx, site.APIKey)
+	_, err2 := GetSiteByAPIKey(ctx, tx, site.APIKey)
+	after := GetSiteCacheStats()
+
+	// Then: 두 번 모두 ErrSiteNotFound, 두 번째는 DB 조회 없이 네거티브 항목으로 거부
+	if !errors.Is(err1, ErrSiteNotFound) || !errors.Is(err2, ErrSiteNotFound) {
+		t.Fatalf("expected ErrSiteNotFound, got: %v, %v", err1, err2)
+	}
+	if after.Misses-before.Misses != 1 || after.NegativeHits-before.NegativeHits != 1 {
+		t.Errorf("expected 1 miss and 1 negative hit, got %+v -> %+v", before, after)
+	}
+
+	// When: 사이트를 다시 활성화한 뒤 조회
+	if err := UpdateSite(ctx, tx, site.ID, site.Name, site.CORSOrigins, true); err != nil {
+		t.Fatalf("failed to update site: %v", err)
+	}
+	reactivated, err := GetSiteByAPIKey(ctx, tx, site.APIKey)
+
+	// Then: 네거티브 항목이 무효화되어 바로 조회됨
+	if err != nil || reactivated.ID != site.ID {
+		t.Fatalf("expected reactivated site, got: %v, %v", reactivated, err)
+	}
+
+	// When: 사이트를 비활성화한 뒤 조회
+	if err := UpdateSite(ctx, tx, site.ID, site.Name, site.CORSOrigins, false); err != nil {
+		t.Fatalf("failed to update site: %v", err)
+	}
+	_, err = GetSiteByAPIKey(ctx, tx, site.APIKey)
+
+	// Then: 캐시된 사이트가 무효화되어 바로 거부됨
+	if !errors.Is(err, ErrSiteNotFound) {
+		t.Errorf("expected ErrSiteNotFound after deactivation, got: %v", err)
+	}
+}
+
+// TestMemorySiteCache는 메모리 캐시의 LRU 제거, 만료, 사이트 단위 무효화를 테스트합니다
+func TestMemorySiteCache(t *testing.T) {
+	siteA := &models.Site{ID: 1, Name: "A"}
+	siteB := &models.Site{ID: 2, Name: "B"}
+
+	t.Run("최대 항목 수를 넘으면 가장 오래 사용되지 않은 항목 제거", func(t *testing.T) {
+		// Given: 크기 2인 캐시에 두 항목 저장 후 첫 항목 사용
+		cache := NewMemorySiteCache(2)
+		cache.Set("key-a", siteA, time.Minute)
+		cache.Set("key-b", siteB, time.Minute)
+		cache.Get("key-a")
+
+		// When: 세 번째 항목 저장
+		cache.Set("key-c", nil, time.Minute)
+
+		// Then: 최근 사용하지 않은 key-b만 제거
+		if _, ok := cache.Get("key-b"); ok {
+			t.Error("expected key-b to be evicted")
+		}
+		if _, ok := cache.Get("key-a"); !ok {
+			t.Error("expected key-a to remain")
+		}
+		if cache.Len() != 2 {
+			t.Errorf("expected 2 entries, got %d", cache.Len())
+		}
+	})
+
+	t.Run("만료된 항목은 조회되지 않고 제거", func(t *testing.T) {
+		// Given: 이미 만료된 항목
+		cache := NewMemorySiteCache(10)
+		cache.Set("key-a", siteA, -time.Second)
+
+		// When: 조회
+		_, ok := cache.Get("key-a")
+
+		// Then: 조회되지 않고 제거됨
+		if ok || cache.Len() != 0 {
+			t.Errorf("expected expired entry to be removed, ok=%v len=%d", ok, cache.Len())
+		}
+	})
+
+	t.Run("네거티브 항목은 nil 사이트로 조회", func(t *testing.T) {
+		// Given: 네거티브 항목
+		cache := NewMemorySiteCache(10)
+		cache.Set("unknown", nil, time.Minute)
+
+		// When: 조회
+		site, ok := cache.Get("unknown")
+
+		// Then: 항목은 있지만 사이트는 nil
+		if !ok || site != nil {
+			t.Errorf("expected negative entry, got ok=%v site=%v", ok, site)
+		}
+	})
+
+	t.Run("사이트 단위 무효화는 해당 사이트의 키와 네거티브 항목만 제거", func(t *testing.T) {
+		// Given: 두 사이트의 키와 네거티브 항목
+		cache := NewMemorySiteCache(10)
+		cache.Set("key-a1", siteA, time.Minute)
+		cache.Set("key-a2", siteA, time.Minute)
+		cache.Set("key-b", siteB, time.Minute)
+		cache.Set("unknown", nil, time.Minute)
+
+		// When: 사이트 A 무효화
+		cache.DeleteSite(siteA.ID)
+
+		// Then: 사이트 B의 키만 남음
+		for _, key := range []string{"key-a1", "key-a2", "unknown"} {
+			if _, ok := cache.Get(key); ok {
+				t.Errorf("expected %s to be removed", key)
+			}
+		}
+		if site, ok := cache.Get("key-b"); !ok || site.ID != siteB.ID {
+			t.Error("expected key-b to remain")
+		}
+	})
+
+	t.Run("다른 사이트로 교체된 키는 이전 사이트 무효화에 영향받지 않음", func(t *testing.T) {
+		// Given: 사이트 A로 저장했다가 사이트 B로 교체한 키
+		cache := NewMemorySiteCache(10)
+		cache.Set("key", siteA, time.Minute)
+		cache.Set("key", siteB, time.Minute)
+
+		// When: 사이트 A 무효화
+		cache.DeleteSite(siteA.ID)
+
+		// Then: 키는 사이트 B로 남음
+		if site, ok := cache.Get("key"); !ok || site.ID != siteB.ID {
+			t.Error("expected key to remain for site B")
+		}
+		if cache.Len() != 1 {
+			t.Errorf("expected 1 entry, got %d", cache.Len())
+		}
+	})
 }
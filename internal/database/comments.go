@@ -4,15 +4,29 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"time"
 
 	"github.com/june20516/orbithall/internal/models"
 	"golang.org/x/crypto/bcrypt"
 )
 
+// commentColumns는 comments 테이블 조회 시 사용하는 컬럼 목록입니다
+// scanComment의 Scan 순서와 반드시 일치해야 합니다
+const commentColumns = `id, post_id, parent_id, author_name, author_password, content, ip_address_encrypted, ip_address_masked, user_agent, is_deleted, created_at, updated_at, deleted_at`
+
+// rowScanner는 *sql.Row와 *sql.Rows가 공통으로 제공하는 Scan 메서드를 추상화합니다
+// 단건 조회와 목록 조회에서 같은 매핑 코드를 재사용하기 위해 사용합니다
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
 // scanComment는 데이터베이스 row를 Comment 모델로 변환합니다
-// database/sql의 Scan 메서드를 활용하여 12개 필드를 매핑합니다
-func scanComment(row *sql.Row) (*models.Comment, error) {
+// database/sql의 Scan 메서드를 활용하여 13개 필드를 매핑합니다
+// IP 주소 컬럼과 user_agent는 NULL일 수 있으므로 (예: 가져오기한 댓글) 빈 문자열로 변환합니다
+// IP 주소는 암호화된 값과 마스킹 값만 채우며, 복호화는 Admin 조회(GetAdminComments)에서만 합니다
+func scanComment(row rowScanner) (*models.Comment, error) {
 	var comment models.Comment
+	var ipEncrypted, ipMasked, userAgent sql.NullString
 	err := row.Scan(
 		&comment.ID,
 		&comment.PostID,
@@ -20,8 +34,9 @@ func scanComment(row *sql.Row) (*models.Comment, error) {
 		&comment.AuthorName,
 		&comment.AuthorPassword,
 		&comment.Content,
-		&comment.IPAddress,
-		&comment.UserAgent,
+		&ipEncrypted,
+		&ipMasked,
+		&userAgent,
 		&comment.IsDeleted,
 		&comment.CreatedAt,
 		&comment.UpdatedAt,
@@ -30,61 +45,158 @@ func scanComment(row *sql.Row) (*models.Comment, error) {
 	if err != nil {
 		return nil, err
 	}
+	comment.IPAddressEncrypted = ipEncrypted.String
+	comment.IPAddressMasked = ipMasked.String
+	comment.UserAgent = userAgent.String
 	return &comment, nil
 }
 
 // CreateComment는 새로운 댓글을 생성합니다
 // 비밀번호는 bcrypt로 해싱하여 저장하고, 대댓글의 depth를 검증합니다 (1 depth만 허용)
+// IP 주소는 암호화하여 조회용 해시, 마스킹 값과 함께 저장합니다
+// 댓글 INSERT와 포스트의 comment_count 증가는 하나의 트랜잭션에서 실행됩니다
 func CreateComment(ctx context.Context, db DBTX, postID int64, parentID *int64, authorName, password, content, ipAddress, userAgent string) (*models.Comment, error) {
-	// 1단계: 부모 댓글이 있으면 depth 검증 (2depth 금지)
-	if parentID != nil {
-		var parentParentID sql.NullInt64
-		err := db.QueryRowContext(ctx, `
-			SELECT parent_id
-			FROM comments
-			WHERE id = $1
-		`, *parentID).Scan(&parentParentID)
+	// 1단계: 비밀번호 해싱 (bcrypt cost 12, 트랜잭션을 오래 잡지 않도록 먼저 수행)
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), 12)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash password: %w", err)
+	}
+	ip, err := protectIPAddress(ipAddress)
+	if err != nil {
+		return nil, err
+	}
 
-		if err == sql.ErrNoRows {
-			return nil, ErrParentCommentNotFound
+	var comment *models.Comment
+	err = RunInTx(ctx, db, func(tx DBTX) error {
+		// 2단계: 부모 댓글이 있으면 depth 검증 (2depth 금지)
+		if parentID != nil {
+			var parentParentID sql.NullInt64
+			err := tx.QueryRowContext(ctx, `
+				SELECT parent_id
+				FROM comments
+				WHERE id = $1
+			`, *parentID).Scan(&parentParentID)
+
+			if err == sql.ErrNoRows {
+				return ErrParentCommentNotFound
+			}
+			if err != nil {
+				return fmt.Errorf("failed to query parent comment: %w", err)
+			}
+
+			// 부모 댓글이 이미 대댓글이면 (parent_id가 null이 아니면) 2depth이므로 거부
+			if parentParentID.Valid {
+				return ErrNestedReplyNotAllowed
+			}
 		}
+
+		// 3단계: 댓글 INSERT 및 RETURNING으로 생성된 레코드 조회
+		query := `
+			INSERT INTO comments (post_id, parent_id, author_name, author_password, content, ip_address_encrypted, ip_address_hash, ip_address_masked, user_agent, is_deleted)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, FALSE)
+			RETURNING ` + commentColumns
+
+		row := tx.QueryRowContext(ctx, query, postID, parentID, authorName, string(hashedPassword), content, ip.encrypted, ip.hash, ip.masked, userAgent)
+		created, err := scanComment(row)
 		if err != nil {
-			return nil, fmt.Errorf("failed to query parent comment: %w", err)
+			return fmt.Errorf("failed to create comment: %w", err)
 		}
 
-		// 부모 댓글이 이미 대댓글이면 (parent_id가 null이 아니면) 2depth이므로 거부
-		if parentParentID.Valid {
-			return nil, ErrNestedReplyNotAllowed
+		// 4단계: 댓글 수 증가
+		if err := IncrementCommentCount(ctx, tx, postID); err != nil {
+			return err
 		}
+
+		comment = created
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	// 2단계: 비밀번호 해싱 (bcrypt cost 12)
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), 12)
+	return comment, nil
+}
+
+// ImportComment는 외부 플랫폼(Disqus, WordPress 등)에서 가져온 댓글을 저장합니다
+// 원본 작성 시각(CreatedAt)과 삭제 여부(IsDeleted, DeletedAt)를 그대로 유지합니다
+// 가져온 댓글은 비밀번호가 없으므로 author_password는 빈 문자열로 저장되어 위젯에서 수정/삭제할 수 없습니다
+// IP 주소는 암호화하여 저장하며, IP 주소와 User-Agent가 비어있으면 NULL로 저장합니다
+//
+// (post_id, source, sourceID) 조합이 이미 존재하면 새로 만들지 않고 기존 댓글의 ID를 채워 반환합니다
+// 같은 파일을 여러 번 가져와도 중복이 생기지 않도록 ON CONFLICT DO NOTHING + 재조회 패턴을 사용합니다
+// 반환값 created는 새로 저장된 경우 true입니다
+func ImportComment(ctx context.Context, db DBTX, comment *models.Comment, source, sourceID string) (bool, error) {
+	var deletedAt *time.Time
+	if comment.IsDeleted {
+		deletedAt = comment.DeletedAt
+		if deletedAt == nil {
+			// 원본에 삭제 시각이 없으면 가져온 시각을 삭제 시각으로 사용
+			now := time.Now()
+			deletedAt = &now
+		}
+	}
+	ip, err := protectIPAddress(comment.IPAddress)
 	if err != nil {
-		return nil, fmt.Errorf("failed to hash password: %w", err)
+		return false, err
 	}
 
-	// 3단계: 댓글 INSERT 및 RETURNING으로 생성된 레코드 조회
-	query := `
-		INSERT INTO comments (post_id, parent_id, author_name, author_password, content, ip_address, user_agent, is_deleted)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, FALSE)
-		RETURNING id, post_id, parent_id, author_name, author_password, content, ip_address, user_agent, is_deleted, created_at, updated_at, deleted_at
-	`
+	// 1단계: INSERT 시도 (이미 가져온 댓글이면 무시)
+	err = db.QueryRowContext(ctx, `
+		INSERT INTO comments (post_id, parent_id, author_name, author_password, content, ip_address_encrypted, ip_address_hash, ip_address_masked, user_agent, is_deleted, created_at, updated_at, deleted_at, import_source, import_source_id)
+		VALUES ($1, $2, $3, '', $4, $5, $6, $7, $8, $9, $10, $10, $11, $12, $13)
+		ON CONFLICT (post_id, import_source, import_source_id) DO NOTHING
+		RETURNING id
+	`,
+		comment.PostID,
+		comment.ParentID,
+		comment.AuthorName,
+		comment.Content,
+		ip.encrypted,
+		ip.hash,
+		ip.masked,
+		nullIfEmpty(comment.UserAgent),
+		comment.IsDeleted,
+		comment.CreatedAt,
+		deletedAt,
+		source,
+		sourceID,
+	).Scan(&comment.ID)
+
+	if err == nil {
+		comment.DeletedAt = deletedAt
+		return true, nil
+	}
+	if err != sql.ErrNoRows {
+		return false, fmt.Errorf("failed to import comment: %w", err)
+	}
 
-	row := db.QueryRowContext(ctx, query, postID, parentID, authorName, string(hashedPassword), content, ipAddress, userAgent)
-	comment, err := scanComment(row)
+	// 2단계: 충돌한 경우 기존 댓글 ID 재조회
+	err = db.QueryRowContext(ctx, `
+		SELECT id
+		FROM comments
+		WHERE post_id = $1 AND import_source = $2 AND import_source_id = $3
+	`, comment.PostID, source, sourceID).Scan(&comment.ID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create comment: %w", err)
+		return false, fmt.Errorf("failed to get imported comment: %w", err)
 	}
 
-	return comment, nil
+	return false, nil
+}
+
+// nullIfEmpty는 빈 문자열을 NULL로 저장하기 위해 nil로 변환합니다
+// 값이 없는 컬럼을 빈 문자열 대신 NULL로 저장할 때 사용합니다
+func nullIfEmpty(value string) any {
+	if value == "" {
+		return nil
+	}
+	return value
 }
 
 // GetCommentByID는 ID로 댓글을 조회합니다
 // 삭제된 댓글(is_deleted=true)도 조회됩니다
 func GetCommentByID(ctx context.Context, db DBTX, commentID int64) (*models.Comment, error) {
 	query := `
-		SELECT id, post_id, parent_id, author_name, author_password, content, ip_address, user_agent, is_deleted, created_at, updated_at, deleted_at
+		SELECT ` + commentColumns + `
 		FROM comments
 		WHERE id = $1
 	`
@@ -101,19 +213,27 @@ func GetCommentByID(ctx context.Context, db DBTX, commentID int64) (*models.Comm
 	return comment, nil
 }
 
-// UpdateComment는 댓글의 content, ip_address, user_agent를 수정합니다
+// UpdateComment는 댓글의 content, IP 주소(암호화), user_agent를 수정합니다
 // 삭제된 댓글(is_deleted=true)은 수정할 수 없습니다
 func UpdateComment(ctx context.Context, db DBTX, commentID int64, content, ipAddress, userAgent string) error {
+	ip, err := protectIPAddress(ipAddress)
+	if err != nil {
+		return err
+	}
+
 	query := `
 		UPDATE comments
 		SET content = $1,
-			ip_address = $2,
-			user_agent = $3,
+			ip_address = NULL,
+			ip_address_encrypted = $2,
+			ip_address_hash = $3,
+			ip_address_masked = $4,
+			user_agent = $5,
 			updated_at = CLOCK_TIMESTAMP()
-		WHERE id = $4 AND is_deleted = FALSE
+		WHERE id = $6 AND is_deleted = FALSE
 	`
 
-	result, err := db.ExecContext(ctx, query, content, ipAddress, userAgent, commentID)
+	result, err := db.ExecContext(ctx, query, content, ip.encrypted, ip.hash, ip.masked, userAgent, commentID)
 	if err != nil {
 		return fmt.Errorf("failed to update comment: %w", err)
 	}
@@ -133,29 +253,54 @@ func UpdateComment(ctx context.Context, db DBTX, commentID int64, content, ipAdd
 // DeleteComment는 댓글을 soft delete 처리합니다
 // is_deleted를 TRUE로 설정하고 deleted_at에 현재 시각을 기록합니다
 // 이미 삭제된 댓글은 다시 삭제할 수 없습니다
+// 삭제와 포스트의 comment_count 감소는 하나의 트랜잭션에서 실행됩니다
+// 댓글이 없거나 이미 삭제된 상태면 ErrCommentNotFound를 반환합니다 (동시에 삭제된 경우 포함)
 func DeleteComment(ctx context.Context, db DBTX, commentID int64) error {
-	query := `
-		UPDATE comments
-		SET is_deleted = TRUE,
-			deleted_at = CLOCK_TIMESTAMP()
-		WHERE id = $1 AND is_deleted = FALSE
-	`
+	return RunInTx(ctx, db, func(tx DBTX) error {
+		var postID int64
+		err := tx.QueryRowContext(ctx, `
+			UPDATE comments
+			SET is_deleted = TRUE,
+				deleted_at = CLOCK_TIMESTAMP()
+			WHERE id = $1 AND is_deleted = FALSE
+			RETURNING post_id
+		`, commentID).Scan(&postID)
 
-	result, err := db.ExecContext(ctx, query, commentID)
-	if err != nil {
-		return fmt.Errorf("failed to delete comment: %w", err)
-	}
+		if err == sql.ErrNoRows {
+			return ErrCommentNotFound
+		}
+		if err != nil {
+			return fmt.Errorf("failed to delete comment: %w", err)
+		}
 
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		return fmt.Errorf("failed to get rows affected: %w", err)
-	}
+		return DecrementCommentCount(ctx, tx, postID)
+	})
+}
 
-	if rowsAffected == 0 {
-		return fmt.Errorf("comment not found or already deleted")
-	}
+// RestoreComment는 soft delete된 댓글을 복구합니다 (Admin용)
+// is_deleted를 FALSE로, deleted_at을 NULL로 되돌립니다
+// 복구와 포스트의 comment_count 증가는 하나의 트랜잭션에서 실행됩니다
+// 댓글이 없거나 삭제되지 않은 상태면 ErrCommentNotFound를 반환합니다
+func RestoreComment(ctx context.Context, db DBTX, commentID int64) error {
+	return RunInTx(ctx, db, func(tx DBTX) error {
+		var postID int64
+		err := tx.QueryRowContext(ctx, `
+			UPDATE comments
+			SET is_deleted = FALSE,
+				deleted_at = NULL
+			WHERE id = $1 AND is_deleted = TRUE
+			RETURNING post_id
+		`, commentID).Scan(&postID)
 
-	return nil
+		if err == sql.ErrNoRows {
+			return ErrCommentNotFound
+		}
+		if err != nil {
+			return fmt.Errorf("failed to restore comment: %w", err)
+		}
+
+		return IncrementCommentCount(ctx, tx, postID)
+	})
 }
 
 // ListComments는 포스트의 댓글 목록을 2-level 계층 구조로 조회합니다
@@ -175,7 +320,7 @@ func ListComments(ctx context.Context, db DBTX, postID int64, limit, offset int)
 
 	// 2단계: 최상위 댓글 조회 (페이지네이션)
 	query := `
-		SELECT id, post_id, parent_id, author_name, author_password, content, ip_address, user_agent, is_deleted, created_at, updated_at, deleted_at
+		SELECT ` + commentColumns + `
 		FROM comments
 		WHERE post_id = $1 AND parent_id IS NULL
 		ORDER BY created_at ASC, id ASC
@@ -190,25 +335,11 @@ func ListComments(ctx context.Context, db DBTX, postID int64, limit, offset int)
 
 	var comments []*models.Comment
 	for rows.Next() {
-		var comment models.Comment
-		err := rows.Scan(
-			&comment.ID,
-			&comment.PostID,
-			&comment.ParentID,
-			&comment.AuthorName,
-			&comment.AuthorPassword,
-			&comment.Content,
-			&comment.IPAddress,
-			&comment.UserAgent,
-			&comment.IsDeleted,
-			&comment.CreatedAt,
-			&comment.UpdatedAt,
-			&comment.DeletedAt,
-		)
+		comment, err := scanComment(rows)
 		if err != nil {
 			return nil, 0, fmt.Errorf("failed to scan comment: %w", err)
 		}
-		comments = append(comments, &comment)
+		comments = append(comments, comment)
 	}
 
 	if err = rows.Err(); err != nil {
@@ -231,7 +362,7 @@ func ListComments(ctx context.Context, db DBTX, postID int64, limit, offset int)
 // created_at ASC, id ASC 순으로 정렬됩니다
 func getReplies(ctx context.Context, db DBTX, parentID int64) ([]*models.Comment, error) {
 	query := `
-		SELECT id, post_id, parent_id, author_name, author_password, content, ip_address, user_agent, is_deleted, created_at, updated_at, deleted_at
+		SELECT ` + commentColumns + `
 		FROM comments
 		WHERE parent_id = $1
 		ORDER BY created_at ASC, id ASC
@@ -245,25 +376,11 @@ func getReplies(ctx context.Context, db DBTX, parentID int64) ([]*models.Comment
 
 	var replies []*models.Comment
 	for rows.Next() {
-		var reply models.Comment
-		err := rows.Scan(
-			&reply.ID,
-			&reply.PostID,
-			&reply.ParentID,
-			&reply.AuthorName,
-			&reply.AuthorPassword,
-			&reply.Content,
-			&reply.IPAddress,
-			&reply.UserAgent,
-			&reply.IsDeleted,
-			&reply.CreatedAt,
-			&reply.UpdatedAt,
-			&reply.DeletedAt,
-		)
+		reply, err := scanComment(rows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan reply: %w", err)
 		}
-		replies = append(replies, &reply)
+		replies = append(replies, reply)
 	}
 
 	if err = rows.Err(); err != nil {
@@ -274,7 +391,7 @@ func getReplies(ctx context.Context, db DBTX, parentID int64) ([]*models.Comment
 }
 
 // GetAdminComments는 Admin용 댓글 조회 함수입니다
-// 일반 ListComments와 달리 삭제된 댓글도 포함하며, IP 마스킹을 하지 않습니다
+// 일반 ListComments와 달리 삭제된 댓글도 포함하며, 암호화된 IP 주소를 복호화하여 IPAddress에 채웁니다
 func GetAdminComments(ctx context.Context, db DBTX, postID int64, limit, offset int) ([]*models.Comment, int, error) {
 	// 1단계: 최상위 댓글 총 개수 조회 (삭제된 것 포함)
 	var total int
@@ -289,7 +406,7 @@ func GetAdminComments(ctx context.Context, db DBTX, postID int64, limit, offset
 
 	// 2단계: 최상위 댓글 조회 (페이지네이션, 삭제된 것 포함)
 	query := `
-		SELECT id, post_id, parent_id, author_name, author_password, content, ip_address, user_agent, is_deleted, created_at, updated_at, deleted_at
+		SELECT ` + commentColumns + `
 		FROM comments
 		WHERE post_id = $1 AND parent_id IS NULL
 		ORDER BY created_at ASC, id ASC
@@ -304,33 +421,22 @@ func GetAdminComments(ctx context.Context, db DBTX, postID int64, limit, offset
 
 	var comments []*models.Comment
 	for rows.Next() {
-		var comment models.Comment
-		err := rows.Scan(
-			&comment.ID,
-			&comment.PostID,
-			&comment.ParentID,
-			&comment.AuthorName,
-			&comment.AuthorPassword,
-			&comment.Content,
-			&comment.IPAddress, // Admin은 전체 IP 확인 가능
-			&comment.UserAgent,
-			&comment.IsDeleted,
-			&comment.CreatedAt,
-			&comment.UpdatedAt,
-			&comment.DeletedAt,
-		)
+		comment, err := scanComment(rows)
 		if err != nil {
 			return nil, 0, fmt.Errorf("failed to scan comment: %w", err)
 		}
-		comments = append(comments, &comment)
+		comments = append(comments, comment)
 	}
 
 	if err = rows.Err(); err != nil {
 		return nil, 0, fmt.Errorf("rows iteration error: %w", err)
 	}
 
-	// 3단계: 각 최상위 댓글의 대댓글 조회 (삭제된 것 포함)
+	// 3단계: IP 복호화 및 각 최상위 댓글의 대댓글 조회 (삭제된 것 포함)
 	for _, comment := range comments {
+		if comment.IPAddress, err = DecryptIPAddress(comment.IPAddressEncrypted); err != nil {
+			return nil, 0, fmt.Errorf("comment %d: %w", comment.ID, err)
+		}
 		replies, err := getAdminReplies(ctx, db, comment.ID)
 		if err != nil {
 			return nil, 0, fmt.Errorf("failed to get replies for comment %d: %w", comment.ID, err)
@@ -341,10 +447,10 @@ func GetAdminComments(ctx context.Context, db DBTX, postID int64, limit, offset
 	return comments, total, nil
 }
 
-// getAdminReplies는 Admin용 대댓글 조회 (삭제된 것 포함, IP 마스킹 없음)
+// getAdminReplies는 Admin용 대댓글 조회 (삭제된 것 포함, IP 복호화)
 func getAdminReplies(ctx context.Context, db DBTX, parentID int64) ([]*models.Comment, error) {
 	query := `
-		SELECT id, post_id, parent_id, author_name, author_password, content, ip_address, user_agent, is_deleted, created_at, updated_at, deleted_at
+		SELECT ` + commentColumns + `
 		FROM comments
 		WHERE parent_id = $1
 		ORDER BY created_at ASC, id ASC
@@ -358,25 +464,14 @@ func getAdminReplies(ctx context.Context, db DBTX, parentID int64) ([]*models.Co
 
 	var replies []*models.Comment
 	for rows.Next() {
-		var reply models.Comment
-		err := rows.Scan(
-			&reply.ID,
-			&reply.PostID,
-			&reply.ParentID,
-			&reply.AuthorName,
-			&reply.AuthorPassword,
-			&reply.Content,
-			&reply.IPAddress, // Admin은 전체 IP 확인 가능
-			&reply.UserAgent,
-			&reply.IsDeleted,
-			&reply.CreatedAt,
-			&reply.UpdatedAt,
-			&reply.DeletedAt,
-		)
+		reply, err := scanComment(rows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan reply: %w", err)
 		}
-		replies = append(replies, &reply)
+		if reply.IPAddress, err = DecryptIPAddress(reply.IPAddressEncrypted); err != nil {
+			return nil, fmt.Errorf("comment %d: %w", reply.ID, err)
+		}
+		replies = append(replies, reply)
 	}
 
 	if err = rows.Err(); err != nil {
@@ -385,4 +480,3 @@ func getAdminReplies(ctx context.Context, db DBTX, parentID int64) ([]*models.Co
 
 	return replies, nil
 }
-
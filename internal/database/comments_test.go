@@ -1,6 +1,7 @@
 package database
 
 import (
+	"errors"
 	"fmt"
 	"testing"
 
@@ -59,8 +60,15 @@ func TestCreateComment(t *testing.T) {
 		if comment.Content != content {
 			t.Errorf("expected content=%s, got %s", content, comment.Content)
 		}
-		if comment.IPAddress != ipAddress {
-			t.Errorf("expected ip_address=%s, got %s", ipAddress, comment.IPAddress)
+		// IP 주소는 암호화되어 저장되고, 마스킹 값만 평문으로 조회됨
+		if comment.IPAddress != "" {
+			t.Errorf("expected ip_address to be empty outside admin views, got %s", comment.IPAddress)
+		}
+		if decrypted, err := DecryptIPAddress(comment.IPAddressEncrypted); err != nil || decrypted != ipAddress {
+			t.Errorf("expected encrypted ip_address=%s, got %s (err=%v)", ipAddress, decrypted, err)
+		}
+		if comment.IPAddressMasked == "" {
+			t.Error("expected masked ip_address")
 		}
 		if comment.UserAgent != userAgent {
 			t.Errorf("expected user_agent=%s, got %s", userAgent, comment.UserAgent)
@@ -307,8 +315,8 @@ func TestUpdateComment(t *testing.T) {
 			t.Errorf("expected content=%s, got %s", newContent, updated.Content)
 		}
 		// IP와 User-Agent가 수정 시점의 값으로 업데이트되었는지 확인
-		if updated.IPAddress != newIP {
-			t.Errorf("expected ip_address=%s, got %s", newIP, updated.IPAddress)
+		if decrypted, _ := DecryptIPAddress(updated.IPAddressEncrypted); decrypted != newIP {
+			t.Errorf("expected ip_address=%s, got %s", newIP, decrypted)
 		}
 		if updated.UserAgent != newUA {
 			t.Errorf("expected user_agent=%s, got %s", newUA, updated.UserAgent)
@@ -326,9 +334,9 @@ func TestUpdateComment(t *testing.T) {
 		// When: 수정 시도
 		err := UpdateComment(ctx, tx, nonExistentID, "New content", "10.0.0.1", "Agent")
 
-		// Then: 에러 반환
-		if err == nil {
-			t.Fatal("expected error for non-existent comment, got nil")
+		// Then: ErrCommentNotFound 반환
+		if !errors.Is(err, ErrCommentNotFound) {
+			t.Fatalf("expected ErrCommentNotFound for non-existent comment, got %v", err)
 		}
 	})
 
@@ -413,9 +421,9 @@ func TestDeleteComment(t *testing.T) {
 		// When: 삭제 시도
 		err := DeleteComment(ctx, tx, nonExistentID)
 
-		// Then: 에러 반환
-		if err == nil {
-			t.Fatal("expected error for non-existent comment, got nil")
+		// Then: ErrCommentNotFound 반환
+		if !errors.Is(err, ErrCommentNotFound) {
+			t.Fatalf("expected ErrCommentNotFound for non-existent comment, got %v", err)
 		}
 	})
 
@@ -438,10 +446,81 @@ func TestDeleteComment(t *testing.T) {
 		// When: 이미 삭제된 댓글 삭제 시도
 		err = DeleteComment(ctx, tx, commentID)
 
-		// Then: 에러 반환
-		if err == nil {
-			t.Fatal("expected error for already deleted comment, got nil")
+		// Then: ErrCommentNotFound 반환
+		if !errors.Is(err, ErrCommentNotFound) {
+			t.Fatalf("expected ErrCommentNotFound for already deleted comment, got %v", err)
+		}
+	})
+}
+
+// TestCommentCountConsistency는 댓글 작성/삭제/복구 시 comment_count가 함께 변경되는지 테스트합니다
+func TestCommentCountConsistency(t *testing.T) {
+	db := testhelpers.SetupTestDB(t)
+	defer Close(db)
+
+	ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+	defer cleanup()
+
+	siteID := testhelpers.CreateTestSite(ctx, t, tx, "Test Site", "count.com", []string{"http://localhost:3000"}, true).ID
+	postID := testhelpers.CreateTestPost(ctx, t, tx, siteID, "count-post", "Count Post").ID
+
+	assertCount := func(t *testing.T, expected int) {
+		t.Helper()
+		post, err := GetPostByID(ctx, tx, postID)
+		if err != nil {
+			t.Fatalf("failed to get post: %v", err)
+		}
+		if post.CommentCount != expected {
+			t.Errorf("expected comment_count=%d, got %d", expected, post.CommentCount)
+		}
+	}
+
+	// Given: 댓글과 대댓글 작성
+	comment, err := CreateComment(ctx, tx, postID, nil, "Author", "password123", "Content", "192.168.1.1", "Mozilla/5.0")
+	if err != nil {
+		t.Fatalf("failed to create comment: %v", err)
+	}
+	if _, err := CreateComment(ctx, tx, postID, &comment.ID, "Replier", "password123", "Reply", "192.168.1.2", "Mozilla/5.0"); err != nil {
+		t.Fatalf("failed to create reply: %v", err)
+	}
+	assertCount(t, 2)
+
+	t.Run("삭제하면 감소", func(t *testing.T) {
+		if err := DeleteComment(ctx, tx, comment.ID); err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		assertCount(t, 1)
+	})
+
+	t.Run("복구하면 증가", func(t *testing.T) {
+		if err := RestoreComment(ctx, tx, comment.ID); err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		assertCount(t, 2)
+
+		restored, _ := GetCommentByID(ctx, tx, comment.ID)
+		if restored.IsDeleted || restored.DeletedAt != nil {
+			t.Errorf("expected restored comment, got %+v", restored)
+		}
+	})
+
+	t.Run("삭제되지 않은 댓글 복구 시 ErrCommentNotFound", func(t *testing.T) {
+		if err := RestoreComment(ctx, tx, comment.ID); !errors.Is(err, ErrCommentNotFound) {
+			t.Errorf("expected ErrCommentNotFound, got: %v", err)
+		}
+		assertCount(t, 2)
+	})
+
+	t.Run("대댓글 depth 위반 시 카운트 변화 없음", func(t *testing.T) {
+		reply, err := CreateComment(ctx, tx, postID, &comment.ID, "Replier", "password123", "Reply 2", "192.168.1.2", "Mozilla/5.0")
+		if err != nil {
+			t.Fatalf("failed to create reply: %v", err)
+		}
+		_, err = CreateComment(ctx, tx, postID, &reply.ID, "Nested", "password123", "Nested", "192.168.1.3", "Mozilla/5.0")
+		if !errors.Is(err, ErrNestedReplyNotAllowed) {
+			t.Fatalf("expected ErrNestedReplyNotAllowed, got: %v", err)
 		}
+		assertCount(t, 3)
 	})
 }
 
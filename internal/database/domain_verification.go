@@ -0,0 +1,75 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/lib/pq"
+)
+
+// IsDomainVerified는 도메인의 소유권을 검증한 사이트가 있는지 확인합니다 (대소문자 구분 없음)
+func IsDomainVerified(ctx context.Context, db DBTX, domain string) (bool, error) {
+	query := `
+		SELECT EXISTS(
+			SELECT 1 FROM sites
+			WHERE LOWER(domain) = LOWER($1) AND is_verified = TRUE
+		)
+	`
+
+	var exists bool
+	if err := db.QueryRowContext(ctx, query, domain).Scan(&exists); err != nil {
+		return false, fmt.Errorf("failed to check domain verification: %w", err)
+	}
+
+	return exists, nil
+}
+
+// MarkSiteDomainVerified는 사이트의 도메인 소유권 검증을 완료로 표시합니다
+// 처음 검증되는 사이트는 함께 활성화하며, 이미 검증된 사이트는 검증 시각만 갱신합니다 (활성화 상태 유지)
+// 다른 사이트가 같은 도메인을 이미 검증했으면 ErrDomainAlreadyVerified, 사이트가 없으면 sql.ErrNoRows를 반환합니다
+// 캐시된 사이트 정보는 모든 인스턴스에서 무효화됩니다
+func MarkSiteDomainVerified(ctx context.Context, db DBTX, siteID int64) error {
+	query := `
+		UPDATE sites s
+		SET is_verified = TRUE,
+			is_active = CASE WHEN s.is_verified THEN s.is_active ELSE TRUE END,
+			verified_at = NOW(),
+			updated_at = NOW()
+		WHERE s.id = $1
+		  AND NOT EXISTS (
+			SELECT 1 FROM sites o
+			WHERE o.id <> s.id AND LOWER(o.domain) = LOWER(s.domain) AND o.is_verified = TRUE
+		  )
+	`
+
+	result, err := db.ExecContext(ctx, query, siteID)
+	if err != nil {
+		// 동시에 같은 도메인을 검증한 경우 UNIQUE 인덱스(idx_sites_domain_verified)에서 거부됨
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+			return ErrDomainAlreadyVerified
+		}
+		return fmt.Errorf("failed to mark site domain verified: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		// 사이트가 없는지, 다른 사이트가 도메인을 검증했는지 구분
+		var exists bool
+		if err := db.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM sites WHERE id = $1)`, siteID).Scan(&exists); err != nil {
+			return fmt.Errorf("failed to check site: %w", err)
+		}
+		if !exists {
+			return sql.ErrNoRows
+		}
+		return ErrDomainAlreadyVerified
+	}
+
+	return notifySiteChanged(ctx, db, siteID)
+}
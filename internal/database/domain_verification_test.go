@@ -0,0 +1,102 @@
+package database
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/june20516/orbithall/internal/models"
+	"github.com/june20516/orbithall/internal/testhelpers"
+)
+
+// TestMarkSiteDomainVerified는 도메인 소유권 검증 완료 처리를 테스트합니다
+func TestMarkSiteDomainVerified(t *testing.T) {
+	db := testhelpers.SetupTestDB(t)
+	defer Close(db)
+
+	t.Run("처음 검증되면 활성화", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		// Given: 검증되지 않은 비활성 사이트
+		user := &models.User{Email: "verify@example.com", Name: "Verify"}
+		if err := CreateUser(ctx, tx, user); err != nil {
+			t.Fatalf("Failed to create user: %v", err)
+		}
+		site := &models.Site{Name: "Unverified", Domain: "verify.example.com", CORSOrigins: []string{"https://verify.example.com"}}
+		if err := CreateSiteForUser(ctx, tx, site, user.ID); err != nil {
+			t.Fatalf("Failed to create site: %v", err)
+		}
+		if site.VerificationToken == "" || site.VerifiedAt != nil {
+			t.Fatalf("expected token and no verified_at, got %q, %v", site.VerificationToken, site.VerifiedAt)
+		}
+
+		// When
+		if err := MarkSiteDomainVerified(ctx, tx, site.ID); err != nil {
+			t.Fatalf("Failed to mark verified: %v", err)
+		}
+
+		// Then: 검증되고 활성화됨
+		verified, err := GetSiteByID(ctx, tx, site.ID)
+		if err != nil {
+			t.Fatalf("Failed to get site: %v", err)
+		}
+		if !verified.IsVerified || !verified.IsActive || verified.VerifiedAt == nil {
+			t.Errorf("expected verified and active site, got is_verified=%v is_active=%v verified_at=%v", verified.IsVerified, verified.IsActive, verified.VerifiedAt)
+		}
+		if ok, _ := IsDomainVerified(ctx, tx, "VERIFY.example.com"); !ok {
+			t.Error("expected domain to be verified (case insensitive)")
+		}
+	})
+
+	t.Run("이미 검증된 사이트의 재검증은 활성화 상태 유지", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		// Given: 검증 후 비활성화한 사이트
+		site := testhelpers.CreateTestSite(ctx, t, tx, "Inactive", "inactive.example.com", []string{"https://inactive.example.com"}, false)
+
+		// When
+		if err := MarkSiteDomainVerified(ctx, tx, site.ID); err != nil {
+			t.Fatalf("Failed to mark verified: %v", err)
+		}
+
+		// Then: 비활성 상태 유지
+		verified, _ := GetSiteByID(ctx, tx, site.ID)
+		if verified.IsActive {
+			t.Error("expected site to stay inactive")
+		}
+	})
+
+	t.Run("다른 사이트가 검증한 도메인", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		// Given: 같은 도메인을 검증한 사이트와 검증되지 않은 사이트
+		testhelpers.CreateTestSite(ctx, t, tx, "Owner", "taken.example.com", []string{"https://taken.example.com"}, true)
+		user := &models.User{Email: "squatter@example.com", Name: "Squatter"}
+		if err := CreateUser(ctx, tx, user); err != nil {
+			t.Fatalf("Failed to create user: %v", err)
+		}
+		site := &models.Site{Name: "Second", Domain: "Taken.example.com", CORSOrigins: []string{"https://taken.example.com"}}
+		if err := CreateSiteForUser(ctx, tx, site, user.ID); err != nil {
+			t.Fatalf("Failed to create unverified site with same domain: %v", err)
+		}
+
+		// When
+		err := MarkSiteDomainVerified(ctx, tx, site.ID)
+
+		// Then
+		if err != ErrDomainAlreadyVerified {
+			t.Errorf("Expected ErrDomainAlreadyVerified, got %v", err)
+		}
+	})
+
+	t.Run("존재하지 않는 사이트", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		if err := MarkSiteDomainVerified(ctx, tx, 999999); err != sql.ErrNoRows {
+			t.Errorf("Expected sql.ErrNoRows, got %v", err)
+		}
+	})
+}
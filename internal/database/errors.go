@@ -23,4 +23,49 @@ var (
 
 	// ErrEditTimeExpired는 댓글 수정 가능 시간(30분)이 초과되었을 때 발생
 	ErrEditTimeExpired = errors.New("edit time expired")
+
+	// ErrSlugInUse는 별칭으로 추가하려는 slug를 사이트의 다른 포스트나 별칭이 이미 사용 중일 때 발생
+	ErrSlugInUse = errors.New("slug already in use")
+
+	// ErrAPIKeyInactive는 이미 폐기되거나 만료된 API 키를 교체하려 할 때 발생
+	ErrAPIKeyInactive = errors.New("api key is revoked or expired")
+
+	// ErrSiteNotFound는 API 키로 사이트를 조회할 때 키가 없거나 폐기/만료되었거나 사이트가 비활성화되었을 때 발생
+	ErrSiteNotFound = errors.New("site not found or inactive")
+
+	// ErrDomainAlreadyVerified는 다른 사이트가 이미 같은 도메인의 소유권을 검증했을 때 발생
+	ErrDomainAlreadyVerified = errors.New("domain already verified by another site")
+
+	// ErrLastOwner는 사이트의 마지막 owner를 제거하거나 다른 역할로 변경하려 할 때 발생
+	ErrLastOwner = errors.New("cannot remove or demote the last owner")
+
+	// ErrAlreadySiteMember는 이미 사이트 멤버인 사용자를 초대하거나, 초대를 수락하려 할 때 발생
+	ErrAlreadySiteMember = errors.New("user is already a site member")
+
+	// ErrInvitationInactive는 이미 수락되었거나 취소/만료된 초대를 수락하거나 취소하려 할 때 발생
+	ErrInvitationInactive = errors.New("invitation is accepted, revoked or expired")
+
+	// ErrInvitationEmailMismatch는 초대받은 이메일과 다른 계정으로 초대를 수락하려 할 때 발생
+	ErrInvitationEmailMismatch = errors.New("invitation email does not match user email")
+
+	// ErrAlreadySiteOwner는 이미 사이트 owner인 사용자에게 소유권을 이전하려 할 때 발생
+	ErrAlreadySiteOwner = errors.New("user is already a site owner")
+
+	// ErrTransferInactive는 이미 수락/거절/취소되었거나 만료된 소유권 이전 요청을 처리하려 할 때,
+	// 또는 요청한 사용자가 더 이상 owner가 아닐 때 발생
+	ErrTransferInactive = errors.New("ownership transfer is no longer pending")
+
+	// ErrIdentityEmailConflict는 처음 로그인한 ID 공급자 계정의 이메일을 이미 다른 사용자가 사용 중인데,
+	// 그 사용자의 이메일이 검증되지 않아 계정을 연결할 수 없을 때 발생
+	ErrIdentityEmailConflict = errors.New("email is already used by another user")
+
+	// ErrEmailNotVerified는 공급자가 검증하지 않은 이메일로 사용자를 만들거나 기존 사용자에게 계정을 연결하려 할 때,
+	// 또는 이메일이 검증되지 않은 사용자가 초대나 소유권 이전을 수락하려 할 때 발생
+	ErrEmailNotVerified = errors.New("email is not verified")
+
+	// ErrSessionInactive는 로그아웃되었거나 만료된 세션을 refresh token으로 갱신하려 할 때 발생
+	ErrSessionInactive = errors.New("session is revoked or expired")
+
+	// ErrRefreshTokenReused는 이미 교체된 refresh token이 다시 사용되었을 때 발생 (세션은 폐기됨)
+	ErrRefreshTokenReused = errors.New("refresh token was already used")
 )
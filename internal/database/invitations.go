@@ -0,0 +1,235 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/june20516/orbithall/internal/models"
+)
+
+// invitationColumns는 초대 조회 시 사용하는 컬럼 목록입니다 (scanSiteInvitation과 순서 일치)
+const invitationColumns = `id, site_id, email, role, invited_by, expires_at, accepted_at, accepted_by, revoked_at, created_at`
+
+// scanSiteInvitation은 invitationColumns 순서로 조회한 행을 SiteInvitation으로 변환합니다
+// 상태(Status)는 현재 시각 기준으로 계산합니다
+func scanSiteInvitation(row rowScanner) (*models.SiteInvitation, error) {
+	var invitation models.SiteInvitation
+	var invitedBy, acceptedBy sql.NullInt64
+	var acceptedAt, revokedAt sql.NullTime
+	err := row.Scan(
+		&invitation.ID,
+		&invitation.SiteID,
+		&invitation.Email,
+		&invitation.Role,
+		&invitedBy,
+		&invitation.ExpiresAt,
+		&acceptedAt,
+		&acceptedBy,
+		&revokedAt,
+		&invitation.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if invitedBy.Valid {
+		invitation.InvitedBy = &invitedBy.Int64
+	}
+	if acceptedAt.Valid {
+		invitation.AcceptedAt = &acceptedAt.Time
+	}
+	if acceptedBy.Valid {
+		invitation.AcceptedBy = &acceptedBy.Int64
+	}
+	if revokedAt.Valid {
+		invitation.RevokedAt = &revokedAt.Time
+	}
+	invitation.Status = invitation.StatusAt(time.Now())
+
+	return &invitation, nil
+}
+
+// CreateSiteInvitation은 사이트 멤버 초대를 생성하고 토큰을 발급합니다
+// invitation의 SiteID, Email, Role, InvitedBy, ExpiresAt을 채워 전달하면 생성된 ID, 토큰, 시각을 채웁니다
+// 같은 이메일에 대한 대기 중인 초대가 있으면 취소하고 새로 발급하며,
+// 이미 사이트 멤버인 이메일이면 ErrAlreadySiteMember를 반환합니다
+func CreateSiteInvitation(ctx context.Context, db DBTX, invitation *models.SiteInvitation) error {
+	return RunInTx(ctx, db, func(tx DBTX) error {
+		var isMember bool
+		err := tx.QueryRowContext(ctx, `
+			SELECT EXISTS(
+				SELECT 1 FROM user_sites us
+				INNER JOIN users u ON u.id = us.user_id
+				WHERE us.site_id = $1 AND LOWER(u.email) = LOWER($2)
+			)
+		`, invitation.SiteID, invitation.Email).Scan(&isMember)
+		if err != nil {
+			return fmt.Errorf("failed to check site member: %w", err)
+		}
+		if isMember {
+			return ErrAlreadySiteMember
+		}
+
+		// 이전 초대 취소 (대기 중인 초대는 이메일당 하나)
+		_, err = tx.ExecContext(ctx, `
+			UPDATE site_invitations
+			SET revoked_at = NOW()
+			WHERE site_id = $1 AND LOWER(email) = LOWER($2)
+				AND accepted_at IS NULL AND revoked_at IS NULL
+		`, invitation.SiteID, invitation.Email)
+		if err != nil {
+			return fmt.Errorf("failed to revoke previous invitation: %w", err)
+		}
+
+		token := models.GenerateInvitationToken()
+		query := `
+			INSERT INTO site_invitations (site_id, email, role, token_hash, invited_by, expires_at)
+			VALUES ($1, $2, $3, $4, $5, $6)
+			RETURNING ` + invitationColumns
+
+		created, err := scanSiteInvitation(tx.QueryRowContext(ctx, query,
+			invitation.SiteID,
+			invitation.Email,
+			invitation.Role,
+			models.HashInvitationToken(token),
+			invitation.InvitedBy,
+			invitation.ExpiresAt,
+		))
+		if err != nil {
+			return fmt.Errorf("failed to create site invitation: %w", err)
+		}
+
+		*invitation = *created
+		invitation.Token = token
+		return nil
+	})
+}
+
+// ListSiteInvitations는 사이트의 초대 목록을 최신순으로 조회합니다 (수락/취소/만료된 초대 포함)
+func ListSiteInvitations(ctx context.Context, db DBTX, siteID int64) ([]models.SiteInvitation, error) {
+	query := `
+		SELECT ` + invitationColumns + `
+		FROM site_invitations
+		WHERE site_id = $1
+		ORDER BY created_at DESC, id DESC
+	`
+
+	rows, err := db.QueryContext(ctx, query, siteID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query site invitations: %w", err)
+	}
+	defer rows.Close()
+
+	invitations := []models.SiteInvitation{}
+	for rows.Next() {
+		invitation, err := scanSiteInvitation(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan site invitation: %w", err)
+		}
+		invitations = append(invitations, *invitation)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating site invitation rows: %w", err)
+	}
+
+	return invitations, nil
+}
+
+// RevokeSiteInvitation은 수락되지 않은 초대를 취소합니다
+// 초대가 없거나 다른 사이트의 초대면 sql.ErrNoRows, 이미 수락/취소된 초대면 ErrInvitationInactive를 반환합니다
+func RevokeSiteInvitation(ctx context.Context, db DBTX, siteID, invitationID int64) (*models.SiteInvitation, error) {
+	query := `
+		UPDATE site_invitations
+		SET revoked_at = NOW()
+		WHERE id = $1 AND site_id = $2 AND accepted_at IS NULL AND revoked_at IS NULL
+		RETURNING ` + invitationColumns
+
+	invitation, err := scanSiteInvitation(db.QueryRowContext(ctx, query, invitationID, siteID))
+	if err == nil {
+		return invitation, nil
+	}
+	if err != sql.ErrNoRows {
+		return nil, fmt.Errorf("failed to revoke site invitation: %w", err)
+	}
+
+	// 초대가 존재하면 이미 수락/취소된 것
+	var exists bool
+	err = db.QueryRowContext(ctx, `
+		SELECT EXISTS(SELECT 1 FROM site_invitations WHERE id = $1 AND site_id = $2)
+	`, invitationID, siteID).Scan(&exists)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check site invitation: %w", err)
+	}
+	if exists {
+		return nil, ErrInvitationInactive
+	}
+	return nil, sql.ErrNoRows
+}
+
+// AcceptSiteInvitation은 초대 토큰으로 초대를 수락하고 사용자를 초대된 역할로 사이트에 연결합니다
+// 토큰에 해당하는 초대가 없으면 sql.ErrNoRows, 수락/취소/만료된 초대면 ErrInvitationInactive,
+// 초대받은 이메일과 사용자 이메일이 다르면 ErrInvitationEmailMismatch, 사용자 이메일이 검증되지 않았으면 ErrEmailNotVerified,
+// 사용자가 이미 사이트 멤버면 ErrAlreadySiteMember를 반환합니다
+func AcceptSiteInvitation(ctx context.Context, db DBTX, token string, user *models.User) (*models.SiteInvitation, error) {
+	var accepted *models.SiteInvitation
+	err := RunInTx(ctx, db, func(tx DBTX) error {
+		// 동시에 같은 토큰으로 두 번 수락하지 않도록 초대 행을 잠금
+		query := `
+			SELECT ` + invitationColumns + `
+			FROM site_invitations
+			WHERE token_hash = $1
+			FOR UPDATE
+		`
+
+		invitation, err := scanSiteInvitation(tx.QueryRowContext(ctx, query, models.HashInvitationToken(token)))
+		if err == sql.ErrNoRows {
+			return sql.ErrNoRows
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get site invitation: %w", err)
+		}
+
+		if invitation.Status != models.InvitationStatusPending {
+			return ErrInvitationInactive
+		}
+		if !strings.EqualFold(strings.TrimSpace(invitation.Email), strings.TrimSpace(user.Email)) {
+			return ErrInvitationEmailMismatch
+		}
+		if !user.EmailVerified {
+			return ErrEmailNotVerified
+		}
+
+		role, err := GetUserSiteRole(ctx, tx, user.ID, invitation.SiteID)
+		if err != nil {
+			return err
+		}
+		if role != "" {
+			return ErrAlreadySiteMember
+		}
+
+		if err := AddUserToSite(ctx, tx, user.ID, invitation.SiteID, invitation.Role); err != nil {
+			return err
+		}
+
+		query = `
+			UPDATE site_invitations
+			SET accepted_at = NOW(), accepted_by = $2
+			WHERE id = $1
+			RETURNING ` + invitationColumns
+
+		accepted, err = scanSiteInvitation(tx.QueryRowContext(ctx, query, invitation.ID, user.ID))
+		if err != nil {
+			return fmt.Errorf("failed to accept site invitation: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return accepted, nil
+}
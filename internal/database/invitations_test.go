@@ -0,0 +1,228 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/june20516/orbithall/internal/models"
+	"github.com/june20516/orbithall/internal/testhelpers"
+)
+
+// TestCreateSiteInvitation은 초대 생성과 재초대를 테스트합니다
+func TestCreateSiteInvitation(t *testing.T) {
+	db := testhelpers.SetupTestDB(t)
+	defer Close(db)
+
+	t.Run("초대 생성 후 토큰 발급, 재초대 시 이전 초대 취소", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		// Given
+		site, users := createSiteMembers(ctx, t, tx, "invite", models.SiteRoleOwner)
+		first := &models.SiteInvitation{SiteID: site.ID, Email: "new@example.com", Role: models.SiteRoleViewer, InvitedBy: &users[0].ID, ExpiresAt: time.Now().Add(time.Hour)}
+		if err := CreateSiteInvitation(ctx, tx, first); err != nil {
+			t.Fatalf("Failed to create invitation: %v", err)
+		}
+		if first.ID == 0 || first.Token == "" || first.Status != models.InvitationStatusPending {
+			t.Fatalf("Unexpected invitation: %+v", first)
+		}
+
+		// When: 같은 이메일(대소문자 다름)로 다시 초대
+		second := &models.SiteInvitation{SiteID: site.ID, Email: "NEW@example.com", Role: models.SiteRoleManager, ExpiresAt: time.Now().Add(time.Hour)}
+		if err := CreateSiteInvitation(ctx, tx, second); err != nil {
+			t.Fatalf("Failed to re-invite: %v", err)
+		}
+
+		// Then: 이전 초대는 취소되고 토큰은 목록에 포함되지 않음
+		invitations, err := ListSiteInvitations(ctx, tx, site.ID)
+		if err != nil {
+			t.Fatalf("Failed to list invitations: %v", err)
+		}
+		if len(invitations) != 2 || invitations[0].ID != second.ID || invitations[1].Status != models.InvitationStatusRevoked {
+			t.Errorf("Unexpected invitations: %+v", invitations)
+		}
+		for _, invitation := range invitations {
+			if invitation.Token != "" {
+				t.Error("Expected token to be omitted from list")
+			}
+		}
+	})
+
+	t.Run("이미 멤버인 이메일은 ErrAlreadySiteMember", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		site, users := createSiteMembers(ctx, t, tx, "invitemember", models.SiteRoleOwner)
+
+		invitation := &models.SiteInvitation{SiteID: site.ID, Email: users[0].Email, Role: models.SiteRoleViewer, ExpiresAt: time.Now().Add(time.Hour)}
+		err := CreateSiteInvitation(ctx, tx, invitation)
+
+		if !errors.Is(err, ErrAlreadySiteMember) {
+			t.Errorf("Expected ErrAlreadySiteMember, got %v", err)
+		}
+	})
+}
+
+// TestAcceptSiteInvitation은 초대 수락을 테스트합니다
+func TestAcceptSiteInvitation(t *testing.T) {
+	db := testhelpers.SetupTestDB(t)
+	defer Close(db)
+
+	t.Run("초대 후 가입한 사용자가 수락", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		// Given: 가입하지 않은 이메일로 초대
+		site, _ := createSiteMembers(ctx, t, tx, "accept", models.SiteRoleOwner)
+		invitation := &models.SiteInvitation{SiteID: site.ID, Email: "Invitee@example.com", Role: models.SiteRoleModerator, ExpiresAt: time.Now().Add(time.Hour)}
+		if err := CreateSiteInvitation(ctx, tx, invitation); err != nil {
+			t.Fatalf("Failed to create invitation: %v", err)
+		}
+
+		// When: 초대 후 가입한 사용자가 수락
+		user := &models.User{Email: "invitee@example.com", EmailVerified: true, Name: "Invitee"}
+		if err := CreateUser(ctx, tx, user); err != nil {
+			t.Fatalf("Failed to create user: %v", err)
+		}
+		accepted, err := AcceptSiteInvitation(ctx, tx, invitation.Token, user)
+
+		// Then: 초대된 역할로 연결되고 초대는 수락됨
+		if err != nil {
+			t.Fatalf("Failed to accept invitation: %v", err)
+		}
+		if accepted.Status != models.InvitationStatusAccepted || accepted.AcceptedBy == nil || *accepted.AcceptedBy != user.ID {
+			t.Errorf("Unexpected accepted invitation: %+v", accepted)
+		}
+		role, err := GetUserSiteRole(ctx, tx, user.ID, site.ID)
+		if err != nil {
+			t.Fatalf("Failed to get role: %v", err)
+		}
+		if role != models.SiteRoleModerator {
+			t.Errorf("Expected role moderator, got %q", role)
+		}
+
+		// 같은 토큰으로 다시 수락 불가
+		if _, err := AcceptSiteInvitation(ctx, tx, invitation.Token, user); !errors.Is(err, ErrInvitationInactive) {
+			t.Errorf("Expected ErrInvitationInactive on reuse, got %v", err)
+		}
+	})
+
+	t.Run("다른 이메일 계정은 수락 불가", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		site, users := createSiteMembers(ctx, t, tx, "mismatch", models.SiteRoleOwner, models.SiteRoleViewer)
+		invitation := &models.SiteInvitation{SiteID: site.ID, Email: "someone@example.com", Role: models.SiteRoleManager, ExpiresAt: time.Now().Add(time.Hour)}
+		if err := CreateSiteInvitation(ctx, tx, invitation); err != nil {
+			t.Fatalf("Failed to create invitation: %v", err)
+		}
+
+		_, err := AcceptSiteInvitation(ctx, tx, invitation.Token, users[1])
+
+		if !errors.Is(err, ErrInvitationEmailMismatch) {
+			t.Errorf("Expected ErrInvitationEmailMismatch, got %v", err)
+		}
+	})
+
+	t.Run("이메일이 검증되지 않은 계정은 수락 불가", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		// Given: 초대받은 이메일로 만들었지만 이메일이 검증되지 않은 사용자
+		site, _ := createSiteMembers(ctx, t, tx, "unverified", models.SiteRoleOwner)
+		user := &models.User{Email: "unverified@example.com", Name: "Unverified"}
+		if err := CreateUser(ctx, tx, user); err != nil {
+			t.Fatalf("Failed to create user: %v", err)
+		}
+		invitation := &models.SiteInvitation{SiteID: site.ID, Email: user.Email, Role: models.SiteRoleManager, ExpiresAt: time.Now().Add(time.Hour)}
+		if err := CreateSiteInvitation(ctx, tx, invitation); err != nil {
+			t.Fatalf("Failed to create invitation: %v", err)
+		}
+
+		// When
+		_, err := AcceptSiteInvitation(ctx, tx, invitation.Token, user)
+
+		// Then: ErrEmailNotVerified, 사이트에 연결되지 않음
+		if !errors.Is(err, ErrEmailNotVerified) {
+			t.Errorf("Expected ErrEmailNotVerified, got %v", err)
+		}
+		if role, _ := GetUserSiteRole(ctx, tx, user.ID, site.ID); role != "" {
+			t.Errorf("Expected no site role, got %q", role)
+		}
+	})
+
+	t.Run("만료/취소된 초대와 없는 토큰", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		site, _ := createSiteMembers(ctx, t, tx, "inactive", models.SiteRoleOwner)
+		user := &models.User{Email: "late@example.com", EmailVerified: true, Name: "Late"}
+		if err := CreateUser(ctx, tx, user); err != nil {
+			t.Fatalf("Failed to create user: %v", err)
+		}
+
+		// 만료된 초대
+		expired := &models.SiteInvitation{SiteID: site.ID, Email: user.Email, Role: models.SiteRoleViewer, ExpiresAt: time.Now().Add(-time.Minute)}
+		if err := CreateSiteInvitation(ctx, tx, expired); err != nil {
+			t.Fatalf("Failed to create invitation: %v", err)
+		}
+		if _, err := AcceptSiteInvitation(ctx, tx, expired.Token, user); !errors.Is(err, ErrInvitationInactive) {
+			t.Errorf("Expected ErrInvitationInactive for expired invitation, got %v", err)
+		}
+
+		// 취소된 초대
+		revoked := &models.SiteInvitation{SiteID: site.ID, Email: user.Email, Role: models.SiteRoleViewer, ExpiresAt: time.Now().Add(time.Hour)}
+		if err := CreateSiteInvitation(ctx, tx, revoked); err != nil {
+			t.Fatalf("Failed to create invitation: %v", err)
+		}
+		if _, err := RevokeSiteInvitation(ctx, tx, site.ID, revoked.ID); err != nil {
+			t.Fatalf("Failed to revoke invitation: %v", err)
+		}
+		if _, err := AcceptSiteInvitation(ctx, tx, revoked.Token, user); !errors.Is(err, ErrInvitationInactive) {
+			t.Errorf("Expected ErrInvitationInactive for revoked invitation, got %v", err)
+		}
+
+		// 없는 토큰
+		if _, err := AcceptSiteInvitation(ctx, tx, "orb_inv_unknown", user); err != sql.ErrNoRows {
+			t.Errorf("Expected sql.ErrNoRows for unknown token, got %v", err)
+		}
+	})
+}
+
+// TestRevokeSiteInvitation은 초대 취소를 테스트합니다
+func TestRevokeSiteInvitation(t *testing.T) {
+	db := testhelpers.SetupTestDB(t)
+	defer Close(db)
+
+	ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+	defer cleanup()
+
+	// Given
+	site, _ := createSiteMembers(ctx, t, tx, "revoke", models.SiteRoleOwner)
+	other, _ := createSiteMembers(ctx, t, tx, "revokeother", models.SiteRoleOwner)
+	invitation := &models.SiteInvitation{SiteID: site.ID, Email: "revoke@example.com", Role: models.SiteRoleViewer, ExpiresAt: time.Now().Add(time.Hour)}
+	if err := CreateSiteInvitation(ctx, tx, invitation); err != nil {
+		t.Fatalf("Failed to create invitation: %v", err)
+	}
+
+	// 다른 사이트로는 취소 불가
+	if _, err := RevokeSiteInvitation(ctx, tx, other.ID, invitation.ID); err != sql.ErrNoRows {
+		t.Errorf("Expected sql.ErrNoRows for other site, got %v", err)
+	}
+
+	// 취소 성공
+	revoked, err := RevokeSiteInvitation(ctx, tx, site.ID, invitation.ID)
+	if err != nil {
+		t.Fatalf("Failed to revoke invitation: %v", err)
+	}
+	if revoked.Status != models.InvitationStatusRevoked {
+		t.Errorf("Expected revoked status, got %s", revoked.Status)
+	}
+
+	// 다시 취소하면 ErrInvitationInactive
+	if _, err := RevokeSiteInvitation(ctx, tx, site.ID, invitation.ID); !errors.Is(err, ErrInvitationInactive) {
+		t.Errorf("Expected ErrInvitationInactive, got %v", err)
+	}
+}
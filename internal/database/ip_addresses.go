@@ -0,0 +1,162 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/june20516/orbithall/internal/ipcrypt"
+	"github.com/june20516/orbithall/internal/models"
+)
+
+// ipKeyring은 댓글 작성자 IP 주소 암호화에 사용하는 키링입니다
+// 서버 시작 시 SetIPKeyring으로 설정하며, 설정되지 않으면 개발용 키링을 사용합니다
+var ipKeyring atomic.Pointer[ipcrypt.Keyring]
+
+// SetIPKeyring은 IP 주소 암호화에 사용할 키링을 설정합니다
+func SetIPKeyring(keyring *ipcrypt.Keyring) {
+	ipKeyring.Store(keyring)
+}
+
+// currentIPKeyring은 설정된 키링을 반환합니다 (없으면 개발용 키링)
+func currentIPKeyring() *ipcrypt.Keyring {
+	if keyring := ipKeyring.Load(); keyring != nil {
+		return keyring
+	}
+	ipKeyring.CompareAndSwap(nil, ipcrypt.Development())
+	return ipKeyring.Load()
+}
+
+// protectedIP는 comments 테이블에 저장하는 IP 주소 관련 컬럼 값입니다
+// IP 주소가 없으면 모든 값이 nil(NULL)입니다
+type protectedIP struct {
+	encrypted any // ip_address_encrypted
+	hash      any // ip_address_hash
+	masked    any // ip_address_masked
+}
+
+// protectIPAddress는 IP 주소를 암호화하고 조회용 해시와 표시용 마스킹 값을 만듭니다
+func protectIPAddress(ip string) (protectedIP, error) {
+	if ip == "" {
+		return protectedIP{}, nil
+	}
+
+	keyring := currentIPKeyring()
+	encrypted, err := keyring.Encrypt(ip)
+	if err != nil {
+		return protectedIP{}, fmt.Errorf("failed to encrypt ip address: %w", err)
+	}
+
+	return protectedIP{
+		encrypted: encrypted,
+		hash:      keyring.Hash(ip),
+		masked:    models.MaskIPAddress(ip),
+	}, nil
+}
+
+// HashIPAddress는 IP 주소의 조회용 해시를 반환합니다
+// comments.ip_address_hash와 비교하여 같은 IP의 댓글을 찾을 때 사용합니다
+func HashIPAddress(ip string) string {
+	return currentIPKeyring().Hash(ip)
+}
+
+// DecryptIPAddress는 암호화된 IP 주소를 복호화합니다 (Admin 조회 전용)
+// 빈 문자열이면 빈 문자열을 반환합니다
+func DecryptIPAddress(encrypted string) (string, error) {
+	if encrypted == "" {
+		return "", nil
+	}
+
+	ip, err := currentIPKeyring().Decrypt(encrypted)
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt ip address: %w", err)
+	}
+
+	return ip, nil
+}
+
+// MigrateCommentIPAddresses는 평문으로 저장된 IP 주소와 활성 키가 아닌 키로 암호화된 IP 주소를
+// 활성 키로 (다시) 암호화합니다
+// 기존 데이터 마이그레이션과 키 교체에 사용하며, batchSize개씩 나누어 각각의 트랜잭션에서 처리합니다
+// 처리한 댓글 수를 반환합니다
+func MigrateCommentIPAddresses(ctx context.Context, db DBTX, batchSize int) (int64, error) {
+	keyring := currentIPKeyring()
+
+	var total int64
+	for {
+		var migrated int
+		err := RunInTx(ctx, db, func(tx DBTX) error {
+			// 1. 대상 댓글 조회 및 잠금 (동시에 실행 중인 다른 서버와 겹치지 않도록 SKIP LOCKED)
+			rows, err := tx.QueryContext(ctx, `
+				SELECT id, host(ip_address), ip_address_encrypted
+				FROM comments
+				WHERE ip_address IS NOT NULL
+				   OR (ip_address_encrypted IS NOT NULL AND split_part(ip_address_encrypted, ':', 1) <> $1)
+				ORDER BY id
+				LIMIT $2
+				FOR UPDATE SKIP LOCKED
+			`, keyring.ActiveKeyID(), batchSize)
+			if err != nil {
+				return fmt.Errorf("failed to query comment ip addresses: %w", err)
+			}
+			defer rows.Close()
+
+			type target struct {
+				id int64
+				ip string
+			}
+			var targets []target
+			for rows.Next() {
+				var id int64
+				var plain, encrypted sql.NullString
+				if err := rows.Scan(&id, &plain, &encrypted); err != nil {
+					return fmt.Errorf("failed to scan comment ip address: %w", err)
+				}
+
+				// 2. 평문이 있으면 그대로, 없으면 이전 키로 복호화
+				ip := plain.String
+				if !plain.Valid {
+					ip, err = keyring.Decrypt(encrypted.String)
+					if err != nil {
+						return fmt.Errorf("failed to decrypt ip address of comment %d (key %q): %w", id, ipcrypt.KeyID(encrypted.String), err)
+					}
+				}
+				targets = append(targets, target{id: id, ip: ip})
+			}
+			if err := rows.Err(); err != nil {
+				return fmt.Errorf("error iterating comment ip addresses: %w", err)
+			}
+
+			// 3. 활성 키로 암호화하여 저장하고 평문 삭제
+			for _, t := range targets {
+				protected, err := protectIPAddress(t.ip)
+				if err != nil {
+					return err
+				}
+				_, err = tx.ExecContext(ctx, `
+					UPDATE comments
+					SET ip_address = NULL,
+						ip_address_encrypted = $2,
+						ip_address_hash = $3,
+						ip_address_masked = $4
+					WHERE id = $1
+				`, t.id, protected.encrypted, protected.hash, protected.masked)
+				if err != nil {
+					return fmt.Errorf("failed to update comment ip address: %w", err)
+				}
+			}
+
+			migrated = len(targets)
+			return nil
+		})
+		if err != nil {
+			return total, err
+		}
+
+		total += int64(migrated)
+		if migrated < batchSize {
+			return total, nil
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package database
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/june20516/orbithall/internal/ipcrypt"
+	"github.com/june20516/orbithall/internal/testhelpers"
+)
+
+// TestMigrateCommentIPAddresses는 평문 IP 암호화와 키 교체 후 재암호화를 테스트합니다
+func TestMigrateCommentIPAddresses(t *testing.T) {
+	db := testhelpers.SetupTestDB(t)
+	defer Close(db)
+
+	ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+	defer cleanup()
+
+	oldKey := bytes.Repeat([]byte{1}, 32)
+	newKey := bytes.Repeat([]byte{2}, 32)
+	hashKey := bytes.Repeat([]byte{9}, 32)
+	oldKeyring, _ := ipcrypt.NewKeyring("old", map[string][]byte{"old": oldKey}, hashKey)
+	newKeyring, _ := ipcrypt.NewKeyring("new", map[string][]byte{"old": oldKey, "new": newKey}, hashKey)
+	SetIPKeyring(oldKeyring)
+	defer SetIPKeyring(nil)
+
+	site := testhelpers.CreateTestSite(ctx, t, tx, "IP Site", "ip.com", []string{"http://localhost:3000"}, true)
+	post := testhelpers.CreateTestPost(ctx, t, tx, site.ID, "post", "Post")
+
+	t.Run("평문 IP 암호화", func(t *testing.T) {
+		// Given: 암호화 도입 전 평문으로 저장된 댓글
+		var commentID int64
+		err := tx.QueryRowContext(ctx, `
+			INSERT INTO comments (post_id, author_name, author_password, content, ip_address, user_agent)
+			VALUES ($1, 'Legacy', '', 'Legacy comment', '192.168.1.100', 'Agent')
+			RETURNING id
+		`, post.ID).Scan(&commentID)
+		if err != nil {
+			t.Fatalf("failed to insert legacy comment: %v", err)
+		}
+
+		// When: 마이그레이션 실행
+		migrated, err := MigrateCommentIPAddresses(ctx, tx, 100)
+
+		// Then: 평문 삭제, 암호화/해시/마스킹 값 저장
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if migrated < 1 {
+			t.Errorf("expected at least 1 migrated comment, got %d", migrated)
+		}
+		var plainCount int
+		tx.QueryRowContext(ctx, `SELECT COUNT(*) FROM comments WHERE id = $1 AND ip_address IS NOT NULL`, commentID).Scan(&plainCount)
+		if plainCount != 0 {
+			t.Error("expected plaintext ip_address to be removed")
+		}
+		comment, _ := GetCommentByID(ctx, tx, commentID)
+		if ip, _ := DecryptIPAddress(comment.IPAddressEncrypted); ip != "192.168.1.100" {
+			t.Errorf("expected encrypted 192.168.1.100, got %s", ip)
+		}
+		if comment.IPAddressMasked != "192.168.***.***" {
+			t.Errorf("expected masked ip, got %s", comment.IPAddressMasked)
+		}
+		var hash string
+		tx.QueryRowContext(ctx, `SELECT ip_address_hash FROM comments WHERE id = $1`, commentID).Scan(&hash)
+		if hash != HashIPAddress("192.168.1.100") {
+			t.Errorf("expected ip_address_hash to match, got %s", hash)
+		}
+	})
+
+	t.Run("키 교체 후 활성 키로 재암호화", func(t *testing.T) {
+		// Given: 이전 키로 암호화된 댓글
+		comment, err := CreateComment(ctx, tx, post.ID, nil, "홍길동", "test1234", "댓글", "10.0.0.1", "test")
+		if err != nil {
+			t.Fatalf("failed to create comment: %v", err)
+		}
+		if ipcrypt.KeyID(comment.IPAddressEncrypted) != "old" {
+			t.Fatalf("expected old key, got %s", comment.IPAddressEncrypted)
+		}
+
+		// When: 새 키 활성화 후 마이그레이션 실행
+		SetIPKeyring(newKeyring)
+		if _, err := MigrateCommentIPAddresses(ctx, tx, 100); err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+
+		// Then: 새 키로 암호화, 같은 IP
+		stored, _ := GetCommentByID(ctx, tx, comment.ID)
+		if ipcrypt.KeyID(stored.IPAddressEncrypted) != "new" {
+			t.Errorf("expected new key, got %s", stored.IPAddressEncrypted)
+		}
+		if ip, _ := DecryptIPAddress(stored.IPAddressEncrypted); ip != "10.0.0.1" {
+			t.Errorf("expected 10.0.0.1, got %s", ip)
+		}
+	})
+}
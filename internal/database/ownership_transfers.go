@@ -0,0 +1,297 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/june20516/orbithall/internal/models"
+)
+
+// transferSelect는 소유권 이전 요청 조회 쿼리입니다 (scanSiteOwnershipTransfer와 순서 일치)
+// 받는 사람이 사이트를 확인할 수 있도록 사이트 이름/도메인과 요청한 owner의 이메일을 함께 조회합니다
+const transferSelect = `
+	SELECT
+		t.id, t.site_id, s.name, s.domain, t.from_user_id, COALESCE(u.email, ''), t.to_email,
+		t.expires_at, t.accepted_at, t.accepted_by, t.declined_at, t.cancelled_at, t.created_at
+	FROM site_ownership_transfers t
+	INNER JOIN sites s ON s.id = t.site_id
+	LEFT JOIN users u ON u.id = t.from_user_id
+`
+
+// transferPending은 수락/거절/취소되지 않은 이전 요청 조건입니다 (만료 여부는 별도 확인)
+const transferPending = `t.accepted_at IS NULL AND t.declined_at IS NULL AND t.cancelled_at IS NULL`
+
+// scanSiteOwnershipTransfer는 transferSelect 순서로 조회한 행을 SiteOwnershipTransfer로 변환합니다
+// 상태(Status)는 현재 시각 기준으로 계산합니다
+func scanSiteOwnershipTransfer(row rowScanner) (*models.SiteOwnershipTransfer, error) {
+	var transfer models.SiteOwnershipTransfer
+	var fromUserID, acceptedBy sql.NullInt64
+	var acceptedAt, declinedAt, cancelledAt sql.NullTime
+	err := row.Scan(
+		&transfer.ID,
+		&transfer.SiteID,
+		&transfer.SiteName,
+		&transfer.SiteDomain,
+		&fromUserID,
+		&transfer.FromEmail,
+		&transfer.ToEmail,
+		&transfer.ExpiresAt,
+		&acceptedAt,
+		&acceptedBy,
+		&declinedAt,
+		&cancelledAt,
+		&transfer.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if fromUserID.Valid {
+		transfer.FromUserID = &fromUserID.Int64
+	}
+	if acceptedAt.Valid {
+		transfer.AcceptedAt = &acceptedAt.Time
+	}
+	if acceptedBy.Valid {
+		transfer.AcceptedBy = &acceptedBy.Int64
+	}
+	if declinedAt.Valid {
+		transfer.DeclinedAt = &declinedAt.Time
+	}
+	if cancelledAt.Valid {
+		transfer.CancelledAt = &cancelledAt.Time
+	}
+	transfer.Status = transfer.StatusAt(time.Now())
+
+	return &transfer, nil
+}
+
+// GetSiteOwnershipTransfer는 ID로 소유권 이전 요청을 조회합니다
+// 요청이 없으면 sql.ErrNoRows를 반환합니다
+func GetSiteOwnershipTransfer(ctx context.Context, db DBTX, transferID int64) (*models.SiteOwnershipTransfer, error) {
+	transfer, err := scanSiteOwnershipTransfer(db.QueryRowContext(ctx, transferSelect+`WHERE t.id = $1`, transferID))
+	if err == sql.ErrNoRows {
+		return nil, sql.ErrNoRows
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ownership transfer: %w", err)
+	}
+
+	return transfer, nil
+}
+
+// CreateSiteOwnershipTransfer는 사이트 소유권 이전을 요청합니다
+// transfer의 SiteID, FromUserID, ToEmail, ExpiresAt을 채워 전달하면 생성된 요청과 수락/거절에 사용할 토큰(Token)으로 채웁니다
+// 토큰은 해시만 저장하므로 이후에는 조회할 수 없습니다
+// 사이트에 대기 중인 요청이 있으면 취소하고 새로 요청하며,
+// 받는 사람이 이미 사이트 owner면 ErrAlreadySiteOwner를 반환합니다
+func CreateSiteOwnershipTransfer(ctx context.Context, db DBTX, transfer *models.SiteOwnershipTransfer) error {
+	return RunInTx(ctx, db, func(tx DBTX) error {
+		var isOwner bool
+		err := tx.QueryRowContext(ctx, `
+			SELECT EXISTS(
+				SELECT 1 FROM user_sites us
+				INNER JOIN users u ON u.id = us.user_id
+				WHERE us.site_id = $1 AND LOWER(u.email) = LOWER($2) AND us.role = 'owner'
+			)
+		`, transfer.SiteID, transfer.ToEmail).Scan(&isOwner)
+		if err != nil {
+			return fmt.Errorf("failed to check site owner: %w", err)
+		}
+		if isOwner {
+			return ErrAlreadySiteOwner
+		}
+
+		// 이전 요청 취소 (대기 중인 요청은 사이트당 하나)
+		_, err = tx.ExecContext(ctx, `
+			UPDATE site_ownership_transfers t
+			SET cancelled_at = NOW()
+			WHERE t.site_id = $1 AND `+transferPending, transfer.SiteID)
+		if err != nil {
+			return fmt.Errorf("failed to cancel previous ownership transfer: %w", err)
+		}
+
+		token := models.GenerateTransferToken()
+		var transferID int64
+		err = tx.QueryRowContext(ctx, `
+			INSERT INTO site_ownership_transfers (site_id, from_user_id, to_email, token_hash, expires_at)
+			VALUES ($1, $2, $3, $4, $5)
+			RETURNING id
+		`, transfer.SiteID, transfer.FromUserID, transfer.ToEmail, models.HashTransferToken(token), transfer.ExpiresAt).Scan(&transferID)
+		if err != nil {
+			return fmt.Errorf("failed to create ownership transfer: %w", err)
+		}
+
+		created, err := GetSiteOwnershipTransfer(ctx, tx, transferID)
+		if err != nil {
+			return err
+		}
+		*transfer = *created
+		transfer.Token = token
+		return nil
+	})
+}
+
+// CancelSiteOwnershipTransfer는 사이트의 대기 중인 소유권 이전 요청을 취소합니다
+// 대기 중인 요청이 없으면 sql.ErrNoRows를 반환합니다
+func CancelSiteOwnershipTransfer(ctx context.Context, db DBTX, siteID int64) (*models.SiteOwnershipTransfer, error) {
+	var transferID int64
+	err := db.QueryRowContext(ctx, `
+		UPDATE site_ownership_transfers t
+		SET cancelled_at = NOW()
+		WHERE t.site_id = $1 AND `+transferPending+`
+		RETURNING t.id
+	`, siteID).Scan(&transferID)
+	if err == sql.ErrNoRows {
+		return nil, sql.ErrNoRows
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to cancel ownership transfer: %w", err)
+	}
+
+	return GetSiteOwnershipTransfer(ctx, db, transferID)
+}
+
+// ListIncomingOwnershipTransfers는 이메일로 받은 대기 중인(만료되지 않은) 소유권 이전 요청을 최신순으로 조회합니다
+func ListIncomingOwnershipTransfers(ctx context.Context, db DBTX, email string) ([]models.SiteOwnershipTransfer, error) {
+	query := transferSelect + `
+		WHERE LOWER(t.to_email) = LOWER($1) AND ` + transferPending + ` AND t.expires_at > NOW()
+		ORDER BY t.created_at DESC, t.id DESC
+	`
+
+	rows, err := db.QueryContext(ctx, query, email)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query ownership transfers: %w", err)
+	}
+	defer rows.Close()
+
+	transfers := []models.SiteOwnershipTransfer{}
+	for rows.Next() {
+		transfer, err := scanSiteOwnershipTransfer(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan ownership transfer: %w", err)
+		}
+		transfers = append(transfers, *transfer)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating ownership transfer rows: %w", err)
+	}
+
+	return transfers, nil
+}
+
+// AcceptSiteOwnershipTransfer는 받는 사람(user)으로 이전 토큰(token)을 확인하고 소유권 이전 요청을 수락합니다
+// 받는 사람은 owner가 되고, 요청한 owner는 받는 사람의 이전 역할(멤버가 아니었으면 manager)이 됩니다
+// 요청이 없거나 토큰이 다르거나 user에게 온 요청이 아니면 sql.ErrNoRows, 대기 중이 아니거나 요청한 사용자가 더 이상 owner가 아니면 ErrTransferInactive,
+// 받는 사람의 이메일이 검증되지 않았으면 ErrEmailNotVerified, 받는 사람이 이미 owner면 ErrAlreadySiteOwner를 반환합니다
+func AcceptSiteOwnershipTransfer(ctx context.Context, db DBTX, transferID int64, token string, user *models.User) (*models.SiteOwnershipTransfer, error) {
+	var accepted *models.SiteOwnershipTransfer
+	err := RunInTx(ctx, db, func(tx DBTX) error {
+		// 동시에 두 번 수락하지 않도록 요청 행을 잠금
+		transfer, err := scanSiteOwnershipTransfer(tx.QueryRowContext(ctx, transferSelect+`WHERE t.id = $1 AND t.token_hash = $2 FOR UPDATE OF t`, transferID, models.HashTransferToken(token)))
+		if err == sql.ErrNoRows {
+			return sql.ErrNoRows
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get ownership transfer: %w", err)
+		}
+
+		if !strings.EqualFold(strings.TrimSpace(transfer.ToEmail), strings.TrimSpace(user.Email)) {
+			return sql.ErrNoRows
+		}
+		if transfer.Status != models.TransferStatusPending || transfer.FromUserID == nil {
+			return ErrTransferInactive
+		}
+		if !user.EmailVerified {
+			return ErrEmailNotVerified
+		}
+
+		fromRole, err := GetUserSiteRole(ctx, tx, *transfer.FromUserID, transfer.SiteID)
+		if err != nil {
+			return err
+		}
+		if fromRole != models.SiteRoleOwner {
+			return ErrTransferInactive
+		}
+
+		toRole, err := GetUserSiteRole(ctx, tx, user.ID, transfer.SiteID)
+		if err != nil {
+			return err
+		}
+		if toRole == models.SiteRoleOwner {
+			return ErrAlreadySiteOwner
+		}
+
+		// 받는 사람을 먼저 owner로 만든 뒤 요청한 owner의 역할을 변경 (owner가 없는 순간이 없도록)
+		if toRole == "" {
+			err = AddUserToSite(ctx, tx, user.ID, transfer.SiteID, models.SiteRoleOwner)
+		} else {
+			err = UpdateSiteMemberRole(ctx, tx, transfer.SiteID, user.ID, models.SiteRoleOwner)
+		}
+		if err != nil {
+			return err
+		}
+
+		previousOwnerRole := toRole
+		if previousOwnerRole == "" {
+			previousOwnerRole = models.SiteRoleManager
+		}
+		if err := UpdateSiteMemberRole(ctx, tx, transfer.SiteID, *transfer.FromUserID, previousOwnerRole); err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, `
+			UPDATE site_ownership_transfers
+			SET accepted_at = NOW(), accepted_by = $2
+			WHERE id = $1
+		`, transfer.ID, user.ID)
+		if err != nil {
+			return fmt.Errorf("failed to accept ownership transfer: %w", err)
+		}
+
+		accepted, err = GetSiteOwnershipTransfer(ctx, tx, transfer.ID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return accepted, nil
+}
+
+// DeclineSiteOwnershipTransfer는 받는 사람(user)으로 이전 토큰(token)을 확인하고 소유권 이전 요청을 거절합니다
+// 요청이 없거나 토큰이 다르거나 user에게 온 요청이 아니면 sql.ErrNoRows, 대기 중이 아니면 ErrTransferInactive를 반환합니다
+func DeclineSiteOwnershipTransfer(ctx context.Context, db DBTX, transferID int64, token string, user *models.User) (*models.SiteOwnershipTransfer, error) {
+	transfer, err := scanSiteOwnershipTransfer(db.QueryRowContext(ctx, transferSelect+`WHERE t.id = $1 AND t.token_hash = $2`, transferID, models.HashTransferToken(token)))
+	if err == sql.ErrNoRows {
+		return nil, sql.ErrNoRows
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ownership transfer: %w", err)
+	}
+	if !strings.EqualFold(strings.TrimSpace(transfer.ToEmail), strings.TrimSpace(user.Email)) {
+		return nil, sql.ErrNoRows
+	}
+
+	result, err := db.ExecContext(ctx, `
+		UPDATE site_ownership_transfers t
+		SET declined_at = NOW()
+		WHERE t.id = $1 AND `+transferPending+` AND t.expires_at > NOW()
+	`, transferID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decline ownership transfer: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return nil, ErrTransferInactive
+	}
+
+	return GetSiteOwnershipTransfer(ctx, db, transferID)
+}
@@ -0,0 +1,173 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/june20516/orbithall/internal/models"
+	"github.com/june20516/orbithall/internal/testhelpers"
+)
+
+// TestAcceptSiteOwnershipTransfer는 소유권 이전 요청과 수락을 테스트합니다
+func TestAcceptSiteOwnershipTransfer(t *testing.T) {
+	db := testhelpers.SetupTestDB(t)
+	defer Close(db)
+
+	t.Run("멤버가 아닌 사용자에게 이전", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		// Given: owner가 가입한 다른 사용자에게 이전 요청
+		site, users := createSiteMembers(ctx, t, tx, "transfer", models.SiteRoleOwner)
+		buyer := &models.User{Email: "buyer@example.com", EmailVerified: true, Name: "Buyer"}
+		if err := CreateUser(ctx, tx, buyer); err != nil {
+			t.Fatalf("Failed to create user: %v", err)
+		}
+		transfer := &models.SiteOwnershipTransfer{SiteID: site.ID, FromUserID: &users[0].ID, ToEmail: "Buyer@example.com", ExpiresAt: time.Now().Add(time.Hour)}
+		if err := CreateSiteOwnershipTransfer(ctx, tx, transfer); err != nil {
+			t.Fatalf("Failed to create transfer: %v", err)
+		}
+		if transfer.Status != models.TransferStatusPending || transfer.FromEmail != users[0].Email || transfer.SiteDomain != site.Domain {
+			t.Fatalf("Unexpected transfer: %+v", transfer)
+		}
+
+		// 받는 사람의 대기 중인 요청 목록에 표시
+		incoming, err := ListIncomingOwnershipTransfers(ctx, tx, buyer.Email)
+		if err != nil {
+			t.Fatalf("Failed to list transfers: %v", err)
+		}
+		if len(incoming) != 1 || incoming[0].ID != transfer.ID {
+			t.Errorf("Unexpected incoming transfers: %+v", incoming)
+		}
+
+		// 토큰이 없거나 다르면 sql.ErrNoRows
+		if !strings.HasPrefix(transfer.Token, models.TransferTokenPrefix) {
+			t.Fatalf("Expected transfer token, got %q", transfer.Token)
+		}
+		if _, err := AcceptSiteOwnershipTransfer(ctx, tx, transfer.ID, "orb_trf_wrong", buyer); err != sql.ErrNoRows {
+			t.Errorf("Expected sql.ErrNoRows for wrong token, got %v", err)
+		}
+		if _, err := DeclineSiteOwnershipTransfer(ctx, tx, transfer.ID, "", buyer); err != sql.ErrNoRows {
+			t.Errorf("Expected sql.ErrNoRows for empty token, got %v", err)
+		}
+
+		// When
+		accepted, err := AcceptSiteOwnershipTransfer(ctx, tx, transfer.ID, transfer.Token, buyer)
+
+		// Then: 받는 사람은 owner, 이전 owner는 manager
+		if err != nil {
+			t.Fatalf("Failed to accept transfer: %v", err)
+		}
+		if accepted.Status != models.TransferStatusAccepted {
+			t.Errorf("Expected accepted status, got %s", accepted.Status)
+		}
+		if role, _ := GetUserSiteRole(ctx, tx, buyer.ID, site.ID); role != models.SiteRoleOwner {
+			t.Errorf("Expected buyer to be owner, got %q", role)
+		}
+		if role, _ := GetUserSiteRole(ctx, tx, users[0].ID, site.ID); role != models.SiteRoleManager {
+			t.Errorf("Expected previous owner to be manager, got %q", role)
+		}
+
+		// 다시 수락 불가
+		if _, err := AcceptSiteOwnershipTransfer(ctx, tx, transfer.ID, transfer.Token, buyer); !errors.Is(err, ErrTransferInactive) {
+			t.Errorf("Expected ErrTransferInactive on reuse, got %v", err)
+		}
+	})
+
+	t.Run("멤버에게 이전하면 역할 교환", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		// Given: moderator에게 이전 요청
+		site, users := createSiteMembers(ctx, t, tx, "swap", models.SiteRoleOwner, models.SiteRoleModerator)
+		transfer := &models.SiteOwnershipTransfer{SiteID: site.ID, FromUserID: &users[0].ID, ToEmail: users[1].Email, ExpiresAt: time.Now().Add(time.Hour)}
+		if err := CreateSiteOwnershipTransfer(ctx, tx, transfer); err != nil {
+			t.Fatalf("Failed to create transfer: %v", err)
+		}
+
+		// When
+		if _, err := AcceptSiteOwnershipTransfer(ctx, tx, transfer.ID, transfer.Token, users[1]); err != nil {
+			t.Fatalf("Failed to accept transfer: %v", err)
+		}
+
+		// Then: 역할 교환
+		if role, _ := GetUserSiteRole(ctx, tx, users[1].ID, site.ID); role != models.SiteRoleOwner {
+			t.Errorf("Expected recipient to be owner, got %q", role)
+		}
+		if role, _ := GetUserSiteRole(ctx, tx, users[0].ID, site.ID); role != models.SiteRoleModerator {
+			t.Errorf("Expected previous owner to be moderator, got %q", role)
+		}
+	})
+
+	t.Run("다른 사용자는 수락 불가, 이미 owner에게는 요청 불가", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		site, users := createSiteMembers(ctx, t, tx, "wrongrecipient", models.SiteRoleOwner, models.SiteRoleViewer)
+
+		// 요청한 owner 본인에게는 요청 불가
+		self := &models.SiteOwnershipTransfer{SiteID: site.ID, FromUserID: &users[0].ID, ToEmail: users[0].Email, ExpiresAt: time.Now().Add(time.Hour)}
+		if err := CreateSiteOwnershipTransfer(ctx, tx, self); !errors.Is(err, ErrAlreadySiteOwner) {
+			t.Errorf("Expected ErrAlreadySiteOwner, got %v", err)
+		}
+
+		// 받는 사람이 아닌 사용자의 수락은 sql.ErrNoRows
+		transfer := &models.SiteOwnershipTransfer{SiteID: site.ID, FromUserID: &users[0].ID, ToEmail: "other@example.com", ExpiresAt: time.Now().Add(time.Hour)}
+		if err := CreateSiteOwnershipTransfer(ctx, tx, transfer); err != nil {
+			t.Fatalf("Failed to create transfer: %v", err)
+		}
+		if _, err := AcceptSiteOwnershipTransfer(ctx, tx, transfer.ID, transfer.Token, users[1]); err != sql.ErrNoRows {
+			t.Errorf("Expected sql.ErrNoRows, got %v", err)
+		}
+
+		// 받는 사람이어도 이메일이 검증되지 않았으면 ErrEmailNotVerified
+		unverified := &models.User{Email: "other@example.com", Name: "Other"}
+		if err := CreateUser(ctx, tx, unverified); err != nil {
+			t.Fatalf("Failed to create user: %v", err)
+		}
+		if _, err := AcceptSiteOwnershipTransfer(ctx, tx, transfer.ID, transfer.Token, unverified); !errors.Is(err, ErrEmailNotVerified) {
+			t.Errorf("Expected ErrEmailNotVerified, got %v", err)
+		}
+	})
+
+	t.Run("새 요청은 이전 요청을 취소, 취소/거절 후 수락 불가", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		site, users := createSiteMembers(ctx, t, tx, "replace", models.SiteRoleOwner)
+		recipient := &models.User{Email: "recipient@example.com", EmailVerified: true, Name: "Recipient"}
+		if err := CreateUser(ctx, tx, recipient); err != nil {
+			t.Fatalf("Failed to create user: %v", err)
+		}
+
+		first := &models.SiteOwnershipTransfer{SiteID: site.ID, FromUserID: &users[0].ID, ToEmail: recipient.Email, ExpiresAt: time.Now().Add(time.Hour)}
+		if err := CreateSiteOwnershipTransfer(ctx, tx, first); err != nil {
+			t.Fatalf("Failed to create transfer: %v", err)
+		}
+		second := &models.SiteOwnershipTransfer{SiteID: site.ID, FromUserID: &users[0].ID, ToEmail: recipient.Email, ExpiresAt: time.Now().Add(time.Hour)}
+		if err := CreateSiteOwnershipTransfer(ctx, tx, second); err != nil {
+			t.Fatalf("Failed to create transfer: %v", err)
+		}
+
+		// 대체된 요청은 수락 불가
+		if _, err := AcceptSiteOwnershipTransfer(ctx, tx, first.ID, first.Token, recipient); !errors.Is(err, ErrTransferInactive) {
+			t.Errorf("Expected ErrTransferInactive for replaced transfer, got %v", err)
+		}
+
+		// 거절 후 수락 불가
+		if _, err := DeclineSiteOwnershipTransfer(ctx, tx, second.ID, second.Token, recipient); err != nil {
+			t.Fatalf("Failed to decline transfer: %v", err)
+		}
+		if _, err := AcceptSiteOwnershipTransfer(ctx, tx, second.ID, second.Token, recipient); !errors.Is(err, ErrTransferInactive) {
+			t.Errorf("Expected ErrTransferInactive for declined transfer, got %v", err)
+		}
+
+		// 대기 중인 요청이 없으면 취소 불가
+		if _, err := CancelSiteOwnershipTransfer(ctx, tx, site.ID); err != sql.ErrNoRows {
+			t.Errorf("Expected sql.ErrNoRows, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,223 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// ListPostSlugAliases는 포스트의 slug 별칭 목록을 조회합니다
+// 추가된 순서대로 정렬됩니다
+func ListPostSlugAliases(ctx context.Context, db DBTX, postID int64) ([]string, error) {
+	query := `
+		SELECT slug
+		FROM post_slug_aliases
+		WHERE post_id = $1
+		ORDER BY created_at, id
+	`
+
+	rows, err := db.QueryContext(ctx, query, postID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list post slug aliases: %w", err)
+	}
+	defer rows.Close()
+
+	aliases := []string{}
+	for rows.Next() {
+		var slug string
+		if err := rows.Scan(&slug); err != nil {
+			return nil, fmt.Errorf("failed to scan post slug alias: %w", err)
+		}
+		aliases = append(aliases, slug)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating post slug aliases: %w", err)
+	}
+
+	return aliases, nil
+}
+
+// AddPostSlugAlias는 포스트에 slug 별칭을 추가합니다
+// 이후 해당 slug로 조회하거나 댓글을 작성하면 이 포스트가 사용됩니다
+// 별칭은 포스트와 같은 영역(실제/샌드박스)에 속하며, 같은 영역에서만 중복을 검사합니다
+// 사이트의 다른 포스트 slug나 별칭이 이미 사용 중이면 ErrSlugInUse를 반환합니다
+// (이미 댓글이 있는 포스트의 slug를 합치려면 MergePosts를 사용)
+func AddPostSlugAlias(ctx context.Context, db DBTX, siteID, postID int64, slug string) error {
+	query := `
+		INSERT INTO post_slug_aliases (site_id, post_id, slug, is_sandbox)
+		SELECT $1, p.id, $3, p.is_sandbox
+		FROM posts p
+		WHERE p.id = $2
+		  AND NOT EXISTS (
+			SELECT 1 FROM posts o WHERE o.site_id = $1 AND o.is_sandbox = p.is_sandbox AND o.slug = $3
+		  )
+		ON CONFLICT (site_id, is_sandbox, slug) DO NOTHING
+	`
+
+	result, err := db.ExecContext(ctx, query, siteID, postID, slug)
+	if err != nil {
+		return fmt.Errorf("failed to add post slug alias: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrSlugInUse
+	}
+
+	return nil
+}
+
+// DeletePostSlugAlias는 포스트의 slug 별칭을 삭제합니다
+// 별칭이 존재하지 않으면 sql.ErrNoRows를 반환합니다
+func DeletePostSlugAlias(ctx context.Context, db DBTX, postID int64, slug string) error {
+	query := `
+		DELETE FROM post_slug_aliases
+		WHERE post_id = $1 AND slug = $2
+	`
+
+	result, err := db.ExecContext(ctx, query, postID, slug)
+	if err != nil {
+		return fmt.Errorf("failed to delete post slug alias: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// MergePosts는 source 포스트의 모든 댓글을 target 포스트로 옮기고 source 포스트를 삭제합니다
+// source의 slug와 별칭은 target의 별칭이 되어, 이전 slug로도 target의 댓글이 조회됩니다
+// target의 제목/URL이 비어있으면 source의 값으로 채우고, 댓글 수는 실제 댓글로 다시 계산합니다
+// 모든 작업은 하나의 트랜잭션에서 실행되며, 옮긴 댓글 수를 반환합니다
+// 두 포스트 중 하나라도 없거나 서로 다른 사이트 또는 영역(실제/샌드박스)에 속하면 sql.ErrNoRows를 반환합니다
+func MergePosts(ctx context.Context, db DBTX, sourceID, targetID int64) (int64, error) {
+	if sourceID == targetID {
+		return 0, fmt.Errorf("cannot merge post into itself")
+	}
+
+	var moved int64
+	err := RunInTx(ctx, db, func(tx DBTX) error {
+		// 1. 두 포스트를 잠그고 조회 (동시에 댓글이 작성되거나 병합되는 것을 방지)
+		var source, target struct {
+			siteID  int64
+			slug    string
+			title   string
+			url     string
+			sandbox bool
+		}
+		lockQuery := `
+			SELECT site_id, slug, title, url, is_sandbox
+			FROM posts
+			WHERE id = $1
+			FOR UPDATE
+		`
+		if err := tx.QueryRowContext(ctx, lockQuery, sourceID).Scan(&source.siteID, &source.slug, &source.title, &source.url, &source.sandbox); err != nil {
+			if err == sql.ErrNoRows {
+				return sql.ErrNoRows
+			}
+			return fmt.Errorf("failed to lock source post: %w", err)
+		}
+		if err := tx.QueryRowContext(ctx, lockQuery, targetID).Scan(&target.siteID, &target.slug, &target.title, &target.url, &target.sandbox); err != nil {
+			if err == sql.ErrNoRows {
+				return sql.ErrNoRows
+			}
+			return fmt.Errorf("failed to lock target post: %w", err)
+		}
+		if source.siteID != target.siteID || source.sandbox != target.sandbox {
+			return sql.ErrNoRows
+		}
+
+		// 2. 양쪽에 같은 원본 ID로 가져온 댓글이 있으면 source 댓글의 출처 기록을 제거
+		// (포스트 내 (출처, 원본 ID) unique 제약 충돌 방지, 댓글 자체는 유지)
+		_, err := tx.ExecContext(ctx, `
+			UPDATE comments s
+			SET import_source = NULL,
+			    import_source_id = NULL
+			WHERE s.post_id = $1
+			  AND s.import_source IS NOT NULL
+			  AND EXISTS (
+				SELECT 1 FROM comments t
+				WHERE t.post_id = $2
+				  AND t.import_source = s.import_source
+				  AND t.import_source_id = s.import_source_id
+			  )
+		`, sourceID, targetID)
+		if err != nil {
+			return fmt.Errorf("failed to clear conflicting import sources: %w", err)
+		}
+
+		// 3. 댓글 이동 (대댓글도 함께 이동하므로 부모-자식 관계 유지)
+		result, err := tx.ExecContext(ctx, `
+			UPDATE comments
+			SET post_id = $2
+			WHERE post_id = $1
+		`, sourceID, targetID)
+		if err != nil {
+			return fmt.Errorf("failed to move comments: %w", err)
+		}
+		moved, err = result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to get rows affected: %w", err)
+		}
+
+		// 4. source의 별칭을 target으로 이동
+		_, err = tx.ExecContext(ctx, `
+			UPDATE post_slug_aliases
+			SET post_id = $2
+			WHERE post_id = $1
+		`, sourceID, targetID)
+		if err != nil {
+			return fmt.Errorf("failed to move post slug aliases: %w", err)
+		}
+
+		// 5. source 포스트 삭제 후 source slug를 target의 별칭으로 등록
+		if _, err := tx.ExecContext(ctx, `DELETE FROM posts WHERE id = $1`, sourceID); err != nil {
+			return fmt.Errorf("failed to delete source post: %w", err)
+		}
+		if err := AddPostSlugAlias(ctx, tx, target.siteID, targetID, source.slug); err != nil {
+			return err
+		}
+
+		// 6. target의 비어있는 메타데이터를 source 값으로 채움
+		// (source 제목이 slug 그대로면 의미 있는 제목이 아니므로 사용하지 않음)
+		title := source.title
+		if title == source.slug {
+			title = ""
+		}
+		if _, err := FillPostMetadata(ctx, tx, targetID, title, source.url); err != nil {
+			return err
+		}
+
+		// 7. 댓글 수 재계산 (삭제되지 않은 댓글 기준)
+		_, err = tx.ExecContext(ctx, `
+			UPDATE posts
+			SET comment_count = (
+				SELECT COUNT(*) FROM comments WHERE post_id = $1 AND is_deleted = false
+			),
+			    updated_at = NOW()
+			WHERE id = $1
+		`, targetID)
+		if err != nil {
+			return fmt.Errorf("failed to recalculate comment count: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return moved, nil
+}
@@ -0,0 +1,169 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/june20516/orbithall/internal/testhelpers"
+)
+
+// TestAddPostSlugAlias는 slug 별칭 추가와 별칭을 통한 포스트 조회를 테스트합니다
+func TestAddPostSlugAlias(t *testing.T) {
+	db := testhelpers.SetupTestDB(t)
+	defer Close(db)
+
+	ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+	defer cleanup()
+
+	site := testhelpers.CreateTestSite(ctx, t, tx, "Test Site", "alias.com", []string{"http://localhost:3000"}, true)
+	post := testhelpers.CreateTestPost(ctx, t, tx, site.ID, "new-slug", "Renamed Post")
+	testhelpers.CreateTestPost(ctx, t, tx, site.ID, "other-post", "Other Post")
+
+	t.Run("별칭 추가 후 이전 slug로 조회 성공", func(t *testing.T) {
+		// Given: 별칭 추가
+		if err := AddPostSlugAlias(ctx, tx, site.ID, post.ID, "old-slug"); err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+
+		// When: 이전 slug로 조회
+		found, err := GetPostBySlug(ctx, tx, site.ID, "old-slug", false)
+
+		// Then: 별칭이 가리키는 포스트 반환
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if found == nil || found.ID != post.ID {
+			t.Fatalf("expected post %d, got %+v", post.ID, found)
+		}
+		if found.Slug != "new-slug" {
+			t.Errorf("expected canonical slug new-slug, got %s", found.Slug)
+		}
+	})
+
+	t.Run("별칭 slug로 GetOrCreatePost 호출 시 새 포스트를 만들지 않음", func(t *testing.T) {
+		// When: 별칭 slug로 GetOrCreatePost 호출
+		found, err := GetOrCreatePost(ctx, tx, site.ID, "old-slug", "old-slug", false)
+
+		// Then: 기존 포스트 반환
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if found.ID != post.ID {
+			t.Errorf("expected post %d, got %d", post.ID, found.ID)
+		}
+	})
+
+	t.Run("이미 사용 중인 slug면 ErrSlugInUse", func(t *testing.T) {
+		// When: 다른 포스트의 slug와 이미 등록된 별칭을 추가
+		errPost := AddPostSlugAlias(ctx, tx, site.ID, post.ID, "other-post")
+		errAlias := AddPostSlugAlias(ctx, tx, site.ID, post.ID, "old-slug")
+
+		// Then: ErrSlugInUse 반환
+		if !errors.Is(errPost, ErrSlugInUse) {
+			t.Errorf("expected ErrSlugInUse for post slug, got: %v", errPost)
+		}
+		if !errors.Is(errAlias, ErrSlugInUse) {
+			t.Errorf("expected ErrSlugInUse for alias, got: %v", errAlias)
+		}
+	})
+
+	t.Run("별칭 삭제", func(t *testing.T) {
+		// When: 별칭 삭제
+		err := DeletePostSlugAlias(ctx, tx, post.ID, "old-slug")
+
+		// Then: 더 이상 이전 slug로 조회되지 않음
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		found, _ := GetPostBySlug(ctx, tx, site.ID, "old-slug", false)
+		if found != nil {
+			t.Errorf("expected nil post, got %+v", found)
+		}
+		if err := DeletePostSlugAlias(ctx, tx, post.ID, "old-slug"); err != sql.ErrNoRows {
+			t.Errorf("expected sql.ErrNoRows, got: %v", err)
+		}
+	})
+}
+
+// TestMergePosts는 포스트 병합을 테스트합니다
+func TestMergePosts(t *testing.T) {
+	db := testhelpers.SetupTestDB(t)
+	defer Close(db)
+
+	t.Run("댓글 이동, 별칭 등록, 댓글 수 재계산", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		// Given: 이전 slug 포스트(댓글 2개 + 대댓글 1개, 별칭 1개)와 새 slug 포스트(댓글 1개)
+		site := testhelpers.CreateTestSite(ctx, t, tx, "Test Site", "merge.com", []string{"http://localhost:3000"}, true)
+		source := testhelpers.CreateTestPost(ctx, t, tx, site.ID, "old-slug", "Original Title")
+		target, _ := GetOrCreatePost(ctx, tx, site.ID, "new-slug", "new-slug", false)
+		if err := AddPostSlugAlias(ctx, tx, site.ID, source.ID, "older-slug"); err != nil {
+			t.Fatalf("failed to add alias: %v", err)
+		}
+
+		parent, err := CreateComment(ctx, tx, source.ID, nil, "홍길동", "test1234", "첫 댓글", "127.0.0.1", "test")
+		if err != nil {
+			t.Fatalf("failed to create comment: %v", err)
+		}
+		if _, err := CreateComment(ctx, tx, source.ID, &parent.ID, "김철수", "test1234", "대댓글", "127.0.0.1", "test"); err != nil {
+			t.Fatalf("failed to create reply: %v", err)
+		}
+		if _, err := CreateComment(ctx, tx, target.ID, nil, "이영희", "test1234", "새 포스트 댓글", "127.0.0.1", "test"); err != nil {
+			t.Fatalf("failed to create comment: %v", err)
+		}
+
+		// When: source를 target으로 병합
+		moved, err := MergePosts(ctx, tx, source.ID, target.ID)
+
+		// Then: 댓글 2개 이동, source 삭제
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if moved != 2 {
+			t.Errorf("expected 2 moved comments, got %d", moved)
+		}
+		if deleted, _ := GetPostByID(ctx, tx, source.ID); deleted != nil {
+			t.Errorf("expected source post to be deleted, got %+v", deleted)
+		}
+
+		merged, err := GetPostByID(ctx, tx, target.ID)
+		if err != nil || merged == nil {
+			t.Fatalf("failed to get merged post: %v", err)
+		}
+		if merged.CommentCount != 3 {
+			t.Errorf("expected comment_count=3, got %d", merged.CommentCount)
+		}
+		if merged.Title != "Original Title" {
+			t.Errorf("expected title from source, got %q", merged.Title)
+		}
+
+		// 이전 slug와 별칭 모두 target으로 연결
+		for _, slug := range []string{"old-slug", "older-slug"} {
+			found, err := GetPostBySlug(ctx, tx, site.ID, slug, false)
+			if err != nil || found == nil || found.ID != target.ID {
+				t.Errorf("expected %s to resolve to post %d, got %+v (err=%v)", slug, target.ID, found, err)
+			}
+		}
+	})
+
+	t.Run("다른 사이트의 포스트는 병합 불가", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		// Given: 서로 다른 사이트의 포스트
+		site1 := testhelpers.CreateTestSite(ctx, t, tx, "Site 1", "merge1.com", []string{"http://localhost:3000"}, true)
+		site2 := testhelpers.CreateTestSite(ctx, t, tx, "Site 2", "merge2.com", []string{"http://localhost:3000"}, true)
+		source := testhelpers.CreateTestPost(ctx, t, tx, site1.ID, "post", "Post")
+		target := testhelpers.CreateTestPost(ctx, t, tx, site2.ID, "post", "Post")
+
+		// When: 병합 시도
+		_, err := MergePosts(ctx, tx, source.ID, target.ID)
+
+		// Then: sql.ErrNoRows
+		if err != sql.ErrNoRows {
+			t.Errorf("expected sql.ErrNoRows, got: %v", err)
+		}
+	})
+}
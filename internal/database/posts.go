@@ -6,24 +6,36 @@ import (
 	"fmt"
 
 	"github.com/june20516/orbithall/internal/models"
+	"github.com/lib/pq"
 )
 
 // GetPostBySlug는 사이트 ID와 slug로 포스트를 조회합니다
-// site_id와 slug 조합은 유니크하므로 정확히 하나의 포스트를 반환합니다
-func GetPostBySlug(ctx context.Context, db DBTX, siteID int64, slug string) (*models.Post, error) {
+// slug가 포스트의 별칭(이전 slug)이면 별칭이 가리키는 포스트를 반환합니다
+// sandbox가 true면 테스트 모드 키로 생성된 샌드박스 포스트에서, false면 실제 포스트에서 조회합니다
+// 사이트의 같은 영역 안에서 slug는 포스트 slug 또는 별칭 중 하나로만 사용되므로 최대 하나의 포스트를 반환합니다
+func GetPostBySlug(ctx context.Context, db DBTX, siteID int64, slug string, sandbox bool) (*models.Post, error) {
 	query := `
-		SELECT id, site_id, slug, title, comment_count, created_at, updated_at
+		SELECT id, site_id, slug, title, url, comment_count, is_locked, is_sandbox, created_at, updated_at
 		FROM posts
-		WHERE site_id = $1 AND slug = $2
+		WHERE site_id = $1 AND slug = $2 AND is_sandbox = $3
+		UNION ALL
+		SELECT p.id, p.site_id, p.slug, p.title, p.url, p.comment_count, p.is_locked, p.is_sandbox, p.created_at, p.updated_at
+		FROM post_slug_aliases a
+		INNER JOIN posts p ON p.id = a.post_id
+		WHERE a.site_id = $1 AND a.slug = $2 AND a.is_sandbox = $3
+		LIMIT 1
 	`
 
 	var post models.Post
-	err := db.QueryRowContext(ctx, query, siteID, slug).Scan(
+	err := db.QueryRowContext(ctx, query, siteID, slug, sandbox).Scan(
 		&post.ID,
 		&post.SiteID,
 		&post.Slug,
 		&post.Title,
+		&post.URL,
 		&post.CommentCount,
+		&post.IsLocked,
+		&post.IsSandbox,
 		&post.CreatedAt,
 		&post.UpdatedAt,
 	)
@@ -41,7 +53,7 @@ func GetPostBySlug(ctx context.Context, db DBTX, siteID int64, slug string) (*mo
 // GetPostByID는 ID로 포스트를 조회합니다
 func GetPostByID(ctx context.Context, db DBTX, id int64) (*models.Post, error) {
 	query := `
-		SELECT id, site_id, slug, title, comment_count, created_at, updated_at
+		SELECT id, site_id, slug, title, url, comment_count, is_locked, is_sandbox, created_at, updated_at
 		FROM posts
 		WHERE id = $1
 	`
@@ -52,7 +64,10 @@ func GetPostByID(ctx context.Context, db DBTX, id int64) (*models.Post, error) {
 		&post.SiteID,
 		&post.Slug,
 		&post.Title,
+		&post.URL,
 		&post.CommentCount,
+		&post.IsLocked,
+		&post.IsSandbox,
 		&post.CreatedAt,
 		&post.UpdatedAt,
 	)
@@ -69,22 +84,33 @@ func GetPostByID(ctx context.Context, db DBTX, id int64) (*models.Post, error) {
 
 // GetOrCreatePost는 포스트를 조회하고, 없으면 생성합니다
 // Next.js 블로그에는 존재하지만 DB에는 없는 포스트를 처음 댓글 작성 시 자동 생성합니다
+// slug가 별칭이면 새 포스트를 만들지 않고 별칭이 가리키는 포스트를 반환합니다
+// sandbox가 true면 샌드박스 영역에서 조회/생성합니다 (테스트 모드 키)
 // Race condition 방지를 위해 ON CONFLICT DO NOTHING + 재조회 패턴 사용
-func GetOrCreatePost(ctx context.Context, db DBTX, siteID int64, slug, title string) (*models.Post, error) {
+func GetOrCreatePost(ctx context.Context, db DBTX, siteID int64, slug, title string, sandbox bool) (*models.Post, error) {
+	// 0단계: 기존 포스트 또는 별칭 조회
+	existing, err := GetPostBySlug(ctx, db, siteID, slug, sandbox)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return existing, nil
+	}
+
 	// 1단계: INSERT 시도 (중복 시 무시)
 	// 동시에 여러 요청이 들어와도 unique constraint에 의해 하나만 생성됨
-	_, err := db.ExecContext(ctx, `
-		INSERT INTO posts (site_id, slug, title, comment_count)
-		VALUES ($1, $2, $3, 0)
-		ON CONFLICT (site_id, slug) DO NOTHING
-	`, siteID, slug, title)
+	_, err = db.ExecContext(ctx, `
+		INSERT INTO posts (site_id, slug, title, comment_count, is_sandbox)
+		VALUES ($1, $2, $3, 0, $4)
+		ON CONFLICT (site_id, is_sandbox, slug) DO NOTHING
+	`, siteID, slug, title, sandbox)
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to insert post: %w", err)
 	}
 
 	// 2단계: 반드시 재조회 (INSERT가 성공했든 충돌했든 확실히 존재함)
-	post, err := GetPostBySlug(ctx, db, siteID, slug)
+	post, err := GetPostBySlug(ctx, db, siteID, slug, sandbox)
 	if err != nil {
 		return nil, err
 	}
@@ -150,31 +176,164 @@ func DecrementCommentCount(ctx context.Context, db DBTX, postID int64) error {
 	return nil
 }
 
+// FillPostMetadata는 아직 채워지지 않은 포스트 메타데이터만 채웁니다 (선착순)
+// 제목은 slug와 같을 때(제목 없이 자동 생성된 포스트)만, URL은 비어있을 때만 변경되므로
+// 한 번 채워진 값은 방문자가 임의의 값을 보내도 덮어써지지 않습니다
+// 빈 문자열 인자는 무시하며, 실제로 변경되었는지 여부를 반환합니다
+func FillPostMetadata(ctx context.Context, db DBTX, postID int64, title, url string) (bool, error) {
+	query := `
+		UPDATE posts
+		SET title = CASE WHEN $2 <> '' AND title = slug THEN $2 ELSE title END,
+		    url = CASE WHEN $3 <> '' AND url = '' THEN $3 ELSE url END,
+		    updated_at = NOW()
+		WHERE id = $1
+		  AND (($2 <> '' AND title = slug AND $2 <> slug) OR ($3 <> '' AND url = ''))
+	`
+
+	result, err := db.ExecContext(ctx, query, postID, title, url)
+	if err != nil {
+		return false, fmt.Errorf("failed to fill post metadata: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// UpdatePost는 포스트의 제목과 URL을 변경합니다 (Admin용)
+// FillPostMetadata와 달리 기존 값과 관계없이 덮어씁니다
+// 포스트가 존재하지 않으면 sql.ErrNoRows를 반환합니다
+func UpdatePost(ctx context.Context, db DBTX, postID int64, title, url string) error {
+	query := `
+		UPDATE posts
+		SET title = $2,
+		    url = $3,
+		    updated_at = NOW()
+		WHERE id = $1
+	`
+
+	result, err := db.ExecContext(ctx, query, postID, title, url)
+	if err != nil {
+		return fmt.Errorf("failed to update post: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// SetPostLocked는 포스트의 댓글 작성 잠금 상태를 변경합니다
+// 포스트가 존재하지 않으면 sql.ErrNoRows를 반환합니다
+func SetPostLocked(ctx context.Context, db DBTX, postID int64, locked bool) error {
+	query := `
+		UPDATE posts
+		SET is_locked = $2,
+		    updated_at = NOW()
+		WHERE id = $1
+	`
+
+	result, err := db.ExecContext(ctx, query, postID, locked)
+	if err != nil {
+		return fmt.Errorf("failed to set post locked: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// GetCommentCountsBySlugs는 여러 slug의 댓글 수와 마지막 활동 시각을 한 번의 쿼리로 조회합니다
+// 별칭 slug는 별칭이 가리키는 포스트의 값을 반환합니다
+// sandbox가 true면 샌드박스 포스트의 값을 조회합니다 (테스트 모드 키)
+// 포스트가 없는 slug는 결과 map에 포함되지 않습니다
+func GetCommentCountsBySlugs(ctx context.Context, db DBTX, siteID int64, slugs []string, sandbox bool) (map[string]models.PostCommentCount, error) {
+	query := `
+		SELECT
+			r.slug,
+			COUNT(c.id) as comment_count,
+			MAX(c.updated_at) as last_activity_at
+		FROM unnest($2::text[]) AS r(slug)
+		LEFT JOIN post_slug_aliases a ON a.site_id = $1 AND a.is_sandbox = $3 AND a.slug = r.slug
+		INNER JOIN posts p ON p.site_id = $1 AND p.is_sandbox = $3 AND (p.slug = r.slug OR p.id = a.post_id)
+		LEFT JOIN comments c ON c.post_id = p.id AND c.is_deleted = false
+		GROUP BY r.slug
+	`
+
+	rows, err := db.QueryContext(ctx, query, siteID, pq.Array(slugs), sandbox)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get comment counts: %w", err)
+	}
+	defer rows.Close()
+
+	counts := make(map[string]models.PostCommentCount, len(slugs))
+	for rows.Next() {
+		var slug string
+		var count models.PostCommentCount
+		var lastActivityAt sql.NullTime
+
+		if err := rows.Scan(&slug, &count.CommentCount, &lastActivityAt); err != nil {
+			return nil, fmt.Errorf("failed to scan comment count: %w", err)
+		}
+		if lastActivityAt.Valid {
+			count.LastActivityAt = &lastActivityAt.Time
+		}
+
+		counts[slug] = count
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating comment counts: %w", err)
+	}
+
+	return counts, nil
+}
+
 // ListPostsBySite는 사이트별 Post 목록을 조회합니다
 // Admin용으로 각 Post별 활성/삭제 댓글 수를 포함합니다
+// CommentCount는 저장된 값이므로 ActiveCommentCount와 다르면 재계산이 필요합니다 (ReconcileCommentCounts)
+// sandbox가 false면 실제 포스트만, true면 샌드박스 포스트만 조회합니다
 // 최신 댓글 순으로 정렬됩니다
-func ListPostsBySite(ctx context.Context, db DBTX, siteID int64) ([]*models.Post, error) {
+func ListPostsBySite(ctx context.Context, db DBTX, siteID int64, sandbox bool) ([]*models.Post, error) {
 	query := `
 		SELECT
 			p.id,
 			p.site_id,
 			p.slug,
 			p.title,
+			p.url,
 			p.comment_count,
+			p.is_locked,
+			p.is_sandbox,
 			p.created_at,
 			p.updated_at,
-			COUNT(c.id) as total_comments,
 			COUNT(CASE WHEN c.is_deleted = false THEN 1 END) as active_comments,
 			COUNT(CASE WHEN c.is_deleted = true THEN 1 END) as deleted_comments,
 			MAX(c.created_at) as last_comment_at
 		FROM posts p
 		LEFT JOIN comments c ON c.post_id = p.id
-		WHERE p.site_id = $1
-		GROUP BY p.id, p.site_id, p.slug, p.title, p.comment_count, p.created_at, p.updated_at
+		WHERE p.site_id = $1 AND p.is_sandbox = $2
+		GROUP BY p.id, p.site_id, p.slug, p.title, p.url, p.comment_count, p.is_locked, p.is_sandbox, p.created_at, p.updated_at
 		ORDER BY last_comment_at DESC NULLS LAST, p.created_at DESC
 	`
 
-	rows, err := db.QueryContext(ctx, query, siteID)
+	rows, err := db.QueryContext(ctx, query, siteID, sandbox)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list posts: %w", err)
 	}
@@ -183,7 +342,6 @@ func ListPostsBySite(ctx context.Context, db DBTX, siteID int64) ([]*models.Post
 	var posts []*models.Post
 	for rows.Next() {
 		post := &models.Post{}
-		var totalComments int
 		var lastCommentAt sql.NullTime
 
 		err := rows.Scan(
@@ -191,10 +349,12 @@ func ListPostsBySite(ctx context.Context, db DBTX, siteID int64) ([]*models.Post
 			&post.SiteID,
 			&post.Slug,
 			&post.Title,
+			&post.URL,
 			&post.CommentCount,
+			&post.IsLocked,
+			&post.IsSandbox,
 			&post.CreatedAt,
 			&post.UpdatedAt,
-			&totalComments,
 			&post.ActiveCommentCount,
 			&post.DeletedCommentCount,
 			&lastCommentAt,
@@ -203,9 +363,6 @@ func ListPostsBySite(ctx context.Context, db DBTX, siteID int64) ([]*models.Post
 			return nil, fmt.Errorf("failed to scan post: %w", err)
 		}
 
-		// CommentCount를 실제 총 댓글 수로 업데이트
-		post.CommentCount = totalComments
-
 		posts = append(posts, post)
 	}
 
@@ -216,3 +373,81 @@ func ListPostsBySite(ctx context.Context, db DBTX, siteID int64) ([]*models.Post
 	return posts, nil
 }
 
+// DeleteSandboxData는 사이트의 샌드박스 포스트와 댓글(테스트 모드 키로 작성된 데이터)을 모두 삭제합니다
+// 댓글과 별칭은 포스트와 함께 삭제되며(CASCADE), 실제 데이터에는 영향이 없습니다
+// 삭제된 포스트 수와 댓글 수를 반환합니다
+func DeleteSandboxData(ctx context.Context, db DBTX, siteID int64) (deletedPosts, deletedComments int64, err error) {
+	query := `
+		WITH deleted AS (
+			DELETE FROM posts
+			WHERE site_id = $1 AND is_sandbox = true
+			RETURNING id
+		)
+		SELECT
+			(SELECT COUNT(*) FROM deleted),
+			(SELECT COUNT(*) FROM comments WHERE post_id IN (SELECT id FROM deleted))
+	`
+
+	// CTE의 DELETE 결과는 같은 쿼리에서 보이지 않으므로 댓글은 삭제 전 상태로 셈
+	if err := db.QueryRowContext(ctx, query, siteID).Scan(&deletedPosts, &deletedComments); err != nil {
+		return 0, 0, fmt.Errorf("failed to delete sandbox data: %w", err)
+	}
+
+	return deletedPosts, deletedComments, nil
+}
+
+// commentCountDriftQuery는 저장된 comment_count와 실제 댓글 수(삭제되지 않은 댓글)가 다른 포스트를 찾습니다
+// $1이 0이면 모든 사이트, 아니면 해당 사이트의 포스트만 대상으로 합니다
+// $1은 int4로 추론되지 않도록 BIGINT로 캐스팅합니다 (site_id가 int4 범위를 넘어도 동작)
+const commentCountDriftQuery = `
+	SELECT
+		p.id,
+		p.site_id,
+		p.slug,
+		COALESCE(p.comment_count, 0) as stored_count,
+		COUNT(c.id) as actual_count
+	FROM posts p
+	LEFT JOIN comments c ON c.post_id = p.id AND c.is_deleted = false
+	WHERE ($1::BIGINT = 0 OR p.site_id = $1::BIGINT)
+	GROUP BY p.id, p.site_id, p.slug, p.comment_count
+	HAVING COALESCE(p.comment_count, 0) <> COUNT(c.id)
+`
+
+// ReconcileCommentCounts는 사이트의 comment_count를 실제 댓글 수로 다시 계산합니다
+// siteID가 0이면 모든 사이트를 대상으로 합니다
+// dryRun이 true면 값을 변경하지 않고 차이만 반환합니다
+// 실행 중에 작성된 댓글 때문에 다시 차이가 생길 수 있으며, 다음 실행에서 보정됩니다
+// 차이가 있던 포스트 목록을 반환합니다 (차이가 없으면 빈 슬라이스)
+func ReconcileCommentCounts(ctx context.Context, db DBTX, siteID int64, dryRun bool) ([]models.CommentCountDrift, error) {
+	query := commentCountDriftQuery
+	if !dryRun {
+		query = `
+			UPDATE posts p
+			SET comment_count = d.actual_count
+			FROM (` + commentCountDriftQuery + `) d
+			WHERE p.id = d.id
+			RETURNING d.id, d.site_id, d.slug, d.stored_count, d.actual_count
+		`
+	}
+
+	rows, err := db.QueryContext(ctx, query, siteID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reconcile comment counts: %w", err)
+	}
+	defer rows.Close()
+
+	drifts := []models.CommentCountDrift{}
+	for rows.Next() {
+		var drift models.CommentCountDrift
+		if err := rows.Scan(&drift.PostID, &drift.SiteID, &drift.Slug, &drift.StoredCount, &drift.ActualCount); err != nil {
+			return nil, fmt.Errorf("failed to scan comment count drift: %w", err)
+		}
+		drifts = append(drifts, drift)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating comment count drift: %w", err)
+	}
+
+	return drifts, nil
+}
@@ -3,10 +3,12 @@ package database
 import (
 	// 1. context 임포트
 
+	"database/sql"
 	"testing"
 
+	"github.com/june20516/orbithall/internal/models"
 	"github.com/june20516/orbithall/internal/testhelpers"
-	// "github.com/lib/pq" // (pq는 insertTestSite 헬퍼에서 필요할 수 있음)
+	"github.com/lib/pq"
 )
 
 // TestGetPostBySlug는 GetPostBySlug 메서드를 테스트합니다
@@ -27,7 +29,7 @@ func TestGetPostBySlug(t *testing.T) {
 		testhelpers.CreateTestPost(ctx, t, tx, siteID, slug, title)
 
 		// When: GetPostBySlug 호출
-		post, err := GetPostBySlug(ctx, tx, siteID, slug)
+		post, err := GetPostBySlug(ctx, tx, siteID, slug, false)
 
 		// Then: 포스트 조회 성공
 		if err != nil {
@@ -53,7 +55,7 @@ func TestGetPostBySlug(t *testing.T) {
 		nonExistentSlug := "non-existent-post"
 
 		// When: GetPostBySlug 호출
-		post, err := GetPostBySlug(ctx, tx, siteID, nonExistentSlug)
+		post, err := GetPostBySlug(ctx, tx, siteID, nonExistentSlug, false)
 
 		// Then: nil 반환, 에러 없음
 		if err != nil {
@@ -72,7 +74,7 @@ func TestGetPostBySlug(t *testing.T) {
 		testhelpers.CreateTestPost(ctx, t, tx, siteID, slug, "Isolated Post")
 
 		// When: site_id=2로 조회
-		post, err := GetPostBySlug(ctx, tx, site2.ID, slug)
+		post, err := GetPostBySlug(ctx, tx, site2.ID, slug, false)
 
 		// Then: nil 반환 (사이트 격리)
 		if err != nil {
@@ -161,7 +163,7 @@ func TestGetOrCreatePost(t *testing.T) {
 		existingID := testhelpers.CreateTestPost(ctx, t, tx, siteID, slug, title).ID
 
 		// When: GetOrCreatePost 호출
-		post, err := GetOrCreatePost(ctx, tx, siteID, slug, "New Title")
+		post, err := GetOrCreatePost(ctx, tx, siteID, slug, "New Title", false)
 
 		// Then: 기존 포스트 반환 (title은 변경되지 않음)
 		if err != nil {
@@ -184,7 +186,7 @@ func TestGetOrCreatePost(t *testing.T) {
 		title := "New Post"
 
 		// When: GetOrCreatePost 호출
-		post, err := GetOrCreatePost(ctx, tx, siteID, slug, title)
+		post, err := GetOrCreatePost(ctx, tx, siteID, slug, title, false)
 
 		// Then: 새로운 포스트 생성
 		if err != nil {
@@ -226,9 +228,9 @@ func TestGetOrCreatePost(t *testing.T) {
 		title := "Idempotent Post"
 
 		// When: GetOrCreatePost를 3번 호출
-		post1, err1 := GetOrCreatePost(ctx, tx, siteID, slug, title)
-		post2, err2 := GetOrCreatePost(ctx, tx, siteID, slug, title)
-		post3, err3 := GetOrCreatePost(ctx, tx, siteID, slug, title)
+		post1, err1 := GetOrCreatePost(ctx, tx, siteID, slug, title, false)
+		post2, err2 := GetOrCreatePost(ctx, tx, siteID, slug, title, false)
+		post3, err3 := GetOrCreatePost(ctx, tx, siteID, slug, title, false)
 
 		// Then: 모두 같은 포스트 반환
 		if err1 != nil || err2 != nil || err3 != nil {
@@ -451,6 +453,162 @@ func TestDecrementCommentCount(t *testing.T) {
 	})
 }
 
+// TestFillPostMetadata는 FillPostMetadata 메서드를 테스트합니다
+func TestFillPostMetadata(t *testing.T) {
+	db := setupTestDB(t)
+	defer Close(db)
+
+	ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+	defer cleanup()
+
+	siteID := testhelpers.CreateTestSite(ctx, t, tx, "Test Site", "test.com", []string{"http://localhost:3000"}, true).ID
+
+	t.Run("slug가 제목인 포스트는 처음 전달된 제목과 URL로 채움", func(t *testing.T) {
+		// Given: slug가 제목으로 저장된 포스트
+		postID := testhelpers.CreateTestPost(ctx, t, tx, siteID, "fill-first", "fill-first").ID
+
+		// When: FillPostMetadata 호출
+		updated, err := FillPostMetadata(ctx, tx, postID, "Real Title", "https://test.com/fill-first")
+
+		// Then: 제목과 URL 채워짐
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if !updated {
+			t.Error("expected updated=true")
+		}
+		post, _ := GetPostByID(ctx, tx, postID)
+		if post.Title != "Real Title" || post.URL != "https://test.com/fill-first" {
+			t.Errorf("unexpected post metadata: title=%s, url=%s", post.Title, post.URL)
+		}
+	})
+
+	t.Run("이미 채워진 값은 덮어쓰지 않음", func(t *testing.T) {
+		// Given: 제목과 URL이 채워진 포스트
+		postID := testhelpers.CreateTestPost(ctx, t, tx, siteID, "fill-once", "fill-once").ID
+		if _, err := FillPostMetadata(ctx, tx, postID, "Original", "https://test.com/fill-once"); err != nil {
+			t.Fatalf("failed to fill metadata: %v", err)
+		}
+
+		// When: 다른 값으로 다시 호출
+		updated, err := FillPostMetadata(ctx, tx, postID, "Vandalized", "https://evil.com/")
+
+		// Then: 변경 없음
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if updated {
+			t.Error("expected updated=false")
+		}
+		post, _ := GetPostByID(ctx, tx, postID)
+		if post.Title != "Original" || post.URL != "https://test.com/fill-once" {
+			t.Errorf("expected metadata to be unchanged, got title=%s, url=%s", post.Title, post.URL)
+		}
+	})
+
+	t.Run("빈 값은 무시", func(t *testing.T) {
+		// Given: slug가 제목인 포스트
+		postID := testhelpers.CreateTestPost(ctx, t, tx, siteID, "fill-empty", "fill-empty").ID
+
+		// When: 빈 값으로 호출
+		updated, err := FillPostMetadata(ctx, tx, postID, "", "")
+
+		// Then: 변경 없음
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if updated {
+			t.Error("expected updated=false")
+		}
+	})
+}
+
+// TestUpdatePost는 UpdatePost 메서드를 테스트합니다
+func TestUpdatePost(t *testing.T) {
+	db := setupTestDB(t)
+	defer Close(db)
+
+	ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+	defer cleanup()
+
+	siteID := testhelpers.CreateTestSite(ctx, t, tx, "Test Site", "test.com", []string{"http://localhost:3000"}, true).ID
+
+	t.Run("기존 값과 관계없이 덮어씀", func(t *testing.T) {
+		// Given: 제목이 채워진 포스트
+		postID := testhelpers.CreateTestPost(ctx, t, tx, siteID, "admin-update", "Old Title").ID
+
+		// When: UpdatePost 호출
+		err := UpdatePost(ctx, tx, postID, "New Title", "https://test.com/admin-update")
+
+		// Then: 변경됨
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		post, _ := GetPostByID(ctx, tx, postID)
+		if post.Title != "New Title" || post.URL != "https://test.com/admin-update" {
+			t.Errorf("unexpected post metadata: title=%s, url=%s", post.Title, post.URL)
+		}
+	})
+
+	t.Run("존재하지 않는 포스트 ID는 sql.ErrNoRows 반환", func(t *testing.T) {
+		// When: 존재하지 않는 포스트 ID로 호출
+		err := UpdatePost(ctx, tx, 99999, "Title", "")
+
+		// Then: sql.ErrNoRows 반환
+		if err != sql.ErrNoRows {
+			t.Fatalf("expected sql.ErrNoRows, got %v", err)
+		}
+	})
+}
+
+// TestSetPostLocked는 SetPostLocked 메서드를 테스트합니다
+func TestSetPostLocked(t *testing.T) {
+	db := setupTestDB(t)
+	defer Close(db)
+
+	ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+	defer cleanup()
+
+	siteID := testhelpers.CreateTestSite(ctx, t, tx, "Test Site", "test.com", []string{"http://localhost:3000"}, true).ID
+
+	t.Run("잠금 후 해제", func(t *testing.T) {
+		// Given: 잠기지 않은 포스트
+		postID := testhelpers.CreateTestPost(ctx, t, tx, siteID, "lock-post", "Lock Post").ID
+
+		// When: 잠금
+		if err := SetPostLocked(ctx, tx, postID, true); err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+
+		// Then: 잠김 상태로 조회됨
+		post, _ := GetPostByID(ctx, tx, postID)
+		if !post.IsLocked {
+			t.Error("expected post to be locked")
+		}
+
+		// When: 해제
+		if err := SetPostLocked(ctx, tx, postID, false); err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+
+		// Then: 잠기지 않은 상태로 조회됨
+		post, _ = GetPostByID(ctx, tx, postID)
+		if post.IsLocked {
+			t.Error("expected post to be unlocked")
+		}
+	})
+
+	t.Run("존재하지 않는 포스트 ID는 sql.ErrNoRows 반환", func(t *testing.T) {
+		// When: 존재하지 않는 포스트 ID로 호출
+		err := SetPostLocked(ctx, tx, 99999, true)
+
+		// Then: sql.ErrNoRows 반환
+		if err != sql.ErrNoRows {
+			t.Fatalf("expected sql.ErrNoRows, got %v", err)
+		}
+	})
+}
+
 // TestListPostsBySite는 사이트별 Post 목록 조회 기능을 테스트합니다
 func TestListPostsBySite(t *testing.T) {
 	db := testhelpers.SetupTestDB(t)
@@ -502,7 +660,7 @@ func TestListPostsBySite(t *testing.T) {
 		// post3에는 댓글 없음
 
 		// Post 목록 조회
-		posts, err := ListPostsBySite(ctx, tx, site.ID)
+		posts, err := ListPostsBySite(ctx, tx, site.ID, false)
 		if err != nil {
 			t.Fatalf("Failed to list posts: %v", err)
 		}
@@ -532,8 +690,9 @@ func TestListPostsBySite(t *testing.T) {
 				}
 			case "post-2":
 				foundPost2 = true
-				if post.CommentCount != 2 {
-					t.Errorf("Post2 expected comment count 2, got %d", post.CommentCount)
+				// comment_count는 삭제되지 않은 댓글만 셈
+				if post.CommentCount != 1 {
+					t.Errorf("Post2 expected comment count 1, got %d", post.CommentCount)
 				}
 				if post.ActiveCommentCount != 1 {
 					t.Errorf("Post2 expected active comment count 1, got %d", post.ActiveCommentCount)
@@ -569,7 +728,7 @@ func TestListPostsBySite(t *testing.T) {
 			[]string{"https://empty.com"}, true)
 
 		// Post 목록 조회
-		posts, err := ListPostsBySite(ctx, tx, site.ID)
+		posts, err := ListPostsBySite(ctx, tx, site.ID, false)
 		if err != nil {
 			t.Fatalf("Failed to list posts: %v", err)
 		}
@@ -580,3 +739,247 @@ func TestListPostsBySite(t *testing.T) {
 		}
 	})
 }
+
+// TestGetCommentCountsBySlugs는 여러 slug의 댓글 수 일괄 조회를 테스트합니다
+func TestGetCommentCountsBySlugs(t *testing.T) {
+	db := testhelpers.SetupTestDB(t)
+	defer Close(db)
+
+	ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+	defer cleanup()
+
+	// Given: 댓글 2개(1개 삭제)인 포스트, 댓글 없는 포스트, 별칭
+	site := testhelpers.CreateTestSite(ctx, t, tx, "Counts Site", "counts.com", []string{"http://localhost:3000"}, true)
+	post := testhelpers.CreateTestPost(ctx, t, tx, site.ID, "with-comments", "With Comments")
+	testhelpers.CreateTestPost(ctx, t, tx, site.ID, "no-comments", "No Comments")
+	if err := AddPostSlugAlias(ctx, tx, site.ID, post.ID, "old-with-comments"); err != nil {
+		t.Fatalf("Failed to add alias: %v", err)
+	}
+	if _, err := CreateComment(ctx, tx, post.ID, nil, "홍길동", "test1234", "남은 댓글", "127.0.0.1", "test"); err != nil {
+		t.Fatalf("Failed to create comment: %v", err)
+	}
+	deleted, err := CreateComment(ctx, tx, post.ID, nil, "김철수", "test1234", "삭제될 댓글", "127.0.0.1", "test")
+	if err != nil {
+		t.Fatalf("Failed to create comment: %v", err)
+	}
+	if _, err := tx.ExecContext(ctx, "UPDATE comments SET is_deleted = true WHERE id = $1", deleted.ID); err != nil {
+		t.Fatalf("Failed to delete comment: %v", err)
+	}
+
+	// When: 일괄 조회
+	counts, err := GetCommentCountsBySlugs(ctx, tx, site.ID, []string{"with-comments", "old-with-comments", "no-comments", "missing"}, false)
+
+	// Then: 삭제되지 않은 댓글만 집계, 없는 포스트는 제외
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	for _, slug := range []string{"with-comments", "old-with-comments"} {
+		if counts[slug].CommentCount != 1 || counts[slug].LastActivityAt == nil {
+			t.Errorf("%s: unexpected count %+v", slug, counts[slug])
+		}
+	}
+	if c, ok := counts["no-comments"]; !ok || c.CommentCount != 0 || c.LastActivityAt != nil {
+		t.Errorf("no-comments: unexpected count %+v (ok=%v)", c, ok)
+	}
+	if _, ok := counts["missing"]; ok {
+		t.Error("expected missing slug to be absent")
+	}
+}
+
+// TestReconcileCommentCounts는 comment_count 재계산을 테스트합니다
+func TestReconcileCommentCounts(t *testing.T) {
+	db := testhelpers.SetupTestDB(t)
+	defer Close(db)
+
+	ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+	defer cleanup()
+
+	// Given: comment_count가 실제와 다른 포스트 (다른 사이트 포스트도 어긋나 있음)
+	site := testhelpers.CreateTestSite(ctx, t, tx, "Reconcile Site", "reconcile.com", []string{"http://localhost:3000"}, true)
+	otherSite := testhelpers.CreateTestSite(ctx, t, tx, "Other Site", "reconcile-other.com", []string{"http://localhost:3000"}, true)
+	post := testhelpers.CreateTestPost(ctx, t, tx, site.ID, "drifted", "Drifted")
+	testhelpers.CreateTestPost(ctx, t, tx, site.ID, "accurate", "Accurate")
+	otherPost := testhelpers.CreateTestPost(ctx, t, tx, otherSite.ID, "other-drifted", "Other Drifted")
+
+	if _, err := CreateComment(ctx, tx, post.ID, nil, "Author", "password123", "Content", "127.0.0.1", "test"); err != nil {
+		t.Fatalf("Failed to create comment: %v", err)
+	}
+	if _, err := tx.ExecContext(ctx, "UPDATE posts SET comment_count = 5 WHERE id = ANY($1)", pq.Array([]int64{post.ID, otherPost.ID})); err != nil {
+		t.Fatalf("Failed to corrupt comment_count: %v", err)
+	}
+
+	t.Run("dry run은 차이만 보고", func(t *testing.T) {
+		// When: dry run
+		drifts, err := ReconcileCommentCounts(ctx, tx, site.ID, true)
+
+		// Then: 해당 사이트의 어긋난 포스트만 보고, 값은 유지
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if len(drifts) != 1 || drifts[0].PostID != post.ID || drifts[0].StoredCount != 5 || drifts[0].ActualCount != 1 {
+			t.Fatalf("unexpected drift: %+v", drifts)
+		}
+		stored, _ := GetPostByID(ctx, tx, post.ID)
+		if stored.CommentCount != 5 {
+			t.Errorf("expected comment_count to stay 5, got %d", stored.CommentCount)
+		}
+	})
+
+	t.Run("재계산 후 차이 없음", func(t *testing.T) {
+		// When: 재계산
+		drifts, err := ReconcileCommentCounts(ctx, tx, site.ID, false)
+
+		// Then: 값 보정, 다른 사이트는 그대로
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if len(drifts) != 1 {
+			t.Fatalf("expected 1 drift, got %+v", drifts)
+		}
+		stored, _ := GetPostByID(ctx, tx, post.ID)
+		if stored.CommentCount != 1 {
+			t.Errorf("expected comment_count=1, got %d", stored.CommentCount)
+		}
+		other, _ := GetPostByID(ctx, tx, otherPost.ID)
+		if other.CommentCount != 5 {
+			t.Errorf("expected other site untouched, got %d", other.CommentCount)
+		}
+
+		again, _ := ReconcileCommentCounts(ctx, tx, site.ID, true)
+		if len(again) != 0 {
+			t.Errorf("expected no drift after reconcile, got %+v", again)
+		}
+	})
+
+	t.Run("siteID가 0이면 모든 사이트", func(t *testing.T) {
+		// When: 전체 재계산
+		if _, err := ReconcileCommentCounts(ctx, tx, 0, false); err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+
+		// Then: 다른 사이트도 보정
+		other, _ := GetPostByID(ctx, tx, otherPost.ID)
+		if other.CommentCount != 0 {
+			t.Errorf("expected comment_count=0, got %d", other.CommentCount)
+		}
+	})
+}
+
+// TestSandboxPosts는 테스트 모드(샌드박스) 포스트가 실제 포스트와 분리되는지 테스트합니다
+func TestSandboxPosts(t *testing.T) {
+	db := setupTestDB(t)
+	defer Close(db)
+
+	ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+	defer cleanup()
+
+	site := testhelpers.CreateTestSite(ctx, t, tx, "Sandbox Site", "sandbox.com", []string{"http://localhost:3000"}, true)
+	live := testhelpers.CreateTestPost(ctx, t, tx, site.ID, "shared-slug", "Live Post")
+	if _, err := CreateComment(ctx, tx, live.ID, nil, "Author", "password123", "Live", "127.0.0.1", "test-agent"); err != nil {
+		t.Fatalf("Failed to create comment: %v", err)
+	}
+	if err := IncrementCommentCount(ctx, tx, live.ID); err != nil {
+		t.Fatalf("Failed to increment comment count: %v", err)
+	}
+
+	var sandbox *models.Post
+	t.Run("같은 slug라도 샌드박스 포스트는 별도로 생성됨", func(t *testing.T) {
+		// When: 샌드박스 영역에서 같은 slug로 GetOrCreatePost 호출
+		var err error
+		sandbox, err = GetOrCreatePost(ctx, tx, site.ID, "shared-slug", "Sandbox Post", true)
+
+		// Then: 실제 포스트와 다른 샌드박스 포스트 생성
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if sandbox.ID == live.ID {
+			t.Fatal("expected separate sandbox post")
+		}
+		if !sandbox.IsSandbox {
+			t.Error("expected is_sandbox=true")
+		}
+		if _, err := CreateComment(ctx, tx, sandbox.ID, nil, "Tester", "password123", "Sandbox", "127.0.0.1", "test-agent"); err != nil {
+			t.Fatalf("Failed to create comment: %v", err)
+		}
+
+		// 실제 영역 조회는 여전히 실제 포스트 반환
+		found, _ := GetPostBySlug(ctx, tx, site.ID, "shared-slug", false)
+		if found == nil || found.ID != live.ID || found.IsSandbox {
+			t.Errorf("expected live post, got %+v", found)
+		}
+	})
+
+	t.Run("목록, 통계, 댓글 수 조회는 영역별로 분리됨", func(t *testing.T) {
+		// When: 실제 영역과 샌드박스 영역 각각 조회
+		livePosts, err := ListPostsBySite(ctx, tx, site.ID, false)
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		sandboxPosts, _ := ListPostsBySite(ctx, tx, site.ID, true)
+		liveStats, _ := GetSiteStats(ctx, tx, site.ID, false)
+		sandboxCounts, _ := GetCommentCountsBySlugs(ctx, tx, site.ID, []string{"shared-slug"}, true)
+
+		// Then: 각 영역의 데이터만 반환
+		if len(livePosts) != 1 || livePosts[0].ID != live.ID {
+			t.Errorf("expected only live post, got %+v", livePosts)
+		}
+		if len(sandboxPosts) != 1 || sandboxPosts[0].ID != sandbox.ID {
+			t.Errorf("expected only sandbox post, got %+v", sandboxPosts)
+		}
+		if liveStats.PostCount != 1 || liveStats.CommentCount != 1 {
+			t.Errorf("expected live stats 1/1, got %+v", liveStats)
+		}
+		if sandboxCounts["shared-slug"].CommentCount != 1 {
+			t.Errorf("expected sandbox comment count 1, got %+v", sandboxCounts)
+		}
+	})
+
+	t.Run("다른 영역의 포스트와는 병합할 수 없음", func(t *testing.T) {
+		// When: 샌드박스 포스트를 실제 포스트로 병합
+		_, err := MergePosts(ctx, tx, sandbox.ID, live.ID)
+
+		// Then: sql.ErrNoRows
+		if err != sql.ErrNoRows {
+			t.Fatalf("expected sql.ErrNoRows, got %v", err)
+		}
+	})
+
+	t.Run("별칭은 포스트와 같은 영역에서만 중복 검사", func(t *testing.T) {
+		// Given: 실제 영역에만 있는 slug
+		testhelpers.CreateTestPost(ctx, t, tx, site.ID, "live-only", "Live Only")
+
+		// When: 샌드박스 포스트에 같은 slug를 별칭으로 추가
+		if err := AddPostSlugAlias(ctx, tx, site.ID, sandbox.ID, "live-only"); err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+
+		// Then: 샌드박스 영역에서만 별칭으로 조회됨
+		found, _ := GetPostBySlug(ctx, tx, site.ID, "live-only", true)
+		if found == nil || found.ID != sandbox.ID {
+			t.Errorf("expected sandbox post via alias, got %+v", found)
+		}
+		found, _ = GetPostBySlug(ctx, tx, site.ID, "live-only", false)
+		if found == nil || found.IsSandbox {
+			t.Errorf("expected live post, got %+v", found)
+		}
+	})
+
+	t.Run("샌드박스 데이터 삭제", func(t *testing.T) {
+		// When: DeleteSandboxData 호출
+		deletedPosts, deletedComments, err := DeleteSandboxData(ctx, tx, site.ID)
+
+		// Then: 샌드박스 포스트와 댓글만 삭제
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if deletedPosts != 1 || deletedComments != 1 {
+			t.Errorf("expected 1 post and 1 comment deleted, got %d/%d", deletedPosts, deletedComments)
+		}
+		if found, _ := GetPostByID(ctx, tx, sandbox.ID); found != nil {
+			t.Error("expected sandbox post to be deleted")
+		}
+		if found, _ := GetPostByID(ctx, tx, live.ID); found == nil || found.CommentCount != 1 {
+			t.Errorf("expected live post untouched, got %+v", found)
+		}
+	})
+}
@@ -0,0 +1,119 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/june20516/orbithall/internal/models"
+)
+
+// UpdateSiteRateLimits는 사이트의 작업별 요청 제한 설정을 변경합니다
+// 캐시된 사이트 정보는 모든 인스턴스에서 무효화됩니다
+func UpdateSiteRateLimits(ctx context.Context, db DBTX, siteID int64, limits models.SiteRateLimits) error {
+	value, err := json.Marshal(limits)
+	if err != nil {
+		return fmt.Errorf("failed to marshal site rate limits: %w", err)
+	}
+
+	query := `
+		UPDATE sites
+		SET rate_limits = $1, updated_at = NOW()
+		WHERE id = $2
+	`
+
+	result, err := db.ExecContext(ctx, query, value, siteID)
+	if err != nil {
+		return fmt.Errorf("failed to update site rate limits: %w", err)
+	}
+
+	// 영향받은 행 수 확인
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return notifySiteChanged(ctx, db, siteID)
+}
+
+// unmarshalRateLimits는 sites.rate_limits 컬럼 값(JSONB)을 limits에 채웁니다
+func unmarshalRateLimits(value []byte, limits *models.SiteRateLimits) error {
+	if len(value) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(value, limits); err != nil {
+		return fmt.Errorf("failed to unmarshal site rate limits: %w", err)
+	}
+	return nil
+}
+
+// idleRateLimitBucketRetention은 요청이 없는 토큰 버킷을 DeleteIdleRateLimitBuckets로 삭제하기 전까지 보관하는 기간입니다
+// 설정 가능한 가장 느린 제한(분당 1회, burst 1000)도 이 기간 안에 가득 충전되므로, 삭제해도 제한 결과는 달라지지 않습니다
+const idleRateLimitBucketRetention = 24 * time.Hour
+
+// TakeRateLimitToken은 bucket의 key 토큰 버킷에서 토큰 1개를 소비합니다
+// 버킷은 최대 burst개의 토큰으로 시작하고, 초당 ratePerSecond개씩 burst까지 충전됩니다
+// 토큰이 1개 이상 남아 있으면 소비하고 true, 부족하면 버킷을 그대로 두고 false를 반환하며,
+// 처리 후 남은 토큰 수(소수 포함)를 함께 반환합니다
+// 허용 여부는 하나의 UPSERT 문으로 충전과 소비를 처리하므로 여러 인스턴스가 동시에 호출해도 원자적으로 판단됩니다
+func TakeRateLimitToken(ctx context.Context, db DBTX, bucket, key string, ratePerSecond float64, burst int) (bool, float64, error) {
+	if burst < 1 {
+		return false, 0, nil
+	}
+
+	query := `
+		INSERT INTO rate_limit_buckets (bucket, key, tokens, updated_at)
+		VALUES ($1, $2, $3::DOUBLE PRECISION - 1, NOW())
+		ON CONFLICT (bucket, key) DO UPDATE
+		SET tokens = LEAST($3::DOUBLE PRECISION, rate_limit_buckets.tokens + EXTRACT(EPOCH FROM NOW() - rate_limit_buckets.updated_at)::DOUBLE PRECISION * $4::DOUBLE PRECISION) - 1,
+			updated_at = NOW()
+		WHERE LEAST($3::DOUBLE PRECISION, rate_limit_buckets.tokens + EXTRACT(EPOCH FROM NOW() - rate_limit_buckets.updated_at)::DOUBLE PRECISION * $4::DOUBLE PRECISION) >= 1
+		RETURNING tokens
+	`
+
+	var tokens float64
+	err := db.QueryRowContext(ctx, query, bucket, key, float64(burst), ratePerSecond).Scan(&tokens)
+	if err == nil {
+		return true, tokens, nil
+	}
+	if err != sql.ErrNoRows {
+		return false, 0, fmt.Errorf("failed to take rate limit token: %w", err)
+	}
+
+	// 충전해도 토큰이 부족하여 갱신되지 않음: 현재 토큰 수만 조회
+	query = `
+		SELECT LEAST($3::DOUBLE PRECISION, tokens + EXTRACT(EPOCH FROM NOW() - updated_at)::DOUBLE PRECISION * $4::DOUBLE PRECISION)
+		FROM rate_limit_buckets
+		WHERE bucket = $1 AND key = $2
+	`
+	if err := db.QueryRowContext(ctx, query, bucket, key, float64(burst), ratePerSecond).Scan(&tokens); err != nil && err != sql.ErrNoRows {
+		return false, 0, fmt.Errorf("failed to get rate limit tokens: %w", err)
+	}
+
+	return false, tokens, nil
+}
+
+// DeleteIdleRateLimitBuckets는 마지막 요청 후 24시간이 지난 토큰 버킷을 삭제하고 삭제한 버킷 수를 반환합니다
+// 삭제된 버킷은 다음 요청 때 가득 찬 상태로 다시 만들어집니다
+func DeleteIdleRateLimitBuckets(ctx context.Context, db DBTX) (int64, error) {
+	result, err := db.ExecContext(ctx, `
+		DELETE FROM rate_limit_buckets
+		WHERE updated_at < $1
+	`, time.Now().Add(-idleRateLimitBucketRetention))
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete idle rate limit buckets: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected, nil
+}
@@ -0,0 +1,181 @@
+package database
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/june20516/orbithall/internal/models"
+	"github.com/june20516/orbithall/internal/testhelpers"
+)
+
+// TestTakeRateLimitToken은 DB에 저장되는 토큰 버킷의 소비와 충전을 테스트합니다
+func TestTakeRateLimitToken(t *testing.T) {
+	db := testhelpers.SetupTestDB(t)
+	defer Close(db)
+
+	t.Run("burst만큼 허용 후 거부, 키와 bucket별로 독립", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		// When: 충전 속도 0, burst 2인 버킷에서 3번 요청
+		var results []bool
+		for i := 0; i < 3; i++ {
+			allowed, _, err := TakeRateLimitToken(ctx, tx, "test-bucket", "192.168.1.1", 0, 2)
+			if err != nil {
+				t.Fatalf("failed to take token: %v", err)
+			}
+			results = append(results, allowed)
+		}
+		otherKey, _, _ := TakeRateLimitToken(ctx, tx, "test-bucket", "192.168.1.2", 0, 2)
+		otherBucket, _, _ := TakeRateLimitToken(ctx, tx, "other-bucket", "192.168.1.1", 0, 2)
+
+		// Then: 2번만 허용, 다른 키와 bucket은 영향 없음
+		if !results[0] || !results[1] || results[2] {
+			t.Errorf("expected [true true false], got %v", results)
+		}
+		if !otherKey || !otherBucket {
+			t.Errorf("expected other key and bucket to be allowed, got %v, %v", otherKey, otherBucket)
+		}
+	})
+
+	t.Run("경과 시간만큼 충전, burst를 넘지 않음", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		// Given: 초당 1개 충전, burst 2인 버킷을 모두 소비한 뒤 마지막 갱신을 1시간 전으로 변경
+		for i := 0; i < 2; i++ {
+			TakeRateLimitToken(ctx, tx, "test-bucket", "192.168.1.1", 1, 2)
+		}
+		if _, err := tx.ExecContext(ctx, `UPDATE rate_limit_buckets SET updated_at = NOW() - INTERVAL '1 hour' WHERE bucket = 'test-bucket'`); err != nil {
+			t.Fatalf("failed to update bucket: %v", err)
+		}
+
+		// When: 3번 요청
+		var results []bool
+		for i := 0; i < 3; i++ {
+			allowed, _, err := TakeRateLimitToken(ctx, tx, "test-bucket", "192.168.1.1", 1, 2)
+			if err != nil {
+				t.Fatalf("failed to take token: %v", err)
+			}
+			results = append(results, allowed)
+		}
+
+		// Then: burst(2)까지만 충전되어 2번만 허용 (같은 트랜잭션 안에서는 NOW()가 고정되어 추가 충전 없음)
+		if !results[0] || !results[1] || results[2] {
+			t.Errorf("expected [true true false], got %v", results)
+		}
+	})
+
+	t.Run("처리 후 남은 토큰 수 반환", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		// When: 충전 속도 0, burst 2인 버킷에서 3번 요청
+		var remaining []float64
+		for i := 0; i < 3; i++ {
+			_, tokens, err := TakeRateLimitToken(ctx, tx, "test-bucket", "192.168.1.1", 0, 2)
+			if err != nil {
+				t.Fatalf("failed to take token: %v", err)
+			}
+			remaining = append(remaining, tokens)
+		}
+
+		// Then: 1, 0, 0 (거부 시에는 소비하지 않고 현재 토큰 수 반환)
+		if remaining[0] != 1 || remaining[1] != 0 || remaining[2] != 0 {
+			t.Errorf("expected [1 0 0], got %v", remaining)
+		}
+	})
+}
+
+// TestDeleteIdleRateLimitBuckets는 오래 요청이 없던 토큰 버킷 삭제를 테스트합니다
+func TestDeleteIdleRateLimitBuckets(t *testing.T) {
+	db := testhelpers.SetupTestDB(t)
+	defer Close(db)
+
+	ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+	defer cleanup()
+
+	// Given: 마지막 요청이 25시간 전인 버킷과 방금 요청한 버킷
+	for _, key := range []string{"192.168.1.1", "192.168.1.2"} {
+		if _, _, err := TakeRateLimitToken(ctx, tx, "idle-bucket", key, 0, 2); err != nil {
+			t.Fatalf("failed to take token: %v", err)
+		}
+	}
+	if _, err := tx.ExecContext(ctx, `UPDATE rate_limit_buckets SET updated_at = NOW() - INTERVAL '25 hours' WHERE bucket = 'idle-bucket' AND key = '192.168.1.1'`); err != nil {
+		t.Fatalf("failed to update bucket: %v", err)
+	}
+
+	// When
+	deleted, err := DeleteIdleRateLimitBuckets(ctx, tx)
+
+	// Then: 오래된 버킷만 삭제
+	if err != nil {
+		t.Fatalf("failed to delete idle buckets: %v", err)
+	}
+	if deleted < 1 {
+		t.Errorf("expected idle bucket to be deleted, got %d", deleted)
+	}
+	var remaining string
+	if err := tx.QueryRowContext(ctx, `SELECT string_agg(key, ',') FROM rate_limit_buckets WHERE bucket = 'idle-bucket'`).Scan(&remaining); err != nil {
+		t.Fatalf("failed to query buckets: %v", err)
+	}
+	if remaining != "192.168.1.2" {
+		t.Errorf("expected only recent bucket to remain, got %q", remaining)
+	}
+
+	// 삭제된 키는 가득 찬 버킷으로 다시 시작
+	if _, tokens, _ := TakeRateLimitToken(ctx, tx, "idle-bucket", "192.168.1.1", 0, 2); tokens != 1 {
+		t.Errorf("expected recreated bucket with 1 token left, got %v", tokens)
+	}
+}
+
+// TestUpdateSiteRateLimits는 사이트별 요청 제한 설정 변경을 테스트합니다
+func TestUpdateSiteRateLimits(t *testing.T) {
+	db := testhelpers.SetupTestDB(t)
+	defer Close(db)
+
+	t.Run("설정 저장 후 조회", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		// Given: 제한을 설정하지 않은 사이트
+		site := testhelpers.CreateTestSite(ctx, t, tx, "Rate Limit Site", "ratelimit.example.com", []string{"https://ratelimit.example.com"}, true)
+
+		// When: 댓글 작성 제한과 세션 단위 제한을 설정
+		limits := models.SiteRateLimits{
+			Create:       &models.RateLimit{PerMinute: 3, Burst: 1},
+			KeyBySession: true,
+		}
+		if err := UpdateSiteRateLimits(ctx, tx, site.ID, limits); err != nil {
+			t.Fatalf("failed to update rate limits: %v", err)
+		}
+
+		// Then: 설정한 작업은 사이트 제한, 나머지는 기본 제한
+		updated, err := GetSiteByID(ctx, tx, site.ID)
+		if err != nil {
+			t.Fatalf("failed to get site: %v", err)
+		}
+		if got := updated.RateLimits.For(models.RateLimitActionCreate); got != (models.RateLimit{PerMinute: 3, Burst: 1}) {
+			t.Errorf("expected create limit {3 1}, got %v", got)
+		}
+		if got := updated.RateLimits.For(models.RateLimitActionList); got != models.DefaultRateLimits[models.RateLimitActionList] {
+			t.Errorf("expected default list limit, got %v", got)
+		}
+		if !updated.RateLimits.KeyBySession {
+			t.Error("expected KeyBySession to be true")
+		}
+	})
+
+	t.Run("존재하지 않는 사이트", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		// When: 존재하지 않는 사이트 ID로 변경
+		err := UpdateSiteRateLimits(ctx, tx, 999999, models.SiteRateLimits{})
+
+		// Then: sql.ErrNoRows
+		if err != sql.ErrNoRows {
+			t.Errorf("expected sql.ErrNoRows, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,253 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/june20516/orbithall/internal/models"
+	"github.com/lib/pq"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// UpdateSiteRetention은 사이트의 개인정보 보관 기간을 변경합니다
+// personalDays: 댓글 작성 후 IP 주소/User-Agent 보관 일수 (0이면 무기한)
+// deletedDays: 삭제된 댓글의 작성자 이름/내용 보관 일수 (0이면 무기한)
+// 캐시된 사이트 정보는 모든 인스턴스에서 무효화됩니다
+func UpdateSiteRetention(ctx context.Context, db DBTX, siteID int64, personalDays, deletedDays int) error {
+	query := `
+		UPDATE sites
+		SET personal_data_retention_days = $1,
+			deleted_content_retention_days = $2,
+			updated_at = NOW()
+		WHERE id = $3
+	`
+
+	result, err := db.ExecContext(ctx, query, personalDays, deletedDays, siteID)
+	if err != nil {
+		return fmt.Errorf("failed to update site retention: %w", err)
+	}
+
+	// 영향받은 행 수 확인
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return notifySiteChanged(ctx, db, siteID)
+}
+
+// AnonymizeExpiredPersonalData는 사이트별 보관 기간이 지난 댓글의 IP 주소와 User-Agent를 삭제합니다
+// personal_data_retention_days가 0인 사이트는 대상에서 제외됩니다
+// 익명화된 댓글 수를 반환합니다
+func AnonymizeExpiredPersonalData(ctx context.Context, db DBTX) (int64, error) {
+	query := `
+		UPDATE comments c
+		SET ip_address = NULL,
+			ip_address_encrypted = NULL,
+			ip_address_hash = NULL,
+			ip_address_masked = NULL,
+			user_agent = NULL
+		FROM posts p
+		INNER JOIN sites s ON s.id = p.site_id
+		WHERE c.post_id = p.id
+		  AND s.personal_data_retention_days > 0
+		  AND (c.ip_address IS NOT NULL OR c.ip_address_encrypted IS NOT NULL OR c.user_agent IS NOT NULL)
+		  AND c.created_at < NOW() - make_interval(days => s.personal_data_retention_days)
+	`
+
+	result, err := db.ExecContext(ctx, query)
+	if err != nil {
+		return 0, fmt.Errorf("failed to anonymize expired personal data: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected, nil
+}
+
+// PurgeDeletedCommentContent는 삭제 후 사이트별 보관 기간이 지난 댓글의 내용과 작성자 정보를 비웁니다
+// 댓글 행은 대댓글 계층 구조 유지를 위해 남겨두고, 작성자 이름/비밀번호/내용/IP/User-Agent만 삭제합니다
+// deleted_content_retention_days가 0인 사이트는 대상에서 제외됩니다
+// 비워진 댓글 수를 반환합니다
+func PurgeDeletedCommentContent(ctx context.Context, db DBTX) (int64, error) {
+	query := `
+		UPDATE comments c
+		SET author_name = '',
+			author_password = '',
+			content = '',
+			ip_address = NULL,
+			ip_address_encrypted = NULL,
+			ip_address_hash = NULL,
+			ip_address_masked = NULL,
+			user_agent = NULL
+		FROM posts p
+		INNER JOIN sites s ON s.id = p.site_id
+		WHERE c.post_id = p.id
+		  AND c.is_deleted = TRUE
+		  AND s.deleted_content_retention_days > 0
+		  AND c.deleted_at < NOW() - make_interval(days => s.deleted_content_retention_days)
+		  AND (c.author_name <> '' OR c.author_password <> '' OR c.content <> ''
+		       OR c.ip_address IS NOT NULL OR c.ip_address_encrypted IS NOT NULL OR c.user_agent IS NOT NULL)
+	`
+
+	result, err := db.ExecContext(ctx, query)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge deleted comment content: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected, nil
+}
+
+// EraseComments는 사이트에서 조건에 일치하는 모든 댓글의 개인정보를 삭제합니다 (개인정보 삭제 요청 처리용)
+// 조건(authorName, ipAddress, password)은 비어있지 않은 것만 AND로 적용되며, 최소 하나는 있어야 합니다
+// ipAddress는 암호화된 값 대신 조회용 해시(ip_address_hash)로 비교합니다
+// password는 bcrypt 해시라 SQL로 비교할 수 없으므로 다른 조건으로 좁힌 후보를 하나씩 비교합니다
+//
+// hardDelete가 false면 일치한 댓글의 작성자 정보와 내용을 비우고 삭제 상태로 만듭니다
+// hardDelete가 true면 댓글 행을 완전히 삭제하되, 일치하지 않는 대댓글이 달린 댓글은
+// 다른 사람의 대댓글이 함께 삭제되지 않도록 익명화만 합니다
+// 영향받은 포스트의 comment_count는 다시 계산합니다
+//
+// dryRun이 true면 변경 없이 일치한 댓글만 보고합니다
+// 모든 작업은 하나의 트랜잭션에서 실행됩니다
+func EraseComments(ctx context.Context, db DBTX, siteID int64, authorName, ipAddress, password string, hardDelete, dryRun bool) (*models.CommentErasureReport, error) {
+	if authorName == "" && ipAddress == "" && password == "" {
+		return nil, fmt.Errorf("at least one erasure criterion is required")
+	}
+
+	report := &models.CommentErasureReport{
+		DryRun:     dryRun,
+		CommentIDs: []int64{},
+		PostIDs:    []int64{},
+	}
+
+	var ipHash string
+	if ipAddress != "" {
+		ipHash = HashIPAddress(ipAddress)
+	}
+
+	err := RunInTx(ctx, db, func(tx DBTX) error {
+		// 1. 조건에 일치하는 댓글 조회 및 잠금 (아직 암호화되지 않은 평문 IP도 비교)
+		rows, err := tx.QueryContext(ctx, `
+			SELECT c.id, c.post_id, c.author_password
+			FROM comments c
+			INNER JOIN posts p ON p.id = c.post_id
+			WHERE p.site_id = $1
+			  AND ($2 = '' OR c.author_name = $2)
+			  AND ($3 = '' OR c.ip_address_hash = $3 OR host(c.ip_address) = $4)
+			ORDER BY c.id
+			FOR UPDATE OF c
+		`, siteID, authorName, ipHash, ipAddress)
+		if err != nil {
+			return fmt.Errorf("failed to query comments for erasure: %w", err)
+		}
+		defer rows.Close()
+
+		seenPosts := make(map[int64]bool)
+		for rows.Next() {
+			var id, postID int64
+			var hashedPassword string
+			if err := rows.Scan(&id, &postID, &hashedPassword); err != nil {
+				return fmt.Errorf("failed to scan comment for erasure: %w", err)
+			}
+
+			// 2. 비밀번호 조건이 있으면 bcrypt로 비교 (가져온 댓글처럼 비밀번호가 없으면 제외)
+			if password != "" {
+				if hashedPassword == "" || bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(password)) != nil {
+					continue
+				}
+			}
+
+			report.CommentIDs = append(report.CommentIDs, id)
+			if !seenPosts[postID] {
+				seenPosts[postID] = true
+				report.PostIDs = append(report.PostIDs, postID)
+			}
+		}
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("error iterating comments for erasure: %w", err)
+		}
+
+		report.MatchedComments = len(report.CommentIDs)
+		if dryRun || report.MatchedComments == 0 {
+			return nil
+		}
+
+		// 3. 완전 삭제: 대댓글을 먼저 삭제한 뒤, 남은 대댓글이 없는 최상위 댓글 삭제
+		if hardDelete {
+			for _, query := range []string{
+				`DELETE FROM comments WHERE id = ANY($1) AND parent_id IS NOT NULL`,
+				`DELETE FROM comments c
+				 WHERE c.id = ANY($1)
+				   AND NOT EXISTS (SELECT 1 FROM comments r WHERE r.parent_id = c.id)`,
+			} {
+				result, err := tx.ExecContext(ctx, query, pq.Array(report.CommentIDs))
+				if err != nil {
+					return fmt.Errorf("failed to delete comments: %w", err)
+				}
+				deleted, err := result.RowsAffected()
+				if err != nil {
+					return fmt.Errorf("failed to get rows affected: %w", err)
+				}
+				report.DeletedComments += int(deleted)
+			}
+		}
+
+		// 4. 남은 댓글 익명화 (작성자 정보와 내용을 비우고 삭제 상태로 전환)
+		result, err := tx.ExecContext(ctx, `
+			UPDATE comments
+			SET author_name = '',
+				author_password = '',
+				content = '',
+				ip_address = NULL,
+				ip_address_encrypted = NULL,
+				ip_address_hash = NULL,
+				ip_address_masked = NULL,
+				user_agent = NULL,
+				is_deleted = TRUE,
+				deleted_at = COALESCE(deleted_at, CLOCK_TIMESTAMP())
+			WHERE id = ANY($1)
+		`, pq.Array(report.CommentIDs))
+		if err != nil {
+			return fmt.Errorf("failed to anonymize comments: %w", err)
+		}
+		anonymized, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to get rows affected: %w", err)
+		}
+		report.AnonymizedComments = int(anonymized)
+
+		// 5. 영향받은 포스트의 댓글 수 재계산 (삭제되지 않은 댓글 기준)
+		_, err = tx.ExecContext(ctx, `
+			UPDATE posts p
+			SET comment_count = (
+				SELECT COUNT(*) FROM comments c WHERE c.post_id = p.id AND c.is_deleted = FALSE
+			),
+			    updated_at = NOW()
+			WHERE p.id = ANY($1)
+		`, pq.Array(report.PostIDs))
+		if err != nil {
+			return fmt.Errorf("failed to recalculate comment counts: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
@@ -0,0 +1,191 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/june20516/orbithall/internal/testhelpers"
+)
+
+// TestApplyRetention은 보관 기간이 지난 개인정보 삭제를 테스트합니다
+func TestApplyRetention(t *testing.T) {
+	db := testhelpers.SetupTestDB(t)
+	defer Close(db)
+
+	t.Run("보관 기간이 지난 IP/User-Agent만 삭제", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		// Given: 보관 기간 30일인 사이트의 40일 전 댓글과 오늘 댓글, 보관 기간 없는 사이트의 40일 전 댓글
+		site := testhelpers.CreateTestSite(ctx, t, tx, "Retention Site", "retention.com", []string{"http://localhost:3000"}, true)
+		other := testhelpers.CreateTestSite(ctx, t, tx, "Forever Site", "forever.com", []string{"http://localhost:3000"}, true)
+		if err := UpdateSiteRetention(ctx, tx, site.ID, 30, 0); err != nil {
+			t.Fatalf("failed to update retention: %v", err)
+		}
+		post := testhelpers.CreateTestPost(ctx, t, tx, site.ID, "post", "Post")
+		otherPost := testhelpers.CreateTestPost(ctx, t, tx, other.ID, "post", "Post")
+
+		old, _ := CreateComment(ctx, tx, post.ID, nil, "홍길동", "test1234", "오래된 댓글", "127.0.0.1", "test-agent")
+		recent, _ := CreateComment(ctx, tx, post.ID, nil, "홍길동", "test1234", "최근 댓글", "127.0.0.1", "test-agent")
+		kept, _ := CreateComment(ctx, tx, otherPost.ID, nil, "홍길동", "test1234", "무기한 보관", "127.0.0.1", "test-agent")
+		if _, err := tx.ExecContext(ctx, "UPDATE comments SET created_at = NOW() - INTERVAL '40 days' WHERE id IN ($1, $2)", old.ID, kept.ID); err != nil {
+			t.Fatalf("failed to age comments: %v", err)
+		}
+
+		// When: 익명화 실행
+		anonymized, err := AnonymizeExpiredPersonalData(ctx, tx)
+
+		// Then: 보관 기간이 지난 댓글 1개만 익명화
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if anonymized != 1 {
+			t.Errorf("expected 1 anonymized comment, got %d", anonymized)
+		}
+		if c, _ := GetCommentByID(ctx, tx, old.ID); c.IPAddressEncrypted != "" || c.IPAddressMasked != "" || c.UserAgent != "" || c.Content != "오래된 댓글" {
+			t.Errorf("expected only personal data removed, got %+v", c)
+		}
+		for _, id := range []int64{recent.ID, kept.ID} {
+			if c, _ := GetCommentByID(ctx, tx, id); c.IPAddressEncrypted == "" {
+				t.Errorf("expected comment %d to keep ip address", id)
+			}
+		}
+	})
+
+	t.Run("보관 기간이 지난 삭제된 댓글의 내용 비움", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		// Given: 삭제 후 보관 기간 7일인 사이트의 10일 전 삭제된 댓글과 삭제되지 않은 댓글
+		site := testhelpers.CreateTestSite(ctx, t, tx, "Purge Site", "purge.com", []string{"http://localhost:3000"}, true)
+		if err := UpdateSiteRetention(ctx, tx, site.ID, 0, 7); err != nil {
+			t.Fatalf("failed to update retention: %v", err)
+		}
+		post := testhelpers.CreateTestPost(ctx, t, tx, site.ID, "post", "Post")
+		deleted, _ := CreateComment(ctx, tx, post.ID, nil, "홍길동", "test1234", "삭제된 댓글", "127.0.0.1", "test-agent")
+		active, _ := CreateComment(ctx, tx, post.ID, nil, "김철수", "test1234", "댓글", "127.0.0.1", "test-agent")
+		if err := DeleteComment(ctx, tx, deleted.ID); err != nil {
+			t.Fatalf("failed to delete comment: %v", err)
+		}
+		if _, err := tx.ExecContext(ctx, "UPDATE comments SET deleted_at = NOW() - INTERVAL '10 days' WHERE id = $1", deleted.ID); err != nil {
+			t.Fatalf("failed to age comment: %v", err)
+		}
+
+		// When: 삭제된 댓글 정리 실행 (두 번 실행해도 다시 처리하지 않음)
+		purged, err := PurgeDeletedCommentContent(ctx, tx)
+		again, _ := PurgeDeletedCommentContent(ctx, tx)
+
+		// Then: 삭제된 댓글만 비워짐
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if purged != 1 || again != 0 {
+			t.Errorf("expected 1 then 0 purged comments, got %d then %d", purged, again)
+		}
+		c, _ := GetCommentByID(ctx, tx, deleted.ID)
+		if c.AuthorName != "" || c.AuthorPassword != "" || c.Content != "" || c.IPAddressEncrypted != "" {
+			t.Errorf("expected deleted comment to be purged, got %+v", c)
+		}
+		if c, _ := GetCommentByID(ctx, tx, active.ID); c.Content != "댓글" {
+			t.Errorf("expected active comment to be kept, got %+v", c)
+		}
+	})
+}
+
+// TestEraseComments는 개인정보 삭제 요청 처리를 테스트합니다
+func TestEraseComments(t *testing.T) {
+	db := testhelpers.SetupTestDB(t)
+	defer Close(db)
+
+	t.Run("dry run은 대상만 보고", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		// Given: 홍길동의 댓글 2개와 다른 사람의 댓글 1개
+		site := testhelpers.CreateTestSite(ctx, t, tx, "Erase Site", "erase.com", []string{"http://localhost:3000"}, true)
+		post := testhelpers.CreateTestPost(ctx, t, tx, site.ID, "post", "Post")
+		CreateComment(ctx, tx, post.ID, nil, "홍길동", "test1234", "댓글 1", "10.0.0.1", "test")
+		CreateComment(ctx, tx, post.ID, nil, "홍길동", "other5678", "댓글 2", "10.0.0.2", "test")
+		CreateComment(ctx, tx, post.ID, nil, "김철수", "test1234", "댓글 3", "10.0.0.1", "test")
+
+		// When: 작성자 이름으로 dry run
+		report, err := EraseComments(ctx, tx, site.ID, "홍길동", "", "", false, true)
+
+		// Then: 2개 일치, 변경 없음
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if report.MatchedComments != 2 || report.AnonymizedComments != 0 || report.DeletedComments != 0 {
+			t.Errorf("unexpected report: %+v", report)
+		}
+		if stored, _ := GetPostByID(ctx, tx, post.ID); stored.CommentCount != 3 {
+			t.Errorf("expected comment_count=3, got %d", stored.CommentCount)
+		}
+	})
+
+	t.Run("작성자 이름과 비밀번호로 익명화", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		// Given: 같은 이름, 다른 비밀번호의 댓글
+		site := testhelpers.CreateTestSite(ctx, t, tx, "Erase Site", "erase-password.com", []string{"http://localhost:3000"}, true)
+		post := testhelpers.CreateTestPost(ctx, t, tx, site.ID, "post", "Post")
+		mine, _ := CreateComment(ctx, tx, post.ID, nil, "홍길동", "test1234", "내 댓글", "10.0.0.1", "test")
+		other, _ := CreateComment(ctx, tx, post.ID, nil, "홍길동", "other5678", "동명이인 댓글", "10.0.0.2", "test")
+
+		// When: 이름과 비밀번호로 익명화
+		report, err := EraseComments(ctx, tx, site.ID, "홍길동", "", "test1234", false, false)
+
+		// Then: 비밀번호가 일치한 댓글만 익명화, 댓글 수 재계산
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if report.MatchedComments != 1 || report.AnonymizedComments != 1 || report.CommentIDs[0] != mine.ID {
+			t.Errorf("unexpected report: %+v", report)
+		}
+		c, _ := GetCommentByID(ctx, tx, mine.ID)
+		if !c.IsDeleted || c.AuthorName != "" || c.Content != "" || c.IPAddressEncrypted != "" {
+			t.Errorf("expected anonymized comment, got %+v", c)
+		}
+		if c, _ := GetCommentByID(ctx, tx, other.ID); c.IsDeleted {
+			t.Error("expected other comment to be kept")
+		}
+		if stored, _ := GetPostByID(ctx, tx, post.ID); stored.CommentCount != 1 {
+			t.Errorf("expected comment_count=1, got %d", stored.CommentCount)
+		}
+	})
+
+	t.Run("IP로 완전 삭제 시 다른 사람의 대댓글이 달린 댓글은 익명화", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		// Given: 같은 IP의 댓글 2개, 그중 하나에 다른 사람의 대댓글
+		site := testhelpers.CreateTestSite(ctx, t, tx, "Erase Site", "erase-delete.com", []string{"http://localhost:3000"}, true)
+		post := testhelpers.CreateTestPost(ctx, t, tx, site.ID, "post", "Post")
+		parent, _ := CreateComment(ctx, tx, post.ID, nil, "홍길동", "test1234", "댓글", "10.0.0.1", "test")
+		reply, _ := CreateComment(ctx, tx, post.ID, &parent.ID, "김철수", "test1234", "대댓글", "10.0.0.9", "test")
+		single, _ := CreateComment(ctx, tx, post.ID, nil, "홍길동", "test1234", "단독 댓글", "10.0.0.1", "test")
+
+		// When: IP로 완전 삭제
+		report, err := EraseComments(ctx, tx, site.ID, "", "10.0.0.1", "", true, false)
+
+		// Then: 단독 댓글은 삭제, 대댓글이 달린 댓글은 익명화, 다른 사람의 대댓글은 유지
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if report.MatchedComments != 2 || report.DeletedComments != 1 || report.AnonymizedComments != 1 {
+			t.Errorf("unexpected report: %+v", report)
+		}
+		if c, _ := GetCommentByID(ctx, tx, single.ID); c != nil {
+			t.Errorf("expected single comment to be deleted, got %+v", c)
+		}
+		if c, _ := GetCommentByID(ctx, tx, parent.ID); c == nil || !c.IsDeleted || c.Content != "" {
+			t.Errorf("expected parent comment to be anonymized, got %+v", c)
+		}
+		if c, _ := GetCommentByID(ctx, tx, reply.ID); c == nil || c.IsDeleted {
+			t.Errorf("expected reply to be kept, got %+v", c)
+		}
+		if stored, _ := GetPostByID(ctx, tx, post.ID); stored.CommentCount != 1 {
+			t.Errorf("expected comment_count=1, got %d", stored.CommentCount)
+		}
+	})
+}
@@ -0,0 +1,314 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/june20516/orbithall/internal/models"
+)
+
+// sessionColumns는 세션 조회 시 사용하는 컬럼 목록입니다 (scanSession과 순서 일치)
+const sessionColumns = `id, user_id, user_agent, ip_address_masked, created_at, last_used_at, expires_at, revoked_at`
+
+// sessionActive는 로그아웃되지 않고 만료되지 않은 세션 조건입니다
+const sessionActive = `revoked_at IS NULL AND expires_at > NOW()`
+
+// expiredSessionRetention은 만료되거나 로그아웃된 세션을 DeleteExpiredSessions로 삭제하기 전까지 보관하는 기간입니다
+const expiredSessionRetention = 30 * 24 * time.Hour
+
+// scanSession은 sessionColumns 순서로 조회한 행을 Session으로 변환합니다
+func scanSession(row rowScanner) (*models.Session, error) {
+	var session models.Session
+	var revokedAt sql.NullTime
+	err := row.Scan(
+		&session.ID,
+		&session.UserID,
+		&session.UserAgent,
+		&session.IPAddress,
+		&session.CreatedAt,
+		&session.LastUsedAt,
+		&session.ExpiresAt,
+		&revokedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if revokedAt.Valid {
+		session.RevokedAt = &revokedAt.Time
+	}
+
+	return &session, nil
+}
+
+// CreateSession은 로그인 세션을 만들고 refresh token을 발급합니다
+// session의 UserID, UserAgent, IPAddress, ExpiresAt을 채워 전달하면 생성된 ID, refresh token, 시각을 채웁니다
+// IP 주소는 마스킹해 저장합니다
+func CreateSession(ctx context.Context, db DBTX, session *models.Session) error {
+	token := models.GenerateRefreshToken()
+	query := `
+		INSERT INTO sessions (user_id, refresh_token_hash, user_agent, ip_address_masked, expires_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING ` + sessionColumns
+
+	created, err := scanSession(db.QueryRowContext(ctx, query,
+		session.UserID,
+		models.HashRefreshToken(token),
+		session.UserAgent,
+		models.MaskIPAddress(session.IPAddress),
+		session.ExpiresAt,
+	))
+	if err != nil {
+		return fmt.Errorf("failed to create session: %w", err)
+	}
+
+	*session = *created
+	session.RefreshToken = token
+	return nil
+}
+
+// RefreshSession은 refresh token으로 세션을 갱신합니다
+// refresh token을 새로 발급해 교체하고(이전 토큰은 더 이상 사용 불가), 클라이언트 정보와 마지막 사용 시각, 만료 시각을 갱신합니다
+// 만료 시각은 expiresAt으로 연장하되, 세션을 만든 시각부터 maxLifetime이 지난 시각을 넘지 않습니다
+// 토큰에 해당하는 세션이 없으면 sql.ErrNoRows, 로그아웃되었거나 만료된 세션이면 ErrSessionInactive를 반환합니다
+// 세션에서 이미 교체된 토큰이 다시 사용되면 (몇 번 전에 교체된 토큰이든) 토큰이 탈취된 것으로 보고 세션을 폐기한 뒤 ErrRefreshTokenReused를 반환합니다
+func RefreshSession(ctx context.Context, db DBTX, refreshToken, userAgent, ipAddress string, expiresAt time.Time, maxLifetime time.Duration) (*models.Session, error) {
+	tokenHash := models.HashRefreshToken(refreshToken)
+
+	var refreshed *models.Session
+	err := RunInTx(ctx, db, func(tx DBTX) error {
+		// 동시에 같은 토큰으로 두 번 갱신하지 않도록 세션 행을 잠금
+		query := `
+			SELECT ` + sessionColumns + `
+			FROM sessions
+			WHERE refresh_token_hash = $1
+			FOR UPDATE
+		`
+
+		session, err := scanSession(tx.QueryRowContext(ctx, query, tokenHash))
+		if err == sql.ErrNoRows {
+			return sql.ErrNoRows
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get session: %w", err)
+		}
+
+		if session.RevokedAt != nil || !time.Now().Before(session.ExpiresAt) {
+			return ErrSessionInactive
+		}
+
+		// 교체되는 토큰을 기록 (다시 사용되면 재사용으로 감지)
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO session_refresh_tokens (token_hash, session_id)
+			VALUES ($1, $2)
+		`, tokenHash, session.ID)
+		if err != nil {
+			return fmt.Errorf("failed to record rotated refresh token: %w", err)
+		}
+
+		token := models.GenerateRefreshToken()
+		query = `
+			UPDATE sessions
+			SET refresh_token_hash = $2,
+				user_agent = $3,
+				ip_address_masked = $4,
+				last_used_at = NOW(),
+				expires_at = LEAST($5, created_at + make_interval(secs => $6))
+			WHERE id = $1
+			RETURNING ` + sessionColumns
+
+		refreshed, err = scanSession(tx.QueryRowContext(ctx, query,
+			session.ID,
+			models.HashRefreshToken(token),
+			userAgent,
+			models.MaskIPAddress(ipAddress),
+			expiresAt,
+			maxLifetime.Seconds(),
+		))
+		if err != nil {
+			return fmt.Errorf("failed to refresh session: %w", err)
+		}
+		refreshed.RefreshToken = token
+		return nil
+	})
+	if err == sql.ErrNoRows {
+		// 세션 폐기가 롤백되지 않도록 트랜잭션 밖에서 재사용 여부 확인
+		return nil, revokeReusedRefreshToken(ctx, db, tokenHash)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return refreshed, nil
+}
+
+// revokeReusedRefreshToken은 현재 토큰과 일치하는 세션이 없을 때 세션에서 이미 교체된 토큰인지 확인합니다
+// 교체된 토큰이면 세션을 폐기하고 ErrRefreshTokenReused를, 아니면 sql.ErrNoRows를 반환합니다
+func revokeReusedRefreshToken(ctx context.Context, db DBTX, tokenHash string) error {
+	result, err := db.ExecContext(ctx, `
+		UPDATE sessions
+		SET revoked_at = COALESCE(revoked_at, NOW())
+		WHERE id = (SELECT session_id FROM session_refresh_tokens WHERE token_hash = $1)
+	`, tokenHash)
+	if err != nil {
+		return fmt.Errorf("failed to revoke reused session: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected > 0 {
+		return ErrRefreshTokenReused
+	}
+	return sql.ErrNoRows
+}
+
+// GetActiveSessionUser는 로그아웃되지 않고 만료되지 않은 세션의 사용자를 조회합니다
+// 세션이 없거나, userID의 세션이 아니거나, 로그아웃/만료된 세션이면 nil을 반환합니다
+func GetActiveSessionUser(ctx context.Context, db DBTX, sessionID, userID int64) (*models.User, error) {
+	query := `
+		SELECT u.id, u.email, u.email_verified, u.name, u.picture_url, u.created_at, u.updated_at
+		FROM sessions s
+		INNER JOIN users u ON u.id = s.user_id
+		WHERE s.id = $1 AND s.user_id = $2 AND s.revoked_at IS NULL AND s.expires_at > NOW()
+	`
+
+	var user models.User
+	err := db.QueryRowContext(ctx, query, sessionID, userID).Scan(
+		&user.ID,
+		&user.Email,
+		&user.EmailVerified,
+		&user.Name,
+		&user.PictureURL,
+		&user.CreatedAt,
+		&user.UpdatedAt,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, nil // 유효한 세션이 없는 경우 nil 반환
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get session user: %w", err)
+	}
+
+	return &user, nil
+}
+
+// ListActiveSessions는 사용자의 로그아웃되지 않고 만료되지 않은 세션을 최근 사용순으로 조회합니다
+func ListActiveSessions(ctx context.Context, db DBTX, userID int64) ([]models.Session, error) {
+	query := `
+		SELECT ` + sessionColumns + `
+		FROM sessions
+		WHERE user_id = $1 AND ` + sessionActive + `
+		ORDER BY last_used_at DESC, id DESC
+	`
+
+	rows, err := db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query sessions: %w", err)
+	}
+	defer rows.Close()
+
+	sessions := []models.Session{}
+	for rows.Next() {
+		session, err := scanSession(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan session: %w", err)
+		}
+		sessions = append(sessions, *session)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating session rows: %w", err)
+	}
+
+	return sessions, nil
+}
+
+// RevokeSession은 사용자의 세션을 로그아웃합니다
+// 세션이 없거나, 다른 사용자의 세션이거나, 이미 로그아웃/만료된 세션이면 sql.ErrNoRows를 반환합니다
+func RevokeSession(ctx context.Context, db DBTX, userID, sessionID int64) error {
+	result, err := db.ExecContext(ctx, `
+		UPDATE sessions
+		SET revoked_at = NOW()
+		WHERE id = $1 AND user_id = $2 AND `+sessionActive,
+		sessionID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to revoke session: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// RevokeSessionByRefreshToken은 refresh token의 세션을 로그아웃합니다
+// 토큰에 해당하는 유효한 세션이 없으면 sql.ErrNoRows를 반환합니다
+func RevokeSessionByRefreshToken(ctx context.Context, db DBTX, refreshToken string) error {
+	result, err := db.ExecContext(ctx, `
+		UPDATE sessions
+		SET revoked_at = NOW()
+		WHERE refresh_token_hash = $1 AND `+sessionActive,
+		models.HashRefreshToken(refreshToken))
+	if err != nil {
+		return fmt.Errorf("failed to revoke session: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// RevokeAllSessions는 사용자의 모든 세션을 로그아웃하고 로그아웃된 세션 수를 반환합니다
+func RevokeAllSessions(ctx context.Context, db DBTX, userID int64) (int64, error) {
+	result, err := db.ExecContext(ctx, `
+		UPDATE sessions
+		SET revoked_at = NOW()
+		WHERE user_id = $1 AND `+sessionActive,
+		userID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected, nil
+}
+
+// DeleteExpiredSessions는 만료되거나 로그아웃된 지 30일이 지난 세션을 삭제하고 삭제한 세션 수를 반환합니다
+// (PostgreSQL의 LEAST는 NULL을 무시하므로 로그아웃되지 않은 세션은 만료 시각으로 비교)
+// 보관 기간 동안은 이미 교체된 refresh token의 재사용을 감지할 수 있습니다 (교체된 토큰 기록은 세션과 함께 삭제)
+func DeleteExpiredSessions(ctx context.Context, db DBTX) (int64, error) {
+	result, err := db.ExecContext(ctx, `
+		DELETE FROM sessions
+		WHERE LEAST(revoked_at, expires_at) < $1
+	`, time.Now().Add(-expiredSessionRetention))
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired sessions: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected, nil
+}
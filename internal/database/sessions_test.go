@@ -0,0 +1,209 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/june20516/orbithall/internal/models"
+	"github.com/june20516/orbithall/internal/testhelpers"
+)
+
+// TestRefreshSession은 refresh token 교체와 재사용 감지를 테스트합니다
+func TestRefreshSession(t *testing.T) {
+	db := testhelpers.SetupTestDB(t)
+	defer Close(db)
+
+	t.Run("갱신하면 refresh token 교체", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		// Given: 로그인 세션
+		user := &models.User{Email: "session-refresh@example.com", Name: "Refresh"}
+		if err := CreateUser(ctx, tx, user); err != nil {
+			t.Fatalf("Failed to create user: %v", err)
+		}
+		session := &models.Session{UserID: user.ID, UserAgent: "agent-1", IPAddress: "203.0.113.7", ExpiresAt: time.Now().Add(time.Hour)}
+		if err := CreateSession(ctx, tx, session); err != nil {
+			t.Fatalf("Failed to create session: %v", err)
+		}
+		if session.ID == 0 || session.RefreshToken == "" || session.IPAddress == "203.0.113.7" {
+			t.Fatalf("Unexpected session: %+v", session)
+		}
+
+		// When
+		refreshed, err := RefreshSession(ctx, tx, session.RefreshToken, "agent-2", "198.51.100.1", time.Now().Add(2*time.Hour), 24*time.Hour)
+
+		// Then: 같은 세션, 새 토큰, 클라이언트 정보 갱신
+		if err != nil {
+			t.Fatalf("Failed to refresh session: %v", err)
+		}
+		if refreshed.ID != session.ID || refreshed.RefreshToken == session.RefreshToken || refreshed.UserAgent != "agent-2" {
+			t.Errorf("Unexpected refreshed session: %+v", refreshed)
+		}
+
+		// 새 토큰으로 다시 갱신 가능
+		if _, err := RefreshSession(ctx, tx, refreshed.RefreshToken, "agent-2", "198.51.100.1", time.Now().Add(2*time.Hour), 24*time.Hour); err != nil {
+			t.Errorf("Expected new token to refresh, got %v", err)
+		}
+	})
+
+	t.Run("교체된 토큰을 다시 사용하면 세션 폐기", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		// Given: 한 번 갱신한 세션
+		user := &models.User{Email: "session-reuse@example.com", Name: "Reuse"}
+		if err := CreateUser(ctx, tx, user); err != nil {
+			t.Fatalf("Failed to create user: %v", err)
+		}
+		session := &models.Session{UserID: user.ID, ExpiresAt: time.Now().Add(time.Hour)}
+		if err := CreateSession(ctx, tx, session); err != nil {
+			t.Fatalf("Failed to create session: %v", err)
+		}
+		refreshed, err := RefreshSession(ctx, tx, session.RefreshToken, "", "", time.Now().Add(time.Hour), 24*time.Hour)
+		if err != nil {
+			t.Fatalf("Failed to refresh session: %v", err)
+		}
+
+		// When: 이전 토큰 재사용
+		_, err = RefreshSession(ctx, tx, session.RefreshToken, "", "", time.Now().Add(time.Hour), 24*time.Hour)
+
+		// Then: ErrRefreshTokenReused, 새 토큰도 사용 불가
+		if !errors.Is(err, ErrRefreshTokenReused) {
+			t.Errorf("Expected ErrRefreshTokenReused, got %v", err)
+		}
+		if _, err := RefreshSession(ctx, tx, refreshed.RefreshToken, "", "", time.Now().Add(time.Hour), 24*time.Hour); !errors.Is(err, ErrSessionInactive) {
+			t.Errorf("Expected ErrSessionInactive after reuse, got %v", err)
+		}
+		if found, _ := GetActiveSessionUser(ctx, tx, session.ID, user.ID); found != nil {
+			t.Errorf("Expected session to be revoked, got user %+v", found)
+		}
+	})
+
+	t.Run("여러 번 전에 교체된 토큰을 다시 사용해도 세션 폐기", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		// Given: 두 번 갱신한 세션
+		user := &models.User{Email: "session-reuse-old@example.com", Name: "Reuse"}
+		if err := CreateUser(ctx, tx, user); err != nil {
+			t.Fatalf("Failed to create user: %v", err)
+		}
+		session := &models.Session{UserID: user.ID, ExpiresAt: time.Now().Add(time.Hour)}
+		if err := CreateSession(ctx, tx, session); err != nil {
+			t.Fatalf("Failed to create session: %v", err)
+		}
+		token := session.RefreshToken
+		for i := 0; i < 2; i++ {
+			refreshed, err := RefreshSession(ctx, tx, token, "", "", time.Now().Add(time.Hour), 24*time.Hour)
+			if err != nil {
+				t.Fatalf("Failed to refresh session: %v", err)
+			}
+			token = refreshed.RefreshToken
+		}
+
+		// When: 처음 발급된 토큰 재사용
+		_, err := RefreshSession(ctx, tx, session.RefreshToken, "", "", time.Now().Add(time.Hour), 24*time.Hour)
+
+		// Then: ErrRefreshTokenReused, 현재 토큰도 사용 불가
+		if !errors.Is(err, ErrRefreshTokenReused) {
+			t.Errorf("Expected ErrRefreshTokenReused, got %v", err)
+		}
+		if _, err := RefreshSession(ctx, tx, token, "", "", time.Now().Add(time.Hour), 24*time.Hour); !errors.Is(err, ErrSessionInactive) {
+			t.Errorf("Expected ErrSessionInactive after reuse, got %v", err)
+		}
+	})
+
+	t.Run("갱신해도 최대 유지 기간을 넘겨 연장되지 않음", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		// Given: 로그인 세션
+		user := &models.User{Email: "session-lifetime@example.com", Name: "Lifetime"}
+		if err := CreateUser(ctx, tx, user); err != nil {
+			t.Fatalf("Failed to create user: %v", err)
+		}
+		session := &models.Session{UserID: user.ID, ExpiresAt: time.Now().Add(time.Hour)}
+		if err := CreateSession(ctx, tx, session); err != nil {
+			t.Fatalf("Failed to create session: %v", err)
+		}
+
+		// When: 최대 유지 기간(2시간)보다 긴 기간으로 갱신
+		refreshed, err := RefreshSession(ctx, tx, session.RefreshToken, "", "", time.Now().Add(30*24*time.Hour), 2*time.Hour)
+
+		// Then: 만료 시각은 세션을 만든 시각부터 2시간
+		if err != nil {
+			t.Fatalf("Failed to refresh session: %v", err)
+		}
+		if want := session.CreatedAt.Add(2 * time.Hour); !refreshed.ExpiresAt.Equal(want) {
+			t.Errorf("Expected expires_at %v, got %v", want, refreshed.ExpiresAt)
+		}
+	})
+
+	t.Run("알 수 없는 토큰", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		_, err := RefreshSession(ctx, tx, models.GenerateRefreshToken(), "", "", time.Now().Add(time.Hour), 24*time.Hour)
+		if err != sql.ErrNoRows {
+			t.Errorf("Expected sql.ErrNoRows, got %v", err)
+		}
+	})
+}
+
+// TestRevokeSession은 세션 로그아웃을 테스트합니다
+func TestRevokeSession(t *testing.T) {
+	db := testhelpers.SetupTestDB(t)
+	defer Close(db)
+
+	ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+	defer cleanup()
+
+	// Given: 세션 세 개
+	user := &models.User{Email: "session-revoke@example.com", Name: "Revoke"}
+	if err := CreateUser(ctx, tx, user); err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+	sessions := make([]*models.Session, 3)
+	for i := range sessions {
+		sessions[i] = &models.Session{UserID: user.ID, ExpiresAt: time.Now().Add(time.Hour)}
+		if err := CreateSession(ctx, tx, sessions[i]); err != nil {
+			t.Fatalf("Failed to create session: %v", err)
+		}
+	}
+
+	// 유효한 세션의 사용자 조회
+	if found, err := GetActiveSessionUser(ctx, tx, sessions[0].ID, user.ID); err != nil || found == nil || found.ID != user.ID {
+		t.Fatalf("Expected active session user, got %+v (err: %v)", found, err)
+	}
+	if found, _ := GetActiveSessionUser(ctx, tx, sessions[0].ID, user.ID+1); found != nil {
+		t.Errorf("Expected nil for another user's session, got %+v", found)
+	}
+
+	// ID로 로그아웃 (두 번째는 sql.ErrNoRows)
+	if err := RevokeSession(ctx, tx, user.ID, sessions[0].ID); err != nil {
+		t.Fatalf("Failed to revoke session: %v", err)
+	}
+	if err := RevokeSession(ctx, tx, user.ID, sessions[0].ID); err != sql.ErrNoRows {
+		t.Errorf("Expected sql.ErrNoRows on second revoke, got %v", err)
+	}
+	if found, _ := GetActiveSessionUser(ctx, tx, sessions[0].ID, user.ID); found != nil {
+		t.Errorf("Expected nil for revoked session, got %+v", found)
+	}
+
+	// refresh token으로 로그아웃
+	if err := RevokeSessionByRefreshToken(ctx, tx, sessions[1].RefreshToken); err != nil {
+		t.Fatalf("Failed to revoke session by refresh token: %v", err)
+	}
+
+	// 남은 세션 모두 로그아웃
+	revoked, err := RevokeAllSessions(ctx, tx, user.ID)
+	if err != nil || revoked != 1 {
+		t.Errorf("Expected 1 session revoked, got %d (err: %v)", revoked, err)
+	}
+	if active, _ := ListActiveSessions(ctx, tx, user.ID); len(active) != 0 {
+		t.Errorf("Expected no active sessions, got %+v", active)
+	}
+}
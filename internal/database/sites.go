@@ -10,58 +10,76 @@ import (
 )
 
 // CreateSiteForUser는 사이트를 생성하고 사용자를 owner로 연결합니다
-// 트랜잭션 내에서 두 작업을 수행하여 원자성을 보장합니다
-// API Key는 자동으로 생성됩니다 (orb_live_ prefix)
+// 트랜잭션 내에서 모든 작업을 수행하여 원자성을 보장합니다
+// 기본 API Key("default" 라벨)가 함께 발급되어 site.APIKey에 채워집니다 (orb_live_ prefix)
+// 도메인 검증 토큰은 DB에서 생성되어 site.VerificationToken에 채워집니다
+// 검증되지 않은 사이트(site.IsVerified가 false)는 활성화할 수 없습니다 (sites_active_requires_verified 제약조건)
 func CreateSiteForUser(ctx context.Context, db DBTX, site *models.Site, userID int64) error {
-	// API Key 자동 생성
-	if site.APIKey == "" {
-		site.APIKey = models.GenerateAPIKey("orb_live_")
-	}
-
-	// 사이트 생성
-	query := `
-		INSERT INTO sites (name, domain, api_key, cors_origins, is_active)
-		VALUES ($1, $2, $3, $4, $5)
-		RETURNING id, created_at, updated_at
-	`
-
-	err := db.QueryRowContext(ctx, query,
-		site.Name,
-		site.Domain,
-		site.APIKey,
-		pq.Array(site.CORSOrigins),
-		site.IsActive,
-	).Scan(&site.ID, &site.CreatedAt, &site.UpdatedAt)
+	return RunInTx(ctx, db, func(tx DBTX) error {
+		// 사이트 생성
+		query := `
+			INSERT INTO sites (name, domain, cors_origins, is_active, is_verified, verified_at)
+			VALUES ($1, $2, $3, $4, $5, CASE WHEN $5 THEN NOW() END)
+			RETURNING id, verification_token, verified_at, created_at, updated_at
+		`
+
+		err := tx.QueryRowContext(ctx, query,
+			site.Name,
+			site.Domain,
+			pq.Array(site.CORSOrigins),
+			site.IsActive,
+			site.IsVerified,
+		).Scan(&site.ID, &site.VerificationToken, &site.VerifiedAt, &site.CreatedAt, &site.UpdatedAt)
+
+		if err != nil {
+			return fmt.Errorf("failed to create site: %w", err)
+		}
 
-	if err != nil {
-		return fmt.Errorf("failed to create site: %w", err)
-	}
+		// 기본 API Key 발급 (site.APIKey가 지정되어 있으면 그 값 사용)
+		key := &models.SiteAPIKey{
+			SiteID: site.ID,
+			Label:  "default",
+			Key:    site.APIKey,
+		}
+		if err := CreateSiteAPIKey(ctx, tx, key); err != nil {
+			return err
+		}
+		site.APIKey = key.Key
 
-	// 사용자를 사이트에 owner로 연결
-	if err := AddUserToSite(ctx, db, userID, site.ID, "owner"); err != nil {
-		return fmt.Errorf("failed to add user to site: %w", err)
-	}
+		// 사용자를 사이트에 owner로 연결
+		if err := AddUserToSite(ctx, tx, userID, site.ID, "owner"); err != nil {
+			return fmt.Errorf("failed to add user to site: %w", err)
+		}
 
-	return nil
+		return nil
+	})
 }
 
 // GetSiteByID는 ID로 사이트를 조회합니다
 // 사이트가 존재하지 않으면 sql.ErrNoRows를 반환합니다
 func GetSiteByID(ctx context.Context, db DBTX, siteID int64) (*models.Site, error) {
 	query := `
-		SELECT id, name, domain, api_key, cors_origins, is_active, created_at, updated_at
+		SELECT id, name, domain, cors_origins, is_active, is_verified, verification_token, verified_at,
+			auto_close_days, personal_data_retention_days, deleted_content_retention_days, rate_limits, created_at, updated_at
 		FROM sites
 		WHERE id = $1
 	`
 
 	site := &models.Site{}
+	var rateLimits []byte
 	err := db.QueryRowContext(ctx, query, siteID).Scan(
 		&site.ID,
 		&site.Name,
 		&site.Domain,
-		&site.APIKey,
 		pq.Array(&site.CORSOrigins),
 		&site.IsActive,
+		&site.IsVerified,
+		&site.VerificationToken,
+		&site.VerifiedAt,
+		&site.AutoCloseDays,
+		&site.PersonalDataRetentionDays,
+		&site.DeletedContentRetentionDays,
+		&rateLimits,
 		&site.CreatedAt,
 		&site.UpdatedAt,
 	)
@@ -73,12 +91,17 @@ func GetSiteByID(ctx context.Context, db DBTX, siteID int64) (*models.Site, erro
 		return nil, fmt.Errorf("failed to get site: %w", err)
 	}
 
+	if err := unmarshalRateLimits(rateLimits, &site.RateLimits); err != nil {
+		return nil, err
+	}
+
 	return site, nil
 }
 
 // UpdateSite는 사이트 정보를 수정합니다
 // name, cors_origins, is_active 필드만 수정 가능합니다
-// domain과 api_key는 수정 불가능합니다
+// domain은 수정 불가능하며, API 키는 site_api_keys에서 따로 관리합니다
+// 캐시된 사이트 정보는 모든 인스턴스에서 무효화됩니다
 func UpdateSite(ctx context.Context, db DBTX, siteID int64, name string, corsOrigins []string, isActive bool) error {
 	query := `
 		UPDATE sites
@@ -107,11 +130,40 @@ func UpdateSite(ctx context.Context, db DBTX, siteID int64, name string, corsOri
 		return sql.ErrNoRows
 	}
 
-	return nil
+	return notifySiteChanged(ctx, db, siteID)
+}
+
+// UpdateSiteAutoCloseDays는 사이트의 댓글 자동 마감 기간을 변경합니다
+// 0이면 자동 마감하지 않습니다
+// 캐시된 사이트 정보는 모든 인스턴스에서 무효화됩니다
+func UpdateSiteAutoCloseDays(ctx context.Context, db DBTX, siteID int64, days int) error {
+	query := `
+		UPDATE sites
+		SET auto_close_days = $1, updated_at = NOW()
+		WHERE id = $2
+	`
+
+	result, err := db.ExecContext(ctx, query, days, siteID)
+	if err != nil {
+		return fmt.Errorf("failed to update site auto close days: %w", err)
+	}
+
+	// 영향받은 행 수 확인
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return notifySiteChanged(ctx, db, siteID)
 }
 
 // DeleteSite는 사이트를 삭제합니다
 // CASCADE 설정으로 인해 연결된 posts, comments, user_sites도 자동 삭제됩니다
+// 캐시된 사이트 정보는 모든 인스턴스에서 무효화됩니다
 func DeleteSite(ctx context.Context, db DBTX, siteID int64) error {
 	query := `
 		DELETE FROM sites
@@ -133,12 +185,13 @@ func DeleteSite(ctx context.Context, db DBTX, siteID int64) error {
 		return sql.ErrNoRows
 	}
 
-	return nil
+	return notifySiteChanged(ctx, db, siteID)
 }
 
 // GetSiteStats는 사이트의 통계 정보를 조회합니다
 // Post 수, 활성 댓글 수, 삭제된 댓글 수를 반환합니다
-func GetSiteStats(ctx context.Context, db DBTX, siteID int64) (*models.SiteStats, error) {
+// sandbox가 false면 실제 데이터만, true면 샌드박스 데이터(테스트 모드 키로 작성)만 집계합니다
+func GetSiteStats(ctx context.Context, db DBTX, siteID int64, sandbox bool) (*models.SiteStats, error) {
 	query := `
 		SELECT
 			COUNT(DISTINCT p.id) as post_count,
@@ -146,11 +199,11 @@ func GetSiteStats(ctx context.Context, db DBTX, siteID int64) (*models.SiteStats
 			COUNT(CASE WHEN c.is_deleted = true THEN 1 END) as deleted_comment_count
 		FROM posts p
 		LEFT JOIN comments c ON c.post_id = p.id
-		WHERE p.site_id = $1
+		WHERE p.site_id = $1 AND p.is_sandbox = $2
 	`
 
 	stats := &models.SiteStats{}
-	err := db.QueryRowContext(ctx, query, siteID).Scan(
+	err := db.QueryRowContext(ctx, query, siteID, sandbox).Scan(
 		&stats.PostCount,
 		&stats.CommentCount,
 		&stats.DeletedCommentCount,
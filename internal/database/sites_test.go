@@ -19,9 +19,8 @@ func TestCreateSiteForUser(t *testing.T) {
 
 		// 테스트 사용자 생성
 		user := &models.User{
-			Email:    "siteowner@example.com",
-			Name:     "Site Owner",
-			GoogleID: "google-site-owner",
+			Email: "siteowner@example.com",
+			Name:  "Site Owner",
 		}
 		if err := CreateUser(ctx, tx, user); err != nil {
 			t.Fatalf("Failed to create user: %v", err)
@@ -33,6 +32,7 @@ func TestCreateSiteForUser(t *testing.T) {
 			Domain:      "testsite.com",
 			CORSOrigins: []string{"https://testsite.com"},
 			IsActive:    true,
+			IsVerified:  true,
 		}
 
 		err := CreateSiteForUser(ctx, tx, site, user.ID)
@@ -81,9 +81,8 @@ func TestCreateSiteForUser(t *testing.T) {
 
 		// 테스트 사용자 생성
 		user := &models.User{
-			Email:    "multisiteowner@example.com",
-			Name:     "Multi Site Owner",
-			GoogleID: "google-multi-site",
+			Email: "multisiteowner@example.com",
+			Name:  "Multi Site Owner",
 		}
 		if err := CreateUser(ctx, tx, user); err != nil {
 			t.Fatalf("Failed to create user: %v", err)
@@ -96,6 +95,7 @@ func TestCreateSiteForUser(t *testing.T) {
 				Domain:      "site" + string(rune('0'+i)) + ".com",
 				CORSOrigins: []string{"https://site" + string(rune('0'+i)) + ".com"},
 				IsActive:    true,
+				IsVerified:  true,
 			}
 
 			if err := CreateSiteForUser(ctx, tx, site, user.ID); err != nil {
@@ -119,9 +119,8 @@ func TestCreateSiteForUser(t *testing.T) {
 
 		// 테스트 사용자 생성
 		user := &models.User{
-			Email:    "apikeytest@example.com",
-			Name:     "API Key Test",
-			GoogleID: "google-apikey-test",
+			Email: "apikeytest@example.com",
+			Name:  "API Key Test",
 		}
 		if err := CreateUser(ctx, tx, user); err != nil {
 			t.Fatalf("Failed to create user: %v", err)
@@ -133,6 +132,7 @@ func TestCreateSiteForUser(t *testing.T) {
 			Domain:      "site1.com",
 			CORSOrigins: []string{"https://site1.com"},
 			IsActive:    true,
+			IsVerified:  true,
 		}
 		if err := CreateSiteForUser(ctx, tx, site1, user.ID); err != nil {
 			t.Fatalf("Failed to create site 1: %v", err)
@@ -144,6 +144,7 @@ func TestCreateSiteForUser(t *testing.T) {
 			Domain:      "site2.com",
 			CORSOrigins: []string{"https://site2.com"},
 			IsActive:    true,
+			IsVerified:  true,
 		}
 		if err := CreateSiteForUser(ctx, tx, site2, user.ID); err != nil {
 			t.Fatalf("Failed to create site 2: %v", err)
@@ -167,9 +168,8 @@ func TestGetSiteByID(t *testing.T) {
 
 		// 테스트 사용자 및 사이트 생성
 		user := &models.User{
-			Email:    "getsitetest@example.com",
-			Name:     "Get Site Test",
-			GoogleID: "google-getsite",
+			Email: "getsitetest@example.com",
+			Name:  "Get Site Test",
 		}
 		if err := CreateUser(ctx, tx, user); err != nil {
 			t.Fatalf("Failed to create user: %v", err)
@@ -180,6 +180,7 @@ func TestGetSiteByID(t *testing.T) {
 			Domain:      "testsite.com",
 			CORSOrigins: []string{"https://testsite.com"},
 			IsActive:    true,
+			IsVerified:  true,
 		}
 		if err := CreateSiteForUser(ctx, tx, site, user.ID); err != nil {
 			t.Fatalf("Failed to create site: %v", err)
@@ -226,9 +227,8 @@ func TestUpdateSite(t *testing.T) {
 
 		// 테스트 사용자 및 사이트 생성
 		user := &models.User{
-			Email:    "updatetest@example.com",
-			Name:     "Update Test",
-			GoogleID: "google-update",
+			Email: "updatetest@example.com",
+			Name:  "Update Test",
 		}
 		if err := CreateUser(ctx, tx, user); err != nil {
 			t.Fatalf("Failed to create user: %v", err)
@@ -239,6 +239,7 @@ func TestUpdateSite(t *testing.T) {
 			Domain:      "original.com",
 			CORSOrigins: []string{"https://original.com"},
 			IsActive:    true,
+			IsVerified:  true,
 		}
 		if err := CreateSiteForUser(ctx, tx, site, user.ID); err != nil {
 			t.Fatalf("Failed to create site: %v", err)
@@ -270,12 +271,16 @@ func TestUpdateSite(t *testing.T) {
 			t.Errorf("Expected is_active %v, got %v", newIsActive, updatedSite.IsActive)
 		}
 
-		// domain과 api_key는 변경되지 않아야 함
+		// domain과 API 키는 변경되지 않아야 함
 		if updatedSite.Domain != site.Domain {
 			t.Errorf("Domain should not change, got %s", updatedSite.Domain)
 		}
-		if updatedSite.APIKey != site.APIKey {
-			t.Errorf("API key should not change")
+		keys, err := ListSiteAPIKeys(ctx, tx, site.ID)
+		if err != nil {
+			t.Fatalf("Failed to list api keys: %v", err)
+		}
+		if len(keys) != 1 || keys[0].Key != site.APIKey {
+			t.Errorf("API key should not change, got %+v", keys)
 		}
 	})
 
@@ -291,6 +296,45 @@ func TestUpdateSite(t *testing.T) {
 	})
 }
 
+// TestUpdateSiteAutoCloseDays는 댓글 자동 마감 기간 수정 기능을 테스트합니다
+func TestUpdateSiteAutoCloseDays(t *testing.T) {
+	db := testhelpers.SetupTestDB(t)
+	defer Close(db)
+
+	t.Run("자동 마감 기간 수정 성공", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		// Given: 자동 마감이 없는 사이트
+		site := testhelpers.CreateTestSite(ctx, t, tx, "Close Site", "close.com", []string{"https://close.com"}, true)
+
+		// When: 30일로 수정
+		if err := UpdateSiteAutoCloseDays(ctx, tx, site.ID, 30); err != nil {
+			t.Fatalf("Failed to update auto close days: %v", err)
+		}
+
+		// Then: 조회 시 반영됨
+		updatedSite, err := GetSiteByID(ctx, tx, site.ID)
+		if err != nil {
+			t.Fatalf("Failed to get updated site: %v", err)
+		}
+		if updatedSite.AutoCloseDays != 30 {
+			t.Errorf("Expected auto_close_days 30, got %d", updatedSite.AutoCloseDays)
+		}
+	})
+
+	t.Run("존재하지 않는 사이트 수정", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		// 존재하지 않는 ID로 수정 시도
+		err := UpdateSiteAutoCloseDays(ctx, tx, 99999, 30)
+		if err != sql.ErrNoRows {
+			t.Errorf("Expected sql.ErrNoRows, got %v", err)
+		}
+	})
+}
+
 // TestDeleteSite는 사이트 삭제 기능을 테스트합니다
 func TestDeleteSite(t *testing.T) {
 	db := testhelpers.SetupTestDB(t)
@@ -302,9 +346,8 @@ func TestDeleteSite(t *testing.T) {
 
 		// 테스트 사용자 및 사이트 생성
 		user := &models.User{
-			Email:    "deletetest@example.com",
-			Name:     "Delete Test",
-			GoogleID: "google-delete",
+			Email: "deletetest@example.com",
+			Name:  "Delete Test",
 		}
 		if err := CreateUser(ctx, tx, user); err != nil {
 			t.Fatalf("Failed to create user: %v", err)
@@ -315,6 +358,7 @@ func TestDeleteSite(t *testing.T) {
 			Domain:      "todelete.com",
 			CORSOrigins: []string{"https://todelete.com"},
 			IsActive:    true,
+			IsVerified:  true,
 		}
 		if err := CreateSiteForUser(ctx, tx, site, user.ID); err != nil {
 			t.Fatalf("Failed to create site: %v", err)
@@ -375,7 +419,7 @@ func TestGetSiteStats(t *testing.T) {
 		}
 
 		// 통계 조회
-		stats, err := GetSiteStats(ctx, tx, site.ID)
+		stats, err := GetSiteStats(ctx, tx, site.ID, false)
 		if err != nil {
 			t.Fatalf("Failed to get site stats: %v", err)
 		}
@@ -425,7 +469,7 @@ func TestGetSiteStats(t *testing.T) {
 		}
 
 		// 통계 조회
-		stats, err := GetSiteStats(ctx, tx, site.ID)
+		stats, err := GetSiteStats(ctx, tx, site.ID, false)
 		if err != nil {
 			t.Fatalf("Failed to get site stats: %v", err)
 		}
@@ -451,7 +495,7 @@ func TestGetSiteStats(t *testing.T) {
 			[]string{"https://empty.test.com"}, true)
 
 		// 통계 조회
-		stats, err := GetSiteStats(ctx, tx, site.ID)
+		stats, err := GetSiteStats(ctx, tx, site.ID, false)
 		if err != nil {
 			t.Fatalf("Failed to get site stats: %v", err)
 		}
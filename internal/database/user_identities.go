@@ -0,0 +1,154 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/june20516/orbithall/internal/models"
+)
+
+// GetUserByIdentity는 ID 공급자 계정(provider, subject)에 연결된 사용자를 조회합니다
+// 연결된 사용자가 없는 경우 nil을 반환합니다
+func GetUserByIdentity(ctx context.Context, db DBTX, provider, subject string) (*models.User, error) {
+	query := `
+		SELECT u.id, u.email, u.email_verified, u.name, u.picture_url, u.created_at, u.updated_at
+		FROM users u
+		INNER JOIN user_identities i ON i.user_id = u.id
+		WHERE i.provider = $1 AND i.subject = $2
+	`
+
+	var user models.User
+	err := db.QueryRowContext(ctx, query, provider, subject).Scan(
+		&user.ID,
+		&user.Email,
+		&user.EmailVerified,
+		&user.Name,
+		&user.PictureURL,
+		&user.CreatedAt,
+		&user.UpdatedAt,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, nil // 연결된 사용자가 없는 경우 nil 반환
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user by identity: %w", err)
+	}
+
+	return &user, nil
+}
+
+// CreateUserIdentity는 사용자에게 ID 공급자 계정을 연결합니다
+// identity의 UserID, Provider, Subject, Email을 채워 전달하면 생성된 ID와 시각을 채웁니다
+func CreateUserIdentity(ctx context.Context, db DBTX, identity *models.UserIdentity) error {
+	query := `
+		INSERT INTO user_identities (user_id, provider, subject, email)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at, last_login_at
+	`
+
+	err := db.QueryRowContext(ctx, query,
+		identity.UserID,
+		identity.Provider,
+		identity.Subject,
+		identity.Email,
+	).Scan(&identity.ID, &identity.CreatedAt, &identity.LastLoginAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to create user identity: %w", err)
+	}
+
+	return nil
+}
+
+// LoginWithIdentity는 ID 공급자 계정으로 로그인한 사용자를 조회하거나 생성합니다
+// 계정이 연결된 사용자가 있으면 그 사용자를 반환하며, 공급자가 사용자의 이메일을 검증했으면(emailVerified) 사용자의 이메일을 검증된 것으로 표시합니다
+// 계정이 연결된 사용자가 없으면 공급자가 이메일을 검증한 경우에만 진행하고, 검증하지 않았으면 ErrEmailNotVerified를 반환합니다
+// 같은 이메일(대소문자 구분 없음)의 사용자가 있으면 그 사용자의 이메일도 검증된 경우에만 계정을 연결하고 아니면 ErrIdentityEmailConflict를 반환하며
+// (다른 사람이 미리 만든 계정이나 기존 계정을 가로채지 못하도록), 같은 이메일의 사용자도 없으면 profile(Email, Name, PictureURL)로 사용자를 생성하고 계정을 연결합니다
+func LoginWithIdentity(ctx context.Context, db DBTX, provider, subject string, profile *models.User, emailVerified bool) (*models.User, error) {
+	var user *models.User
+	err := RunInTx(ctx, db, func(tx DBTX) error {
+		var err error
+		user, err = GetUserByIdentity(ctx, tx, provider, subject)
+		if err != nil {
+			return err
+		}
+		if user != nil {
+			_, err = tx.ExecContext(ctx, `
+				UPDATE user_identities
+				SET last_login_at = NOW()
+				WHERE provider = $1 AND subject = $2
+			`, provider, subject)
+			if err != nil {
+				return fmt.Errorf("failed to update user identity: %w", err)
+			}
+
+			if emailVerified && !user.EmailVerified && strings.EqualFold(strings.TrimSpace(profile.Email), strings.TrimSpace(user.Email)) {
+				_, err = tx.ExecContext(ctx, `
+					UPDATE users
+					SET email_verified = TRUE, updated_at = NOW()
+					WHERE id = $1
+				`, user.ID)
+				if err != nil {
+					return fmt.Errorf("failed to verify user email: %w", err)
+				}
+				user.EmailVerified = true
+			}
+			return nil
+		}
+
+		if !emailVerified {
+			return ErrEmailNotVerified
+		}
+
+		// 같은 이메일의 기존 사용자 조회
+		var existing models.User
+		err = tx.QueryRowContext(ctx, `
+			SELECT id, email, email_verified, name, picture_url, created_at, updated_at
+			FROM users
+			WHERE LOWER(email) = LOWER($1)
+		`, profile.Email).Scan(
+			&existing.ID,
+			&existing.Email,
+			&existing.EmailVerified,
+			&existing.Name,
+			&existing.PictureURL,
+			&existing.CreatedAt,
+			&existing.UpdatedAt,
+		)
+		switch {
+		case err == nil:
+			if !existing.EmailVerified {
+				return ErrIdentityEmailConflict
+			}
+			user = &existing
+		case err == sql.ErrNoRows:
+			user = &models.User{
+				Email:         profile.Email,
+				EmailVerified: true,
+				Name:          profile.Name,
+				PictureURL:    profile.PictureURL,
+			}
+			if err := CreateUser(ctx, tx, user); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("failed to get user by email: %w", err)
+		}
+
+		return CreateUserIdentity(ctx, tx, &models.UserIdentity{
+			UserID:   user.ID,
+			Provider: provider,
+			Subject:  subject,
+			Email:    profile.Email,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
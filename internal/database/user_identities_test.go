@@ -0,0 +1,152 @@
+package database
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/june20516/orbithall/internal/models"
+	"github.com/june20516/orbithall/internal/testhelpers"
+)
+
+// TestLoginWithIdentity는 ID 공급자 계정으로 사용자를 조회/생성하는 것을 테스트합니다
+func TestLoginWithIdentity(t *testing.T) {
+	db := testhelpers.SetupTestDB(t)
+	defer Close(db)
+
+	t.Run("처음 로그인하면 사용자 생성 후 계정 연결", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		// Given: 연결된 사용자가 없는 OIDC 계정
+		profile := &models.User{Email: "oidc@example.com", Name: "OIDC User"}
+
+		// When
+		user, err := LoginWithIdentity(ctx, tx, "oidc", "sub-1", profile, true)
+
+		// Then: 검증된 이메일로 사용자 생성, 같은 계정으로 다시 로그인하면 같은 사용자
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if user.ID == 0 || user.Email != profile.Email || !user.EmailVerified {
+			t.Fatalf("unexpected user: %+v", user)
+		}
+
+		again, err := LoginWithIdentity(ctx, tx, "oidc", "sub-1", &models.User{Email: "changed@example.com", Name: "Changed"}, false)
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if again.ID != user.ID || again.Email != profile.Email {
+			t.Errorf("expected same user, got %+v", again)
+		}
+
+		found, err := GetUserByIdentity(ctx, tx, "oidc", "sub-1")
+		if err != nil || found == nil || found.ID != user.ID {
+			t.Errorf("expected identity linked to user %d, got %+v (err: %v)", user.ID, found, err)
+		}
+	})
+
+	t.Run("검증된 이메일이면 기존 사용자에 연결", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		// Given: Google로 가입한 사용자
+		existing, err := LoginWithIdentity(ctx, tx, "google", "google-sub", &models.User{Email: "same@example.com", Name: "Same"}, true)
+		if err != nil {
+			t.Fatalf("failed to create user: %v", err)
+		}
+
+		// When: 같은 이메일(대소문자 다름)의 검증된 OIDC 계정으로 로그인
+		user, err := LoginWithIdentity(ctx, tx, "oidc", "oidc-sub", &models.User{Email: "Same@Example.com", Name: "Same"}, true)
+
+		// Then: 기존 사용자에 연결
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if user.ID != existing.ID {
+			t.Errorf("expected user %d, got %d", existing.ID, user.ID)
+		}
+	})
+
+	t.Run("검증되지 않은 이메일로는 사용자를 만들거나 연결하지 않음", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		// Given: 이미 가입한 사용자
+		if _, err := LoginWithIdentity(ctx, tx, "google", "google-victim", &models.User{Email: "victim@example.com", Name: "Victim"}, true); err != nil {
+			t.Fatalf("failed to create user: %v", err)
+		}
+
+		// When: 같은 이메일, 새 이메일의 검증되지 않은 계정으로 로그인
+		_, linkErr := LoginWithIdentity(ctx, tx, "oidc", "attacker", &models.User{Email: "victim@example.com", Name: "Attacker"}, false)
+		_, createErr := LoginWithIdentity(ctx, tx, "oidc", "attacker-2", &models.User{Email: "unverified@example.com", Name: "Attacker"}, false)
+
+		// Then: ErrEmailNotVerified, 사용자 생성 안 됨
+		if !errors.Is(linkErr, ErrEmailNotVerified) || !errors.Is(createErr, ErrEmailNotVerified) {
+			t.Errorf("expected ErrEmailNotVerified, got: %v, %v", linkErr, createErr)
+		}
+		if user, _ := GetUserByEmail(ctx, tx, "unverified@example.com"); user != nil {
+			t.Errorf("expected no user, got: %+v", user)
+		}
+	})
+
+	t.Run("이메일이 검증되지 않은 기존 사용자에게는 연결하지 않음", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		// Given: 이메일이 검증되지 않은 사용자 (다른 사람이 미리 만든 계정일 수 있음)
+		if err := CreateUser(ctx, tx, &models.User{Email: "preclaimed@example.com", Name: "Preclaimed"}); err != nil {
+			t.Fatalf("failed to create user: %v", err)
+		}
+
+		// When: 같은 이메일의 검증된 계정으로 로그인
+		_, err := LoginWithIdentity(ctx, tx, "oidc", "owner", &models.User{Email: "preclaimed@example.com", Name: "Owner"}, true)
+
+		// Then: ErrIdentityEmailConflict
+		if !errors.Is(err, ErrIdentityEmailConflict) {
+			t.Errorf("expected ErrIdentityEmailConflict, got: %v", err)
+		}
+	})
+
+	t.Run("연결된 계정으로 검증된 같은 이메일로 로그인하면 이메일 검증", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		// Given: 이메일이 검증되지 않은 상태로 계정이 연결된 기존 사용자
+		user := &models.User{Email: "legacy@example.com", Name: "Legacy"}
+		if err := CreateUser(ctx, tx, user); err != nil {
+			t.Fatalf("failed to create user: %v", err)
+		}
+		if err := CreateUserIdentity(ctx, tx, &models.UserIdentity{UserID: user.ID, Provider: "google", Subject: "google-legacy", Email: user.Email}); err != nil {
+			t.Fatalf("failed to create identity: %v", err)
+		}
+
+		// 검증되지 않은 로그인은 상태를 바꾸지 않음
+		if again, err := LoginWithIdentity(ctx, tx, "google", "google-legacy", &models.User{Email: user.Email}, false); err != nil || again.EmailVerified {
+			t.Fatalf("expected unverified user, got %+v (err: %v)", again, err)
+		}
+
+		// When
+		verified, err := LoginWithIdentity(ctx, tx, "google", "google-legacy", &models.User{Email: "Legacy@example.com"}, true)
+
+		// Then: 이메일 검증 표시
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if found, _ := GetUserByID(ctx, tx, user.ID); !verified.EmailVerified || found == nil || !found.EmailVerified {
+			t.Errorf("expected verified user, got %+v, %+v", verified, found)
+		}
+	})
+
+	t.Run("연결되지 않은 계정은 nil 반환", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		user, err := GetUserByIdentity(ctx, tx, "oidc", "nonexistent")
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if user != nil {
+			t.Errorf("expected nil user, got: %+v", user)
+		}
+	})
+}
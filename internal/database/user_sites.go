@@ -2,6 +2,7 @@ package database
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 
 	"github.com/june20516/orbithall/internal/models"
@@ -25,14 +26,17 @@ func AddUserToSite(ctx context.Context, db DBTX, userID, siteID int64, role stri
 	return nil
 }
 
-// GetUserSites는 사용자가 소유한 사이트 목록을 조회합니다
+// GetUserSites는 사용자가 멤버인 사이트 목록을 조회합니다 (site.Role에 사용자의 역할 포함)
+// verification_token은 사이트 관리 권한(PermissionSiteManage)이 있는 역할에만 채워집니다
 // JOIN 쿼리로 N+1 문제 방지
 // created_at 내림차순 정렬 (최신 사이트 먼저)
 func GetUserSites(ctx context.Context, db DBTX, userID int64) ([]models.Site, error) {
 	query := `
 		SELECT
-			s.id, s.name, s.domain, s.api_key, s.cors_origins, s.is_active,
-			s.created_at, s.updated_at
+			s.id, s.name, s.domain, s.cors_origins, s.is_active, s.is_verified, s.verification_token, s.verified_at,
+			s.auto_close_days,
+			s.personal_data_retention_days, s.deleted_content_retention_days, s.rate_limits,
+			us.role, s.created_at, s.updated_at
 		FROM sites s
 		INNER JOIN user_sites us ON s.id = us.site_id
 		WHERE us.user_id = $1
@@ -48,19 +52,33 @@ func GetUserSites(ctx context.Context, db DBTX, userID int64) ([]models.Site, er
 	var sites []models.Site
 	for rows.Next() {
 		var site models.Site
+		var rateLimits []byte
 		err := rows.Scan(
 			&site.ID,
 			&site.Name,
 			&site.Domain,
-			&site.APIKey,
 			pq.Array(&site.CORSOrigins),
 			&site.IsActive,
+			&site.IsVerified,
+			&site.VerificationToken,
+			&site.VerifiedAt,
+			&site.AutoCloseDays,
+			&site.PersonalDataRetentionDays,
+			&site.DeletedContentRetentionDays,
+			&rateLimits,
+			&site.Role,
 			&site.CreatedAt,
 			&site.UpdatedAt,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan site: %w", err)
 		}
+		if err := unmarshalRateLimits(rateLimits, &site.RateLimits); err != nil {
+			return nil, err
+		}
+		if !models.SiteRoleHasPermission(site.Role, models.PermissionSiteManage) {
+			site.VerificationToken = ""
+		}
 		sites = append(sites, site)
 	}
 
@@ -81,7 +99,7 @@ func GetUserSites(ctx context.Context, db DBTX, userID int64) ([]models.Site, er
 func GetSiteUsers(ctx context.Context, db DBTX, siteID int64) ([]models.User, error) {
 	query := `
 		SELECT
-			u.id, u.email, u.name, u.picture_url, u.google_id,
+			u.id, u.email, u.name, u.picture_url,
 			u.created_at, u.updated_at
 		FROM users u
 		INNER JOIN user_sites us ON u.id = us.user_id
@@ -103,7 +121,6 @@ func GetSiteUsers(ctx context.Context, db DBTX, siteID int64) ([]models.User, er
 			&user.Email,
 			&user.Name,
 			&user.PictureURL,
-			&user.GoogleID,
 			&user.CreatedAt,
 			&user.UpdatedAt,
 		)
@@ -140,21 +157,193 @@ func RemoveUserFromSite(ctx context.Context, db DBTX, userID, siteID int64) erro
 	return nil
 }
 
-// HasUserSiteAccess는 사용자가 사이트에 접근 권한이 있는지 확인합니다
-// role = 'owner'인 경우에만 true 반환
+// HasUserSiteAccess는 사용자가 사이트의 멤버인지 확인합니다 (역할 무관)
+// 작업별 권한은 HasUserSitePermission으로 확인합니다
 func HasUserSiteAccess(ctx context.Context, db DBTX, userID, siteID int64) (bool, error) {
+	role, err := GetUserSiteRole(ctx, db, userID, siteID)
+	if err != nil {
+		return false, err
+	}
+
+	return role != "", nil
+}
+
+// GetUserSiteRole은 사용자의 사이트 역할을 조회합니다
+// 사용자가 사이트의 멤버가 아니면 빈 문자열을 반환합니다
+func GetUserSiteRole(ctx context.Context, db DBTX, userID, siteID int64) (string, error) {
 	query := `
-		SELECT EXISTS(
-			SELECT 1 FROM user_sites
-			WHERE user_id = $1 AND site_id = $2 AND role = 'owner'
-		)
+		SELECT role FROM user_sites
+		WHERE user_id = $1 AND site_id = $2
+	`
+
+	var role string
+	err := db.QueryRowContext(ctx, query, userID, siteID).Scan(&role)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get site role: %w", err)
+	}
+
+	return role, nil
+}
+
+// HasUserSitePermission은 사용자의 사이트 역할이 권한(models.Permission*)을 허용하는지 확인합니다
+// 사용자가 사이트의 멤버가 아니면 false를 반환합니다
+func HasUserSitePermission(ctx context.Context, db DBTX, userID, siteID int64, permission string) (bool, error) {
+	role, err := GetUserSiteRole(ctx, db, userID, siteID)
+	if err != nil {
+		return false, err
+	}
+
+	return models.SiteRoleHasPermission(role, permission), nil
+}
+
+// siteMemberColumns는 사이트 멤버 조회 시 선택하는 컬럼입니다 (scanSiteMember와 순서 일치)
+const siteMemberColumns = `
+	u.id, u.email, u.name, u.picture_url,
+	u.created_at, u.updated_at, us.role, us.created_at
+`
+
+// scanSiteMember는 siteMemberColumns 순서로 사이트 멤버를 스캔합니다
+func scanSiteMember(row rowScanner, member *models.SiteMember) error {
+	return row.Scan(
+		&member.ID,
+		&member.Email,
+		&member.Name,
+		&member.PictureURL,
+		&member.CreatedAt,
+		&member.UpdatedAt,
+		&member.Role,
+		&member.JoinedAt,
+	)
+}
+
+// GetSiteMembers는 사이트의 멤버 목록을 역할과 함께 조회합니다
+// 역할 순서(owner, manager, moderator, viewer), 연결 시각 순으로 정렬합니다
+func GetSiteMembers(ctx context.Context, db DBTX, siteID int64) ([]models.SiteMember, error) {
+	query := `
+		SELECT ` + siteMemberColumns + `
+		FROM users u
+		INNER JOIN user_sites us ON u.id = us.user_id
+		WHERE us.site_id = $1
+		ORDER BY array_position($2::TEXT[], us.role::TEXT), us.created_at, u.id
+	`
+
+	rows, err := db.QueryContext(ctx, query, siteID, pq.Array(models.SiteRoles))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query site members: %w", err)
+	}
+	defer rows.Close()
+
+	members := []models.SiteMember{}
+	for rows.Next() {
+		var member models.SiteMember
+		if err := scanSiteMember(rows, &member); err != nil {
+			return nil, fmt.Errorf("failed to scan site member: %w", err)
+		}
+		members = append(members, member)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating site member rows: %w", err)
+	}
+
+	return members, nil
+}
+
+// GetSiteMember는 사이트 멤버 한 명을 역할과 함께 조회합니다
+// 사용자가 사이트의 멤버가 아니면 sql.ErrNoRows를 반환합니다
+func GetSiteMember(ctx context.Context, db DBTX, siteID, userID int64) (*models.SiteMember, error) {
+	query := `
+		SELECT ` + siteMemberColumns + `
+		FROM users u
+		INNER JOIN user_sites us ON u.id = us.user_id
+		WHERE us.site_id = $1 AND us.user_id = $2
 	`
 
-	var exists bool
-	err := db.QueryRowContext(ctx, query, userID, siteID).Scan(&exists)
+	var member models.SiteMember
+	err := scanSiteMember(db.QueryRowContext(ctx, query, siteID, userID), &member)
+	if err == sql.ErrNoRows {
+		return nil, sql.ErrNoRows
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get site member: %w", err)
+	}
+
+	return &member, nil
+}
+
+// UpdateSiteMemberRole은 사이트 멤버의 역할을 변경합니다
+// 멤버가 아니면 sql.ErrNoRows, 마지막 owner를 다른 역할로 변경하면 ErrLastOwner를 반환합니다
+func UpdateSiteMemberRole(ctx context.Context, db DBTX, siteID, userID int64, role string) error {
+	return RunInTx(ctx, db, func(tx DBTX) error {
+		if err := checkLastOwner(ctx, tx, siteID, userID, role != models.SiteRoleOwner); err != nil {
+			return err
+		}
+
+		query := `
+			UPDATE user_sites
+			SET role = $3
+			WHERE user_id = $1 AND site_id = $2
+		`
+
+		if _, err := tx.ExecContext(ctx, query, userID, siteID, role); err != nil {
+			return fmt.Errorf("failed to update site member role: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// RemoveSiteMember는 사이트 멤버를 제거합니다
+// 멤버가 아니면 sql.ErrNoRows, 마지막 owner를 제거하면 ErrLastOwner를 반환합니다
+func RemoveSiteMember(ctx context.Context, db DBTX, siteID, userID int64) error {
+	return RunInTx(ctx, db, func(tx DBTX) error {
+		if err := checkLastOwner(ctx, tx, siteID, userID, true); err != nil {
+			return err
+		}
+
+		return RemoveUserFromSite(ctx, tx, userID, siteID)
+	})
+}
+
+// checkLastOwner는 멤버가 존재하는지, 그리고 owner 권한을 잃어도(losingOwner) 다른 owner가 남는지 확인합니다
+// 사이트의 owner 행을 잠가 동시에 두 owner를 제거/강등하여 owner가 없어지는 것을 막습니다 (트랜잭션 안에서 호출)
+func checkLastOwner(ctx context.Context, tx DBTX, siteID, userID int64, losingOwner bool) error {
+	rows, err := tx.QueryContext(ctx, `
+		SELECT user_id FROM user_sites
+		WHERE site_id = $1 AND role = 'owner'
+		FOR UPDATE
+	`, siteID)
 	if err != nil {
-		return false, fmt.Errorf("failed to check site ownership: %w", err)
+		return fmt.Errorf("failed to lock site owners: %w", err)
+	}
+	var owners []int64
+	for rows.Next() {
+		var ownerID int64
+		if err := rows.Scan(&ownerID); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to scan site owner: %w", err)
+		}
+		owners = append(owners, ownerID)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating site owner rows: %w", err)
 	}
 
-	return exists, nil
+	role, err := GetUserSiteRole(ctx, tx, userID, siteID)
+	if err != nil {
+		return err
+	}
+	if role == "" {
+		return sql.ErrNoRows
+	}
+
+	if losingOwner && role == models.SiteRoleOwner && len(owners) <= 1 {
+		return ErrLastOwner
+	}
+
+	return nil
 }
@@ -1,6 +1,10 @@
 package database
 
 import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/june20516/orbithall/internal/models"
@@ -19,9 +23,8 @@ func TestAddUserToSite(t *testing.T) {
 
 		// 테스트 사용자 생성
 		user := &models.User{
-			Email:    "test@example.com",
-			Name:     "Test User",
-			GoogleID: "google-123",
+			Email: "test@example.com",
+			Name:  "Test User",
 		}
 		if err := CreateUser(ctx, tx, user); err != nil {
 			t.Fatalf("Failed to create user: %v", err)
@@ -52,9 +55,8 @@ func TestAddUserToSite(t *testing.T) {
 
 		// 테스트 사용자 생성
 		user := &models.User{
-			Email:    "test2@example.com",
-			Name:     "Test User 2",
-			GoogleID: "google-456",
+			Email: "test2@example.com",
+			Name:  "Test User 2",
 		}
 		if err := CreateUser(ctx, tx, user); err != nil {
 			t.Fatalf("Failed to create user: %v", err)
@@ -95,9 +97,8 @@ func TestGetUserSites(t *testing.T) {
 
 		// 테스트 사용자 생성
 		user := &models.User{
-			Email:    "owner@example.com",
-			Name:     "Site Owner",
-			GoogleID: "google-owner",
+			Email: "owner@example.com",
+			Name:  "Site Owner",
 		}
 		if err := CreateUser(ctx, tx, user); err != nil {
 			t.Fatalf("Failed to create user: %v", err)
@@ -128,15 +129,53 @@ func TestGetUserSites(t *testing.T) {
 		}
 	})
 
+	t.Run("사이트 관리 권한이 없는 멤버에게는 검증 토큰 숨김", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		// Given: 같은 사이트의 관리자와 조회자
+		site := testhelpers.CreateTestSite(ctx, t, tx, "Token Site", "token.com", []string{"https://token.com"}, true)
+		roles := map[string]string{
+			models.SiteRoleManager: "",
+			models.SiteRoleViewer:  "",
+		}
+		for role := range roles {
+			user := &models.User{Email: role + "@token.com", Name: role}
+			if err := CreateUser(ctx, tx, user); err != nil {
+				t.Fatalf("Failed to create user: %v", err)
+			}
+			if err := AddUserToSite(ctx, tx, user.ID, site.ID, role); err != nil {
+				t.Fatalf("Failed to add user to site: %v", err)
+			}
+
+			// When
+			sites, err := GetUserSites(ctx, tx, user.ID)
+			if err != nil {
+				t.Fatalf("Failed to get user sites: %v", err)
+			}
+			if len(sites) != 1 {
+				t.Fatalf("Expected 1 site for %s, got %d", role, len(sites))
+			}
+			roles[role] = sites[0].VerificationToken
+		}
+
+		// Then: 관리자만 토큰을 받음
+		if roles[models.SiteRoleManager] == "" {
+			t.Error("expected verification token for manager")
+		}
+		if roles[models.SiteRoleViewer] != "" {
+			t.Errorf("expected no verification token for viewer, got %q", roles[models.SiteRoleViewer])
+		}
+	})
+
 	t.Run("사이트가 없는 사용자는 빈 배열 반환", func(t *testing.T) {
 		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
 		defer cleanup()
 
 		// 테스트 사용자 생성 (사이트 없음)
 		user := &models.User{
-			Email:    "nosite@example.com",
-			Name:     "No Site User",
-			GoogleID: "google-nosite",
+			Email: "nosite@example.com",
+			Name:  "No Site User",
 		}
 		if err := CreateUser(ctx, tx, user); err != nil {
 			t.Fatalf("Failed to create user: %v", err)
@@ -170,9 +209,8 @@ func TestGetSiteUsers(t *testing.T) {
 		// 2명 사용자 생성 및 연결
 		for i := 1; i <= 2; i++ {
 			user := &models.User{
-				Email:    "user" + string(rune('0'+i)) + "@example.com",
-				Name:     "User " + string(rune('0'+i)),
-				GoogleID: "google-user-" + string(rune('0'+i)),
+				Email: "user" + string(rune('0'+i)) + "@example.com",
+				Name:  "User " + string(rune('0'+i)),
 			}
 			if err := CreateUser(ctx, tx, user); err != nil {
 				t.Fatalf("Failed to create user: %v", err)
@@ -206,9 +244,8 @@ func TestRemoveUserFromSite(t *testing.T) {
 
 		// 테스트 사용자 생성
 		user := &models.User{
-			Email:    "remove@example.com",
-			Name:     "Remove Test",
-			GoogleID: "google-remove",
+			Email: "remove@example.com",
+			Name:  "Remove Test",
 		}
 		if err := CreateUser(ctx, tx, user); err != nil {
 			t.Fatalf("Failed to create user: %v", err)
@@ -257,9 +294,8 @@ func TestHasUserSiteAccess(t *testing.T) {
 
 		// 테스트 사용자 생성
 		user := &models.User{
-			Email:    "owner@example.com",
-			Name:     "Owner",
-			GoogleID: "google-owner-check",
+			Email: "owner@example.com",
+			Name:  "Owner",
 		}
 		if err := CreateUser(ctx, tx, user); err != nil {
 			t.Fatalf("Failed to create user: %v", err)
@@ -287,9 +323,8 @@ func TestHasUserSiteAccess(t *testing.T) {
 
 		// 테스트 사용자 생성
 		user := &models.User{
-			Email:    "notowner@example.com",
-			Name:     "Not Owner",
-			GoogleID: "google-not-owner",
+			Email: "notowner@example.com",
+			Name:  "Not Owner",
 		}
 		if err := CreateUser(ctx, tx, user); err != nil {
 			t.Fatalf("Failed to create user: %v", err)
@@ -308,3 +343,210 @@ func TestHasUserSiteAccess(t *testing.T) {
 		}
 	})
 }
+
+// createSiteMembers는 사이트와 역할별 멤버를 생성합니다 (roles 순서대로 사용자 생성)
+func createSiteMembers(ctx context.Context, t *testing.T, tx DBTX, prefix string, roles ...string) (*models.Site, []*models.User) {
+	t.Helper()
+
+	site := testhelpers.CreateTestSite(ctx, t, tx, prefix+" Site", prefix+".example.com", []string{"https://" + prefix + ".example.com"}, true)
+
+	var users []*models.User
+	for i, role := range roles {
+		user := &models.User{
+			Email:         fmt.Sprintf("%s-%d@example.com", prefix, i),
+			EmailVerified: true,
+			Name:          fmt.Sprintf("%s %d", prefix, i),
+		}
+		if err := CreateUser(ctx, tx, user); err != nil {
+			t.Fatalf("Failed to create user: %v", err)
+		}
+		if err := AddUserToSite(ctx, tx, user.ID, site.ID, role); err != nil {
+			t.Fatalf("Failed to add user to site: %v", err)
+		}
+		users = append(users, user)
+	}
+
+	return &site, users
+}
+
+// TestHasUserSitePermission은 역할별 사이트 권한 확인을 테스트합니다
+func TestHasUserSitePermission(t *testing.T) {
+	db := testhelpers.SetupTestDB(t)
+	defer Close(db)
+
+	ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+	defer cleanup()
+
+	// Given: owner, moderator 멤버와 멤버가 아닌 사용자
+	site, users := createSiteMembers(ctx, t, tx, "perm", models.SiteRoleOwner, models.SiteRoleModerator)
+	outsider := &models.User{Email: "perm-outsider@example.com", Name: "Outsider"}
+	if err := CreateUser(ctx, tx, outsider); err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		userID     int64
+		permission string
+		want       bool
+	}{
+		{"owner는 사이트 삭제 가능", users[0].ID, models.PermissionSiteDelete, true},
+		{"moderator는 댓글 관리 가능", users[1].ID, models.PermissionCommentModerate, true},
+		{"moderator는 사이트 설정 불가", users[1].ID, models.PermissionSiteManage, false},
+		{"멤버가 아니면 조회 불가", outsider.ID, models.PermissionSiteView, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := HasUserSitePermission(ctx, tx, tt.userID, site.ID, tt.permission)
+			if err != nil {
+				t.Fatalf("Failed to check permission: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("HasUserSitePermission() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestGetSiteMembers는 사이트 멤버 목록 조회를 테스트합니다
+func TestGetSiteMembers(t *testing.T) {
+	db := testhelpers.SetupTestDB(t)
+	defer Close(db)
+
+	ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+	defer cleanup()
+
+	// Given: viewer, owner, moderator 순서로 연결된 멤버
+	site, users := createSiteMembers(ctx, t, tx, "members", models.SiteRoleViewer, models.SiteRoleOwner, models.SiteRoleModerator)
+
+	// When
+	members, err := GetSiteMembers(ctx, tx, site.ID)
+	if err != nil {
+		t.Fatalf("Failed to get site members: %v", err)
+	}
+
+	// Then: 역할 순서(owner, moderator, viewer)로 정렬
+	if len(members) != 3 {
+		t.Fatalf("Expected 3 members, got %d", len(members))
+	}
+	wantIDs := []int64{users[1].ID, users[2].ID, users[0].ID}
+	wantRoles := []string{models.SiteRoleOwner, models.SiteRoleModerator, models.SiteRoleViewer}
+	for i, member := range members {
+		if member.ID != wantIDs[i] || member.Role != wantRoles[i] {
+			t.Errorf("members[%d] = (%d, %s), want (%d, %s)", i, member.ID, member.Role, wantIDs[i], wantRoles[i])
+		}
+	}
+}
+
+// TestUpdateSiteMemberRole은 멤버 역할 변경과 마지막 owner 보호를 테스트합니다
+func TestUpdateSiteMemberRole(t *testing.T) {
+	db := testhelpers.SetupTestDB(t)
+	defer Close(db)
+
+	t.Run("역할 변경 성공", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		// Given: owner와 viewer
+		site, users := createSiteMembers(ctx, t, tx, "promote", models.SiteRoleOwner, models.SiteRoleViewer)
+
+		// When: viewer를 manager로 변경
+		if err := UpdateSiteMemberRole(ctx, tx, site.ID, users[1].ID, models.SiteRoleManager); err != nil {
+			t.Fatalf("Failed to update role: %v", err)
+		}
+
+		// Then
+		member, err := GetSiteMember(ctx, tx, site.ID, users[1].ID)
+		if err != nil {
+			t.Fatalf("Failed to get site member: %v", err)
+		}
+		if member.Role != models.SiteRoleManager {
+			t.Errorf("Expected role manager, got %s", member.Role)
+		}
+	})
+
+	t.Run("마지막 owner 강등 불가", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		// Given: owner 한 명
+		site, users := createSiteMembers(ctx, t, tx, "lastowner", models.SiteRoleOwner, models.SiteRoleManager)
+
+		// When
+		err := UpdateSiteMemberRole(ctx, tx, site.ID, users[0].ID, models.SiteRoleManager)
+
+		// Then
+		if !errors.Is(err, ErrLastOwner) {
+			t.Errorf("Expected ErrLastOwner, got %v", err)
+		}
+	})
+
+	t.Run("다른 owner가 있으면 강등 가능", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		// Given: owner 두 명
+		site, users := createSiteMembers(ctx, t, tx, "twoowners", models.SiteRoleOwner, models.SiteRoleOwner)
+
+		// When
+		err := UpdateSiteMemberRole(ctx, tx, site.ID, users[0].ID, models.SiteRoleViewer)
+
+		// Then
+		if err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+	})
+
+	t.Run("멤버가 아니면 sql.ErrNoRows", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		site, _ := createSiteMembers(ctx, t, tx, "nomember", models.SiteRoleOwner)
+
+		err := UpdateSiteMemberRole(ctx, tx, site.ID, 999999, models.SiteRoleViewer)
+		if err != sql.ErrNoRows {
+			t.Errorf("Expected sql.ErrNoRows, got %v", err)
+		}
+	})
+}
+
+// TestRemoveSiteMember는 멤버 제거와 마지막 owner 보호를 테스트합니다
+func TestRemoveSiteMember(t *testing.T) {
+	db := testhelpers.SetupTestDB(t)
+	defer Close(db)
+
+	t.Run("멤버 제거 성공", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		// Given: owner와 moderator
+		site, users := createSiteMembers(ctx, t, tx, "remove", models.SiteRoleOwner, models.SiteRoleModerator)
+
+		// When
+		if err := RemoveSiteMember(ctx, tx, site.ID, users[1].ID); err != nil {
+			t.Fatalf("Failed to remove member: %v", err)
+		}
+
+		// Then
+		if _, err := GetSiteMember(ctx, tx, site.ID, users[1].ID); err != sql.ErrNoRows {
+			t.Errorf("Expected sql.ErrNoRows after removal, got %v", err)
+		}
+	})
+
+	t.Run("마지막 owner 제거 불가", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		// Given: owner 한 명
+		site, users := createSiteMembers(ctx, t, tx, "removeowner", models.SiteRoleOwner, models.SiteRoleViewer)
+
+		// When
+		err := RemoveSiteMember(ctx, tx, site.ID, users[0].ID)
+
+		// Then
+		if !errors.Is(err, ErrLastOwner) {
+			t.Errorf("Expected ErrLastOwner, got %v", err)
+		}
+	})
+}
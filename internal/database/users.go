@@ -12,16 +12,16 @@ import (
 // RETURNING 절을 사용하여 생성된 ID와 타임스탬프를 user 포인터에 설정합니다
 func CreateUser(ctx context.Context, db DBTX, user *models.User) error {
 	query := `
-		INSERT INTO users (email, name, picture_url, google_id)
+		INSERT INTO users (email, email_verified, name, picture_url)
 		VALUES ($1, $2, $3, $4)
 		RETURNING id, created_at, updated_at
 	`
 
 	err := db.QueryRowContext(ctx, query,
 		user.Email,
+		user.EmailVerified,
 		user.Name,
 		user.PictureURL,
-		user.GoogleID,
 	).Scan(&user.ID, &user.CreatedAt, &user.UpdatedAt)
 
 	if err != nil {
@@ -31,41 +31,11 @@ func CreateUser(ctx context.Context, db DBTX, user *models.User) error {
 	return nil
 }
 
-// GetUserByGoogleID는 Google ID로 사용자를 조회합니다
-// 사용자를 찾지 못한 경우 nil을 반환합니다
-func GetUserByGoogleID(ctx context.Context, db DBTX, googleID string) (*models.User, error) {
-	query := `
-		SELECT id, email, name, picture_url, google_id, created_at, updated_at
-		FROM users
-		WHERE google_id = $1
-	`
-
-	var user models.User
-	err := db.QueryRowContext(ctx, query, googleID).Scan(
-		&user.ID,
-		&user.Email,
-		&user.Name,
-		&user.PictureURL,
-		&user.GoogleID,
-		&user.CreatedAt,
-		&user.UpdatedAt,
-	)
-
-	if err == sql.ErrNoRows {
-		return nil, nil // 사용자를 찾지 못한 경우 nil 반환
-	}
-	if err != nil {
-		return nil, fmt.Errorf("failed to get user by google_id: %w", err)
-	}
-
-	return &user, nil
-}
-
 // GetUserByEmail은 이메일로 사용자를 조회합니다
 // 사용자를 찾지 못한 경우 nil을 반환합니다
 func GetUserByEmail(ctx context.Context, db DBTX, email string) (*models.User, error) {
 	query := `
-		SELECT id, email, name, picture_url, google_id, created_at, updated_at
+		SELECT id, email, email_verified, name, picture_url, created_at, updated_at
 		FROM users
 		WHERE email = $1
 	`
@@ -74,9 +44,9 @@ func GetUserByEmail(ctx context.Context, db DBTX, email string) (*models.User, e
 	err := db.QueryRowContext(ctx, query, email).Scan(
 		&user.ID,
 		&user.Email,
+		&user.EmailVerified,
 		&user.Name,
 		&user.PictureURL,
-		&user.GoogleID,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 	)
@@ -95,7 +65,7 @@ func GetUserByEmail(ctx context.Context, db DBTX, email string) (*models.User, e
 // 사용자를 찾지 못한 경우 nil을 반환합니다
 func GetUserByID(ctx context.Context, db DBTX, id int64) (*models.User, error) {
 	query := `
-		SELECT id, email, name, picture_url, google_id, created_at, updated_at
+		SELECT id, email, email_verified, name, picture_url, created_at, updated_at
 		FROM users
 		WHERE id = $1
 	`
@@ -104,9 +74,9 @@ func GetUserByID(ctx context.Context, db DBTX, id int64) (*models.User, error) {
 	err := db.QueryRowContext(ctx, query, id).Scan(
 		&user.ID,
 		&user.Email,
+		&user.EmailVerified,
 		&user.Name,
 		&user.PictureURL,
-		&user.GoogleID,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 	)
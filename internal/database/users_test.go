@@ -23,7 +23,6 @@ func TestCreateUser(t *testing.T) {
 			Email:      "test@example.com",
 			Name:       "Test User",
 			PictureURL: "https://example.com/pic.jpg",
-			GoogleID:   "google-id-123",
 		}
 
 		// When: CreateUser 호출
@@ -44,38 +43,6 @@ func TestCreateUser(t *testing.T) {
 		}
 	})
 
-	t.Run("중복된 Google ID는 에러 반환", func(t *testing.T) {
-		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
-		defer cleanup()
-
-		// Given: 이미 존재하는 Google ID
-		googleID := "google-id-duplicate"
-		user1 := &models.User{
-			Email:    "user1@example.com",
-			Name:     "User 1",
-			GoogleID: googleID,
-		}
-
-		// 첫 번째 사용자 생성
-		err := CreateUser(ctx, tx, user1)
-		if err != nil {
-			t.Fatalf("failed to create first user: %v", err)
-		}
-
-		// When: 같은 Google ID로 두 번째 사용자 생성 시도
-		user2 := &models.User{
-			Email:    "user2@example.com",
-			Name:     "User 2",
-			GoogleID: googleID,
-		}
-		err = CreateUser(ctx, tx, user2)
-
-		// Then: 에러 반환
-		if err == nil {
-			t.Fatal("expected error for duplicate google_id, got nil")
-		}
-	})
-
 	t.Run("중복된 Email은 에러 반환", func(t *testing.T) {
 		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
 		defer cleanup()
@@ -83,9 +50,8 @@ func TestCreateUser(t *testing.T) {
 		// Given: 이미 존재하는 Email
 		email := "duplicate@example.com"
 		user1 := &models.User{
-			Email:    email,
-			Name:     "User 1",
-			GoogleID: "google-id-1",
+			Email: email,
+			Name:  "User 1",
 		}
 
 		// 첫 번째 사용자 생성
@@ -96,9 +62,8 @@ func TestCreateUser(t *testing.T) {
 
 		// When: 같은 Email로 두 번째 사용자 생성 시도
 		user2 := &models.User{
-			Email:    email,
-			Name:     "User 2",
-			GoogleID: "google-id-2",
+			Email: email,
+			Name:  "User 2",
 		}
 		err = CreateUser(ctx, tx, user2)
 
@@ -109,70 +74,6 @@ func TestCreateUser(t *testing.T) {
 	})
 }
 
-// TestGetUserByGoogleID는 GetUserByGoogleID 메서드를 테스트합니다
-func TestGetUserByGoogleID(t *testing.T) {
-	db := testhelpers.SetupTestDB(t)
-	defer Close(db)
-
-	t.Run("존재하는 사용자 조회 성공", func(t *testing.T) {
-		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
-		defer cleanup()
-		// Given: 테스트 사용자 생성
-		googleID := "google-id-exists"
-		email := "exists@example.com"
-		name := "Existing User"
-
-		user := &models.User{
-			Email:    email,
-			Name:     name,
-			GoogleID: googleID,
-		}
-		err := CreateUser(ctx, tx, user)
-		if err != nil {
-			t.Fatalf("failed to create test user: %v", err)
-		}
-
-		// When: GetUserByGoogleID 호출
-		foundUser, err := GetUserByGoogleID(ctx, tx, googleID)
-
-		// Then: 사용자 조회 성공
-		if err != nil {
-			t.Fatalf("expected no error, got: %v", err)
-		}
-		if foundUser == nil {
-			t.Fatal("expected user, got nil")
-		}
-		if foundUser.Email != email {
-			t.Errorf("expected email=%s, got %s", email, foundUser.Email)
-		}
-		if foundUser.Name != name {
-			t.Errorf("expected name=%s, got %s", name, foundUser.Name)
-		}
-		if foundUser.GoogleID != googleID {
-			t.Errorf("expected google_id=%s, got %s", googleID, foundUser.GoogleID)
-		}
-	})
-
-	t.Run("존재하지 않는 Google ID는 nil 반환", func(t *testing.T) {
-		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
-		defer cleanup()
-
-		// Given: 존재하지 않는 Google ID
-		nonExistentGoogleID := "google-id-nonexistent"
-
-		// When: GetUserByGoogleID 호출
-		user, err := GetUserByGoogleID(ctx, tx, nonExistentGoogleID)
-
-		// Then: nil 반환, 에러 없음
-		if err != nil {
-			t.Fatalf("expected no error, got: %v", err)
-		}
-		if user != nil {
-			t.Errorf("expected nil user, got: %+v", user)
-		}
-	})
-}
-
 // TestGetUserByEmail는 GetUserByEmail 메서드를 테스트합니다
 func TestGetUserByEmail(t *testing.T) {
 	db := testhelpers.SetupTestDB(t)
@@ -184,12 +85,10 @@ func TestGetUserByEmail(t *testing.T) {
 		// Given: 테스트 사용자 생성
 		email := "email@example.com"
 		name := "Email User"
-		googleID := "google-id-email"
 
 		user := &models.User{
-			Email:    email,
-			Name:     name,
-			GoogleID: googleID,
+			Email: email,
+			Name:  name,
 		}
 		err := CreateUser(ctx, tx, user)
 		if err != nil {
@@ -247,9 +146,8 @@ func TestGetUserByID(t *testing.T) {
 		name := "ID User"
 
 		user := &models.User{
-			Email:    email,
-			Name:     name,
-			GoogleID: "google-id-for-id",
+			Email: email,
+			Name:  name,
 		}
 		err := CreateUser(ctx, tx, user)
 		if err != nil {
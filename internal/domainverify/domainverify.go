@@ -0,0 +1,274 @@
+package domainverify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// 검증 방식
+const (
+	MethodDNS  = "dns"  // DNS TXT 레코드
+	MethodHTTP = "http" // well-known HTTP 파일
+)
+
+const (
+	// DNSRecordPrefix는 검증용 TXT 레코드를 등록할 서브도메인입니다 (_orbithall.<도메인>)
+	DNSRecordPrefix = "_orbithall."
+
+	// DNSValuePrefix는 TXT 레코드 값의 prefix입니다 (orbithall-verification=<토큰>)
+	DNSValuePrefix = "orbithall-verification="
+
+	// HTTPFilePath는 검증 토큰을 게시할 파일 경로입니다 (https://<도메인>/.well-known/orbithall-verification.txt)
+	HTTPFilePath = "/.well-known/orbithall-verification.txt"
+)
+
+// maxHTTPFileSize는 검증 파일에서 읽는 최대 바이트 수입니다
+const maxHTTPFileSize = 1024
+
+var (
+	// ErrInvalidDomain은 도메인이 검증할 수 있는 호스트 이름 형식이 아닐 때 반환됩니다
+	ErrInvalidDomain = errors.New("invalid domain")
+
+	// ErrNotVerified는 DNS TXT 레코드와 HTTP 파일 어디에서도 검증 토큰을 찾지 못했을 때 반환됩니다
+	ErrNotVerified = errors.New("domain verification token not found")
+)
+
+// Resolver는 DNS TXT 레코드를 조회합니다 (*net.Resolver 호환)
+type Resolver interface {
+	LookupTXT(ctx context.Context, name string) ([]string, error)
+}
+
+// Fetcher는 URL의 본문을 가져옵니다
+// 본문은 최대 maxBytes까지만 읽습니다
+type Fetcher interface {
+	Fetch(ctx context.Context, url string, maxBytes int64) ([]byte, error)
+}
+
+// Verifier는 사이트 도메인의 소유권을 검증합니다
+// DNS TXT 레코드를 먼저 확인하고, 없으면 well-known HTTP 파일을 확인합니다
+type Verifier struct {
+	resolver Resolver
+	fetcher  Fetcher
+}
+
+// New는 resolver와 fetcher로 Verifier를 생성합니다
+// 테스트에서 네트워크 없이 검증하려면 가짜 구현을 전달합니다
+func New(resolver Resolver, fetcher Fetcher) *Verifier {
+	return &Verifier{resolver: resolver, fetcher: fetcher}
+}
+
+// NewDefault는 시스템 DNS resolver와 HTTPFetcher를 사용하는 Verifier를 생성합니다
+func NewDefault() *Verifier {
+	return New(net.DefaultResolver, NewHTTPFetcher(10*time.Second))
+}
+
+// Instructions는 사이트 관리자에게 안내하는 검증 방법입니다
+type Instructions struct {
+	Token           string `json:"token"`
+	DNSRecordName   string `json:"dns_record_name"`
+	DNSRecordValue  string `json:"dns_record_value"`
+	HTTPFileURL     string `json:"http_file_url"`
+	HTTPFileContent string `json:"http_file_content"`
+}
+
+// InstructionsFor는 도메인과 토큰의 검증 방법을 반환합니다
+func InstructionsFor(domain, token string) Instructions {
+	domain = normalizeDomain(domain)
+	return Instructions{
+		Token:           token,
+		DNSRecordName:   DNSRecordPrefix + domain,
+		DNSRecordValue:  DNSValuePrefix + token,
+		HTTPFileURL:     "https://" + domain + HTTPFilePath,
+		HTTPFileContent: token,
+	}
+}
+
+// Verify는 도메인에 검증 토큰이 게시되었는지 확인하고 확인된 방식을 반환합니다
+// 두 방식 모두 토큰을 찾지 못하면 ErrNotVerified를 감싼 오류를 반환합니다 (조회 실패 원인 포함)
+func (v *Verifier) Verify(ctx context.Context, domain, token string) (string, error) {
+	domain = normalizeDomain(domain)
+	if !isValidHostname(domain) {
+		return "", ErrInvalidDomain
+	}
+	if token == "" {
+		return "", ErrNotVerified
+	}
+
+	instructions := InstructionsFor(domain, token)
+
+	records, dnsErr := v.resolver.LookupTXT(ctx, instructions.DNSRecordName)
+	for _, record := range records {
+		if strings.TrimSpace(record) == instructions.DNSRecordValue {
+			return MethodDNS, nil
+		}
+	}
+
+	body, httpErr := v.fetcher.Fetch(ctx, instructions.HTTPFileURL, maxHTTPFileSize)
+	if httpErr == nil && strings.TrimSpace(string(body)) == instructions.HTTPFileContent {
+		return MethodHTTP, nil
+	}
+
+	// 조회 실패 원인은 관리자가 설정을 확인할 수 있도록 함께 반환
+	var causes []string
+	if dnsErr != nil {
+		causes = append(causes, fmt.Sprintf("dns: %v", dnsErr))
+	}
+	if httpErr != nil {
+		causes = append(causes, fmt.Sprintf("http: %v", httpErr))
+	}
+	if len(causes) == 0 {
+		return "", ErrNotVerified
+	}
+	return "", fmt.Errorf("%w (%s)", ErrNotVerified, strings.Join(causes, "; "))
+}
+
+// normalizeDomain은 도메인의 공백과 끝의 점을 제거하고 소문자로 변환합니다
+func normalizeDomain(domain string) string {
+	return strings.ToLower(strings.TrimSuffix(strings.TrimSpace(domain), "."))
+}
+
+// isValidHostname은 도메인이 점으로 구분된 호스트 이름인지 확인합니다
+// IP 주소, 포트, 경로, 사용자 정보가 포함된 값은 허용하지 않습니다
+func isValidHostname(domain string) bool {
+	if len(domain) == 0 || len(domain) > 253 || !strings.Contains(domain, ".") {
+		return false
+	}
+	if _, err := netip.ParseAddr(domain); err == nil {
+		return false
+	}
+
+	for _, label := range strings.Split(domain, ".") {
+		if len(label) == 0 || len(label) > 63 || label[0] == '-' || label[len(label)-1] == '-' {
+			return false
+		}
+		for _, c := range label {
+			if !(c >= 'a' && c <= 'z' || c >= '0' && c <= '9' || c == '-') {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// HTTPFetcher는 HTTPS로 검증 파일을 가져오는 Fetcher 구현입니다
+// 도메인이 내부 주소로 해석되어 서버 내부 네트워크에 요청하지 않도록 공인 IP 주소로만 연결하며,
+// 리다이렉트는 같은 호스트 안에서만 따라갑니다
+type HTTPFetcher struct {
+	client *http.Client
+}
+
+// NewHTTPFetcher는 요청당 timeout이 적용되는 HTTPFetcher를 생성합니다
+func NewHTTPFetcher(timeout time.Duration) *HTTPFetcher {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: func(network, address string, c syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			addr, err := netip.ParseAddr(host)
+			if err != nil {
+				return err
+			}
+			if !isPublicAddr(addr.Unmap()) {
+				return fmt.Errorf("refusing to connect to non-public address %s", addr)
+			}
+			return nil
+		},
+	}
+
+	transport := &http.Transport{
+		DialContext:         dialer.DialContext,
+		TLSHandshakeTimeout: timeout,
+	}
+
+	return &HTTPFetcher{
+		client: &http.Client{
+			Timeout:   timeout,
+			Transport: transport,
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				if len(via) >= 3 {
+					return errors.New("too many redirects")
+				}
+				if req.URL.Host != via[0].URL.Host {
+					return fmt.Errorf("redirect to another host %s is not allowed", req.URL.Host)
+				}
+				return nil
+			},
+		},
+	}
+}
+
+// Fetch는 URL을 GET으로 요청하고 본문을 최대 maxBytes까지 반환합니다
+// 200 이외의 응답은 오류로 처리합니다
+func (f *HTTPFetcher) Fetch(ctx context.Context, url string, maxBytes int64) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	return io.ReadAll(io.LimitReader(resp.Body, maxBytes))
+}
+
+// reservedPrefixes는 stdlib 판별 함수가 걸러내지 않는 특수 용도 대역입니다 (IANA Special-Purpose Address Registry)
+// 공유 주소(CGNAT), 문서/벤치마크용, NAT64/6to4처럼 내부 주소로 이어질 수 있는 변환 대역을 포함합니다
+var reservedPrefixes = []netip.Prefix{
+	// IPv4
+	netip.MustParsePrefix("0.0.0.0/8"),       // "이 네트워크"
+	netip.MustParsePrefix("100.64.0.0/10"),   // 공유 주소 (CGNAT)
+	netip.MustParsePrefix("192.0.0.0/24"),    // IETF 프로토콜 할당
+	netip.MustParsePrefix("192.0.2.0/24"),    // 문서용 (TEST-NET-1)
+	netip.MustParsePrefix("192.88.99.0/24"),  // 6to4 릴레이 (폐기)
+	netip.MustParsePrefix("198.18.0.0/15"),   // 벤치마크
+	netip.MustParsePrefix("198.51.100.0/24"), // 문서용 (TEST-NET-2)
+	netip.MustParsePrefix("203.0.113.0/24"),  // 문서용 (TEST-NET-3)
+	netip.MustParsePrefix("240.0.0.0/4"),     // 예약 (브로드캐스트 포함)
+
+	// IPv6
+	netip.MustParsePrefix("64:ff9b::/96"),   // NAT64
+	netip.MustParsePrefix("64:ff9b:1::/48"), // 로컬 NAT64
+	netip.MustParsePrefix("100::/64"),       // 폐기용 (discard)
+	netip.MustParsePrefix("2001::/23"),      // IETF 프로토콜 할당 (Teredo 포함)
+	netip.MustParsePrefix("2001:db8::/32"),  // 문서용
+	netip.MustParsePrefix("2002::/16"),      // 6to4
+	netip.MustParsePrefix("3fff::/20"),      // 문서용
+	netip.MustParsePrefix("fec0::/10"),      // 사이트 로컬 (폐기)
+}
+
+// isPublicAddr은 주소가 인터넷에서 접근 가능한 공인 주소인지 확인합니다
+func isPublicAddr(addr netip.Addr) bool {
+	if !addr.IsValid() ||
+		addr.IsLoopback() ||
+		addr.IsPrivate() ||
+		addr.IsLinkLocalUnicast() ||
+		addr.IsLinkLocalMulticast() ||
+		addr.IsInterfaceLocalMulticast() ||
+		addr.IsMulticast() ||
+		addr.IsUnspecified() {
+		return false
+	}
+	for _, prefix := range reservedPrefixes {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,207 @@
+package domainverify
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeResolver는 고정된 TXT 레코드를 반환하는 테스트용 Resolver입니다
+type fakeResolver struct {
+	records map[string][]string
+	queried []string
+}
+
+func (r *fakeResolver) LookupTXT(ctx context.Context, name string) ([]string, error) {
+	r.queried = append(r.queried, name)
+	records, ok := r.records[name]
+	if !ok {
+		return nil, errors.New("no such host")
+	}
+	return records, nil
+}
+
+// fakeFetcher는 고정된 본문을 반환하는 테스트용 Fetcher입니다
+type fakeFetcher struct {
+	bodies  map[string]string
+	fetched []string
+}
+
+func (f *fakeFetcher) Fetch(ctx context.Context, url string, maxBytes int64) ([]byte, error) {
+	f.fetched = append(f.fetched, url)
+	body, ok := f.bodies[url]
+	if !ok {
+		return nil, errors.New("unexpected status 404")
+	}
+	return []byte(body), nil
+}
+
+// TestVerify는 DNS TXT 레코드와 HTTP 파일로 도메인을 검증하는지 테스트합니다
+func TestVerify(t *testing.T) {
+	const token = "abc123"
+
+	t.Run("DNS TXT 레코드로 검증", func(t *testing.T) {
+		// Given: _orbithall.<도메인>에 토큰 TXT 레코드가 있음 (다른 레코드와 함께)
+		resolver := &fakeResolver{records: map[string][]string{
+			"_orbithall.blog.example.com": {"v=spf1 -all", "orbithall-verification=abc123"},
+		}}
+		fetcher := &fakeFetcher{}
+
+		// When: 대소문자와 끝의 점이 섞인 도메인으로 검증
+		method, err := New(resolver, fetcher).Verify(context.Background(), "Blog.Example.com.", token)
+
+		// Then: DNS 방식으로 검증되고 HTTP 파일은 확인하지 않음
+		if err != nil || method != MethodDNS {
+			t.Fatalf("expected dns verification, got %q, %v", method, err)
+		}
+		if len(fetcher.fetched) != 0 {
+			t.Errorf("expected no http fetch, got %v", fetcher.fetched)
+		}
+	})
+
+	t.Run("DNS 레코드가 없으면 HTTP 파일로 검증", func(t *testing.T) {
+		// Given: well-known 파일에 토큰이 있음 (줄바꿈 포함)
+		resolver := &fakeResolver{}
+		fetcher := &fakeFetcher{bodies: map[string]string{
+			"https://blog.example.com/.well-known/orbithall-verification.txt": "abc123\n",
+		}}
+
+		// When
+		method, err := New(resolver, fetcher).Verify(context.Background(), "blog.example.com", token)
+
+		// Then
+		if err != nil || method != MethodHTTP {
+			t.Fatalf("expected http verification, got %q, %v", method, err)
+		}
+	})
+
+	t.Run("토큰이 다르면 실패", func(t *testing.T) {
+		// Given: 다른 토큰이 게시됨
+		resolver := &fakeResolver{records: map[string][]string{
+			"_orbithall.blog.example.com": {"orbithall-verification=other"},
+		}}
+		fetcher := &fakeFetcher{}
+
+		// When
+		_, err := New(resolver, fetcher).Verify(context.Background(), "blog.example.com", token)
+
+		// Then: ErrNotVerified와 HTTP 조회 실패 원인
+		if !errors.Is(err, ErrNotVerified) {
+			t.Fatalf("expected ErrNotVerified, got %v", err)
+		}
+		if !strings.Contains(err.Error(), "http: unexpected status 404") {
+			t.Errorf("expected http cause in error, got %v", err)
+		}
+	})
+
+	t.Run("호스트 이름이 아닌 도메인은 조회하지 않음", func(t *testing.T) {
+		for _, domain := range []string{"", "localhost", "192.168.0.1", "evil.com/path", "user@example.com", "example.com:8080", "-bad.example.com"} {
+			resolver := &fakeResolver{}
+			fetcher := &fakeFetcher{}
+
+			_, err := New(resolver, fetcher).Verify(context.Background(), domain, token)
+
+			if !errors.Is(err, ErrInvalidDomain) {
+				t.Errorf("%q: expected ErrInvalidDomain, got %v", domain, err)
+			}
+			if len(resolver.queried) != 0 || len(fetcher.fetched) != 0 {
+				t.Errorf("%q: expected no lookups", domain)
+			}
+		}
+	})
+}
+
+// TestInstructionsFor는 검증 방법 안내를 테스트합니다
+func TestInstructionsFor(t *testing.T) {
+	instructions := InstructionsFor("Blog.Example.com", "abc123")
+
+	if instructions.DNSRecordName != "_orbithall.blog.example.com" {
+		t.Errorf("unexpected dns record name: %s", instructions.DNSRecordName)
+	}
+	if instructions.DNSRecordValue != "orbithall-verification=abc123" {
+		t.Errorf("unexpected dns record value: %s", instructions.DNSRecordValue)
+	}
+	if instructions.HTTPFileURL != "https://blog.example.com/.well-known/orbithall-verification.txt" {
+		t.Errorf("unexpected http file url: %s", instructions.HTTPFileURL)
+	}
+	if instructions.HTTPFileContent != "abc123" {
+		t.Errorf("unexpected http file content: %s", instructions.HTTPFileContent)
+	}
+}
+
+// TestHTTPFetcher_RefusesNonPublicAddress는 내부 주소로는 연결하지 않는지 테스트합니다
+func TestHTTPFetcher_RefusesNonPublicAddress(t *testing.T) {
+	// Given: 루프백 주소에서 실행 중인 서버
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("abc123"))
+	}))
+	defer server.Close()
+
+	// When: 서버에 요청
+	_, err := NewHTTPFetcher(time.Second).Fetch(context.Background(), server.URL, maxHTTPFileSize)
+
+	// Then: 연결 거부
+	if err == nil || !strings.Contains(err.Error(), "non-public address") {
+		t.Errorf("expected non-public address error, got %v", err)
+	}
+}
+
+// TestIsPublicAddr는 공인 주소 판별을 테스트합니다
+func TestIsPublicAddr(t *testing.T) {
+	tests := []struct {
+		addr     string
+		expected bool
+	}{
+		// 공인 주소
+		{"8.8.8.8", true},
+		{"1.1.1.1", true},
+		{"2606:4700:4700::1111", true},
+
+		// stdlib 판별 함수로 거부
+		{"127.0.0.1", false},
+		{"10.0.0.1", false},
+		{"172.16.0.1", false},
+		{"192.168.0.1", false},
+		{"169.254.169.254", false},
+		{"224.0.0.1", false},
+		{"::1", false},
+		{"fd00::1", false},
+		{"fe80::1", false},
+		{"::", false},
+
+		// 특수 용도 대역으로 거부
+		{"0.1.2.3", false},
+		{"100.64.0.1", false},
+		{"100.127.255.254", false},
+		{"192.0.0.8", false},
+		{"192.0.2.1", false},
+		{"192.88.99.1", false},
+		{"198.18.0.1", false},
+		{"198.19.255.254", false},
+		{"198.51.100.1", false},
+		{"203.0.113.1", false},
+		{"240.0.0.1", false},
+		{"255.255.255.255", false},
+		{"64:ff9b::a00:1", false},
+		{"64:ff9b:1::1", false},
+		{"100::1", false},
+		{"2001::1", false},
+		{"2001:db8::1", false},
+		{"2002:a00:1::1", false},
+		{"3fff::1", false},
+		{"fec0::1", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.addr, func(t *testing.T) {
+			if got := isPublicAddr(netip.MustParseAddr(tt.addr)); got != tt.expected {
+				t.Errorf("isPublicAddr(%s) = %v, want %v", tt.addr, got, tt.expected)
+			}
+		})
+	}
+}
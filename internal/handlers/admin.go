@@ -8,22 +8,41 @@ import (
 
 	"github.com/go-chi/chi/v5"
 	"github.com/june20516/orbithall/internal/database"
+	"github.com/june20516/orbithall/internal/domainverify"
 	"github.com/june20516/orbithall/internal/models"
+	"github.com/june20516/orbithall/internal/notify"
 	"github.com/june20516/orbithall/internal/validators"
 )
 
 // AdminHandler는 Admin API 요청을 처리합니다
 type AdminHandler struct {
-	db database.DBTX
+	db       database.DBTX
+	verifier *domainverify.Verifier
+	notifier notify.Notifier
 }
 
 // NewAdminHandler는 AdminHandler의 새 인스턴스를 생성합니다
+// 도메인 소유권 검증은 시스템 DNS와 HTTPS 요청을 사용하고 (SetDomainVerifier로 변경 가능),
+// 사용자 알림은 서버 로그에 기록합니다 (SetNotifier로 변경 가능)
 func NewAdminHandler(db database.DBTX) *AdminHandler {
 	return &AdminHandler{
-		db: db,
+		db:       db,
+		verifier: domainverify.NewDefault(),
+		notifier: notify.LogNotifier{},
 	}
 }
 
+// SetDomainVerifier는 도메인 소유권 검증에 사용할 Verifier를 설정합니다
+// 테스트에서 네트워크 없이 검증하려면 가짜 resolver/fetcher로 만든 Verifier를 전달합니다
+func (h *AdminHandler) SetDomainVerifier(verifier *domainverify.Verifier) {
+	h.verifier = verifier
+}
+
+// SetNotifier는 사용자 알림(소유권 이전 등)에 사용할 Notifier를 설정합니다
+func (h *AdminHandler) SetNotifier(notifier notify.Notifier) {
+	h.notifier = notifier
+}
+
 // ListSitesResponse는 사이트 목록 응답입니다
 type ListSitesResponse struct {
 	Sites []models.Site `json:"sites"`
@@ -31,7 +50,7 @@ type ListSitesResponse struct {
 
 // ListSites는 JWT 인증된 사용자의 사이트 목록을 반환합니다
 // @Summary      내 사이트 목록 조회
-// @Description  JWT 인증된 사용자가 소유한 모든 사이트 목록을 반환합니다
+// @Description  JWT 인증된 사용자가 소유한 모든 사이트 목록을 반환합니다. verification_token은 사이트 관리 권한이 있는 사이트에만 포함됩니다
 // @Tags         admin
 // @Accept       json
 // @Produce      json
@@ -63,7 +82,7 @@ func (h *AdminHandler) ListSites(w http.ResponseWriter, r *http.Request) {
 
 // GetSite는 특정 사이트 상세 정보를 반환합니다
 // @Summary      사이트 상세 조회
-// @Description  특정 사이트의 상세 정보를 반환합니다 (소유자만 접근 가능)
+// @Description  특정 사이트의 상세 정보를 반환합니다 (소유자만 접근 가능). verification_token은 사이트 관리 권한이 있는 멤버에게만 포함됩니다
 // @Tags         admin
 // @Accept       json
 // @Produce      json
@@ -103,7 +122,7 @@ func (h *AdminHandler) GetSite(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 접근 권한 확인
-	hasAccess, err := database.HasUserSiteAccess(r.Context(), h.db, user.ID, siteID)
+	hasAccess, err := database.HasUserSitePermission(r.Context(), h.db, user.ID, siteID, models.PermissionSiteView)
 	if err != nil {
 		http.Error(w, "Failed to check access", http.StatusInternalServerError)
 		return
@@ -114,6 +133,16 @@ func (h *AdminHandler) GetSite(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// 검증 토큰은 사이트 관리 권한이 있는 멤버에게만 반환
+	canManage, err := database.HasUserSitePermission(r.Context(), h.db, user.ID, siteID, models.PermissionSiteManage)
+	if err != nil {
+		http.Error(w, "Failed to check access", http.StatusInternalServerError)
+		return
+	}
+	if !canManage {
+		site.VerificationToken = ""
+	}
+
 	// 응답 반환
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
@@ -122,7 +151,7 @@ func (h *AdminHandler) GetSite(w http.ResponseWriter, r *http.Request) {
 
 // CreateSite는 새 사이트를 생성합니다
 // @Summary      사이트 생성
-// @Description  새로운 사이트를 생성하고 API Key를 발급합니다
+// @Description  새로운 사이트를 생성하고 API Key를 발급합니다. 사이트는 비활성 상태로 생성되며, 도메인 소유권을 검증(POST /admin/sites/{id}/verification)하면 활성화됩니다. 다른 사이트가 이미 검증한 도메인은 등록할 수 없습니다.
 // @Tags         admin
 // @Accept       json
 // @Produce      json
@@ -130,6 +159,7 @@ func (h *AdminHandler) GetSite(w http.ResponseWriter, r *http.Request) {
 // @Success      201 {object} models.Site
 // @Failure      400 {object} map[string]interface{} "Invalid input"
 // @Failure      401 {string} string "Unauthorized"
+// @Failure      409 {string} string "Domain already registered"
 // @Failure      500 {string} string "Failed to create site"
 // @Security     BearerAuth
 // @Router       /admin/sites [post]
@@ -164,15 +194,31 @@ func (h *AdminHandler) CreateSite(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// 사이트 생성
+	// 다른 사이트가 소유권을 검증한 도메인은 등록 불가
+	domainVerified, err := database.IsDomainVerified(r.Context(), h.db, input.Domain)
+	if err != nil {
+		http.Error(w, "Failed to check domain", http.StatusInternalServerError)
+		return
+	}
+	if domainVerified {
+		http.Error(w, "Domain already registered", http.StatusConflict)
+		return
+	}
+
+	// 사이트 생성 (도메인 소유권 검증 전까지 비활성)
 	site := &models.Site{
 		Name:        input.Name,
 		Domain:      input.Domain,
 		CORSOrigins: input.CORSOrigins,
-		IsActive:    true,
+		IsActive:    false,
 	}
 
-	err := database.CreateSiteForUser(r.Context(), h.db, site, user.ID)
+	err = database.RunInTx(r.Context(), h.db, func(tx database.DBTX) error {
+		if err := database.CreateSiteForUser(r.Context(), tx, site, user.ID); err != nil {
+			return err
+		}
+		return recordAudit(r, tx, site.ID, models.AuditActionSiteCreate, models.AuditTargetSite, site.ID, nil, newSiteAuditState(site))
+	})
 	if err != nil {
 		http.Error(w, "Failed to create site", http.StatusInternalServerError)
 		return
@@ -186,7 +232,7 @@ func (h *AdminHandler) CreateSite(w http.ResponseWriter, r *http.Request) {
 
 // UpdateSite는 사이트 정보를 수정합니다
 // @Summary      사이트 수정
-// @Description  사이트 정보를 수정합니다 (소유자만 접근 가능, domain과 api_key는 수정 불가)
+// @Description  사이트 정보를 수정합니다 (소유자만 접근 가능, domain은 수정 불가, API 키는 API 키 관리 API 사용, rate_limits로 작업별 요청 제한 설정, 도메인 소유권을 검증하지 않은 사이트는 활성화 불가)
 // @Tags         admin
 // @Accept       json
 // @Produce      json
@@ -197,6 +243,7 @@ func (h *AdminHandler) CreateSite(w http.ResponseWriter, r *http.Request) {
 // @Failure      401 {string} string "Unauthorized"
 // @Failure      403 {string} string "Forbidden"
 // @Failure      404 {string} string "Site not found"
+// @Failure      409 {string} string "Domain must be verified before activation"
 // @Failure      500 {string} string "Failed to update site"
 // @Security     BearerAuth
 // @Router       /admin/sites/{id} [put]
@@ -240,7 +287,7 @@ func (h *AdminHandler) UpdateSite(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 접근 권한 확인
-	hasAccess, err := database.HasUserSiteAccess(r.Context(), h.db, user.ID, siteID)
+	hasAccess, err := database.HasUserSitePermission(r.Context(), h.db, user.ID, siteID, models.PermissionSiteManage)
 	if err != nil {
 		http.Error(w, "Failed to check access", http.StatusInternalServerError)
 		return
@@ -277,8 +324,50 @@ func (h *AdminHandler) UpdateSite(w http.ResponseWriter, r *http.Request) {
 		isActive = *input.IsActive
 	}
 
-	// 사이트 수정
-	err = database.UpdateSite(r.Context(), h.db, siteID, name, corsOrigins, isActive)
+	// 도메인 소유권을 검증하지 않은 사이트는 활성화 불가
+	if isActive && !site.IsVerified {
+		http.Error(w, "Domain must be verified before activation", http.StatusConflict)
+		return
+	}
+
+	// 사이트 수정 (자동 마감 일수, 보관 기간, 요청 제한은 제공된 경우에만 함께 수정)
+	var updatedSite *models.Site
+	err = database.RunInTx(r.Context(), h.db, func(tx database.DBTX) error {
+		if err := database.UpdateSite(r.Context(), tx, siteID, name, corsOrigins, isActive); err != nil {
+			return err
+		}
+		if input.AutoCloseDays != nil {
+			if err := database.UpdateSiteAutoCloseDays(r.Context(), tx, siteID, *input.AutoCloseDays); err != nil {
+				return err
+			}
+		}
+		if input.PersonalDataRetentionDays != nil || input.DeletedContentRetentionDays != nil {
+			personalDays := site.PersonalDataRetentionDays
+			deletedDays := site.DeletedContentRetentionDays
+			if input.PersonalDataRetentionDays != nil {
+				personalDays = *input.PersonalDataRetentionDays
+			}
+			if input.DeletedContentRetentionDays != nil {
+				deletedDays = *input.DeletedContentRetentionDays
+			}
+			if err := database.UpdateSiteRetention(r.Context(), tx, siteID, personalDays, deletedDays); err != nil {
+				return err
+			}
+		}
+		if input.RateLimits != nil {
+			if err := database.UpdateSiteRateLimits(r.Context(), tx, siteID, *input.RateLimits); err != nil {
+				return err
+			}
+		}
+
+		// 수정된 사이트 재조회 후 변경 전/후 상태를 감사 로그에 기록
+		var err error
+		updatedSite, err = database.GetSiteByID(r.Context(), tx, siteID)
+		if err != nil {
+			return err
+		}
+		return recordAudit(r, tx, siteID, models.AuditActionSiteUpdate, models.AuditTargetSite, siteID, newSiteAuditState(site), newSiteAuditState(updatedSite))
+	})
 	if err != nil {
 		if err == sql.ErrNoRows {
 			http.Error(w, "Site not found", http.StatusNotFound)
@@ -288,13 +377,6 @@ func (h *AdminHandler) UpdateSite(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// 수정된 사이트 재조회
-	updatedSite, err := database.GetSiteByID(r.Context(), h.db, siteID)
-	if err != nil {
-		http.Error(w, "Failed to get updated site", http.StatusInternalServerError)
-		return
-	}
-
 	// 200 OK 응답
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
@@ -333,7 +415,7 @@ func (h *AdminHandler) DeleteSite(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 접근 권한 확인
-	hasAccess, err := database.HasUserSiteAccess(r.Context(), h.db, user.ID, siteID)
+	hasAccess, err := database.HasUserSitePermission(r.Context(), h.db, user.ID, siteID, models.PermissionSiteDelete)
 	if err != nil {
 		http.Error(w, "Failed to check access", http.StatusInternalServerError)
 		return
@@ -344,8 +426,17 @@ func (h *AdminHandler) DeleteSite(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// 사이트 삭제
-	err = database.DeleteSite(r.Context(), h.db, siteID)
+	// 사이트 삭제 (삭제 전 상태를 감사 로그에 기록)
+	err = database.RunInTx(r.Context(), h.db, func(tx database.DBTX) error {
+		site, err := database.GetSiteByID(r.Context(), tx, siteID)
+		if err != nil {
+			return err
+		}
+		if err := database.DeleteSite(r.Context(), tx, siteID); err != nil {
+			return err
+		}
+		return recordAudit(r, tx, siteID, models.AuditActionSiteDelete, models.AuditTargetSite, siteID, newSiteAuditState(site), nil)
+	})
 	if err != nil {
 		if err == sql.ErrNoRows {
 			http.Error(w, "Site not found", http.StatusNotFound)
@@ -385,11 +476,12 @@ func (h *AdminHandler) GetProfile(w http.ResponseWriter, r *http.Request) {
 
 // GetSiteStats는 특정 사이트의 통계를 반환합니다
 // @Summary      사이트 통계 조회
-// @Description  특정 사이트의 포스트 수, 댓글 수, 삭제된 댓글 수를 반환합니다
+// @Description  특정 사이트의 포스트 수, 댓글 수, 삭제된 댓글 수를 반환합니다. 테스트 모드 키로 작성된 샌드박스 데이터는 sandbox=true일 때만 (샌드박스 데이터만) 집계합니다.
 // @Tags         admin
 // @Accept       json
 // @Produce      json
 // @Param        id path int true "Site ID"
+// @Param        sandbox query bool false "true면 샌드박스 데이터의 통계 조회"
 // @Success      200 {object} models.SiteStats
 // @Failure      400 {string} string "Invalid site ID"
 // @Failure      403 {string} string "Forbidden"
@@ -413,7 +505,7 @@ func (h *AdminHandler) GetSiteStats(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 사용자가 해당 사이트에 접근 권한이 있는지 확인
-	hasAccess, err := database.HasUserSiteAccess(r.Context(), h.db, user.ID, siteID)
+	hasAccess, err := database.HasUserSitePermission(r.Context(), h.db, user.ID, siteID, models.PermissionSiteView)
 	if err != nil {
 		http.Error(w, "Failed to check site access", http.StatusInternalServerError)
 		return
@@ -424,8 +516,9 @@ func (h *AdminHandler) GetSiteStats(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// 통계 조회
-	stats, err := database.GetSiteStats(r.Context(), h.db, siteID)
+	// 통계 조회 (기본값: 실제 데이터)
+	sandbox := r.URL.Query().Get("sandbox") == "true"
+	stats, err := database.GetSiteStats(r.Context(), h.db, siteID, sandbox)
 	if err != nil {
 		http.Error(w, "Failed to get site stats", http.StatusInternalServerError)
 		return
@@ -439,11 +532,12 @@ func (h *AdminHandler) GetSiteStats(w http.ResponseWriter, r *http.Request) {
 
 // ListSitePosts는 특정 사이트의 포스트 목록을 반환합니다
 // @Summary      사이트 포스트 목록 조회
-// @Description  특정 사이트의 포스트 목록을 활성/삭제 댓글 수와 함께 반환합니다
+// @Description  특정 사이트의 포스트 목록을 활성/삭제 댓글 수와 함께 반환합니다. 테스트 모드 키로 생성된 샌드박스 포스트는 sandbox=true일 때만 (샌드박스 포스트만) 반환합니다.
 // @Tags         admin
 // @Accept       json
 // @Produce      json
 // @Param        id path int true "Site ID"
+// @Param        sandbox query bool false "true면 샌드박스 포스트 목록 조회"
 // @Success      200 {array} models.Post
 // @Failure      400 {string} string "Invalid site ID"
 // @Failure      403 {string} string "Forbidden"
@@ -467,7 +561,7 @@ func (h *AdminHandler) ListSitePosts(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 사용자가 해당 사이트에 접근 권한이 있는지 확인
-	hasAccess, err := database.HasUserSiteAccess(r.Context(), h.db, user.ID, siteID)
+	hasAccess, err := database.HasUserSitePermission(r.Context(), h.db, user.ID, siteID, models.PermissionSiteView)
 	if err != nil {
 		http.Error(w, "Failed to check site access", http.StatusInternalServerError)
 		return
@@ -478,8 +572,9 @@ func (h *AdminHandler) ListSitePosts(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// 포스트 목록 조회
-	posts, err := database.ListPostsBySite(r.Context(), h.db, siteID)
+	// 포스트 목록 조회 (기본값: 실제 포스트)
+	sandbox := r.URL.Query().Get("sandbox") == "true"
+	posts, err := database.ListPostsBySite(r.Context(), h.db, siteID, sandbox)
 	if err != nil {
 		http.Error(w, "Failed to get posts", http.StatusInternalServerError)
 		return
@@ -499,6 +594,7 @@ func (h *AdminHandler) ListSitePosts(w http.ResponseWriter, r *http.Request) {
 // @Produce      json
 // @Param        slug path string true "Post Slug"
 // @Param        site_id query int true "Site ID"
+// @Param        sandbox query bool false "true면 샌드박스 포스트에서 조회"
 // @Param        limit query int false "댓글 개수 (기본값: 50)"
 // @Param        offset query int false "오프셋 (기본값: 0)"
 // @Success      200 {object} object{comments=[]models.Comment,total=int}
@@ -536,7 +632,7 @@ func (h *AdminHandler) GetPostComments(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 사용자가 해당 사이트에 접근 권한이 있는지 확인
-	hasAccess, err := database.HasUserSiteAccess(r.Context(), h.db, user.ID, siteID)
+	hasAccess, err := database.HasUserSitePermission(r.Context(), h.db, user.ID, siteID, models.PermissionCommentModerate)
 	if err != nil {
 		http.Error(w, "Failed to check site access", http.StatusInternalServerError)
 		return
@@ -547,8 +643,9 @@ func (h *AdminHandler) GetPostComments(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// 포스트 조회
-	post, err := database.GetPostBySlug(r.Context(), h.db, siteID, slug)
+	// 포스트 조회 (기본값: 실제 포스트)
+	sandbox := r.URL.Query().Get("sandbox") == "true"
+	post, err := database.GetPostBySlug(r.Context(), h.db, siteID, slug, sandbox)
 	if err != nil {
 		http.Error(w, "Failed to get post", http.StatusInternalServerError)
 		return
@@ -574,7 +671,7 @@ func (h *AdminHandler) GetPostComments(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	// Admin용 댓글 조회 (삭제된 것 포함, IP 마스킹 없음)
+	// Admin용 댓글 조회 (삭제된 것 포함, 암호화된 IP 복호화)
 	comments, total, err := database.GetAdminComments(r.Context(), h.db, post.ID, limit, offset)
 	if err != nil {
 		http.Error(w, "Failed to get comments", http.StatusInternalServerError)
@@ -583,11 +680,9 @@ func (h *AdminHandler) GetPostComments(w http.ResponseWriter, r *http.Request) {
 
 	// Admin은 전체 IP와 마스킹된 IP 모두 볼 수 있음
 	for _, comment := range comments {
-		comment.IPAddressMasked = models.MaskIPAddress(comment.IPAddress)
 		comment.IPAddressUnmasked = comment.IPAddress
 		// 대댓글도 동일하게 처리
 		for _, reply := range comment.Replies {
-			reply.IPAddressMasked = models.MaskIPAddress(reply.IPAddress)
 			reply.IPAddressUnmasked = reply.IPAddress
 		}
 	}
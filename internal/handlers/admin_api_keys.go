@@ -0,0 +1,299 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/june20516/orbithall/internal/database"
+	"github.com/june20516/orbithall/internal/models"
+	"github.com/june20516/orbithall/internal/validators"
+)
+
+// ListAPIKeysResponse는 API 키 목록 응답입니다
+type ListAPIKeysResponse struct {
+	APIKeys []models.SiteAPIKey `json:"api_keys"`
+}
+
+// RotateAPIKeyResponse는 API 키 교체 응답입니다
+type RotateAPIKeyResponse struct {
+	APIKey         *models.SiteAPIKey `json:"api_key"`
+	PreviousAPIKey *models.SiteAPIKey `json:"previous_api_key"`
+}
+
+// apiKeyAuditState는 감사 로그에 기록하는 API 키 상태입니다
+// 키 값은 감사 로그에 남기지 않습니다
+type apiKeyAuditState struct {
+	Label          string     `json:"label"`
+	TestMode       bool       `json:"test_mode"`
+	Secret         bool       `json:"secret"`
+	Scopes         []string   `json:"scopes"`
+	AllowedOrigins []string   `json:"allowed_origins"`
+	ExpiresAt      *time.Time `json:"expires_at"`
+	RevokedAt      *time.Time `json:"revoked_at"`
+}
+
+// newAPIKeyAuditState는 API 키의 감사 로그 상태를 만듭니다
+func newAPIKeyAuditState(key *models.SiteAPIKey) *apiKeyAuditState {
+	return &apiKeyAuditState{
+		Label:          key.Label,
+		TestMode:       key.TestMode,
+		Secret:         key.Secret,
+		Scopes:         key.Scopes,
+		AllowedOrigins: key.AllowedOrigins,
+		ExpiresAt:      key.ExpiresAt,
+		RevokedAt:      key.RevokedAt,
+	}
+}
+
+// ListSiteAPIKeys는 사이트의 API 키 목록을 반환합니다
+// @Summary      API 키 목록 조회
+// @Description  사이트에 발급된 모든 API 키를 반환합니다. 폐기되거나 만료된 키도 포함됩니다. 비밀 키의 값은 마스킹됩니다.
+// @Tags         admin
+// @Produce      json
+// @Param        id path int true "Site ID"
+// @Success      200 {object} ListAPIKeysResponse
+// @Failure      400 {string} string "Invalid site ID"
+// @Failure      401 {string} string "Unauthorized"
+// @Failure      403 {string} string "Forbidden"
+// @Failure      500 {string} string "Failed to get api keys"
+// @Security     BearerAuth
+// @Router       /admin/sites/{id}/api-keys [get]
+func (h *AdminHandler) ListSiteAPIKeys(w http.ResponseWriter, r *http.Request) {
+	siteID, ok := h.authorizeSite(w, r, models.PermissionSiteManage)
+	if !ok {
+		return
+	}
+
+	// API 키 목록 조회
+	keys, err := database.ListSiteAPIKeys(r.Context(), h.db, siteID)
+	if err != nil {
+		http.Error(w, "Failed to get api keys", http.StatusInternalServerError)
+		return
+	}
+
+	// 비밀 키 값은 발급/교체 응답에서만 공개
+	for i := range keys {
+		keys[i] = keys[i].Masked()
+	}
+
+	// 응답 반환
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(ListAPIKeysResponse{APIKeys: keys})
+}
+
+// CreateSiteAPIKey는 사이트에 새 API 키를 발급합니다
+// @Summary      API 키 발급
+// @Description  사이트에 라벨이 붙은 새 API 키를 발급합니다. allowed_origins를 지정하면 이 키로는 해당 Origin에서만 요청할 수 있고, 지정하지 않으면 사이트의 cors_origins를 사용합니다. expires_at을 지정하면 그 시각 이후 키가 거부됩니다. test_mode=true면 orb_test_ 키가 발급되며, 이 키로 작성한 포스트와 댓글은 실제 데이터와 분리된 샌드박스에 저장됩니다. secret=true면 서버 API 전용 비밀 키(orb_secret_)가 발급되며, scopes로 허용할 작업을 지정해야 합니다. 비밀 키 값은 이 응답에서만 전체가 반환됩니다.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        id  path int                          true "Site ID"
+// @Param        key body validators.APIKeyCreateInput true "발급할 키 정보"
+// @Success      201 {object} models.SiteAPIKey
+// @Failure      400 {object} map[string]interface{} "Invalid input"
+// @Failure      401 {string} string "Unauthorized"
+// @Failure      403 {string} string "Forbidden"
+// @Failure      500 {string} string "Failed to create api key"
+// @Security     BearerAuth
+// @Router       /admin/sites/{id}/api-keys [post]
+func (h *AdminHandler) CreateSiteAPIKey(w http.ResponseWriter, r *http.Request) {
+	// Content-Type 검증
+	if r.Header.Get("Content-Type") != "application/json" {
+		http.Error(w, "Content-Type must be application/json", http.StatusBadRequest)
+		return
+	}
+
+	// JSON 요청 파싱
+	var input validators.APIKeyCreateInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	// 입력 검증
+	if err := input.Validate(); err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	siteID, ok := h.authorizeSite(w, r, models.PermissionSiteManage)
+	if !ok {
+		return
+	}
+
+	// API 키 발급
+	key := &models.SiteAPIKey{
+		SiteID:         siteID,
+		Label:          strings.TrimSpace(input.Label),
+		TestMode:       input.TestMode,
+		Secret:         input.Secret,
+		Scopes:         input.Scopes,
+		AllowedOrigins: input.AllowedOrigins,
+		ExpiresAt:      input.ExpiresAt,
+	}
+	err := database.RunInTx(r.Context(), h.db, func(tx database.DBTX) error {
+		if err := database.CreateSiteAPIKey(r.Context(), tx, key); err != nil {
+			return err
+		}
+		return recordAudit(r, tx, siteID, models.AuditActionAPIKeyCreate, models.AuditTargetAPIKey, key.ID, nil, newAPIKeyAuditState(key))
+	})
+	if err != nil {
+		http.Error(w, "Failed to create api key", http.StatusInternalServerError)
+		return
+	}
+
+	// 201 Created 응답
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(key)
+}
+
+// RotateSiteAPIKey는 API 키를 새 키로 교체합니다
+// @Summary      API 키 교체
+// @Description  같은 라벨, 허용 Origin, 종류, scope를 가진 새 키를 발급하고, 이전 키는 유예 기간(grace_period_hours, 기본 24시간) 동안만 사용할 수 있게 합니다. 유예 기간 동안 두 키가 모두 동작하므로 새 키를 배포한 뒤 이전 키가 자연스럽게 만료됩니다. grace_period_hours가 0이면 이전 키는 즉시 만료됩니다. 요청 본문은 생략할 수 있습니다.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        id     path int                          true  "Site ID"
+// @Param        keyId  path int                          true  "API Key ID"
+// @Param        rotate body validators.APIKeyRotateInput false "유예 기간"
+// @Success      200 {object} RotateAPIKeyResponse
+// @Failure      400 {object} map[string]interface{} "Invalid input"
+// @Failure      401 {string} string "Unauthorized"
+// @Failure      403 {string} string "Forbidden"
+// @Failure      404 {string} string "API key not found"
+// @Failure      409 {string} string "API key is revoked or expired"
+// @Failure      500 {string} string "Failed to rotate api key"
+// @Security     BearerAuth
+// @Router       /admin/sites/{id}/api-keys/{keyId}/rotate [post]
+func (h *AdminHandler) RotateSiteAPIKey(w http.ResponseWriter, r *http.Request) {
+	// JSON 요청 파싱 (본문 생략 가능)
+	var input validators.APIKeyRotateInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil && err != io.EOF {
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	// 입력 검증
+	if err := input.Validate(); err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	siteID, keyID, ok := h.authorizeSiteAPIKey(w, r)
+	if !ok {
+		return
+	}
+
+	// 키 교체 (이전 키 캐시 즉시 제거)
+	var newKey, oldKey *models.SiteAPIKey
+	err := database.RunInTx(r.Context(), h.db, func(tx database.DBTX) error {
+		var err error
+		newKey, oldKey, err = database.RotateSiteAPIKey(r.Context(), tx, siteID, keyID, input.GracePeriod())
+		if err != nil {
+			return err
+		}
+		after := map[string]any{
+			"new_api_key_id":   newKey.ID,
+			"previous_api_key": newAPIKeyAuditState(oldKey),
+		}
+		return recordAudit(r, tx, siteID, models.AuditActionAPIKeyRotate, models.AuditTargetAPIKey, keyID, nil, after)
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			http.Error(w, "API key not found", http.StatusNotFound)
+			return
+		}
+		if errors.Is(err, database.ErrAPIKeyInactive) {
+			http.Error(w, "API key is revoked or expired", http.StatusConflict)
+			return
+		}
+		http.Error(w, "Failed to rotate api key", http.StatusInternalServerError)
+		return
+	}
+
+	// 200 OK 응답
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	previous := oldKey.Masked()
+	json.NewEncoder(w).Encode(RotateAPIKeyResponse{
+		APIKey:         newKey,
+		PreviousAPIKey: &previous,
+	})
+}
+
+// RevokeSiteAPIKey는 API 키를 즉시 폐기합니다
+// @Summary      API 키 폐기
+// @Description  API 키를 즉시 폐기합니다. 폐기된 키로 보낸 요청은 INVALID_API_KEY로 거부됩니다. 키가 유출된 경우 사용합니다.
+// @Tags         admin
+// @Param        id    path int true "Site ID"
+// @Param        keyId path int true "API Key ID"
+// @Success      204 "No Content"
+// @Failure      400 {string} string "Invalid ID"
+// @Failure      401 {string} string "Unauthorized"
+// @Failure      403 {string} string "Forbidden"
+// @Failure      404 {string} string "API key not found"
+// @Failure      500 {string} string "Failed to revoke api key"
+// @Security     BearerAuth
+// @Router       /admin/sites/{id}/api-keys/{keyId} [delete]
+func (h *AdminHandler) RevokeSiteAPIKey(w http.ResponseWriter, r *http.Request) {
+	siteID, keyID, ok := h.authorizeSiteAPIKey(w, r)
+	if !ok {
+		return
+	}
+
+	// 키 폐기 (캐시 즉시 제거)
+	err := database.RunInTx(r.Context(), h.db, func(tx database.DBTX) error {
+		key, err := database.RevokeSiteAPIKey(r.Context(), tx, siteID, keyID)
+		if err != nil {
+			return err
+		}
+		return recordAudit(r, tx, siteID, models.AuditActionAPIKeyRevoke, models.AuditTargetAPIKey, keyID, nil, newAPIKeyAuditState(key))
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			http.Error(w, "API key not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, "Failed to revoke api key", http.StatusInternalServerError)
+		return
+	}
+
+	// 204 No Content 응답
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// authorizeSiteAPIKey는 URL의 사이트/API 키 ID를 검증하고 사용자의 접근 권한을 확인합니다
+// 키가 해당 사이트에 속하는지는 database 함수가 site_id 조건으로 확인합니다
+// 실패 시 에러 응답을 작성하고 false를 반환합니다
+func (h *AdminHandler) authorizeSiteAPIKey(w http.ResponseWriter, r *http.Request) (int64, int64, bool) {
+	// URL 파라미터에서 key_id 추출
+	keyID, err := strconv.ParseInt(chi.URLParam(r, "keyId"), 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid API key ID", http.StatusBadRequest)
+		return 0, 0, false
+	}
+
+	siteID, ok := h.authorizeSite(w, r, models.PermissionSiteManage)
+	if !ok {
+		return 0, 0, false
+	}
+
+	return siteID, keyID, true
+}
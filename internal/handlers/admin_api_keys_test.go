@@ -0,0 +1,146 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/june20516/orbithall/internal/database"
+	"github.com/june20516/orbithall/internal/models"
+	"github.com/june20516/orbithall/internal/testhelpers"
+)
+
+// newSiteAPIKeyRequest는 /admin/sites/{id}/api-keys[/{keyId}] 요청을 생성합니다
+// keyID가 0이면 keyId 파라미터를 추가하지 않습니다
+func newSiteAPIKeyRequest(ctx context.Context, user *models.User, method string, siteID, keyID int64, body []byte) *http.Request {
+	site := strconv.FormatInt(siteID, 10)
+	if keyID == 0 {
+		return newAdminRequest(ctx, user, method, "/admin/sites/"+site+"/api-keys", string(body), "id", site)
+	}
+	key := strconv.FormatInt(keyID, 10)
+	return newAdminRequest(ctx, user, method, "/admin/sites/"+site+"/api-keys/"+key, string(body), "id", site, "keyId", key)
+}
+
+// TestSiteAPIKeyHandlers는 API 키 발급, 교체, 폐기 API를 테스트합니다
+func TestSiteAPIKeyHandlers(t *testing.T) {
+	db := testhelpers.SetupTestDB(t)
+	defer database.Close(db)
+
+	t.Run("키 발급 후 목록에 포함", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		// Given: 기본 키가 있는 사이트
+		user, site, _ := setupSitePostTest(t, ctx, tx, "keys-create@example.com")
+		handler := NewAdminHandler(tx)
+
+		// When: 라벨과 허용 Origin을 지정하여 키 발급
+		bodyBytes, _ := json.Marshal(map[string]interface{}{
+			"label":           "staging",
+			"allowed_origins": []string{"https://staging.post-site.com"},
+		})
+		rec := httptest.NewRecorder()
+		handler.CreateSiteAPIKey(rec, newSiteAPIKeyRequest(ctx, user, http.MethodPost, site.ID, 0, bodyBytes))
+
+		// Then: 201 Created, 목록에 2개
+		if rec.Code != http.StatusCreated {
+			t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusCreated, rec.Code, rec.Body.String())
+		}
+		var created models.SiteAPIKey
+		json.NewDecoder(rec.Body).Decode(&created)
+		if created.Label != "staging" || created.Key == "" || len(created.AllowedOrigins) != 1 {
+			t.Errorf("Unexpected key: %+v", created)
+		}
+
+		listRec := httptest.NewRecorder()
+		handler.ListSiteAPIKeys(listRec, newSiteAPIKeyRequest(ctx, user, http.MethodGet, site.ID, 0, nil))
+		var response ListAPIKeysResponse
+		json.NewDecoder(listRec.Body).Decode(&response)
+		if len(response.APIKeys) != 2 {
+			t.Errorf("Expected 2 keys, got %d", len(response.APIKeys))
+		}
+	})
+
+	t.Run("키 폐기 후 API 요청 거부", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		// Given: 한 번 사용되어 캐시된 기본 키
+		user, site, _ := setupSitePostTest(t, ctx, tx, "keys-revoke@example.com")
+		defer database.InvalidateAPIKey(site.APIKey)
+		if _, err := database.GetSiteByAPIKey(ctx, tx, site.APIKey); err != nil {
+			t.Fatalf("Failed to get site: %v", err)
+		}
+		keys, _ := database.ListSiteAPIKeys(ctx, tx, site.ID)
+
+		// When: 키 폐기
+		rec := httptest.NewRecorder()
+		NewAdminHandler(tx).RevokeSiteAPIKey(rec, newSiteAPIKeyRequest(ctx, user, http.MethodDelete, site.ID, keys[0].ID, nil))
+
+		// Then: 204 No Content, 이후 요청은 INVALID_API_KEY
+		if rec.Code != http.StatusNoContent {
+			t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusNoContent, rec.Code, rec.Body.String())
+		}
+
+		apiReq := httptest.NewRequest(http.MethodGet, "/api/posts/admin-post/comments", nil)
+		apiReq.Header.Set("X-Orbithall-API-Key", site.APIKey)
+		apiRec := httptest.NewRecorder()
+		AuthMiddleware(tx)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		})).ServeHTTP(apiRec, apiReq)
+		if apiRec.Code != http.StatusForbidden {
+			t.Errorf("Expected status %d, got %d", http.StatusForbidden, apiRec.Code)
+		}
+	})
+
+	t.Run("키 교체 - 본문 없이 기본 유예 기간 적용", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		// Given: 기본 키
+		user, site, _ := setupSitePostTest(t, ctx, tx, "keys-rotate@example.com")
+		keys, _ := database.ListSiteAPIKeys(ctx, tx, site.ID)
+
+		// When: 본문 없이 교체
+		req := newSiteAPIKeyRequest(ctx, user, http.MethodPost, site.ID, keys[0].ID, nil)
+		rec := httptest.NewRecorder()
+		NewAdminHandler(tx).RotateSiteAPIKey(rec, req)
+
+		// Then: 200 OK, 새 키 발급, 이전 키는 만료 예정
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, rec.Code, rec.Body.String())
+		}
+		var response RotateAPIKeyResponse
+		json.NewDecoder(rec.Body).Decode(&response)
+		defer database.InvalidateAPIKey(response.APIKey.Key)
+		defer database.InvalidateAPIKey(response.PreviousAPIKey.Key)
+		if response.APIKey.Key == "" || response.APIKey.Key == site.APIKey {
+			t.Errorf("Expected new key, got %+v", response.APIKey)
+		}
+		if response.PreviousAPIKey.ExpiresAt == nil {
+			t.Error("Expected previous key expiry to be set")
+		}
+	})
+
+	t.Run("권한 없음 - 다른 사용자의 사이트", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		// Given: 다른 사용자
+		_, site, _ := setupSitePostTest(t, ctx, tx, "keys-owner@example.com")
+		other := &models.User{Email: "keys-other@example.com", Name: "Other"}
+		database.CreateUser(ctx, tx, other)
+
+		// When: 다른 사용자가 키 목록 조회
+		rec := httptest.NewRecorder()
+		NewAdminHandler(tx).ListSiteAPIKeys(rec, newSiteAPIKeyRequest(ctx, other, http.MethodGet, site.ID, 0, nil))
+
+		// Then: 403 Forbidden
+		if rec.Code != http.StatusForbidden {
+			t.Errorf("Expected status %d, got %d", http.StatusForbidden, rec.Code)
+		}
+	})
+}
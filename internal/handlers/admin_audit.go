@@ -0,0 +1,183 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+
+	"github.com/june20516/orbithall/internal/database"
+	"github.com/june20516/orbithall/internal/httputil"
+	"github.com/june20516/orbithall/internal/models"
+)
+
+// 감사 로그 조회 페이지 크기
+const (
+	defaultAuditLogLimit = 50
+	maxAuditLogLimit     = 200
+)
+
+// ListAuditLogResponse는 감사 로그 조회 응답입니다
+type ListAuditLogResponse struct {
+	Entries []models.AuditLogEntry `json:"entries"`
+	Total   int                    `json:"total"`
+	Limit   int                    `json:"limit"`
+	Offset  int                    `json:"offset"`
+}
+
+// siteAuditState는 감사 로그에 기록하는 사이트 상태입니다
+// API 키는 감사 로그에 남기지 않습니다
+type siteAuditState struct {
+	Name                        string                `json:"name"`
+	Domain                      string                `json:"domain"`
+	CORSOrigins                 []string              `json:"cors_origins"`
+	IsActive                    bool                  `json:"is_active"`
+	IsVerified                  bool                  `json:"is_verified"`
+	AutoCloseDays               int                   `json:"auto_close_days"`
+	PersonalDataRetentionDays   int                   `json:"personal_data_retention_days"`
+	DeletedContentRetentionDays int                   `json:"deleted_content_retention_days"`
+	RateLimits                  models.SiteRateLimits `json:"rate_limits"`
+}
+
+// newSiteAuditState는 사이트의 감사 로그 상태를 만듭니다
+// site가 nil이면 nil을 반환합니다 (감사 로그에는 NULL로 저장)
+func newSiteAuditState(site *models.Site) *siteAuditState {
+	if site == nil {
+		return nil
+	}
+	return &siteAuditState{
+		Name:                        site.Name,
+		Domain:                      site.Domain,
+		CORSOrigins:                 site.CORSOrigins,
+		IsActive:                    site.IsActive,
+		IsVerified:                  site.IsVerified,
+		AutoCloseDays:               site.AutoCloseDays,
+		PersonalDataRetentionDays:   site.PersonalDataRetentionDays,
+		DeletedContentRetentionDays: site.DeletedContentRetentionDays,
+		RateLimits:                  site.RateLimits,
+	}
+}
+
+// postAuditState는 감사 로그에 기록하는 포스트 상태입니다
+type postAuditState struct {
+	Slug     string `json:"slug"`
+	Title    string `json:"title"`
+	URL      string `json:"url"`
+	IsLocked bool   `json:"is_locked"`
+}
+
+// newPostAuditState는 포스트의 감사 로그 상태를 만듭니다
+func newPostAuditState(post *models.Post) *postAuditState {
+	return &postAuditState{
+		Slug:     post.Slug,
+		Title:    post.Title,
+		URL:      post.URL,
+		IsLocked: post.IsLocked,
+	}
+}
+
+// recordAudit는 관리자 작업을 감사 로그에 기록합니다
+// 작업자는 Admin API면 로그인한 관리자, 서버 API면 요청에 사용한 비밀 키입니다
+// 작업과 함께 커밋/롤백되도록 작업과 같은 트랜잭션(tx)을 전달합니다
+// before/after가 nil이면 해당 상태는 기록하지 않습니다
+func recordAudit(r *http.Request, tx database.DBTX, siteID int64, action, targetType string, targetID int64, before, after any) error {
+	entry := &models.AuditLogEntry{
+		SiteID:     siteID,
+		Action:     action,
+		TargetType: targetType,
+		TargetID:   targetID,
+		IPAddress:  httputil.GetIPAddress(r),
+	}
+
+	if user, ok := r.Context().Value(userContextKey).(*models.User); ok {
+		entry.ActorUserID = &user.ID
+	} else if site := GetSiteFromContext(r.Context()); site != nil && site.APIKeyID != 0 {
+		entry.ActorAPIKeyID = &site.APIKeyID
+	}
+
+	var err error
+	if entry.Before, err = marshalAuditState(before); err != nil {
+		return err
+	}
+	if entry.After, err = marshalAuditState(after); err != nil {
+		return err
+	}
+
+	return database.CreateAuditLog(r.Context(), tx, entry)
+}
+
+// marshalAuditState는 감사 로그 상태를 JSON으로 변환합니다
+// nil이거나 nil 포인터(생성 전/삭제 후 상태)이면 JSON null 대신 nil을 반환해 SQL NULL로 저장합니다
+func marshalAuditState(state any) (json.RawMessage, error) {
+	if state == nil {
+		return nil, nil
+	}
+	if v := reflect.ValueOf(state); v.Kind() == reflect.Pointer && v.IsNil() {
+		return nil, nil
+	}
+	data, err := json.Marshal(state)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal audit state: %w", err)
+	}
+	return data, nil
+}
+
+// ListSiteAuditLog는 사이트의 관리자 작업 감사 로그를 반환합니다
+// @Summary      감사 로그 조회
+// @Description  사이트 설정 변경, 포스트/댓글 관리 등 관리자 작업 기록을 최신순으로 반환합니다. 각 항목에는 작업한 관리자(서버 API 작업은 사용한 비밀 키), 작업 종류, 대상, 변경 전/후 상태, 요청 IP, 시각이 포함됩니다.
+// @Tags         admin
+// @Produce      json
+// @Param        id     path  int true  "Site ID"
+// @Param        limit  query int false "페이지 크기 (기본 50, 최대 200)"
+// @Param        offset query int false "건너뛸 항목 수 (기본 0)"
+// @Success      200 {object} ListAuditLogResponse
+// @Failure      400 {string} string "Invalid site ID or pagination"
+// @Failure      401 {string} string "Unauthorized"
+// @Failure      403 {string} string "Forbidden"
+// @Failure      500 {string} string "Failed to get audit log"
+// @Security     BearerAuth
+// @Router       /admin/sites/{id}/audit [get]
+func (h *AdminHandler) ListSiteAuditLog(w http.ResponseWriter, r *http.Request) {
+	siteID, ok := h.authorizeSite(w, r, models.PermissionSiteManage)
+	if !ok {
+		return
+	}
+
+	// 페이지네이션 파라미터 파싱
+	limit := defaultAuditLogLimit
+	if value := r.URL.Query().Get("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 || parsed > maxAuditLogLimit {
+			http.Error(w, "Invalid limit", http.StatusBadRequest)
+			return
+		}
+		limit = parsed
+	}
+	offset := 0
+	if value := r.URL.Query().Get("offset"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 0 {
+			http.Error(w, "Invalid offset", http.StatusBadRequest)
+			return
+		}
+		offset = parsed
+	}
+
+	// 감사 로그 조회
+	entries, total, err := database.ListAuditLog(r.Context(), h.db, siteID, limit, offset)
+	if err != nil {
+		http.Error(w, "Failed to get audit log", http.StatusInternalServerError)
+		return
+	}
+
+	// 200 OK 응답
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(ListAuditLogResponse{
+		Entries: entries,
+		Total:   total,
+		Limit:   limit,
+		Offset:  offset,
+	})
+}
@@ -0,0 +1,178 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/june20516/orbithall/internal/database"
+	"github.com/june20516/orbithall/internal/models"
+	"github.com/june20516/orbithall/internal/testhelpers"
+)
+
+// newSiteAuditRequest는 /admin/sites/{id}/audit 요청을 생성합니다
+func newSiteAuditRequest(ctx context.Context, user *models.User, siteID int64, query string) *http.Request {
+	site := strconv.FormatInt(siteID, 10)
+	return newAdminRequest(ctx, user, http.MethodGet, "/admin/sites/"+site+"/audit"+query, "", "id", site)
+}
+
+// TestListSiteAuditLog는 관리자 작업 감사 로그 기록과 조회를 테스트합니다
+func TestListSiteAuditLog(t *testing.T) {
+	db := testhelpers.SetupTestDB(t)
+	defer database.Close(db)
+
+	t.Run("포스트 잠금이 변경 전/후 상태와 함께 기록됨", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		// Given: 포스트 잠금
+		user, site, post := setupSitePostTest(t, ctx, tx, "audit-admin@example.com")
+		handler := NewAdminHandler(tx)
+		lockRec := httptest.NewRecorder()
+		handler.LockSitePost(lockRec, newSitePostRequest(ctx, user, http.MethodPost, site.ID, post.ID, nil))
+		if lockRec.Code != http.StatusOK {
+			t.Fatalf("Failed to lock post: %d %s", lockRec.Code, lockRec.Body.String())
+		}
+
+		// When: 감사 로그 조회
+		rec := httptest.NewRecorder()
+		handler.ListSiteAuditLog(rec, newSiteAuditRequest(ctx, user, site.ID, ""))
+
+		// Then: 잠금 기록 1건
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, rec.Code, rec.Body.String())
+		}
+		var response ListAuditLogResponse
+		if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+		if response.Total != 1 || len(response.Entries) != 1 {
+			t.Fatalf("Expected 1 entry, got %+v", response)
+		}
+
+		entry := response.Entries[0]
+		if entry.Action != models.AuditActionPostLock || entry.TargetType != models.AuditTargetPost || entry.TargetID != post.ID {
+			t.Errorf("Unexpected entry: %+v", entry)
+		}
+		if entry.ActorUserID == nil || *entry.ActorUserID != user.ID {
+			t.Errorf("Expected actor %d, got %v", user.ID, entry.ActorUserID)
+		}
+
+		var before, after postAuditState
+		json.Unmarshal(entry.Before, &before)
+		json.Unmarshal(entry.After, &after)
+		if before.IsLocked || !after.IsLocked {
+			t.Errorf("Expected is_locked false -> true, got %s -> %s", entry.Before, entry.After)
+		}
+	})
+
+	t.Run("사이트 수정 기록에 API 키를 남기지 않음", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		// Given: 사이트 이름 수정
+		user, site, _ := setupSitePostTest(t, ctx, tx, "audit-site@example.com")
+		handler := NewAdminHandler(tx)
+		bodyBytes, _ := json.Marshal(map[string]string{"name": "Renamed Site"})
+		req := httptest.NewRequest(http.MethodPut, "/admin/sites/"+strconv.FormatInt(site.ID, 10), bytes.NewBuffer(bodyBytes))
+		req.Header.Set("Content-Type", "application/json")
+		req = req.WithContext(newSiteAuditRequest(ctx, user, site.ID, "").Context())
+		updateRec := httptest.NewRecorder()
+		handler.UpdateSite(updateRec, req)
+		if updateRec.Code != http.StatusOK {
+			t.Fatalf("Failed to update site: %d %s", updateRec.Code, updateRec.Body.String())
+		}
+
+		// When: 감사 로그 조회
+		rec := httptest.NewRecorder()
+		handler.ListSiteAuditLog(rec, newSiteAuditRequest(ctx, user, site.ID, ""))
+
+		// Then: 이름 변경 기록, API 키 없음
+		var response ListAuditLogResponse
+		json.NewDecoder(rec.Body).Decode(&response)
+		if len(response.Entries) != 1 || response.Entries[0].Action != models.AuditActionSiteUpdate {
+			t.Fatalf("Expected site.update entry, got %+v", response.Entries)
+		}
+
+		var before, after map[string]interface{}
+		json.Unmarshal(response.Entries[0].Before, &before)
+		json.Unmarshal(response.Entries[0].After, &after)
+		if before["name"] != "Post Site" || after["name"] != "Renamed Site" {
+			t.Errorf("Unexpected name change: %v -> %v", before["name"], after["name"])
+		}
+		if _, ok := after["api_key"]; ok {
+			t.Error("Expected api_key to be excluded from audit state")
+		}
+	})
+
+	t.Run("잘못된 limit이면 400", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		// Given: 사이트 소유자
+		user, site, _ := setupSitePostTest(t, ctx, tx, "audit-limit@example.com")
+
+		// When: limit 범위 초과
+		rec := httptest.NewRecorder()
+		NewAdminHandler(tx).ListSiteAuditLog(rec, newSiteAuditRequest(ctx, user, site.ID, "?limit=1000"))
+
+		// Then: 400 Bad Request
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("Expected status %d, got %d", http.StatusBadRequest, rec.Code)
+		}
+	})
+
+	t.Run("권한 없음 - 다른 사용자의 사이트", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		// Given: 다른 사용자
+		_, site, _ := setupSitePostTest(t, ctx, tx, "audit-owner@example.com")
+		other := &models.User{Email: "audit-other@example.com", Name: "Other"}
+		database.CreateUser(ctx, tx, other)
+
+		// When: 다른 사용자가 조회
+		rec := httptest.NewRecorder()
+		NewAdminHandler(tx).ListSiteAuditLog(rec, newSiteAuditRequest(ctx, other, site.ID, ""))
+
+		// Then: 403 Forbidden
+		if rec.Code != http.StatusForbidden {
+			t.Errorf("Expected status %d, got %d", http.StatusForbidden, rec.Code)
+		}
+	})
+}
+
+// TestMarshalAuditState는 감사 로그 상태 변환을 테스트합니다
+func TestMarshalAuditState(t *testing.T) {
+	var nilSite *models.Site
+
+	tests := []struct {
+		name     string
+		state    any
+		expected string
+	}{
+		{"nil", nil, ""},
+		{"nil 포인터는 JSON null이 아닌 nil", newSiteAuditState(nilSite), ""},
+		{"상태", &transferAuditState{FromEmail: "a@example.com"}, `"from_email":"a@example.com"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := marshalAuditState(tt.state)
+			if err != nil {
+				t.Fatalf("expected no error, got: %v", err)
+			}
+			if tt.expected == "" && data != nil {
+				t.Errorf("Expected nil, got %s", data)
+			}
+			if tt.expected != "" && !strings.Contains(string(data), tt.expected) {
+				t.Errorf("Expected %s in %s", tt.expected, data)
+			}
+		})
+	}
+}
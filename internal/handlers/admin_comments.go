@@ -0,0 +1,150 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/june20516/orbithall/internal/database"
+	"github.com/june20516/orbithall/internal/models"
+	"github.com/june20516/orbithall/internal/validators"
+)
+
+// RestoreSiteComment는 삭제된 댓글을 복구합니다
+// @Summary      댓글 복구
+// @Description  soft delete된 댓글을 복구합니다. 복구된 댓글은 다시 위젯에 표시되고 포스트의 댓글 수에 포함됩니다.
+// @Tags         admin
+// @Param        id        path int true "Site ID"
+// @Param        commentId path int true "Comment ID"
+// @Success      204 "No Content"
+// @Failure      400 {string} string "Invalid ID"
+// @Failure      401 {string} string "Unauthorized"
+// @Failure      403 {string} string "Forbidden"
+// @Failure      404 {string} string "Comment not found"
+// @Failure      500 {string} string "Failed to restore comment"
+// @Security     BearerAuth
+// @Router       /admin/sites/{id}/comments/{commentId}/restore [post]
+func (h *AdminHandler) RestoreSiteComment(w http.ResponseWriter, r *http.Request) {
+	// URL 파라미터에서 comment_id 추출
+	commentID, err := strconv.ParseInt(chi.URLParam(r, "commentId"), 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid comment ID", http.StatusBadRequest)
+		return
+	}
+
+	siteID, ok := h.authorizeSite(w, r, models.PermissionCommentModerate)
+	if !ok {
+		return
+	}
+
+	// 댓글이 해당 사이트에 속하는지 확인 (다른 사이트의 댓글은 존재하지 않는 것으로 취급)
+	comment, err := database.GetCommentByID(r.Context(), h.db, commentID)
+	if err != nil {
+		http.Error(w, "Failed to get comment", http.StatusInternalServerError)
+		return
+	}
+	if comment == nil {
+		http.Error(w, "Comment not found", http.StatusNotFound)
+		return
+	}
+	post, err := database.GetPostByID(r.Context(), h.db, comment.PostID)
+	if err != nil {
+		http.Error(w, "Failed to get post", http.StatusInternalServerError)
+		return
+	}
+	if post == nil || post.SiteID != siteID {
+		http.Error(w, "Comment not found", http.StatusNotFound)
+		return
+	}
+
+	// 댓글 복구 (댓글 수 증가 포함)
+	err = database.RunInTx(r.Context(), h.db, func(tx database.DBTX) error {
+		if err := database.RestoreComment(r.Context(), tx, commentID); err != nil {
+			return err
+		}
+		return recordAudit(r, tx, siteID, models.AuditActionCommentRestore, models.AuditTargetComment, commentID,
+			map[string]bool{"is_deleted": true}, map[string]bool{"is_deleted": false})
+	})
+	if err != nil {
+		if errors.Is(err, database.ErrCommentNotFound) {
+			http.Error(w, "Comment not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, "Failed to restore comment", http.StatusInternalServerError)
+		return
+	}
+
+	// 204 No Content 응답
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// EraseSiteComments는 사이트에서 조건에 일치하는 모든 댓글의 개인정보를 삭제합니다
+// @Summary      개인정보 삭제 요청 처리
+// @Description  작성자 이름, IP 주소, 비밀번호 조건에 모두 일치하는 사이트의 댓글을 찾아 개인정보를 삭제합니다. mode=anonymize(기본값)면 작성자 정보와 내용을 비우고 삭제 상태로 만들고, mode=delete면 댓글을 완전히 삭제합니다(다른 사람의 대댓글이 달린 댓글은 익명화). 비밀번호 조건은 작성자 이름과 함께 사용해야 합니다. dry_run=true면 변경하지 않고 대상만 보고합니다.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        id      path  int  true  "Site ID"
+// @Param        dry_run query bool false "true면 변경하지 않고 대상만 보고"
+// @Param        request body  validators.CommentErasureInput true "삭제 조건"
+// @Success      200 {object} models.CommentErasureReport
+// @Failure      400 {string} string "Invalid request body or validation error"
+// @Failure      401 {string} string "Unauthorized"
+// @Failure      403 {string} string "Forbidden"
+// @Failure      500 {string} string "Failed to erase comments"
+// @Security     BearerAuth
+// @Router       /admin/sites/{id}/erasure [post]
+func (h *AdminHandler) EraseSiteComments(w http.ResponseWriter, r *http.Request) {
+	siteID, ok := h.authorizeSite(w, r, models.PermissionCommentModerate)
+	if !ok {
+		return
+	}
+
+	// 요청 body 파싱
+	var input validators.CommentErasureInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	// 입력 검증
+	if err := input.Validate(); err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+		return
+	}
+
+	// IP 주소는 DB의 표기와 같도록 정규화 (예: IPv6 축약 표기)
+	ipAddress := strings.TrimSpace(input.IPAddress)
+	if ipAddress != "" {
+		ipAddress = net.ParseIP(ipAddress).String()
+	}
+	hardDelete := input.Mode == validators.ErasureModeDelete
+	dryRun := r.URL.Query().Get("dry_run") == "true"
+
+	// 개인정보 삭제 (댓글 수 재계산 포함)
+	// 감사 로그에는 삭제 조건(개인정보)을 남기지 않고 처리 결과만 기록
+	var report *models.CommentErasureReport
+	err := database.RunInTx(r.Context(), h.db, func(tx database.DBTX) error {
+		var err error
+		report, err = database.EraseComments(r.Context(), tx, siteID, strings.TrimSpace(input.AuthorName), ipAddress, input.Password, hardDelete, dryRun)
+		if err != nil || dryRun {
+			return err
+		}
+		return recordAudit(r, tx, siteID, models.AuditActionCommentErase, models.AuditTargetSite, siteID, nil, report)
+	})
+	if err != nil {
+		http.Error(w, "Failed to erase comments", http.StatusInternalServerError)
+		return
+	}
+
+	// 200 OK 응답
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(report)
+}
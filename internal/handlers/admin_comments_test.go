@@ -0,0 +1,196 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/june20516/orbithall/internal/database"
+	"github.com/june20516/orbithall/internal/models"
+	"github.com/june20516/orbithall/internal/testhelpers"
+)
+
+// newSiteCommentRequest는 /admin/sites/{id}/comments/{commentId}/... 요청을 생성합니다
+func newSiteCommentRequest(ctx context.Context, user *models.User, siteID, commentID int64) *http.Request {
+	site, comment := strconv.FormatInt(siteID, 10), strconv.FormatInt(commentID, 10)
+	return newAdminRequest(ctx, user, http.MethodPost, "/admin/sites/"+site+"/comments/"+comment+"/restore", "", "id", site, "commentId", comment)
+}
+
+// TestRestoreSiteComment는 삭제된 댓글 복구 기능을 테스트합니다
+func TestRestoreSiteComment(t *testing.T) {
+	db := testhelpers.SetupTestDB(t)
+	defer database.Close(db)
+
+	t.Run("복구 성공 및 댓글 수 증가", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		// Given: 삭제된 댓글
+		user, site, post := setupSitePostTest(t, ctx, tx, "restore@example.com")
+		comment, err := database.CreateComment(ctx, tx, post.ID, nil, "홍길동", "test1234", "댓글", "127.0.0.1", "test")
+		if err != nil {
+			t.Fatalf("Failed to create comment: %v", err)
+		}
+		if err := database.DeleteComment(ctx, tx, comment.ID); err != nil {
+			t.Fatalf("Failed to delete comment: %v", err)
+		}
+
+		// When: RestoreSiteComment 호출
+		rec := httptest.NewRecorder()
+		NewAdminHandler(tx).RestoreSiteComment(rec, newSiteCommentRequest(ctx, user, site.ID, comment.ID))
+
+		// Then: 204 No Content, 댓글 수 복구
+		if rec.Code != http.StatusNoContent {
+			t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusNoContent, rec.Code, rec.Body.String())
+		}
+		stored, _ := database.GetPostByID(ctx, tx, post.ID)
+		if stored.CommentCount != 1 {
+			t.Errorf("Expected comment_count=1, got %d", stored.CommentCount)
+		}
+	})
+
+	t.Run("삭제되지 않은 댓글이면 404", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		// Given: 삭제되지 않은 댓글
+		user, site, post := setupSitePostTest(t, ctx, tx, "restore-active@example.com")
+		comment, err := database.CreateComment(ctx, tx, post.ID, nil, "홍길동", "test1234", "댓글", "127.0.0.1", "test")
+		if err != nil {
+			t.Fatalf("Failed to create comment: %v", err)
+		}
+
+		// When: 복구 시도
+		rec := httptest.NewRecorder()
+		NewAdminHandler(tx).RestoreSiteComment(rec, newSiteCommentRequest(ctx, user, site.ID, comment.ID))
+
+		// Then: 404 Not Found
+		if rec.Code != http.StatusNotFound {
+			t.Errorf("Expected status %d, got %d", http.StatusNotFound, rec.Code)
+		}
+	})
+
+	t.Run("다른 사이트의 댓글이면 404", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		// Given: 다른 사이트의 삭제된 댓글
+		user, site, _ := setupSitePostTest(t, ctx, tx, "restore-other@example.com")
+		otherSite := testhelpers.CreateTestSite(ctx, t, tx, "Other Site", "restore-other.com", []string{"https://restore-other.com"}, true)
+		otherPost := testhelpers.CreateTestPost(ctx, t, tx, otherSite.ID, "other-post", "Other Post")
+		comment, err := database.CreateComment(ctx, tx, otherPost.ID, nil, "홍길동", "test1234", "댓글", "127.0.0.1", "test")
+		if err != nil {
+			t.Fatalf("Failed to create comment: %v", err)
+		}
+		database.DeleteComment(ctx, tx, comment.ID)
+
+		// When: 내 사이트 경로로 복구 시도
+		rec := httptest.NewRecorder()
+		NewAdminHandler(tx).RestoreSiteComment(rec, newSiteCommentRequest(ctx, user, site.ID, comment.ID))
+
+		// Then: 404 Not Found
+		if rec.Code != http.StatusNotFound {
+			t.Errorf("Expected status %d, got %d", http.StatusNotFound, rec.Code)
+		}
+	})
+}
+
+// TestEraseSiteComments는 개인정보 삭제 요청 처리 API를 테스트합니다
+func TestEraseSiteComments(t *testing.T) {
+	db := testhelpers.SetupTestDB(t)
+	defer database.Close(db)
+
+	// newErasureRequest는 /admin/sites/{id}/erasure 요청을 생성합니다
+	newErasureRequest := func(ctx context.Context, user *models.User, siteID int64, query string, body map[string]string) *http.Request {
+		bodyBytes, _ := json.Marshal(body)
+		req := httptest.NewRequest(http.MethodPost, "/admin/sites/"+strconv.FormatInt(siteID, 10)+"/erasure"+query, bytes.NewReader(bodyBytes))
+		req.Header.Set("Content-Type", "application/json")
+		req = req.WithContext(context.WithValue(ctx, userContextKey, user))
+
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", strconv.FormatInt(siteID, 10))
+		return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	}
+
+	t.Run("작성자 이름으로 익명화 및 결과 보고", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		// Given: 홍길동의 댓글 1개
+		user, site, post := setupSitePostTest(t, ctx, tx, "erase@example.com")
+		comment, err := database.CreateComment(ctx, tx, post.ID, nil, "홍길동", "test1234", "댓글", "127.0.0.1", "test")
+		if err != nil {
+			t.Fatalf("Failed to create comment: %v", err)
+		}
+
+		// When: EraseSiteComments 호출
+		rec := httptest.NewRecorder()
+		NewAdminHandler(tx).EraseSiteComments(rec, newErasureRequest(ctx, user, site.ID, "", map[string]string{"author_name": "홍길동"}))
+
+		// Then: 200 OK, 익명화 결과 보고
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, rec.Code, rec.Body.String())
+		}
+		var report models.CommentErasureReport
+		if err := json.NewDecoder(rec.Body).Decode(&report); err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+		if report.DryRun || report.MatchedComments != 1 || report.AnonymizedComments != 1 {
+			t.Errorf("Unexpected report: %+v", report)
+		}
+		if stored, _ := database.GetCommentByID(ctx, tx, comment.ID); stored.AuthorName != "" || !stored.IsDeleted {
+			t.Errorf("Expected comment to be anonymized, got %+v", stored)
+		}
+	})
+
+	t.Run("dry_run이면 변경하지 않음", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		// Given: 삭제 대상 댓글
+		user, site, post := setupSitePostTest(t, ctx, tx, "erase-dry@example.com")
+		comment, err := database.CreateComment(ctx, tx, post.ID, nil, "홍길동", "test1234", "댓글", "127.0.0.1", "test")
+		if err != nil {
+			t.Fatalf("Failed to create comment: %v", err)
+		}
+
+		// When: dry_run=true로 호출
+		rec := httptest.NewRecorder()
+		NewAdminHandler(tx).EraseSiteComments(rec, newErasureRequest(ctx, user, site.ID, "?dry_run=true", map[string]string{"ip_address": "127.0.0.1", "mode": "delete"}))
+
+		// Then: 일치한 댓글만 보고, 댓글은 유지
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, rec.Code, rec.Body.String())
+		}
+		var report models.CommentErasureReport
+		json.NewDecoder(rec.Body).Decode(&report)
+		if !report.DryRun || report.MatchedComments != 1 || report.DeletedComments != 0 {
+			t.Errorf("Unexpected report: %+v", report)
+		}
+		if stored, _ := database.GetCommentByID(ctx, tx, comment.ID); stored == nil || stored.IsDeleted {
+			t.Errorf("Expected comment to be kept, got %+v", stored)
+		}
+	})
+
+	t.Run("조건이 없으면 400", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		// Given: 사이트 관리자
+		user, site, _ := setupSitePostTest(t, ctx, tx, "erase-empty@example.com")
+
+		// When: 빈 조건으로 호출
+		rec := httptest.NewRecorder()
+		NewAdminHandler(tx).EraseSiteComments(rec, newErasureRequest(ctx, user, site.ID, "", map[string]string{"mode": "delete"}))
+
+		// Then: 400 Bad Request
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("Expected status %d, got %d", http.StatusBadRequest, rec.Code)
+		}
+	})
+}
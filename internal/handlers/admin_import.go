@@ -0,0 +1,145 @@
+package handlers
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/june20516/orbithall/internal/database"
+	"github.com/june20516/orbithall/internal/importer"
+	"github.com/june20516/orbithall/internal/models"
+)
+
+// maxImportFileSize는 가져오기 파일의 최대 크기입니다 (50MB)
+const maxImportFileSize = 50 << 20
+
+// ImportDisqus는 Disqus 내보내기 XML 파일을 사이트의 댓글로 가져옵니다
+// @Summary      Disqus 댓글 가져오기
+// @Description  Disqus 내보내기 XML을 업로드하여 스레드를 포스트(slug)로 매핑하고 댓글을 가져옵니다. 2-depth 이상의 대댓글은 최상위 조상 댓글의 대댓글로 옮겨지며, 원본 작성 시각과 삭제 여부가 유지됩니다. 같은 파일을 다시 가져와도 중복되지 않습니다.
+// @Tags         admin
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        id           path     int     true   "Site ID"
+// @Param        file         formData file    true   "Disqus 내보내기 XML 파일"
+// @Param        slug_pattern formData string  false  "URL에서 slug를 추출할 정규식 (slug 이름 그룹 또는 첫 번째 캡처 그룹 사용, 기본값: URL 경로의 마지막 세그먼트)"
+// @Param        dry_run      formData bool    false  "true면 저장하지 않고 매핑 결과만 반환"
+// @Success      200 {object} importer.Report
+// @Failure      400 {string} string "Invalid input"
+// @Failure      401 {string} string "Unauthorized"
+// @Failure      403 {string} string "Forbidden"
+// @Failure      500 {string} string "Failed to import comments"
+// @Security     BearerAuth
+// @Router       /admin/sites/{id}/import/disqus [post]
+func (h *AdminHandler) ImportDisqus(w http.ResponseWriter, r *http.Request) {
+	h.importComments(w, r, importer.SourceDisqus, func(file io.Reader, _ *http.Request) ([]*importer.Thread, error) {
+		return importer.ParseDisqus(file)
+	})
+}
+
+// ImportWordPress는 WordPress 내보내기(WXR) 파일을 사이트의 댓글로 가져옵니다
+// @Summary      WordPress 댓글 가져오기
+// @Description  WordPress 내보내기(WXR) 파일을 업로드하여 글의 wp:post_name을 포스트 slug로, 글 제목을 포스트 제목으로 사용해 승인된 댓글을 가져옵니다. 대댓글 계층과 작성자 IP, 원본 작성 시각이 유지되며 2-depth 이상의 대댓글은 최상위 조상 댓글의 대댓글로 옮겨집니다. 승인 대기 댓글과 핑백/트랙백은 가져오지 않습니다. 같은 파일을 다시 가져와도 중복되지 않습니다.
+// @Tags         admin
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        id            path     int     true   "Site ID"
+// @Param        file          formData file    true   "WordPress 내보내기(WXR) 파일"
+// @Param        slug_pattern  formData string  false  "post_name이 없는 글의 URL에서 slug를 추출할 정규식 (기본값: URL 경로의 마지막 세그먼트)"
+// @Param        include_spam  formData bool    false  "true면 스팸 댓글을 삭제된 댓글로 가져옴 (기본값: 건너뜀)"
+// @Param        include_trash formData bool    false  "true면 휴지통의 댓글을 삭제된 댓글로 가져옴 (기본값: 건너뜀)"
+// @Param        dry_run       formData bool    false  "true면 저장하지 않고 매핑 결과만 반환"
+// @Success      200 {object} importer.Report
+// @Failure      400 {string} string "Invalid input"
+// @Failure      401 {string} string "Unauthorized"
+// @Failure      403 {string} string "Forbidden"
+// @Failure      500 {string} string "Failed to import comments"
+// @Security     BearerAuth
+// @Router       /admin/sites/{id}/import/wordpress [post]
+func (h *AdminHandler) ImportWordPress(w http.ResponseWriter, r *http.Request) {
+	h.importComments(w, r, importer.SourceWordPress, func(file io.Reader, r *http.Request) ([]*importer.Thread, error) {
+		includeSpam, _ := strconv.ParseBool(r.FormValue("include_spam"))
+		includeTrash, _ := strconv.ParseBool(r.FormValue("include_trash"))
+		return importer.ParseWordPress(file, importer.WXROptions{
+			IncludeSpam:  includeSpam,
+			IncludeTrash: includeTrash,
+		})
+	})
+}
+
+// importComments는 플랫폼별 가져오기 핸들러의 공통 처리 로직입니다
+// 권한 확인 → 업로드 파일 파싱 → slug 규칙 결정 → 가져오기 → 결과 보고 순서로 처리합니다
+// parse는 업로드 파일과 함께 요청을 받아 플랫폼별 폼 옵션을 읽을 수 있습니다
+func (h *AdminHandler) importComments(w http.ResponseWriter, r *http.Request, source string, parse func(file io.Reader, r *http.Request) ([]*importer.Thread, error)) {
+	// Context에서 사용자 추출
+	user, ok := r.Context().Value(userContextKey).(*models.User)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	// URL 파라미터에서 site_id 추출
+	siteIDStr := chi.URLParam(r, "id")
+	siteID, err := strconv.ParseInt(siteIDStr, 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid site ID", http.StatusBadRequest)
+		return
+	}
+
+	// 접근 권한 확인
+	hasAccess, err := database.HasUserSitePermission(r.Context(), h.db, user.ID, siteID, models.PermissionSiteManage)
+	if err != nil {
+		http.Error(w, "Failed to check access", http.StatusInternalServerError)
+		return
+	}
+
+	if !hasAccess {
+		http.Error(w, "Forbidden", http.StatusForbidden)
+		return
+	}
+
+	// 업로드 파일 추출 (최대 크기 제한)
+	r.Body = http.MaxBytesReader(w, r.Body, maxImportFileSize)
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		http.Error(w, "file is required", http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+
+	// slug 규칙 결정 (지정하지 않으면 URL 경로의 마지막 세그먼트)
+	slugRule := importer.SlugRule(importer.LastPathSegment)
+	if pattern := r.FormValue("slug_pattern"); pattern != "" {
+		slugRule, err = importer.NewPatternSlugRule(pattern)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	dryRun, _ := strconv.ParseBool(r.FormValue("dry_run"))
+
+	// 파일 파싱
+	threads, err := parse(file, r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// 가져오기 (하나의 트랜잭션으로 실행)
+	report, err := importer.Import(r.Context(), h.db, siteID, threads, importer.Options{
+		Source:   source,
+		SlugRule: slugRule,
+		DryRun:   dryRun,
+	})
+	if err != nil {
+		http.Error(w, "Failed to import comments", http.StatusInternalServerError)
+		return
+	}
+
+	// 응답 반환
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(report)
+}
@@ -0,0 +1,332 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/june20516/orbithall/internal/database"
+	"github.com/june20516/orbithall/internal/importer"
+	"github.com/june20516/orbithall/internal/models"
+	"github.com/june20516/orbithall/internal/testhelpers"
+)
+
+// testDisqusUpload는 핸들러 테스트용 최소 Disqus 내보내기 XML입니다
+const testDisqusUpload = `<?xml version="1.0" encoding="utf-8"?>
+<disqus xmlns="http://disqus.com" xmlns:dsq="http://disqus.com/disqus-internals">
+  <thread dsq:id="1">
+    <link>https://blog.example.com/2019/05/imported-post/</link>
+    <title>Imported Post</title>
+  </thread>
+  <thread dsq:id="2">
+    <link>https://blog.example.com/about</link>
+    <title>About</title>
+  </thread>
+  <post dsq:id="10">
+    <message><![CDATA[Hello from Disqus]]></message>
+    <createdAt>2019-05-02T10:00:00Z</createdAt>
+    <author><name>Alice</name></author>
+    <thread dsq:id="1"/>
+  </post>
+</disqus>`
+
+// newImportRequest는 multipart 업로드 요청을 생성합니다
+func newImportRequest(t *testing.T, ctx context.Context, user *models.User, siteID int64, content string, fields map[string]string) *http.Request {
+	t.Helper()
+
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	if content != "" {
+		part, err := writer.CreateFormFile("file", "export.xml")
+		if err != nil {
+			t.Fatalf("Failed to create form file: %v", err)
+		}
+		part.Write([]byte(content))
+	}
+	for key, value := range fields {
+		writer.WriteField(key, value)
+	}
+	writer.Close()
+
+	req := httptest.NewRequest(http.MethodPost, "/admin/sites/"+strconv.FormatInt(siteID, 10)+"/import/disqus", body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	req = req.WithContext(context.WithValue(ctx, userContextKey, user))
+
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", strconv.FormatInt(siteID, 10))
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+}
+
+// TestImportDisqus는 Disqus 가져오기 엔드포인트를 테스트합니다
+func TestImportDisqus(t *testing.T) {
+	db := testhelpers.SetupTestDB(t)
+	defer database.Close(db)
+
+	setup := func(t *testing.T, ctx context.Context, tx testhelpers.DBTX) (*models.User, *models.Site) {
+		user := &models.User{
+			Email: "importer@example.com",
+			Name:  "Importer",
+		}
+		if err := database.CreateUser(ctx, tx, user); err != nil {
+			t.Fatalf("Failed to create user: %v", err)
+		}
+		site := &models.Site{
+			Name:        "Import Site",
+			Domain:      "import-site.com",
+			CORSOrigins: []string{"https://import-site.com"},
+			IsActive:    true,
+			IsVerified:  true,
+		}
+		if err := database.CreateSiteForUser(ctx, tx, site, user.ID); err != nil {
+			t.Fatalf("Failed to create site: %v", err)
+		}
+		return user, site
+	}
+
+	t.Run("가져오기 성공 - slug 패턴 적용 및 미매핑 스레드 보고", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		// Given: 사용자와 사이트
+		user, site := setup(t, ctx, tx)
+
+		// When: 날짜 경로 뒤의 세그먼트만 slug로 사용하는 패턴으로 업로드
+		req := newImportRequest(t, ctx, user, site.ID, testDisqusUpload, map[string]string{
+			"slug_pattern": `/\d{4}/\d{2}/(?P<slug>[^/]+)`,
+		})
+		rec := httptest.NewRecorder()
+		NewAdminHandler(tx).ImportDisqus(rec, req)
+
+		// Then: 200 OK, 댓글 1개 저장, /about 스레드는 미매핑
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, rec.Code, rec.Body.String())
+		}
+
+		var report importer.Report
+		if err := json.Unmarshal(rec.Body.Bytes(), &report); err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+		if report.CommentsImported != 1 {
+			t.Errorf("Expected 1 imported comment, got %d", report.CommentsImported)
+		}
+		if len(report.UnmappedThreads) != 1 || report.UnmappedThreads[0].Link != "https://blog.example.com/about" {
+			t.Errorf("Expected /about thread to be unmapped, got %+v", report.UnmappedThreads)
+		}
+
+		post, err := database.GetPostBySlug(ctx, tx, site.ID, "imported-post", false)
+		if err != nil || post == nil {
+			t.Fatalf("Expected post imported-post to exist (err=%v)", err)
+		}
+	})
+
+	t.Run("dry_run이면 저장하지 않음", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		// Given: 사용자와 사이트
+		user, site := setup(t, ctx, tx)
+
+		// When: dry_run으로 업로드
+		req := newImportRequest(t, ctx, user, site.ID, testDisqusUpload, map[string]string{"dry_run": "true"})
+		rec := httptest.NewRecorder()
+		NewAdminHandler(tx).ImportDisqus(rec, req)
+
+		// Then: 200 OK, 포스트 생성 안 됨
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, rec.Code, rec.Body.String())
+		}
+		post, _ := database.GetPostBySlug(ctx, tx, site.ID, "imported-post", false)
+		if post != nil {
+			t.Error("Expected no post to be created in dry run")
+		}
+	})
+
+	t.Run("파일이 없으면 400", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		// Given: 사용자와 사이트
+		user, site := setup(t, ctx, tx)
+
+		// When: 파일 없이 요청
+		req := newImportRequest(t, ctx, user, site.ID, "", nil)
+		rec := httptest.NewRecorder()
+		NewAdminHandler(tx).ImportDisqus(rec, req)
+
+		// Then: 400 Bad Request
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("Expected status %d, got %d", http.StatusBadRequest, rec.Code)
+		}
+	})
+
+	t.Run("잘못된 slug 패턴이면 400", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		// Given: 사용자와 사이트
+		user, site := setup(t, ctx, tx)
+
+		// When: 캡처 그룹 없는 패턴
+		req := newImportRequest(t, ctx, user, site.ID, testDisqusUpload, map[string]string{"slug_pattern": `/posts/.+`})
+		rec := httptest.NewRecorder()
+		NewAdminHandler(tx).ImportDisqus(rec, req)
+
+		// Then: 400 Bad Request
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("Expected status %d, got %d", http.StatusBadRequest, rec.Code)
+		}
+	})
+
+	t.Run("다른 사용자의 사이트면 403", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		// Given: 사이트 소유자가 아닌 사용자
+		_, site := setup(t, ctx, tx)
+		other := &models.User{
+			Email: "other@example.com",
+			Name:  "Other",
+		}
+		if err := database.CreateUser(ctx, tx, other); err != nil {
+			t.Fatalf("Failed to create user: %v", err)
+		}
+
+		// When: 다른 사용자가 업로드
+		req := newImportRequest(t, ctx, other, site.ID, testDisqusUpload, nil)
+		rec := httptest.NewRecorder()
+		NewAdminHandler(tx).ImportDisqus(rec, req)
+
+		// Then: 403 Forbidden
+		if rec.Code != http.StatusForbidden {
+			t.Errorf("Expected status %d, got %d", http.StatusForbidden, rec.Code)
+		}
+	})
+}
+
+// testWordPressUpload는 핸들러 테스트용 최소 WordPress WXR 파일입니다
+const testWordPressUpload = `<?xml version="1.0" encoding="UTF-8" ?>
+<rss version="2.0" xmlns:wp="http://wordpress.org/export/1.2/">
+<channel>
+  <item>
+    <title>Real Title</title>
+    <link>https://blog.example.com/?p=3</link>
+    <wp:post_id>3</wp:post_id>
+    <wp:post_name><![CDATA[real-title]]></wp:post_name>
+    <wp:post_type><![CDATA[post]]></wp:post_type>
+    <wp:comment>
+      <wp:comment_id>1</wp:comment_id>
+      <wp:comment_author><![CDATA[Alice]]></wp:comment_author>
+      <wp:comment_date_gmt><![CDATA[2021-03-01 09:00:00]]></wp:comment_date_gmt>
+      <wp:comment_content><![CDATA[Approved]]></wp:comment_content>
+      <wp:comment_approved><![CDATA[1]]></wp:comment_approved>
+      <wp:comment_parent>0</wp:comment_parent>
+    </wp:comment>
+    <wp:comment>
+      <wp:comment_id>2</wp:comment_id>
+      <wp:comment_author><![CDATA[Bob]]></wp:comment_author>
+      <wp:comment_date_gmt><![CDATA[2021-03-02 09:00:00]]></wp:comment_date_gmt>
+      <wp:comment_content><![CDATA[Trashed]]></wp:comment_content>
+      <wp:comment_approved><![CDATA[trash]]></wp:comment_approved>
+      <wp:comment_parent>0</wp:comment_parent>
+    </wp:comment>
+  </item>
+</channel>
+</rss>`
+
+// TestImportWordPress는 WordPress 가져오기 엔드포인트를 테스트합니다
+func TestImportWordPress(t *testing.T) {
+	db := testhelpers.SetupTestDB(t)
+	defer database.Close(db)
+
+	setup := func(t *testing.T, ctx context.Context, tx testhelpers.DBTX) (*models.User, *models.Site) {
+		user := &models.User{
+			Email: "wp-importer@example.com",
+			Name:  "WP Importer",
+		}
+		if err := database.CreateUser(ctx, tx, user); err != nil {
+			t.Fatalf("Failed to create user: %v", err)
+		}
+		site := &models.Site{
+			Name:        "WP Site",
+			Domain:      "wp-site.com",
+			CORSOrigins: []string{"https://wp-site.com"},
+			IsActive:    true,
+			IsVerified:  true,
+		}
+		if err := database.CreateSiteForUser(ctx, tx, site, user.ID); err != nil {
+			t.Fatalf("Failed to create site: %v", err)
+		}
+		return user, site
+	}
+
+	t.Run("post_name과 제목으로 포스트 생성, 휴지통 댓글은 기본적으로 건너뜀", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		// Given: 사용자와 사이트
+		user, site := setup(t, ctx, tx)
+
+		// When: 기본 옵션으로 업로드
+		req := newImportRequest(t, ctx, user, site.ID, testWordPressUpload, nil)
+		rec := httptest.NewRecorder()
+		NewAdminHandler(tx).ImportWordPress(rec, req)
+
+		// Then: 200 OK, 승인된 댓글만 저장
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, rec.Code, rec.Body.String())
+		}
+
+		var report importer.Report
+		if err := json.Unmarshal(rec.Body.Bytes(), &report); err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+		if report.CommentsImported != 1 || report.CommentsSkipped[importer.SkipReasonTrash] != 1 {
+			t.Errorf("Unexpected report: %+v", report)
+		}
+
+		post, err := database.GetPostBySlug(ctx, tx, site.ID, "real-title", false)
+		if err != nil || post == nil {
+			t.Fatalf("Expected post real-title to exist (err=%v)", err)
+		}
+		if post.Title != "Real Title" {
+			t.Errorf("Expected title=Real Title, got %s", post.Title)
+		}
+	})
+
+	t.Run("include_trash면 휴지통 댓글을 삭제된 댓글로 가져옴", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		// Given: 사용자와 사이트
+		user, site := setup(t, ctx, tx)
+
+		// When: include_trash로 업로드
+		req := newImportRequest(t, ctx, user, site.ID, testWordPressUpload, map[string]string{"include_trash": "true"})
+		rec := httptest.NewRecorder()
+		NewAdminHandler(tx).ImportWordPress(rec, req)
+
+		// Then: 2개 저장, 활성 댓글 수는 1
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, rec.Code, rec.Body.String())
+		}
+
+		var report importer.Report
+		if err := json.Unmarshal(rec.Body.Bytes(), &report); err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+		if report.CommentsImported != 2 {
+			t.Errorf("Expected 2 imported comments, got %d", report.CommentsImported)
+		}
+
+		post, _ := database.GetPostBySlug(ctx, tx, site.ID, "real-title", false)
+		if post == nil || post.CommentCount != 1 {
+			t.Errorf("Expected comment_count=1, got %+v", post)
+		}
+	})
+}
@@ -0,0 +1,296 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/june20516/orbithall/internal/database"
+	"github.com/june20516/orbithall/internal/models"
+	"github.com/june20516/orbithall/internal/notify"
+	"github.com/june20516/orbithall/internal/validators"
+)
+
+// ListSiteInvitationsResponse는 사이트 초대 목록 응답입니다
+type ListSiteInvitationsResponse struct {
+	Invitations []models.SiteInvitation `json:"invitations"`
+}
+
+// AcceptInvitationRequest는 초대 수락 요청 본문입니다
+type AcceptInvitationRequest struct {
+	Token string `json:"token"`
+}
+
+// AcceptInvitationResponse는 초대 수락 응답입니다
+type AcceptInvitationResponse struct {
+	// Site는 연결된 사이트입니다 (role에 부여된 역할 포함)
+	Site *models.Site `json:"site"`
+}
+
+// invitationAuditState는 감사 로그에 기록하는 초대 상태입니다
+// 초대 토큰은 감사 로그에 남기지 않습니다
+type invitationAuditState struct {
+	Email     string    `json:"email"`
+	Role      string    `json:"role"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// newInvitationAuditState는 초대의 감사 로그 상태를 만듭니다
+func newInvitationAuditState(invitation *models.SiteInvitation) *invitationAuditState {
+	return &invitationAuditState{
+		Email:     invitation.Email,
+		Role:      invitation.Role,
+		ExpiresAt: invitation.ExpiresAt,
+	}
+}
+
+// ListSiteInvitations는 사이트의 멤버 초대 목록을 반환합니다
+// @Summary      사이트 초대 목록 조회
+// @Description  사이트의 멤버 초대를 최신순으로 반환합니다 (수락/취소/만료된 초대 포함). 초대 토큰은 포함되지 않습니다.
+// @Tags         admin
+// @Produce      json
+// @Param        id path int true "Site ID"
+// @Success      200 {object} ListSiteInvitationsResponse
+// @Failure      400 {string} string "Invalid site ID"
+// @Failure      401 {string} string "Unauthorized"
+// @Failure      403 {string} string "Forbidden"
+// @Failure      500 {string} string "Failed to get invitations"
+// @Security     BearerAuth
+// @Router       /admin/sites/{id}/invitations [get]
+func (h *AdminHandler) ListSiteInvitations(w http.ResponseWriter, r *http.Request) {
+	siteID, ok := h.authorizeSite(w, r, models.PermissionMemberManage)
+	if !ok {
+		return
+	}
+
+	invitations, err := database.ListSiteInvitations(r.Context(), h.db, siteID)
+	if err != nil {
+		http.Error(w, "Failed to get invitations", http.StatusInternalServerError)
+		return
+	}
+
+	// 200 OK 응답
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(ListSiteInvitationsResponse{Invitations: invitations})
+}
+
+// CreateSiteInvitation은 이메일로 사이트 멤버를 초대합니다
+// @Summary      사이트 멤버 초대
+// @Description  이메일과 역할로 초대를 생성하고 한 번만 사용할 수 있는 초대 토큰을 발급합니다. 토큰은 초대받은 이메일로만 전송되며 응답에는 포함되지 않습니다. 초대받은 사람은 아직 가입하지 않았어도 되며, 같은 이메일로 로그인한 뒤 토큰으로 수락합니다. 같은 이메일에 대기 중인 초대가 있으면 취소하고 새로 발급합니다.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        id         path int                                  true "Site ID"
+// @Param        invitation body validators.SiteInvitationCreateInput true "초대 정보"
+// @Success      201 {object} models.SiteInvitation
+// @Failure      400 {object} map[string]interface{} "Invalid input"
+// @Failure      401 {string} string "Unauthorized"
+// @Failure      403 {string} string "Forbidden"
+// @Failure      409 {string} string "User is already a site member"
+// @Failure      500 {string} string "Failed to create invitation"
+// @Security     BearerAuth
+// @Router       /admin/sites/{id}/invitations [post]
+func (h *AdminHandler) CreateSiteInvitation(w http.ResponseWriter, r *http.Request) {
+	// JSON 요청 파싱
+	var input validators.SiteInvitationCreateInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	// 입력 검증
+	if err := input.Validate(); err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	siteID, ok := h.authorizeSite(w, r, models.PermissionMemberManage)
+	if !ok {
+		return
+	}
+	user := r.Context().Value(userContextKey).(*models.User)
+
+	// 초대 생성
+	invitation := &models.SiteInvitation{
+		SiteID:    siteID,
+		Email:     input.Email,
+		Role:      input.Role,
+		InvitedBy: &user.ID,
+		ExpiresAt: time.Now().Add(input.ExpiresIn()),
+	}
+	var site *models.Site
+	err := database.RunInTx(r.Context(), h.db, func(tx database.DBTX) error {
+		var err error
+		if site, err = database.GetSiteByID(r.Context(), tx, siteID); err != nil {
+			return err
+		}
+		if site == nil {
+			return sql.ErrNoRows
+		}
+		if err := database.CreateSiteInvitation(r.Context(), tx, invitation); err != nil {
+			return err
+		}
+		return recordAudit(r, tx, siteID, models.AuditActionInvitationCreate, models.AuditTargetInvitation, invitation.ID, nil, newInvitationAuditState(invitation))
+	})
+	if err != nil {
+		if errors.Is(err, database.ErrAlreadySiteMember) {
+			http.Error(w, "User is already a site member", http.StatusConflict)
+			return
+		}
+		http.Error(w, "Failed to create invitation", http.StatusInternalServerError)
+		return
+	}
+
+	// 초대 토큰은 초대받은 이메일로만 전송
+	h.notify(r.Context(), notify.Message{
+		To:      invitation.Email,
+		Subject: fmt.Sprintf("[Orbithall] %s 사이트 멤버 초대", site.Name),
+		Body: fmt.Sprintf("%s님이 %s(%s) 사이트에 %s 역할로 초대했습니다.\n%s까지 이 이메일 계정으로 Orbithall 관리 화면에 로그인한 뒤 아래 초대 토큰으로 수락할 수 있습니다.\n\n초대 토큰: %s\n",
+			user.Email, site.Name, site.Domain, invitation.Role, formatNotifyTime(invitation.ExpiresAt), invitation.Token),
+	})
+
+	// 201 Created 응답
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(invitation)
+}
+
+// RevokeSiteInvitation은 수락되지 않은 초대를 취소합니다
+// @Summary      사이트 초대 취소
+// @Description  초대를 취소합니다. 취소된 초대의 토큰으로는 수락할 수 없습니다.
+// @Tags         admin
+// @Param        id           path int true "Site ID"
+// @Param        invitationId path int true "Invitation ID"
+// @Success      204 "No Content"
+// @Failure      400 {string} string "Invalid ID"
+// @Failure      401 {string} string "Unauthorized"
+// @Failure      403 {string} string "Forbidden"
+// @Failure      404 {string} string "Invitation not found"
+// @Failure      409 {string} string "Invitation is already accepted or revoked"
+// @Failure      500 {string} string "Failed to revoke invitation"
+// @Security     BearerAuth
+// @Router       /admin/sites/{id}/invitations/{invitationId} [delete]
+func (h *AdminHandler) RevokeSiteInvitation(w http.ResponseWriter, r *http.Request) {
+	// URL 파라미터에서 invitation_id 추출
+	invitationID, err := strconv.ParseInt(chi.URLParam(r, "invitationId"), 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid invitation ID", http.StatusBadRequest)
+		return
+	}
+
+	siteID, ok := h.authorizeSite(w, r, models.PermissionMemberManage)
+	if !ok {
+		return
+	}
+
+	// 초대 취소
+	err = database.RunInTx(r.Context(), h.db, func(tx database.DBTX) error {
+		invitation, err := database.RevokeSiteInvitation(r.Context(), tx, siteID, invitationID)
+		if err != nil {
+			return err
+		}
+		return recordAudit(r, tx, siteID, models.AuditActionInvitationRevoke, models.AuditTargetInvitation, invitationID, newInvitationAuditState(invitation), nil)
+	})
+	if err != nil {
+		switch {
+		case err == sql.ErrNoRows:
+			http.Error(w, "Invitation not found", http.StatusNotFound)
+		case errors.Is(err, database.ErrInvitationInactive):
+			http.Error(w, "Invitation is already accepted or revoked", http.StatusConflict)
+		default:
+			http.Error(w, "Failed to revoke invitation", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	// 204 No Content 응답
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// AcceptInvitation은 로그인한 사용자로 사이트 초대를 수락합니다
+// @Summary      사이트 초대 수락
+// @Description  /auth/google/verify로 로그인한 사용자가 초대 토큰으로 초대를 수락하면 초대된 역할로 사이트에 연결됩니다. 초대받은 이메일과 로그인한 계정의 이메일이 같고(대소문자 구분 없음) ID 공급자가 검증한 이메일이어야 하며, 토큰은 한 번만 사용할 수 있습니다.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        request body AcceptInvitationRequest true "초대 토큰"
+// @Success      200 {object} AcceptInvitationResponse
+// @Failure      400 {string} string "token is required"
+// @Failure      401 {string} string "Unauthorized"
+// @Failure      403 {string} string "Invitation was sent to a different email or email is not verified"
+// @Failure      404 {string} string "Invitation not found"
+// @Failure      409 {string} string "User is already a site member"
+// @Failure      410 {string} string "Invitation is no longer valid"
+// @Failure      500 {string} string "Failed to accept invitation"
+// @Security     BearerAuth
+// @Router       /admin/invitations/accept [post]
+func (h *AdminHandler) AcceptInvitation(w http.ResponseWriter, r *http.Request) {
+	// Context에서 사용자 추출
+	user, ok := r.Context().Value(userContextKey).(*models.User)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	// JSON 요청 파싱
+	var req AcceptInvitationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+	req.Token = strings.TrimSpace(req.Token)
+	if req.Token == "" {
+		http.Error(w, "token is required", http.StatusBadRequest)
+		return
+	}
+
+	// 초대 수락 후 사이트 멤버로 연결
+	var site *models.Site
+	err := database.RunInTx(r.Context(), h.db, func(tx database.DBTX) error {
+		invitation, err := database.AcceptSiteInvitation(r.Context(), tx, req.Token, user)
+		if err != nil {
+			return err
+		}
+
+		site, err = database.GetSiteByID(r.Context(), tx, invitation.SiteID)
+		if err != nil {
+			return err
+		}
+		site.Role = invitation.Role
+
+		return recordAudit(r, tx, invitation.SiteID, models.AuditActionInvitationAccept, models.AuditTargetInvitation, invitation.ID, nil, newInvitationAuditState(invitation))
+	})
+	if err != nil {
+		switch {
+		case err == sql.ErrNoRows:
+			http.Error(w, "Invitation not found", http.StatusNotFound)
+		case errors.Is(err, database.ErrInvitationInactive):
+			http.Error(w, "Invitation is no longer valid", http.StatusGone)
+		case errors.Is(err, database.ErrInvitationEmailMismatch):
+			http.Error(w, "Invitation was sent to a different email", http.StatusForbidden)
+		case errors.Is(err, database.ErrEmailNotVerified):
+			http.Error(w, "Email is not verified", http.StatusForbidden)
+		case errors.Is(err, database.ErrAlreadySiteMember):
+			http.Error(w, "User is already a site member", http.StatusConflict)
+		default:
+			http.Error(w, "Failed to accept invitation", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	// 200 OK 응답
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(AcceptInvitationResponse{Site: site})
+}
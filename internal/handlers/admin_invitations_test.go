@@ -0,0 +1,177 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/june20516/orbithall/internal/database"
+	"github.com/june20516/orbithall/internal/models"
+	"github.com/june20516/orbithall/internal/testhelpers"
+)
+
+// newSiteInvitationRequest는 /admin/sites/{id}/invitations 요청을 생성합니다
+func newSiteInvitationRequest(ctx context.Context, user *models.User, method string, siteID int64, body string) *http.Request {
+	site := strconv.FormatInt(siteID, 10)
+	return newAdminRequest(ctx, user, method, "/admin/sites/"+site+"/invitations", body, "id", site)
+}
+
+// newAcceptInvitationRequest는 /admin/invitations/accept 요청을 생성합니다
+func newAcceptInvitationRequest(ctx context.Context, user *models.User, token string) *http.Request {
+	body := fmt.Sprintf(`{"token":%q}`, token)
+	req := httptest.NewRequest(http.MethodPost, "/admin/invitations/accept", strings.NewReader(body))
+	return req.WithContext(context.WithValue(ctx, userContextKey, user))
+}
+
+// TestSiteInvitationHandlers는 사이트 멤버 초대 API를 테스트합니다
+func TestSiteInvitationHandlers(t *testing.T) {
+	db := testhelpers.SetupTestDB(t)
+	defer database.Close(db)
+
+	// createOwnerSite는 사이트와 owner를 생성합니다
+	createOwnerSite := func(t *testing.T, ctx context.Context, tx database.DBTX, prefix string) (*models.Site, *models.User) {
+		site := testhelpers.CreateTestSite(ctx, t, tx, prefix, prefix+".example.com", []string{"https://" + prefix + ".example.com"}, true)
+		owner := &models.User{Email: prefix + "-owner@example.com", Name: "Owner"}
+		if err := database.CreateUser(ctx, tx, owner); err != nil {
+			t.Fatalf("Failed to create user: %v", err)
+		}
+		if err := database.AddUserToSite(ctx, tx, owner.ID, site.ID, models.SiteRoleOwner); err != nil {
+			t.Fatalf("Failed to add user to site: %v", err)
+		}
+		return &site, owner
+	}
+
+	t.Run("초대 생성 후 가입한 사용자가 수락", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		// Given: owner가 가입하지 않은 이메일을 manager로 초대
+		site, owner := createOwnerSite(t, ctx, tx, "invite-flow")
+		notifier := &recordingNotifier{}
+		handler := NewAdminHandler(tx)
+		handler.SetNotifier(notifier)
+
+		rec := httptest.NewRecorder()
+		handler.CreateSiteInvitation(rec, newSiteInvitationRequest(ctx, owner, http.MethodPost, site.ID, `{"email":"teammate@example.com","role":"manager"}`))
+		if rec.Code != http.StatusCreated {
+			t.Fatalf("Expected 201, got %d: %s", rec.Code, rec.Body.String())
+		}
+		if strings.Contains(rec.Body.String(), models.InvitationTokenPrefix) {
+			t.Errorf("Expected no invitation token in create response, got %s", rec.Body.String())
+		}
+
+		// 초대받은 이메일로만 토큰 전송
+		if to := notifier.recipients(); len(to) != 1 || to[0] != "teammate@example.com" {
+			t.Fatalf("Expected notification to invitee only, got %v", to)
+		}
+		token := tokenFromNotification(t, notifier.messages[0].Body, models.InvitationTokenPrefix)
+
+		// When: 초대 후 가입한 사용자가 수락
+		invitee := &models.User{Email: "teammate@example.com", EmailVerified: true, Name: "Teammate"}
+		if err := database.CreateUser(ctx, tx, invitee); err != nil {
+			t.Fatalf("Failed to create user: %v", err)
+		}
+		rec = httptest.NewRecorder()
+		handler.AcceptInvitation(rec, newAcceptInvitationRequest(ctx, invitee, token))
+
+		// Then: 200 OK, manager 역할로 사이트 연결
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+		var response AcceptInvitationResponse
+		if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		if response.Site == nil || response.Site.ID != site.ID || response.Site.Role != models.SiteRoleManager {
+			t.Errorf("Unexpected accept response: %+v", response.Site)
+		}
+
+		// 같은 토큰으로 다시 수락하면 410
+		rec = httptest.NewRecorder()
+		handler.AcceptInvitation(rec, newAcceptInvitationRequest(ctx, invitee, token))
+		if rec.Code != http.StatusGone {
+			t.Errorf("Expected 410 on reuse, got %d", rec.Code)
+		}
+	})
+
+	t.Run("다른 이메일 계정으로 수락하면 403", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		site, owner := createOwnerSite(t, ctx, tx, "invite-mismatch")
+		invitation := &models.SiteInvitation{SiteID: site.ID, Email: "invited@example.com", Role: models.SiteRoleViewer, ExpiresAt: time.Now().Add(time.Hour)}
+		if err := database.CreateSiteInvitation(ctx, tx, invitation); err != nil {
+			t.Fatalf("Failed to create invitation: %v", err)
+		}
+
+		rec := httptest.NewRecorder()
+		NewAdminHandler(tx).AcceptInvitation(rec, newAcceptInvitationRequest(ctx, owner, invitation.Token))
+
+		if rec.Code != http.StatusForbidden {
+			t.Errorf("Expected 403, got %d", rec.Code)
+		}
+	})
+
+	t.Run("이메일이 검증되지 않은 계정으로 수락하면 403", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		site, _ := createOwnerSite(t, ctx, tx, "invite-unverified")
+		invitee := &models.User{Email: "unverified-invitee@example.com", Name: "Unverified"}
+		if err := database.CreateUser(ctx, tx, invitee); err != nil {
+			t.Fatalf("Failed to create user: %v", err)
+		}
+		invitation := &models.SiteInvitation{SiteID: site.ID, Email: invitee.Email, Role: models.SiteRoleViewer, ExpiresAt: time.Now().Add(time.Hour)}
+		if err := database.CreateSiteInvitation(ctx, tx, invitation); err != nil {
+			t.Fatalf("Failed to create invitation: %v", err)
+		}
+
+		rec := httptest.NewRecorder()
+		NewAdminHandler(tx).AcceptInvitation(rec, newAcceptInvitationRequest(ctx, invitee, invitation.Token))
+
+		if rec.Code != http.StatusForbidden {
+			t.Errorf("Expected 403, got %d", rec.Code)
+		}
+	})
+
+	t.Run("manager는 초대 불가", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		site, _ := createOwnerSite(t, ctx, tx, "invite-manager")
+		manager := &models.User{Email: "invite-manager-m@example.com", Name: "Manager"}
+		if err := database.CreateUser(ctx, tx, manager); err != nil {
+			t.Fatalf("Failed to create user: %v", err)
+		}
+		if err := database.AddUserToSite(ctx, tx, manager.ID, site.ID, models.SiteRoleManager); err != nil {
+			t.Fatalf("Failed to add user to site: %v", err)
+		}
+
+		rec := httptest.NewRecorder()
+		NewAdminHandler(tx).CreateSiteInvitation(rec, newSiteInvitationRequest(ctx, manager, http.MethodPost, site.ID, `{"email":"x@example.com","role":"viewer"}`))
+
+		if rec.Code != http.StatusForbidden {
+			t.Errorf("Expected 403, got %d", rec.Code)
+		}
+	})
+
+	t.Run("잘못된 입력은 400", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		site, owner := createOwnerSite(t, ctx, tx, "invite-invalid")
+
+		rec := httptest.NewRecorder()
+		NewAdminHandler(tx).CreateSiteInvitation(rec, newSiteInvitationRequest(ctx, owner, http.MethodPost, site.ID, `{"email":"not-an-email","role":"viewer"}`))
+
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("Expected 400, got %d", rec.Code)
+		}
+	})
+}
@@ -0,0 +1,217 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/june20516/orbithall/internal/database"
+	"github.com/june20516/orbithall/internal/models"
+	"github.com/june20516/orbithall/internal/validators"
+)
+
+// ListSiteMembersResponse는 사이트 멤버 목록 응답입니다
+type ListSiteMembersResponse struct {
+	Members []models.SiteMember `json:"members"`
+}
+
+// memberAuditState는 감사 로그에 기록하는 사이트 멤버 상태입니다
+type memberAuditState struct {
+	Email string `json:"email"`
+	Role  string `json:"role"`
+}
+
+// newMemberAuditState는 사이트 멤버의 감사 로그 상태를 만듭니다
+// member가 nil이면 nil을 반환합니다 (감사 로그에는 NULL로 저장)
+func newMemberAuditState(member *models.SiteMember) *memberAuditState {
+	if member == nil {
+		return nil
+	}
+	return &memberAuditState{
+		Email: member.Email,
+		Role:  member.Role,
+	}
+}
+
+// ListSiteMembers는 사이트의 멤버 목록을 역할과 함께 반환합니다
+// @Summary      사이트 멤버 목록 조회
+// @Description  사이트에 연결된 사용자와 역할(owner, manager, moderator, viewer)을 역할 순서로 반환합니다. 모든 멤버가 조회할 수 있습니다.
+// @Tags         admin
+// @Produce      json
+// @Param        id path int true "Site ID"
+// @Success      200 {object} ListSiteMembersResponse
+// @Failure      400 {string} string "Invalid site ID"
+// @Failure      401 {string} string "Unauthorized"
+// @Failure      403 {string} string "Forbidden"
+// @Failure      500 {string} string "Failed to get members"
+// @Security     BearerAuth
+// @Router       /admin/sites/{id}/members [get]
+func (h *AdminHandler) ListSiteMembers(w http.ResponseWriter, r *http.Request) {
+	siteID, ok := h.authorizeSite(w, r, models.PermissionSiteView)
+	if !ok {
+		return
+	}
+
+	members, err := database.GetSiteMembers(r.Context(), h.db, siteID)
+	if err != nil {
+		http.Error(w, "Failed to get members", http.StatusInternalServerError)
+		return
+	}
+
+	// 200 OK 응답
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(ListSiteMembersResponse{Members: members})
+}
+
+// UpdateSiteMemberRole은 사이트 멤버의 역할을 변경합니다
+// @Summary      사이트 멤버 역할 변경
+// @Description  멤버의 역할을 변경합니다. owner만 변경할 수 있으며, 사이트에는 항상 owner가 한 명 이상 남아야 합니다 (마지막 owner를 강등하면 409).
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        id     path int                            true "Site ID"
+// @Param        userId path int                            true "User ID"
+// @Param        member body validators.SiteMemberRoleInput true "변경할 역할"
+// @Success      200 {object} models.SiteMember
+// @Failure      400 {object} map[string]interface{} "Invalid input"
+// @Failure      401 {string} string "Unauthorized"
+// @Failure      403 {string} string "Forbidden"
+// @Failure      404 {string} string "Member not found"
+// @Failure      409 {string} string "Site must have at least one owner"
+// @Failure      500 {string} string "Failed to update member"
+// @Security     BearerAuth
+// @Router       /admin/sites/{id}/members/{userId} [put]
+func (h *AdminHandler) UpdateSiteMemberRole(w http.ResponseWriter, r *http.Request) {
+	// JSON 요청 파싱
+	var input validators.SiteMemberRoleInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	// 입력 검증
+	if err := input.Validate(); err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	memberID, err := strconv.ParseInt(chi.URLParam(r, "userId"), 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid user ID", http.StatusBadRequest)
+		return
+	}
+
+	siteID, ok := h.authorizeSite(w, r, models.PermissionMemberManage)
+	if !ok {
+		return
+	}
+
+	// 역할 변경 후 변경 전/후 상태를 감사 로그에 기록
+	var updated *models.SiteMember
+	err = database.RunInTx(r.Context(), h.db, func(tx database.DBTX) error {
+		before, err := database.GetSiteMember(r.Context(), tx, siteID, memberID)
+		if err != nil {
+			return err
+		}
+
+		if err := database.UpdateSiteMemberRole(r.Context(), tx, siteID, memberID, input.Role); err != nil {
+			return err
+		}
+
+		updated, err = database.GetSiteMember(r.Context(), tx, siteID, memberID)
+		if err != nil {
+			return err
+		}
+		return recordAudit(r, tx, siteID, models.AuditActionMemberRoleUpdate, models.AuditTargetUser, memberID, newMemberAuditState(before), newMemberAuditState(updated))
+	})
+	if err != nil {
+		writeSiteMemberError(w, err, "Failed to update member")
+		return
+	}
+
+	// 200 OK 응답
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(updated)
+}
+
+// RemoveSiteMember는 사이트 멤버를 제거합니다
+// @Summary      사이트 멤버 제거
+// @Description  멤버를 사이트에서 제거합니다. owner는 다른 멤버를 제거할 수 있고, 모든 멤버는 자기 자신을 제거(사이트 탈퇴)할 수 있습니다. 마지막 owner는 제거할 수 없습니다 (409).
+// @Tags         admin
+// @Param        id     path int true "Site ID"
+// @Param        userId path int true "User ID"
+// @Success      204 "No Content"
+// @Failure      400 {string} string "Invalid ID"
+// @Failure      401 {string} string "Unauthorized"
+// @Failure      403 {string} string "Forbidden"
+// @Failure      404 {string} string "Member not found"
+// @Failure      409 {string} string "Site must have at least one owner"
+// @Failure      500 {string} string "Failed to remove member"
+// @Security     BearerAuth
+// @Router       /admin/sites/{id}/members/{userId} [delete]
+func (h *AdminHandler) RemoveSiteMember(w http.ResponseWriter, r *http.Request) {
+	// Context에서 사용자 추출
+	user, ok := r.Context().Value(userContextKey).(*models.User)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	memberID, err := strconv.ParseInt(chi.URLParam(r, "userId"), 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid user ID", http.StatusBadRequest)
+		return
+	}
+
+	// 자기 자신은 멤버이기만 하면 탈퇴 가능, 다른 멤버 제거는 멤버 관리 권한 필요
+	permission := models.PermissionMemberManage
+	if memberID == user.ID {
+		permission = models.PermissionSiteView
+	}
+
+	siteID, ok := h.authorizeSite(w, r, permission)
+	if !ok {
+		return
+	}
+
+	// 멤버 제거 후 제거 전 상태를 감사 로그에 기록
+	err = database.RunInTx(r.Context(), h.db, func(tx database.DBTX) error {
+		before, err := database.GetSiteMember(r.Context(), tx, siteID, memberID)
+		if err != nil {
+			return err
+		}
+
+		if err := database.RemoveSiteMember(r.Context(), tx, siteID, memberID); err != nil {
+			return err
+		}
+		return recordAudit(r, tx, siteID, models.AuditActionMemberRemove, models.AuditTargetUser, memberID, newMemberAuditState(before), nil)
+	})
+	if err != nil {
+		writeSiteMemberError(w, err, "Failed to remove member")
+		return
+	}
+
+	// 204 No Content 응답
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeSiteMemberError는 멤버 변경 오류를 HTTP 응답으로 변환합니다
+func writeSiteMemberError(w http.ResponseWriter, err error, message string) {
+	switch {
+	case err == sql.ErrNoRows:
+		http.Error(w, "Member not found", http.StatusNotFound)
+	case errors.Is(err, database.ErrLastOwner):
+		http.Error(w, "Site must have at least one owner", http.StatusConflict)
+	default:
+		http.Error(w, message, http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,200 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/june20516/orbithall/internal/database"
+	"github.com/june20516/orbithall/internal/models"
+	"github.com/june20516/orbithall/internal/testhelpers"
+)
+
+// newSiteMemberRequest는 /admin/sites/{id}/members/{userId} 요청을 생성합니다
+func newSiteMemberRequest(ctx context.Context, user *models.User, method string, siteID, memberID int64, body string) *http.Request {
+	site, member := strconv.FormatInt(siteID, 10), strconv.FormatInt(memberID, 10)
+	return newAdminRequest(ctx, user, method, "/admin/sites/"+site+"/members/"+member, body, "id", site, "userId", member)
+}
+
+// TestSiteMemberHandlers는 사이트 멤버 관리 API와 역할별 권한을 테스트합니다
+func TestSiteMemberHandlers(t *testing.T) {
+	db := testhelpers.SetupTestDB(t)
+	defer database.Close(db)
+
+	// createMembers는 사이트와 역할별 멤버를 생성합니다
+	createMembers := func(t *testing.T, ctx context.Context, tx database.DBTX, prefix string, roles ...string) (*models.Site, []*models.User) {
+		site := testhelpers.CreateTestSite(ctx, t, tx, prefix, prefix+".example.com", []string{"https://" + prefix + ".example.com"}, true)
+		var users []*models.User
+		for i, role := range roles {
+			user := &models.User{
+				Email: fmt.Sprintf("%s-%d@example.com", prefix, i),
+				Name:  role,
+			}
+			if err := database.CreateUser(ctx, tx, user); err != nil {
+				t.Fatalf("Failed to create user: %v", err)
+			}
+			if err := database.AddUserToSite(ctx, tx, user.ID, site.ID, role); err != nil {
+				t.Fatalf("Failed to add user to site: %v", err)
+			}
+			users = append(users, user)
+		}
+		return &site, users
+	}
+
+	t.Run("멤버 목록 조회", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		// Given: owner와 viewer
+		site, users := createMembers(t, ctx, tx, "list-members", models.SiteRoleOwner, models.SiteRoleViewer)
+		handler := NewAdminHandler(tx)
+
+		// When: viewer가 조회
+		req := newSiteMemberRequest(ctx, users[1], http.MethodGet, site.ID, 0, "")
+		rec := httptest.NewRecorder()
+		handler.ListSiteMembers(rec, req)
+
+		// Then: 200 OK, 역할 포함
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+		var response ListSiteMembersResponse
+		if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		if len(response.Members) != 2 || response.Members[0].Role != models.SiteRoleOwner {
+			t.Errorf("Unexpected members: %+v", response.Members)
+		}
+	})
+
+	t.Run("owner가 역할 변경", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		// Given
+		site, users := createMembers(t, ctx, tx, "change-role", models.SiteRoleOwner, models.SiteRoleViewer)
+		handler := NewAdminHandler(tx)
+
+		// When: viewer를 moderator로 변경
+		rec := httptest.NewRecorder()
+		handler.UpdateSiteMemberRole(rec, newSiteMemberRequest(ctx, users[0], http.MethodPut, site.ID, users[1].ID, `{"role":"moderator"}`))
+
+		// Then: 200 OK, 감사 로그 기록
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+		var member models.SiteMember
+		if err := json.NewDecoder(rec.Body).Decode(&member); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		if member.Role != models.SiteRoleModerator {
+			t.Errorf("Expected role moderator, got %s", member.Role)
+		}
+
+		entries, _, err := database.ListAuditLog(ctx, tx, site.ID, 10, 0)
+		if err != nil {
+			t.Fatalf("Failed to list audit log: %v", err)
+		}
+		if len(entries) == 0 || entries[0].Action != models.AuditActionMemberRoleUpdate {
+			t.Errorf("Expected member.role_update audit entry, got %+v", entries)
+		}
+	})
+
+	t.Run("manager는 역할 변경 불가", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		site, users := createMembers(t, ctx, tx, "manager-role", models.SiteRoleOwner, models.SiteRoleManager, models.SiteRoleViewer)
+		handler := NewAdminHandler(tx)
+
+		rec := httptest.NewRecorder()
+		handler.UpdateSiteMemberRole(rec, newSiteMemberRequest(ctx, users[1], http.MethodPut, site.ID, users[2].ID, `{"role":"manager"}`))
+
+		if rec.Code != http.StatusForbidden {
+			t.Errorf("Expected 403, got %d", rec.Code)
+		}
+	})
+
+	t.Run("잘못된 역할은 400", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		site, users := createMembers(t, ctx, tx, "invalid-role", models.SiteRoleOwner, models.SiteRoleViewer)
+		handler := NewAdminHandler(tx)
+
+		rec := httptest.NewRecorder()
+		handler.UpdateSiteMemberRole(rec, newSiteMemberRequest(ctx, users[0], http.MethodPut, site.ID, users[1].ID, `{"role":"admin"}`))
+
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("Expected 400, got %d", rec.Code)
+		}
+	})
+
+	t.Run("마지막 owner 강등은 409", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		site, users := createMembers(t, ctx, tx, "last-owner", models.SiteRoleOwner)
+		handler := NewAdminHandler(tx)
+
+		rec := httptest.NewRecorder()
+		handler.UpdateSiteMemberRole(rec, newSiteMemberRequest(ctx, users[0], http.MethodPut, site.ID, users[0].ID, `{"role":"viewer"}`))
+
+		if rec.Code != http.StatusConflict {
+			t.Errorf("Expected 409, got %d", rec.Code)
+		}
+	})
+
+	t.Run("멤버는 스스로 탈퇴 가능", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		site, users := createMembers(t, ctx, tx, "leave", models.SiteRoleOwner, models.SiteRoleViewer)
+		handler := NewAdminHandler(tx)
+
+		rec := httptest.NewRecorder()
+		handler.RemoveSiteMember(rec, newSiteMemberRequest(ctx, users[1], http.MethodDelete, site.ID, users[1].ID, ""))
+
+		if rec.Code != http.StatusNoContent {
+			t.Fatalf("Expected 204, got %d: %s", rec.Code, rec.Body.String())
+		}
+		if hasAccess, _ := database.HasUserSiteAccess(ctx, tx, users[1].ID, site.ID); hasAccess {
+			t.Error("Expected member to be removed")
+		}
+	})
+
+	t.Run("viewer는 다른 멤버 제거 불가", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		site, users := createMembers(t, ctx, tx, "viewer-remove", models.SiteRoleOwner, models.SiteRoleViewer)
+		handler := NewAdminHandler(tx)
+
+		rec := httptest.NewRecorder()
+		handler.RemoveSiteMember(rec, newSiteMemberRequest(ctx, users[1], http.MethodDelete, site.ID, users[0].ID, ""))
+
+		if rec.Code != http.StatusForbidden {
+			t.Errorf("Expected 403, got %d", rec.Code)
+		}
+	})
+
+	t.Run("viewer는 사이트 설정 변경 불가", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		site, users := createMembers(t, ctx, tx, "viewer-update", models.SiteRoleOwner, models.SiteRoleViewer)
+		handler := NewAdminHandler(tx)
+
+		req := newSiteMemberRequest(ctx, users[1], http.MethodPut, site.ID, 0, `{"name":"Renamed"}`)
+		rec := httptest.NewRecorder()
+		handler.UpdateSite(rec, req)
+
+		if rec.Code != http.StatusForbidden {
+			t.Errorf("Expected 403, got %d", rec.Code)
+		}
+	})
+}
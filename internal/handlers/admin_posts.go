@@ -0,0 +1,621 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/june20516/orbithall/internal/database"
+	"github.com/june20516/orbithall/internal/models"
+	"github.com/june20516/orbithall/internal/validators"
+)
+
+// GetSitePost는 사이트의 포스트 메타데이터를 반환합니다
+// @Summary      포스트 조회
+// @Description  사이트에 속한 포스트의 메타데이터(제목, URL, 댓글 수, slug 별칭)를 반환합니다
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        id     path int true "Site ID"
+// @Param        postId path int true "Post ID"
+// @Success      200 {object} models.Post
+// @Failure      400 {string} string "Invalid ID"
+// @Failure      401 {string} string "Unauthorized"
+// @Failure      403 {string} string "Forbidden"
+// @Failure      404 {string} string "Post not found"
+// @Failure      500 {string} string "Failed to get post"
+// @Security     BearerAuth
+// @Router       /admin/sites/{id}/posts/{postId} [get]
+func (h *AdminHandler) GetSitePost(w http.ResponseWriter, r *http.Request) {
+	post, ok := h.authorizeSitePost(w, r, models.PermissionSiteView)
+	if !ok {
+		return
+	}
+
+	// slug 별칭 조회
+	aliases, err := database.ListPostSlugAliases(r.Context(), h.db, post.ID)
+	if err != nil {
+		http.Error(w, "Failed to get post", http.StatusInternalServerError)
+		return
+	}
+	post.Aliases = aliases
+
+	// 응답 반환
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(post)
+}
+
+// UpdateSitePost는 포스트의 제목과 URL을 수정합니다
+// @Summary      포스트 메타데이터 수정
+// @Description  포스트의 제목과 canonical URL을 수정합니다. 위젯이 전달하는 값은 비어있는 경우에만 저장되므로, 이미 채워진 제목이나 URL을 바꾸려면 이 API를 사용합니다. url을 빈 문자열로 보내면 URL이 삭제되어 위젯이 다시 채울 수 있습니다.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        id     path int                        true "Site ID"
+// @Param        postId path int                        true "Post ID"
+// @Param        post   body validators.PostUpdateInput true "수정할 포스트 정보"
+// @Success      200 {object} models.Post
+// @Failure      400 {object} map[string]interface{} "Invalid input"
+// @Failure      401 {string} string "Unauthorized"
+// @Failure      403 {string} string "Forbidden"
+// @Failure      404 {string} string "Post not found"
+// @Failure      500 {string} string "Failed to update post"
+// @Security     BearerAuth
+// @Router       /admin/sites/{id}/posts/{postId} [put]
+func (h *AdminHandler) UpdateSitePost(w http.ResponseWriter, r *http.Request) {
+	// Content-Type 검증
+	if r.Header.Get("Content-Type") != "application/json" {
+		http.Error(w, "Content-Type must be application/json", http.StatusBadRequest)
+		return
+	}
+
+	// JSON 요청 파싱
+	var input validators.PostUpdateInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	// 입력 검증
+	if err := input.Validate(); err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	post, ok := h.authorizeSitePost(w, r, models.PermissionSiteManage)
+	if !ok {
+		return
+	}
+
+	// 수정할 필드 결정 (제공된 필드만 수정)
+	title := post.Title
+	postURL := post.URL
+
+	if input.Title != nil {
+		title = strings.TrimSpace(*input.Title)
+	}
+	if input.URL != nil {
+		postURL = *input.URL
+	}
+
+	// 포스트 수정 후 재조회 (변경 전/후 상태를 감사 로그에 기록)
+	var updatedPost *models.Post
+	err := database.RunInTx(r.Context(), h.db, func(tx database.DBTX) error {
+		if err := database.UpdatePost(r.Context(), tx, post.ID, title, postURL); err != nil {
+			return err
+		}
+		var err error
+		updatedPost, err = database.GetPostByID(r.Context(), tx, post.ID)
+		if err != nil {
+			return err
+		}
+		if updatedPost == nil {
+			return sql.ErrNoRows
+		}
+		return recordAudit(r, tx, post.SiteID, models.AuditActionPostUpdate, models.AuditTargetPost, post.ID, newPostAuditState(post), newPostAuditState(updatedPost))
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			http.Error(w, "Post not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, "Failed to update post", http.StatusInternalServerError)
+		return
+	}
+
+	// 200 OK 응답
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(updatedPost)
+}
+
+// LockSitePost는 포스트의 댓글 작성을 잠급니다
+// @Summary      포스트 잠금
+// @Description  포스트에 새 댓글을 작성하지 못하도록 잠급니다. 기존 댓글 조회는 계속 가능하며, 댓글 목록 응답의 is_locked가 true가 됩니다.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        id     path int true "Site ID"
+// @Param        postId path int true "Post ID"
+// @Success      200 {object} models.Post
+// @Failure      400 {string} string "Invalid ID"
+// @Failure      401 {string} string "Unauthorized"
+// @Failure      403 {string} string "Forbidden"
+// @Failure      404 {string} string "Post not found"
+// @Failure      500 {string} string "Failed to lock post"
+// @Security     BearerAuth
+// @Router       /admin/sites/{id}/posts/{postId}/lock [post]
+func (h *AdminHandler) LockSitePost(w http.ResponseWriter, r *http.Request) {
+	h.setSitePostLocked(w, r, true)
+}
+
+// UnlockSitePost는 포스트의 댓글 작성 잠금을 해제합니다
+// @Summary      포스트 잠금 해제
+// @Description  포스트의 댓글 작성 잠금을 해제합니다. 사이트의 자동 마감 기간이 지난 포스트는 잠금을 해제해도 댓글을 작성할 수 없습니다.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        id     path int true "Site ID"
+// @Param        postId path int true "Post ID"
+// @Success      200 {object} models.Post
+// @Failure      400 {string} string "Invalid ID"
+// @Failure      401 {string} string "Unauthorized"
+// @Failure      403 {string} string "Forbidden"
+// @Failure      404 {string} string "Post not found"
+// @Failure      500 {string} string "Failed to unlock post"
+// @Security     BearerAuth
+// @Router       /admin/sites/{id}/posts/{postId}/unlock [post]
+func (h *AdminHandler) UnlockSitePost(w http.ResponseWriter, r *http.Request) {
+	h.setSitePostLocked(w, r, false)
+}
+
+// setSitePostLocked는 포스트 잠금/해제 핸들러의 공통 처리 로직입니다
+func (h *AdminHandler) setSitePostLocked(w http.ResponseWriter, r *http.Request, locked bool) {
+	post, ok := h.authorizeSitePost(w, r, models.PermissionCommentModerate)
+	if !ok {
+		return
+	}
+
+	// 잠금 상태 변경
+	action := models.AuditActionPostUnlock
+	if locked {
+		action = models.AuditActionPostLock
+	}
+	before := newPostAuditState(post)
+	after := *before
+	after.IsLocked = locked
+	err := database.RunInTx(r.Context(), h.db, func(tx database.DBTX) error {
+		if err := database.SetPostLocked(r.Context(), tx, post.ID, locked); err != nil {
+			return err
+		}
+		return recordAudit(r, tx, post.SiteID, action, models.AuditTargetPost, post.ID, before, &after)
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			http.Error(w, "Post not found", http.StatusNotFound)
+			return
+		}
+		if locked {
+			http.Error(w, "Failed to lock post", http.StatusInternalServerError)
+		} else {
+			http.Error(w, "Failed to unlock post", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	post.IsLocked = locked
+
+	// 200 OK 응답
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(post)
+}
+
+// AddSitePostAlias는 포스트에 slug 별칭을 추가합니다
+// @Summary      포스트 slug 별칭 추가
+// @Description  포스트에 이전 slug를 별칭으로 추가합니다. 별칭으로 댓글을 조회하거나 작성하면 이 포스트가 사용됩니다. 이미 포스트가 존재하는 slug는 별칭으로 추가할 수 없으며, 이 경우 포스트 병합 API를 사용합니다.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        id     path int                       true "Site ID"
+// @Param        postId path int                       true "Post ID"
+// @Param        alias  body validators.PostAliasInput true "추가할 별칭"
+// @Success      201 {object} models.Post
+// @Failure      400 {object} map[string]interface{} "Invalid input"
+// @Failure      401 {string} string "Unauthorized"
+// @Failure      403 {string} string "Forbidden"
+// @Failure      404 {string} string "Post not found"
+// @Failure      409 {string} string "Slug already in use"
+// @Failure      500 {string} string "Failed to add alias"
+// @Security     BearerAuth
+// @Router       /admin/sites/{id}/posts/{postId}/aliases [post]
+func (h *AdminHandler) AddSitePostAlias(w http.ResponseWriter, r *http.Request) {
+	// Content-Type 검증
+	if r.Header.Get("Content-Type") != "application/json" {
+		http.Error(w, "Content-Type must be application/json", http.StatusBadRequest)
+		return
+	}
+
+	// JSON 요청 파싱
+	var input validators.PostAliasInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	// 입력 검증
+	if err := input.Validate(); err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	post, ok := h.authorizeSitePost(w, r, models.PermissionSiteManage)
+	if !ok {
+		return
+	}
+
+	// 별칭 추가
+	slug := strings.TrimSpace(input.Slug)
+	err := database.RunInTx(r.Context(), h.db, func(tx database.DBTX) error {
+		if err := database.AddPostSlugAlias(r.Context(), tx, post.SiteID, post.ID, slug); err != nil {
+			return err
+		}
+		return recordAudit(r, tx, post.SiteID, models.AuditActionPostAliasAdd, models.AuditTargetPost, post.ID, nil, map[string]string{"alias": slug})
+	})
+	if err != nil {
+		if errors.Is(err, database.ErrSlugInUse) {
+			http.Error(w, "Slug already in use", http.StatusConflict)
+			return
+		}
+		http.Error(w, "Failed to add alias", http.StatusInternalServerError)
+		return
+	}
+
+	// 별칭 목록 재조회
+	aliases, err := database.ListPostSlugAliases(r.Context(), h.db, post.ID)
+	if err != nil {
+		http.Error(w, "Failed to get aliases", http.StatusInternalServerError)
+		return
+	}
+	post.Aliases = aliases
+
+	// 201 Created 응답
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(post)
+}
+
+// DeleteSitePostAlias는 포스트의 slug 별칭을 삭제합니다
+// @Summary      포스트 slug 별칭 삭제
+// @Description  포스트의 slug 별칭을 삭제합니다. 이후 해당 slug로 댓글을 작성하면 새 포스트가 생성됩니다.
+// @Tags         admin
+// @Param        id     path int    true "Site ID"
+// @Param        postId path int    true "Post ID"
+// @Param        slug   path string true "Alias slug"
+// @Success      204 "No Content"
+// @Failure      400 {string} string "Invalid ID"
+// @Failure      401 {string} string "Unauthorized"
+// @Failure      403 {string} string "Forbidden"
+// @Failure      404 {string} string "Alias not found"
+// @Failure      500 {string} string "Failed to delete alias"
+// @Security     BearerAuth
+// @Router       /admin/sites/{id}/posts/{postId}/aliases/{slug} [delete]
+func (h *AdminHandler) DeleteSitePostAlias(w http.ResponseWriter, r *http.Request) {
+	post, ok := h.authorizeSitePost(w, r, models.PermissionSiteManage)
+	if !ok {
+		return
+	}
+
+	// 별칭 삭제
+	slug := chi.URLParam(r, "slug")
+	err := database.RunInTx(r.Context(), h.db, func(tx database.DBTX) error {
+		if err := database.DeletePostSlugAlias(r.Context(), tx, post.ID, slug); err != nil {
+			return err
+		}
+		return recordAudit(r, tx, post.SiteID, models.AuditActionPostAliasDelete, models.AuditTargetPost, post.ID, map[string]string{"alias": slug}, nil)
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			http.Error(w, "Alias not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, "Failed to delete alias", http.StatusInternalServerError)
+		return
+	}
+
+	// 204 No Content 응답
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// MergeSitePostResponse는 포스트 병합 응답입니다
+type MergeSitePostResponse struct {
+	Post          *models.Post `json:"post"`
+	MovedComments int64        `json:"moved_comments"`
+}
+
+// MergeSitePost는 다른 포스트의 댓글을 이 포스트로 병합합니다
+// @Summary      포스트 병합
+// @Description  source_post_id 포스트의 모든 댓글을 이 포스트로 옮기고 source 포스트를 삭제합니다. source 포스트의 slug와 별칭은 이 포스트의 별칭이 되며, 댓글 수는 다시 계산됩니다. 블로그 포스트의 URL이 바뀌어 새 slug로 포스트가 따로 생성된 경우에 사용합니다.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        id     path int                       true "Site ID"
+// @Param        postId path int                       true "Target Post ID"
+// @Param        merge  body validators.PostMergeInput true "병합할 포스트"
+// @Success      200 {object} MergeSitePostResponse
+// @Failure      400 {object} map[string]interface{} "Invalid input"
+// @Failure      401 {string} string "Unauthorized"
+// @Failure      403 {string} string "Forbidden"
+// @Failure      404 {string} string "Post not found"
+// @Failure      500 {string} string "Failed to merge posts"
+// @Security     BearerAuth
+// @Router       /admin/sites/{id}/posts/{postId}/merge [post]
+func (h *AdminHandler) MergeSitePost(w http.ResponseWriter, r *http.Request) {
+	// Content-Type 검증
+	if r.Header.Get("Content-Type") != "application/json" {
+		http.Error(w, "Content-Type must be application/json", http.StatusBadRequest)
+		return
+	}
+
+	// JSON 요청 파싱
+	var input validators.PostMergeInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	// 입력 검증
+	if err := input.Validate(); err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	target, ok := h.authorizeSitePost(w, r, models.PermissionSiteManage)
+	if !ok {
+		return
+	}
+
+	if input.SourcePostID == target.ID {
+		http.Error(w, "Cannot merge post into itself", http.StatusBadRequest)
+		return
+	}
+
+	// source 포스트 확인 (다른 사이트의 포스트는 존재하지 않는 것으로 취급)
+	source, err := database.GetPostByID(r.Context(), h.db, input.SourcePostID)
+	if err != nil {
+		http.Error(w, "Failed to get post", http.StatusInternalServerError)
+		return
+	}
+	if source == nil || source.SiteID != target.SiteID {
+		http.Error(w, "Source post not found", http.StatusNotFound)
+		return
+	}
+
+	// 병합 (단일 트랜잭션, 병합된 source 포스트를 감사 로그에 기록)
+	var moved int64
+	err = database.RunInTx(r.Context(), h.db, func(tx database.DBTX) error {
+		var err error
+		moved, err = database.MergePosts(r.Context(), tx, source.ID, target.ID)
+		if err != nil {
+			return err
+		}
+		before := map[string]any{
+			"source_post_id": source.ID,
+			"source":         newPostAuditState(source),
+			"target":         newPostAuditState(target),
+		}
+		after := map[string]any{"moved_comments": moved}
+		return recordAudit(r, tx, target.SiteID, models.AuditActionPostMerge, models.AuditTargetPost, target.ID, before, after)
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			http.Error(w, "Post not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, "Failed to merge posts", http.StatusInternalServerError)
+		return
+	}
+
+	// 병합된 포스트 재조회
+	mergedPost, err := database.GetPostByID(r.Context(), h.db, target.ID)
+	if err != nil || mergedPost == nil {
+		http.Error(w, "Failed to get merged post", http.StatusInternalServerError)
+		return
+	}
+	mergedPost.Aliases, err = database.ListPostSlugAliases(r.Context(), h.db, target.ID)
+	if err != nil {
+		http.Error(w, "Failed to get merged post", http.StatusInternalServerError)
+		return
+	}
+
+	// 200 OK 응답
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(MergeSitePostResponse{
+		Post:          mergedPost,
+		MovedComments: moved,
+	})
+}
+
+// ReconcileCommentCountsResponse는 댓글 수 재계산 응답입니다
+type ReconcileCommentCountsResponse struct {
+	DryRun bool                       `json:"dry_run"`
+	Drift  []models.CommentCountDrift `json:"drift"`
+}
+
+// ReconcileSiteCommentCounts는 사이트 포스트들의 댓글 수를 실제 댓글 수로 다시 계산합니다
+// @Summary      댓글 수 재계산
+// @Description  사이트의 모든 포스트에 대해 저장된 comment_count를 삭제되지 않은 실제 댓글 수와 비교하고, 차이가 있으면 보정합니다. 응답의 drift에는 차이가 있던 포스트와 보정 전/후 값이 포함됩니다. dry_run=true면 값을 변경하지 않고 차이만 보고합니다.
+// @Tags         admin
+// @Produce      json
+// @Param        id      path  int  true  "Site ID"
+// @Param        dry_run query bool false "true면 변경하지 않고 차이만 보고"
+// @Success      200 {object} ReconcileCommentCountsResponse
+// @Failure      400 {string} string "Invalid site ID"
+// @Failure      401 {string} string "Unauthorized"
+// @Failure      403 {string} string "Forbidden"
+// @Failure      500 {string} string "Failed to reconcile comment counts"
+// @Security     BearerAuth
+// @Router       /admin/sites/{id}/comment-counts/reconcile [post]
+func (h *AdminHandler) ReconcileSiteCommentCounts(w http.ResponseWriter, r *http.Request) {
+	siteID, ok := h.authorizeSite(w, r, models.PermissionSiteManage)
+	if !ok {
+		return
+	}
+
+	dryRun := r.URL.Query().Get("dry_run") == "true"
+
+	// 댓글 수 재계산 (실제로 보정한 경우에만 감사 로그에 기록)
+	var drift []models.CommentCountDrift
+	err := database.RunInTx(r.Context(), h.db, func(tx database.DBTX) error {
+		var err error
+		drift, err = database.ReconcileCommentCounts(r.Context(), tx, siteID, dryRun)
+		if err != nil {
+			return err
+		}
+		if dryRun || len(drift) == 0 {
+			return nil
+		}
+		return recordAudit(r, tx, siteID, models.AuditActionCommentReconcile, models.AuditTargetSite, siteID, nil, map[string]any{"drift": drift})
+	})
+	if err != nil {
+		http.Error(w, "Failed to reconcile comment counts", http.StatusInternalServerError)
+		return
+	}
+
+	// 200 OK 응답
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(ReconcileCommentCountsResponse{
+		DryRun: dryRun,
+		Drift:  drift,
+	})
+}
+
+// WipeSandboxResponse는 샌드박스 데이터 삭제 응답입니다
+type WipeSandboxResponse struct {
+	DeletedPosts    int64 `json:"deleted_posts"`
+	DeletedComments int64 `json:"deleted_comments"`
+}
+
+// WipeSiteSandbox는 테스트 모드 키로 작성된 사이트의 샌드박스 데이터를 모두 삭제합니다
+// @Summary      샌드박스 데이터 삭제
+// @Description  테스트 모드 API 키(orb_test_)로 생성된 사이트의 포스트와 댓글을 모두 삭제합니다. 실제 데이터와 API 키는 변경되지 않습니다.
+// @Tags         admin
+// @Produce      json
+// @Param        id path int true "Site ID"
+// @Success      200 {object} WipeSandboxResponse
+// @Failure      400 {string} string "Invalid site ID"
+// @Failure      401 {string} string "Unauthorized"
+// @Failure      403 {string} string "Forbidden"
+// @Failure      500 {string} string "Failed to wipe sandbox data"
+// @Security     BearerAuth
+// @Router       /admin/sites/{id}/sandbox [delete]
+func (h *AdminHandler) WipeSiteSandbox(w http.ResponseWriter, r *http.Request) {
+	siteID, ok := h.authorizeSite(w, r, models.PermissionSiteManage)
+	if !ok {
+		return
+	}
+
+	// 샌드박스 데이터 삭제 (삭제된 데이터가 있는 경우에만 감사 로그에 기록)
+	var response WipeSandboxResponse
+	err := database.RunInTx(r.Context(), h.db, func(tx database.DBTX) error {
+		var err error
+		response.DeletedPosts, response.DeletedComments, err = database.DeleteSandboxData(r.Context(), tx, siteID)
+		if err != nil {
+			return err
+		}
+		if response.DeletedPosts == 0 {
+			return nil
+		}
+		return recordAudit(r, tx, siteID, models.AuditActionSandboxWipe, models.AuditTargetSite, siteID, nil, response)
+	})
+	if err != nil {
+		http.Error(w, "Failed to wipe sandbox data", http.StatusInternalServerError)
+		return
+	}
+
+	// 200 OK 응답
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
+// authorizeSite는 URL의 사이트 ID를 검증하고 사용자의 사이트 역할이 권한(models.Permission*)을 허용하는지 확인합니다
+// 실패 시 에러 응답을 작성하고 false를 반환합니다
+func (h *AdminHandler) authorizeSite(w http.ResponseWriter, r *http.Request, permission string) (int64, bool) {
+	// Context에서 사용자 추출
+	user, ok := r.Context().Value(userContextKey).(*models.User)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return 0, false
+	}
+
+	// URL 파라미터에서 site_id 추출
+	siteID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid site ID", http.StatusBadRequest)
+		return 0, false
+	}
+
+	// 접근 권한 확인
+	hasAccess, err := database.HasUserSitePermission(r.Context(), h.db, user.ID, siteID, permission)
+	if err != nil {
+		http.Error(w, "Failed to check access", http.StatusInternalServerError)
+		return 0, false
+	}
+
+	if !hasAccess {
+		http.Error(w, "Forbidden", http.StatusForbidden)
+		return 0, false
+	}
+
+	return siteID, true
+}
+
+// authorizeSitePost는 URL의 사이트/포스트 ID를 검증하고 사용자의 사이트 역할이 권한을 허용하는지 확인합니다
+// 포스트가 해당 사이트에 속하지 않으면 404로 응답합니다
+// 실패 시 에러 응답을 작성하고 false를 반환합니다
+func (h *AdminHandler) authorizeSitePost(w http.ResponseWriter, r *http.Request, permission string) (*models.Post, bool) {
+	// URL 파라미터에서 post_id 추출
+	postID, err := strconv.ParseInt(chi.URLParam(r, "postId"), 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid post ID", http.StatusBadRequest)
+		return nil, false
+	}
+
+	siteID, ok := h.authorizeSite(w, r, permission)
+	if !ok {
+		return nil, false
+	}
+
+	// 포스트 조회 (다른 사이트의 포스트는 존재하지 않는 것으로 취급)
+	post, err := database.GetPostByID(r.Context(), h.db, postID)
+	if err != nil {
+		http.Error(w, "Failed to get post", http.StatusInternalServerError)
+		return nil, false
+	}
+	if post == nil || post.SiteID != siteID {
+		http.Error(w, "Post not found", http.StatusNotFound)
+		return nil, false
+	}
+
+	return post, true
+}
@@ -0,0 +1,494 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/june20516/orbithall/internal/database"
+	"github.com/june20516/orbithall/internal/models"
+	"github.com/june20516/orbithall/internal/testhelpers"
+)
+
+// newSitePostRequest는 /admin/sites/{id}/posts/{postId} 요청을 생성합니다
+func newSitePostRequest(ctx context.Context, user *models.User, method string, siteID, postID int64, body []byte) *http.Request {
+	site, post := strconv.FormatInt(siteID, 10), strconv.FormatInt(postID, 10)
+	return newAdminRequest(ctx, user, method, "/admin/sites/"+site+"/posts/"+post, string(body), "id", site, "postId", post)
+}
+
+// setupSitePostTest는 사용자, 사이트, 포스트를 생성합니다
+func setupSitePostTest(t *testing.T, ctx context.Context, tx testhelpers.DBTX, email string) (*models.User, *models.Site, models.Post) {
+	t.Helper()
+
+	user := &models.User{
+		Email: email,
+		Name:  "Post Admin",
+	}
+	if err := database.CreateUser(ctx, tx, user); err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+	site := &models.Site{
+		Name:        "Post Site",
+		Domain:      "post-site.com",
+		CORSOrigins: []string{"https://post-site.com"},
+		IsActive:    true,
+		IsVerified:  true,
+	}
+	if err := database.CreateSiteForUser(ctx, tx, site, user.ID); err != nil {
+		t.Fatalf("Failed to create site: %v", err)
+	}
+	post := testhelpers.CreateTestPost(ctx, t, tx, site.ID, "admin-post", "admin-post")
+	return user, site, post
+}
+
+// TestGetSitePost는 포스트 메타데이터 조회 기능을 테스트합니다
+func TestGetSitePost(t *testing.T) {
+	db := testhelpers.SetupTestDB(t)
+	defer database.Close(db)
+
+	t.Run("포스트 조회 성공", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		// Given: 사용자, 사이트, 포스트
+		user, site, post := setupSitePostTest(t, ctx, tx, "get-post@example.com")
+
+		// When: GetSitePost 호출
+		rec := httptest.NewRecorder()
+		NewAdminHandler(tx).GetSitePost(rec, newSitePostRequest(ctx, user, http.MethodGet, site.ID, post.ID, nil))
+
+		// Then: 200 OK
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, rec.Code, rec.Body.String())
+		}
+		var response models.Post
+		if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+		if response.ID != post.ID || response.Slug != "admin-post" {
+			t.Errorf("Unexpected post: %+v", response)
+		}
+	})
+
+	t.Run("다른 사이트의 포스트면 404", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		// Given: 다른 사이트에 속한 포스트
+		user, site, _ := setupSitePostTest(t, ctx, tx, "other-post@example.com")
+		otherSite := testhelpers.CreateTestSite(ctx, t, tx, "Other Site", "other-site.com", []string{"https://other-site.com"}, true)
+		otherPost := testhelpers.CreateTestPost(ctx, t, tx, otherSite.ID, "other-post", "Other Post")
+
+		// When: 내 사이트 경로로 다른 사이트의 포스트 조회
+		rec := httptest.NewRecorder()
+		NewAdminHandler(tx).GetSitePost(rec, newSitePostRequest(ctx, user, http.MethodGet, site.ID, otherPost.ID, nil))
+
+		// Then: 404 Not Found
+		if rec.Code != http.StatusNotFound {
+			t.Errorf("Expected status %d, got %d", http.StatusNotFound, rec.Code)
+		}
+	})
+}
+
+// TestUpdateSitePost는 포스트 메타데이터 수정 기능을 테스트합니다
+func TestUpdateSitePost(t *testing.T) {
+	db := testhelpers.SetupTestDB(t)
+	defer database.Close(db)
+
+	t.Run("제목과 URL 수정 성공", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		// Given: 사용자, 사이트, 포스트
+		user, site, post := setupSitePostTest(t, ctx, tx, "update-post@example.com")
+		body, _ := json.Marshal(map[string]string{
+			"title": "Admin Title",
+			"url":   "https://post-site.com/posts/admin-post",
+		})
+
+		// When: UpdateSitePost 호출
+		rec := httptest.NewRecorder()
+		NewAdminHandler(tx).UpdateSitePost(rec, newSitePostRequest(ctx, user, http.MethodPut, site.ID, post.ID, body))
+
+		// Then: 200 OK, 수정된 값 반환
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, rec.Code, rec.Body.String())
+		}
+		var response models.Post
+		if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+		if response.Title != "Admin Title" || response.URL != "https://post-site.com/posts/admin-post" {
+			t.Errorf("Unexpected post: %+v", response)
+		}
+
+		// 관리자가 지정한 제목은 위젯이 덮어쓸 수 없음
+		updated, err := database.FillPostMetadata(ctx, tx, post.ID, "Widget Title", "")
+		if err != nil || updated {
+			t.Errorf("Expected admin title to be kept (updated=%v, err=%v)", updated, err)
+		}
+	})
+
+	t.Run("제공된 필드만 수정", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		// Given: URL이 채워진 포스트
+		user, site, post := setupSitePostTest(t, ctx, tx, "partial-post@example.com")
+		if err := database.UpdatePost(ctx, tx, post.ID, "Title", "https://post-site.com/a"); err != nil {
+			t.Fatalf("Failed to update post: %v", err)
+		}
+		body, _ := json.Marshal(map[string]string{"title": "New Title"})
+
+		// When: 제목만 수정
+		rec := httptest.NewRecorder()
+		NewAdminHandler(tx).UpdateSitePost(rec, newSitePostRequest(ctx, user, http.MethodPut, site.ID, post.ID, body))
+
+		// Then: URL은 유지
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, rec.Code, rec.Body.String())
+		}
+		var response models.Post
+		json.Unmarshal(rec.Body.Bytes(), &response)
+		if response.Title != "New Title" || response.URL != "https://post-site.com/a" {
+			t.Errorf("Unexpected post: %+v", response)
+		}
+	})
+
+	t.Run("잘못된 URL이면 400", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		// Given: 사용자, 사이트, 포스트
+		user, site, post := setupSitePostTest(t, ctx, tx, "invalid-post@example.com")
+		body, _ := json.Marshal(map[string]string{"url": "javascript:alert(1)"})
+
+		// When: 잘못된 URL로 수정
+		rec := httptest.NewRecorder()
+		NewAdminHandler(tx).UpdateSitePost(rec, newSitePostRequest(ctx, user, http.MethodPut, site.ID, post.ID, body))
+
+		// Then: 400 Bad Request
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("Expected status %d, got %d", http.StatusBadRequest, rec.Code)
+		}
+	})
+
+	t.Run("다른 사용자의 사이트면 403", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		// Given: 사이트 소유자가 아닌 사용자
+		_, site, post := setupSitePostTest(t, ctx, tx, "owner-post@example.com")
+		other := &models.User{
+			Email: "intruder@example.com",
+			Name:  "Intruder",
+		}
+		if err := database.CreateUser(ctx, tx, other); err != nil {
+			t.Fatalf("Failed to create user: %v", err)
+		}
+		body, _ := json.Marshal(map[string]string{"title": "Hacked"})
+
+		// When: 다른 사용자가 수정
+		rec := httptest.NewRecorder()
+		NewAdminHandler(tx).UpdateSitePost(rec, newSitePostRequest(ctx, other, http.MethodPut, site.ID, post.ID, body))
+
+		// Then: 403 Forbidden
+		if rec.Code != http.StatusForbidden {
+			t.Errorf("Expected status %d, got %d", http.StatusForbidden, rec.Code)
+		}
+	})
+}
+
+// TestLockSitePost는 포스트 잠금/해제 기능을 테스트합니다
+func TestLockSitePost(t *testing.T) {
+	db := testhelpers.SetupTestDB(t)
+	defer database.Close(db)
+
+	t.Run("잠금 후 해제 성공", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		// Given: 사용자, 사이트, 포스트
+		user, site, post := setupSitePostTest(t, ctx, tx, "lock-post@example.com")
+		handler := NewAdminHandler(tx)
+
+		// When: LockSitePost 호출
+		rec := httptest.NewRecorder()
+		handler.LockSitePost(rec, newSitePostRequest(ctx, user, http.MethodPost, site.ID, post.ID, nil))
+
+		// Then: 200 OK, is_locked=true
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, rec.Code, rec.Body.String())
+		}
+		var response models.Post
+		json.Unmarshal(rec.Body.Bytes(), &response)
+		if !response.IsLocked {
+			t.Error("Expected is_locked true")
+		}
+
+		// When: UnlockSitePost 호출
+		rec = httptest.NewRecorder()
+		handler.UnlockSitePost(rec, newSitePostRequest(ctx, user, http.MethodPost, site.ID, post.ID, nil))
+
+		// Then: 200 OK, DB에도 반영
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, rec.Code, rec.Body.String())
+		}
+		stored, _ := database.GetPostByID(ctx, tx, post.ID)
+		if stored.IsLocked {
+			t.Error("Expected post to be unlocked")
+		}
+	})
+
+	t.Run("다른 사용자의 사이트면 403", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		// Given: 사이트 소유자가 아닌 사용자
+		_, site, post := setupSitePostTest(t, ctx, tx, "lock-owner@example.com")
+		other := &models.User{
+			Email: "lock-intruder@example.com",
+			Name:  "Intruder",
+		}
+		if err := database.CreateUser(ctx, tx, other); err != nil {
+			t.Fatalf("Failed to create user: %v", err)
+		}
+
+		// When: 다른 사용자가 잠금
+		rec := httptest.NewRecorder()
+		NewAdminHandler(tx).LockSitePost(rec, newSitePostRequest(ctx, other, http.MethodPost, site.ID, post.ID, nil))
+
+		// Then: 403 Forbidden
+		if rec.Code != http.StatusForbidden {
+			t.Errorf("Expected status %d, got %d", http.StatusForbidden, rec.Code)
+		}
+	})
+}
+
+// TestSitePostAliases는 포스트 slug 별칭 추가/삭제 기능을 테스트합니다
+func TestSitePostAliases(t *testing.T) {
+	db := testhelpers.SetupTestDB(t)
+	defer database.Close(db)
+
+	t.Run("별칭 추가 후 삭제 성공", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		// Given: 사용자, 사이트, 포스트
+		user, site, post := setupSitePostTest(t, ctx, tx, "alias-post@example.com")
+		handler := NewAdminHandler(tx)
+		body, _ := json.Marshal(map[string]string{"slug": "old-admin-post"})
+
+		// When: AddSitePostAlias 호출
+		rec := httptest.NewRecorder()
+		handler.AddSitePostAlias(rec, newSitePostRequest(ctx, user, http.MethodPost, site.ID, post.ID, body))
+
+		// Then: 201 Created, 별칭 포함
+		if rec.Code != http.StatusCreated {
+			t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusCreated, rec.Code, rec.Body.String())
+		}
+		var response models.Post
+		json.Unmarshal(rec.Body.Bytes(), &response)
+		if len(response.Aliases) != 1 || response.Aliases[0] != "old-admin-post" {
+			t.Errorf("Unexpected aliases: %v", response.Aliases)
+		}
+
+		// When: DeleteSitePostAlias 호출
+		req := newSitePostRequest(ctx, user, http.MethodDelete, site.ID, post.ID, nil)
+		chi.RouteContext(req.Context()).URLParams.Add("slug", "old-admin-post")
+		rec = httptest.NewRecorder()
+		handler.DeleteSitePostAlias(rec, req)
+
+		// Then: 204 No Content
+		if rec.Code != http.StatusNoContent {
+			t.Errorf("Expected status %d, got %d", http.StatusNoContent, rec.Code)
+		}
+	})
+
+	t.Run("다른 포스트의 slug면 409", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		// Given: 같은 사이트의 다른 포스트
+		user, site, post := setupSitePostTest(t, ctx, tx, "alias-conflict@example.com")
+		testhelpers.CreateTestPost(ctx, t, tx, site.ID, "taken-slug", "Taken")
+		body, _ := json.Marshal(map[string]string{"slug": "taken-slug"})
+
+		// When: 사용 중인 slug를 별칭으로 추가
+		rec := httptest.NewRecorder()
+		NewAdminHandler(tx).AddSitePostAlias(rec, newSitePostRequest(ctx, user, http.MethodPost, site.ID, post.ID, body))
+
+		// Then: 409 Conflict
+		if rec.Code != http.StatusConflict {
+			t.Errorf("Expected status %d, got %d", http.StatusConflict, rec.Code)
+		}
+	})
+}
+
+// TestMergeSitePost는 포스트 병합 기능을 테스트합니다
+func TestMergeSitePost(t *testing.T) {
+	db := testhelpers.SetupTestDB(t)
+	defer database.Close(db)
+
+	t.Run("병합 성공", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		// Given: 댓글이 있는 이전 slug 포스트
+		user, site, post := setupSitePostTest(t, ctx, tx, "merge-post@example.com")
+		oldPost := testhelpers.CreateTestPost(ctx, t, tx, site.ID, "old-admin-post", "Old Post")
+		if _, err := database.CreateComment(ctx, tx, oldPost.ID, nil, "홍길동", "test1234", "이전 댓글", "127.0.0.1", "test"); err != nil {
+			t.Fatalf("Failed to create comment: %v", err)
+		}
+		body, _ := json.Marshal(map[string]int64{"source_post_id": oldPost.ID})
+
+		// When: MergeSitePost 호출
+		rec := httptest.NewRecorder()
+		NewAdminHandler(tx).MergeSitePost(rec, newSitePostRequest(ctx, user, http.MethodPost, site.ID, post.ID, body))
+
+		// Then: 200 OK, 댓글 이동 및 별칭 등록
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, rec.Code, rec.Body.String())
+		}
+		var response MergeSitePostResponse
+		if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+		if response.MovedComments != 1 || response.Post.CommentCount != 1 {
+			t.Errorf("Unexpected response: %+v", response)
+		}
+		if len(response.Post.Aliases) != 1 || response.Post.Aliases[0] != "old-admin-post" {
+			t.Errorf("Unexpected aliases: %v", response.Post.Aliases)
+		}
+	})
+
+	t.Run("다른 사이트의 포스트면 404", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		// Given: 다른 사이트의 포스트
+		user, site, post := setupSitePostTest(t, ctx, tx, "merge-other@example.com")
+		otherSite := testhelpers.CreateTestSite(ctx, t, tx, "Other Site", "merge-other-site.com", []string{"https://merge-other-site.com"}, true)
+		otherPost := testhelpers.CreateTestPost(ctx, t, tx, otherSite.ID, "other-post", "Other Post")
+		body, _ := json.Marshal(map[string]int64{"source_post_id": otherPost.ID})
+
+		// When: 다른 사이트의 포스트 병합 시도
+		rec := httptest.NewRecorder()
+		NewAdminHandler(tx).MergeSitePost(rec, newSitePostRequest(ctx, user, http.MethodPost, site.ID, post.ID, body))
+
+		// Then: 404 Not Found
+		if rec.Code != http.StatusNotFound {
+			t.Errorf("Expected status %d, got %d", http.StatusNotFound, rec.Code)
+		}
+	})
+}
+
+// TestReconcileSiteCommentCounts는 댓글 수 재계산 기능을 테스트합니다
+func TestReconcileSiteCommentCounts(t *testing.T) {
+	db := testhelpers.SetupTestDB(t)
+	defer database.Close(db)
+
+	ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+	defer cleanup()
+
+	// Given: comment_count가 어긋난 포스트
+	user, site, post := setupSitePostTest(t, ctx, tx, "reconcile@example.com")
+	if _, err := tx.ExecContext(ctx, "UPDATE posts SET comment_count = 4 WHERE id = $1", post.ID); err != nil {
+		t.Fatalf("Failed to corrupt comment_count: %v", err)
+	}
+	handler := NewAdminHandler(tx)
+
+	newRequest := func(target string) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, target, nil)
+		req = req.WithContext(context.WithValue(ctx, userContextKey, user))
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", strconv.FormatInt(site.ID, 10))
+		return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	}
+
+	t.Run("dry run은 차이만 보고", func(t *testing.T) {
+		// When: dry_run=true로 호출
+		rec := httptest.NewRecorder()
+		handler.ReconcileSiteCommentCounts(rec, newRequest("/admin/sites/1/comment-counts/reconcile?dry_run=true"))
+
+		// Then: 200 OK, 차이 보고, 값 유지
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, rec.Code, rec.Body.String())
+		}
+		var response ReconcileCommentCountsResponse
+		json.Unmarshal(rec.Body.Bytes(), &response)
+		if !response.DryRun || len(response.Drift) != 1 || response.Drift[0].StoredCount != 4 || response.Drift[0].ActualCount != 0 {
+			t.Errorf("Unexpected response: %+v", response)
+		}
+		stored, _ := database.GetPostByID(ctx, tx, post.ID)
+		if stored.CommentCount != 4 {
+			t.Errorf("Expected comment_count to stay 4, got %d", stored.CommentCount)
+		}
+	})
+
+	t.Run("재계산 성공", func(t *testing.T) {
+		// When: 재계산 호출
+		rec := httptest.NewRecorder()
+		handler.ReconcileSiteCommentCounts(rec, newRequest("/admin/sites/1/comment-counts/reconcile"))
+
+		// Then: 200 OK, 값 보정
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, rec.Code, rec.Body.String())
+		}
+		stored, _ := database.GetPostByID(ctx, tx, post.ID)
+		if stored.CommentCount != 0 {
+			t.Errorf("Expected comment_count=0, got %d", stored.CommentCount)
+		}
+	})
+}
+
+// TestWipeSiteSandbox는 샌드박스 데이터 삭제 기능을 테스트합니다
+func TestWipeSiteSandbox(t *testing.T) {
+	db := testhelpers.SetupTestDB(t)
+	defer database.Close(db)
+
+	ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+	defer cleanup()
+
+	// Given: 실제 포스트와 같은 slug의 샌드박스 포스트
+	user, site, post := setupSitePostTest(t, ctx, tx, "sandbox@example.com")
+	sandboxPost, err := database.GetOrCreatePost(ctx, tx, site.ID, post.Slug, post.Title, true)
+	if err != nil {
+		t.Fatalf("Failed to create sandbox post: %v", err)
+	}
+	handler := NewAdminHandler(tx)
+
+	req := httptest.NewRequest(http.MethodDelete, "/admin/sites/1/sandbox", nil)
+	req = req.WithContext(context.WithValue(ctx, userContextKey, user))
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", strconv.FormatInt(site.ID, 10))
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+	// When: 샌드박스 삭제
+	rec := httptest.NewRecorder()
+	handler.WipeSiteSandbox(rec, req)
+
+	// Then: 200 OK, 샌드박스 포스트만 삭제, 감사 로그 기록
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+	var response WipeSandboxResponse
+	json.Unmarshal(rec.Body.Bytes(), &response)
+	if response.DeletedPosts != 1 {
+		t.Errorf("Expected 1 deleted post, got %+v", response)
+	}
+	if found, _ := database.GetPostByID(ctx, tx, sandboxPost.ID); found != nil {
+		t.Error("Expected sandbox post to be deleted")
+	}
+	if found, _ := database.GetPostByID(ctx, tx, post.ID); found == nil {
+		t.Error("Expected live post to remain")
+	}
+	entries, _, _ := database.ListAuditLog(ctx, tx, site.ID, 10, 0)
+	if len(entries) == 0 || entries[0].Action != models.AuditActionSandboxWipe {
+		t.Errorf("Expected sandbox.wipe audit entry, got %+v", entries)
+	}
+}
@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/june20516/orbithall/internal/database"
+	"github.com/june20516/orbithall/internal/models"
+)
+
+// ListSessionsResponse는 로그인 세션 목록 응답입니다
+type ListSessionsResponse struct {
+	Sessions []models.Session `json:"sessions"`
+}
+
+// ListSessions는 로그인한 사용자의 로그인 세션 목록을 반환합니다
+// @Summary      로그인 세션 목록 조회
+// @Description  로그아웃되지 않고 만료되지 않은 내 로그인 세션을 최근 사용순으로 반환합니다. 각 세션의 기기(User-Agent), IP 주소(마스킹), 마지막 사용 시각을 포함하며, 요청에 사용한 세션은 current가 true입니다.
+// @Tags         admin
+// @Produce      json
+// @Success      200 {object} ListSessionsResponse
+// @Failure      401 {string} string "Unauthorized"
+// @Failure      500 {string} string "Failed to get sessions"
+// @Security     BearerAuth
+// @Router       /admin/sessions [get]
+func (h *AdminHandler) ListSessions(w http.ResponseWriter, r *http.Request) {
+	// Context에서 사용자 추출
+	user, ok := r.Context().Value(userContextKey).(*models.User)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	sessions, err := database.ListActiveSessions(r.Context(), h.db, user.ID)
+	if err != nil {
+		http.Error(w, "Failed to get sessions", http.StatusInternalServerError)
+		return
+	}
+
+	currentSessionID := GetSessionIDFromContext(r.Context())
+	for i := range sessions {
+		sessions[i].Current = sessions[i].ID == currentSessionID
+	}
+
+	// 200 OK 응답
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(ListSessionsResponse{Sessions: sessions})
+}
+
+// RevokeSession은 로그인한 사용자의 로그인 세션 하나를 로그아웃합니다
+// @Summary      로그인 세션 로그아웃
+// @Description  내 로그인 세션 하나를 로그아웃합니다. 로그아웃한 세션의 access token과 refresh token은 즉시 사용할 수 없습니다.
+// @Tags         admin
+// @Param        sessionId path int true "Session ID"
+// @Success      204 "No Content"
+// @Failure      400 {string} string "Invalid session ID"
+// @Failure      401 {string} string "Unauthorized"
+// @Failure      404 {string} string "Session not found"
+// @Failure      500 {string} string "Failed to revoke session"
+// @Security     BearerAuth
+// @Router       /admin/sessions/{sessionId} [delete]
+func (h *AdminHandler) RevokeSession(w http.ResponseWriter, r *http.Request) {
+	// Context에서 사용자 추출
+	user, ok := r.Context().Value(userContextKey).(*models.User)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	sessionID, err := strconv.ParseInt(chi.URLParam(r, "sessionId"), 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid session ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := database.RevokeSession(r.Context(), h.db, user.ID, sessionID); err != nil {
+		if err == sql.ErrNoRows {
+			http.Error(w, "Session not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, "Failed to revoke session", http.StatusInternalServerError)
+		return
+	}
+
+	// 204 No Content 응답
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RevokeAllSessions는 로그인한 사용자의 모든 로그인 세션을 로그아웃합니다
+// @Summary      모든 로그인 세션 로그아웃
+// @Description  요청에 사용한 세션을 포함해 내 모든 로그인 세션을 로그아웃합니다. 토큰이 유출되었을 때 모든 기기에서 로그아웃하는 데 사용합니다.
+// @Tags         admin
+// @Success      204 "No Content"
+// @Failure      401 {string} string "Unauthorized"
+// @Failure      500 {string} string "Failed to revoke sessions"
+// @Security     BearerAuth
+// @Router       /admin/sessions [delete]
+func (h *AdminHandler) RevokeAllSessions(w http.ResponseWriter, r *http.Request) {
+	// Context에서 사용자 추출
+	user, ok := r.Context().Value(userContextKey).(*models.User)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	if _, err := database.RevokeAllSessions(r.Context(), h.db, user.ID); err != nil {
+		http.Error(w, "Failed to revoke sessions", http.StatusInternalServerError)
+		return
+	}
+
+	// 204 No Content 응답
+	w.WriteHeader(http.StatusNoContent)
+}
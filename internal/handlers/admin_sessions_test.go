@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/june20516/orbithall/internal/database"
+	"github.com/june20516/orbithall/internal/models"
+	"github.com/june20516/orbithall/internal/testhelpers"
+)
+
+// TestSessionHandlers는 로그인 세션 목록 조회와 로그아웃 API를 테스트합니다
+func TestSessionHandlers(t *testing.T) {
+	db := testhelpers.SetupTestDB(t)
+	defer database.Close(db)
+
+	ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+	defer cleanup()
+
+	// Given: 세션 두 개인 사용자와 세션 하나인 다른 사용자
+	user := &models.User{Email: "sessions@example.com", Name: "Sessions"}
+	other := &models.User{Email: "other-sessions@example.com", Name: "Other"}
+	sessions := make([]*models.Session, 3)
+	for i, owner := range []*models.User{user, user, other} {
+		if owner.ID == 0 {
+			if err := database.CreateUser(ctx, tx, owner); err != nil {
+				t.Fatalf("Failed to create user: %v", err)
+			}
+		}
+		sessions[i] = &models.Session{UserID: owner.ID, UserAgent: "test-agent", IPAddress: "203.0.113.7", ExpiresAt: time.Now().Add(time.Hour)}
+		if err := database.CreateSession(ctx, tx, sessions[i]); err != nil {
+			t.Fatalf("Failed to create session: %v", err)
+		}
+	}
+	handler := NewAdminHandler(tx)
+
+	// 첫 번째 세션으로 요청
+	requestCtx := context.WithValue(ctx, sessionContextKey, sessions[0].ID)
+
+	t.Run("목록에 현재 세션 표시", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		handler.ListSessions(rec, newAdminRequest(requestCtx, user, http.MethodGet, "/admin/sessions", ""))
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+		var response ListSessionsResponse
+		if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		if len(response.Sessions) != 2 {
+			t.Fatalf("Expected 2 sessions, got %+v", response.Sessions)
+		}
+		for _, session := range response.Sessions {
+			if session.Current != (session.ID == sessions[0].ID) {
+				t.Errorf("Unexpected current flag: %+v", session)
+			}
+			if session.IPAddress == "203.0.113.7" {
+				t.Errorf("Expected masked ip address, got %q", session.IPAddress)
+			}
+		}
+	})
+
+	t.Run("다른 사용자의 세션은 404", func(t *testing.T) {
+		sessionID := strconv.FormatInt(sessions[2].ID, 10)
+		rec := httptest.NewRecorder()
+		handler.RevokeSession(rec, newAdminRequest(requestCtx, user, http.MethodDelete, "/admin/sessions/"+sessionID, "", "sessionId", sessionID))
+
+		if rec.Code != http.StatusNotFound {
+			t.Errorf("Expected 404, got %d", rec.Code)
+		}
+	})
+
+	t.Run("세션 하나 로그아웃 후 모든 세션 로그아웃", func(t *testing.T) {
+		sessionID := strconv.FormatInt(sessions[1].ID, 10)
+		rec := httptest.NewRecorder()
+		handler.RevokeSession(rec, newAdminRequest(requestCtx, user, http.MethodDelete, "/admin/sessions/"+sessionID, "", "sessionId", sessionID))
+		if rec.Code != http.StatusNoContent {
+			t.Fatalf("Expected 204, got %d: %s", rec.Code, rec.Body.String())
+		}
+		if active, _ := database.ListActiveSessions(ctx, tx, user.ID); len(active) != 1 || active[0].ID != sessions[0].ID {
+			t.Errorf("Expected only current session to remain, got %+v", active)
+		}
+
+		rec = httptest.NewRecorder()
+		handler.RevokeAllSessions(rec, newAdminRequest(requestCtx, user, http.MethodDelete, "/admin/sessions", ""))
+		if rec.Code != http.StatusNoContent {
+			t.Fatalf("Expected 204, got %d: %s", rec.Code, rec.Body.String())
+		}
+		if active, _ := database.ListActiveSessions(ctx, tx, user.ID); len(active) != 0 {
+			t.Errorf("Expected all sessions to be revoked, got %+v", active)
+		}
+
+		// 다른 사용자의 세션은 유지
+		if active, _ := database.ListActiveSessions(ctx, tx, other.ID); len(active) != 1 {
+			t.Errorf("Expected other user's session to remain, got %+v", active)
+		}
+	})
+}
@@ -26,9 +26,8 @@ func TestListSites(t *testing.T) {
 
 		// Given: 사용자 생성
 		user := &models.User{
-			Email:    "admin@example.com",
-			Name:     "Admin User",
-			GoogleID: "google-admin",
+			Email: "admin@example.com",
+			Name:  "Admin User",
 		}
 		if err := database.CreateUser(ctx, tx, user); err != nil {
 			t.Fatalf("Failed to create user: %v", err)
@@ -41,6 +40,7 @@ func TestListSites(t *testing.T) {
 				Domain:      "site" + strconv.Itoa(i) + ".com",
 				CORSOrigins: []string{"https://site" + strconv.Itoa(i) + ".com"},
 				IsActive:    true,
+				IsVerified:  true,
 			}
 			if err := database.CreateSiteForUser(ctx, tx, site, user.ID); err != nil {
 				t.Fatalf("Failed to create site %d: %v", i, err)
@@ -81,9 +81,8 @@ func TestListSites(t *testing.T) {
 
 		// Given: 사이트가 없는 사용자
 		user := &models.User{
-			Email:    "nosite@example.com",
-			Name:     "No Site User",
-			GoogleID: "google-nosite",
+			Email: "nosite@example.com",
+			Name:  "No Site User",
 		}
 		if err := database.CreateUser(ctx, tx, user); err != nil {
 			t.Fatalf("Failed to create user: %v", err)
@@ -123,9 +122,8 @@ func TestGetSite(t *testing.T) {
 
 		// Given: 사용자와 사이트 생성
 		user := &models.User{
-			Email:    "owner@example.com",
-			Name:     "Owner",
-			GoogleID: "google-owner",
+			Email: "owner@example.com",
+			Name:  "Owner",
 		}
 		if err := database.CreateUser(ctx, tx, user); err != nil {
 			t.Fatalf("Failed to create user: %v", err)
@@ -136,6 +134,7 @@ func TestGetSite(t *testing.T) {
 			Domain:      "mysite.com",
 			CORSOrigins: []string{"https://mysite.com"},
 			IsActive:    true,
+			IsVerified:  true,
 		}
 		if err := database.CreateSiteForUser(ctx, tx, site, user.ID); err != nil {
 			t.Fatalf("Failed to create site: %v", err)
@@ -168,22 +167,61 @@ func TestGetSite(t *testing.T) {
 		}
 	})
 
+	t.Run("사이트 조회 - 관리 권한이 없으면 검증 토큰 숨김", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		// Given: 소유자의 사이트와 조회자 멤버
+		owner := &models.User{Email: "token-owner@example.com", Name: "Owner"}
+		viewer := &models.User{Email: "token-viewer@example.com", Name: "Viewer"}
+		for _, u := range []*models.User{owner, viewer} {
+			if err := database.CreateUser(ctx, tx, u); err != nil {
+				t.Fatalf("Failed to create user: %v", err)
+			}
+		}
+		site := &models.Site{Name: "Token Site", Domain: "token-site.com", CORSOrigins: []string{"https://token-site.com"}}
+		if err := database.CreateSiteForUser(ctx, tx, site, owner.ID); err != nil {
+			t.Fatalf("Failed to create site: %v", err)
+		}
+		if err := database.AddUserToSite(ctx, tx, viewer.ID, site.ID, models.SiteRoleViewer); err != nil {
+			t.Fatalf("Failed to add viewer: %v", err)
+		}
+
+		getSite := func(user *models.User) map[string]interface{} {
+			req := newAdminRequest(ctx, user, http.MethodGet, "/admin/sites/"+strconv.FormatInt(site.ID, 10), "", "id", strconv.FormatInt(site.ID, 10))
+			rec := httptest.NewRecorder()
+			NewAdminHandler(tx).GetSite(rec, req)
+			if rec.Code != http.StatusOK {
+				t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, rec.Code, rec.Body.String())
+			}
+			var response map[string]interface{}
+			json.Unmarshal(rec.Body.Bytes(), &response)
+			return response
+		}
+
+		// When & Then: 소유자에게만 토큰 반환
+		if token, _ := getSite(owner)["verification_token"].(string); token == "" {
+			t.Error("expected verification_token for owner")
+		}
+		if token, ok := getSite(viewer)["verification_token"]; ok {
+			t.Errorf("expected no verification_token for viewer, got %v", token)
+		}
+	})
+
 	t.Run("사이트 조회 실패 - 소유하지 않음", func(t *testing.T) {
 		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
 		defer cleanup()
 
 		// Given: 두 사용자와 사이트 (user1이 소유)
 		user1 := &models.User{
-			Email:    "user1@example.com",
-			Name:     "User 1",
-			GoogleID: "google-user1",
+			Email: "user1@example.com",
+			Name:  "User 1",
 		}
 		database.CreateUser(ctx, tx, user1)
 
 		user2 := &models.User{
-			Email:    "user2@example.com",
-			Name:     "User 2",
-			GoogleID: "google-user2",
+			Email: "user2@example.com",
+			Name:  "User 2",
 		}
 		database.CreateUser(ctx, tx, user2)
 
@@ -192,6 +230,7 @@ func TestGetSite(t *testing.T) {
 			Domain:      "user1site.com",
 			CORSOrigins: []string{"https://user1site.com"},
 			IsActive:    true,
+			IsVerified:  true,
 		}
 		database.CreateSiteForUser(ctx, tx, site, user1.ID)
 
@@ -219,9 +258,8 @@ func TestGetSite(t *testing.T) {
 		defer cleanup()
 
 		user := &models.User{
-			Email:    "user@example.com",
-			Name:     "User",
-			GoogleID: "google-user",
+			Email: "user@example.com",
+			Name:  "User",
 		}
 		database.CreateUser(ctx, tx, user)
 
@@ -256,9 +294,8 @@ func TestCreateSite(t *testing.T) {
 
 		// Given: 사용자 생성
 		user := &models.User{
-			Email:    "creator@example.com",
-			Name:     "Creator",
-			GoogleID: "google-creator",
+			Email: "creator@example.com",
+			Name:  "Creator",
 		}
 		database.CreateUser(ctx, tx, user)
 
@@ -292,6 +329,14 @@ func TestCreateSite(t *testing.T) {
 		if response["api_key"] == nil || response["api_key"] == "" {
 			t.Error("Expected API key to be generated")
 		}
+
+		// 도메인 소유권 검증 전까지 비활성, 검증 토큰 발급
+		if response["is_active"] != false || response["is_verified"] != false {
+			t.Errorf("Expected unverified inactive site, got is_active=%v is_verified=%v", response["is_active"], response["is_verified"])
+		}
+		if response["verification_token"] == nil || response["verification_token"] == "" {
+			t.Error("Expected verification token to be generated")
+		}
 	})
 
 	t.Run("사이트 생성 실패 - 입력 검증 오류", func(t *testing.T) {
@@ -299,9 +344,8 @@ func TestCreateSite(t *testing.T) {
 		defer cleanup()
 
 		user := &models.User{
-			Email:    "creator2@example.com",
-			Name:     "Creator 2",
-			GoogleID: "google-creator2",
+			Email: "creator2@example.com",
+			Name:  "Creator 2",
 		}
 		database.CreateUser(ctx, tx, user)
 
@@ -338,9 +382,8 @@ func TestUpdateSite(t *testing.T) {
 
 		// Given: 사용자와 사이트 생성
 		user := &models.User{
-			Email:    "updater@example.com",
-			Name:     "Updater",
-			GoogleID: "google-updater",
+			Email: "updater@example.com",
+			Name:  "Updater",
 		}
 		database.CreateUser(ctx, tx, user)
 
@@ -349,6 +392,7 @@ func TestUpdateSite(t *testing.T) {
 			Domain:      "original.com",
 			CORSOrigins: []string{"https://original.com"},
 			IsActive:    true,
+			IsVerified:  true,
 		}
 		database.CreateSiteForUser(ctx, tx, site, user.ID)
 
@@ -392,16 +436,14 @@ func TestUpdateSite(t *testing.T) {
 
 		// Given: 두 사용자와 사이트
 		user1 := &models.User{
-			Email:    "owner@example.com",
-			Name:     "Owner",
-			GoogleID: "google-owner-update",
+			Email: "owner@example.com",
+			Name:  "Owner",
 		}
 		database.CreateUser(ctx, tx, user1)
 
 		user2 := &models.User{
-			Email:    "notowner@example.com",
-			Name:     "Not Owner",
-			GoogleID: "google-notowner",
+			Email: "notowner@example.com",
+			Name:  "Not Owner",
 		}
 		database.CreateUser(ctx, tx, user2)
 
@@ -410,6 +452,7 @@ func TestUpdateSite(t *testing.T) {
 			Domain:      "ownersite.com",
 			CORSOrigins: []string{"https://ownersite.com"},
 			IsActive:    true,
+			IsVerified:  true,
 		}
 		database.CreateSiteForUser(ctx, tx, site, user1.ID)
 
@@ -450,9 +493,8 @@ func TestDeleteSite(t *testing.T) {
 
 		// Given: 사용자와 사이트 생성
 		user := &models.User{
-			Email:    "deleter@example.com",
-			Name:     "Deleter",
-			GoogleID: "google-deleter",
+			Email: "deleter@example.com",
+			Name:  "Deleter",
 		}
 		database.CreateUser(ctx, tx, user)
 
@@ -461,6 +503,7 @@ func TestDeleteSite(t *testing.T) {
 			Domain:      "todelete.com",
 			CORSOrigins: []string{"https://todelete.com"},
 			IsActive:    true,
+			IsVerified:  true,
 		}
 		database.CreateSiteForUser(ctx, tx, site, user.ID)
 
@@ -489,16 +532,14 @@ func TestDeleteSite(t *testing.T) {
 
 		// Given: 두 사용자와 사이트
 		user1 := &models.User{
-			Email:    "owner-del@example.com",
-			Name:     "Owner Del",
-			GoogleID: "google-owner-del",
+			Email: "owner-del@example.com",
+			Name:  "Owner Del",
 		}
 		database.CreateUser(ctx, tx, user1)
 
 		user2 := &models.User{
-			Email:    "notowner-del@example.com",
-			Name:     "Not Owner Del",
-			GoogleID: "google-notowner-del",
+			Email: "notowner-del@example.com",
+			Name:  "Not Owner Del",
 		}
 		database.CreateUser(ctx, tx, user2)
 
@@ -507,6 +548,7 @@ func TestDeleteSite(t *testing.T) {
 			Domain:      "ownersitedel.com",
 			CORSOrigins: []string{"https://ownersitedel.com"},
 			IsActive:    true,
+			IsVerified:  true,
 		}
 		database.CreateSiteForUser(ctx, tx, site, user1.ID)
 
@@ -543,7 +585,6 @@ func TestGetProfile(t *testing.T) {
 		user := &models.User{
 			Email:      "profile@example.com",
 			Name:       "Profile User",
-			GoogleID:   "google-profile",
 			PictureURL: "https://example.com/picture.jpg",
 		}
 		database.CreateUser(ctx, tx, user)
@@ -584,9 +625,8 @@ func TestGetSiteStats(t *testing.T) {
 
 		// Given: 사용자 및 사이트 생성
 		user := &models.User{
-			Email:    "admin@example.com",
-			Name:     "Admin",
-			GoogleID: "google-admin",
+			Email: "admin@example.com",
+			Name:  "Admin",
 		}
 		if err := database.CreateUser(ctx, tx, user); err != nil {
 			t.Fatalf("Failed to create user: %v", err)
@@ -597,6 +637,7 @@ func TestGetSiteStats(t *testing.T) {
 			Domain:      "test.com",
 			CORSOrigins: []string{"https://test.com"},
 			IsActive:    true,
+			IsVerified:  true,
 		}
 		if err := database.CreateSiteForUser(ctx, tx, site, user.ID); err != nil {
 			t.Fatalf("Failed to create site: %v", err)
@@ -653,14 +694,12 @@ func TestGetSiteStats(t *testing.T) {
 
 		// Given: 두 명의 사용자
 		user1 := &models.User{
-			Email:    "user1@example.com",
-			Name:     "User 1",
-			GoogleID: "google-user1",
+			Email: "user1@example.com",
+			Name:  "User 1",
 		}
 		user2 := &models.User{
-			Email:    "user2@example.com",
-			Name:     "User 2",
-			GoogleID: "google-user2",
+			Email: "user2@example.com",
+			Name:  "User 2",
 		}
 		database.CreateUser(ctx, tx, user1)
 		database.CreateUser(ctx, tx, user2)
@@ -671,6 +710,7 @@ func TestGetSiteStats(t *testing.T) {
 			Domain:      "user2.com",
 			CORSOrigins: []string{"https://user2.com"},
 			IsActive:    true,
+			IsVerified:  true,
 		}
 		database.CreateSiteForUser(ctx, tx, site, user2.ID)
 
@@ -706,9 +746,8 @@ func TestListSitePosts(t *testing.T) {
 
 		// Given: 사용자와 사이트, 여러 개의 포스트(댓글 포함)
 		user := &models.User{
-			Email:    "test@example.com",
-			Name:     "Test User",
-			GoogleID: "google-test",
+			Email: "test@example.com",
+			Name:  "Test User",
 		}
 		database.CreateUser(ctx, tx, user)
 
@@ -717,6 +756,7 @@ func TestListSitePosts(t *testing.T) {
 			Domain:      "test.com",
 			CORSOrigins: []string{"https://test.com"},
 			IsActive:    true,
+			IsVerified:  true,
 		}
 		database.CreateSiteForUser(ctx, tx, site, user.ID)
 
@@ -802,14 +842,12 @@ func TestListSitePosts(t *testing.T) {
 
 		// Given: 두 명의 사용자
 		user1 := &models.User{
-			Email:    "user1@example.com",
-			Name:     "User 1",
-			GoogleID: "google-user1",
+			Email: "user1@example.com",
+			Name:  "User 1",
 		}
 		user2 := &models.User{
-			Email:    "user2@example.com",
-			Name:     "User 2",
-			GoogleID: "google-user2",
+			Email: "user2@example.com",
+			Name:  "User 2",
 		}
 		database.CreateUser(ctx, tx, user1)
 		database.CreateUser(ctx, tx, user2)
@@ -820,6 +858,7 @@ func TestListSitePosts(t *testing.T) {
 			Domain:      "user2.com",
 			CORSOrigins: []string{"https://user2.com"},
 			IsActive:    true,
+			IsVerified:  true,
 		}
 		database.CreateSiteForUser(ctx, tx, site, user2.ID)
 
@@ -854,9 +893,8 @@ func TestGetPostComments(t *testing.T) {
 
 		// Given: 사용자와 사이트, 포스트, 댓글(삭제된 것 포함)
 		user := &models.User{
-			Email:    "test@example.com",
-			Name:     "Test User",
-			GoogleID: "google-test",
+			Email: "test@example.com",
+			Name:  "Test User",
 		}
 		database.CreateUser(ctx, tx, user)
 
@@ -865,6 +903,7 @@ func TestGetPostComments(t *testing.T) {
 			Domain:      "test.com",
 			CORSOrigins: []string{"https://test.com"},
 			IsActive:    true,
+			IsVerified:  true,
 		}
 		database.CreateSiteForUser(ctx, tx, site, user.ID)
 
@@ -958,14 +997,12 @@ func TestGetPostComments(t *testing.T) {
 
 		// Given: 두 명의 사용자
 		user1 := &models.User{
-			Email:    "user1@example.com",
-			Name:     "User 1",
-			GoogleID: "google-user1",
+			Email: "user1@example.com",
+			Name:  "User 1",
 		}
 		user2 := &models.User{
-			Email:    "user2@example.com",
-			Name:     "User 2",
-			GoogleID: "google-user2",
+			Email: "user2@example.com",
+			Name:  "User 2",
 		}
 		database.CreateUser(ctx, tx, user1)
 		database.CreateUser(ctx, tx, user2)
@@ -976,6 +1013,7 @@ func TestGetPostComments(t *testing.T) {
 			Domain:      "user2.com",
 			CORSOrigins: []string{"https://user2.com"},
 			IsActive:    true,
+			IsVerified:  true,
 		}
 		database.CreateSiteForUser(ctx, tx, site, user2.ID)
 
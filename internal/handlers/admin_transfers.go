@@ -0,0 +1,432 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/june20516/orbithall/internal/database"
+	"github.com/june20516/orbithall/internal/models"
+	"github.com/june20516/orbithall/internal/notify"
+	"github.com/june20516/orbithall/internal/validators"
+)
+
+// ListOwnershipTransfersResponse는 받은 소유권 이전 요청 목록 응답입니다
+type ListOwnershipTransfersResponse struct {
+	Transfers []models.SiteOwnershipTransfer `json:"transfers"`
+}
+
+// OwnershipTransferTokenRequest는 소유권 이전 수락/거절 요청 본문입니다
+type OwnershipTransferTokenRequest struct {
+	// Token은 받는 사람 이메일로 전송된 이전 토큰입니다
+	Token string `json:"token"`
+}
+
+// AcceptOwnershipTransferResponse는 소유권 이전 수락 응답입니다
+type AcceptOwnershipTransferResponse struct {
+	// Site는 이전받은 사이트입니다 (role은 owner)
+	Site *models.Site `json:"site"`
+
+	// PreviousOwnerRole은 이전 owner에게 부여된 역할입니다
+	PreviousOwnerRole string `json:"previous_owner_role"`
+}
+
+// transferAuditState는 감사 로그에 기록하는 소유권 이전 요청 상태입니다
+type transferAuditState struct {
+	FromEmail string    `json:"from_email"`
+	ToEmail   string    `json:"to_email"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// newTransferAuditState는 소유권 이전 요청의 감사 로그 상태를 만듭니다
+func newTransferAuditState(transfer *models.SiteOwnershipTransfer) *transferAuditState {
+	return &transferAuditState{
+		FromEmail: transfer.FromEmail,
+		ToEmail:   transfer.ToEmail,
+		ExpiresAt: transfer.ExpiresAt,
+	}
+}
+
+// transferOwnersAuditState는 소유권 이전 수락 전/후의 owner와 역할입니다
+type transferOwnersAuditState struct {
+	Owner             string `json:"owner"`
+	PreviousOwner     string `json:"previous_owner,omitempty"`
+	PreviousOwnerRole string `json:"previous_owner_role,omitempty"`
+	RecipientRole     string `json:"recipient_role,omitempty"`
+}
+
+// RequestSiteTransfer는 사이트 소유권 이전을 요청합니다
+// @Summary      사이트 소유권 이전 요청
+// @Description  받는 사람의 이메일로 사이트 소유권 이전을 요청합니다 (owner만 가능). 한 번만 사용할 수 있는 이전 토큰은 받는 사람 이메일로만 전송되며 응답에는 포함되지 않습니다. 받는 사람이 같은 이메일로 로그인해 토큰으로 수락하면 받는 사람은 owner가 되고, 요청한 owner는 받는 사람의 이전 역할(멤버가 아니었으면 manager)이 됩니다. 사이트에 대기 중인 요청이 있으면 취소하고 새로 요청하며, 받는 사람과 요청한 owner에게 알림을 보냅니다.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        id       path int                                true "Site ID"
+// @Param        transfer body validators.SiteTransferCreateInput true "받는 사람"
+// @Success      201 {object} models.SiteOwnershipTransfer
+// @Failure      400 {object} map[string]interface{} "Invalid input"
+// @Failure      401 {string} string "Unauthorized"
+// @Failure      403 {string} string "Forbidden"
+// @Failure      409 {string} string "User is already a site owner"
+// @Failure      500 {string} string "Failed to request transfer"
+// @Security     BearerAuth
+// @Router       /admin/sites/{id}/transfer [post]
+func (h *AdminHandler) RequestSiteTransfer(w http.ResponseWriter, r *http.Request) {
+	// JSON 요청 파싱
+	var input validators.SiteTransferCreateInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	// 입력 검증
+	if err := input.Validate(); err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	siteID, ok := h.authorizeSite(w, r, models.PermissionSiteTransfer)
+	if !ok {
+		return
+	}
+	user := r.Context().Value(userContextKey).(*models.User)
+
+	// 이전 요청 생성
+	transfer := &models.SiteOwnershipTransfer{
+		SiteID:     siteID,
+		FromUserID: &user.ID,
+		ToEmail:    input.Email,
+		ExpiresAt:  time.Now().Add(input.ExpiresIn()),
+	}
+	err := database.RunInTx(r.Context(), h.db, func(tx database.DBTX) error {
+		if err := database.CreateSiteOwnershipTransfer(r.Context(), tx, transfer); err != nil {
+			return err
+		}
+		return recordAudit(r, tx, siteID, models.AuditActionTransferRequest, models.AuditTargetTransfer, transfer.ID, nil, newTransferAuditState(transfer))
+	})
+	if err != nil {
+		if errors.Is(err, database.ErrAlreadySiteOwner) {
+			http.Error(w, "User is already a site owner", http.StatusConflict)
+			return
+		}
+		http.Error(w, "Failed to request transfer", http.StatusInternalServerError)
+		return
+	}
+
+	site := transferSiteLabel(transfer)
+	h.notify(r.Context(),
+		notify.Message{
+			To:      transfer.ToEmail,
+			Subject: fmt.Sprintf("[Orbithall] %s 사이트 소유권 이전 요청", transfer.SiteName),
+			Body: fmt.Sprintf("%s님이 %s 사이트의 소유권을 이전하려고 합니다.\n%s까지 이 이메일 계정으로 Orbithall 관리 화면에 로그인한 뒤 아래 이전 토큰으로 요청을 수락하거나 거절할 수 있습니다.\n\n이전 토큰: %s\n",
+				transfer.FromEmail, site, formatNotifyTime(transfer.ExpiresAt), transfer.Token),
+		},
+		notify.Message{
+			To:      transfer.FromEmail,
+			Subject: fmt.Sprintf("[Orbithall] %s 사이트 소유권 이전을 요청했습니다", transfer.SiteName),
+			Body: fmt.Sprintf("%s 사이트의 소유권 이전을 %s님에게 요청했습니다.\n받는 사람이 수락하면 소유권이 이전되며, 그 전까지 요청을 취소할 수 있습니다.\n",
+				site, transfer.ToEmail),
+		},
+	)
+
+	// 201 Created 응답
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(transfer)
+}
+
+// CancelSiteTransfer는 사이트의 대기 중인 소유권 이전 요청을 취소합니다
+// @Summary      사이트 소유권 이전 요청 취소
+// @Description  대기 중인 소유권 이전 요청을 취소하고 받는 사람에게 알립니다 (owner만 가능).
+// @Tags         admin
+// @Param        id path int true "Site ID"
+// @Success      204 "No Content"
+// @Failure      400 {string} string "Invalid site ID"
+// @Failure      401 {string} string "Unauthorized"
+// @Failure      403 {string} string "Forbidden"
+// @Failure      404 {string} string "No pending transfer"
+// @Failure      500 {string} string "Failed to cancel transfer"
+// @Security     BearerAuth
+// @Router       /admin/sites/{id}/transfer [delete]
+func (h *AdminHandler) CancelSiteTransfer(w http.ResponseWriter, r *http.Request) {
+	siteID, ok := h.authorizeSite(w, r, models.PermissionSiteTransfer)
+	if !ok {
+		return
+	}
+
+	// 이전 요청 취소
+	var transfer *models.SiteOwnershipTransfer
+	err := database.RunInTx(r.Context(), h.db, func(tx database.DBTX) error {
+		var err error
+		transfer, err = database.CancelSiteOwnershipTransfer(r.Context(), tx, siteID)
+		if err != nil {
+			return err
+		}
+		return recordAudit(r, tx, siteID, models.AuditActionTransferCancel, models.AuditTargetTransfer, transfer.ID, newTransferAuditState(transfer), nil)
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			http.Error(w, "No pending transfer", http.StatusNotFound)
+			return
+		}
+		http.Error(w, "Failed to cancel transfer", http.StatusInternalServerError)
+		return
+	}
+
+	h.notify(r.Context(), notify.Message{
+		To:      transfer.ToEmail,
+		Subject: fmt.Sprintf("[Orbithall] %s 사이트 소유권 이전 요청이 취소되었습니다", transfer.SiteName),
+		Body:    fmt.Sprintf("%s님이 %s 사이트의 소유권 이전 요청을 취소했습니다.\n", transfer.FromEmail, transferSiteLabel(transfer)),
+	})
+
+	// 204 No Content 응답
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListOwnershipTransfers는 로그인한 사용자가 받은 대기 중인 소유권 이전 요청을 반환합니다
+// @Summary      받은 소유권 이전 요청 목록
+// @Description  로그인한 계정의 이메일로 받은 대기 중인(만료되지 않은) 소유권 이전 요청을 최신순으로 반환합니다.
+// @Tags         admin
+// @Produce      json
+// @Success      200 {object} ListOwnershipTransfersResponse
+// @Failure      401 {string} string "Unauthorized"
+// @Failure      500 {string} string "Failed to get transfers"
+// @Security     BearerAuth
+// @Router       /admin/transfers [get]
+func (h *AdminHandler) ListOwnershipTransfers(w http.ResponseWriter, r *http.Request) {
+	// Context에서 사용자 추출
+	user, ok := r.Context().Value(userContextKey).(*models.User)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	transfers, err := database.ListIncomingOwnershipTransfers(r.Context(), h.db, user.Email)
+	if err != nil {
+		http.Error(w, "Failed to get transfers", http.StatusInternalServerError)
+		return
+	}
+
+	// 200 OK 응답
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(ListOwnershipTransfersResponse{Transfers: transfers})
+}
+
+// AcceptOwnershipTransfer는 로그인한 사용자로 소유권 이전 요청을 수락합니다
+// @Summary      소유권 이전 수락
+// @Description  이메일로 받은 이전 토큰으로 소유권 이전 요청을 수락합니다. 로그인한 계정의 이메일은 받는 사람 이메일과 같고 ID 공급자가 검증한 이메일이어야 합니다. 로그인한 사용자가 owner가 되고 이전 owner의 역할은 한 트랜잭션에서 함께 변경됩니다. 수락 결과를 양쪽에 알립니다.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        transferId path int                           true "Transfer ID"
+// @Param        request    body OwnershipTransferTokenRequest true "이전 토큰"
+// @Success      200 {object} AcceptOwnershipTransferResponse
+// @Failure      400 {string} string "Invalid transfer ID or token is required"
+// @Failure      401 {string} string "Unauthorized"
+// @Failure      403 {string} string "Email is not verified"
+// @Failure      404 {string} string "Transfer not found"
+// @Failure      409 {string} string "User is already a site owner"
+// @Failure      410 {string} string "Transfer is no longer pending"
+// @Failure      500 {string} string "Failed to accept transfer"
+// @Security     BearerAuth
+// @Router       /admin/transfers/{transferId}/accept [post]
+func (h *AdminHandler) AcceptOwnershipTransfer(w http.ResponseWriter, r *http.Request) {
+	user, transferID, token, ok := parseTransferRequest(w, r)
+	if !ok {
+		return
+	}
+
+	// 이전 수락 후 변경 전/후 owner를 감사 로그에 기록
+	var transfer *models.SiteOwnershipTransfer
+	var site *models.Site
+	var previousOwnerRole string
+	err := database.RunInTx(r.Context(), h.db, func(tx database.DBTX) error {
+		pending, err := database.GetSiteOwnershipTransfer(r.Context(), tx, transferID)
+		if err != nil {
+			return err
+		}
+		recipientRole, err := database.GetUserSiteRole(r.Context(), tx, user.ID, pending.SiteID)
+		if err != nil {
+			return err
+		}
+
+		transfer, err = database.AcceptSiteOwnershipTransfer(r.Context(), tx, transferID, token, user)
+		if err != nil {
+			return err
+		}
+		previousOwnerRole, err = database.GetUserSiteRole(r.Context(), tx, *transfer.FromUserID, transfer.SiteID)
+		if err != nil {
+			return err
+		}
+
+		site, err = database.GetSiteByID(r.Context(), tx, transfer.SiteID)
+		if err != nil {
+			return err
+		}
+		site.Role = models.SiteRoleOwner
+
+		before := &transferOwnersAuditState{Owner: transfer.FromEmail, RecipientRole: recipientRole}
+		after := &transferOwnersAuditState{Owner: user.Email, PreviousOwner: transfer.FromEmail, PreviousOwnerRole: previousOwnerRole}
+		return recordAudit(r, tx, transfer.SiteID, models.AuditActionTransferAccept, models.AuditTargetTransfer, transfer.ID, before, after)
+	})
+	if err != nil {
+		switch {
+		case err == sql.ErrNoRows:
+			http.Error(w, "Transfer not found", http.StatusNotFound)
+		case errors.Is(err, database.ErrTransferInactive):
+			http.Error(w, "Transfer is no longer pending", http.StatusGone)
+		case errors.Is(err, database.ErrEmailNotVerified):
+			http.Error(w, "Email is not verified", http.StatusForbidden)
+		case errors.Is(err, database.ErrAlreadySiteOwner):
+			http.Error(w, "User is already a site owner", http.StatusConflict)
+		default:
+			http.Error(w, "Failed to accept transfer", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	label := transferSiteLabel(transfer)
+	h.notify(r.Context(),
+		notify.Message{
+			To:      transfer.FromEmail,
+			Subject: fmt.Sprintf("[Orbithall] %s 사이트 소유권이 이전되었습니다", transfer.SiteName),
+			Body:    fmt.Sprintf("%s님이 소유권 이전을 수락하여 %s 사이트의 owner가 되었습니다.\n회원님의 역할은 %s로 변경되었습니다.\n", user.Email, label, previousOwnerRole),
+		},
+		notify.Message{
+			To:      user.Email,
+			Subject: fmt.Sprintf("[Orbithall] %s 사이트의 owner가 되었습니다", transfer.SiteName),
+			Body:    fmt.Sprintf("%s 사이트의 소유권 이전을 수락했습니다.\n이제 회원님이 사이트의 owner입니다.\n", label),
+		},
+	)
+
+	// 200 OK 응답
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(AcceptOwnershipTransferResponse{
+		Site:              site,
+		PreviousOwnerRole: previousOwnerRole,
+	})
+}
+
+// DeclineOwnershipTransfer는 로그인한 사용자로 소유권 이전 요청을 거절합니다
+// @Summary      소유권 이전 거절
+// @Description  이메일로 받은 이전 토큰으로 소유권 이전 요청을 거절하고 요청한 owner에게 알립니다.
+// @Tags         admin
+// @Accept       json
+// @Param        transferId path int                           true "Transfer ID"
+// @Param        request    body OwnershipTransferTokenRequest true "이전 토큰"
+// @Success      204 "No Content"
+// @Failure      400 {string} string "Invalid transfer ID or token is required"
+// @Failure      401 {string} string "Unauthorized"
+// @Failure      404 {string} string "Transfer not found"
+// @Failure      410 {string} string "Transfer is no longer pending"
+// @Failure      500 {string} string "Failed to decline transfer"
+// @Security     BearerAuth
+// @Router       /admin/transfers/{transferId}/decline [post]
+func (h *AdminHandler) DeclineOwnershipTransfer(w http.ResponseWriter, r *http.Request) {
+	user, transferID, token, ok := parseTransferRequest(w, r)
+	if !ok {
+		return
+	}
+
+	// 이전 요청 거절
+	var transfer *models.SiteOwnershipTransfer
+	err := database.RunInTx(r.Context(), h.db, func(tx database.DBTX) error {
+		var err error
+		transfer, err = database.DeclineSiteOwnershipTransfer(r.Context(), tx, transferID, token, user)
+		if err != nil {
+			return err
+		}
+		return recordAudit(r, tx, transfer.SiteID, models.AuditActionTransferDecline, models.AuditTargetTransfer, transfer.ID, newTransferAuditState(transfer), nil)
+	})
+	if err != nil {
+		switch {
+		case err == sql.ErrNoRows:
+			http.Error(w, "Transfer not found", http.StatusNotFound)
+		case errors.Is(err, database.ErrTransferInactive):
+			http.Error(w, "Transfer is no longer pending", http.StatusGone)
+		default:
+			http.Error(w, "Failed to decline transfer", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	h.notify(r.Context(), notify.Message{
+		To:      transfer.FromEmail,
+		Subject: fmt.Sprintf("[Orbithall] %s 사이트 소유권 이전 요청이 거절되었습니다", transfer.SiteName),
+		Body:    fmt.Sprintf("%s님이 %s 사이트의 소유권 이전 요청을 거절했습니다.\n", user.Email, transferSiteLabel(transfer)),
+	})
+
+	// 204 No Content 응답
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// parseTransferRequest는 로그인한 사용자, URL의 이전 요청 ID, 요청 본문의 이전 토큰을 추출합니다
+// 실패 시 에러 응답을 작성하고 false를 반환합니다
+func parseTransferRequest(w http.ResponseWriter, r *http.Request) (*models.User, int64, string, bool) {
+	// Context에서 사용자 추출
+	user, ok := r.Context().Value(userContextKey).(*models.User)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return nil, 0, "", false
+	}
+
+	// URL 파라미터에서 transfer_id 추출
+	transferID, err := strconv.ParseInt(chi.URLParam(r, "transferId"), 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid transfer ID", http.StatusBadRequest)
+		return nil, 0, "", false
+	}
+
+	// JSON 요청 파싱
+	var req OwnershipTransferTokenRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		return nil, 0, "", false
+	}
+	req.Token = strings.TrimSpace(req.Token)
+	if req.Token == "" {
+		http.Error(w, "token is required", http.StatusBadRequest)
+		return nil, 0, "", false
+	}
+
+	return user, transferID, req.Token, true
+}
+
+// notify는 작업이 커밋된 뒤 사용자 알림을 보냅니다
+// 알림 실패는 이미 완료된 작업을 되돌리지 않으므로 로그만 남깁니다
+// 요청이 끝나도 전송이 중단되지 않도록 요청 Context의 취소는 전파하지 않습니다
+func (h *AdminHandler) notify(ctx context.Context, messages ...notify.Message) {
+	ctx = context.WithoutCancel(ctx)
+	for _, msg := range messages {
+		if msg.To == "" {
+			continue
+		}
+		if err := h.notifier.Notify(ctx, msg); err != nil {
+			log.Printf("[ERROR] Failed to send notification to %s: %v", msg.To, err)
+		}
+	}
+}
+
+// transferSiteLabel은 알림에 표시할 사이트 이름과 도메인을 반환합니다
+func transferSiteLabel(transfer *models.SiteOwnershipTransfer) string {
+	return fmt.Sprintf("%s(%s)", transfer.SiteName, transfer.SiteDomain)
+}
+
+// formatNotifyTime은 알림에 표시할 시각을 UTC로 포맷합니다
+func formatNotifyTime(t time.Time) string {
+	return t.UTC().Format("2006-01-02 15:04 UTC")
+}
@@ -0,0 +1,194 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/june20516/orbithall/internal/database"
+	"github.com/june20516/orbithall/internal/models"
+	"github.com/june20516/orbithall/internal/testhelpers"
+)
+
+// TestOwnershipTransferHandlers는 사이트 소유권 이전 API를 테스트합니다
+func TestOwnershipTransferHandlers(t *testing.T) {
+	db := testhelpers.SetupTestDB(t)
+	defer database.Close(db)
+
+	// createUser는 테스트 사용자를 생성합니다
+	createUser := func(t *testing.T, ctx context.Context, tx database.DBTX, email string) *models.User {
+		user := &models.User{Email: email, EmailVerified: true, Name: email}
+		if err := database.CreateUser(ctx, tx, user); err != nil {
+			t.Fatalf("Failed to create user: %v", err)
+		}
+		return user
+	}
+
+	t.Run("요청 후 받는 사람이 수락", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		// Given: owner와 받는 사람
+		site := testhelpers.CreateTestSite(ctx, t, tx, "Transfer", "transfer-handler.example.com", []string{"https://transfer-handler.example.com"}, true)
+		owner := createUser(t, ctx, tx, "seller@example.com")
+		if err := database.AddUserToSite(ctx, tx, owner.ID, site.ID, models.SiteRoleOwner); err != nil {
+			t.Fatalf("Failed to add user to site: %v", err)
+		}
+		buyer := createUser(t, ctx, tx, "buyer-handler@example.com")
+
+		notifier := &recordingNotifier{}
+		handler := NewAdminHandler(tx)
+		handler.SetNotifier(notifier)
+		siteID := strconv.FormatInt(site.ID, 10)
+
+		// When: owner가 이전 요청
+		rec := httptest.NewRecorder()
+		handler.RequestSiteTransfer(rec, newAdminRequest(ctx, owner, http.MethodPost, "/admin/sites/"+siteID+"/transfer", `{"email":"buyer-handler@example.com"}`, "id", siteID))
+		if rec.Code != http.StatusCreated {
+			t.Fatalf("Expected 201, got %d: %s", rec.Code, rec.Body.String())
+		}
+		var transfer models.SiteOwnershipTransfer
+		if err := json.NewDecoder(rec.Body).Decode(&transfer); err != nil {
+			t.Fatalf("Failed to decode transfer: %v", err)
+		}
+
+		// 이전 토큰은 받는 사람 알림에만 포함
+		if len(notifier.messages) != 2 {
+			t.Fatalf("Expected 2 notifications, got %d", len(notifier.messages))
+		}
+		token := tokenFromNotification(t, notifier.messages[0].Body, models.TransferTokenPrefix)
+		if strings.Contains(notifier.messages[1].Body, token) {
+			t.Errorf("Expected token to be sent only to the recipient, got %q", notifier.messages[1].Body)
+		}
+
+		// 받는 사람 목록에 표시
+		rec = httptest.NewRecorder()
+		handler.ListOwnershipTransfers(rec, newAdminRequest(ctx, buyer, http.MethodGet, "/admin/transfers", ""))
+		var list ListOwnershipTransfersResponse
+		if err := json.NewDecoder(rec.Body).Decode(&list); err != nil {
+			t.Fatalf("Failed to decode list: %v", err)
+		}
+		if len(list.Transfers) != 1 || list.Transfers[0].ID != transfer.ID {
+			t.Fatalf("Unexpected incoming transfers: %+v", list.Transfers)
+		}
+
+		// 토큰 없이 수락하면 400, 다른 토큰이면 404
+		transferID := strconv.FormatInt(transfer.ID, 10)
+		acceptPath := fmt.Sprintf("/admin/transfers/%s/accept", transferID)
+		rec = httptest.NewRecorder()
+		handler.AcceptOwnershipTransfer(rec, newAdminRequest(ctx, buyer, http.MethodPost, acceptPath, `{}`, "transferId", transferID))
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("Expected 400 without token, got %d", rec.Code)
+		}
+		rec = httptest.NewRecorder()
+		handler.AcceptOwnershipTransfer(rec, newAdminRequest(ctx, buyer, http.MethodPost, acceptPath, `{"token":"orb_trf_wrong"}`, "transferId", transferID))
+		if rec.Code != http.StatusNotFound {
+			t.Errorf("Expected 404 with wrong token, got %d", rec.Code)
+		}
+
+		// 받는 사람이 토큰으로 수락
+		rec = httptest.NewRecorder()
+		handler.AcceptOwnershipTransfer(rec, newAdminRequest(ctx, buyer, http.MethodPost, acceptPath, fmt.Sprintf(`{"token":%q}`, token), "transferId", transferID))
+
+		// Then: 200 OK, 역할 변경, 감사 로그, 양쪽 알림
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+		var response AcceptOwnershipTransferResponse
+		if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		if response.Site.Role != models.SiteRoleOwner || response.PreviousOwnerRole != models.SiteRoleManager {
+			t.Errorf("Unexpected accept response: %+v", response)
+		}
+
+		entries, _, err := database.ListAuditLog(ctx, tx, site.ID, 10, 0)
+		if err != nil {
+			t.Fatalf("Failed to list audit log: %v", err)
+		}
+		if len(entries) == 0 || entries[0].Action != models.AuditActionTransferAccept {
+			t.Errorf("Expected transfer.accept audit entry, got %+v", entries)
+		}
+
+		want := []string{buyer.Email, owner.Email, owner.Email, buyer.Email}
+		if got := notifier.recipients(); strings.Join(got, ",") != strings.Join(want, ",") {
+			t.Errorf("Expected notifications to %v, got %v", want, got)
+		}
+	})
+
+	t.Run("manager는 이전 요청 불가", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		site := testhelpers.CreateTestSite(ctx, t, tx, "Transfer", "transfer-manager.example.com", []string{"https://transfer-manager.example.com"}, true)
+		manager := createUser(t, ctx, tx, "transfer-manager@example.com")
+		if err := database.AddUserToSite(ctx, tx, manager.ID, site.ID, models.SiteRoleManager); err != nil {
+			t.Fatalf("Failed to add user to site: %v", err)
+		}
+		siteID := strconv.FormatInt(site.ID, 10)
+
+		rec := httptest.NewRecorder()
+		NewAdminHandler(tx).RequestSiteTransfer(rec, newAdminRequest(ctx, manager, http.MethodPost, "/admin/sites/"+siteID+"/transfer", `{"email":"x@example.com"}`, "id", siteID))
+
+		if rec.Code != http.StatusForbidden {
+			t.Errorf("Expected 403, got %d", rec.Code)
+		}
+	})
+
+	t.Run("받는 사람이 아니면 404", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		site := testhelpers.CreateTestSite(ctx, t, tx, "Transfer", "transfer-other.example.com", []string{"https://transfer-other.example.com"}, true)
+		owner := createUser(t, ctx, tx, "transfer-other-owner@example.com")
+		if err := database.AddUserToSite(ctx, tx, owner.ID, site.ID, models.SiteRoleOwner); err != nil {
+			t.Fatalf("Failed to add user to site: %v", err)
+		}
+		stranger := createUser(t, ctx, tx, "stranger@example.com")
+		transfer := &models.SiteOwnershipTransfer{SiteID: site.ID, FromUserID: &owner.ID, ToEmail: "intended@example.com", ExpiresAt: time.Now().Add(time.Hour)}
+		if err := database.CreateSiteOwnershipTransfer(ctx, tx, transfer); err != nil {
+			t.Fatalf("Failed to create transfer: %v", err)
+		}
+		transferID := strconv.FormatInt(transfer.ID, 10)
+
+		rec := httptest.NewRecorder()
+		NewAdminHandler(tx).AcceptOwnershipTransfer(rec, newAdminRequest(ctx, stranger, http.MethodPost, "/admin/transfers/"+transferID+"/accept", fmt.Sprintf(`{"token":%q}`, transfer.Token), "transferId", transferID))
+
+		if rec.Code != http.StatusNotFound {
+			t.Errorf("Expected 404, got %d", rec.Code)
+		}
+	})
+
+	t.Run("이메일이 검증되지 않은 받는 사람은 403", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		site := testhelpers.CreateTestSite(ctx, t, tx, "Transfer", "transfer-unverified.example.com", []string{"https://transfer-unverified.example.com"}, true)
+		owner := createUser(t, ctx, tx, "transfer-unverified-owner@example.com")
+		if err := database.AddUserToSite(ctx, tx, owner.ID, site.ID, models.SiteRoleOwner); err != nil {
+			t.Fatalf("Failed to add user to site: %v", err)
+		}
+		recipient := &models.User{Email: "unverified-recipient@example.com", Name: "Unverified"}
+		if err := database.CreateUser(ctx, tx, recipient); err != nil {
+			t.Fatalf("Failed to create user: %v", err)
+		}
+		transfer := &models.SiteOwnershipTransfer{SiteID: site.ID, FromUserID: &owner.ID, ToEmail: recipient.Email, ExpiresAt: time.Now().Add(time.Hour)}
+		if err := database.CreateSiteOwnershipTransfer(ctx, tx, transfer); err != nil {
+			t.Fatalf("Failed to create transfer: %v", err)
+		}
+		transferID := strconv.FormatInt(transfer.ID, 10)
+
+		rec := httptest.NewRecorder()
+		NewAdminHandler(tx).AcceptOwnershipTransfer(rec, newAdminRequest(ctx, recipient, http.MethodPost, "/admin/transfers/"+transferID+"/accept", fmt.Sprintf(`{"token":%q}`, transfer.Token), "transferId", transferID))
+
+		if rec.Code != http.StatusForbidden {
+			t.Errorf("Expected 403, got %d", rec.Code)
+		}
+	})
+}
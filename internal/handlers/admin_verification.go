@@ -0,0 +1,148 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/june20516/orbithall/internal/database"
+	"github.com/june20516/orbithall/internal/domainverify"
+	"github.com/june20516/orbithall/internal/models"
+)
+
+// SiteVerificationResponse는 사이트 도메인 소유권 검증 상태와 검증 방법입니다
+type SiteVerificationResponse struct {
+	Domain       string                    `json:"domain"`
+	IsVerified   bool                      `json:"is_verified"`
+	VerifiedAt   *time.Time                `json:"verified_at,omitempty"`
+	Method       string                    `json:"method,omitempty"`
+	Instructions domainverify.Instructions `json:"instructions"`
+}
+
+// newSiteVerificationResponse는 사이트의 검증 상태 응답을 만듭니다
+func newSiteVerificationResponse(site *models.Site) SiteVerificationResponse {
+	return SiteVerificationResponse{
+		Domain:       site.Domain,
+		IsVerified:   site.IsVerified,
+		VerifiedAt:   site.VerifiedAt,
+		Instructions: domainverify.InstructionsFor(site.Domain, site.VerificationToken),
+	}
+}
+
+// GetSiteVerification은 사이트의 도메인 소유권 검증 상태와 검증 방법을 반환합니다
+// @Summary      도메인 검증 상태 조회
+// @Description  사이트 도메인의 소유권 검증 여부와 검증 방법을 반환합니다. _orbithall.<도메인>에 TXT 레코드(orbithall-verification=<토큰>)를 추가하거나, https://<도메인>/.well-known/orbithall-verification.txt 파일에 토큰을 게시한 뒤 검증을 요청합니다.
+// @Tags         admin
+// @Produce      json
+// @Param        id path int true "Site ID"
+// @Success      200 {object} SiteVerificationResponse
+// @Failure      400 {string} string "Invalid site ID"
+// @Failure      401 {string} string "Unauthorized"
+// @Failure      403 {string} string "Forbidden"
+// @Failure      404 {string} string "Site not found"
+// @Failure      500 {string} string "Failed to get site"
+// @Security     BearerAuth
+// @Router       /admin/sites/{id}/verification [get]
+func (h *AdminHandler) GetSiteVerification(w http.ResponseWriter, r *http.Request) {
+	siteID, ok := h.authorizeSite(w, r, models.PermissionSiteManage)
+	if !ok {
+		return
+	}
+
+	site, err := database.GetSiteByID(r.Context(), h.db, siteID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			http.Error(w, "Site not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, "Failed to get site", http.StatusInternalServerError)
+		return
+	}
+
+	// 응답 반환
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(newSiteVerificationResponse(site))
+}
+
+// VerifySiteDomain은 사이트 도메인의 소유권을 확인합니다
+// @Summary      도메인 소유권 검증
+// @Description  DNS TXT 레코드와 well-known HTTP 파일에서 검증 토큰을 확인합니다. 처음 검증에 성공하면 사이트가 활성화됩니다. 이미 검증된 사이트도 다시 확인할 수 있으며, 확인에 실패해도 기존 검증 상태는 유지됩니다. 다른 사이트가 이미 같은 도메인을 검증했으면 409를 반환합니다.
+// @Tags         admin
+// @Produce      json
+// @Param        id path int true "Site ID"
+// @Success      200 {object} SiteVerificationResponse
+// @Failure      400 {string} string "Invalid site ID"
+// @Failure      401 {string} string "Unauthorized"
+// @Failure      403 {string} string "Forbidden"
+// @Failure      404 {string} string "Site not found"
+// @Failure      409 {string} string "Domain already verified by another site"
+// @Failure      422 {object} map[string]interface{} "Verification token not found"
+// @Failure      500 {string} string "Failed to verify domain"
+// @Security     BearerAuth
+// @Router       /admin/sites/{id}/verification [post]
+func (h *AdminHandler) VerifySiteDomain(w http.ResponseWriter, r *http.Request) {
+	siteID, ok := h.authorizeSite(w, r, models.PermissionSiteManage)
+	if !ok {
+		return
+	}
+
+	site, err := database.GetSiteByID(r.Context(), h.db, siteID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			http.Error(w, "Site not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, "Failed to get site", http.StatusInternalServerError)
+		return
+	}
+
+	// DNS TXT 레코드, well-known HTTP 파일 순서로 토큰 확인
+	method, err := h.verifier.Verify(r.Context(), site.Domain, site.VerificationToken)
+	if err != nil {
+		// 조회 실패 원인과 검증 방법을 함께 반환
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"error":        err.Error(),
+			"instructions": domainverify.InstructionsFor(site.Domain, site.VerificationToken),
+		})
+		return
+	}
+
+	// 검증 완료 표시 후 변경 전/후 상태를 감사 로그에 기록
+	var verifiedSite *models.Site
+	err = database.RunInTx(r.Context(), h.db, func(tx database.DBTX) error {
+		if err := database.MarkSiteDomainVerified(r.Context(), tx, siteID); err != nil {
+			return err
+		}
+
+		var err error
+		verifiedSite, err = database.GetSiteByID(r.Context(), tx, siteID)
+		if err != nil {
+			return err
+		}
+		return recordAudit(r, tx, siteID, models.AuditActionSiteVerify, models.AuditTargetSite, siteID, newSiteAuditState(site), newSiteAuditState(verifiedSite))
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, database.ErrDomainAlreadyVerified):
+			http.Error(w, "Domain already verified by another site", http.StatusConflict)
+		case err == sql.ErrNoRows:
+			http.Error(w, "Site not found", http.StatusNotFound)
+		default:
+			http.Error(w, "Failed to verify domain", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	response := newSiteVerificationResponse(verifiedSite)
+	response.Method = method
+
+	// 응답 반환
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
@@ -0,0 +1,141 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/june20516/orbithall/internal/database"
+	"github.com/june20516/orbithall/internal/domainverify"
+	"github.com/june20516/orbithall/internal/models"
+	"github.com/june20516/orbithall/internal/testhelpers"
+)
+
+// stubResolver는 고정된 TXT 레코드를 반환하는 테스트용 DNS resolver입니다
+type stubResolver map[string][]string
+
+func (r stubResolver) LookupTXT(ctx context.Context, name string) ([]string, error) {
+	if records, ok := r[name]; ok {
+		return records, nil
+	}
+	return nil, errors.New("no such host")
+}
+
+// stubFetcher는 항상 404를 반환하는 테스트용 HTTP fetcher입니다
+type stubFetcher struct{}
+
+func (stubFetcher) Fetch(ctx context.Context, url string, maxBytes int64) ([]byte, error) {
+	return nil, errors.New("unexpected status 404")
+}
+
+// newSiteVerificationRequest는 /admin/sites/{id}/verification 요청을 생성합니다
+func newSiteVerificationRequest(ctx context.Context, user *models.User, method string, siteID int64) *http.Request {
+	site := strconv.FormatInt(siteID, 10)
+	return newAdminRequest(ctx, user, method, "/admin/sites/"+site+"/verification", "", "id", site)
+}
+
+// TestSiteVerificationHandlers는 도메인 소유권 검증 API를 테스트합니다
+func TestSiteVerificationHandlers(t *testing.T) {
+	db := testhelpers.SetupTestDB(t)
+	defer database.Close(db)
+
+	// createUnverifiedSite는 검증되지 않은 비활성 사이트와 소유자를 생성합니다
+	createUnverifiedSite := func(t *testing.T, ctx context.Context, tx database.DBTX, email, domain string) (*models.User, *models.Site) {
+		user := &models.User{Email: email, Name: "Verifier"}
+		if err := database.CreateUser(ctx, tx, user); err != nil {
+			t.Fatalf("Failed to create user: %v", err)
+		}
+		site := &models.Site{Name: "Unverified", Domain: domain, CORSOrigins: []string{"https://" + domain}}
+		if err := database.CreateSiteForUser(ctx, tx, site, user.ID); err != nil {
+			t.Fatalf("Failed to create site: %v", err)
+		}
+		return user, site
+	}
+
+	t.Run("DNS TXT 레코드로 검증 후 활성화", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		// Given: 토큰 TXT 레코드를 등록한 미검증 사이트
+		user, site := createUnverifiedSite(t, ctx, tx, "verify-dns@example.com", "dns.example.com")
+		handler := NewAdminHandler(tx)
+		handler.SetDomainVerifier(domainverify.New(stubResolver{
+			"_orbithall.dns.example.com": {"orbithall-verification=" + site.VerificationToken},
+		}, stubFetcher{}))
+
+		// When
+		rec := httptest.NewRecorder()
+		handler.VerifySiteDomain(rec, newSiteVerificationRequest(ctx, user, http.MethodPost, site.ID))
+
+		// Then: 200 OK, DNS 방식으로 검증되고 사이트 활성화
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, rec.Code, rec.Body.String())
+		}
+		var response SiteVerificationResponse
+		json.Unmarshal(rec.Body.Bytes(), &response)
+		if !response.IsVerified || response.Method != domainverify.MethodDNS {
+			t.Errorf("Expected verified by dns, got %+v", response)
+		}
+		verified, _ := database.GetSiteByID(ctx, tx, site.ID)
+		if !verified.IsActive {
+			t.Error("Expected site to be activated")
+		}
+	})
+
+	t.Run("토큰을 찾지 못하면 422와 검증 방법 반환", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		// Given: 토큰을 게시하지 않은 미검증 사이트
+		user, site := createUnverifiedSite(t, ctx, tx, "verify-fail@example.com", "fail.example.com")
+		handler := NewAdminHandler(tx)
+		handler.SetDomainVerifier(domainverify.New(stubResolver{}, stubFetcher{}))
+
+		// When
+		rec := httptest.NewRecorder()
+		handler.VerifySiteDomain(rec, newSiteVerificationRequest(ctx, user, http.MethodPost, site.ID))
+
+		// Then: 422, 사이트는 미검증 상태 유지
+		if rec.Code != http.StatusUnprocessableEntity {
+			t.Fatalf("Expected status %d, got %d", http.StatusUnprocessableEntity, rec.Code)
+		}
+		var response map[string]interface{}
+		json.Unmarshal(rec.Body.Bytes(), &response)
+		if response["instructions"] == nil {
+			t.Error("Expected instructions in response")
+		}
+		unverified, _ := database.GetSiteByID(ctx, tx, site.ID)
+		if unverified.IsVerified || unverified.IsActive {
+			t.Error("Expected site to stay unverified and inactive")
+		}
+	})
+
+	t.Run("검증 전 활성화 거부", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		// Given: 미검증 사이트
+		user, site := createUnverifiedSite(t, ctx, tx, "verify-activate@example.com", "activate.example.com")
+
+		// When: is_active=true로 수정
+		req := httptest.NewRequest(http.MethodPut, "/admin/sites/"+strconv.FormatInt(site.ID, 10), strings.NewReader(`{"is_active": true}`))
+		req.Header.Set("Content-Type", "application/json")
+		req = req.WithContext(context.WithValue(ctx, userContextKey, user))
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", strconv.FormatInt(site.ID, 10))
+		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+		rec := httptest.NewRecorder()
+		NewAdminHandler(tx).UpdateSite(rec, req)
+
+		// Then: 409 Conflict
+		if rec.Code != http.StatusConflict {
+			t.Errorf("Expected status %d, got %d", http.StatusConflict, rec.Code)
+		}
+	})
+}
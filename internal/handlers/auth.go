@@ -3,50 +3,93 @@ package handlers
 import (
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"net/http"
+	"strings"
+	"time"
 
+	"github.com/go-chi/chi/v5"
 	"github.com/june20516/orbithall/internal/auth"
 	"github.com/june20516/orbithall/internal/database"
+	"github.com/june20516/orbithall/internal/httputil"
 	"github.com/june20516/orbithall/internal/models"
 )
 
+// maxUserNameLength는 users.name 컬럼의 최대 길이입니다
+const maxUserNameLength = 100
+
+// maxUserAgentLength는 세션에 기록하는 User-Agent의 최대 길이입니다
+const maxUserAgentLength = 512
+
 // AuthHandler는 인증 관련 HTTP 요청을 처리합니다
 type AuthHandler struct {
-	db *sql.DB
+	db        database.DBTX
+	providers map[string]auth.IdentityProvider
 }
 
 // NewAuthHandler는 AuthHandler의 새 인스턴스를 생성합니다
-func NewAuthHandler(db *sql.DB) *AuthHandler {
-	return &AuthHandler{
+// 기본 ID 공급자는 Google이며, 다른 공급자는 SetProviders로 설정합니다
+func NewAuthHandler(db database.DBTX) *AuthHandler {
+	h := &AuthHandler{
 		db: db,
 	}
+	h.SetProviders(auth.NewGoogleProvider())
+	return h
+}
+
+// SetProviders는 로그인에 사용할 ID 공급자 목록을 교체합니다
+func (h *AuthHandler) SetProviders(providers ...auth.IdentityProvider) {
+	h.providers = make(map[string]auth.IdentityProvider, len(providers))
+	for _, provider := range providers {
+		h.providers[provider.Name()] = provider
+	}
 }
 
 // GoogleVerifyRequest는 Google ID Token 검증 요청 본문입니다
+// name, picture는 ID Token에 해당 클레임이 없을 때만 사용합니다 (이메일은 항상 ID Token의 클레임을 사용)
 type GoogleVerifyRequest struct {
 	IDToken string `json:"id_token"`
-	Email   string `json:"email"`
 	Name    string `json:"name"`
 	Picture string `json:"picture"`
 }
 
-// GoogleVerifyResponse는 Google ID Token 검증 성공 응답입니다
-type GoogleVerifyResponse struct {
-	Token string        `json:"token"`
-	User  *models.User `json:"user"`
+// IdentityVerifyRequest는 ID 공급자 ID Token 검증 요청 본문입니다
+type IdentityVerifyRequest struct {
+	IDToken string `json:"id_token"`
+}
+
+// RefreshTokenRequest는 세션 갱신/로그아웃 요청 본문입니다
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+// AuthTokenResponse는 로그인과 세션 갱신 성공 응답입니다
+type AuthTokenResponse struct {
+	// Token은 Admin API 호출에 사용하는 access token(JWT)입니다
+	Token string `json:"token"`
+
+	// ExpiresIn은 access token의 유효 기간(초)입니다
+	ExpiresIn int64 `json:"expires_in"`
+
+	// RefreshToken은 /auth/refresh로 새 access token을 받을 때 사용하는 토큰입니다 (갱신할 때마다 교체됨)
+	RefreshToken string `json:"refresh_token"`
+
+	User *models.User `json:"user"`
 }
 
 // GoogleVerify는 Google ID Token을 검증하고 백엔드 JWT를 발급합니다
 //
 // @Summary      Google OAuth 인증 및 JWT 발급
-// @Description  Google ID Token을 검증하고 사용자를 생성/조회한 후 백엔드 JWT를 발급합니다
+// @Description  Google ID Token을 검증하고 사용자를 생성/조회한 후 로그인 세션을 만들어 access token(JWT)과 refresh token을 발급합니다. 이메일은 ID Token의 email 클레임을 사용하며, 처음 로그인할 때는 email_verified가 true여야 합니다.
 // @Tags         auth
 // @Accept       json
 // @Produce      json
 // @Param        request body GoogleVerifyRequest true "Google 인증 정보"
-// @Success      200 {object} GoogleVerifyResponse "JWT 토큰 및 사용자 정보"
+// @Success      200 {object} AuthTokenResponse "토큰 및 사용자 정보"
 // @Failure      400 {string} string "Invalid request body or missing required fields"
 // @Failure      401 {string} string "Invalid Google ID Token"
+// @Failure      403 {string} string "ID Token email must be verified"
+// @Failure      409 {string} string "Email is already registered with another login"
 // @Failure      500 {string} string "Internal server error"
 // @Router       /auth/google/verify [post]
 func (h *AuthHandler) GoogleVerify(w http.ResponseWriter, r *http.Request) {
@@ -68,76 +111,295 @@ func (h *AuthHandler) GoogleVerify(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "id_token is required", http.StatusBadRequest)
 		return
 	}
-	if req.Email == "" {
-		http.Error(w, "email is required", http.StatusBadRequest)
+
+	provider, ok := h.providers[auth.GoogleProviderName]
+	if !ok {
+		http.Error(w, "Unknown identity provider", http.StatusNotFound)
+		return
+	}
+
+	// 4. ID Token 검증 후 로그인
+	h.login(w, r, provider, req.IDToken, &models.User{
+		Name:       req.Name,
+		PictureURL: req.Picture,
+	})
+}
+
+// ProviderVerify는 설정된 ID 공급자(OIDC 등)의 ID Token을 검증하고 백엔드 JWT를 발급합니다
+//
+// @Summary      ID 공급자 인증 및 JWT 발급
+// @Description  OIDC_ISSUER_URL로 설정한 공급자(Keycloak 등)가 발급한 ID Token의 서명(JWKS), 발급자, 대상(aud), 만료를 검증하고 사용자를 생성/조회한 후 로그인 세션을 만들어 access token(JWT)과 refresh token을 발급합니다. ID Token에는 email 클레임이 있어야 하며, 처음 로그인할 때는 email_verified가 true여야 합니다. 처음 로그인한 계정의 이메일을 이미 다른 사용자가 사용 중이면 그 사용자의 이메일도 검증된 경우에만 그 사용자에게 연결합니다.
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        provider path string                true "공급자 이름 (예: oidc)"
+// @Param        request  body IdentityVerifyRequest true "ID Token"
+// @Success      200 {object} AuthTokenResponse "토큰 및 사용자 정보"
+// @Failure      400 {string} string "Invalid request body or missing required fields"
+// @Failure      401 {string} string "Invalid ID Token"
+// @Failure      404 {string} string "Unknown identity provider"
+// @Failure      403 {string} string "ID Token email must be verified"
+// @Failure      409 {string} string "Email is already registered with another login"
+// @Failure      500 {string} string "Internal server error"
+// @Failure      502 {string} string "Identity provider unavailable"
+// @Router       /auth/{provider}/verify [post]
+func (h *AuthHandler) ProviderVerify(w http.ResponseWriter, r *http.Request) {
+	provider, ok := h.providers[chi.URLParam(r, "provider")]
+	if !ok {
+		http.Error(w, "Unknown identity provider", http.StatusNotFound)
+		return
+	}
+
+	// Content-Type 검증
+	if r.Header.Get("Content-Type") != "application/json" {
+		http.Error(w, "Content-Type must be application/json", http.StatusBadRequest)
+		return
+	}
+
+	// JSON 요청 파싱
+	var req IdentityVerifyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
 		return
 	}
-	if req.Name == "" {
-		http.Error(w, "name is required", http.StatusBadRequest)
+	if req.IDToken == "" {
+		http.Error(w, "id_token is required", http.StatusBadRequest)
 		return
 	}
 
-	// 4. Google ID Token 검증
-	payload, err := auth.VerifyGoogleIDToken(r.Context(), req.IDToken)
+	h.login(w, r, provider, req.IDToken, &models.User{})
+}
+
+// login은 ID Token을 검증하고 연결된 사용자를 조회(없으면 생성)한 뒤 백엔드 JWT를 발급합니다
+// fallback의 이름, 사진은 ID Token에 해당 클레임이 없을 때 사용합니다
+// 이메일은 ID Token의 email 클레임만 사용하며, 공급자가 검증하지 않은 이메일로는 새 사용자를 만들거나 기존 사용자에게 연결하지 않습니다
+func (h *AuthHandler) login(w http.ResponseWriter, r *http.Request, provider auth.IdentityProvider, idToken string, fallback *models.User) {
+	// ID Token 검증
+	identity, err := provider.Verify(r.Context(), idToken)
 	if err != nil {
-		if err == auth.ErrInvalidIDToken {
-			http.Error(w, "Invalid Google ID Token", http.StatusUnauthorized)
-			return
+		switch {
+		case errors.Is(err, auth.ErrInvalidIDToken):
+			if provider.Name() == auth.GoogleProviderName {
+				http.Error(w, "Invalid Google ID Token", http.StatusUnauthorized)
+				return
+			}
+			http.Error(w, "Invalid ID Token", http.StatusUnauthorized)
+		case errors.Is(err, auth.ErrProviderUnavailable):
+			http.Error(w, "Identity provider unavailable", http.StatusBadGateway)
+		default:
+			http.Error(w, "Failed to verify ID Token", http.StatusInternalServerError)
 		}
-		http.Error(w, "Failed to verify Google ID Token", http.StatusInternalServerError)
 		return
 	}
 
-	// 5. 트랜잭션 시작
-	tx, err := h.db.BeginTx(r.Context(), nil)
-	if err != nil {
-		http.Error(w, "Failed to start transaction", http.StatusInternalServerError)
+	profile := &models.User{
+		Email:      identity.Email,
+		Name:       firstNonEmpty(identity.Name, fallback.Name),
+		PictureURL: firstNonEmpty(identity.Picture, fallback.PictureURL),
+	}
+	if profile.Email == "" {
+		http.Error(w, "ID Token must include an email claim", http.StatusUnauthorized)
 		return
 	}
-	defer tx.Rollback()
+	profile.Name = userDisplayName(profile.Name, profile.Email)
+
+	// 연결된 사용자 조회 (없으면 생성 후 계정 연결) 후 로그인 세션 생성
+	var user *models.User
+	session := &models.Session{
+		UserAgent: clientUserAgent(r),
+		IPAddress: httputil.GetIPAddress(r),
+		ExpiresAt: time.Now().Add(min(auth.RefreshTokenTTL(), auth.SessionMaxLifetime())),
+	}
+	err = database.RunInTx(r.Context(), h.db, func(tx database.DBTX) error {
+		var err error
+		user, err = database.LoginWithIdentity(r.Context(), tx, identity.Provider, identity.Subject, profile, identity.EmailVerified)
+		if err != nil {
+			return err
+		}
 
-	// 6. Google ID로 사용자 조회
-	user, err := database.GetUserByGoogleID(r.Context(), tx, payload.GoogleID)
+		session.UserID = user.ID
+		return database.CreateSession(r.Context(), tx, session)
+	})
 	if err != nil {
+		if errors.Is(err, database.ErrIdentityEmailConflict) {
+			http.Error(w, "Email is already registered with another login", http.StatusConflict)
+			return
+		}
+		if errors.Is(err, database.ErrEmailNotVerified) {
+			http.Error(w, "ID Token email must be verified", http.StatusForbidden)
+			return
+		}
 		http.Error(w, "Failed to get user", http.StatusInternalServerError)
 		return
 	}
 
-	// 7. 사용자가 없으면 생성
-	if user == nil {
-		user = &models.User{
-			Email:      req.Email,
-			Name:       req.Name,
-			PictureURL: req.Picture,
-			GoogleID:   payload.GoogleID,
-		}
+	h.respondWithTokens(w, user, session)
+}
 
-		if err := database.CreateUser(r.Context(), tx, user); err != nil {
-			http.Error(w, "Failed to create user", http.StatusInternalServerError)
-			return
+// Refresh는 refresh token으로 로그인 세션을 갱신하고 새 access token과 refresh token을 발급합니다
+//
+// @Summary      세션 갱신 (access token 재발급)
+// @Description  refresh token으로 새 access token(JWT)과 refresh token을 발급합니다. 사용한 refresh token은 더 이상 사용할 수 없으며, 세션에서 이미 사용한 refresh token이 다시 사용되면 탈취된 것으로 보고 세션을 로그아웃합니다. 세션은 갱신할 때마다 연장되지만 로그인한 시각부터 최대 유지 기간(JWT_SESSION_MAX_LIFETIME)이 지나면 다시 로그인해야 합니다.
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        request body RefreshTokenRequest true "refresh token"
+// @Success      200 {object} AuthTokenResponse "토큰 및 사용자 정보"
+// @Failure      400 {string} string "refresh_token is required"
+// @Failure      401 {string} string "Invalid or expired refresh token"
+// @Failure      500 {string} string "Internal server error"
+// @Router       /auth/refresh [post]
+func (h *AuthHandler) Refresh(w http.ResponseWriter, r *http.Request) {
+	req, ok := parseRefreshTokenRequest(w, r)
+	if !ok {
+		return
+	}
+
+	// 세션 갱신 (refresh token 교체)
+	// 재사용 감지 시 세션 폐기가 롤백되지 않도록 바깥 트랜잭션 없이 호출 (RefreshSession이 자체 트랜잭션 사용)
+	// 만료 시각은 갱신할 때마다 연장하되 로그인한 시각부터 SessionMaxLifetime을 넘지 않음
+	session, err := database.RefreshSession(r.Context(), h.db, req.RefreshToken, clientUserAgent(r), httputil.GetIPAddress(r),
+		time.Now().Add(auth.RefreshTokenTTL()), auth.SessionMaxLifetime())
+	if err != nil {
+		switch {
+		case err == sql.ErrNoRows, errors.Is(err, database.ErrSessionInactive):
+			http.Error(w, "Invalid or expired refresh token", http.StatusUnauthorized)
+		case errors.Is(err, database.ErrRefreshTokenReused):
+			http.Error(w, "Refresh token was already used; session has been logged out", http.StatusUnauthorized)
+		default:
+			http.Error(w, "Failed to refresh session", http.StatusInternalServerError)
 		}
+		return
 	}
 
-	// 8. 트랜잭션 커밋
-	if err := tx.Commit(); err != nil {
-		http.Error(w, "Failed to commit transaction", http.StatusInternalServerError)
+	user, err := database.GetUserByID(r.Context(), h.db, session.UserID)
+	if err != nil {
+		http.Error(w, "Failed to refresh session", http.StatusInternalServerError)
 		return
 	}
+	if user == nil {
+		http.Error(w, "Invalid or expired refresh token", http.StatusUnauthorized)
+		return
+	}
+
+	h.respondWithTokens(w, user, session)
+}
+
+// Logout은 refresh token의 로그인 세션을 로그아웃합니다
+//
+// @Summary      로그아웃
+// @Description  refresh token의 세션을 로그아웃합니다. 로그아웃한 세션의 access token과 refresh token은 더 이상 사용할 수 없습니다. 이미 로그아웃되었거나 알 수 없는 토큰이어도 204를 반환합니다.
+// @Tags         auth
+// @Accept       json
+// @Param        request body RefreshTokenRequest true "refresh token"
+// @Success      204 "No Content"
+// @Failure      400 {string} string "refresh_token is required"
+// @Failure      500 {string} string "Failed to log out"
+// @Router       /auth/logout [post]
+func (h *AuthHandler) Logout(w http.ResponseWriter, r *http.Request) {
+	req, ok := parseRefreshTokenRequest(w, r)
+	if !ok {
+		return
+	}
+
+	err := database.RevokeSessionByRefreshToken(r.Context(), h.db, req.RefreshToken)
+	if err != nil && err != sql.ErrNoRows {
+		http.Error(w, "Failed to log out", http.StatusInternalServerError)
+		return
+	}
+
+	// 204 No Content 응답
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// parseRefreshTokenRequest는 refresh token 요청 본문을 파싱합니다
+// 실패하면 400 응답을 쓰고 false를 반환합니다
+func parseRefreshTokenRequest(w http.ResponseWriter, r *http.Request) (*RefreshTokenRequest, bool) {
+	var req RefreshTokenRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		return nil, false
+	}
+	req.RefreshToken = strings.TrimSpace(req.RefreshToken)
+	if req.RefreshToken == "" {
+		http.Error(w, "refresh_token is required", http.StatusBadRequest)
+		return nil, false
+	}
+	return &req, true
+}
 
-	// 9. JWT 생성
-	token, err := auth.GenerateJWT(user.ID, user.Email)
+// respondWithTokens는 세션의 access token(JWT)을 발급하고 refresh token과 함께 응답합니다
+func (h *AuthHandler) respondWithTokens(w http.ResponseWriter, user *models.User, session *models.Session) {
+	// JWT 생성
+	token, err := auth.GenerateJWT(user.ID, user.Email, session.ID)
 	if err != nil {
 		http.Error(w, "Failed to generate JWT", http.StatusInternalServerError)
 		return
 	}
 
-	// 10. 응답
-	response := GoogleVerifyResponse{
-		Token: token,
-		User:  user,
+	// 응답
+	response := AuthTokenResponse{
+		Token:        token,
+		ExpiresIn:    int64(auth.AccessTokenTTL().Seconds()),
+		RefreshToken: session.RefreshToken,
+		User:         user,
 	}
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(response)
 }
+
+// clientUserAgent는 세션에 기록할 User-Agent를 반환합니다 (최대 512자)
+func clientUserAgent(r *http.Request) string {
+	userAgent := r.UserAgent()
+	if runes := []rune(userAgent); len(runes) > maxUserAgentLength {
+		userAgent = string(runes[:maxUserAgentLength])
+	}
+	return userAgent
+}
+
+// firstNonEmpty는 비어 있지 않은 첫 번째 값을 반환합니다
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value = strings.TrimSpace(value); value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// userDisplayName은 사용자 이름을 정리합니다
+// 공급자가 이름을 알려주지 않으면 이메일의 @ 앞부분을 사용하고, users.name 길이에 맞게 자릅니다
+func userDisplayName(name, email string) string {
+	if name == "" {
+		name, _, _ = strings.Cut(email, "@")
+	}
+	if runes := []rune(name); len(runes) > maxUserNameLength {
+		name = string(runes[:maxUserNameLength])
+	}
+	return name
+}
+
+// JWKS는 access token(JWT) 서명 검증에 사용하는 공개 키 목록을 반환합니다
+//
+// @Summary      JWT 공개 키 목록 (JWKS)
+// @Description  access token(JWT)을 검증할 수 있는 공개 키 목록(RFC 7517)을 반환합니다. 토큰 헤더의 kid에 해당하는 키로 서명을 검증하며, 키 교체 중에는 이전 키와 다음 키도 함께 포함됩니다. JWT_SECRET(HS256)만 사용하면 빈 목록을 반환합니다.
+// @Tags         auth
+// @Produce      json
+// @Success      200 {object} auth.JSONWebKeySet
+// @Failure      500 {string} string "Failed to load signing keys"
+// @Router       /.well-known/jwks.json [get]
+func (h *AuthHandler) JWKS(w http.ResponseWriter, r *http.Request) {
+	set, err := auth.PublicSigningKeys()
+	if err != nil {
+		http.Error(w, "Failed to load signing keys", http.StatusInternalServerError)
+		return
+	}
+
+	// 다른 서비스가 캐시할 수 있도록 허용 (키 교체 시 새 키는 활성화 전에 미리 공개)
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(set)
+}
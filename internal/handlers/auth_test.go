@@ -2,20 +2,26 @@ package handlers
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/go-chi/chi/v5"
+	"github.com/june20516/orbithall/internal/auth"
 	"github.com/june20516/orbithall/internal/database"
+	"github.com/june20516/orbithall/internal/models"
 	"github.com/june20516/orbithall/internal/testhelpers"
 )
 
 func init() {
 	// 테스트용 환경변수 설정
 	os.Setenv("JWT_SECRET", "test-secret-key-at-least-32-characters-long-for-security")
-	os.Setenv("JWT_EXPIRATION_HOURS", "168")
 	os.Setenv("GOOGLE_CLIENT_ID", "test-client-id.apps.googleusercontent.com")
 }
 
@@ -32,15 +38,7 @@ func TestGoogleVerify_MissingFields(t *testing.T) {
 	}{
 		{
 			name:        "id_token 누락",
-			requestBody: map[string]interface{}{"email": "test@example.com", "name": "Test User"},
-		},
-		{
-			name:        "email 누락",
-			requestBody: map[string]interface{}{"id_token": "token", "name": "Test User"},
-		},
-		{
-			name:        "name 누락",
-			requestBody: map[string]interface{}{"id_token": "token", "email": "test@example.com"},
+			requestBody: map[string]interface{}{"name": "Test User"},
 		},
 		{
 			name:        "빈 요청 본문",
@@ -137,6 +135,349 @@ func TestGoogleVerify_InvalidGoogleToken(t *testing.T) {
 	}
 }
 
-// 참고: 실제 Google ID Token 검증 및 JWT 발급 테스트는 통합 테스트에서 수행
+// 참고: 실제 Google ID Token 검증은 통합 테스트에서 수행
 // 실제 토큰을 사용하려면 Google OAuth Playground에서 발급받아야 함
-// 또는 VerifyGoogleIDToken을 모킹하는 별도 테스트가 필요함
+// 로그인 흐름은 아래 stubProvider로 테스트
+
+// stubProvider는 미리 정한 계정 정보를 반환하는 테스트용 IdentityProvider입니다
+type stubProvider struct {
+	name     string
+	identity *auth.Identity
+	err      error
+}
+
+func (p *stubProvider) Name() string {
+	return p.name
+}
+
+func (p *stubProvider) Verify(ctx context.Context, idToken string) (*auth.Identity, error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+	return p.identity, nil
+}
+
+// newProviderVerifyRequest는 /auth/{provider}/verify 요청을 생성합니다
+func newProviderVerifyRequest(ctx context.Context, provider, idToken string) *http.Request {
+	bodyBytes, _ := json.Marshal(map[string]string{"id_token": idToken})
+	req := httptest.NewRequest(http.MethodPost, "/auth/"+provider+"/verify", bytes.NewBuffer(bodyBytes))
+	req.Header.Set("Content-Type", "application/json")
+
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("provider", provider)
+	return req.WithContext(context.WithValue(ctx, chi.RouteCtxKey, rctx))
+}
+
+// TestProviderVerify는 OIDC 등 ID 공급자 로그인을 테스트합니다
+func TestProviderVerify(t *testing.T) {
+	db := testhelpers.SetupTestDB(t)
+	defer database.Close(db)
+
+	t.Run("처음 로그인하면 사용자 생성 후 JWT 발급", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		// Given: 이름 클레임이 없는 OIDC 계정
+		handler := NewAuthHandler(tx)
+		handler.SetProviders(&stubProvider{name: "oidc", identity: &auth.Identity{
+			Provider:      "oidc",
+			Subject:       "kc-1",
+			Email:         "keycloak-user@example.com",
+			EmailVerified: true,
+		}})
+
+		// When
+		rec := httptest.NewRecorder()
+		handler.ProviderVerify(rec, newProviderVerifyRequest(ctx, "oidc", "token"))
+
+		// Then: 200 OK, 이메일 앞부분을 이름으로 사용
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, rec.Code, rec.Body.String())
+		}
+		var response AuthTokenResponse
+		if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		if response.Token == "" || response.User.Email != "keycloak-user@example.com" || response.User.Name != "keycloak-user" {
+			t.Errorf("Unexpected response: %+v", response)
+		}
+		if !strings.HasPrefix(response.RefreshToken, models.RefreshTokenPrefix) || response.ExpiresIn != int64(auth.AccessTokenTTL().Seconds()) {
+			t.Errorf("Unexpected refresh token or expires_in: %+v", response)
+		}
+		claims, err := auth.ValidateJWT(response.Token)
+		if err != nil || claims.UserID != response.User.ID {
+			t.Errorf("Expected JWT for user %d, got %+v (err: %v)", response.User.ID, claims, err)
+		}
+
+		// 로그인 세션 생성
+		sessions, _ := database.ListActiveSessions(ctx, tx, response.User.ID)
+		if len(sessions) != 1 || sessions[0].ID != claims.SessionID {
+			t.Errorf("Expected one session %d, got %+v", claims.SessionID, sessions)
+		}
+	})
+
+	t.Run("검증되지 않은 이메일로는 사용자를 만들거나 연결하지 않음", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		if _, err := database.LoginWithIdentity(ctx, tx, "google", "google-taken", &models.User{Email: "taken@example.com", Name: "Taken"}, true); err != nil {
+			t.Fatalf("Failed to create user: %v", err)
+		}
+
+		for _, email := range []string{"new-unverified@example.com", "taken@example.com"} {
+			handler := NewAuthHandler(tx)
+			handler.SetProviders(&stubProvider{name: "oidc", identity: &auth.Identity{
+				Provider: "oidc",
+				Subject:  "kc-" + email,
+				Email:    email,
+			}})
+
+			rec := httptest.NewRecorder()
+			handler.ProviderVerify(rec, newProviderVerifyRequest(ctx, "oidc", "token"))
+
+			if rec.Code != http.StatusForbidden {
+				t.Errorf("Expected status %d for %s, got %d", http.StatusForbidden, email, rec.Code)
+			}
+		}
+		if user, _ := database.GetUserByEmail(ctx, tx, "new-unverified@example.com"); user != nil {
+			t.Errorf("Expected no user for unverified email, got %+v", user)
+		}
+	})
+
+	t.Run("이메일이 검증되지 않은 기존 사용자에게는 연결하지 않고 409", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		// Given: 이메일이 검증되지 않은 기존 사용자 (다른 사람이 미리 만든 계정일 수 있음)
+		if err := database.CreateUser(ctx, tx, &models.User{Email: "preclaimed@example.com", Name: "Preclaimed"}); err != nil {
+			t.Fatalf("Failed to create user: %v", err)
+		}
+		handler := NewAuthHandler(tx)
+		handler.SetProviders(&stubProvider{name: "oidc", identity: &auth.Identity{
+			Provider:      "oidc",
+			Subject:       "kc-preclaimed",
+			Email:         "preclaimed@example.com",
+			EmailVerified: true,
+		}})
+
+		rec := httptest.NewRecorder()
+		handler.ProviderVerify(rec, newProviderVerifyRequest(ctx, "oidc", "token"))
+
+		if rec.Code != http.StatusConflict {
+			t.Errorf("Expected status %d, got %d", http.StatusConflict, rec.Code)
+		}
+	})
+
+	t.Run("공급자 오류 응답", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			provider string
+			err      error
+			expected int
+		}{
+			{"설정되지 않은 공급자", "unknown", nil, http.StatusNotFound},
+			{"유효하지 않은 토큰", "oidc", auth.ErrInvalidIDToken, http.StatusUnauthorized},
+			{"공급자 장애", "oidc", auth.ErrProviderUnavailable, http.StatusBadGateway},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				handler := NewAuthHandler(db)
+				handler.SetProviders(&stubProvider{name: "oidc", err: tt.err})
+
+				rec := httptest.NewRecorder()
+				handler.ProviderVerify(rec, newProviderVerifyRequest(context.Background(), tt.provider, "token"))
+
+				if rec.Code != tt.expected {
+					t.Errorf("Expected status %d, got %d", tt.expected, rec.Code)
+				}
+			})
+		}
+	})
+}
+
+// newRefreshTokenRequest는 /auth/refresh, /auth/logout 요청을 생성합니다
+func newRefreshTokenRequest(ctx context.Context, target, refreshToken string) *http.Request {
+	bodyBytes, _ := json.Marshal(map[string]string{"refresh_token": refreshToken})
+	req := httptest.NewRequest(http.MethodPost, target, bytes.NewBuffer(bodyBytes))
+	req.Header.Set("Content-Type", "application/json")
+	return req.WithContext(ctx)
+}
+
+// TestRefreshAndLogout은 refresh token으로 세션을 갱신하고 로그아웃하는 흐름을 테스트합니다
+func TestRefreshAndLogout(t *testing.T) {
+	db := testhelpers.SetupTestDB(t)
+	defer database.Close(db)
+
+	t.Run("갱신하면 refresh token 교체, 로그아웃하면 사용 불가", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		// Given: 로그인 세션
+		user, err := database.LoginWithIdentity(ctx, tx, "google", "google-refresh", &models.User{Email: "refresh@example.com", Name: "Refresh"}, true)
+		if err != nil {
+			t.Fatalf("Failed to create user: %v", err)
+		}
+		session := &models.Session{UserID: user.ID, ExpiresAt: time.Now().Add(time.Hour)}
+		if err := database.CreateSession(ctx, tx, session); err != nil {
+			t.Fatalf("Failed to create session: %v", err)
+		}
+		handler := NewAuthHandler(tx)
+
+		// When: 갱신
+		rec := httptest.NewRecorder()
+		handler.Refresh(rec, newRefreshTokenRequest(ctx, "/auth/refresh", session.RefreshToken))
+
+		// Then: 같은 세션의 새 access token과 새 refresh token 발급
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, rec.Code, rec.Body.String())
+		}
+		var response AuthTokenResponse
+		if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		if response.RefreshToken == "" || response.RefreshToken == session.RefreshToken || response.User.ID != user.ID {
+			t.Errorf("Unexpected response: %+v", response)
+		}
+		claims, err := auth.ValidateJWT(response.Token)
+		if err != nil || claims.SessionID != session.ID {
+			t.Errorf("Expected JWT for session %d, got %+v (err: %v)", session.ID, claims, err)
+		}
+
+		// When: 로그아웃
+		rec = httptest.NewRecorder()
+		handler.Logout(rec, newRefreshTokenRequest(ctx, "/auth/logout", response.RefreshToken))
+
+		// Then: 204, 새 refresh token으로 갱신 불가
+		if rec.Code != http.StatusNoContent {
+			t.Errorf("Expected status %d, got %d", http.StatusNoContent, rec.Code)
+		}
+		rec = httptest.NewRecorder()
+		handler.Refresh(rec, newRefreshTokenRequest(ctx, "/auth/refresh", response.RefreshToken))
+		if rec.Code != http.StatusUnauthorized {
+			t.Errorf("Expected status %d after logout, got %d", http.StatusUnauthorized, rec.Code)
+		}
+	})
+
+	t.Run("교체된 refresh token을 다시 사용하면 401 후 세션 로그아웃", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		// Given: 한 번 갱신한 세션
+		user, err := database.LoginWithIdentity(ctx, tx, "google", "google-reuse", &models.User{Email: "reuse@example.com", Name: "Reuse"}, true)
+		if err != nil {
+			t.Fatalf("Failed to create user: %v", err)
+		}
+		session := &models.Session{UserID: user.ID, ExpiresAt: time.Now().Add(time.Hour)}
+		if err := database.CreateSession(ctx, tx, session); err != nil {
+			t.Fatalf("Failed to create session: %v", err)
+		}
+		handler := NewAuthHandler(tx)
+		rec := httptest.NewRecorder()
+		handler.Refresh(rec, newRefreshTokenRequest(ctx, "/auth/refresh", session.RefreshToken))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d", http.StatusOK, rec.Code)
+		}
+
+		// When: 이전 refresh token 재사용
+		rec = httptest.NewRecorder()
+		handler.Refresh(rec, newRefreshTokenRequest(ctx, "/auth/refresh", session.RefreshToken))
+
+		// Then: 401, 세션 로그아웃
+		if rec.Code != http.StatusUnauthorized {
+			t.Errorf("Expected status %d, got %d", http.StatusUnauthorized, rec.Code)
+		}
+		if sessions, _ := database.ListActiveSessions(ctx, tx, user.ID); len(sessions) != 0 {
+			t.Errorf("Expected session to be revoked, got %+v", sessions)
+		}
+	})
+
+	t.Run("트랜잭션 밖에서도 재사용 감지 후 세션 로그아웃 유지", func(t *testing.T) {
+		ctx := context.Background()
+
+		// Given: 커밋된 사용자와 한 번 갱신한 세션 (*sql.DB 사용)
+		user := &models.User{Email: fmt.Sprintf("reuse-committed-%d@example.com", time.Now().UnixNano()), Name: "Reuse"}
+		if err := database.CreateUser(ctx, db, user); err != nil {
+			t.Fatalf("Failed to create user: %v", err)
+		}
+		defer db.ExecContext(ctx, "DELETE FROM users WHERE id = $1", user.ID)
+		session := &models.Session{UserID: user.ID, ExpiresAt: time.Now().Add(time.Hour)}
+		if err := database.CreateSession(ctx, db, session); err != nil {
+			t.Fatalf("Failed to create session: %v", err)
+		}
+		handler := NewAuthHandler(db)
+		rec := httptest.NewRecorder()
+		handler.Refresh(rec, newRefreshTokenRequest(ctx, "/auth/refresh", session.RefreshToken))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d", http.StatusOK, rec.Code)
+		}
+		var response AuthTokenResponse
+		if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+
+		// When: 이전 refresh token 재사용
+		rec = httptest.NewRecorder()
+		handler.Refresh(rec, newRefreshTokenRequest(ctx, "/auth/refresh", session.RefreshToken))
+
+		// Then: 401, 세션 폐기가 커밋되어 새 refresh token도 사용 불가
+		if rec.Code != http.StatusUnauthorized {
+			t.Errorf("Expected status %d, got %d", http.StatusUnauthorized, rec.Code)
+		}
+		if sessions, _ := database.ListActiveSessions(ctx, db, user.ID); len(sessions) != 0 {
+			t.Errorf("Expected session to be revoked, got %+v", sessions)
+		}
+		rec = httptest.NewRecorder()
+		handler.Refresh(rec, newRefreshTokenRequest(ctx, "/auth/refresh", response.RefreshToken))
+		if rec.Code != http.StatusUnauthorized {
+			t.Errorf("Expected status %d with rotated token after reuse, got %d", http.StatusUnauthorized, rec.Code)
+		}
+	})
+
+	t.Run("요청 검증", func(t *testing.T) {
+		handler := NewAuthHandler(db)
+
+		// refresh_token 누락은 400
+		rec := httptest.NewRecorder()
+		handler.Refresh(rec, newRefreshTokenRequest(context.Background(), "/auth/refresh", " "))
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("Expected status %d, got %d", http.StatusBadRequest, rec.Code)
+		}
+
+		// 알 수 없는 토큰으로 갱신하면 401, 로그아웃은 204
+		rec = httptest.NewRecorder()
+		handler.Refresh(rec, newRefreshTokenRequest(context.Background(), "/auth/refresh", models.GenerateRefreshToken()))
+		if rec.Code != http.StatusUnauthorized {
+			t.Errorf("Expected status %d, got %d", http.StatusUnauthorized, rec.Code)
+		}
+		rec = httptest.NewRecorder()
+		handler.Logout(rec, newRefreshTokenRequest(context.Background(), "/auth/logout", models.GenerateRefreshToken()))
+		if rec.Code != http.StatusNoContent {
+			t.Errorf("Expected status %d, got %d", http.StatusNoContent, rec.Code)
+		}
+	})
+}
+
+// TestJWKS는 JWT 공개 키 목록 응답을 테스트합니다
+func TestJWKS(t *testing.T) {
+	// Given: Ed25519 서명 키
+	keys := auth.DevelopmentSigningKeys()
+	auth.SetSigningKeys(keys)
+	defer auth.SetSigningKeys(nil)
+
+	// When
+	rec := httptest.NewRecorder()
+	NewAuthHandler(nil).JWKS(rec, httptest.NewRequest(http.MethodGet, "/.well-known/jwks.json", nil))
+
+	// Then: 활성 키의 공개 키 포함
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d", http.StatusOK, rec.Code)
+	}
+	var set auth.JSONWebKeySet
+	if err := json.NewDecoder(rec.Body).Decode(&set); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(set.Keys) != 1 || set.Keys[0].Kid != keys.ActiveKeyID() || set.Keys[0].Kty != "OKP" || set.Keys[0].Alg != "EdDSA" {
+		t.Errorf("Unexpected jwks: %+v", set)
+	}
+}
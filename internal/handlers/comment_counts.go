@@ -0,0 +1,123 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/june20516/orbithall/internal/database"
+	"github.com/june20516/orbithall/internal/models"
+	"github.com/june20516/orbithall/internal/validators"
+)
+
+// commentCountsCacheControl은 댓글 수 조회(GET) 응답의 캐시 정책입니다
+// 목록 페이지의 댓글 수는 약간 늦게 반영되어도 무방하므로 짧게 캐시합니다
+const commentCountsCacheControl = "public, max-age=60"
+
+// CommentCountsResponse는 댓글 수 일괄 조회 응답입니다
+// 요청한 모든 slug가 키로 포함되며, 댓글이 없는 포스트는 comment_count가 0입니다
+type CommentCountsResponse struct {
+	Counts map[string]models.PostCommentCount `json:"counts"`
+}
+
+// GetCommentCounts godoc
+// @Summary 댓글 수 일괄 조회
+// @Description 여러 포스트의 댓글 수와 마지막 활동 시각을 한 번에 조회합니다. 블로그 목록 페이지에서 글마다 댓글 수를 표시할 때 사용하며, 응답은 60초간 캐시할 수 있습니다. slug가 많아 URL이 길어지면 POST를 사용합니다.
+// @Tags comments
+// @Produce json
+// @Security ApiKeyAuth
+// @Param slugs query string true "쉼표로 구분한 포스트 slug 목록 (최대 100개)"
+// @Success 200 {object} CommentCountsResponse "댓글 수 조회 성공"
+// @Failure 400 {object} object{error=object{code=string,message=string,details=object}} "INVALID_INPUT - slug 누락 또는 개수 초과" example({"error":{"code":"INVALID_INPUT","message":"Validation failed","details":{"slugs":"At least one slug is required"}}})
+// @Failure 401 {object} object{error=object{code=string,message=string}} "MISSING_API_KEY - API 키 헤더 누락" example({"error":{"code":"MISSING_API_KEY","message":"API key is required"}})
+// @Failure 403 {object} object{error=object{code=string,message=string}} "INVALID_API_KEY | SITE_INACTIVE | INVALID_ORIGIN" example({"error":{"code":"INVALID_API_KEY","message":"Invalid API key"}})
+// @Failure 500 {object} object{error=object{code=string,message=string}} "INTERNAL_SERVER_ERROR - 서버 내부 오류" example({"error":{"code":"INTERNAL_SERVER_ERROR","message":"Internal server error"}})
+// @Router /api/posts/counts [get]
+func (h *CommentHandler) GetCommentCounts(w http.ResponseWriter, r *http.Request) {
+	var slugs []string
+	if raw := r.URL.Query().Get("slugs"); raw != "" {
+		slugs = strings.Split(raw, ",")
+	}
+
+	h.respondCommentCounts(w, r, validators.CommentCountsInput{Slugs: slugs}, true)
+}
+
+// BatchCommentCounts godoc
+// @Summary 댓글 수 일괄 조회 (POST)
+// @Description GET /api/posts/counts와 같지만 slug 목록을 요청 본문으로 전달합니다. URL 길이 제한을 피해야 할 때 사용합니다.
+// @Tags comments
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param request body validators.CommentCountsInput true "조회할 slug 목록 (최대 100개)"
+// @Success 200 {object} CommentCountsResponse "댓글 수 조회 성공"
+// @Failure 400 {object} object{error=object{code=string,message=string,details=object}} "INVALID_INPUT - 잘못된 요청 본문, slug 누락 또는 개수 초과" example({"error":{"code":"INVALID_INPUT","message":"Validation failed","details":{"slugs":"At most 100 slugs are allowed"}}})
+// @Failure 401 {object} object{error=object{code=string,message=string}} "MISSING_API_KEY - API 키 헤더 누락" example({"error":{"code":"MISSING_API_KEY","message":"API key is required"}})
+// @Failure 403 {object} object{error=object{code=string,message=string}} "INVALID_API_KEY | SITE_INACTIVE | INVALID_ORIGIN" example({"error":{"code":"INVALID_API_KEY","message":"Invalid API key"}})
+// @Failure 500 {object} object{error=object{code=string,message=string}} "INTERNAL_SERVER_ERROR - 서버 내부 오류" example({"error":{"code":"INTERNAL_SERVER_ERROR","message":"Internal server error"}})
+// @Router /api/posts/counts [post]
+func (h *CommentHandler) BatchCommentCounts(w http.ResponseWriter, r *http.Request) {
+	var input validators.CommentCountsInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		respondError(w, http.StatusBadRequest, ErrInvalidInput, "Invalid request body", nil)
+		return
+	}
+
+	h.respondCommentCounts(w, r, input, false)
+}
+
+// respondCommentCounts는 댓글 수 일괄 조회 핸들러의 공통 처리 로직입니다
+// cacheable이 true면 Cache-Control 헤더를 설정합니다 (GET 전용)
+func (h *CommentHandler) respondCommentCounts(w http.ResponseWriter, r *http.Request, input validators.CommentCountsInput, cacheable bool) {
+	// 1. Context에서 사이트 정보 추출
+	ctx := r.Context()
+	site := GetSiteFromContext(ctx)
+	if site == nil {
+		respondError(w, http.StatusUnauthorized, ErrMissingAPIKey, "Site not found in context", nil)
+		return
+	}
+
+	// 2. 입력 검증
+	if err := input.Validate(); err != nil {
+		if validationErrs, ok := err.(validators.ValidationErrors); ok {
+			respondError(w, http.StatusBadRequest, ErrInvalidInput, "Validation failed", validationErrs)
+			return
+		}
+		respondError(w, http.StatusBadRequest, ErrInvalidInput, err.Error(), nil)
+		return
+	}
+
+	// 3. slug 정리 (앞뒤 공백 제거, 중복 제거)
+	slugs := make([]string, 0, len(input.Slugs))
+	seen := make(map[string]bool, len(input.Slugs))
+	for _, slug := range input.Slugs {
+		slug = strings.TrimSpace(slug)
+		if seen[slug] {
+			continue
+		}
+		seen[slug] = true
+		slugs = append(slugs, slug)
+	}
+
+	// 4. 댓글 수 조회 (단일 쿼리)
+	counts, err := database.GetCommentCountsBySlugs(ctx, h.db, site.ID, slugs, site.TestMode)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, ErrInternalServer, "Failed to get comment counts", nil)
+		return
+	}
+
+	// 5. 포스트가 없는 slug는 0으로 채움
+	for _, slug := range slugs {
+		if _, ok := counts[slug]; !ok {
+			counts[slug] = models.PostCommentCount{}
+		}
+	}
+
+	// 6. 응답 (API 키와 Origin별로 캐시가 분리되도록 Vary 지정)
+	if cacheable {
+		w.Header().Set("Cache-Control", commentCountsCacheControl)
+		w.Header().Add("Vary", "Origin")
+		w.Header().Add("Vary", "X-Orbithall-API-Key")
+	}
+	respondJSON(w, http.StatusOK, CommentCountsResponse{Counts: counts})
+}
@@ -0,0 +1,128 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/june20516/orbithall/internal/database"
+	"github.com/june20516/orbithall/internal/testhelpers"
+)
+
+// ============================================
+// 댓글 수 일괄 조회 테스트
+// ============================================
+
+func TestGetCommentCounts(t *testing.T) {
+	db := testhelpers.SetupTestDB(t)
+	defer database.Close(db)
+
+	t.Run("GET - 요청한 모든 slug 반환 및 캐시 헤더 설정", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		// Given: 댓글 1개인 포스트
+		apiKey := testhelpers.CreateTestSite(ctx, t, tx, "Test Site", "counts.test.com", []string{"http://localhost:3000"}, true).APIKey
+		site, _ := database.GetSiteByAPIKey(ctx, tx, apiKey)
+		post := testhelpers.CreateTestPost(ctx, t, tx, site.ID, "first-post", "First Post")
+		if _, err := database.CreateComment(ctx, tx, post.ID, nil, "홍길동", "test1234", "댓글", "127.0.0.1", "test"); err != nil {
+			t.Fatalf("Failed to create comment: %v", err)
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/api/posts/counts?slugs=first-post,%20missing-post,first-post", nil)
+		req = req.WithContext(withSiteContext(req.Context(), site))
+		rec := httptest.NewRecorder()
+
+		// When: GetCommentCounts 호출
+		NewCommentHandler(tx).GetCommentCounts(rec, req)
+
+		// Then: 200 OK, 중복 제거, 없는 slug는 0
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, rec.Code, rec.Body.String())
+		}
+		if rec.Header().Get("Cache-Control") != commentCountsCacheControl {
+			t.Errorf("Expected Cache-Control %q, got %q", commentCountsCacheControl, rec.Header().Get("Cache-Control"))
+		}
+
+		var response CommentCountsResponse
+		if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+		if len(response.Counts) != 2 {
+			t.Fatalf("Expected 2 slugs, got %v", response.Counts)
+		}
+		if response.Counts["first-post"].CommentCount != 1 || response.Counts["first-post"].LastActivityAt == nil {
+			t.Errorf("Unexpected first-post count: %+v", response.Counts["first-post"])
+		}
+		if response.Counts["missing-post"].CommentCount != 0 || response.Counts["missing-post"].LastActivityAt != nil {
+			t.Errorf("Unexpected missing-post count: %+v", response.Counts["missing-post"])
+		}
+	})
+
+	t.Run("GET - slugs 누락 시 400", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		// Given: slugs 파라미터 없는 요청
+		apiKey := testhelpers.CreateTestSite(ctx, t, tx, "Test Site", "counts-empty.test.com", []string{"http://localhost:3000"}, true).APIKey
+		site, _ := database.GetSiteByAPIKey(ctx, tx, apiKey)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/posts/counts", nil)
+		req = req.WithContext(withSiteContext(req.Context(), site))
+		rec := httptest.NewRecorder()
+
+		// When: GetCommentCounts 호출
+		NewCommentHandler(tx).GetCommentCounts(rec, req)
+
+		// Then: 400 INVALID_INPUT
+		if rec.Code != http.StatusBadRequest {
+			t.Fatalf("Expected status %d, got %d", http.StatusBadRequest, rec.Code)
+		}
+		var response ErrorResponse
+		json.NewDecoder(rec.Body).Decode(&response)
+		if response.Error.Code != ErrInvalidInput {
+			t.Errorf("Expected error code %s, got %s", ErrInvalidInput, response.Error.Code)
+		}
+	})
+
+	t.Run("POST - 요청 본문의 slug 목록 조회", func(t *testing.T) {
+		ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+		defer cleanup()
+
+		// Given: 댓글이 있는 포스트
+		apiKey := testhelpers.CreateTestSite(ctx, t, tx, "Test Site", "counts-post.test.com", []string{"http://localhost:3000"}, true).APIKey
+		site, _ := database.GetSiteByAPIKey(ctx, tx, apiKey)
+		post := testhelpers.CreateTestPost(ctx, t, tx, site.ID, "batch-post", "Batch Post")
+		if _, err := database.CreateComment(ctx, tx, post.ID, nil, "홍길동", "test1234", "댓글", "127.0.0.1", "test"); err != nil {
+			t.Fatalf("Failed to create comment: %v", err)
+		}
+
+		bodyBytes, _ := json.Marshal(map[string][]string{"slugs": {"batch-post", "other-post"}})
+		req := httptest.NewRequest(http.MethodPost, "/api/posts/counts", bytes.NewBuffer(bodyBytes))
+		req.Header.Set("Content-Type", "application/json")
+		req = req.WithContext(withSiteContext(req.Context(), site))
+		rec := httptest.NewRecorder()
+
+		// When: BatchCommentCounts 호출
+		NewCommentHandler(tx).BatchCommentCounts(rec, req)
+
+		// Then: 200 OK, 캐시 헤더 없음
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, rec.Code, rec.Body.String())
+		}
+		if rec.Header().Get("Cache-Control") != "" {
+			t.Errorf("Expected no Cache-Control, got %q", rec.Header().Get("Cache-Control"))
+		}
+
+		var response CommentCountsResponse
+		json.NewDecoder(rec.Body).Decode(&response)
+		if response.Counts["batch-post"].CommentCount != 1 {
+			t.Errorf("Unexpected batch-post count: %+v", response.Counts["batch-post"])
+		}
+		if _, ok := response.Counts["other-post"]; !ok {
+			t.Error("Expected other-post in response")
+		}
+	})
+}
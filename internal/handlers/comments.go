@@ -3,11 +3,15 @@ package handlers
 import (
 	"encoding/json"
 	"errors"
+	"log"
 	"net/http"
+	"net/url"
+	"strings"
 	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/june20516/orbithall/internal/database"
+	"github.com/june20516/orbithall/internal/httputil"
 	"github.com/june20516/orbithall/internal/models"
 	"github.com/june20516/orbithall/internal/sanitizer"
 	"github.com/june20516/orbithall/internal/validators"
@@ -43,15 +47,15 @@ func NewCommentHandler(db database.DBTX) *CommentHandler {
 // 댓글 전용 헬퍼 함수
 // ============================================
 
-// filterDeletedCommentsAndMaskIP는 삭제된 댓글을 필터링하고 모든 댓글의 IP를 마스킹합니다
+// filterDeletedComments는 삭제된 댓글을 필터링합니다
 //
 // 삭제된 댓글의 필터링 규칙 (Soft Delete 방식):
 //   - 대댓글이 있는 삭제된 댓글: 계층 구조 유지를 위해 응답에 포함
 //     (author_name과 content는 빈 문자열, isDeleted=true로 클라이언트가 판단)
 //   - 대댓글이 없는 삭제된 댓글: 응답 배열에서 완전히 제거
 //
-// IP 마스킹: 모든 댓글의 IP 주소를 부분 마스킹 (예: 192.168.***.***)
-func filterDeletedCommentsAndMaskIP(comments []*models.Comment) []*models.Comment {
+// IP 주소는 저장 시점에 마스킹된 값(예: 192.168.***.***)만 조회되므로 별도 처리가 필요 없습니다
+func filterDeletedComments(comments []*models.Comment) []*models.Comment {
 	filtered := make([]*models.Comment, 0, len(comments))
 
 	for _, comment := range comments {
@@ -70,25 +74,11 @@ func filterDeletedCommentsAndMaskIP(comments []*models.Comment) []*models.Commen
 				// 삭제된 댓글의 내용은 비움 (클라이언트가 isDeleted 플래그로 판단)
 				comment.AuthorName = ""
 				comment.Content = ""
-				comment.IPAddressMasked = models.MaskIPAddress(comment.IPAddress)
-
-				// 대댓글들의 IP 마스킹
-				for j := range comment.Replies {
-					comment.Replies[j].IPAddressMasked = models.MaskIPAddress(comment.Replies[j].IPAddress)
-				}
 
 				filtered = append(filtered, comment)
 			}
 			// 활성 대댓글이 없는 삭제된 댓글은 배열에서 완전히 제거
 		} else {
-			// 삭제되지 않은 댓글: IP 마스킹만 수행
-			comment.IPAddressMasked = models.MaskIPAddress(comment.IPAddress)
-
-			// 대댓글들의 IP 마스킹
-			for j := range comment.Replies {
-				comment.Replies[j].IPAddressMasked = models.MaskIPAddress(comment.Replies[j].IPAddress)
-			}
-
 			filtered = append(filtered, comment)
 		}
 	}
@@ -96,13 +86,36 @@ func filterDeletedCommentsAndMaskIP(comments []*models.Comment) []*models.Commen
 	return filtered
 }
 
+// isSiteURL은 URL이 사이트에 속하는지 확인합니다
+// 호스트가 사이트 도메인 또는 CORS 허용 오리진의 호스트와 일치해야 합니다
+// 방문자가 다른 사이트의 URL을 포스트 URL로 등록하지 못하도록 사용합니다
+func isSiteURL(site *models.Site, rawURL string) bool {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return false
+	}
+
+	if strings.EqualFold(u.Hostname(), site.Domain) {
+		return true
+	}
+
+	for _, origin := range site.CORSOrigins {
+		o, err := url.Parse(origin)
+		if err == nil && o.Host != "" && strings.EqualFold(u.Host, o.Host) {
+			return true
+		}
+	}
+
+	return false
+}
+
 // ============================================
 // HTTP 핸들러 메서드
 // ============================================
 
 // CreateComment godoc
 // @Summary 댓글 생성
-// @Description 특정 포스트에 새로운 댓글을 생성합니다. 대댓글(parent_id 지정)도 가능하지만 2-depth 이상은 허용되지 않습니다.
+// @Description 특정 포스트에 새로운 댓글을 생성합니다. 대댓글(parent_id 지정)도 가능하지만 2-depth 이상은 허용되지 않습니다. post_title과 post_url은 포스트에 아직 제목(slug와 같은 자동 제목)이나 URL이 없을 때만 저장되며(선착순), post_url은 사이트 도메인 또는 CORS 허용 오리진에 속해야 저장됩니다.
 // @Tags comments
 // @Accept json
 // @Produce json
@@ -112,7 +125,7 @@ func filterDeletedCommentsAndMaskIP(comments []*models.Comment) []*models.Commen
 // @Success 201 {object} models.Comment "댓글 생성 성공"
 // @Failure 400 {object} object{error=object{code=string,message=string,details=object}} "INVALID_INPUT - slug 누락, 잘못된 입력, 검증 실패, 2-depth 초과" example({"error":{"code":"INVALID_INPUT","message":"Validation failed","details":{}}})
 // @Failure 401 {object} object{error=object{code=string,message=string}} "MISSING_API_KEY - API 키 헤더 누락" example({"error":{"code":"MISSING_API_KEY","message":"API key is required"}})
-// @Failure 403 {object} object{error=object{code=string,message=string}} "INVALID_API_KEY | SITE_INACTIVE | INVALID_ORIGIN" example({"error":{"code":"INVALID_API_KEY","message":"Invalid API key"}})
+// @Failure 403 {object} object{error=object{code=string,message=string}} "INVALID_API_KEY | SITE_INACTIVE | INVALID_ORIGIN | POST_LOCKED - 잠겼거나 자동 마감된 포스트" example({"error":{"code":"POST_LOCKED","message":"Comments are closed for this post"}})
 // @Failure 404 {object} object{error=object{code=string,message=string}} "COMMENT_NOT_FOUND - 부모 댓글을 찾을 수 없음" example({"error":{"code":"COMMENT_NOT_FOUND","message":"Parent comment not found"}})
 // @Failure 500 {object} object{error=object{code=string,message=string}} "INTERNAL_SERVER_ERROR - 서버 내부 오류" example({"error":{"code":"INTERNAL_SERVER_ERROR","message":"Internal server error"}})
 // @Router /api/posts/{slug}/comments [post]
@@ -155,25 +168,49 @@ func (h *CommentHandler) CreateComment(w http.ResponseWriter, r *http.Request) {
 	input.Content = sanitizer.SanitizeComment(input.Content)
 	input.AuthorName = sanitizer.SanitizeComment(input.AuthorName)
 
-	// 6. 포스트 가져오기 또는 생성 (slug를 title로도 사용)
-	post, err := database.GetOrCreatePost(ctx, h.db, site.ID, slug, slug)
+	// 6. 포스트 가져오기 또는 생성 (제목이 없으면 slug를 title로 사용)
+	postTitle := strings.TrimSpace(sanitizer.SanitizeComment(input.PostTitle))
+	title := postTitle
+	if title == "" {
+		title = slug
+	}
+
+	post, err := database.GetOrCreatePost(ctx, h.db, site.ID, slug, title, site.TestMode)
 	if err != nil {
 		respondError(w, http.StatusInternalServerError, ErrInternalServer, "Failed to get or create post", nil)
 		return
 	}
 
-	// 7. parent_id가 있으면 int64로 변환
+	// 7. 댓글 마감 여부 확인 (잠금 또는 사이트의 자동 마감 기간 경과)
+	if post.IsClosed(site.AutoCloseDays, time.Now()) {
+		respondError(w, http.StatusForbidden, ErrPostLocked, "Comments are closed for this post", nil)
+		return
+	}
+
+	// 8. 포스트 메타데이터 채우기 (선착순: 비어있는 값만 채우며, 사이트에 속한 URL만 저장)
+	postURL := ""
+	if input.PostURL != "" && isSiteURL(site, input.PostURL) {
+		postURL = input.PostURL
+	}
+	if (postTitle != "" && post.Title == post.Slug) || (postURL != "" && post.URL == "") {
+		if _, err := database.FillPostMetadata(ctx, h.db, post.ID, postTitle, postURL); err != nil {
+			// 메타데이터는 부가 정보이므로 실패해도 댓글 작성은 계속 진행
+			log.Printf("[WARN] Failed to fill post metadata (post_id=%d): %v", post.ID, err)
+		}
+	}
+
+	// 9. parent_id가 있으면 int64로 변환
 	var parentID *int64
 	if input.ParentID != nil {
 		pid := int64(*input.ParentID)
 		parentID = &pid
 	}
 
-	// 8. IP 주소 및 User-Agent 추출
-	ipAddress := GetIPAddress(r)
-	userAgent := GetUserAgent(r)
+	// 10. IP 주소 및 User-Agent 추출
+	ipAddress := httputil.GetIPAddress(r)
+	userAgent := httputil.GetUserAgent(r)
 
-	// 9. 댓글 생성 (database.CreateComment가 2-depth 검증 및 비밀번호 해싱 처리)
+	// 11. 댓글 생성 (database.CreateComment가 2-depth 검증, 비밀번호 해싱, 댓글 수 증가를 하나의 트랜잭션으로 처리)
 	comment, err := database.CreateComment(ctx, h.db, post.ID, parentID, input.AuthorName, input.Password, input.Content, ipAddress, userAgent)
 	if err != nil {
 		// Sentinel errors를 사용한 에러 타입 확인
@@ -189,16 +226,7 @@ func (h *CommentHandler) CreateComment(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// 10. 댓글 카운트 증가
-	if err := database.IncrementCommentCount(ctx, h.db, post.ID); err != nil {
-		// 카운트 증가 실패는 로깅만 하고 계속 진행 (댓글은 이미 생성됨)
-		// TODO: 로깅 추가
-	}
-
-	// 11. IP 주소 마스킹
-	comment.IPAddressMasked = models.MaskIPAddress(comment.IPAddress)
-
-	// 12. 201 Created 응답 (비밀번호 해시 제외)
+	// 12. 201 Created 응답 (비밀번호 해시 제외, IP 주소는 저장 시 마스킹된 값)
 	response := map[string]interface{}{
 		"id":                comment.ID,
 		"post_id":           comment.PostID,
@@ -217,7 +245,7 @@ func (h *CommentHandler) CreateComment(w http.ResponseWriter, r *http.Request) {
 
 // ListComments godoc
 // @Summary 댓글 목록 조회
-// @Description 특정 포스트의 댓글 목록을 페이지네이션과 함께 조회합니다. 삭제된 댓글 중 대댓글이 있는 경우 계층 구조 유지를 위해 빈 내용으로 포함됩니다.
+// @Description 특정 포스트의 댓글 목록을 페이지네이션과 함께 조회합니다. 삭제된 댓글 중 대댓글이 있는 경우 계층 구조 유지를 위해 빈 내용으로 포함됩니다. is_locked가 true면 잠금 또는 자동 마감으로 새 댓글을 작성할 수 없습니다.
 // @Tags comments
 // @Accept json
 // @Produce json
@@ -225,7 +253,7 @@ func (h *CommentHandler) CreateComment(w http.ResponseWriter, r *http.Request) {
 // @Param slug path string true "Post Slug"
 // @Param page query int false "페이지 번호 (기본값: 1)"
 // @Param limit query int false "페이지당 댓글 수 (기본값: 50, 최대: 100)"
-// @Success 200 {object} object{comments=[]models.Comment,pagination=object{current_page=int,total_pages=int,total_comments=int,per_page=int}} "댓글 목록 조회 성공"
+// @Success 200 {object} object{comments=[]models.Comment,is_locked=bool,pagination=object{current_page=int,total_pages=int,total_comments=int,per_page=int}} "댓글 목록 조회 성공"
 // @Failure 400 {object} object{error=object{code=string,message=string}} "INVALID_INPUT - slug 누락" example({"error":{"code":"INVALID_INPUT","message":"Post slug is required"}})
 // @Failure 401 {object} object{error=object{code=string,message=string}} "MISSING_API_KEY - API 키 헤더 누락" example({"error":{"code":"MISSING_API_KEY","message":"API key is required"}})
 // @Failure 403 {object} object{error=object{code=string,message=string}} "INVALID_API_KEY | SITE_INACTIVE | INVALID_ORIGIN" example({"error":{"code":"INVALID_API_KEY","message":"Invalid API key"}})
@@ -260,16 +288,17 @@ func (h *CommentHandler) ListComments(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 4. 포스트 조회 (없으면 빈 배열 반환)
-	post, err := database.GetPostBySlug(ctx, h.db, site.ID, slug)
+	post, err := database.GetPostBySlug(ctx, h.db, site.ID, slug, site.TestMode)
 	if err != nil {
 		respondError(w, http.StatusInternalServerError, ErrInternalServer, "Failed to get post", nil)
 		return
 	}
 
-	// 포스트가 없으면 빈 배열 반환
+	// 포스트가 없으면 빈 배열 반환 (첫 댓글 작성 시 생성되므로 잠기지 않은 상태)
 	if post == nil {
 		respondJSON(w, http.StatusOK, map[string]interface{}{
-			"comments": []models.Comment{},
+			"comments":  []models.Comment{},
+			"is_locked": false,
 			"pagination": map[string]interface{}{
 				"current_page":   page,
 				"total_pages":    0,
@@ -290,16 +319,17 @@ func (h *CommentHandler) ListComments(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// 7. 삭제된 댓글 필터링 및 IP 마스킹
+	// 7. 삭제된 댓글 필터링
 	// (대댓글 있으면 빈 값으로 포함, 없으면 제거)
-	comments = filterDeletedCommentsAndMaskIP(comments)
+	comments = filterDeletedComments(comments)
 
 	// 8. 페이지네이션 계산
 	totalPages := (totalCount + limit - 1) / limit
 
-	// 9. 응답
+	// 9. 응답 (is_locked: 잠금 또는 자동 마감으로 댓글 작성 불가 여부)
 	respondJSON(w, http.StatusOK, map[string]interface{}{
-		"comments": comments,
+		"comments":  comments,
+		"is_locked": post.IsClosed(site.AutoCloseDays, time.Now()),
 		"pagination": map[string]interface{}{
 			"current_page":   page,
 			"total_pages":    totalPages,
@@ -382,8 +412,8 @@ func (h *CommentHandler) UpdateComment(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// 8. 사이트 격리 확인
-	if post.SiteID != site.ID {
+	// 8. 사이트 격리 확인 (라이브 키와 테스트 모드 키는 서로의 댓글에 접근 불가)
+	if post.SiteID != site.ID || post.IsSandbox != site.TestMode {
 		respondError(w, http.StatusForbidden, ErrCommentNotFound, "Comment not found", nil)
 		return
 	}
@@ -401,8 +431,8 @@ func (h *CommentHandler) UpdateComment(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 11. IP 주소 및 User-Agent 추출 (수정 시점의 값으로 업데이트)
-	ipAddress := GetIPAddress(r)
-	userAgent := GetUserAgent(r)
+	ipAddress := httputil.GetIPAddress(r)
+	userAgent := httputil.GetUserAgent(r)
 
 	// 12. 댓글 수정
 	if err := database.UpdateComment(ctx, h.db, commentID, input.Content, ipAddress, userAgent); err != nil {
@@ -417,10 +447,7 @@ func (h *CommentHandler) UpdateComment(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// 14. IP 주소 마스킹
-	updatedComment.IPAddressMasked = models.MaskIPAddress(updatedComment.IPAddress)
-
-	// 15. 200 OK 응답 (비밀번호 해시 제외)
+	// 14. 200 OK 응답 (비밀번호 해시 제외, IP 주소는 저장 시 마스킹된 값)
 	response := map[string]interface{}{
 		"id":                updatedComment.ID,
 		"post_id":           updatedComment.PostID,
@@ -503,8 +530,8 @@ func (h *CommentHandler) DeleteComment(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// 7. 사이트 격리 확인
-	if post.SiteID != site.ID {
+	// 7. 사이트 격리 확인 (라이브 키와 테스트 모드 키는 서로의 댓글에 접근 불가)
+	if post.SiteID != site.ID || post.IsSandbox != site.TestMode {
 		respondError(w, http.StatusForbidden, ErrCommentNotFound, "Comment not found", nil)
 		return
 	}
@@ -521,8 +548,13 @@ func (h *CommentHandler) DeleteComment(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// 10. 댓글 삭제 (soft delete)
+	// 10. 댓글 삭제 (soft delete, 댓글 수 감소 포함)
 	if err := database.DeleteComment(ctx, h.db, commentID); err != nil {
+		// 조회 이후 다른 요청이 먼저 삭제한 경우
+		if errors.Is(err, database.ErrCommentNotFound) {
+			respondError(w, http.StatusNotFound, ErrCommentNotFound, "Comment not found", nil)
+			return
+		}
 		respondError(w, http.StatusInternalServerError, ErrInternalServer, "Failed to delete comment", nil)
 		return
 	}
@@ -99,6 +99,194 @@ func TestCreateComment_Success_TopLevel(t *testing.T) {
 	}
 }
 
+func TestCreateComment_PostMetadata_FirstWriteWins(t *testing.T) {
+	db := testhelpers.SetupTestDB(t)
+	defer database.Close(db)
+
+	ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+	defer cleanup()
+
+	// Given: 활성 사이트
+	apiKey := testhelpers.CreateTestSite(ctx, t, tx, "Test Site", "meta.test.com", []string{"http://localhost:3000"}, true).APIKey
+	site, _ := database.GetSiteByAPIKey(ctx, tx, apiKey)
+	handler := NewCommentHandler(tx)
+
+	createWithMetadata := func(title, postURL string) {
+		requestBody := map[string]interface{}{
+			"author_name": "홍길동",
+			"password":    "test1234",
+			"content":     "메타데이터 테스트",
+			"post_title":  title,
+			"post_url":    postURL,
+		}
+		bodyBytes, _ := json.Marshal(requestBody)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/posts/meta-post/comments", bytes.NewBuffer(bodyBytes))
+		req.Header.Set("Content-Type", "application/json")
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("slug", "meta-post")
+		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+		req = req.WithContext(withSiteContext(req.Context(), site))
+
+		rec := httptest.NewRecorder()
+		handler.CreateComment(rec, req)
+		if rec.Code != http.StatusCreated {
+			t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusCreated, rec.Code, rec.Body.String())
+		}
+	}
+
+	// When: 첫 댓글이 제목과 URL을 전달하고, 두 번째 댓글이 다른 값을 전달
+	createWithMetadata("메타데이터 포스트", "https://meta.test.com/posts/meta-post")
+	createWithMetadata("Vandalized", "https://meta.test.com/elsewhere")
+
+	// Then: 처음 전달된 값이 유지됨
+	post, err := database.GetPostBySlug(ctx, tx, site.ID, "meta-post", false)
+	if err != nil || post == nil {
+		t.Fatalf("Expected post to exist (err=%v)", err)
+	}
+	if post.Title != "메타데이터 포스트" {
+		t.Errorf("Expected title '메타데이터 포스트', got %q", post.Title)
+	}
+	if post.URL != "https://meta.test.com/posts/meta-post" {
+		t.Errorf("Expected first URL to be kept, got %q", post.URL)
+	}
+}
+
+func TestCreateComment_PostMetadata_IgnoresForeignURL(t *testing.T) {
+	db := testhelpers.SetupTestDB(t)
+	defer database.Close(db)
+
+	ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+	defer cleanup()
+
+	// Given: 활성 사이트
+	apiKey := testhelpers.CreateTestSite(ctx, t, tx, "Test Site", "foreign.test.com", []string{"http://localhost:3000"}, true).APIKey
+	site, _ := database.GetSiteByAPIKey(ctx, tx, apiKey)
+
+	requestBody := map[string]interface{}{
+		"author_name": "홍길동",
+		"password":    "test1234",
+		"content":     "외부 URL 테스트",
+		"post_url":    "https://evil.example.com/phishing",
+	}
+	bodyBytes, _ := json.Marshal(requestBody)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/posts/foreign-post/comments", bytes.NewBuffer(bodyBytes))
+	req.Header.Set("Content-Type", "application/json")
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("slug", "foreign-post")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	req = req.WithContext(withSiteContext(req.Context(), site))
+
+	rec := httptest.NewRecorder()
+
+	// When: 사이트에 속하지 않은 URL로 댓글 작성
+	NewCommentHandler(tx).CreateComment(rec, req)
+
+	// Then: 댓글은 생성되지만 URL은 저장되지 않음, 제목은 slug
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusCreated, rec.Code, rec.Body.String())
+	}
+	post, _ := database.GetPostBySlug(ctx, tx, site.ID, "foreign-post", false)
+	if post == nil {
+		t.Fatal("Expected post to exist")
+	}
+	if post.URL != "" {
+		t.Errorf("Expected foreign URL to be ignored, got %q", post.URL)
+	}
+	if post.Title != "foreign-post" {
+		t.Errorf("Expected slug as title, got %q", post.Title)
+	}
+}
+
+func TestCreateComment_TestModeKey_Sandbox(t *testing.T) {
+	db := testhelpers.SetupTestDB(t)
+	defer database.Close(db)
+
+	ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+	defer cleanup()
+
+	// Given: 라이브 키와 테스트 모드 키가 있는 사이트
+	liveSite := testhelpers.CreateTestSite(ctx, t, tx, "Test Site", "sandbox.test.com", []string{"http://localhost:3000"}, true)
+	testKey := testhelpers.CreateTestAPIKey(ctx, t, tx, liveSite.ID, "sandbox", true)
+	defer database.InvalidateAPIKey(testKey)
+	testSite, _ := database.GetSiteByAPIKey(ctx, tx, testKey)
+	handler := NewCommentHandler(tx)
+
+	newRequest := func(method string, site *models.Site, body []byte) *http.Request {
+		req := httptest.NewRequest(method, "/api/posts/sandbox-post/comments", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("slug", "sandbox-post")
+		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+		return req.WithContext(withSiteContext(req.Context(), site))
+	}
+
+	// When: 테스트 모드 키로 댓글 작성
+	bodyBytes, _ := json.Marshal(map[string]interface{}{
+		"author_name": "테스터",
+		"password":    "test1234",
+		"content":     "샌드박스 댓글",
+	})
+	rec := httptest.NewRecorder()
+	handler.CreateComment(rec, newRequest(http.MethodPost, testSite, bodyBytes))
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusCreated, rec.Code, rec.Body.String())
+	}
+
+	// Then: 샌드박스 포스트에 저장되고, 라이브 키로는 보이지 않음
+	sandboxPost, _ := database.GetPostBySlug(ctx, tx, liveSite.ID, "sandbox-post", true)
+	if sandboxPost == nil || sandboxPost.CommentCount != 1 {
+		t.Fatalf("Expected sandbox post with 1 comment, got %+v", sandboxPost)
+	}
+	if livePost, _ := database.GetPostBySlug(ctx, tx, liveSite.ID, "sandbox-post", false); livePost != nil {
+		t.Errorf("Expected no live post, got %+v", livePost)
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ListComments(rec, newRequest(http.MethodGet, &liveSite, nil))
+	var response struct {
+		Comments []interface{} `json:"comments"`
+	}
+	json.NewDecoder(rec.Body).Decode(&response)
+	if len(response.Comments) != 0 {
+		t.Errorf("Expected live key to see 0 comments, got %d", len(response.Comments))
+	}
+}
+
+func TestIsSiteURL(t *testing.T) {
+	site := &models.Site{
+		Domain:      "blog.example.com",
+		CORSOrigins: []string{"https://blog.example.com", "http://localhost:3000"},
+	}
+
+	tests := []struct {
+		name     string
+		url      string
+		expected bool
+	}{
+		{"사이트 도메인", "https://blog.example.com/posts/hello", true},
+		{"도메인 대소문자 무시", "https://BLOG.example.com/posts/hello", true},
+		{"CORS 오리진 (포트 포함)", "http://localhost:3000/posts/hello", true},
+		{"포트가 다른 오리진", "http://localhost:4000/posts/hello", false},
+		{"다른 도메인", "https://evil.example.com/posts/hello", false},
+		{"하위 도메인 사칭", "https://blog.example.com.evil.com/", false},
+		{"호스트 없음", "/posts/hello", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// When: URL 확인
+			result := isSiteURL(site, tt.url)
+
+			// Then: 기대값과 일치
+			if result != tt.expected {
+				t.Errorf("isSiteURL(%q) = %v, want %v", tt.url, result, tt.expected)
+			}
+		})
+	}
+}
+
 func TestCreateComment_Success_Reply(t *testing.T) {
 	db := testhelpers.SetupTestDB(t)
 	defer database.Close(db)
@@ -111,7 +299,7 @@ func TestCreateComment_Success_Reply(t *testing.T) {
 	site, _ := database.GetSiteByAPIKey(ctx, tx, apiKey)
 
 	// 부모 댓글 생성 (포스트 자동 생성됨)
-	post, _ := database.GetOrCreatePost(ctx, tx, site.ID, "test-post", "Test Post")
+	post, _ := database.GetOrCreatePost(ctx, tx, site.ID, "test-post", "Test Post", false)
 	parentComment, _ := database.CreateComment(ctx, tx, post.ID, nil, "Parent Author", "password123", "Parent comment", "127.0.0.1", "Test Agent")
 
 	handler := NewCommentHandler(tx)
@@ -166,7 +354,7 @@ func TestCreateComment_Fail_2DepthReply(t *testing.T) {
 	apiKey := testhelpers.CreateTestSite(ctx, t, tx, "Test Site", "2depth.test.com", []string{"http://localhost:3000"}, true).APIKey
 	site, _ := database.GetSiteByAPIKey(ctx, tx, apiKey)
 
-	post, _ := database.GetOrCreatePost(ctx, tx, site.ID, "test-post", "Test Post")
+	post, _ := database.GetOrCreatePost(ctx, tx, site.ID, "test-post", "Test Post", false)
 	parentComment, _ := database.CreateComment(ctx, tx, post.ID, nil, "Parent", "pass123", "Parent", "127.0.0.1", "Agent")
 	childComment, _ := database.CreateComment(ctx, tx, post.ID, &parentComment.ID, "Child", "pass123", "Child", "127.0.0.1", "Agent")
 
@@ -367,7 +555,7 @@ func TestListComments_Success_TreeStructure(t *testing.T) {
 	// Given: 사이트, 포스트, 댓글 계층 구조 생성
 	apiKey := testhelpers.CreateTestSite(ctx, t, tx, "Test Site", "list.test.com", []string{"http://localhost:3000"}, true).APIKey
 	site, _ := database.GetSiteByAPIKey(ctx, tx, apiKey)
-	post, _ := database.GetOrCreatePost(ctx, tx, site.ID, "test-post", "Test Post")
+	post, _ := database.GetOrCreatePost(ctx, tx, site.ID, "test-post", "Test Post", false)
 
 	// 최상위 댓글 2개
 	parent1, _ := database.CreateComment(ctx, tx, post.ID, nil, "Parent1", "pass123", "첫 번째 댓글", "127.0.0.1", "Agent")
@@ -463,7 +651,7 @@ func TestListComments_Success_Pagination(t *testing.T) {
 	// Given: 사이트, 포스트, 최상위 댓글 3개 생성
 	apiKey := testhelpers.CreateTestSite(ctx, t, tx, "Test Site", "pagination.test.com", []string{"http://localhost:3000"}, true).APIKey
 	site, _ := database.GetSiteByAPIKey(ctx, tx, apiKey)
-	post, _ := database.GetOrCreatePost(ctx, tx, site.ID, "test-post", "Test Post")
+	post, _ := database.GetOrCreatePost(ctx, tx, site.ID, "test-post", "Test Post", false)
 
 	for i := 1; i <= 3; i++ {
 		database.CreateComment(ctx, tx, post.ID, nil, "Author", "pass123", "댓글 내용", "127.0.0.1", "Agent")
@@ -567,6 +755,129 @@ func TestListComments_Success_EmptyPost(t *testing.T) {
 	}
 }
 
+func TestCreateComment_Fail_PostLocked(t *testing.T) {
+	db := testhelpers.SetupTestDB(t)
+	defer database.Close(db)
+
+	tests := []struct {
+		name  string
+		setup func(t *testing.T, ctx context.Context, tx testhelpers.DBTX, siteID, postID int64)
+	}{
+		{
+			name: "관리자가 잠근 포스트",
+			setup: func(t *testing.T, ctx context.Context, tx testhelpers.DBTX, siteID, postID int64) {
+				if err := database.SetPostLocked(ctx, tx, postID, true); err != nil {
+					t.Fatalf("Failed to lock post: %v", err)
+				}
+			},
+		},
+		{
+			name: "자동 마감 기간이 지난 포스트",
+			setup: func(t *testing.T, ctx context.Context, tx testhelpers.DBTX, siteID, postID int64) {
+				if err := database.UpdateSiteAutoCloseDays(ctx, tx, siteID, 30); err != nil {
+					t.Fatalf("Failed to update auto close days: %v", err)
+				}
+				if _, err := tx.ExecContext(ctx, "UPDATE posts SET created_at = NOW() - INTERVAL '31 days' WHERE id = $1", postID); err != nil {
+					t.Fatalf("Failed to age post: %v", err)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+			defer cleanup()
+
+			// Given: 마감된 포스트
+			siteID := testhelpers.CreateTestSite(ctx, t, tx, "Test Site", "locked.test.com", []string{"http://localhost:3000"}, true).ID
+			postID := testhelpers.CreateTestPost(ctx, t, tx, siteID, "locked-post", "Locked Post").ID
+			tt.setup(t, ctx, tx, siteID, postID)
+			site, err := database.GetSiteByID(ctx, tx, siteID)
+			if err != nil {
+				t.Fatalf("Failed to get site: %v", err)
+			}
+
+			requestBody := map[string]interface{}{
+				"author_name": "홍길동",
+				"password":    "test1234",
+				"content":     "마감된 포스트에 댓글",
+			}
+			bodyBytes, _ := json.Marshal(requestBody)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/posts/locked-post/comments", bytes.NewBuffer(bodyBytes))
+			req.Header.Set("Content-Type", "application/json")
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("slug", "locked-post")
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+			req = req.WithContext(withSiteContext(req.Context(), site))
+
+			rec := httptest.NewRecorder()
+
+			// When: CreateComment 호출
+			NewCommentHandler(tx).CreateComment(rec, req)
+
+			// Then: 403 POST_LOCKED
+			if rec.Code != http.StatusForbidden {
+				t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusForbidden, rec.Code, rec.Body.String())
+			}
+			var response ErrorResponse
+			json.NewDecoder(rec.Body).Decode(&response)
+			if response.Error.Code != ErrPostLocked {
+				t.Errorf("Expected error code %s, got %s", ErrPostLocked, response.Error.Code)
+			}
+		})
+	}
+}
+
+func TestListComments_LockedPost(t *testing.T) {
+	db := testhelpers.SetupTestDB(t)
+	defer database.Close(db)
+
+	ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+	defer cleanup()
+
+	// Given: 댓글이 있는 잠긴 포스트
+	apiKey := testhelpers.CreateTestSite(ctx, t, tx, "Test Site", "locked-list.test.com", []string{"http://localhost:3000"}, true).APIKey
+	site, _ := database.GetSiteByAPIKey(ctx, tx, apiKey)
+	postID := testhelpers.CreateTestPost(ctx, t, tx, site.ID, "locked-list", "Locked List").ID
+	if _, err := database.CreateComment(ctx, tx, postID, nil, "홍길동", "test1234", "잠기기 전 댓글", "127.0.0.1", "test"); err != nil {
+		t.Fatalf("Failed to create comment: %v", err)
+	}
+	if err := database.SetPostLocked(ctx, tx, postID, true); err != nil {
+		t.Fatalf("Failed to lock post: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/posts/locked-list/comments", nil)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("slug", "locked-list")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	req = req.WithContext(withSiteContext(req.Context(), site))
+
+	rec := httptest.NewRecorder()
+
+	// When: ListComments 호출
+	NewCommentHandler(tx).ListComments(rec, req)
+
+	// Then: 200 OK, 댓글과 잠금 상태 반환
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d", http.StatusOK, rec.Code)
+	}
+
+	var response struct {
+		Comments []interface{} `json:"comments"`
+		IsLocked bool          `json:"is_locked"`
+	}
+	json.NewDecoder(rec.Body).Decode(&response)
+
+	if len(response.Comments) != 1 {
+		t.Errorf("Expected 1 comment, got %d", len(response.Comments))
+	}
+	if !response.IsLocked {
+		t.Error("Expected is_locked true")
+	}
+}
+
 func TestListComments_DeletedComments(t *testing.T) {
 	db := testhelpers.SetupTestDB(t)
 	defer database.Close(db)
@@ -577,7 +888,7 @@ func TestListComments_DeletedComments(t *testing.T) {
 	// Given: 사이트, 포스트, 댓글 생성
 	apiKey := testhelpers.CreateTestSite(ctx, t, tx, "Test Site", "deleted.test.com", []string{"http://localhost:3000"}, true).APIKey
 	site, _ := database.GetSiteByAPIKey(ctx, tx, apiKey)
-	post, _ := database.GetOrCreatePost(ctx, tx, site.ID, "test-post", "Test Post")
+	post, _ := database.GetOrCreatePost(ctx, tx, site.ID, "test-post", "Test Post", false)
 
 	// 최상위 댓글 (대댓글 있음)
 	parent, _ := database.CreateComment(ctx, tx, post.ID, nil, "Parent", "pass123", "부모 댓글", "127.0.0.1", "Agent")
@@ -658,7 +969,7 @@ func TestUpdateComment_Success(t *testing.T) {
 	// Given: 사이트, 포스트, 댓글 생성
 	apiKey := testhelpers.CreateTestSite(ctx, t, tx, "Test Site", "update.test.com", []string{"http://localhost:3000"}, true).APIKey
 	site, _ := database.GetSiteByAPIKey(ctx, tx, apiKey)
-	post, _ := database.GetOrCreatePost(ctx, tx, site.ID, "test-post", "Test Post")
+	post, _ := database.GetOrCreatePost(ctx, tx, site.ID, "test-post", "Test Post", false)
 	comment, _ := database.CreateComment(ctx, tx, post.ID, nil, "Author", "password123", "Original content", "127.0.0.1", "Original Agent")
 
 	handler := NewCommentHandler(tx)
@@ -720,7 +1031,7 @@ func TestUpdateComment_Fail_WrongPassword(t *testing.T) {
 	// Given: 사이트, 포스트, 댓글 생성
 	apiKey := testhelpers.CreateTestSite(ctx, t, tx, "Test Site", "wrongpass.test.com", []string{"http://localhost:3000"}, true).APIKey
 	site, _ := database.GetSiteByAPIKey(ctx, tx, apiKey)
-	post, _ := database.GetOrCreatePost(ctx, tx, site.ID, "test-post", "Test Post")
+	post, _ := database.GetOrCreatePost(ctx, tx, site.ID, "test-post", "Test Post", false)
 	comment, _ := database.CreateComment(ctx, tx, post.ID, nil, "Author", "password123", "Content", "127.0.0.1", "Agent")
 
 	handler := NewCommentHandler(tx)
@@ -769,7 +1080,7 @@ func TestUpdateComment_Fail_EditTimeExpired(t *testing.T) {
 	// Given: 31분 전에 작성된 댓글
 	apiKey := testhelpers.CreateTestSite(ctx, t, tx, "Test Site", "expired.test.com", []string{"http://localhost:3000"}, true).APIKey
 	site, _ := database.GetSiteByAPIKey(ctx, tx, apiKey)
-	post, _ := database.GetOrCreatePost(ctx, tx, site.ID, "test-post", "Test Post")
+	post, _ := database.GetOrCreatePost(ctx, tx, site.ID, "test-post", "Test Post", false)
 	comment, _ := database.CreateComment(ctx, tx, post.ID, nil, "Author", "password123", "Content", "127.0.0.1", "Agent")
 
 	// created_at을 31분 전으로 변경 (직접 DB 조작)
@@ -866,7 +1177,7 @@ func TestUpdateComment_Fail_ValidationError(t *testing.T) {
 	// Given: 사이트, 포스트, 댓글 생성
 	apiKey := testhelpers.CreateTestSite(ctx, t, tx, "Test Site", "validation.test.com", []string{"http://localhost:3000"}, true).APIKey
 	site, _ := database.GetSiteByAPIKey(ctx, tx, apiKey)
-	post, _ := database.GetOrCreatePost(ctx, tx, site.ID, "test-post", "Test Post")
+	post, _ := database.GetOrCreatePost(ctx, tx, site.ID, "test-post", "Test Post", false)
 	comment, _ := database.CreateComment(ctx, tx, post.ID, nil, "Author", "password123", "Content", "127.0.0.1", "Agent")
 
 	handler := NewCommentHandler(tx)
@@ -915,7 +1226,7 @@ func TestUpdateComment_XSS_HTMLSanitization(t *testing.T) {
 	// Given: 사이트, 포스트, 댓글 생성
 	apiKey := testhelpers.CreateTestSite(ctx, t, tx, "Test Site", "xss-update.test.com", []string{"http://localhost:3000"}, true).APIKey
 	site, _ := database.GetSiteByAPIKey(ctx, tx, apiKey)
-	post, _ := database.GetOrCreatePost(ctx, tx, site.ID, "test-post", "Test Post")
+	post, _ := database.GetOrCreatePost(ctx, tx, site.ID, "test-post", "Test Post", false)
 	comment, _ := database.CreateComment(ctx, tx, post.ID, nil, "Author", "password123", "Content", "127.0.0.1", "Agent")
 
 	handler := NewCommentHandler(tx)
@@ -970,7 +1281,7 @@ func TestDeleteComment_Success(t *testing.T) {
 	// Given: 사이트, 포스트, 댓글 생성
 	apiKey := testhelpers.CreateTestSite(ctx, t, tx, "Test Site", "delete.test.com", []string{"http://localhost:3000"}, true).APIKey
 	site, _ := database.GetSiteByAPIKey(ctx, tx, apiKey)
-	post, _ := database.GetOrCreatePost(ctx, tx, site.ID, "test-post", "Test Post")
+	post, _ := database.GetOrCreatePost(ctx, tx, site.ID, "test-post", "Test Post", false)
 	comment, _ := database.CreateComment(ctx, tx, post.ID, nil, "Author", "password123", "Original content", "127.0.0.1", "Agent")
 
 	handler := NewCommentHandler(tx)
@@ -1021,7 +1332,7 @@ func TestDeleteComment_Fail_WrongPassword(t *testing.T) {
 	// Given: 사이트, 포스트, 댓글 생성
 	apiKey := testhelpers.CreateTestSite(ctx, t, tx, "Test Site", "delete.test.com", []string{"http://localhost:3000"}, true).APIKey
 	site, _ := database.GetSiteByAPIKey(ctx, tx, apiKey)
-	post, _ := database.GetOrCreatePost(ctx, tx, site.ID, "test-post", "Test Post")
+	post, _ := database.GetOrCreatePost(ctx, tx, site.ID, "test-post", "Test Post", false)
 	comment, _ := database.CreateComment(ctx, tx, post.ID, nil, "Author", "password123", "Content", "127.0.0.1", "Agent")
 
 	handler := NewCommentHandler(tx)
@@ -1068,7 +1379,7 @@ func TestDeleteComment_Fail_DeleteTimeExpired(t *testing.T) {
 	// Given: 31분 전에 생성된 댓글
 	apiKey := testhelpers.CreateTestSite(ctx, t, tx, "Test Site", "delete.test.com", []string{"http://localhost:3000"}, true).APIKey
 	site, _ := database.GetSiteByAPIKey(ctx, tx, apiKey)
-	post, _ := database.GetOrCreatePost(ctx, tx, site.ID, "test-post", "Test Post")
+	post, _ := database.GetOrCreatePost(ctx, tx, site.ID, "test-post", "Test Post", false)
 	comment, _ := database.CreateComment(ctx, tx, post.ID, nil, "Author", "password123", "Content", "127.0.0.1", "Agent")
 
 	// created_at을 31분 전으로 변경 (직접 DB 조작)
@@ -1164,7 +1475,7 @@ func TestDeleteComment_Fail_MissingPassword(t *testing.T) {
 	// Given: 사이트, 포스트, 댓글 생성
 	apiKey := testhelpers.CreateTestSite(ctx, t, tx, "Test Site", "delete.test.com", []string{"http://localhost:3000"}, true).APIKey
 	site, _ := database.GetSiteByAPIKey(ctx, tx, apiKey)
-	post, _ := database.GetOrCreatePost(ctx, tx, site.ID, "test-post", "Test Post")
+	post, _ := database.GetOrCreatePost(ctx, tx, site.ID, "test-post", "Test Post", false)
 	comment, _ := database.CreateComment(ctx, tx, post.ID, nil, "Author", "password123", "Content", "127.0.0.1", "Agent")
 
 	handler := NewCommentHandler(tx)
@@ -3,26 +3,12 @@ package handlers
 import (
 	"net/http"
 	"strconv"
-
-	"github.com/june20516/orbithall/internal/httputil"
 )
 
 // ============================================
 // HTTP 요청 관련 공통 헬퍼 함수
 // ============================================
 
-// GetIPAddress는 httputil.GetIPAddress를 호출합니다
-// 하위 호환성을 위해 유지되며, 새 코드는 httputil.GetIPAddress를 직접 사용하는 것을 권장합니다
-func GetIPAddress(r *http.Request) string {
-	return httputil.GetIPAddress(r)
-}
-
-// GetUserAgent는 httputil.GetUserAgent를 호출합니다
-// 하위 호환성을 위해 유지되며, 새 코드는 httputil.GetUserAgent를 직접 사용하는 것을 권장합니다
-func GetUserAgent(r *http.Request) string {
-	return httputil.GetUserAgent(r)
-}
-
 // ParseInt64Param은 문자열을 int64로 파싱합니다
 // URL 파라미터를 숫자로 변환할 때 사용합니다
 func ParseInt64Param(value string) (int64, error) {
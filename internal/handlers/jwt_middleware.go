@@ -14,6 +14,9 @@ import (
 // userContextKey는 Context에 사용자 정보를 저장할 때 사용하는 키입니다
 const userContextKey contextKey = "user"
 
+// sessionContextKey는 Context에 access token의 로그인 세션 ID를 저장할 때 사용하는 키입니다
+const sessionContextKey contextKey = "session"
+
 // JWTAuthMiddleware는 JWT 기반 인증 미들웨어입니다
 // Authorization 헤더에서 Bearer 토큰을 추출하고 검증합니다
 func JWTAuthMiddleware(db database.DBTX) func(http.Handler) http.Handler {
@@ -46,21 +49,22 @@ func JWTAuthMiddleware(db database.DBTX) func(http.Handler) http.Handler {
 				return
 			}
 
-			// 4. 사용자 조회
-			user, err := database.GetUserByID(r.Context(), db, claims.UserID)
+			// 4. 세션과 사용자 조회 (로그아웃되었거나 만료된 세션의 토큰은 거부)
+			user, err := database.GetActiveSessionUser(r.Context(), db, claims.SessionID, claims.UserID)
 			if err != nil {
 				respondWithError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to get user")
 				return
 			}
 
-			// 5. 사용자 존재 여부 확인
+			// 5. 세션과 사용자 존재 여부 확인
 			if user == nil {
-				respondWithError(w, http.StatusUnauthorized, "USER_NOT_FOUND", "User not found")
+				respondWithError(w, http.StatusUnauthorized, "SESSION_REVOKED", "Session has been logged out or expired")
 				return
 			}
 
-			// 6. Context에 사용자 정보 저장
+			// 6. Context에 사용자와 세션 정보 저장
 			ctx := SetUserInContext(r.Context(), user)
+			ctx = context.WithValue(ctx, sessionContextKey, claims.SessionID)
 
 			// 7. 다음 핸들러 호출
 			next.ServeHTTP(w, r.WithContext(ctx))
@@ -83,6 +87,13 @@ func GetUserFromContext(ctx context.Context) *models.User {
 	return user
 }
 
+// GetSessionIDFromContext는 Context에서 access token의 로그인 세션 ID를 추출합니다
+// 세션 정보가 없으면 0을 반환합니다
+func GetSessionIDFromContext(ctx context.Context) int64 {
+	sessionID, _ := ctx.Value(sessionContextKey).(int64)
+	return sessionID
+}
+
 // respondWithError는 에러 응답을 JSON 형식으로 반환합니다
 func respondWithError(w http.ResponseWriter, statusCode int, errorCode, message string) {
 	w.Header().Set("Content-Type", "application/json")
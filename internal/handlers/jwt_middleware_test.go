@@ -5,6 +5,7 @@ import (
 	"net/http/httptest"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/june20516/orbithall/internal/auth"
 	"github.com/june20516/orbithall/internal/database"
@@ -15,7 +16,6 @@ import (
 func init() {
 	// 테스트용 환경변수 설정
 	os.Setenv("JWT_SECRET", "test-secret-key-minimum-32-characters-long-12345")
-	os.Setenv("JWT_EXPIRATION_HOURS", "168")
 }
 
 // TestJWTAuthMiddleware_ValidToken은 유효한 JWT 토큰으로 인증이 성공하는지 테스트합니다
@@ -32,15 +32,20 @@ func TestJWTAuthMiddleware_ValidToken(t *testing.T) {
 		Email:      "test@example.com",
 		Name:       "Test User",
 		PictureURL: "https://example.com/picture.jpg",
-		GoogleID:   "google-test-id-123",
 	}
 	err := database.CreateUser(ctx, tx, testUser)
 	if err != nil {
 		t.Fatalf("Failed to create test user: %v", err)
 	}
 
+	// 로그인 세션 생성
+	session := &models.Session{UserID: testUser.ID, ExpiresAt: time.Now().Add(time.Hour)}
+	if err := database.CreateSession(ctx, tx, session); err != nil {
+		t.Fatalf("Failed to create session: %v", err)
+	}
+
 	// JWT 토큰 생성
-	token, err := auth.GenerateJWT(testUser.ID, testUser.Email)
+	token, err := auth.GenerateJWT(testUser.ID, testUser.Email, session.ID)
 	if err != nil {
 		t.Fatalf("Failed to generate JWT: %v", err)
 	}
@@ -62,6 +67,9 @@ func TestJWTAuthMiddleware_ValidToken(t *testing.T) {
 		if user.Email != testUser.Email {
 			t.Errorf("Expected email %s, got %s", testUser.Email, user.Email)
 		}
+		if sessionID := GetSessionIDFromContext(r.Context()); sessionID != session.ID {
+			t.Errorf("Expected session ID %d, got %d", session.ID, sessionID)
+		}
 
 		w.WriteHeader(http.StatusOK)
 		w.Write([]byte("success"))
@@ -190,7 +198,7 @@ func TestJWTAuthMiddleware_UserNotFound(t *testing.T) {
 
 	// 존재하지 않는 사용자 ID로 JWT 생성
 	nonExistentUserID := int64(999999)
-	token, err := auth.GenerateJWT(nonExistentUserID, "nonexistent@example.com")
+	token, err := auth.GenerateJWT(nonExistentUserID, "nonexistent@example.com", 1)
 	if err != nil {
 		t.Fatalf("Failed to generate JWT: %v", err)
 	}
@@ -219,3 +227,47 @@ func TestJWTAuthMiddleware_UserNotFound(t *testing.T) {
 		t.Errorf("Expected status %d, got %d", http.StatusUnauthorized, rr.Code)
 	}
 }
+
+// TestJWTAuthMiddleware_RevokedSession은 로그아웃된 세션의 토큰일 때 401을 반환하는지 테스트합니다
+func TestJWTAuthMiddleware_RevokedSession(t *testing.T) {
+	// DB 연결 및 트랜잭션 시작
+	db := testhelpers.SetupTestDB(t)
+	defer database.Close(db)
+
+	ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+	defer cleanup()
+
+	// Given: 로그아웃된 세션의 유효한 JWT
+	testUser := &models.User{Email: "revoked@example.com", Name: "Revoked User"}
+	if err := database.CreateUser(ctx, tx, testUser); err != nil {
+		t.Fatalf("Failed to create test user: %v", err)
+	}
+	session := &models.Session{UserID: testUser.ID, ExpiresAt: time.Now().Add(time.Hour)}
+	if err := database.CreateSession(ctx, tx, session); err != nil {
+		t.Fatalf("Failed to create session: %v", err)
+	}
+	token, err := auth.GenerateJWT(testUser.ID, testUser.Email, session.ID)
+	if err != nil {
+		t.Fatalf("Failed to generate JWT: %v", err)
+	}
+	if err := database.RevokeSession(ctx, tx, testUser.ID, session.ID); err != nil {
+		t.Fatalf("Failed to revoke session: %v", err)
+	}
+
+	// 다음 핸들러 (호출되면 안 됨)
+	nextHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("Next handler should not be called when session is revoked")
+		w.WriteHeader(http.StatusOK)
+	})
+
+	// When: 요청
+	req := httptest.NewRequest(http.MethodGet, "/admin/test", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	rr := httptest.NewRecorder()
+	JWTAuthMiddleware(tx)(nextHandler).ServeHTTP(rr, req)
+
+	// Then: 401
+	if rr.Code != http.StatusUnauthorized {
+		t.Errorf("Expected status %d, got %d", http.StatusUnauthorized, rr.Code)
+	}
+}
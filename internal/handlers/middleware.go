@@ -22,12 +22,17 @@ const (
 	ErrSiteInactive  = "SITE_INACTIVE"   // 비활성화된 사이트
 	ErrInvalidOrigin = "INVALID_ORIGIN"  // CORS Origin 불일치
 
+	// 비밀 키 관련 에러
+	ErrSecretKeyInBrowser = "SECRET_KEY_IN_BROWSER" // 비밀 키를 브라우저(Origin 헤더 포함) 요청에 사용
+	ErrInsufficientScope  = "INSUFFICIENT_SCOPE"    // 비밀 키에 필요한 scope 없음
+
 	// 입력 검증 에러
 	ErrInvalidInput = "INVALID_INPUT" // 입력 검증 실패
 
 	// 리소스 관련 에러
 	ErrPostNotFound    = "POST_NOT_FOUND"    // 포스트 없음
 	ErrCommentNotFound = "COMMENT_NOT_FOUND" // 댓글 없음
+	ErrPostLocked      = "POST_LOCKED"       // 잠겼거나 자동 마감된 포스트 (댓글 작성 불가)
 
 	// 권한 관련 에러
 	ErrWrongPassword   = "WRONG_PASSWORD"    // 비밀번호 불일치
@@ -145,6 +150,12 @@ func AuthMiddleware(db database.DBTX) func(http.Handler) http.Handler {
 			}
 			ctx := r.Context()
 
+			// 비밀 키는 서버 API 전용 (페이지 소스에 노출되는 공개 API에는 사용 불가)
+			if models.IsSecretAPIKey(apiKey) {
+				respondError(w, http.StatusForbidden, ErrInvalidAPIKey, "Secret API keys can only be used with the server API", nil)
+				return
+			}
+
 			// 2. API 키로 사이트 조회 (캐시 자동 사용)
 			site, err := database.GetSiteByAPIKey(ctx, db, apiKey)
 			if err != nil {
@@ -178,3 +189,64 @@ func AuthMiddleware(db database.DBTX) func(http.Handler) http.Handler {
 		})
 	}
 }
+
+// SecretKeyAuthMiddleware는 서버 API용 비밀 키 인증을 수행하는 미들웨어입니다
+// 백엔드에서만 호출하는 서버 API 엔드포인트에 적용되어야 합니다
+//
+// 처리 흐름:
+// 1. X-Orbithall-API-Key 헤더 추출
+// 2. 비밀 키(orb_secret_)인지 확인 (공개 키 거부)
+// 3. Origin 헤더가 있으면 거부 (브라우저 요청에서 비밀 키 사용 방지)
+// 4. API 키로 사이트 조회 (캐시 사용)
+// 5. Context에 사이트 정보 저장
+func SecretKeyAuthMiddleware(db database.DBTX) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// 1. API 키 추출
+			apiKey := r.Header.Get("X-Orbithall-API-Key")
+			if apiKey == "" {
+				respondError(w, http.StatusUnauthorized, ErrMissingAPIKey, "API key is required", nil)
+				return
+			}
+
+			// 2. 비밀 키 확인
+			if !models.IsSecretAPIKey(apiKey) {
+				respondError(w, http.StatusForbidden, ErrInvalidAPIKey, "Secret API key is required", nil)
+				return
+			}
+
+			// 3. 브라우저 요청 거부 (브라우저는 항상 Origin 헤더를 포함)
+			if r.Header.Get("Origin") != "" {
+				respondError(w, http.StatusForbidden, ErrSecretKeyInBrowser, "Secret API keys must not be used from browsers", nil)
+				return
+			}
+
+			// 4. API 키로 사이트 조회 (캐시 자동 사용, 비활성 사이트와 폐기/만료된 키는 조회되지 않음)
+			ctx := r.Context()
+			site, err := database.GetSiteByAPIKey(ctx, db, apiKey)
+			if err != nil {
+				respondError(w, http.StatusForbidden, ErrInvalidAPIKey, "Invalid API key", nil)
+				return
+			}
+
+			// 5. Context에 사이트 정보 저장
+			ctx = context.WithValue(ctx, siteContextKey, site)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// RequireScope는 요청에 사용한 비밀 키가 주어진 scope를 가지고 있는지 확인하는 미들웨어입니다
+// SecretKeyAuthMiddleware 뒤에 적용해야 합니다
+func RequireScope(scope string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			site := GetSiteFromContext(r.Context())
+			if site == nil || !site.HasScope(scope) {
+				respondError(w, http.StatusForbidden, ErrInsufficientScope, "API key does not have the required scope", map[string]string{"scope": scope})
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
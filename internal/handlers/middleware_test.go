@@ -1,12 +1,14 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
 	"github.com/june20516/orbithall/internal/database"
+	"github.com/june20516/orbithall/internal/models"
 	"github.com/june20516/orbithall/internal/testhelpers"
 )
 
@@ -341,3 +343,105 @@ func TestIsOriginAllowed(t *testing.T) {
 		})
 	}
 }
+
+// createTestSecretKey는 사이트에 주어진 scope의 비밀 키를 발급합니다
+func createTestSecretKey(t *testing.T, ctx context.Context, tx testhelpers.DBTX, siteID int64, scopes ...string) string {
+	t.Helper()
+
+	key := &models.SiteAPIKey{SiteID: siteID, Label: "backend", Secret: true, Scopes: scopes}
+	if err := database.CreateSiteAPIKey(ctx, tx, key); err != nil {
+		t.Fatalf("Failed to create secret key: %v", err)
+	}
+	t.Cleanup(func() { database.InvalidateAPIKey(key.Key) })
+	return key.Key
+}
+
+func TestAuthMiddleware_RejectsSecretKey(t *testing.T) {
+	db := testhelpers.SetupTestDB(t)
+	defer database.Close(db)
+
+	ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+	defer cleanup()
+
+	// Given: 비밀 키
+	site := testhelpers.CreateTestSite(ctx, t, tx, "Test Site", "test.com", []string{"http://localhost:3000"}, true)
+	secretKey := createTestSecretKey(t, ctx, tx, site.ID, models.ScopeCommentsReadPrivate)
+
+	nextHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("Expected request to be rejected")
+	})
+	handler := AuthMiddleware(tx)(nextHandler)
+
+	// When: 공개 API에 비밀 키 사용
+	req := httptest.NewRequest(http.MethodGet, "/api/comments", nil)
+	req.Header.Set("X-Orbithall-API-Key", secretKey)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	// Then: 403 INVALID_API_KEY
+	var response ErrorResponse
+	json.NewDecoder(rec.Body).Decode(&response)
+	if rec.Code != http.StatusForbidden || response.Error.Code != ErrInvalidAPIKey {
+		t.Errorf("Expected 403 %s, got %d %s", ErrInvalidAPIKey, rec.Code, response.Error.Code)
+	}
+}
+
+func TestSecretKeyAuthMiddleware(t *testing.T) {
+	db := testhelpers.SetupTestDB(t)
+	defer database.Close(db)
+
+	ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+	defer cleanup()
+
+	// Given: 공개 키와 비밀 키가 있는 사이트
+	site := testhelpers.CreateTestSite(ctx, t, tx, "Test Site", "test.com", []string{"http://localhost:3000"}, true)
+	defer database.InvalidateAPIKey(site.APIKey)
+	secretKey := createTestSecretKey(t, ctx, tx, site.ID, models.ScopeCommentsReadPrivate)
+
+	// 비밀 키 인증 후 comments:moderate scope 확인
+	nextHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	readHandler := SecretKeyAuthMiddleware(tx)(RequireScope(models.ScopeCommentsReadPrivate)(nextHandler))
+	moderateHandler := SecretKeyAuthMiddleware(tx)(RequireScope(models.ScopeCommentsModerate)(nextHandler))
+
+	tests := []struct {
+		name       string
+		handler    http.Handler
+		apiKey     string
+		origin     string
+		wantStatus int
+		wantCode   string
+	}{
+		{"비밀 키와 scope 일치 - 성공", readHandler, secretKey, "", http.StatusOK, ""},
+		{"공개 키 - 거부", readHandler, site.APIKey, "", http.StatusForbidden, ErrInvalidAPIKey},
+		{"브라우저 Origin 포함 - 거부", readHandler, secretKey, "http://localhost:3000", http.StatusForbidden, ErrSecretKeyInBrowser},
+		{"scope 없음 - 거부", moderateHandler, secretKey, "", http.StatusForbidden, ErrInsufficientScope},
+		{"존재하지 않는 비밀 키 - 거부", readHandler, models.GenerateAPIKey(models.APIKeyPrefixSecret), "", http.StatusForbidden, ErrInvalidAPIKey},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// When: 서버 API 요청
+			req := httptest.NewRequest(http.MethodGet, "/server/posts/test/comments", nil)
+			req.Header.Set("X-Orbithall-API-Key", tt.apiKey)
+			if tt.origin != "" {
+				req.Header.Set("Origin", tt.origin)
+			}
+			rec := httptest.NewRecorder()
+			tt.handler.ServeHTTP(rec, req)
+
+			// Then: 기대한 상태 코드와 에러 코드
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d. Body: %s", tt.wantStatus, rec.Code, rec.Body.String())
+			}
+			if tt.wantCode != "" {
+				var response ErrorResponse
+				json.NewDecoder(rec.Body).Decode(&response)
+				if response.Error.Code != tt.wantCode {
+					t.Errorf("Expected error code %s, got %s", tt.wantCode, response.Error.Code)
+				}
+			}
+		})
+	}
+}
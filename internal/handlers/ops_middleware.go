@@ -0,0 +1,29 @@
+package handlers
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+)
+
+// OpsTokenMiddleware는 운영용 엔드포인트(캐시 통계 등)를 운영 토큰으로 보호하는 미들웨어입니다
+// Authorization 헤더의 Bearer 토큰이 token과 같아야 통과합니다
+// token이 비어 있으면 엔드포인트를 비활성화합니다 (404 Not Found)
+func OpsTokenMiddleware(token string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if token == "" {
+				http.NotFound(w, r)
+				return
+			}
+
+			provided, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if !ok || subtle.ConstantTimeCompare([]byte(provided), []byte(token)) != 1 {
+				respondWithError(w, http.StatusUnauthorized, "INVALID_TOKEN", "Valid ops token is required")
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestOpsTokenMiddleware는 운영 토큰 검증을 테스트합니다
+func TestOpsTokenMiddleware(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	tests := []struct {
+		name     string
+		token    string
+		header   string
+		expected int
+	}{
+		{name: "올바른 토큰은 통과", token: "ops-secret", header: "Bearer ops-secret", expected: http.StatusOK},
+		{name: "토큰 없으면 401", token: "ops-secret", header: "", expected: http.StatusUnauthorized},
+		{name: "잘못된 토큰은 401", token: "ops-secret", header: "Bearer wrong", expected: http.StatusUnauthorized},
+		{name: "Bearer 형식이 아니면 401", token: "ops-secret", header: "ops-secret", expected: http.StatusUnauthorized},
+		{name: "토큰이 설정되지 않으면 비활성화 (404)", token: "", header: "Bearer ", expected: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given
+			req := httptest.NewRequest(http.MethodGet, "/health/cache", nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			rec := httptest.NewRecorder()
+
+			// When
+			OpsTokenMiddleware(tt.token)(next).ServeHTTP(rec, req)
+
+			// Then
+			if rec.Code != tt.expected {
+				t.Errorf("Expected status %d, got %d", tt.expected, rec.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/june20516/orbithall/internal/httputil"
+	"github.com/june20516/orbithall/internal/models"
+	"github.com/june20516/orbithall/internal/ratelimit"
+)
+
+// SessionHeader는 사이트별 세션 단위 요청 제한에 사용하는 세션 ID 헤더입니다
+const SessionHeader = "X-Orbithall-Session"
+
+// maxSessionIDLength는 제한 키에 사용하는 세션 ID의 최대 길이입니다 (초과분은 잘라냄)
+const maxSessionIDLength = 128
+
+// SiteRateLimit은 공개 API 작업(action)에 사이트별 요청 제한을 적용하는 Rule을 반환합니다
+// 사이트가 설정한 제한(없으면 기본 제한)을 사이트와 IP 조합별로 적용합니다
+// 사이트가 세션 단위 제한을 켰으면 같은 IP를 공유하는 사용자를 위해 IP별 제한을 SessionSharedIPFactor배로 늘리고,
+// 세션별 제한은 SiteSessionRateLimit으로 함께 적용합니다 (세션 ID를 바꿔도 IP별 제한은 유지)
+// AuthMiddleware 뒤에 적용해야 합니다 (Context에 사이트가 없으면 제한하지 않음)
+func SiteRateLimit(action string) ratelimit.Rule {
+	return func(r *http.Request) (ratelimit.Target, bool) {
+		site := GetSiteFromContext(r.Context())
+		if site == nil {
+			return ratelimit.Target{}, false
+		}
+
+		limit := site.RateLimits.For(action)
+		if site.RateLimits.KeyBySession {
+			limit.PerMinute *= models.SessionSharedIPFactor
+			limit.Burst *= models.SessionSharedIPFactor
+		}
+		return ratelimit.Target{
+			Bucket: "comment-" + action,
+			Key:    fmt.Sprintf("site:%d:ip:%s", site.ID, httputil.GetIPAddress(r)),
+			Limit:  ratelimit.PerMinute(limit.PerMinute, limit.Burst),
+		}, true
+	}
+}
+
+// SiteSessionRateLimit은 사이트가 세션 단위 제한을 켰을 때 공개 API 작업(action)에 세션별 요청 제한을 적용하는 Rule을 반환합니다
+// 요청에 세션 ID 헤더가 있으면 사이트가 설정한 제한을 사이트, IP, 세션 ID 조합별로 적용합니다
+// SiteRateLimit과 함께 적용하는 추가 제한이며, 단독으로 사용하면 세션 ID를 바꿔 제한을 우회할 수 있습니다
+func SiteSessionRateLimit(action string) ratelimit.Rule {
+	return func(r *http.Request) (ratelimit.Target, bool) {
+		site := GetSiteFromContext(r.Context())
+		if site == nil || !site.RateLimits.KeyBySession {
+			return ratelimit.Target{}, false
+		}
+
+		session := r.Header.Get(SessionHeader)
+		if session == "" {
+			return ratelimit.Target{}, false
+		}
+		if len(session) > maxSessionIDLength {
+			session = session[:maxSessionIDLength]
+		}
+
+		limit := site.RateLimits.For(action)
+		return ratelimit.Target{
+			Bucket: "comment-" + action + "-session",
+			Key:    fmt.Sprintf("site:%d:ip:%s:session:%s", site.ID, httputil.GetIPAddress(r), session),
+			Limit:  ratelimit.PerMinute(limit.PerMinute, limit.Burst),
+		}, true
+	}
+}
+
+// AdminRateLimit은 Admin API 요청에 관리자별 요청 제한을 적용하는 Rule을 반환합니다
+// JWTAuthMiddleware 뒤에 적용해야 합니다 (Context에 관리자가 없으면 IP별로 적용)
+func AdminRateLimit(limit ratelimit.Limit) ratelimit.Rule {
+	return func(r *http.Request) (ratelimit.Target, bool) {
+		key := "ip:" + httputil.GetIPAddress(r)
+		if user, ok := r.Context().Value(userContextKey).(*models.User); ok {
+			key = fmt.Sprintf("user:%d", user.ID)
+		}
+		return ratelimit.Target{Bucket: "admin", Key: key, Limit: limit}, true
+	}
+}
+
+// ServerRateLimit은 서버 API 요청에 비밀 키별 요청 제한을 적용하는 Rule을 반환합니다
+// SecretKeyAuthMiddleware 뒤에 적용해야 합니다 (Context에 사이트가 없으면 IP별로 적용)
+func ServerRateLimit(limit ratelimit.Limit) ratelimit.Rule {
+	return func(r *http.Request) (ratelimit.Target, bool) {
+		key := "ip:" + httputil.GetIPAddress(r)
+		if site := GetSiteFromContext(r.Context()); site != nil {
+			key = fmt.Sprintf("key:%d", site.APIKeyID)
+		}
+		return ratelimit.Target{Bucket: "server", Key: key, Limit: limit}, true
+	}
+}
@@ -0,0 +1,148 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/june20516/orbithall/internal/models"
+	"github.com/june20516/orbithall/internal/ratelimit"
+)
+
+// TestSiteRateLimit은 사이트별 요청 제한 대상 결정을 테스트합니다
+func TestSiteRateLimit(t *testing.T) {
+	t.Run("사이트 설정이 없으면 기본 제한을 사이트와 IP별로 적용", func(t *testing.T) {
+		// Given: 제한을 설정하지 않은 사이트의 요청
+		site := &models.Site{ID: 1}
+		req := httptest.NewRequest("POST", "/api/posts/test/comments", nil)
+		req.RemoteAddr = "192.168.1.1:12345"
+		req = req.WithContext(withSiteContext(req.Context(), site))
+
+		// When
+		target, ok := SiteRateLimit(models.RateLimitActionCreate)(req)
+
+		// Then
+		if !ok {
+			t.Fatal("expected request to be rate limited")
+		}
+		if target.Bucket != "comment-create" || target.Key != "site:1:ip:192.168.1.1" {
+			t.Errorf("unexpected target: bucket=%s, key=%s", target.Bucket, target.Key)
+		}
+		if target.Limit != ratelimit.PerMinute(10, 5) {
+			t.Errorf("expected default create limit, got %+v", target.Limit)
+		}
+	})
+
+	t.Run("세션 단위 제한을 켜도 사이트와 IP별 제한은 유지", func(t *testing.T) {
+		// Given: 작성 제한과 세션 단위 제한을 설정한 사이트의 세션 ID가 있는 요청
+		site := &models.Site{ID: 2, RateLimits: models.SiteRateLimits{
+			Create:       &models.RateLimit{PerMinute: 3, Burst: 1},
+			KeyBySession: true,
+		}}
+		req := httptest.NewRequest("POST", "/api/posts/test/comments", nil)
+		req.RemoteAddr = "192.168.1.1:12345"
+		req.Header.Set(SessionHeader, "session-1")
+		req = req.WithContext(withSiteContext(req.Context(), site))
+
+		// When
+		target, _ := SiteRateLimit(models.RateLimitActionCreate)(req)
+
+		// Then: 세션 ID는 키에 포함하지 않고, 같은 IP 공유를 위해 제한만 늘어남
+		if target.Key != "site:2:ip:192.168.1.1" {
+			t.Errorf("expected ip key, got %s", target.Key)
+		}
+		if target.Limit != ratelimit.PerMinute(3*models.SessionSharedIPFactor, models.SessionSharedIPFactor) {
+			t.Errorf("expected shared ip limit, got %+v", target.Limit)
+		}
+	})
+
+	t.Run("Context에 사이트가 없으면 제한하지 않음", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/api/posts/test/comments", nil)
+		req = req.WithContext(context.Background())
+
+		if _, ok := SiteRateLimit(models.RateLimitActionList)(req); ok {
+			t.Error("expected request without site not to be rate limited")
+		}
+	})
+}
+
+// TestSiteSessionRateLimit은 세션별 추가 요청 제한 대상 결정을 테스트합니다
+func TestSiteSessionRateLimit(t *testing.T) {
+	newRequest := func(site *models.Site, session string) *http.Request {
+		req := httptest.NewRequest("POST", "/api/posts/test/comments", nil)
+		req.RemoteAddr = "192.168.1.1:12345"
+		if session != "" {
+			req.Header.Set(SessionHeader, session)
+		}
+		return req.WithContext(withSiteContext(req.Context(), site))
+	}
+
+	t.Run("사이트 설정 제한을 세션별로 적용", func(t *testing.T) {
+		// Given: 세션 단위 제한을 설정한 사이트의 요청
+		site := &models.Site{ID: 2, RateLimits: models.SiteRateLimits{
+			Create:       &models.RateLimit{PerMinute: 3, Burst: 1},
+			KeyBySession: true,
+		}}
+
+		// When
+		target, ok := SiteSessionRateLimit(models.RateLimitActionCreate)(newRequest(site, strings.Repeat("s", maxSessionIDLength+10)))
+
+		// Then: 세션 ID는 최대 길이로 잘림
+		if !ok {
+			t.Fatal("expected request to be rate limited")
+		}
+		expectedKey := "site:2:ip:192.168.1.1:session:" + strings.Repeat("s", maxSessionIDLength)
+		if target.Bucket != "comment-create-session" || target.Key != expectedKey {
+			t.Errorf("unexpected target: bucket=%s, key=%s", target.Bucket, target.Key)
+		}
+		if target.Limit != ratelimit.PerMinute(3, 1) {
+			t.Errorf("expected site create limit, got %+v", target.Limit)
+		}
+	})
+
+	t.Run("세션 단위 제한을 끄거나 세션 ID가 없으면 적용하지 않음", func(t *testing.T) {
+		off := &models.Site{ID: 1}
+		on := &models.Site{ID: 2, RateLimits: models.SiteRateLimits{KeyBySession: true}}
+
+		if _, ok := SiteSessionRateLimit(models.RateLimitActionCreate)(newRequest(off, "session-1")); ok {
+			t.Error("expected site without key_by_session not to be session limited")
+		}
+		if _, ok := SiteSessionRateLimit(models.RateLimitActionCreate)(newRequest(on, "")); ok {
+			t.Error("expected request without session id not to be session limited")
+		}
+	})
+}
+
+// TestServerRateLimit은 서버 API 요청 제한 대상 결정을 테스트합니다
+func TestServerRateLimit(t *testing.T) {
+	limit := ratelimit.PerMinute(300, 60)
+
+	t.Run("비밀 키별로 적용", func(t *testing.T) {
+		// Given: 비밀 키로 인증한 요청 (같은 사이트의 다른 키는 따로 제한)
+		site := &models.Site{ID: 1, APIKeyID: 42}
+		req := httptest.NewRequest(http.MethodDelete, "/server/comments/1", nil)
+		req.RemoteAddr = "192.168.1.1:12345"
+		req = req.WithContext(withSiteContext(req.Context(), site))
+
+		// When
+		target, ok := ServerRateLimit(limit)(req)
+
+		// Then
+		if !ok || target.Bucket != "server" || target.Key != "key:42" || target.Limit != limit {
+			t.Errorf("unexpected target: ok=%v, %+v", ok, target)
+		}
+	})
+
+	t.Run("인증 전이면 IP별로 적용", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodDelete, "/server/comments/1", nil)
+		req.RemoteAddr = "192.168.1.1:12345"
+
+		target, ok := ServerRateLimit(limit)(req)
+
+		if !ok || target.Key != "ip:192.168.1.1" {
+			t.Errorf("unexpected target: ok=%v, %+v", ok, target)
+		}
+	})
+}
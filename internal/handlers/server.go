@@ -0,0 +1,390 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/june20516/orbithall/internal/database"
+	"github.com/june20516/orbithall/internal/models"
+	"github.com/june20516/orbithall/internal/validators"
+)
+
+// ============================================
+// ServerHandler 구조체
+// ============================================
+
+// ServerHandler는 비밀 키로 인증하는 서버 API 요청을 처리합니다
+// 사이트 운영자의 백엔드가 댓글을 조회/관리할 때 사용하며, 각 엔드포인트는 필요한 scope를 가진 키만 호출할 수 있습니다
+// 비밀 키는 실제 데이터만 다룹니다 (샌드박스 데이터는 테스트 모드 키 전용)
+type ServerHandler struct {
+	db database.DBTX
+}
+
+// NewServerHandler는 ServerHandler의 새 인스턴스를 생성합니다
+func NewServerHandler(db database.DBTX) *ServerHandler {
+	return &ServerHandler{
+		db: db,
+	}
+}
+
+// ServerCommentsResponse는 서버 API 댓글 목록 응답입니다
+type ServerCommentsResponse struct {
+	Comments []*models.Comment `json:"comments"`
+	Total    int               `json:"total"`
+}
+
+// 서버 API 댓글 목록 페이지 크기
+const (
+	defaultServerCommentsLimit = 50
+	maxServerCommentsLimit     = 200
+)
+
+// ListPostComments는 포스트의 모든 댓글을 반환합니다 (삭제된 댓글과 전체 IP 포함)
+// @Summary      댓글 목록 조회 (서버 API)
+// @Description  포스트의 모든 댓글을 조회합니다. 삭제된 댓글도 포함하며 IP 주소는 마스킹하지 않습니다. comments:read_private scope가 필요합니다.
+// @Tags         server
+// @Produce      json
+// @Param        slug   path  string true  "Post Slug"
+// @Param        limit  query int    false "최상위 댓글 개수 (기본 50, 최대 200)"
+// @Param        offset query int    false "오프셋 (기본 0)"
+// @Success      200 {object} ServerCommentsResponse
+// @Failure      401 {object} ErrorResponse "API 키 없음"
+// @Failure      403 {object} ErrorResponse "비밀 키가 아니거나 scope 없음"
+// @Failure      404 {object} ErrorResponse "포스트 없음"
+// @Failure      500 {object} ErrorResponse "서버 오류"
+// @Security     SecretKeyAuth
+// @Router       /server/posts/{slug}/comments [get]
+func (h *ServerHandler) ListPostComments(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	site := GetSiteFromContext(ctx)
+
+	post, ok := h.findPost(w, r, site)
+	if !ok {
+		return
+	}
+
+	// 페이지네이션 (범위를 벗어나면 기본값 사용)
+	limit := ParseQueryInt(r, "limit", defaultServerCommentsLimit)
+	if limit < 1 || limit > maxServerCommentsLimit {
+		limit = defaultServerCommentsLimit
+	}
+	offset := ParseQueryInt(r, "offset", 0)
+	if offset < 0 {
+		offset = 0
+	}
+
+	comments, total, err := database.GetAdminComments(ctx, h.db, post.ID, limit, offset)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, ErrInternalServer, "Failed to get comments", nil)
+		return
+	}
+
+	// 전체 IP 공개 (Admin API와 동일)
+	for _, comment := range comments {
+		comment.IPAddressUnmasked = comment.IPAddress
+		for _, reply := range comment.Replies {
+			reply.IPAddressUnmasked = reply.IPAddress
+		}
+	}
+
+	respondJSON(w, http.StatusOK, ServerCommentsResponse{
+		Comments: comments,
+		Total:    total,
+	})
+}
+
+// DeleteComment는 댓글을 삭제합니다 (비밀번호 확인 없음)
+// @Summary      댓글 삭제 (서버 API)
+// @Description  댓글을 soft delete합니다. 작성자 비밀번호와 작성 후 경과 시간을 확인하지 않으며, 이미 삭제된 댓글이면 아무것도 변경하지 않습니다. comments:moderate scope가 필요합니다.
+// @Tags         server
+// @Param        id path int true "Comment ID"
+// @Success      204 "No Content"
+// @Failure      400 {object} ErrorResponse "잘못된 댓글 ID"
+// @Failure      401 {object} ErrorResponse "API 키 없음"
+// @Failure      403 {object} ErrorResponse "비밀 키가 아니거나 scope 없음"
+// @Failure      404 {object} ErrorResponse "댓글 없음"
+// @Failure      500 {object} ErrorResponse "서버 오류"
+// @Security     SecretKeyAuth
+// @Router       /server/comments/{id} [delete]
+func (h *ServerHandler) DeleteComment(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	site := GetSiteFromContext(ctx)
+
+	comment, ok := h.findComment(w, r, site)
+	if !ok {
+		return
+	}
+	if comment.IsDeleted {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	// 댓글 삭제 (댓글 수 감소 포함)
+	err := database.RunInTx(ctx, h.db, func(tx database.DBTX) error {
+		if err := database.DeleteComment(ctx, tx, comment.ID); err != nil {
+			return err
+		}
+		return recordAudit(r, tx, site.ID, models.AuditActionCommentDelete, models.AuditTargetComment, comment.ID,
+			map[string]bool{"is_deleted": false}, map[string]bool{"is_deleted": true})
+	})
+	if err != nil {
+		// 조회 이후 다른 요청이 먼저 삭제한 경우
+		if errors.Is(err, database.ErrCommentNotFound) {
+			respondError(w, http.StatusNotFound, ErrCommentNotFound, "Comment not found or already deleted", nil)
+			return
+		}
+		respondError(w, http.StatusInternalServerError, ErrInternalServer, "Failed to delete comment", nil)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RestoreComment는 삭제된 댓글을 복구합니다
+// @Summary      댓글 복구 (서버 API)
+// @Description  soft delete된 댓글을 복구합니다. comments:moderate scope가 필요합니다.
+// @Tags         server
+// @Param        id path int true "Comment ID"
+// @Success      204 "No Content"
+// @Failure      400 {object} ErrorResponse "잘못된 댓글 ID"
+// @Failure      401 {object} ErrorResponse "API 키 없음"
+// @Failure      403 {object} ErrorResponse "비밀 키가 아니거나 scope 없음"
+// @Failure      404 {object} ErrorResponse "댓글 없음 또는 삭제되지 않은 댓글"
+// @Failure      500 {object} ErrorResponse "서버 오류"
+// @Security     SecretKeyAuth
+// @Router       /server/comments/{id}/restore [post]
+func (h *ServerHandler) RestoreComment(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	site := GetSiteFromContext(ctx)
+
+	comment, ok := h.findComment(w, r, site)
+	if !ok {
+		return
+	}
+
+	// 댓글 복구 (댓글 수 증가 포함)
+	err := database.RunInTx(ctx, h.db, func(tx database.DBTX) error {
+		if err := database.RestoreComment(ctx, tx, comment.ID); err != nil {
+			return err
+		}
+		return recordAudit(r, tx, site.ID, models.AuditActionCommentRestore, models.AuditTargetComment, comment.ID,
+			map[string]bool{"is_deleted": true}, map[string]bool{"is_deleted": false})
+	})
+	if err != nil {
+		if errors.Is(err, database.ErrCommentNotFound) {
+			respondError(w, http.StatusNotFound, ErrCommentNotFound, "Comment not found or not deleted", nil)
+			return
+		}
+		respondError(w, http.StatusInternalServerError, ErrInternalServer, "Failed to restore comment", nil)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// UpdatePost는 포스트의 제목과 URL을 수정합니다
+// @Summary      포스트 메타데이터 수정 (서버 API)
+// @Description  포스트의 제목과 canonical URL을 수정합니다. 제공된 필드만 수정하며, url을 빈 문자열로 보내면 URL이 삭제됩니다. posts:write scope가 필요합니다.
+// @Tags         server
+// @Accept       json
+// @Produce      json
+// @Param        slug path string                      true "Post Slug"
+// @Param        post body validators.PostUpdateInput  true "수정할 포스트 정보"
+// @Success      200 {object} models.Post
+// @Failure      400 {object} ErrorResponse "입력 검증 실패"
+// @Failure      401 {object} ErrorResponse "API 키 없음"
+// @Failure      403 {object} ErrorResponse "비밀 키가 아니거나 scope 없음"
+// @Failure      404 {object} ErrorResponse "포스트 없음"
+// @Failure      500 {object} ErrorResponse "서버 오류"
+// @Security     SecretKeyAuth
+// @Router       /server/posts/{slug} [put]
+func (h *ServerHandler) UpdatePost(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	site := GetSiteFromContext(ctx)
+
+	// 요청 본문 파싱 및 검증
+	var input validators.PostUpdateInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		respondError(w, http.StatusBadRequest, ErrInvalidInput, "Invalid request body", nil)
+		return
+	}
+	if err := input.Validate(); err != nil {
+		if validationErrs, ok := err.(validators.ValidationErrors); ok {
+			respondError(w, http.StatusBadRequest, ErrInvalidInput, "Validation failed", validationErrs)
+			return
+		}
+		respondError(w, http.StatusBadRequest, ErrInvalidInput, err.Error(), nil)
+		return
+	}
+
+	post, ok := h.findPost(w, r, site)
+	if !ok {
+		return
+	}
+
+	// 수정할 필드 결정 (제공된 필드만 수정)
+	title := post.Title
+	postURL := post.URL
+	if input.Title != nil {
+		title = strings.TrimSpace(*input.Title)
+	}
+	if input.URL != nil {
+		postURL = *input.URL
+	}
+
+	// 포스트 수정 후 재조회 (변경 전/후 상태를 감사 로그에 기록)
+	var updatedPost *models.Post
+	err := database.RunInTx(ctx, h.db, func(tx database.DBTX) error {
+		if err := database.UpdatePost(ctx, tx, post.ID, title, postURL); err != nil {
+			return err
+		}
+		var err error
+		updatedPost, err = database.GetPostByID(ctx, tx, post.ID)
+		if err != nil {
+			return err
+		}
+		if updatedPost == nil {
+			return sql.ErrNoRows
+		}
+		return recordAudit(r, tx, site.ID, models.AuditActionPostUpdate, models.AuditTargetPost, post.ID, newPostAuditState(post), newPostAuditState(updatedPost))
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			respondError(w, http.StatusNotFound, ErrPostNotFound, "Post not found", nil)
+			return
+		}
+		respondError(w, http.StatusInternalServerError, ErrInternalServer, "Failed to update post", nil)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, updatedPost)
+}
+
+// LockPost는 포스트의 댓글 작성을 잠급니다
+// @Summary      포스트 잠금 (서버 API)
+// @Description  포스트에 새 댓글을 작성하지 못하도록 잠급니다. posts:write scope가 필요합니다.
+// @Tags         server
+// @Produce      json
+// @Param        slug path string true "Post Slug"
+// @Success      200 {object} models.Post
+// @Failure      401 {object} ErrorResponse "API 키 없음"
+// @Failure      403 {object} ErrorResponse "비밀 키가 아니거나 scope 없음"
+// @Failure      404 {object} ErrorResponse "포스트 없음"
+// @Failure      500 {object} ErrorResponse "서버 오류"
+// @Security     SecretKeyAuth
+// @Router       /server/posts/{slug}/lock [post]
+func (h *ServerHandler) LockPost(w http.ResponseWriter, r *http.Request) {
+	h.setPostLocked(w, r, true)
+}
+
+// UnlockPost는 포스트의 댓글 작성 잠금을 해제합니다
+// @Summary      포스트 잠금 해제 (서버 API)
+// @Description  포스트의 댓글 작성 잠금을 해제합니다. posts:write scope가 필요합니다.
+// @Tags         server
+// @Produce      json
+// @Param        slug path string true "Post Slug"
+// @Success      200 {object} models.Post
+// @Failure      401 {object} ErrorResponse "API 키 없음"
+// @Failure      403 {object} ErrorResponse "비밀 키가 아니거나 scope 없음"
+// @Failure      404 {object} ErrorResponse "포스트 없음"
+// @Failure      500 {object} ErrorResponse "서버 오류"
+// @Security     SecretKeyAuth
+// @Router       /server/posts/{slug}/unlock [post]
+func (h *ServerHandler) UnlockPost(w http.ResponseWriter, r *http.Request) {
+	h.setPostLocked(w, r, false)
+}
+
+// setPostLocked는 포스트 잠금/해제 핸들러의 공통 처리 로직입니다
+func (h *ServerHandler) setPostLocked(w http.ResponseWriter, r *http.Request, locked bool) {
+	ctx := r.Context()
+	site := GetSiteFromContext(ctx)
+
+	post, ok := h.findPost(w, r, site)
+	if !ok {
+		return
+	}
+
+	action := models.AuditActionPostUnlock
+	if locked {
+		action = models.AuditActionPostLock
+	}
+	before := newPostAuditState(post)
+	after := *before
+	after.IsLocked = locked
+	err := database.RunInTx(ctx, h.db, func(tx database.DBTX) error {
+		if err := database.SetPostLocked(ctx, tx, post.ID, locked); err != nil {
+			return err
+		}
+		return recordAudit(r, tx, site.ID, action, models.AuditTargetPost, post.ID, before, &after)
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			respondError(w, http.StatusNotFound, ErrPostNotFound, "Post not found", nil)
+			return
+		}
+		respondError(w, http.StatusInternalServerError, ErrInternalServer, "Failed to update post", nil)
+		return
+	}
+
+	post.IsLocked = locked
+	respondJSON(w, http.StatusOK, post)
+}
+
+// findPost는 URL의 slug로 사이트의 실제 포스트를 조회합니다 (별칭 포함)
+// 실패 시 에러 응답을 작성하고 false를 반환합니다
+func (h *ServerHandler) findPost(w http.ResponseWriter, r *http.Request, site *models.Site) (*models.Post, bool) {
+	slug := chi.URLParam(r, "slug")
+	if slug == "" {
+		respondError(w, http.StatusBadRequest, ErrInvalidInput, "Post slug is required", nil)
+		return nil, false
+	}
+
+	post, err := database.GetPostBySlug(r.Context(), h.db, site.ID, slug, false)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, ErrInternalServer, "Failed to get post", nil)
+		return nil, false
+	}
+	if post == nil {
+		respondError(w, http.StatusNotFound, ErrPostNotFound, "Post not found", nil)
+		return nil, false
+	}
+
+	return post, true
+}
+
+// findComment는 URL의 댓글 ID로 사이트의 실제 댓글을 조회합니다
+// 다른 사이트나 샌드박스의 댓글은 존재하지 않는 것으로 취급합니다
+// 실패 시 에러 응답을 작성하고 false를 반환합니다
+func (h *ServerHandler) findComment(w http.ResponseWriter, r *http.Request, site *models.Site) (*models.Comment, bool) {
+	commentID, err := ParseInt64Param(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, ErrInvalidInput, "Invalid comment ID", nil)
+		return nil, false
+	}
+
+	comment, err := database.GetCommentByID(r.Context(), h.db, commentID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, ErrInternalServer, "Failed to get comment", nil)
+		return nil, false
+	}
+	if comment == nil {
+		respondError(w, http.StatusNotFound, ErrCommentNotFound, "Comment not found", nil)
+		return nil, false
+	}
+
+	post, err := database.GetPostByID(r.Context(), h.db, comment.PostID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, ErrInternalServer, "Failed to get post", nil)
+		return nil, false
+	}
+	if post == nil || post.SiteID != site.ID || post.IsSandbox {
+		respondError(w, http.StatusNotFound, ErrCommentNotFound, "Comment not found", nil)
+		return nil, false
+	}
+
+	return comment, true
+}
@@ -0,0 +1,243 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/june20516/orbithall/internal/database"
+	"github.com/june20516/orbithall/internal/models"
+	"github.com/june20516/orbithall/internal/testhelpers"
+)
+
+// newServerRequest는 비밀 키로 조회한 사이트가 Context에 담긴 서버 API 요청을 생성합니다
+func newServerRequest(site *models.Site, method, target string, params map[string]string, body []byte) *http.Request {
+	req := httptest.NewRequest(method, target, bytes.NewReader(body))
+	rctx := chi.NewRouteContext()
+	for key, value := range params {
+		rctx.URLParams.Add(key, value)
+	}
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	return req.WithContext(withSiteContext(req.Context(), site))
+}
+
+func TestServerHandler_ListPostComments(t *testing.T) {
+	db := testhelpers.SetupTestDB(t)
+	defer database.Close(db)
+
+	ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+	defer cleanup()
+
+	// Given: 삭제된 댓글이 있는 포스트
+	testSite := testhelpers.CreateTestSite(ctx, t, tx, "Test Site", "server.test.com", []string{"http://localhost:3000"}, true)
+	site, _ := database.GetSiteByAPIKey(ctx, tx, createTestSecretKey(t, ctx, tx, testSite.ID, models.ScopeCommentsReadPrivate))
+	post := testhelpers.CreateTestPost(ctx, t, tx, site.ID, "server-post", "Server Post")
+	comment, err := database.CreateComment(ctx, tx, post.ID, nil, "홍길동", "password123", "삭제될 댓글", "192.168.1.100", "test-agent")
+	if err != nil {
+		t.Fatalf("Failed to create comment: %v", err)
+	}
+	if err := database.DeleteComment(ctx, tx, comment.ID); err != nil {
+		t.Fatalf("Failed to delete comment: %v", err)
+	}
+
+	// When: 서버 API로 댓글 목록 조회
+	rec := httptest.NewRecorder()
+	NewServerHandler(tx).ListPostComments(rec, newServerRequest(site, http.MethodGet, "/server/posts/server-post/comments", map[string]string{"slug": "server-post"}, nil))
+
+	// Then: 삭제된 댓글과 전체 IP 포함
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+	var response ServerCommentsResponse
+	json.NewDecoder(rec.Body).Decode(&response)
+	if response.Total != 1 || len(response.Comments) != 1 {
+		t.Fatalf("Expected 1 comment, got %+v", response)
+	}
+	if !response.Comments[0].IsDeleted || response.Comments[0].IPAddressUnmasked != "192.168.1.100" {
+		t.Errorf("Expected deleted comment with unmasked IP, got %+v", response.Comments[0])
+	}
+}
+
+func TestServerHandler_DeleteAndRestoreComment(t *testing.T) {
+	db := testhelpers.SetupTestDB(t)
+	defer database.Close(db)
+
+	ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+	defer cleanup()
+
+	// Given: 비밀 키로 조회한 사이트와 다른 사이트의 댓글
+	testSite := testhelpers.CreateTestSite(ctx, t, tx, "Test Site", "server.test.com", []string{"http://localhost:3000"}, true)
+	site, _ := database.GetSiteByAPIKey(ctx, tx, createTestSecretKey(t, ctx, tx, testSite.ID, models.ScopeCommentsModerate))
+	post := testhelpers.CreateTestPost(ctx, t, tx, site.ID, "server-post", "Server Post")
+	comment, _ := database.CreateComment(ctx, tx, post.ID, nil, "홍길동", "password123", "댓글", "127.0.0.1", "test-agent")
+	database.IncrementCommentCount(ctx, tx, post.ID)
+
+	otherSite := testhelpers.CreateTestSite(ctx, t, tx, "Other Site", "other.test.com", []string{"http://localhost:3000"}, true)
+	otherPost := testhelpers.CreateTestPost(ctx, t, tx, otherSite.ID, "other-post", "Other Post")
+	otherComment, _ := database.CreateComment(ctx, tx, otherPost.ID, nil, "홍길동", "password123", "댓글", "127.0.0.1", "test-agent")
+
+	handler := NewServerHandler(tx)
+	commentParams := func(id int64) map[string]string {
+		return map[string]string{"id": strconv.FormatInt(id, 10)}
+	}
+
+	t.Run("비밀번호 없이 삭제, 감사 로그에 키 기록", func(t *testing.T) {
+		// When: 댓글 삭제
+		rec := httptest.NewRecorder()
+		handler.DeleteComment(rec, newServerRequest(site, http.MethodDelete, "/server/comments/1", commentParams(comment.ID), nil))
+
+		// Then: 204, 삭제 상태, 댓글 수 감소
+		if rec.Code != http.StatusNoContent {
+			t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusNoContent, rec.Code, rec.Body.String())
+		}
+		deleted, _ := database.GetCommentByID(ctx, tx, comment.ID)
+		if !deleted.IsDeleted {
+			t.Error("Expected comment to be deleted")
+		}
+		stored, _ := database.GetPostByID(ctx, tx, post.ID)
+		if stored.CommentCount != 0 {
+			t.Errorf("Expected comment_count=0, got %d", stored.CommentCount)
+		}
+		entries, _, _ := database.ListAuditLog(ctx, tx, site.ID, 10, 0)
+		if len(entries) == 0 || entries[0].Action != models.AuditActionCommentDelete {
+			t.Fatalf("Expected comment.delete audit entry, got %+v", entries)
+		}
+		if entries[0].ActorAPIKeyID == nil || *entries[0].ActorAPIKeyID != site.APIKeyID || entries[0].ActorUserID != nil {
+			t.Errorf("Expected api key actor, got %+v", entries[0])
+		}
+	})
+
+	t.Run("삭제된 댓글 복구", func(t *testing.T) {
+		// When: 댓글 복구
+		rec := httptest.NewRecorder()
+		handler.RestoreComment(rec, newServerRequest(site, http.MethodPost, "/server/comments/1/restore", commentParams(comment.ID), nil))
+
+		// Then: 204, 복구됨
+		if rec.Code != http.StatusNoContent {
+			t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusNoContent, rec.Code, rec.Body.String())
+		}
+		restored, _ := database.GetCommentByID(ctx, tx, comment.ID)
+		if restored.IsDeleted {
+			t.Error("Expected comment to be restored")
+		}
+	})
+
+	t.Run("다른 사이트의 댓글은 404", func(t *testing.T) {
+		// When: 다른 사이트의 댓글 삭제 시도
+		rec := httptest.NewRecorder()
+		handler.DeleteComment(rec, newServerRequest(site, http.MethodDelete, "/server/comments/1", commentParams(otherComment.ID), nil))
+
+		// Then: 404, 댓글 유지
+		if rec.Code != http.StatusNotFound {
+			t.Fatalf("Expected status %d, got %d", http.StatusNotFound, rec.Code)
+		}
+		untouched, _ := database.GetCommentByID(ctx, tx, otherComment.ID)
+		if untouched.IsDeleted {
+			t.Error("Expected other site's comment to remain")
+		}
+	})
+}
+
+func TestServerHandler_UpdatePost(t *testing.T) {
+	db := testhelpers.SetupTestDB(t)
+	defer database.Close(db)
+
+	ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+	defer cleanup()
+
+	// Given: 포스트
+	testSite := testhelpers.CreateTestSite(ctx, t, tx, "Test Site", "server.test.com", []string{"http://localhost:3000"}, true)
+	site, _ := database.GetSiteByAPIKey(ctx, tx, createTestSecretKey(t, ctx, tx, testSite.ID, models.ScopePostsWrite))
+	testhelpers.CreateTestPost(ctx, t, tx, site.ID, "server-post", "server-post")
+	handler := NewServerHandler(tx)
+	params := map[string]string{"slug": "server-post"}
+
+	t.Run("제목 수정", func(t *testing.T) {
+		// When: 제목 수정
+		body, _ := json.Marshal(map[string]string{"title": "새 제목"})
+		rec := httptest.NewRecorder()
+		handler.UpdatePost(rec, newServerRequest(site, http.MethodPut, "/server/posts/server-post", params, body))
+
+		// Then: 200, 제목 변경
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, rec.Code, rec.Body.String())
+		}
+		var post models.Post
+		json.NewDecoder(rec.Body).Decode(&post)
+		if post.Title != "새 제목" {
+			t.Errorf("Expected title '새 제목', got %q", post.Title)
+		}
+	})
+
+	t.Run("잘못된 URL - 400", func(t *testing.T) {
+		// When: 잘못된 URL로 수정
+		body, _ := json.Marshal(map[string]string{"url": "not-a-url"})
+		rec := httptest.NewRecorder()
+		handler.UpdatePost(rec, newServerRequest(site, http.MethodPut, "/server/posts/server-post", params, body))
+
+		// Then: 400 INVALID_INPUT
+		if rec.Code != http.StatusBadRequest {
+			t.Fatalf("Expected status %d, got %d", http.StatusBadRequest, rec.Code)
+		}
+	})
+
+	t.Run("잠금", func(t *testing.T) {
+		// When: 포스트 잠금
+		rec := httptest.NewRecorder()
+		handler.LockPost(rec, newServerRequest(site, http.MethodPost, "/server/posts/server-post/lock", params, nil))
+
+		// Then: 200, 잠김
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, rec.Code, rec.Body.String())
+		}
+		stored, _ := database.GetPostBySlug(ctx, tx, site.ID, "server-post", false)
+		if !stored.IsLocked {
+			t.Error("Expected post to be locked")
+		}
+	})
+}
+
+// concurrentDeleteDB는 댓글 삭제 쿼리 직전에 같은 댓글을 먼저 삭제해 동시 삭제를 재현합니다
+type concurrentDeleteDB struct {
+	database.DBTX
+	commentID int64
+}
+
+func (db *concurrentDeleteDB) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	if strings.Contains(query, "SET is_deleted = TRUE") {
+		db.DBTX.ExecContext(ctx, "UPDATE comments SET is_deleted = TRUE, deleted_at = NOW() WHERE id = $1", db.commentID)
+	}
+	return db.DBTX.QueryRowContext(ctx, query, args...)
+}
+
+func TestServerHandler_DeleteComment_ConcurrentDelete(t *testing.T) {
+	db := testhelpers.SetupTestDB(t)
+	defer database.Close(db)
+
+	ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+	defer cleanup()
+
+	// Given: 조회 후 삭제 전에 다른 요청이 먼저 삭제하는 댓글
+	testSite := testhelpers.CreateTestSite(ctx, t, tx, "Test Site", "server-race.test.com", []string{"http://localhost:3000"}, true)
+	site, _ := database.GetSiteByAPIKey(ctx, tx, createTestSecretKey(t, ctx, tx, testSite.ID, models.ScopeCommentsModerate))
+	post := testhelpers.CreateTestPost(ctx, t, tx, site.ID, "race-post", "Race Post")
+	comment, _ := database.CreateComment(ctx, tx, post.ID, nil, "홍길동", "password123", "댓글", "127.0.0.1", "test-agent")
+
+	handler := NewServerHandler(&concurrentDeleteDB{DBTX: tx, commentID: comment.ID})
+
+	// When
+	rec := httptest.NewRecorder()
+	handler.DeleteComment(rec, newServerRequest(site, http.MethodDelete, "/server/comments/1", map[string]string{"id": strconv.FormatInt(comment.ID, 10)}, nil))
+
+	// Then: 500이 아닌 404
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d, got %d. Body: %s", http.StatusNotFound, rec.Code, rec.Body.String())
+	}
+}
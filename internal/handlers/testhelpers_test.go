@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/june20516/orbithall/internal/models"
+	"github.com/june20516/orbithall/internal/notify"
+)
+
+// newAdminRequest는 로그인한 사용자(user)의 관리 API 요청을 생성합니다
+// URL 파라미터는 params 순서대로 이름, 값이며, 본문이 있으면 JSON Content-Type을 설정합니다
+func newAdminRequest(ctx context.Context, user *models.User, method, target, body string, params ...string) *http.Request {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req = req.WithContext(context.WithValue(ctx, userContextKey, user))
+
+	rctx := chi.NewRouteContext()
+	for i := 0; i+1 < len(params); i += 2 {
+		rctx.URLParams.Add(params[i], params[i+1])
+	}
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+}
+
+// recordingNotifier는 보낸 알림을 기록하는 테스트용 Notifier입니다
+type recordingNotifier struct {
+	mu       sync.Mutex
+	messages []notify.Message
+}
+
+func (n *recordingNotifier) Notify(ctx context.Context, msg notify.Message) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.messages = append(n.messages, msg)
+	return nil
+}
+
+// recipients는 알림을 받은 이메일 목록을 반환합니다
+func (n *recordingNotifier) recipients() []string {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	var to []string
+	for _, msg := range n.messages {
+		to = append(to, msg.To)
+	}
+	return to
+}
+
+// tokenFromNotification은 알림 본문에서 prefix로 시작하는 토큰을 추출합니다
+func tokenFromNotification(t *testing.T, body, prefix string) string {
+	t.Helper()
+	i := strings.Index(body, prefix)
+	if i < 0 {
+		t.Fatalf("Expected %s token in notification, got %q", prefix, body)
+	}
+	return strings.Fields(body[i:])[0]
+}
@@ -0,0 +1,181 @@
+package httputil
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+	"sync/atomic"
+)
+
+// trustedProxies는 전달 헤더(Forwarded, X-Forwarded-For, X-Real-IP)를 신뢰할 프록시의 주소 대역입니다
+// 서버 시작 시 SetTrustedProxies로 설정하며, 설정되지 않으면 어떤 프록시도 신뢰하지 않습니다 (헤더 무시)
+var trustedProxies atomic.Pointer[[]netip.Prefix]
+
+// SetTrustedProxies는 전달 헤더를 신뢰할 프록시의 주소 대역을 설정합니다
+func SetTrustedProxies(prefixes []netip.Prefix) {
+	trustedProxies.Store(&prefixes)
+}
+
+// ParseTrustedProxies는 쉼표로 구분된 CIDR 또는 IP 주소 목록을 파싱합니다
+// 예: "10.0.0.0/8, 192.168.1.10, fd00::/8" (IP 주소만 쓰면 해당 주소 하나만 신뢰)
+func ParseTrustedProxies(value string) ([]netip.Prefix, error) {
+	var prefixes []netip.Prefix
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		if strings.Contains(item, "/") {
+			prefix, err := netip.ParsePrefix(item)
+			if err != nil {
+				return nil, fmt.Errorf("invalid trusted proxy %q: %w", item, err)
+			}
+			prefixes = append(prefixes, prefix.Masked())
+			continue
+		}
+
+		addr, err := netip.ParseAddr(item)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy %q: %w", item, err)
+		}
+		addr = addr.Unmap()
+		prefixes = append(prefixes, netip.PrefixFrom(addr, addr.BitLen()))
+	}
+	return prefixes, nil
+}
+
+// isTrustedProxy는 주소가 신뢰하는 프록시 대역에 속하는지 확인합니다
+func isTrustedProxy(addr netip.Addr) bool {
+	prefixes := trustedProxies.Load()
+	if prefixes == nil {
+		return false
+	}
+	for _, prefix := range *prefixes {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// clientIPContextKey는 ClientIPMiddleware가 결정한 클라이언트 IP를 Context에 저장할 때 사용하는 키입니다
+type clientIPContextKey struct{}
+
+// ClientIPMiddleware는 요청마다 클라이언트 IP를 한 번 결정하여 Context에 저장하고,
+// RemoteAddr를 클라이언트 IP로 바꿔 이후의 로깅 미들웨어도 같은 IP를 기록하도록 합니다
+// 다른 미들웨어보다 먼저 등록해야 합니다
+func ClientIPMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ip := resolveClientIP(r)
+
+		r = r.WithContext(context.WithValue(r.Context(), clientIPContextKey{}, ip))
+		if _, port, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+			r.RemoteAddr = net.JoinHostPort(ip, port)
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// resolveClientIP는 직접 연결된 주소와 전달 헤더로 클라이언트 IP를 결정합니다
+// 직접 연결된 주소가 신뢰하는 프록시가 아니면 헤더는 클라이언트가 조작할 수 있으므로 무시합니다
+// 신뢰하는 프록시면 Forwarded(RFC 7239), X-Forwarded-For, X-Real-IP 순서로 확인하며,
+// 프록시 체인은 오른쪽(가장 가까운 프록시가 추가한 값)부터 거슬러 올라가 처음 만나는 신뢰하지 않는 주소를 클라이언트로 판단합니다
+func resolveClientIP(r *http.Request) string {
+	host := r.RemoteAddr
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+
+	peer, err := netip.ParseAddr(host)
+	if err != nil {
+		return host
+	}
+	peer = peer.Unmap()
+
+	if !isTrustedProxy(peer) {
+		return peer.String()
+	}
+
+	if values := r.Header.Values("Forwarded"); len(values) > 0 {
+		return walkProxyChain(peer, parseForwarded(values)).String()
+	}
+
+	if values := r.Header.Values("X-Forwarded-For"); len(values) > 0 {
+		var hops []string
+		for _, value := range values {
+			for _, hop := range strings.Split(value, ",") {
+				hops = append(hops, strings.TrimSpace(hop))
+			}
+		}
+		return walkProxyChain(peer, hops).String()
+	}
+
+	if realIP := r.Header.Get("X-Real-IP"); realIP != "" {
+		if addr, ok := parseHopAddr(realIP); ok {
+			return addr.String()
+		}
+	}
+
+	return peer.String()
+}
+
+// walkProxyChain은 프록시 체인(왼쪽이 클라이언트)을 오른쪽부터 거슬러 올라가 클라이언트 주소를 찾습니다
+// 해석할 수 없는 값(unknown, 난독화된 식별자 등)을 만나면 더 거슬러 올라가지 않고 마지막으로 확인한 주소를 반환합니다
+// 모든 주소가 신뢰하는 프록시면 가장 왼쪽 주소를 반환합니다
+func walkProxyChain(peer netip.Addr, hops []string) netip.Addr {
+	addr := peer
+	for i := len(hops) - 1; i >= 0; i-- {
+		if !isTrustedProxy(addr) {
+			return addr
+		}
+
+		hop, ok := parseHopAddr(hops[i])
+		if !ok {
+			return addr
+		}
+		addr = hop
+	}
+	return addr
+}
+
+// parseHopAddr는 프록시 체인의 값 하나를 IP 주소로 해석합니다
+// 포트가 붙은 주소("192.0.2.1:8080", "[2001:db8::1]:8080")와 대괄호로 감싼 IPv6 주소도 허용합니다
+func parseHopAddr(value string) (netip.Addr, bool) {
+	value = strings.TrimSpace(value)
+	if addrPort, err := netip.ParseAddrPort(value); err == nil {
+		return addrPort.Addr().Unmap(), true
+	}
+
+	value = strings.TrimSuffix(strings.TrimPrefix(value, "["), "]")
+	addr, err := netip.ParseAddr(value)
+	if err != nil {
+		return netip.Addr{}, false
+	}
+	return addr.Unmap(), true
+}
+
+// parseForwarded는 Forwarded 헤더 값들에서 for 파라미터를 순서대로 추출합니다 (RFC 7239)
+// 예: `for=192.0.2.60;proto=http, for="[2001:db8::1]:4711"`
+// for 파라미터가 없는 항목은 해석할 수 없는 값("")으로 추가합니다
+func parseForwarded(values []string) []string {
+	var hops []string
+	for _, value := range values {
+		for _, element := range strings.Split(value, ",") {
+			hop := ""
+			for _, pair := range strings.Split(element, ";") {
+				key, val, ok := strings.Cut(strings.TrimSpace(pair), "=")
+				if ok && strings.EqualFold(strings.TrimSpace(key), "for") {
+					hop = strings.Trim(strings.TrimSpace(val), `"`)
+					break
+				}
+			}
+			hops = append(hops, hop)
+		}
+	}
+	return hops
+}
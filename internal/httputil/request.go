@@ -2,37 +2,17 @@ package httputil
 
 import (
 	"net/http"
-	"strings"
 )
 
 // GetIPAddress는 HTTP 요청에서 클라이언트의 실제 IP 주소를 추출합니다
-// 프록시나 로드 밸런서를 거친 경우를 고려하여 다음 순서로 확인합니다:
-// 1. X-Forwarded-For (프록시 체인의 첫 번째 IP)
-// 2. X-Real-IP (프록시가 설정한 실제 IP)
-// 3. RemoteAddr (직접 연결된 클라이언트 IP)
+// ClientIPMiddleware를 거친 요청이면 미들웨어가 결정한 IP를 반환합니다
+// 전달 헤더(Forwarded, X-Forwarded-For, X-Real-IP)는 직접 연결된 주소가 SetTrustedProxies로 설정한
+// 신뢰하는 프록시일 때만 사용하며, 그 외에는 RemoteAddr(직접 연결된 클라이언트 IP)를 반환합니다
 func GetIPAddress(r *http.Request) string {
-	// X-Forwarded-For 헤더 확인 (쉼표로 구분된 IP 리스트)
-	forwarded := r.Header.Get("X-Forwarded-For")
-	if forwarded != "" {
-		// 첫 번째 IP가 실제 클라이언트 IP
-		ips := strings.Split(forwarded, ",")
-		return strings.TrimSpace(ips[0])
+	if ip, ok := r.Context().Value(clientIPContextKey{}).(string); ok {
+		return ip
 	}
-
-	// X-Real-IP 헤더 확인
-	realIP := r.Header.Get("X-Real-IP")
-	if realIP != "" {
-		return realIP
-	}
-
-	// RemoteAddr 사용 (포트 번호 제거)
-	// RemoteAddr 형식: "192.168.1.1:12345"
-	ip := r.RemoteAddr
-	if idx := strings.LastIndex(ip, ":"); idx != -1 {
-		ip = ip[:idx]
-	}
-
-	return ip
+	return resolveClientIP(r)
 }
 
 // GetUserAgent는 HTTP 요청에서 User-Agent 헤더를 추출합니다
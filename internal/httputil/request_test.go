@@ -2,12 +2,26 @@ package httputil
 
 import (
 	"net/http"
+	"net/http/httptest"
 	"testing"
 )
 
-// TestGetIPAddress_XForwardedFor는 X-Forwarded-For 헤더에서 IP를 추출하는지 테스트합니다
+// trustProxies는 테스트 동안 신뢰하는 프록시 대역을 설정하고, 테스트가 끝나면 초기화합니다
+func trustProxies(t *testing.T, value string) {
+	t.Helper()
+
+	prefixes, err := ParseTrustedProxies(value)
+	if err != nil {
+		t.Fatalf("failed to parse trusted proxies: %v", err)
+	}
+	SetTrustedProxies(prefixes)
+	t.Cleanup(func() { SetTrustedProxies(nil) })
+}
+
+// TestGetIPAddress_XForwardedFor는 신뢰하는 프록시를 거친 X-Forwarded-For 헤더에서 IP를 추출하는지 테스트합니다
 func TestGetIPAddress_XForwardedFor(t *testing.T) {
-	// Given: X-Forwarded-For 헤더가 포함된 요청
+	// Given: 신뢰하는 프록시(192.168.1.1)에서 온 X-Forwarded-For 헤더가 포함된 요청
+	trustProxies(t, "192.168.0.0/16")
 	req, _ := http.NewRequest("GET", "/test", nil)
 	req.Header.Set("X-Forwarded-For", "203.0.113.1, 198.51.100.1")
 	req.RemoteAddr = "192.168.1.1:12345"
@@ -15,16 +29,111 @@ func TestGetIPAddress_XForwardedFor(t *testing.T) {
 	// When: IP 주소 추출
 	ip := GetIPAddress(req)
 
-	// Then: X-Forwarded-For의 첫 번째 IP를 반환해야 함
+	// Then: 오른쪽부터 처음 만나는 신뢰하지 않는 IP를 반환해야 함 (203.0.113.1은 198.51.100.1이 조작할 수 있는 값)
+	expected := "198.51.100.1"
+	if ip != expected {
+		t.Errorf("Expected %s, got %s", expected, ip)
+	}
+}
+
+// TestGetIPAddress_ProxyChain은 신뢰하는 프록시가 여러 단계일 때 체인을 거슬러 올라가는지 테스트합니다
+func TestGetIPAddress_ProxyChain(t *testing.T) {
+	// Given: 두 단계의 신뢰하는 프록시(10.0.0.2, 192.168.1.1)를 거친 요청
+	trustProxies(t, "10.0.0.0/8, 192.168.1.1")
+	req, _ := http.NewRequest("GET", "/test", nil)
+	req.Header.Set("X-Forwarded-For", "1.2.3.4, 203.0.113.1, 10.0.0.2")
+	req.RemoteAddr = "192.168.1.1:12345"
+
+	// When: IP 주소 추출
+	ip := GetIPAddress(req)
+
+	// Then: 신뢰하는 프록시를 건너뛴 첫 번째 IP를 반환해야 함
 	expected := "203.0.113.1"
 	if ip != expected {
 		t.Errorf("Expected %s, got %s", expected, ip)
 	}
 }
 
-// TestGetIPAddress_XRealIP는 X-Real-IP 헤더에서 IP를 추출하는지 테스트합니다
+// TestGetIPAddress_UntrustedPeer는 신뢰하지 않는 주소에서 온 전달 헤더를 무시하는지 테스트합니다
+func TestGetIPAddress_UntrustedPeer(t *testing.T) {
+	// Given: 신뢰하는 프록시가 아닌 클라이언트가 IP를 위장한 요청
+	trustProxies(t, "10.0.0.0/8")
+	req, _ := http.NewRequest("GET", "/test", nil)
+	req.Header.Set("X-Forwarded-For", "203.0.113.1")
+	req.Header.Set("Forwarded", "for=203.0.113.2")
+	req.Header.Set("X-Real-IP", "203.0.113.3")
+	req.RemoteAddr = "198.51.100.7:12345"
+
+	// When: IP 주소 추출
+	ip := GetIPAddress(req)
+
+	// Then: 직접 연결된 IP를 반환해야 함
+	expected := "198.51.100.7"
+	if ip != expected {
+		t.Errorf("Expected %s, got %s", expected, ip)
+	}
+}
+
+// TestGetIPAddress_NoTrustedProxies는 신뢰하는 프록시를 설정하지 않으면 헤더를 무시하는지 테스트합니다
+func TestGetIPAddress_NoTrustedProxies(t *testing.T) {
+	// Given: 신뢰하는 프록시 설정 없이 X-Forwarded-For 헤더가 포함된 요청
+	req, _ := http.NewRequest("GET", "/test", nil)
+	req.Header.Set("X-Forwarded-For", "203.0.113.1")
+	req.RemoteAddr = "127.0.0.1:12345"
+
+	// When: IP 주소 추출
+	ip := GetIPAddress(req)
+
+	// Then: 직접 연결된 IP를 반환해야 함
+	expected := "127.0.0.1"
+	if ip != expected {
+		t.Errorf("Expected %s, got %s", expected, ip)
+	}
+}
+
+// TestGetIPAddress_Forwarded는 RFC 7239 Forwarded 헤더에서 IP를 추출하는지 테스트합니다
+func TestGetIPAddress_Forwarded(t *testing.T) {
+	trustProxies(t, "10.0.0.0/8")
+
+	tests := []struct {
+		name     string
+		headers  []string
+		expected string
+	}{
+		{"단일 항목", []string{"for=203.0.113.1;proto=https"}, "203.0.113.1"},
+		{"따옴표와 포트가 있는 IPv6", []string{`for="[2001:db8::1]:4711"`}, "2001:db8::1"},
+		{"따옴표와 포트가 있는 IPv4, 대소문자 무시", []string{`For="203.0.113.1:8080"`}, "203.0.113.1"},
+		{"신뢰하는 프록시 건너뛰기", []string{"for=203.0.113.1, for=10.0.0.5;by=10.0.0.1"}, "203.0.113.1"},
+		{"여러 헤더", []string{"for=203.0.113.1", "for=10.0.0.5"}, "203.0.113.1"},
+		{"해석할 수 없는 값에서 중단", []string{"for=203.0.113.1, for=unknown, for=10.0.0.5"}, "10.0.0.5"},
+		{"for가 없는 항목에서 중단", []string{"for=203.0.113.1, proto=https"}, "10.0.0.1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given: 신뢰하는 프록시(10.0.0.1)에서 온 Forwarded 헤더가 포함된 요청
+			req, _ := http.NewRequest("GET", "/test", nil)
+			for _, header := range tt.headers {
+				req.Header.Add("Forwarded", header)
+			}
+			req.Header.Set("X-Forwarded-For", "198.51.100.1")
+			req.RemoteAddr = "10.0.0.1:12345"
+
+			// When: IP 주소 추출
+			ip := GetIPAddress(req)
+
+			// Then: Forwarded 헤더가 X-Forwarded-For보다 우선해야 함
+			if ip != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, ip)
+			}
+		})
+	}
+}
+
+// TestGetIPAddress_XRealIP는 신뢰하는 프록시가 설정한 X-Real-IP 헤더에서 IP를 추출하는지 테스트합니다
 func TestGetIPAddress_XRealIP(t *testing.T) {
-	// Given: X-Real-IP 헤더가 포함된 요청 (X-Forwarded-For 없음)
+	// Given: 신뢰하는 프록시에서 온 X-Real-IP 헤더가 포함된 요청 (X-Forwarded-For 없음)
+	trustProxies(t, "192.168.0.0/16")
 	req, _ := http.NewRequest("GET", "/test", nil)
 	req.Header.Set("X-Real-IP", "203.0.113.5")
 	req.RemoteAddr = "192.168.1.1:12345"
@@ -41,23 +150,34 @@ func TestGetIPAddress_XRealIP(t *testing.T) {
 
 // TestGetIPAddress_RemoteAddr는 RemoteAddr에서 IP를 추출하는지 테스트합니다
 func TestGetIPAddress_RemoteAddr(t *testing.T) {
-	// Given: 헤더 없이 RemoteAddr만 있는 요청
-	req, _ := http.NewRequest("GET", "/test", nil)
-	req.RemoteAddr = "192.168.1.100:54321"
+	tests := []struct {
+		remoteAddr string
+		expected   string
+	}{
+		{"192.168.1.100:54321", "192.168.1.100"},
+		{"[2001:db8::1]:54321", "2001:db8::1"},
+		{"[::ffff:192.168.1.100]:54321", "192.168.1.100"},
+	}
 
-	// When: IP 주소 추출
-	ip := GetIPAddress(req)
+	for _, tt := range tests {
+		// Given: 헤더 없이 RemoteAddr만 있는 요청
+		req, _ := http.NewRequest("GET", "/test", nil)
+		req.RemoteAddr = tt.remoteAddr
 
-	// Then: RemoteAddr에서 포트를 제거한 IP를 반환해야 함
-	expected := "192.168.1.100"
-	if ip != expected {
-		t.Errorf("Expected %s, got %s", expected, ip)
+		// When: IP 주소 추출
+		ip := GetIPAddress(req)
+
+		// Then: RemoteAddr에서 포트를 제거한 IP를 반환해야 함
+		if ip != tt.expected {
+			t.Errorf("%s: expected %s, got %s", tt.remoteAddr, tt.expected, ip)
+		}
 	}
 }
 
 // TestGetIPAddress_Priority는 헤더 우선순위를 테스트합니다
 func TestGetIPAddress_Priority(t *testing.T) {
-	// Given: 모든 헤더가 포함된 요청
+	// Given: 신뢰하는 프록시에서 온 X-Forwarded-For와 X-Real-IP 헤더가 모두 포함된 요청
+	trustProxies(t, "192.168.0.0/16")
 	req, _ := http.NewRequest("GET", "/test", nil)
 	req.Header.Set("X-Forwarded-For", "203.0.113.1")
 	req.Header.Set("X-Real-IP", "203.0.113.5")
@@ -66,13 +186,73 @@ func TestGetIPAddress_Priority(t *testing.T) {
 	// When: IP 주소 추출
 	ip := GetIPAddress(req)
 
-	// Then: X-Forwarded-For가 최우선이어야 함
+	// Then: X-Forwarded-For가 X-Real-IP보다 우선해야 함
 	expected := "203.0.113.1"
 	if ip != expected {
 		t.Errorf("Expected %s, got %s", expected, ip)
 	}
 }
 
+// TestParseTrustedProxies는 신뢰하는 프록시 목록 파싱을 테스트합니다
+func TestParseTrustedProxies(t *testing.T) {
+	t.Run("CIDR와 IP 주소 혼합", func(t *testing.T) {
+		prefixes, err := ParseTrustedProxies(" 10.1.2.3/8, 192.168.1.10 ,fd00::/8,")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		expected := []string{"10.0.0.0/8", "192.168.1.10/32", "fd00::/8"}
+		if len(prefixes) != len(expected) {
+			t.Fatalf("expected %d prefixes, got %d", len(expected), len(prefixes))
+		}
+		for i, prefix := range prefixes {
+			if prefix.String() != expected[i] {
+				t.Errorf("expected %s, got %s", expected[i], prefix)
+			}
+		}
+	})
+
+	t.Run("빈 값", func(t *testing.T) {
+		prefixes, err := ParseTrustedProxies("")
+		if err != nil || len(prefixes) != 0 {
+			t.Errorf("expected no prefixes, got %v, %v", prefixes, err)
+		}
+	})
+
+	t.Run("잘못된 값", func(t *testing.T) {
+		for _, value := range []string{"10.0.0.0/33", "proxy.example.com"} {
+			if _, err := ParseTrustedProxies(value); err == nil {
+				t.Errorf("expected error for %q", value)
+			}
+		}
+	})
+}
+
+// TestClientIPMiddleware는 미들웨어가 결정한 클라이언트 IP를 이후 핸들러가 사용하는지 테스트합니다
+func TestClientIPMiddleware(t *testing.T) {
+	// Given: 신뢰하는 프록시를 거친 요청
+	trustProxies(t, "10.0.0.0/8")
+	req := httptest.NewRequest("GET", "/test", nil)
+	req.Header.Set("X-Forwarded-For", "203.0.113.1")
+	req.RemoteAddr = "10.0.0.1:12345"
+
+	// When: 미들웨어를 거쳐 핸들러에서 IP 주소 추출
+	var ip, remoteAddr string
+	handler := ClientIPMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ip = GetIPAddress(r)
+		remoteAddr = r.RemoteAddr
+	}))
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	// Then: 클라이언트 IP를 반환하고, 로깅용 RemoteAddr도 클라이언트 IP로 변경되어야 함
+	if ip != "203.0.113.1" {
+		t.Errorf("Expected 203.0.113.1, got %s", ip)
+	}
+	if remoteAddr != "203.0.113.1:12345" {
+		t.Errorf("Expected RemoteAddr 203.0.113.1:12345, got %s", remoteAddr)
+	}
+}
+
 // TestGetUserAgent는 User-Agent 헤더를 추출하는지 테스트합니다
 func TestGetUserAgent(t *testing.T) {
 	// Given: User-Agent 헤더가 포함된 요청
@@ -0,0 +1,115 @@
+package importer
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// SourceDisqus는 Disqus에서 가져온 댓글의 import_source 값입니다
+const SourceDisqus = "disqus"
+
+// ============================================
+// Disqus XML 구조
+// ============================================
+// Disqus 관리자 페이지의 Export 기능으로 받은 XML 파일 형식입니다.
+// <thread>와 <post>가 <disqus> 루트 아래에 평면적으로 나열되고,
+// post는 dsq:id 속성으로 소속 스레드와 부모 댓글을 참조합니다.
+//
+//	<disqus xmlns:dsq="http://disqus.com/disqus-internals">
+//	  <thread dsq:id="1"><link>https://blog.example.com/hello/</link><title>Hello</title></thread>
+//	  <post dsq:id="10"><message>...</message><thread dsq:id="1"/><parent dsq:id="9"/></post>
+//	</disqus>
+
+// disqusExport는 Disqus XML 파일의 루트 요소입니다
+type disqusExport struct {
+	Threads []disqusThread `xml:"thread"`
+	Posts   []disqusPost   `xml:"post"`
+}
+
+// disqusRef는 dsq:id 속성만 가진 참조 요소입니다 (<thread dsq:id="..."/>)
+type disqusRef struct {
+	ID string `xml:"id,attr"`
+}
+
+type disqusThread struct {
+	ID    string `xml:"id,attr"`
+	Link  string `xml:"link"`
+	Title string `xml:"title"`
+}
+
+type disqusPost struct {
+	ID        string       `xml:"id,attr"`
+	Message   string       `xml:"message"`
+	CreatedAt string       `xml:"createdAt"`
+	IsDeleted bool         `xml:"isDeleted"`
+	IsSpam    bool         `xml:"isSpam"`
+	Author    disqusAuthor `xml:"author"`
+	IPAddress string       `xml:"ipAddress"`
+	Thread    disqusRef    `xml:"thread"`
+	Parent    *disqusRef   `xml:"parent"`
+}
+
+type disqusAuthor struct {
+	Name     string `xml:"name"`
+	Username string `xml:"username"`
+}
+
+// ParseDisqus는 Disqus 내보내기 XML을 읽어 스레드 목록으로 변환합니다
+// 댓글은 dsq:id로 참조하는 스레드에 연결되며, 존재하지 않는 스레드를 참조하는 댓글은 무시됩니다
+func ParseDisqus(r io.Reader) ([]*Thread, error) {
+	var export disqusExport
+	if err := xml.NewDecoder(r).Decode(&export); err != nil {
+		return nil, fmt.Errorf("failed to parse disqus export: %w", err)
+	}
+
+	threads := make([]*Thread, 0, len(export.Threads))
+	byID := make(map[string]*Thread, len(export.Threads))
+	for _, t := range export.Threads {
+		thread := &Thread{
+			SourceID: t.ID,
+			Link:     strings.TrimSpace(t.Link),
+			Title:    strings.TrimSpace(t.Title),
+		}
+		threads = append(threads, thread)
+		byID[t.ID] = thread
+	}
+
+	for _, p := range export.Posts {
+		thread, ok := byID[p.Thread.ID]
+		if !ok {
+			continue
+		}
+
+		createdAt, err := time.Parse(time.RFC3339, strings.TrimSpace(p.CreatedAt))
+		if err != nil {
+			return nil, fmt.Errorf("invalid createdAt for post %s: %w", p.ID, err)
+		}
+
+		name := strings.TrimSpace(p.Author.Name)
+		if name == "" {
+			name = strings.TrimSpace(p.Author.Username)
+		}
+
+		comment := &Comment{
+			SourceID:   p.ID,
+			AuthorName: name,
+			Content:    p.Message,
+			IPAddress:  strings.TrimSpace(p.IPAddress),
+			CreatedAt:  createdAt,
+			IsDeleted:  p.IsDeleted,
+		}
+		if p.IsSpam {
+			comment.SkipReason = SkipReasonSpam
+		}
+		if p.Parent != nil {
+			comment.ParentSourceID = p.Parent.ID
+		}
+
+		thread.Comments = append(thread.Comments, comment)
+	}
+
+	return threads, nil
+}
@@ -0,0 +1,174 @@
+package importer
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// testDisqusXML은 Disqus 내보내기 형식의 테스트 데이터입니다
+// thread 1: 3-depth 대댓글, 삭제된 댓글, 스팸 댓글 포함
+// thread 2: slug로 변환할 수 없는 URL (루트 경로)
+const testDisqusXML = `<?xml version="1.0" encoding="utf-8"?>
+<disqus xmlns="http://disqus.com" xmlns:dsq="http://disqus.com/disqus-internals">
+  <category dsq:id="100">
+    <forum>myblog</forum>
+    <title>General</title>
+    <isDefault>true</isDefault>
+  </category>
+  <thread dsq:id="1">
+    <id>post-1</id>
+    <forum>myblog</forum>
+    <category dsq:id="100"/>
+    <link>https://blog.example.com/posts/hello-world/</link>
+    <title>Hello World</title>
+    <createdAt>2015-01-01T00:00:00Z</createdAt>
+  </thread>
+  <thread dsq:id="2">
+    <link>https://blog.example.com/</link>
+    <title>Home</title>
+  </thread>
+  <post dsq:id="10">
+    <message><![CDATA[<p>First comment</p><p>Second line</p>]]></message>
+    <createdAt>2015-01-02T10:00:00Z</createdAt>
+    <isDeleted>false</isDeleted>
+    <isSpam>false</isSpam>
+    <author>
+      <name>Alice</name>
+      <isAnonymous>false</isAnonymous>
+      <username>alice</username>
+    </author>
+    <ipAddress>203.0.113.1</ipAddress>
+    <thread dsq:id="1"/>
+  </post>
+  <post dsq:id="11">
+    <message><![CDATA[Reply to Alice]]></message>
+    <createdAt>2015-01-02T11:00:00Z</createdAt>
+    <isDeleted>false</isDeleted>
+    <isSpam>false</isSpam>
+    <author><name></name><username>bob</username></author>
+    <thread dsq:id="1"/>
+    <parent dsq:id="10"/>
+  </post>
+  <post dsq:id="12">
+    <message><![CDATA[Reply to Bob]]></message>
+    <createdAt>2015-01-02T12:00:00Z</createdAt>
+    <isDeleted>true</isDeleted>
+    <isSpam>false</isSpam>
+    <author><name>Carol</name></author>
+    <thread dsq:id="1"/>
+    <parent dsq:id="11"/>
+  </post>
+  <post dsq:id="13">
+    <message><![CDATA[Buy now]]></message>
+    <createdAt>2015-01-03T00:00:00Z</createdAt>
+    <isDeleted>false</isDeleted>
+    <isSpam>true</isSpam>
+    <author><name>Spammer</name></author>
+    <thread dsq:id="1"/>
+  </post>
+  <post dsq:id="20">
+    <message><![CDATA[Home comment]]></message>
+    <createdAt>2015-02-01T00:00:00Z</createdAt>
+    <author><name>Dave</name></author>
+    <thread dsq:id="2"/>
+  </post>
+  <post dsq:id="30">
+    <message><![CDATA[Orphan]]></message>
+    <createdAt>2015-02-01T00:00:00Z</createdAt>
+    <author><name>Eve</name></author>
+    <thread dsq:id="999"/>
+  </post>
+</disqus>`
+
+// TestParseDisqus_ValidExport_ReturnsThreadsWithComments는 Disqus XML을 스레드/댓글로 변환하는지 테스트합니다
+func TestParseDisqus_ValidExport_ReturnsThreadsWithComments(t *testing.T) {
+	// Given: Disqus 내보내기 XML
+
+	// When: 파싱
+	threads, err := ParseDisqus(strings.NewReader(testDisqusXML))
+
+	// Then: 스레드 2개, 존재하지 않는 스레드를 참조하는 댓글은 제외
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if len(threads) != 2 {
+		t.Fatalf("expected 2 threads, got %d", len(threads))
+	}
+
+	thread := threads[0]
+	if thread.SourceID != "1" {
+		t.Errorf("expected source_id=1, got %s", thread.SourceID)
+	}
+	if thread.Link != "https://blog.example.com/posts/hello-world/" {
+		t.Errorf("unexpected link: %s", thread.Link)
+	}
+	if thread.Title != "Hello World" {
+		t.Errorf("expected title=Hello World, got %s", thread.Title)
+	}
+	if len(thread.Comments) != 4 {
+		t.Fatalf("expected 4 comments in thread 1, got %d", len(thread.Comments))
+	}
+	if len(threads[1].Comments) != 1 {
+		t.Errorf("expected 1 comment in thread 2, got %d", len(threads[1].Comments))
+	}
+
+	first := thread.Comments[0]
+	if first.AuthorName != "Alice" {
+		t.Errorf("expected author=Alice, got %s", first.AuthorName)
+	}
+	if first.IPAddress != "203.0.113.1" {
+		t.Errorf("expected ip=203.0.113.1, got %s", first.IPAddress)
+	}
+	if !first.CreatedAt.Equal(time.Date(2015, 1, 2, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected created_at: %v", first.CreatedAt)
+	}
+	if first.ParentSourceID != "" {
+		t.Errorf("expected no parent, got %s", first.ParentSourceID)
+	}
+
+	// 이름이 없으면 username 사용
+	if thread.Comments[1].AuthorName != "bob" {
+		t.Errorf("expected author=bob, got %s", thread.Comments[1].AuthorName)
+	}
+	if thread.Comments[1].ParentSourceID != "10" {
+		t.Errorf("expected parent=10, got %s", thread.Comments[1].ParentSourceID)
+	}
+	if !thread.Comments[2].IsDeleted {
+		t.Error("expected comment 12 to be deleted")
+	}
+	if thread.Comments[3].SkipReason != SkipReasonSpam {
+		t.Error("expected comment 13 to be spam")
+	}
+}
+
+// TestParseDisqus_InvalidXML_ReturnsError는 잘못된 XML에서 에러를 반환하는지 테스트합니다
+func TestParseDisqus_InvalidXML_ReturnsError(t *testing.T) {
+	// Given: 닫히지 않은 XML
+	input := `<disqus><thread dsq:id="1">`
+
+	// When: 파싱
+	_, err := ParseDisqus(strings.NewReader(input))
+
+	// Then: 에러 반환
+	if err == nil {
+		t.Fatal("expected error for invalid xml, got nil")
+	}
+}
+
+// TestParseDisqus_InvalidCreatedAt_ReturnsError는 잘못된 작성 시각에서 에러를 반환하는지 테스트합니다
+func TestParseDisqus_InvalidCreatedAt_ReturnsError(t *testing.T) {
+	// Given: createdAt 형식이 잘못된 댓글
+	input := `<disqus xmlns:dsq="http://disqus.com/disqus-internals">
+  <thread dsq:id="1"><link>https://example.com/a</link></thread>
+  <post dsq:id="10"><createdAt>yesterday</createdAt><thread dsq:id="1"/></post>
+</disqus>`
+
+	// When: 파싱
+	_, err := ParseDisqus(strings.NewReader(input))
+
+	// Then: 에러 반환
+	if err == nil {
+		t.Fatal("expected error for invalid createdAt, got nil")
+	}
+}
@@ -0,0 +1,432 @@
+package importer
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/june20516/orbithall/internal/database"
+	"github.com/june20516/orbithall/internal/models"
+	"github.com/june20516/orbithall/internal/sanitizer"
+)
+
+// ============================================
+// 공통 데이터 구조
+// ============================================
+// 각 플랫폼별 파서(Disqus, WordPress 등)는 내보내기 파일을 아래 구조로 변환하고,
+// Import가 이 구조를 Orbithall의 posts/comments로 저장합니다.
+
+// Thread는 외부 플랫폼의 댓글 스레드(= 블로그 포스트 하나)를 나타냅니다
+type Thread struct {
+	// SourceID는 원본 플랫폼에서의 스레드 ID입니다
+	SourceID string
+
+	// Link는 원본 포스트의 URL입니다
+	Link string
+
+	// Title은 원본 포스트의 제목입니다
+	Title string
+
+	// Slug는 Orbithall 포스트 slug입니다
+	// 비어있으면 Import 시 Options.SlugRule로 Link에서 추출합니다
+	Slug string
+
+	// Comments는 스레드에 달린 댓글 목록입니다 (순서 무관, 계층은 ParentSourceID로 표현)
+	Comments []*Comment
+}
+
+// Comment는 외부 플랫폼의 댓글 하나를 나타냅니다
+type Comment struct {
+	// SourceID는 원본 플랫폼에서의 댓글 ID입니다 (중복 가져오기 방지에 사용)
+	SourceID string
+
+	// ParentSourceID는 부모 댓글의 원본 ID입니다 (최상위 댓글이면 빈 문자열)
+	ParentSourceID string
+
+	AuthorName string
+	Content    string
+	IPAddress  string
+	UserAgent  string
+	CreatedAt  time.Time
+
+	// IsDeleted는 원본 플랫폼에서 삭제된 댓글인지 여부입니다
+	IsDeleted bool
+
+	// SkipReason이 비어있지 않으면 가져오지 않는 댓글입니다 (예: "spam", "trash")
+	// 파서가 플랫폼별 상태와 옵션에 따라 설정하며, 결과 보고서에 사유별로 집계됩니다
+	SkipReason string
+}
+
+// 댓글을 가져오지 않는 사유
+const (
+	SkipReasonSpam     = "spam"     // 스팸으로 분류된 댓글
+	SkipReasonTrash    = "trash"    // 휴지통으로 이동된 댓글
+	SkipReasonPending  = "pending"  // 승인 대기 중인 댓글
+	SkipReasonPingback = "pingback" // 핑백/트랙백 (사람이 작성한 댓글이 아님)
+)
+
+// ============================================
+// slug 변환 규칙
+// ============================================
+
+// SlugRule은 원본 포스트 URL을 Orbithall slug로 변환합니다
+// 변환할 수 없는 URL이면 ok=false를 반환합니다
+type SlugRule func(link string) (slug string, ok bool)
+
+// LastPathSegment는 URL 경로의 마지막 세그먼트를 slug로 사용하는 기본 규칙입니다
+// 예: "https://blog.example.com/posts/hello-world/" → "hello-world"
+func LastPathSegment(link string) (string, bool) {
+	u, err := url.Parse(strings.TrimSpace(link))
+	if err != nil {
+		return "", false
+	}
+
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+	slug := segments[len(segments)-1]
+	if slug == "" {
+		return "", false
+	}
+
+	// 퍼센트 인코딩된 slug(예: 한글)는 디코딩하여 사용
+	if decoded, err := url.PathUnescape(slug); err == nil {
+		slug = decoded
+	}
+
+	return slug, true
+}
+
+// NewPatternSlugRule은 정규식으로 URL에서 slug를 추출하는 규칙을 생성합니다
+// 정규식에 "slug"라는 이름의 그룹이 있으면 그 값을, 없으면 첫 번째 캡처 그룹을 slug로 사용합니다
+// 예: `/blog/\d{4}/\d{2}/(?P<slug>[^/]+)` → "https://example.com/blog/2020/01/hello/" 에서 "hello"
+func NewPatternSlugRule(pattern string) (SlugRule, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid slug pattern: %w", err)
+	}
+	if re.NumSubexp() == 0 {
+		return nil, fmt.Errorf("slug pattern must contain a capture group")
+	}
+
+	group := re.SubexpIndex("slug")
+	if group == -1 {
+		group = 1
+	}
+
+	return func(link string) (string, bool) {
+		match := re.FindStringSubmatch(link)
+		if match == nil || match[group] == "" {
+			return "", false
+		}
+		return match[group], true
+	}, nil
+}
+
+// ============================================
+// 가져오기
+// ============================================
+
+// Options는 가져오기 동작을 설정합니다
+type Options struct {
+	// Source는 comments.import_source에 기록될 플랫폼 이름입니다 (예: "disqus")
+	Source string
+
+	// SlugRule은 Slug가 비어있는 스레드의 URL을 slug로 변환합니다 (nil이면 LastPathSegment)
+	SlugRule SlugRule
+
+	// DryRun이 true면 데이터베이스에 쓰지 않고 매핑 결과만 보고합니다
+	DryRun bool
+}
+
+// UnmappedThread는 slug로 변환하지 못해 가져오지 않은 스레드입니다
+type UnmappedThread struct {
+	SourceID     string `json:"source_id"`
+	Link         string `json:"link"`
+	Title        string `json:"title"`
+	CommentCount int    `json:"comment_count"`
+}
+
+// Report는 가져오기 결과 요약입니다
+type Report struct {
+	// ThreadsTotal은 파일에 포함된 전체 스레드 수입니다
+	ThreadsTotal int `json:"threads_total"`
+
+	// ThreadsImported는 포스트에 매핑되어 댓글을 가져온 스레드 수입니다
+	ThreadsImported int `json:"threads_imported"`
+
+	// ThreadsEmpty는 slug는 결정되었지만 가져올 댓글이 없어 포스트를 만들지 않은 스레드 수입니다
+	ThreadsEmpty int `json:"threads_empty"`
+
+	// CommentsImported는 새로 저장된 댓글 수입니다
+	CommentsImported int `json:"comments_imported"`
+
+	// CommentsDuplicated는 이미 가져온 적이 있어 건너뛴 댓글 수입니다
+	CommentsDuplicated int `json:"comments_duplicated"`
+
+	// CommentsSkipped는 가져오지 않은 댓글 수를 사유별로 집계합니다 (예: {"spam": 3})
+	CommentsSkipped map[string]int `json:"comments_skipped"`
+
+	// RepliesFlattened는 최대 depth(1)를 넘어 최상위 조상 댓글의 대댓글로 옮겨진 댓글 수입니다
+	RepliesFlattened int `json:"replies_flattened"`
+
+	// UnmappedThreads는 slug를 결정하지 못해 가져오지 않은 스레드 목록입니다
+	UnmappedThreads []UnmappedThread `json:"unmapped_threads"`
+
+	// DryRun은 실제로 저장하지 않은 미리보기 결과인지 여부입니다
+	DryRun bool `json:"dry_run"`
+}
+
+// skip은 가져오지 않은 댓글을 사유별로 집계합니다
+func (r *Report) skip(reason string) {
+	if r.CommentsSkipped == nil {
+		r.CommentsSkipped = map[string]int{}
+	}
+	r.CommentsSkipped[reason]++
+}
+
+// Import는 파싱된 스레드 목록을 사이트의 posts/comments로 저장합니다
+//
+// 처리 규칙:
+//   - 스레드 → 포스트: slug로 매핑하며, 포스트가 없으면 스레드 제목으로 생성합니다
+//   - 계층 구조: Orbithall은 1-depth 대댓글만 허용하므로 더 깊은 대댓글은 최상위 조상 댓글의 대댓글로 옮깁니다
+//   - 부모가 파일에 없거나 가져오지 않는 댓글(스팸 등)이면 최상위 댓글로 가져옵니다
+//   - 기존 포스트의 제목이 slug와 같거나 URL이 비어있으면 원본 제목과 스레드 URL로 채웁니다
+//   - 원본 작성 시각과 삭제 여부를 유지하며, 내용은 위젯 작성 댓글과 동일하게 새니타이징합니다
+//
+// 전체 작업은 하나의 트랜잭션에서 실행되어 중간에 실패하면 아무것도 저장되지 않습니다
+func Import(ctx context.Context, db database.DBTX, siteID int64, threads []*Thread, opts Options) (*Report, error) {
+	if opts.SlugRule == nil {
+		opts.SlugRule = LastPathSegment
+	}
+
+	report := &Report{
+		ThreadsTotal:    len(threads),
+		CommentsSkipped: map[string]int{},
+		UnmappedThreads: []UnmappedThread{},
+		DryRun:          opts.DryRun,
+	}
+
+	// 1단계: 스레드별 slug 결정 및 댓글 정렬 (DB 접근 없음)
+	mapped := make([]*Thread, 0, len(threads))
+	plans := make([][]*plannedComment, 0, len(threads))
+	for _, thread := range threads {
+		slug := thread.Slug
+		if slug == "" {
+			var ok bool
+			slug, ok = opts.SlugRule(thread.Link)
+			if !ok {
+				report.UnmappedThreads = append(report.UnmappedThreads, UnmappedThread{
+					SourceID:     thread.SourceID,
+					Link:         thread.Link,
+					Title:        thread.Title,
+					CommentCount: len(thread.Comments),
+				})
+				continue
+			}
+		}
+		thread.Slug = truncate(slug, 255)
+
+		// 댓글 정렬 (부모 → 자식 순서), 가져올 댓글이 없으면 포스트를 만들지 않음
+		plan := planComments(thread.Comments, report)
+		if len(plan) == 0 {
+			report.ThreadsEmpty++
+			continue
+		}
+		mapped = append(mapped, thread)
+		plans = append(plans, plan)
+	}
+	report.ThreadsImported = len(mapped)
+
+	if opts.DryRun {
+		for _, plan := range plans {
+			report.CommentsImported += len(plan)
+		}
+		return report, nil
+	}
+
+	// 2단계: 트랜잭션 안에서 저장
+	err := database.RunInTx(ctx, db, func(tx database.DBTX) error {
+		for i, thread := range mapped {
+			if err := importThread(ctx, tx, siteID, thread, plans[i], opts.Source, report); err != nil {
+				return fmt.Errorf("thread %s: %w", thread.SourceID, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+// plannedComment는 저장 순서와 최종 부모가 결정된 댓글입니다
+type plannedComment struct {
+	comment *Comment
+	// rootSourceID는 대댓글이 연결될 최상위 댓글의 원본 ID입니다 (최상위 댓글이면 빈 문자열)
+	rootSourceID string
+}
+
+// planComments는 댓글의 최종 부모를 결정하고 저장 순서를 정합니다
+// 최상위 댓글을 먼저, 그 다음 대댓글을 각각 작성 시각 순으로 반환합니다
+func planComments(comments []*Comment, report *Report) []*plannedComment {
+	// 가져오지 않는 댓글을 제외하고 원본 ID로 색인
+	byID := make(map[string]*Comment, len(comments))
+	for _, c := range comments {
+		if c.SkipReason != "" {
+			report.skip(c.SkipReason)
+			continue
+		}
+		byID[c.SourceID] = c
+	}
+
+	var roots, replies []*plannedComment
+	for _, c := range comments {
+		if c.SkipReason != "" {
+			continue
+		}
+
+		// 부모 체인을 따라 최상위 조상을 찾음
+		root, depth := findRoot(c, byID)
+
+		if root == c {
+			roots = append(roots, &plannedComment{comment: c})
+			continue
+		}
+		if depth > 1 {
+			report.RepliesFlattened++
+		}
+		replies = append(replies, &plannedComment{comment: c, rootSourceID: root.SourceID})
+	}
+
+	byCreatedAt := func(list []*plannedComment) {
+		sort.SliceStable(list, func(i, j int) bool {
+			return list[i].comment.CreatedAt.Before(list[j].comment.CreatedAt)
+		})
+	}
+	byCreatedAt(roots)
+	byCreatedAt(replies)
+
+	return append(roots, replies...)
+}
+
+// findRoot는 부모 체인을 따라 올라가 최상위 조상 댓글과 그 거리(depth)를 반환합니다
+// 부모가 없거나 색인에 없는 댓글을 최상위로 보며,
+// 잘못된 파일로 부모 체인이 순환하면 시작 댓글 자신을 최상위로 취급합니다
+func findRoot(c *Comment, byID map[string]*Comment) (*Comment, int) {
+	visited := map[*Comment]bool{c: true}
+	root := c
+	depth := 0
+	for root.ParentSourceID != "" {
+		parent, ok := byID[root.ParentSourceID]
+		if !ok {
+			break
+		}
+		if visited[parent] {
+			return c, 0
+		}
+		visited[parent] = true
+		root = parent
+		depth++
+	}
+	return root, depth
+}
+
+// importThread는 스레드 하나를 포스트로 매핑하고 댓글을 저장합니다
+func importThread(ctx context.Context, db database.DBTX, siteID int64, thread *Thread, plan []*plannedComment, source string, report *Report) error {
+	title := strings.TrimSpace(thread.Title)
+	if title == "" {
+		title = thread.Slug
+	}
+
+	title = truncate(title, 500)
+
+	// 가져온 댓글은 항상 실제 데이터로 저장 (샌드박스는 테스트 모드 키 전용)
+	post, err := database.GetOrCreatePost(ctx, db, siteID, thread.Slug, title, false)
+	if err != nil {
+		return err
+	}
+
+	// 위젯이 먼저 만든 포스트는 slug가 제목으로 저장되어 있으므로 원본 제목과 URL로 채움
+	if _, err := database.FillPostMetadata(ctx, db, post.ID, title, truncate(thread.Link, 2048)); err != nil {
+		return err
+	}
+
+	// 원본 ID → 저장된 댓글 ID
+	savedIDs := make(map[string]int64, len(plan))
+
+	for _, p := range plan {
+		c := p.comment
+
+		var parentID *int64
+		if p.rootSourceID != "" {
+			id := savedIDs[p.rootSourceID]
+			parentID = &id
+		}
+
+		authorName := truncate(strings.TrimSpace(sanitizer.SanitizeComment(c.AuthorName)), 100)
+		if authorName == "" {
+			authorName = "Anonymous"
+		}
+
+		// IP 주소로 파싱할 수 없는 값은 버림 (암호화/마스킹 대상이 아님)
+		ipAddress := c.IPAddress
+		if net.ParseIP(ipAddress) == nil {
+			ipAddress = ""
+		}
+
+		comment := &models.Comment{
+			PostID:     post.ID,
+			ParentID:   parentID,
+			AuthorName: authorName,
+			Content:    sanitizeContent(c.Content),
+			IPAddress:  ipAddress,
+			UserAgent:  c.UserAgent,
+			IsDeleted:  c.IsDeleted,
+			CreatedAt:  c.CreatedAt,
+		}
+
+		created, err := database.ImportComment(ctx, db, comment, source, c.SourceID)
+		if err != nil {
+			return err
+		}
+		savedIDs[c.SourceID] = comment.ID
+
+		if !created {
+			report.CommentsDuplicated++
+			continue
+		}
+		report.CommentsImported++
+
+		if !c.IsDeleted {
+			if err := database.IncrementCommentCount(ctx, db, post.ID); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// paragraphBreak는 문단/줄바꿈 태그를 찾는 정규식입니다
+var paragraphBreak = regexp.MustCompile(`(?i)<br\s*/?>|</p>`)
+
+// sanitizeContent는 HTML 댓글 본문을 일반 텍스트로 변환합니다
+// 문단 구분을 줄바꿈으로 바꾼 뒤 위젯 댓글과 같은 정책으로 모든 태그를 제거합니다
+func sanitizeContent(content string) string {
+	content = paragraphBreak.ReplaceAllString(content, "\n")
+	return strings.TrimSpace(sanitizer.SanitizeComment(content))
+}
+
+// truncate는 문자열을 최대 rune 수로 자릅니다 (DB 컬럼 길이 제한 대응)
+func truncate(s string, max int) string {
+	runes := []rune(s)
+	if len(runes) <= max {
+		return s
+	}
+	return string(runes[:max])
+}
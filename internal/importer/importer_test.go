@@ -0,0 +1,296 @@
+package importer
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/june20516/orbithall/internal/database"
+	"github.com/june20516/orbithall/internal/testhelpers"
+)
+
+// TestLastPathSegment는 기본 slug 규칙을 테스트합니다
+func TestLastPathSegment(t *testing.T) {
+	tests := []struct {
+		name     string
+		link     string
+		expected string
+		ok       bool
+	}{
+		{"마지막 세그먼트 추출", "https://blog.example.com/posts/hello-world", "hello-world", true},
+		{"끝의 슬래시 무시", "https://blog.example.com/posts/hello-world/", "hello-world", true},
+		{"쿼리 문자열 무시", "https://blog.example.com/hello?utm=1", "hello", true},
+		{"퍼센트 인코딩 디코딩", "https://blog.example.com/%ED%95%9C%EA%B8%80", "한글", true},
+		{"루트 경로는 변환 불가", "https://blog.example.com/", "", false},
+		{"빈 URL은 변환 불가", "", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// When: slug 변환
+			slug, ok := LastPathSegment(tt.link)
+
+			// Then: 기대값과 일치
+			if ok != tt.ok || slug != tt.expected {
+				t.Errorf("LastPathSegment(%q) = (%q, %v), want (%q, %v)", tt.link, slug, ok, tt.expected, tt.ok)
+			}
+		})
+	}
+}
+
+// TestNewPatternSlugRule은 정규식 slug 규칙을 테스트합니다
+func TestNewPatternSlugRule(t *testing.T) {
+	t.Run("이름 있는 그룹 사용", func(t *testing.T) {
+		// Given: slug 그룹이 있는 패턴
+		rule, err := NewPatternSlugRule(`/(\d{4})/(?P<slug>[^/]+)/?$`)
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+
+		// When: URL 변환
+		slug, ok := rule("https://example.com/blog/2020/my-post/")
+
+		// Then: slug 그룹 값 반환
+		if !ok || slug != "my-post" {
+			t.Errorf("expected (my-post, true), got (%q, %v)", slug, ok)
+		}
+	})
+
+	t.Run("첫 번째 캡처 그룹 사용", func(t *testing.T) {
+		// Given: 이름 없는 그룹 하나
+		rule, err := NewPatternSlugRule(`/articles/([^/?]+)`)
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+
+		// When: 매칭되는 URL과 매칭되지 않는 URL 변환
+		slug, ok := rule("https://example.com/articles/go-tips?x=1")
+		_, notOK := rule("https://example.com/about")
+
+		// Then: 매칭된 URL만 변환
+		if !ok || slug != "go-tips" {
+			t.Errorf("expected (go-tips, true), got (%q, %v)", slug, ok)
+		}
+		if notOK {
+			t.Error("expected unmatched URL to fail")
+		}
+	})
+
+	t.Run("캡처 그룹이 없으면 에러", func(t *testing.T) {
+		// When: 그룹 없는 패턴
+		_, err := NewPatternSlugRule(`/articles/.+`)
+
+		// Then: 에러 반환
+		if err == nil {
+			t.Fatal("expected error for pattern without group, got nil")
+		}
+	})
+
+	t.Run("잘못된 정규식이면 에러", func(t *testing.T) {
+		// When: 컴파일 불가능한 패턴
+		_, err := NewPatternSlugRule(`(`)
+
+		// Then: 에러 반환
+		if err == nil {
+			t.Fatal("expected error for invalid pattern, got nil")
+		}
+	})
+}
+
+// TestImport_DryRun_ReportsMappingWithoutDB는 DryRun 시 DB 없이 매핑 결과만 보고하는지 테스트합니다
+func TestImport_DryRun_ReportsMappingWithoutDB(t *testing.T) {
+	// Given: 파싱된 Disqus 스레드
+	threads, err := ParseDisqus(strings.NewReader(testDisqusXML))
+	if err != nil {
+		t.Fatalf("failed to parse: %v", err)
+	}
+
+	// When: DryRun으로 가져오기 (db는 nil)
+	report, err := Import(context.Background(), nil, 1, threads, Options{Source: SourceDisqus, DryRun: true})
+
+	// Then: 매핑 결과 보고
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if report.ThreadsTotal != 2 {
+		t.Errorf("expected threads_total=2, got %d", report.ThreadsTotal)
+	}
+	if report.ThreadsImported != 1 {
+		t.Errorf("expected threads_imported=1, got %d", report.ThreadsImported)
+	}
+	if len(report.UnmappedThreads) != 1 || report.UnmappedThreads[0].SourceID != "2" {
+		t.Errorf("expected thread 2 to be unmapped, got %+v", report.UnmappedThreads)
+	}
+	if report.UnmappedThreads[0].CommentCount != 1 {
+		t.Errorf("expected unmapped comment_count=1, got %d", report.UnmappedThreads[0].CommentCount)
+	}
+	if report.CommentsImported != 3 {
+		t.Errorf("expected comments_imported=3, got %d", report.CommentsImported)
+	}
+	if report.CommentsSkipped[SkipReasonSpam] != 1 {
+		t.Errorf("expected 1 skipped spam, got %v", report.CommentsSkipped)
+	}
+	if report.RepliesFlattened != 1 {
+		t.Errorf("expected replies_flattened=1, got %d", report.RepliesFlattened)
+	}
+	if !report.DryRun {
+		t.Error("expected dry_run=true")
+	}
+}
+
+// TestPlanComments는 댓글 저장 순서와 부모 결정 규칙을 테스트합니다
+func TestPlanComments(t *testing.T) {
+	base := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("최상위 댓글이 대댓글보다 먼저 오고 깊은 대댓글은 최상위 조상에 연결", func(t *testing.T) {
+		// Given: a ← b ← c (3-depth), c가 가장 먼저 작성된 것처럼 순서가 섞여 있음
+		comments := []*Comment{
+			{SourceID: "c", ParentSourceID: "b", CreatedAt: base.Add(3 * time.Hour)},
+			{SourceID: "b", ParentSourceID: "a", CreatedAt: base.Add(2 * time.Hour)},
+			{SourceID: "a", CreatedAt: base.Add(1 * time.Hour)},
+		}
+		report := &Report{}
+
+		// When: 계획 수립
+		plan := planComments(comments, report)
+
+		// Then: a, b, c 순서, b와 c 모두 a에 연결
+		if len(plan) != 3 {
+			t.Fatalf("expected 3 planned comments, got %d", len(plan))
+		}
+		if plan[0].comment.SourceID != "a" || plan[0].rootSourceID != "" {
+			t.Errorf("expected root a first, got %+v", plan[0])
+		}
+		if plan[1].comment.SourceID != "b" || plan[1].rootSourceID != "a" {
+			t.Errorf("expected b under a, got %+v", plan[1])
+		}
+		if plan[2].comment.SourceID != "c" || plan[2].rootSourceID != "a" {
+			t.Errorf("expected c flattened under a, got %+v", plan[2])
+		}
+		if report.RepliesFlattened != 1 {
+			t.Errorf("expected replies_flattened=1, got %d", report.RepliesFlattened)
+		}
+	})
+
+	t.Run("부모가 스팸이거나 없으면 최상위 댓글로 처리", func(t *testing.T) {
+		// Given: 스팸 부모를 가진 댓글과 존재하지 않는 부모를 가진 댓글
+		comments := []*Comment{
+			{SourceID: "spam", SkipReason: SkipReasonSpam, CreatedAt: base},
+			{SourceID: "x", ParentSourceID: "spam", CreatedAt: base.Add(time.Hour)},
+			{SourceID: "y", ParentSourceID: "missing", CreatedAt: base.Add(2 * time.Hour)},
+		}
+		report := &Report{}
+
+		// When: 계획 수립
+		plan := planComments(comments, report)
+
+		// Then: 스팸 제외, 나머지는 최상위
+		if len(plan) != 2 {
+			t.Fatalf("expected 2 planned comments, got %d", len(plan))
+		}
+		for _, p := range plan {
+			if p.rootSourceID != "" {
+				t.Errorf("expected %s to be top-level, got root %s", p.comment.SourceID, p.rootSourceID)
+			}
+		}
+		if report.CommentsSkipped[SkipReasonSpam] != 1 {
+			t.Errorf("expected 1 skipped spam, got %v", report.CommentsSkipped)
+		}
+	})
+
+	t.Run("순환 참조는 최상위 댓글로 처리", func(t *testing.T) {
+		// Given: 서로를 부모로 참조하는 댓글
+		comments := []*Comment{
+			{SourceID: "p", ParentSourceID: "q", CreatedAt: base},
+			{SourceID: "q", ParentSourceID: "p", CreatedAt: base.Add(time.Hour)},
+		}
+
+		// When: 계획 수립
+		plan := planComments(comments, &Report{})
+
+		// Then: 둘 다 최상위
+		for _, p := range plan {
+			if p.rootSourceID != "" {
+				t.Errorf("expected %s to be top-level, got root %s", p.comment.SourceID, p.rootSourceID)
+			}
+		}
+	})
+}
+
+// TestImport_Disqus는 Disqus 데이터를 실제 DB에 가져오는 과정을 테스트합니다
+func TestImport_Disqus(t *testing.T) {
+	db := testhelpers.SetupTestDB(t)
+	defer database.Close(db)
+
+	ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+	defer cleanup()
+
+	site := testhelpers.CreateTestSite(ctx, t, tx, "Import Site", "import.com", []string{"http://localhost:3000"}, true)
+
+	threads, err := ParseDisqus(strings.NewReader(testDisqusXML))
+	if err != nil {
+		t.Fatalf("failed to parse: %v", err)
+	}
+
+	t.Run("스레드를 포스트로 매핑하고 댓글 계층과 시각을 유지", func(t *testing.T) {
+		// When: 가져오기
+		report, err := Import(ctx, tx, site.ID, threads, Options{Source: SourceDisqus})
+
+		// Then: 댓글 3개 저장
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if report.CommentsImported != 3 {
+			t.Errorf("expected comments_imported=3, got %d", report.CommentsImported)
+		}
+
+		post, err := database.GetPostBySlug(ctx, tx, site.ID, "hello-world", false)
+		if err != nil || post == nil {
+			t.Fatalf("expected post hello-world, got %v (err=%v)", post, err)
+		}
+		if post.Title != "Hello World" {
+			t.Errorf("expected title=Hello World, got %s", post.Title)
+		}
+
+		comments, total, err := database.GetAdminComments(ctx, tx, post.ID, 50, 0)
+		if err != nil {
+			t.Fatalf("failed to list comments: %v", err)
+		}
+		if total != 1 || len(comments) != 1 {
+			t.Fatalf("expected 1 top-level comment, got %d", total)
+		}
+		root := comments[0]
+		if root.Content != "First comment\nSecond line" {
+			t.Errorf("unexpected content: %q", root.Content)
+		}
+		if !root.CreatedAt.Equal(time.Date(2015, 1, 2, 10, 0, 0, 0, time.UTC)) {
+			t.Errorf("expected original created_at, got %v", root.CreatedAt)
+		}
+		if root.IPAddress != "203.0.113.1" {
+			t.Errorf("expected ip=203.0.113.1, got %s", root.IPAddress)
+		}
+		if len(root.Replies) != 2 {
+			t.Fatalf("expected 2 replies (one flattened), got %d", len(root.Replies))
+		}
+		if !root.Replies[1].IsDeleted || root.Replies[1].DeletedAt == nil {
+			t.Error("expected flattened reply to keep deleted flag")
+		}
+	})
+
+	t.Run("같은 파일을 다시 가져오면 중복 생성하지 않음", func(t *testing.T) {
+		// When: 재가져오기
+		report, err := Import(ctx, tx, site.ID, threads, Options{Source: SourceDisqus})
+
+		// Then: 새로 저장된 댓글 없음
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if report.CommentsImported != 0 {
+			t.Errorf("expected comments_imported=0, got %d", report.CommentsImported)
+		}
+		if report.CommentsDuplicated != 3 {
+			t.Errorf("expected comments_duplicated=3, got %d", report.CommentsDuplicated)
+		}
+	})
+}
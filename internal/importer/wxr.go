@@ -0,0 +1,184 @@
+package importer
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// SourceWordPress는 WordPress에서 가져온 댓글의 import_source 값입니다
+const SourceWordPress = "wordpress"
+
+// ============================================
+// WordPress WXR 구조
+// ============================================
+// WordPress 관리자 페이지의 도구 → 내보내기 기능으로 받은 WXR(RSS 확장) 파일 형식입니다.
+// 글(<item>)마다 wp:post_name(slug)과 그 글에 달린 wp:comment 목록이 들어있고,
+// 댓글은 wp:comment_parent로 같은 글의 부모 댓글 ID를 참조합니다 ("0"이면 최상위).
+//
+//	<rss xmlns:wp="http://wordpress.org/export/1.2/">
+//	  <channel>
+//	    <item>
+//	      <title>Hello</title>
+//	      <link>https://blog.example.com/2020/01/hello/</link>
+//	      <wp:post_name>hello</wp:post_name>
+//	      <wp:comment>
+//	        <wp:comment_id>5</wp:comment_id>
+//	        <wp:comment_approved>1</wp:comment_approved>
+//	        <wp:comment_parent>0</wp:comment_parent>
+//	        ...
+//	      </wp:comment>
+//	    </item>
+//	  </channel>
+//	</rss>
+//
+// wp 네임스페이스 URL은 WXR 버전(1.0~1.2)마다 다르므로 요소 이름만으로 매칭합니다.
+
+// wxrDateLayout은 wp:comment_date(_gmt)의 시각 형식입니다
+const wxrDateLayout = "2006-01-02 15:04:05"
+
+// wxrIgnoredPostTypes는 댓글 스레드로 취급하지 않는 WordPress 글 유형입니다
+var wxrIgnoredPostTypes = map[string]bool{
+	"attachment":    true,
+	"nav_menu_item": true,
+	"revision":      true,
+}
+
+// wxrExport는 WXR 파일의 루트 요소입니다
+type wxrExport struct {
+	Items []wxrItem `xml:"channel>item"`
+}
+
+type wxrItem struct {
+	Title    string       `xml:"title"`
+	Link     string       `xml:"link"`
+	PostID   string       `xml:"post_id"`
+	PostName string       `xml:"post_name"`
+	PostType string       `xml:"post_type"`
+	Comments []wxrComment `xml:"comment"`
+}
+
+type wxrComment struct {
+	ID       string `xml:"comment_id"`
+	Author   string `xml:"comment_author"`
+	AuthorIP string `xml:"comment_author_IP"`
+	Date     string `xml:"comment_date"`
+	DateGMT  string `xml:"comment_date_gmt"`
+	Content  string `xml:"comment_content"`
+	Approved string `xml:"comment_approved"`
+	Type     string `xml:"comment_type"`
+	Parent   string `xml:"comment_parent"`
+}
+
+// WXROptions는 WordPress 댓글 상태별 처리 방식을 설정합니다
+// 승인된 댓글은 항상 가져오고, 승인 대기 중인 댓글과 핑백/트랙백은 항상 건너뜁니다
+type WXROptions struct {
+	// IncludeSpam이 true면 스팸 댓글을 삭제된 댓글로 가져옵니다 (기본값: 건너뜀)
+	IncludeSpam bool
+
+	// IncludeTrash가 true면 휴지통의 댓글을 삭제된 댓글로 가져옵니다 (기본값: 건너뜀)
+	IncludeTrash bool
+}
+
+// ParseWordPress는 WordPress WXR 파일을 읽어 스레드 목록으로 변환합니다
+// wp:post_name을 스레드의 slug로 사용하며, post_name이 없는 글(임시 글 등)은 URL로 slug를 결정합니다
+func ParseWordPress(r io.Reader, opts WXROptions) ([]*Thread, error) {
+	var export wxrExport
+	if err := xml.NewDecoder(r).Decode(&export); err != nil {
+		return nil, fmt.Errorf("failed to parse wordpress export: %w", err)
+	}
+
+	threads := make([]*Thread, 0, len(export.Items))
+	for _, item := range export.Items {
+		if wxrIgnoredPostTypes[strings.TrimSpace(item.PostType)] {
+			continue
+		}
+
+		// WordPress는 한글 등 비ASCII slug를 퍼센트 인코딩하여 저장함
+		slug := strings.TrimSpace(item.PostName)
+		if decoded, err := url.PathUnescape(slug); err == nil {
+			slug = decoded
+		}
+
+		thread := &Thread{
+			SourceID: strings.TrimSpace(item.PostID),
+			Link:     strings.TrimSpace(item.Link),
+			Title:    strings.TrimSpace(item.Title),
+			Slug:     slug,
+		}
+
+		for _, c := range item.Comments {
+			comment, err := convertWXRComment(c, opts)
+			if err != nil {
+				return nil, err
+			}
+			thread.Comments = append(thread.Comments, comment)
+		}
+
+		threads = append(threads, thread)
+	}
+
+	return threads, nil
+}
+
+// convertWXRComment는 wp:comment를 Comment로 변환하고 승인 상태에 따라 SkipReason/IsDeleted를 결정합니다
+func convertWXRComment(c wxrComment, opts WXROptions) (*Comment, error) {
+	id := strings.TrimSpace(c.ID)
+
+	createdAt, err := parseWXRDate(c.DateGMT, c.Date)
+	if err != nil {
+		return nil, fmt.Errorf("invalid comment_date for comment %s: %w", id, err)
+	}
+
+	comment := &Comment{
+		SourceID:   id,
+		AuthorName: strings.TrimSpace(c.Author),
+		Content:    c.Content,
+		IPAddress:  strings.TrimSpace(c.AuthorIP),
+		CreatedAt:  createdAt,
+	}
+	if parent := strings.TrimSpace(c.Parent); parent != "" && parent != "0" {
+		comment.ParentSourceID = parent
+	}
+
+	switch strings.TrimSpace(c.Type) {
+	case "", "comment":
+	default:
+		// pingback, trackback 등
+		comment.SkipReason = SkipReasonPingback
+		return comment, nil
+	}
+
+	switch strings.TrimSpace(c.Approved) {
+	case "1":
+	case "spam":
+		if opts.IncludeSpam {
+			comment.IsDeleted = true
+		} else {
+			comment.SkipReason = SkipReasonSpam
+		}
+	case "trash", "post-trashed":
+		if opts.IncludeTrash {
+			comment.IsDeleted = true
+		} else {
+			comment.SkipReason = SkipReasonTrash
+		}
+	default:
+		// "0": 승인 대기
+		comment.SkipReason = SkipReasonPending
+	}
+
+	return comment, nil
+}
+
+// parseWXRDate는 comment_date_gmt를 UTC로 해석합니다
+// 일부 내보내기 파일은 GMT 시각이 "0000-00-00 00:00:00"이므로 이때는 comment_date(사이트 현지 시각)를 UTC로 간주합니다
+func parseWXRDate(gmt, local string) (time.Time, error) {
+	if t, err := time.Parse(wxrDateLayout, strings.TrimSpace(gmt)); err == nil {
+		return t, nil
+	}
+	return time.Parse(wxrDateLayout, strings.TrimSpace(local))
+}
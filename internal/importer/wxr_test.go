@@ -0,0 +1,241 @@
+package importer
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/june20516/orbithall/internal/database"
+	"github.com/june20516/orbithall/internal/testhelpers"
+)
+
+// testWXR은 WordPress 내보내기(WXR) 형식의 테스트 데이터입니다
+// item 1: 한글 slug, 대댓글, 스팸/휴지통/승인 대기/핑백 댓글 포함
+// item 2: 첨부파일 (스레드로 취급하지 않음)
+const testWXR = `<?xml version="1.0" encoding="UTF-8" ?>
+<rss version="2.0"
+	xmlns:content="http://purl.org/rss/1.0/modules/content/"
+	xmlns:dc="http://purl.org/dc/elements/1.1/"
+	xmlns:wp="http://wordpress.org/export/1.2/">
+<channel>
+	<title>My Blog</title>
+	<link>https://blog.example.com</link>
+	<wp:wxr_version>1.2</wp:wxr_version>
+	<item>
+		<title>안녕하세요 WordPress</title>
+		<link>https://blog.example.com/2020/01/%ec%95%88%eb%85%95/</link>
+		<content:encoded><![CDATA[본문]]></content:encoded>
+		<wp:post_id>7</wp:post_id>
+		<wp:post_name><![CDATA[%ec%95%88%eb%85%95]]></wp:post_name>
+		<wp:status><![CDATA[publish]]></wp:status>
+		<wp:post_type><![CDATA[post]]></wp:post_type>
+		<wp:comment>
+			<wp:comment_id>1</wp:comment_id>
+			<wp:comment_author><![CDATA[Alice]]></wp:comment_author>
+			<wp:comment_author_email><![CDATA[alice@example.com]]></wp:comment_author_email>
+			<wp:comment_author_IP><![CDATA[198.51.100.7]]></wp:comment_author_IP>
+			<wp:comment_date><![CDATA[2020-01-02 19:00:00]]></wp:comment_date>
+			<wp:comment_date_gmt><![CDATA[2020-01-02 10:00:00]]></wp:comment_date_gmt>
+			<wp:comment_content><![CDATA[First!]]></wp:comment_content>
+			<wp:comment_approved><![CDATA[1]]></wp:comment_approved>
+			<wp:comment_type><![CDATA[comment]]></wp:comment_type>
+			<wp:comment_parent>0</wp:comment_parent>
+		</wp:comment>
+		<wp:comment>
+			<wp:comment_id>2</wp:comment_id>
+			<wp:comment_author><![CDATA[Bob]]></wp:comment_author>
+			<wp:comment_author_IP><![CDATA[198.51.100.8]]></wp:comment_author_IP>
+			<wp:comment_date><![CDATA[2020-01-02 20:00:00]]></wp:comment_date>
+			<wp:comment_date_gmt><![CDATA[0000-00-00 00:00:00]]></wp:comment_date_gmt>
+			<wp:comment_content><![CDATA[Reply to Alice]]></wp:comment_content>
+			<wp:comment_approved><![CDATA[1]]></wp:comment_approved>
+			<wp:comment_type><![CDATA[]]></wp:comment_type>
+			<wp:comment_parent>1</wp:comment_parent>
+		</wp:comment>
+		<wp:comment>
+			<wp:comment_id>3</wp:comment_id>
+			<wp:comment_author><![CDATA[Spammer]]></wp:comment_author>
+			<wp:comment_date_gmt><![CDATA[2020-01-03 10:00:00]]></wp:comment_date_gmt>
+			<wp:comment_content><![CDATA[Buy now]]></wp:comment_content>
+			<wp:comment_approved><![CDATA[spam]]></wp:comment_approved>
+			<wp:comment_parent>0</wp:comment_parent>
+		</wp:comment>
+		<wp:comment>
+			<wp:comment_id>4</wp:comment_id>
+			<wp:comment_author><![CDATA[Carol]]></wp:comment_author>
+			<wp:comment_date_gmt><![CDATA[2020-01-04 10:00:00]]></wp:comment_date_gmt>
+			<wp:comment_content><![CDATA[Removed later]]></wp:comment_content>
+			<wp:comment_approved><![CDATA[trash]]></wp:comment_approved>
+			<wp:comment_parent>0</wp:comment_parent>
+		</wp:comment>
+		<wp:comment>
+			<wp:comment_id>5</wp:comment_id>
+			<wp:comment_author><![CDATA[Dave]]></wp:comment_author>
+			<wp:comment_date_gmt><![CDATA[2020-01-05 10:00:00]]></wp:comment_date_gmt>
+			<wp:comment_content><![CDATA[Waiting]]></wp:comment_content>
+			<wp:comment_approved><![CDATA[0]]></wp:comment_approved>
+			<wp:comment_parent>0</wp:comment_parent>
+		</wp:comment>
+		<wp:comment>
+			<wp:comment_id>6</wp:comment_id>
+			<wp:comment_author><![CDATA[Other Blog]]></wp:comment_author>
+			<wp:comment_date_gmt><![CDATA[2020-01-06 10:00:00]]></wp:comment_date_gmt>
+			<wp:comment_content><![CDATA[Linked]]></wp:comment_content>
+			<wp:comment_approved><![CDATA[1]]></wp:comment_approved>
+			<wp:comment_type><![CDATA[pingback]]></wp:comment_type>
+			<wp:comment_parent>0</wp:comment_parent>
+		</wp:comment>
+	</item>
+	<item>
+		<title>image.png</title>
+		<link>https://blog.example.com/image-png/</link>
+		<wp:post_id>8</wp:post_id>
+		<wp:post_name><![CDATA[image-png]]></wp:post_name>
+		<wp:post_type><![CDATA[attachment]]></wp:post_type>
+	</item>
+</channel>
+</rss>`
+
+// TestParseWordPress는 WXR 파싱과 댓글 상태별 처리를 테스트합니다
+func TestParseWordPress(t *testing.T) {
+	t.Run("post_name을 slug로, 제목과 댓글 계층을 유지", func(t *testing.T) {
+		// When: 기본 옵션으로 파싱
+		threads, err := ParseWordPress(strings.NewReader(testWXR), WXROptions{})
+
+		// Then: 첨부파일을 제외한 스레드 1개
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if len(threads) != 1 {
+			t.Fatalf("expected 1 thread, got %d", len(threads))
+		}
+
+		thread := threads[0]
+		if thread.Slug != "안녕" {
+			t.Errorf("expected decoded slug 안녕, got %q", thread.Slug)
+		}
+		if thread.Title != "안녕하세요 WordPress" {
+			t.Errorf("unexpected title: %q", thread.Title)
+		}
+		if thread.SourceID != "7" {
+			t.Errorf("expected source id 7, got %q", thread.SourceID)
+		}
+		if len(thread.Comments) != 6 {
+			t.Fatalf("expected 6 comments, got %d", len(thread.Comments))
+		}
+
+		first := thread.Comments[0]
+		if first.IPAddress != "198.51.100.7" || first.AuthorName != "Alice" || first.ParentSourceID != "" {
+			t.Errorf("unexpected first comment: %+v", first)
+		}
+		if !first.CreatedAt.Equal(time.Date(2020, 1, 2, 10, 0, 0, 0, time.UTC)) {
+			t.Errorf("expected GMT created_at, got %v", first.CreatedAt)
+		}
+
+		reply := thread.Comments[1]
+		if reply.ParentSourceID != "1" {
+			t.Errorf("expected parent 1, got %q", reply.ParentSourceID)
+		}
+		if !reply.CreatedAt.Equal(time.Date(2020, 1, 2, 20, 0, 0, 0, time.UTC)) {
+			t.Errorf("expected local date fallback, got %v", reply.CreatedAt)
+		}
+	})
+
+	t.Run("기본 옵션이면 스팸/휴지통/승인 대기/핑백을 건너뜀", func(t *testing.T) {
+		// When: 기본 옵션으로 파싱
+		threads, err := ParseWordPress(strings.NewReader(testWXR), WXROptions{})
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+
+		// Then: 사유별 SkipReason 설정
+		expected := []string{"", "", SkipReasonSpam, SkipReasonTrash, SkipReasonPending, SkipReasonPingback}
+		for i, c := range threads[0].Comments {
+			if c.SkipReason != expected[i] {
+				t.Errorf("comment %s: expected skip reason %q, got %q", c.SourceID, expected[i], c.SkipReason)
+			}
+		}
+	})
+
+	t.Run("옵션을 지정하면 스팸/휴지통을 삭제된 댓글로 가져옴", func(t *testing.T) {
+		// When: 스팸/휴지통 포함 옵션으로 파싱
+		threads, err := ParseWordPress(strings.NewReader(testWXR), WXROptions{IncludeSpam: true, IncludeTrash: true})
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+
+		// Then: 스팸/휴지통은 삭제 상태로 포함
+		for _, c := range threads[0].Comments[2:4] {
+			if c.SkipReason != "" || !c.IsDeleted {
+				t.Errorf("comment %s: expected deleted import, got %+v", c.SourceID, c)
+			}
+		}
+	})
+
+	t.Run("잘못된 XML이면 에러", func(t *testing.T) {
+		// When: 깨진 XML 파싱
+		_, err := ParseWordPress(strings.NewReader("<rss><channel><item>"), WXROptions{})
+
+		// Then: 에러 반환
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+	})
+}
+
+// TestImport_WordPress는 WordPress 데이터를 실제 DB에 가져오는 과정을 테스트합니다
+func TestImport_WordPress(t *testing.T) {
+	db := testhelpers.SetupTestDB(t)
+	defer database.Close(db)
+
+	ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+	defer cleanup()
+
+	site := testhelpers.CreateTestSite(ctx, t, tx, "WP Site", "wp.com", []string{"http://localhost:3000"}, true)
+
+	// Given: 위젯이 먼저 만든 포스트 (slug가 제목으로 저장됨)
+	if _, err := database.GetOrCreatePost(ctx, tx, site.ID, "안녕", "안녕", false); err != nil {
+		t.Fatalf("failed to create post: %v", err)
+	}
+
+	threads, err := ParseWordPress(strings.NewReader(testWXR), WXROptions{})
+	if err != nil {
+		t.Fatalf("failed to parse: %v", err)
+	}
+
+	// When: 가져오기
+	report, err := Import(ctx, tx, site.ID, threads, Options{Source: SourceWordPress})
+
+	// Then: 승인된 댓글 2개 저장, 나머지는 사유별 집계
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if report.CommentsImported != 2 {
+		t.Errorf("expected comments_imported=2, got %d", report.CommentsImported)
+	}
+	if len(report.CommentsSkipped) != 4 {
+		t.Errorf("expected 4 skip reasons, got %v", report.CommentsSkipped)
+	}
+
+	post, err := database.GetPostBySlug(ctx, tx, site.ID, "안녕", false)
+	if err != nil || post == nil {
+		t.Fatalf("expected post 안녕, got %v (err=%v)", post, err)
+	}
+	if post.Title != "안녕하세요 WordPress" {
+		t.Errorf("expected placeholder title to be replaced, got %q", post.Title)
+	}
+	if post.CommentCount != 2 {
+		t.Errorf("expected comment_count=2, got %d", post.CommentCount)
+	}
+
+	comments, _, err := database.GetAdminComments(ctx, tx, post.ID, 50, 0)
+	if err != nil {
+		t.Fatalf("failed to list comments: %v", err)
+	}
+	if len(comments) != 1 || len(comments[0].Replies) != 1 {
+		t.Fatalf("expected 1 comment with 1 reply, got %+v", comments)
+	}
+	if comments[0].Replies[0].IPAddress != "198.51.100.8" {
+		t.Errorf("expected reply ip=198.51.100.8, got %s", comments[0].Replies[0].IPAddress)
+	}
+}
@@ -0,0 +1,202 @@
+package ipcrypt
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"strings"
+)
+
+var (
+	// ErrUnknownKey는 암호문의 키 ID가 키링에 없을 때 반환됩니다 (폐기된 키로 암호화된 경우)
+	ErrUnknownKey = errors.New("unknown ip encryption key")
+
+	// ErrInvalidCiphertext는 암호문 형식이 잘못되었거나 인증에 실패했을 때 반환됩니다
+	ErrInvalidCiphertext = errors.New("invalid ip ciphertext")
+)
+
+// developmentSecret은 키가 설정되지 않은 개발 환경에서 사용하는 고정 키의 원천입니다
+// 운영 환경에서는 사용되지 않습니다 (LoadFromEnv 참고)
+const developmentSecret = "orbithall-development-ip-key"
+
+// Keyring은 IP 주소 암호화(AES-256-GCM)와 조회용 해시(HMAC-SHA256)에 사용하는 키 묶음입니다
+// 키 교체를 위해 여러 암호화 키를 ID로 구분하여 보관하며, 새로 암호화할 때는 활성 키만 사용합니다
+// 해시 키는 교체하면 기존 해시로 조회할 수 없으므로 하나만 사용합니다
+type Keyring struct {
+	activeID string
+	aeads    map[string]cipher.AEAD
+	hashKey  []byte
+}
+
+// NewKeyring은 암호화 키(키 ID → 32바이트 키)와 해시 키로 키링을 생성합니다
+// activeID는 새로 암호화할 때 사용할 키의 ID이며, keys에 포함되어야 합니다
+func NewKeyring(activeID string, keys map[string][]byte, hashKey []byte) (*Keyring, error) {
+	if _, ok := keys[activeID]; !ok {
+		return nil, fmt.Errorf("active key %q not found", activeID)
+	}
+	if len(hashKey) < 32 {
+		return nil, fmt.Errorf("hash key must be at least 32 bytes")
+	}
+
+	aeads := make(map[string]cipher.AEAD, len(keys))
+	for id, key := range keys {
+		if id == "" || strings.Contains(id, ":") {
+			return nil, fmt.Errorf("invalid key id %q", id)
+		}
+		if len(key) != 32 {
+			return nil, fmt.Errorf("key %q must be 32 bytes", id)
+		}
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create cipher for key %q: %w", id, err)
+		}
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create gcm for key %q: %w", id, err)
+		}
+		aeads[id] = aead
+	}
+
+	return &Keyring{activeID: activeID, aeads: aeads, hashKey: hashKey}, nil
+}
+
+// LoadFromEnv는 환경변수에서 키링을 생성합니다
+// IP_ENCRYPTION_KEYS: "키ID:base64키" 목록 (쉼표 구분, 첫 번째가 활성 키)
+// IP_HASH_KEY: base64 해시 키 (32바이트 이상)
+// 두 환경변수가 모두 없으면 production에서는 에러를, 그 외 환경에서는 개발용 키링을 반환합니다
+func LoadFromEnv() (*Keyring, error) {
+	keysSpec := os.Getenv("IP_ENCRYPTION_KEYS")
+	hashKeySpec := os.Getenv("IP_HASH_KEY")
+
+	if keysSpec == "" && hashKeySpec == "" {
+		if os.Getenv("ENV") == "production" {
+			return nil, fmt.Errorf("IP_ENCRYPTION_KEYS and IP_HASH_KEY environment variables are required")
+		}
+		log.Println("[WARN] IP_ENCRYPTION_KEYS not set, using development IP encryption key")
+		return Development(), nil
+	}
+	if keysSpec == "" || hashKeySpec == "" {
+		return nil, fmt.Errorf("both IP_ENCRYPTION_KEYS and IP_HASH_KEY environment variables are required")
+	}
+
+	// 암호화 키 파싱
+	keys := make(map[string][]byte)
+	var activeID string
+	for _, entry := range strings.Split(keysSpec, ",") {
+		id, encoded, ok := strings.Cut(strings.TrimSpace(entry), ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid IP_ENCRYPTION_KEYS entry %q (expected id:base64key)", entry)
+		}
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("invalid base64 key for %q: %w", id, err)
+		}
+		if _, exists := keys[id]; exists {
+			return nil, fmt.Errorf("duplicate key id %q", id)
+		}
+		keys[id] = key
+		if activeID == "" {
+			activeID = id
+		}
+	}
+
+	// 해시 키 파싱
+	hashKey, err := base64.StdEncoding.DecodeString(hashKeySpec)
+	if err != nil {
+		return nil, fmt.Errorf("invalid base64 IP_HASH_KEY: %w", err)
+	}
+
+	return NewKeyring(activeID, keys, hashKey)
+}
+
+// Development는 개발/테스트용 고정 키링을 반환합니다
+// 키가 공개되어 있으므로 운영 환경에서 사용하면 안 됩니다
+func Development() *Keyring {
+	key := sha256.Sum256([]byte(developmentSecret + ":encryption"))
+	hashKey := sha256.Sum256([]byte(developmentSecret + ":hash"))
+
+	keyring, err := NewKeyring("dev", map[string][]byte{"dev": key[:]}, hashKey[:])
+	if err != nil {
+		panic(err) // 고정 값이므로 발생하지 않음
+	}
+	return keyring
+}
+
+// ActiveKeyID는 새로 암호화할 때 사용하는 키의 ID를 반환합니다
+func (k *Keyring) ActiveKeyID() string {
+	return k.activeID
+}
+
+// Encrypt는 IP 주소를 활성 키로 암호화합니다
+// 결과 형식: "키ID:base64(nonce + 암호문)"
+func (k *Keyring) Encrypt(ip string) (string, error) {
+	aead := k.aeads[k.activeID]
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	sealed := aead.Seal(nonce, nonce, []byte(Normalize(ip)), []byte(k.activeID))
+	return k.activeID + ":" + base64.RawStdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt는 Encrypt로 암호화된 IP 주소를 복호화합니다
+// 암호문의 키 ID가 키링에 없으면 ErrUnknownKey를 반환합니다
+func (k *Keyring) Decrypt(value string) (string, error) {
+	id, encoded, ok := strings.Cut(value, ":")
+	if !ok {
+		return "", ErrInvalidCiphertext
+	}
+
+	aead, ok := k.aeads[id]
+	if !ok {
+		return "", ErrUnknownKey
+	}
+
+	sealed, err := base64.RawStdEncoding.DecodeString(encoded)
+	if err != nil || len(sealed) < aead.NonceSize() {
+		return "", ErrInvalidCiphertext
+	}
+
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, ciphertext, []byte(id))
+	if err != nil {
+		return "", ErrInvalidCiphertext
+	}
+
+	return string(plaintext), nil
+}
+
+// Hash는 IP 주소의 조회용 해시(HMAC-SHA256, hex)를 반환합니다
+// 같은 IP는 표기가 달라도 (예: IPv6 축약) 같은 해시가 되도록 정규화 후 계산합니다
+func (k *Keyring) Hash(ip string) string {
+	mac := hmac.New(sha256.New, k.hashKey)
+	mac.Write([]byte(Normalize(ip)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// KeyID는 암호문을 만든 키의 ID를 반환합니다 (키 교체 대상 판별용)
+func KeyID(value string) string {
+	id, _, _ := strings.Cut(value, ":")
+	return id
+}
+
+// Normalize는 IP 주소를 표준 표기로 변환합니다
+// 파싱할 수 없는 값은 공백만 제거하여 그대로 반환합니다
+func Normalize(ip string) string {
+	ip = strings.TrimSpace(ip)
+	if parsed := net.ParseIP(ip); parsed != nil {
+		return parsed.String()
+	}
+	return ip
+}
@@ -0,0 +1,184 @@
+package ipcrypt
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// testKey는 테스트용 32바이트 키를 생성합니다
+func testKey(b byte) []byte {
+	return bytes.Repeat([]byte{b}, 32)
+}
+
+func TestEncryptDecrypt(t *testing.T) {
+	keyring, err := NewKeyring("k1", map[string][]byte{"k1": testKey(1)}, testKey(9))
+	if err != nil {
+		t.Fatalf("NewKeyring failed: %v", err)
+	}
+
+	t.Run("암호화 후 복호화하면 원래 IP 반환", func(t *testing.T) {
+		encrypted, err := keyring.Encrypt("192.168.1.100")
+		if err != nil {
+			t.Fatalf("Encrypt failed: %v", err)
+		}
+		if !strings.HasPrefix(encrypted, "k1:") {
+			t.Errorf("Expected key id prefix, got %s", encrypted)
+		}
+		if strings.Contains(encrypted, "192.168") {
+			t.Errorf("Ciphertext should not contain plaintext: %s", encrypted)
+		}
+
+		decrypted, err := keyring.Decrypt(encrypted)
+		if err != nil {
+			t.Fatalf("Decrypt failed: %v", err)
+		}
+		if decrypted != "192.168.1.100" {
+			t.Errorf("Expected 192.168.1.100, got %s", decrypted)
+		}
+	})
+
+	t.Run("같은 IP도 매번 다른 암호문", func(t *testing.T) {
+		a, _ := keyring.Encrypt("10.0.0.1")
+		b, _ := keyring.Encrypt("10.0.0.1")
+		if a == b {
+			t.Error("Expected different ciphertexts for the same IP")
+		}
+	})
+
+	t.Run("변조된 암호문은 ErrInvalidCiphertext", func(t *testing.T) {
+		encrypted, _ := keyring.Encrypt("10.0.0.1")
+		tampered := encrypted[:len(encrypted)-2] + "AA"
+		if tampered == encrypted {
+			tampered = encrypted[:len(encrypted)-2] + "BB"
+		}
+
+		if _, err := keyring.Decrypt(tampered); !errors.Is(err, ErrInvalidCiphertext) {
+			t.Errorf("Expected ErrInvalidCiphertext, got %v", err)
+		}
+		if _, err := keyring.Decrypt("not-encrypted"); !errors.Is(err, ErrInvalidCiphertext) {
+			t.Errorf("Expected ErrInvalidCiphertext, got %v", err)
+		}
+	})
+}
+
+func TestKeyRotation(t *testing.T) {
+	// Given: 이전 키로 암호화된 값
+	old, _ := NewKeyring("k1", map[string][]byte{"k1": testKey(1)}, testKey(9))
+	encrypted, _ := old.Encrypt("10.0.0.1")
+
+	// When: 새 키를 활성화하고 이전 키는 복호화용으로 유지
+	rotated, err := NewKeyring("k2", map[string][]byte{"k1": testKey(1), "k2": testKey(2)}, testKey(9))
+	if err != nil {
+		t.Fatalf("NewKeyring failed: %v", err)
+	}
+
+	// Then: 이전 암호문 복호화 가능, 새 암호문은 새 키 사용, 해시는 동일
+	if decrypted, err := rotated.Decrypt(encrypted); err != nil || decrypted != "10.0.0.1" {
+		t.Errorf("Expected to decrypt old ciphertext, got %s (err=%v)", decrypted, err)
+	}
+	reencrypted, _ := rotated.Encrypt("10.0.0.1")
+	if KeyID(reencrypted) != "k2" || KeyID(encrypted) != "k1" {
+		t.Errorf("Unexpected key ids: %s, %s", KeyID(encrypted), KeyID(reencrypted))
+	}
+	if old.Hash("10.0.0.1") != rotated.Hash("10.0.0.1") {
+		t.Error("Expected hash to be stable across key rotation")
+	}
+
+	// 폐기된 키로 암호화된 값은 ErrUnknownKey
+	retired, _ := NewKeyring("k2", map[string][]byte{"k2": testKey(2)}, testKey(9))
+	if _, err := retired.Decrypt(encrypted); !errors.Is(err, ErrUnknownKey) {
+		t.Errorf("Expected ErrUnknownKey, got %v", err)
+	}
+}
+
+func TestHash(t *testing.T) {
+	keyring, _ := NewKeyring("k1", map[string][]byte{"k1": testKey(1)}, testKey(9))
+	other, _ := NewKeyring("k1", map[string][]byte{"k1": testKey(1)}, testKey(8))
+
+	if keyring.Hash("2001:0db8:0000:0000:0000:0000:0000:0001") != keyring.Hash("2001:db8::1") {
+		t.Error("Expected same hash for equivalent IPv6 notations")
+	}
+	if keyring.Hash("10.0.0.1") == keyring.Hash("10.0.0.2") {
+		t.Error("Expected different hashes for different IPs")
+	}
+	if keyring.Hash("10.0.0.1") == other.Hash("10.0.0.1") {
+		t.Error("Expected hash to depend on the hash key")
+	}
+}
+
+func TestNewKeyring_Invalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		activeID string
+		keys     map[string][]byte
+		hashKey  []byte
+	}{
+		{"활성 키 없음", "k2", map[string][]byte{"k1": testKey(1)}, testKey(9)},
+		{"키 길이 오류", "k1", map[string][]byte{"k1": []byte("short")}, testKey(9)},
+		{"해시 키 길이 오류", "k1", map[string][]byte{"k1": testKey(1)}, []byte("short")},
+		{"키 ID에 콜론 포함", "k:1", map[string][]byte{"k:1": testKey(1)}, testKey(9)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewKeyring(tt.activeID, tt.keys, tt.hashKey); err == nil {
+				t.Error("Expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestLoadFromEnv(t *testing.T) {
+	encode := func(b byte) string {
+		return base64.StdEncoding.EncodeToString(testKey(b))
+	}
+
+	t.Run("첫 번째 키가 활성 키", func(t *testing.T) {
+		t.Setenv("IP_ENCRYPTION_KEYS", "2024-06:"+encode(2)+", 2024-01:"+encode(1))
+		t.Setenv("IP_HASH_KEY", encode(9))
+
+		keyring, err := LoadFromEnv()
+		if err != nil {
+			t.Fatalf("LoadFromEnv failed: %v", err)
+		}
+		if keyring.ActiveKeyID() != "2024-06" {
+			t.Errorf("Expected active key 2024-06, got %s", keyring.ActiveKeyID())
+		}
+	})
+
+	t.Run("production에서 키가 없으면 에러", func(t *testing.T) {
+		t.Setenv("ENV", "production")
+		t.Setenv("IP_ENCRYPTION_KEYS", "")
+		t.Setenv("IP_HASH_KEY", "")
+
+		if _, err := LoadFromEnv(); err == nil {
+			t.Error("Expected error, got nil")
+		}
+	})
+
+	t.Run("개발 환경에서 키가 없으면 개발용 키링", func(t *testing.T) {
+		t.Setenv("ENV", "development")
+		t.Setenv("IP_ENCRYPTION_KEYS", "")
+		t.Setenv("IP_HASH_KEY", "")
+
+		keyring, err := LoadFromEnv()
+		if err != nil {
+			t.Fatalf("LoadFromEnv failed: %v", err)
+		}
+		if keyring.ActiveKeyID() != "dev" {
+			t.Errorf("Expected development keyring, got %s", keyring.ActiveKeyID())
+		}
+	})
+
+	t.Run("해시 키만 없으면 에러", func(t *testing.T) {
+		t.Setenv("IP_ENCRYPTION_KEYS", "k1:"+encode(1))
+		t.Setenv("IP_HASH_KEY", "")
+
+		if _, err := LoadFromEnv(); err == nil {
+			t.Error("Expected error, got nil")
+		}
+	})
+}
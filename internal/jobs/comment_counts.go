@@ -0,0 +1,26 @@
+package jobs
+
+import (
+	"context"
+	"log"
+
+	"github.com/june20516/orbithall/internal/database"
+)
+
+// ReconcileCommentCounts는 모든 사이트의 comment_count를 실제 댓글 수로 보정합니다
+// 보정된 포스트가 있으면 포스트별 차이를 로그로 남깁니다
+func ReconcileCommentCounts(db database.DBTX) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		drifts, err := database.ReconcileCommentCounts(ctx, db, 0, false)
+		if err != nil {
+			return err
+		}
+
+		for _, drift := range drifts {
+			log.Printf("[WARN] Comment count drift fixed (site_id=%d, post_id=%d, slug=%s): %d -> %d",
+				drift.SiteID, drift.PostID, drift.Slug, drift.StoredCount, drift.ActualCount)
+		}
+
+		return nil
+	}
+}
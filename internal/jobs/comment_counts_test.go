@@ -0,0 +1,35 @@
+package jobs
+
+import (
+	"testing"
+
+	"github.com/june20516/orbithall/internal/database"
+	"github.com/june20516/orbithall/internal/testhelpers"
+)
+
+// TestReconcileCommentCounts는 댓글 수 보정 작업을 테스트합니다
+func TestReconcileCommentCounts(t *testing.T) {
+	db := testhelpers.SetupTestDB(t)
+	defer database.Close(db)
+
+	ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+	defer cleanup()
+
+	// Given: comment_count가 어긋난 포스트
+	site := testhelpers.CreateTestSite(ctx, t, tx, "Job Site", "job.com", []string{"http://localhost:3000"}, true)
+	post := testhelpers.CreateTestPost(ctx, t, tx, site.ID, "job-post", "Job Post")
+	if _, err := tx.ExecContext(ctx, "UPDATE posts SET comment_count = 3 WHERE id = $1", post.ID); err != nil {
+		t.Fatalf("Failed to corrupt comment_count: %v", err)
+	}
+
+	// When: 작업 실행
+	if err := ReconcileCommentCounts(tx)(ctx); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	// Then: 실제 댓글 수로 보정
+	stored, _ := database.GetPostByID(ctx, tx, post.ID)
+	if stored.CommentCount != 0 {
+		t.Errorf("expected comment_count=0, got %d", stored.CommentCount)
+	}
+}
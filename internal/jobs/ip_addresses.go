@@ -0,0 +1,23 @@
+package jobs
+
+import (
+	"context"
+	"log"
+
+	"github.com/june20516/orbithall/internal/database"
+)
+
+// ipMigrationBatchSize는 IP 주소 암호화 작업의 트랜잭션당 처리 댓글 수입니다
+const ipMigrationBatchSize = 500
+
+// EncryptCommentIPAddresses는 평문으로 남아있는 댓글 IP 주소를 암호화하고,
+// 교체된 이전 키로 암호화된 IP 주소를 활성 키로 다시 암호화합니다
+func EncryptCommentIPAddresses(db database.DBTX) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		migrated, err := database.MigrateCommentIPAddresses(ctx, db, ipMigrationBatchSize)
+		if migrated > 0 {
+			log.Printf("Comment IP addresses encrypted with active key: %d", migrated)
+		}
+		return err
+	}
+}
@@ -0,0 +1,27 @@
+package jobs
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// RunPeriodically는 interval마다 fn을 실행합니다
+// 시작 직후 한 번 실행하고, ctx가 취소되면 종료합니다 (블로킹)
+// fn이 에러를 반환해도 다음 주기에 다시 실행하며, 에러는 로그로 남깁니다
+func RunPeriodically(ctx context.Context, name string, interval time.Duration, fn func(ctx context.Context) error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := fn(ctx); err != nil {
+			log.Printf("[ERROR] Job %s failed: %v", name, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestRunPeriodically는 주기 실행과 종료를 테스트합니다
+func TestRunPeriodically(t *testing.T) {
+	t.Run("시작 직후 실행 후 주기마다 반복, 에러가 나도 계속 실행", func(t *testing.T) {
+		// Given: 호출 횟수를 세고 항상 에러를 반환하는 작업
+		var calls int32
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan struct{})
+
+		// When: 짧은 주기로 실행
+		go func() {
+			RunPeriodically(ctx, "test", 10*time.Millisecond, func(ctx context.Context) error {
+				atomic.AddInt32(&calls, 1)
+				return errors.New("boom")
+			})
+			close(done)
+		}()
+		time.Sleep(55 * time.Millisecond)
+		cancel()
+
+		// Then: 여러 번 실행된 뒤 종료
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("expected job to stop after cancel")
+		}
+		if atomic.LoadInt32(&calls) < 2 {
+			t.Errorf("expected at least 2 calls, got %d", calls)
+		}
+	})
+}
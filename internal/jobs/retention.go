@@ -0,0 +1,41 @@
+package jobs
+
+import (
+	"context"
+	"log"
+
+	"github.com/june20516/orbithall/internal/database"
+)
+
+// ApplyRetentionPolicies는 사이트별 개인정보 보관 기간을 적용합니다
+// 보관 기간이 지난 IP 주소/User-Agent를 삭제하고, 삭제된 댓글의 작성자 정보와 내용을 비웁니다
+// 만료되거나 로그아웃된 지 30일이 지난 로그인 세션과 24시간 동안 요청이 없던 요청 제한 상태도 삭제합니다
+func ApplyRetentionPolicies(db database.DBTX) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		anonymized, err := database.AnonymizeExpiredPersonalData(ctx, db)
+		if err != nil {
+			return err
+		}
+
+		purged, err := database.PurgeDeletedCommentContent(ctx, db)
+		if err != nil {
+			return err
+		}
+
+		sessions, err := database.DeleteExpiredSessions(ctx, db)
+		if err != nil {
+			return err
+		}
+
+		buckets, err := database.DeleteIdleRateLimitBuckets(ctx, db)
+		if err != nil {
+			return err
+		}
+
+		if anonymized > 0 || purged > 0 || sessions > 0 || buckets > 0 {
+			log.Printf("Retention applied: %d comments anonymized, %d deleted comments purged, %d expired sessions deleted, %d idle rate limit buckets deleted", anonymized, purged, sessions, buckets)
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,59 @@
+package jobs
+
+import (
+	"testing"
+
+	"github.com/june20516/orbithall/internal/database"
+	"github.com/june20516/orbithall/internal/testhelpers"
+)
+
+// TestApplyRetentionPolicies는 개인정보 보관 기간 적용 작업을 테스트합니다
+func TestApplyRetentionPolicies(t *testing.T) {
+	db := testhelpers.SetupTestDB(t)
+	defer database.Close(db)
+
+	ctx, tx, cleanup := testhelpers.SetupTxTest(t, db)
+	defer cleanup()
+
+	// Given: 보관 기간 30일인 사이트의 40일 전 댓글
+	site := testhelpers.CreateTestSite(ctx, t, tx, "Job Site", "retention-job.com", []string{"http://localhost:3000"}, true)
+	if err := database.UpdateSiteRetention(ctx, tx, site.ID, 30, 0); err != nil {
+		t.Fatalf("Failed to update retention: %v", err)
+	}
+	post := testhelpers.CreateTestPost(ctx, t, tx, site.ID, "job-post", "Job Post")
+	comment, err := database.CreateComment(ctx, tx, post.ID, nil, "홍길동", "test1234", "댓글", "127.0.0.1", "test")
+	if err != nil {
+		t.Fatalf("Failed to create comment: %v", err)
+	}
+	if _, err := tx.ExecContext(ctx, "UPDATE comments SET created_at = NOW() - INTERVAL '40 days' WHERE id = $1", comment.ID); err != nil {
+		t.Fatalf("Failed to age comment: %v", err)
+	}
+
+	// 하루 넘게 요청이 없던 요청 제한 상태
+	if _, _, err := database.TakeRateLimitToken(ctx, tx, "retention-job", "192.168.1.1", 0, 1); err != nil {
+		t.Fatalf("Failed to take rate limit token: %v", err)
+	}
+	if _, err := tx.ExecContext(ctx, "UPDATE rate_limit_buckets SET updated_at = NOW() - INTERVAL '2 days' WHERE bucket = 'retention-job'"); err != nil {
+		t.Fatalf("Failed to age rate limit bucket: %v", err)
+	}
+
+	// When: 작업 실행
+	if err := ApplyRetentionPolicies(tx)(ctx); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	// Then: IP 주소 삭제
+	stored, _ := database.GetCommentByID(ctx, tx, comment.ID)
+	if stored.IPAddressEncrypted != "" {
+		t.Errorf("expected ip address to be removed, got %q", stored.IPAddressEncrypted)
+	}
+
+	// 요청 제한 상태 삭제
+	var buckets int
+	if err := tx.QueryRowContext(ctx, "SELECT COUNT(*) FROM rate_limit_buckets WHERE bucket = 'retention-job'").Scan(&buckets); err != nil {
+		t.Fatalf("Failed to count rate limit buckets: %v", err)
+	}
+	if buckets != 0 {
+		t.Errorf("expected idle rate limit bucket to be deleted, got %d", buckets)
+	}
+}
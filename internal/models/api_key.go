@@ -0,0 +1,89 @@
+package models
+
+import (
+	"slices"
+	"time"
+)
+
+// 비밀 API 키 scope (키로 허용하는 서버 API 작업)
+const (
+	ScopeCommentsReadPrivate = "comments:read_private" // 삭제된 댓글과 전체 IP를 포함한 댓글 조회
+	ScopeCommentsModerate    = "comments:moderate"     // 댓글 삭제/복구
+	ScopePostsWrite          = "posts:write"           // 포스트 제목/URL 수정, 잠금/해제
+)
+
+// APIKeyScopes는 비밀 키에 부여할 수 있는 모든 scope입니다
+var APIKeyScopes = []string{ScopeCommentsReadPrivate, ScopeCommentsModerate, ScopePostsWrite}
+
+// IsValidAPIKeyScope는 부여할 수 있는 scope인지 확인합니다
+func IsValidAPIKeyScope(scope string) bool {
+	return slices.Contains(APIKeyScopes, scope)
+}
+
+// SiteAPIKey는 사이트의 API 키입니다
+// 사이트마다 여러 개의 키를 발급할 수 있으며, 키별로 허용 Origin과 만료 시각을 지정할 수 있습니다
+type SiteAPIKey struct {
+	ID     int64 `json:"id"`
+	SiteID int64 `json:"site_id"`
+
+	// Label은 관리자가 키를 구분하기 위한 이름입니다 (예: "production")
+	Label string `json:"label"`
+
+	// Key는 X-Orbithall-API-Key 헤더로 전송하는 키 값입니다
+	// 비밀 키는 원문을 저장하지 않으므로 발급/교체 직후에만 채워집니다
+	Key string `json:"key"`
+
+	// KeyHash는 키의 SHA-256 해시입니다 (캐시 항목과 비밀 키 조회에 사용)
+	KeyHash string `json:"-"`
+
+	// KeyPrefix와 KeyLast4는 마스킹된 비밀 키를 표시하기 위한 prefix와 마지막 4자입니다
+	KeyPrefix string `json:"-"`
+	KeyLast4  string `json:"-"`
+
+	// TestMode는 테스트 모드 키(orb_test_)인지 여부입니다
+	// 테스트 모드 키로 작성한 포스트/댓글은 실제 데이터와 분리된 샌드박스에 저장됩니다
+	TestMode bool `json:"test_mode"`
+
+	// Secret은 서버 전용 비밀 키(orb_secret_)인지 여부입니다
+	// 비밀 키는 Origin 헤더가 있는 (브라우저) 요청에서 거부되며, 서버 API에서만 사용할 수 있습니다
+	// 목록 조회 시 키 값은 마스킹됩니다
+	Secret bool `json:"secret"`
+
+	// Scopes는 비밀 키로 허용하는 서버 API 작업 목록입니다 (공개 키는 빈 목록)
+	Scopes []string `json:"scopes"`
+
+	// AllowedOrigins는 이 키로 허용할 Origin 목록입니다
+	// 비어있으면 사이트의 CORSOrigins를 사용합니다
+	AllowedOrigins []string `json:"allowed_origins"`
+
+	// ExpiresAt은 키의 만료 시각입니다 (nil이면 만료 없음)
+	// 키를 교체하면 이전 키의 만료 시각이 유예 기간 종료 시각으로 설정됩니다
+	ExpiresAt *time.Time `json:"expires_at"`
+
+	// RevokedAt은 키가 폐기된 시각입니다 (nil이면 폐기되지 않음)
+	RevokedAt *time.Time `json:"revoked_at"`
+
+	// LastUsedAt은 키가 마지막으로 사용된 시각입니다
+	// 캐시 미스 시에만 갱신되므로 최대 캐시 TTL만큼 늦을 수 있습니다
+	LastUsedAt *time.Time `json:"last_used_at"`
+
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// IsUsable은 키가 폐기되거나 만료되지 않아 사용 가능한지 확인합니다
+func (k *SiteAPIKey) IsUsable(now time.Time) bool {
+	if k.RevokedAt != nil {
+		return false
+	}
+	return k.ExpiresAt == nil || now.Before(*k.ExpiresAt)
+}
+
+// Masked는 키 값을 마스킹한 복사본을 반환합니다 (비밀 키만 마스킹)
+// 비밀 키 값은 발급/교체 응답에서만 전체를 보여주고, 이후에는 prefix와 마지막 4자만 보여줍니다
+func (k *SiteAPIKey) Masked() SiteAPIKey {
+	masked := *k
+	if k.Secret {
+		masked.Key = k.KeyPrefix + "****" + k.KeyLast4
+	}
+	return masked
+}
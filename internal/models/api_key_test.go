@@ -0,0 +1,53 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+// TestSiteAPIKey_IsUsable는 키 사용 가능 여부 판단을 테스트합니다
+func TestSiteAPIKey_IsUsable(t *testing.T) {
+	now := time.Now()
+	past := now.Add(-time.Minute)
+	future := now.Add(time.Minute)
+
+	tests := []struct {
+		name string
+		key  SiteAPIKey
+		want bool
+	}{
+		{name: "만료/폐기 없음", key: SiteAPIKey{}, want: true},
+		{name: "만료 전", key: SiteAPIKey{ExpiresAt: &future}, want: true},
+		{name: "만료됨", key: SiteAPIKey{ExpiresAt: &past}, want: false},
+		{name: "폐기됨", key: SiteAPIKey{RevokedAt: &past}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.key.IsUsable(now); got != tt.want {
+				t.Errorf("IsUsable() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestIsTestAPIKey는 테스트 모드 키 판별을 테스트합니다
+func TestIsTestAPIKey(t *testing.T) {
+	tests := []struct {
+		name string
+		key  string
+		want bool
+	}{
+		{name: "테스트 키", key: GenerateAPIKey(APIKeyPrefixTest), want: true},
+		{name: "라이브 키", key: GenerateAPIKey(APIKeyPrefixLive), want: false},
+		{name: "prefix 없는 키", key: "550e8400-e29b-41d4-a716-446655440000", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsTestAPIKey(tt.key); got != tt.want {
+				t.Errorf("IsTestAPIKey(%q) = %v, want %v", tt.key, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// 감사 로그 작업 종류 (action)
+const (
+	AuditActionSiteCreate       = "site.create"
+	AuditActionSiteUpdate       = "site.update"
+	AuditActionSiteDelete       = "site.delete"
+	AuditActionSiteVerify       = "site.verify"
+	AuditActionPostUpdate       = "post.update"
+	AuditActionPostLock         = "post.lock"
+	AuditActionPostUnlock       = "post.unlock"
+	AuditActionPostAliasAdd     = "post.alias_add"
+	AuditActionPostAliasDelete  = "post.alias_delete"
+	AuditActionPostMerge        = "post.merge"
+	AuditActionCommentDelete    = "comment.delete"
+	AuditActionCommentRestore   = "comment.restore"
+	AuditActionCommentErase     = "comment.erase"
+	AuditActionCommentReconcile = "comment.reconcile_counts"
+	AuditActionAPIKeyCreate     = "api_key.create"
+	AuditActionAPIKeyRotate     = "api_key.rotate"
+	AuditActionAPIKeyRevoke     = "api_key.revoke"
+	AuditActionSandboxWipe      = "sandbox.wipe"
+	AuditActionMemberRoleUpdate = "member.role_update"
+	AuditActionMemberRemove     = "member.remove"
+	AuditActionInvitationCreate = "invitation.create"
+	AuditActionInvitationRevoke = "invitation.revoke"
+	AuditActionInvitationAccept = "invitation.accept"
+	AuditActionTransferRequest  = "transfer.request"
+	AuditActionTransferCancel   = "transfer.cancel"
+	AuditActionTransferAccept   = "transfer.accept"
+	AuditActionTransferDecline  = "transfer.decline"
+)
+
+// 감사 로그 대상 종류 (target_type)
+const (
+	AuditTargetSite       = "site"
+	AuditTargetPost       = "post"
+	AuditTargetComment    = "comment"
+	AuditTargetAPIKey     = "api_key"
+	AuditTargetUser       = "user"
+	AuditTargetInvitation = "invitation"
+	AuditTargetTransfer   = "ownership_transfer"
+)
+
+// AuditLogEntry는 관리자 작업 한 건의 감사 기록입니다
+type AuditLogEntry struct {
+	ID     int64 `json:"id"`
+	SiteID int64 `json:"site_id"`
+
+	// ActorUserID는 작업한 관리자의 ID입니다 (사용자가 삭제되면 nil)
+	ActorUserID *int64 `json:"actor_user_id"`
+
+	// ActorEmail은 작업한 관리자의 이메일입니다 (조회 시에만 채워짐)
+	ActorEmail string `json:"actor_email,omitempty"`
+
+	// ActorAPIKeyID는 서버 API에서 작업에 사용한 비밀 키의 ID입니다 (관리자 작업이거나 키가 삭제되면 nil)
+	ActorAPIKeyID *int64 `json:"actor_api_key_id,omitempty"`
+
+	// ActorAPIKeyLabel은 작업에 사용한 비밀 키의 라벨입니다 (조회 시에만 채워짐)
+	ActorAPIKeyLabel string `json:"actor_api_key_label,omitempty"`
+
+	// Action은 작업 종류입니다 (예: site.update)
+	Action string `json:"action"`
+
+	// TargetType과 TargetID는 작업 대상입니다 (예: post 12)
+	TargetType string `json:"target_type"`
+	TargetID   int64  `json:"target_id"`
+
+	// Before와 After는 변경 전/후 상태입니다 (해당 없으면 생략)
+	Before json.RawMessage `json:"before,omitempty"`
+	After  json.RawMessage `json:"after,omitempty"`
+
+	// IPAddress는 요청 IP입니다
+	// 데이터베이스에는 암호화되어 저장되며, 감사 로그 조회 시에만 복호화합니다
+	IPAddress string `json:"ip_address,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+}
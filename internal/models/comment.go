@@ -31,18 +31,22 @@ type Comment struct {
 	// true인 경우 "삭제된 댓글입니다" 같은 메시지로 표시됩니다
 	IsDeleted bool `json:"is_deleted"`
 
-	// IPAddress는 댓글 작성자의 IP 주소입니다
+	// IPAddress는 댓글 작성자의 IP 주소(평문)입니다
 	// 스팸 방지 목적으로 저장하며, API 응답에는 포함되지 않습니다
-	// 데이터베이스: INET 타입
+	// 데이터베이스에는 암호화되어 저장되며, Admin 조회(GetAdminComments)에서만 복호화하여 채웁니다
 	IPAddress string `json:"-"`
 
+	// IPAddressEncrypted는 암호화된 IP 주소입니다 ("키ID:암호문")
+	// 데이터베이스: ip_address_encrypted 컬럼 (조회용 해시는 ip_address_hash 컬럼)
+	IPAddressEncrypted string `json:"-"`
+
 	// UserAgent는 댓글 작성 시 사용한 브라우저 정보입니다
 	// 스팸 방지 목적으로 저장하며, API 응답에는 포함되지 않습니다
 	UserAgent string `json:"-"`
 
 	// IPAddressMasked는 마스킹된 IP 주소입니다
-	// API 응답에 포함되며, 개인정보 보호를 위해 마지막 옥텟이 가려집니다 (예: 192.168.1.xxx)
-	// 데이터베이스에 저장되지 않고, 런타임에서만 생성됩니다
+	// API 응답에 포함되며, 개인정보 보호를 위해 뒤쪽 옥텟이 가려집니다 (예: 192.168.***.***)
+	// 복호화 없이 표시할 수 있도록 저장 시점에 생성하여 ip_address_masked 컬럼에 저장합니다
 	IPAddressMasked string `json:"ip_address_masked,omitempty"`
 
 	// IPAddressUnmasked는 마스킹되지 않은 전체 IP 주소입니다 (Admin 전용)
@@ -95,3 +99,14 @@ func MaskIPAddress(ip string) string {
 
 	return "****:****:****:****:****:****:****:****"
 }
+
+// CommentErasureReport는 개인정보 삭제 요청(erasure) 처리 결과입니다
+// 조건에 일치한 댓글과 실제로 삭제/익명화된 댓글 수, 영향받은 포스트를 보고합니다
+type CommentErasureReport struct {
+	DryRun             bool    `json:"dry_run"`             // true면 변경 없이 대상만 보고
+	MatchedComments    int     `json:"matched_comments"`    // 조건에 일치한 댓글 수
+	DeletedComments    int     `json:"deleted_comments"`    // 완전히 삭제된 댓글 수
+	AnonymizedComments int     `json:"anonymized_comments"` // 익명화된 댓글 수 (다른 사람의 대댓글이 달린 댓글 포함)
+	CommentIDs         []int64 `json:"comment_ids"`         // 조건에 일치한 댓글 ID 목록
+	PostIDs            []int64 `json:"post_ids"`            // 영향받은 포스트 ID 목록
+}
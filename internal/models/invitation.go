@@ -0,0 +1,78 @@
+package models
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+)
+
+// InvitationTokenPrefix는 사이트 초대 토큰의 prefix입니다
+const InvitationTokenPrefix = "orb_inv_"
+
+// 사이트 초대 상태
+const (
+	InvitationStatusPending  = "pending"  // 수락 대기 중
+	InvitationStatusAccepted = "accepted" // 수락됨
+	InvitationStatusRevoked  = "revoked"  // 취소됨
+	InvitationStatusExpired  = "expired"  // 수락하지 않고 만료됨
+)
+
+// SiteInvitation은 사이트 멤버 초대입니다
+// 초대받은 사람은 아직 가입하지 않았어도 되며, 로그인 후 토큰으로 수락하면 Role 역할로 사이트에 연결됩니다
+type SiteInvitation struct {
+	ID     int64 `json:"id"`
+	SiteID int64 `json:"site_id"`
+
+	// Email은 초대받은 이메일입니다 (수락하는 사용자의 이메일과 일치해야 함)
+	Email string `json:"email"`
+
+	// Role은 수락 시 부여할 역할입니다 (owner, manager, moderator, viewer)
+	Role string `json:"role"`
+
+	// Token은 초대 수락에 사용하는 토큰입니다
+	// DB에는 해시만 저장하므로 초대를 생성할 때만 채워지며, 초대받은 이메일로만 전송하고 응답에는 포함하지 않습니다
+	Token string `json:"-"`
+
+	// Status는 초대 상태입니다 (pending, accepted, revoked, expired)
+	Status string `json:"status"`
+
+	// InvitedBy는 초대한 사용자 ID입니다 (사용자가 삭제되면 nil)
+	InvitedBy *int64 `json:"invited_by"`
+
+	ExpiresAt  time.Time  `json:"expires_at"`
+	AcceptedAt *time.Time `json:"accepted_at"`
+	AcceptedBy *int64     `json:"accepted_by"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// StatusAt은 now 시점의 초대 상태를 반환합니다
+func (i *SiteInvitation) StatusAt(now time.Time) string {
+	switch {
+	case i.AcceptedAt != nil:
+		return InvitationStatusAccepted
+	case i.RevokedAt != nil:
+		return InvitationStatusRevoked
+	case !now.Before(i.ExpiresAt):
+		return InvitationStatusExpired
+	default:
+		return InvitationStatusPending
+	}
+}
+
+// GenerateInvitationToken은 새 초대 토큰을 생성합니다 (orb_inv_ + 64 hex 문자)
+func GenerateInvitationToken() string {
+	bytes := make([]byte, 32)
+	if _, err := rand.Read(bytes); err != nil {
+		// crypto/rand 실패는 시스템 레벨 문제 (GenerateAPIKey와 동일하게 panic)
+		panic("failed to generate random bytes for invitation token: " + err.Error())
+	}
+	return InvitationTokenPrefix + hex.EncodeToString(bytes)
+}
+
+// HashInvitationToken은 DB에 저장하고 조회할 때 사용하는 초대 토큰의 SHA-256 해시를 반환합니다
+func HashInvitationToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,52 @@
+package models
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestSiteInvitation_StatusAt은 초대 상태 판단을 테스트합니다
+func TestSiteInvitation_StatusAt(t *testing.T) {
+	now := time.Now()
+	past := now.Add(-time.Minute)
+	future := now.Add(time.Minute)
+
+	tests := []struct {
+		name       string
+		invitation SiteInvitation
+		want       string
+	}{
+		{name: "대기 중", invitation: SiteInvitation{ExpiresAt: future}, want: InvitationStatusPending},
+		{name: "만료됨", invitation: SiteInvitation{ExpiresAt: past}, want: InvitationStatusExpired},
+		{name: "수락됨 (만료 후에도 유지)", invitation: SiteInvitation{ExpiresAt: past, AcceptedAt: &past}, want: InvitationStatusAccepted},
+		{name: "취소됨", invitation: SiteInvitation{ExpiresAt: future, RevokedAt: &past}, want: InvitationStatusRevoked},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.invitation.StatusAt(now); got != tt.want {
+				t.Errorf("StatusAt() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestGenerateInvitationToken은 초대 토큰 생성과 해시를 테스트합니다
+func TestGenerateInvitationToken(t *testing.T) {
+	token := GenerateInvitationToken()
+	other := GenerateInvitationToken()
+
+	if !strings.HasPrefix(token, InvitationTokenPrefix) || len(token) != len(InvitationTokenPrefix)+64 {
+		t.Errorf("unexpected token format: %s", token)
+	}
+	if token == other {
+		t.Error("expected unique tokens")
+	}
+	if HashInvitationToken(token) != HashInvitationToken(token) || HashInvitationToken(token) == HashInvitationToken(other) {
+		t.Error("expected deterministic, distinct hashes")
+	}
+	if strings.Contains(HashInvitationToken(token), token) {
+		t.Error("hash must not contain the token")
+	}
+}
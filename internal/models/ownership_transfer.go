@@ -0,0 +1,85 @@
+package models
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+)
+
+// TransferTokenPrefix는 사이트 소유권 이전 토큰의 prefix입니다
+const TransferTokenPrefix = "orb_trf_"
+
+// 사이트 소유권 이전 상태
+const (
+	TransferStatusPending   = "pending"   // 받는 사람의 수락 대기 중
+	TransferStatusAccepted  = "accepted"  // 수락되어 이전 완료
+	TransferStatusDeclined  = "declined"  // 받는 사람이 거절
+	TransferStatusCancelled = "cancelled" // 요청한 owner가 취소 (새 요청으로 대체된 경우 포함)
+	TransferStatusExpired   = "expired"   // 수락하지 않고 만료됨
+)
+
+// SiteOwnershipTransfer는 사이트 소유권 이전 요청입니다
+// 현재 owner가 받는 사람의 이메일로 요청하고, 받는 사람이 같은 이메일로 로그인해 이메일로 받은 토큰으로 수락하면
+// 받는 사람은 owner가, 요청한 owner는 받는 사람의 이전 역할(멤버가 아니었으면 manager)이 됩니다
+type SiteOwnershipTransfer struct {
+	ID     int64 `json:"id"`
+	SiteID int64 `json:"site_id"`
+
+	// SiteName, SiteDomain은 받는 사람이 어떤 사이트인지 확인할 수 있도록 함께 반환하는 사이트 정보입니다
+	SiteName   string `json:"site_name"`
+	SiteDomain string `json:"site_domain"`
+
+	// FromUserID, FromEmail은 이전을 요청한 owner입니다 (사용자가 삭제되면 nil, 빈 문자열)
+	FromUserID *int64 `json:"from_user_id"`
+	FromEmail  string `json:"from_email"`
+
+	// ToEmail은 받는 사람 이메일입니다
+	ToEmail string `json:"to_email"`
+
+	// Token은 수락/거절에 사용하는 토큰입니다
+	// 받는 사람 이메일로만 전송하므로 응답에는 포함하지 않으며, DB에는 해시만 저장하므로 요청을 생성할 때만 채워집니다
+	Token string `json:"-"`
+
+	// Status는 이전 요청 상태입니다 (pending, accepted, declined, cancelled, expired)
+	Status string `json:"status"`
+
+	ExpiresAt   time.Time  `json:"expires_at"`
+	AcceptedAt  *time.Time `json:"accepted_at"`
+	AcceptedBy  *int64     `json:"accepted_by"`
+	DeclinedAt  *time.Time `json:"declined_at"`
+	CancelledAt *time.Time `json:"cancelled_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// StatusAt은 now 시점의 이전 요청 상태를 반환합니다
+func (t *SiteOwnershipTransfer) StatusAt(now time.Time) string {
+	switch {
+	case t.AcceptedAt != nil:
+		return TransferStatusAccepted
+	case t.DeclinedAt != nil:
+		return TransferStatusDeclined
+	case t.CancelledAt != nil:
+		return TransferStatusCancelled
+	case !now.Before(t.ExpiresAt):
+		return TransferStatusExpired
+	default:
+		return TransferStatusPending
+	}
+}
+
+// GenerateTransferToken은 새 소유권 이전 토큰을 생성합니다 (orb_trf_ + 64 hex 문자)
+func GenerateTransferToken() string {
+	bytes := make([]byte, 32)
+	if _, err := rand.Read(bytes); err != nil {
+		// crypto/rand 실패는 시스템 레벨 문제 (GenerateAPIKey와 동일하게 panic)
+		panic("failed to generate random bytes for transfer token: " + err.Error())
+	}
+	return TransferTokenPrefix + hex.EncodeToString(bytes)
+}
+
+// HashTransferToken은 DB에 저장하고 조회할 때 사용하는 소유권 이전 토큰의 SHA-256 해시를 반환합니다
+func HashTransferToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
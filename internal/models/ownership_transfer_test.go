@@ -0,0 +1,50 @@
+package models
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestSiteOwnershipTransfer_StatusAt은 소유권 이전 요청 상태 판단을 테스트합니다
+func TestSiteOwnershipTransfer_StatusAt(t *testing.T) {
+	now := time.Now()
+	past := now.Add(-time.Minute)
+	future := now.Add(time.Minute)
+
+	tests := []struct {
+		name     string
+		transfer SiteOwnershipTransfer
+		want     string
+	}{
+		{name: "대기 중", transfer: SiteOwnershipTransfer{ExpiresAt: future}, want: TransferStatusPending},
+		{name: "만료됨", transfer: SiteOwnershipTransfer{ExpiresAt: past}, want: TransferStatusExpired},
+		{name: "수락됨", transfer: SiteOwnershipTransfer{ExpiresAt: past, AcceptedAt: &past}, want: TransferStatusAccepted},
+		{name: "거절됨", transfer: SiteOwnershipTransfer{ExpiresAt: future, DeclinedAt: &past}, want: TransferStatusDeclined},
+		{name: "취소됨", transfer: SiteOwnershipTransfer{ExpiresAt: future, CancelledAt: &past}, want: TransferStatusCancelled},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.transfer.StatusAt(now); got != tt.want {
+				t.Errorf("StatusAt() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestGenerateTransferToken은 소유권 이전 토큰 생성과 해시를 테스트합니다
+func TestGenerateTransferToken(t *testing.T) {
+	token := GenerateTransferToken()
+	other := GenerateTransferToken()
+
+	if !strings.HasPrefix(token, TransferTokenPrefix) || len(token) != len(TransferTokenPrefix)+64 {
+		t.Errorf("unexpected token format: %s", token)
+	}
+	if token == other {
+		t.Error("expected unique tokens")
+	}
+	if HashTransferToken(token) != HashTransferToken(token) || HashTransferToken(token) == HashTransferToken(other) {
+		t.Error("expected deterministic, distinct hashes")
+	}
+}
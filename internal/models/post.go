@@ -11,16 +11,35 @@ type Post struct {
 	SiteID int64 `json:"site_id"`
 
 	// Slug는 포스트의 URL slug입니다 (예: "how-to-use-go")
-	// 데이터베이스: (site_id, slug) 조합이 unique 제약조건
+	// 데이터베이스: (site_id, is_sandbox, slug) 조합이 unique 제약조건
 	Slug string `json:"slug"`
 
 	// Title은 포스트의 제목입니다
+	// 위젯이 제목을 전달하지 않은 채 생성된 포스트는 slug가 제목으로 저장되며,
+	// 이후 위젯이 처음 전달한 제목으로 한 번만 채워집니다 (선착순)
 	Title string `json:"title"`
 
-	// CommentCount는 이 포스트에 달린 댓글 수입니다
-	// 캐시 역할을 하며, 댓글 추가/삭제 시 업데이트됩니다
+	// URL은 포스트의 canonical URL입니다 (예: "https://blog.example.com/posts/how-to-use-go")
+	// 위젯이 처음 전달한 값으로 한 번만 채워지며, 이후 변경은 Admin API로만 가능합니다
+	URL string `json:"url"`
+
+	// CommentCount는 이 포스트에 달린 삭제되지 않은 댓글 수입니다 (대댓글 포함)
+	// 캐시 역할을 하며, 댓글 작성/삭제/복구와 같은 트랜잭션에서 함께 업데이트됩니다
+	// soft delete된 댓글은 대댓글 때문에 목록에 남아 있더라도 세지 않습니다
 	CommentCount int `json:"comment_count"`
 
+	// IsLocked는 관리자가 포스트의 댓글 작성을 막았는지 여부입니다
+	// 잠긴 포스트도 댓글 조회는 가능합니다
+	IsLocked bool `json:"is_locked"`
+
+	// IsSandbox는 테스트 모드 API 키(orb_test_)로 생성된 포스트인지 여부입니다
+	// 샌드박스 포스트와 댓글은 실제 데이터와 분리되며, 테스트 모드 키로만 조회/작성할 수 있습니다
+	IsSandbox bool `json:"is_sandbox"`
+
+	// Aliases는 이 포스트로 연결되는 이전 slug 목록입니다
+	// Admin 포스트 상세 조회에서만 채워집니다
+	Aliases []string `json:"aliases,omitempty"`
+
 	// Admin용 추가 필드 (ListPostsBySite에서만 사용)
 	ActiveCommentCount  int `json:"active_comment_count,omitempty"`
 	DeletedCommentCount int `json:"deleted_comment_count,omitempty"`
@@ -30,3 +49,35 @@ type Post struct {
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
+
+// IsClosed는 포스트에 새 댓글을 작성할 수 없는지 확인합니다
+// 관리자가 잠갔거나, 사이트의 자동 마감 기간(autoCloseDays일, 0이면 마감 없음)이 포스트 생성 시점부터 지난 경우입니다
+func (p *Post) IsClosed(autoCloseDays int, now time.Time) bool {
+	if p.IsLocked {
+		return true
+	}
+	if autoCloseDays <= 0 {
+		return false
+	}
+	return now.After(p.CreatedAt.AddDate(0, 0, autoCloseDays))
+}
+
+// PostCommentCount는 목록 페이지에 표시할 포스트별 댓글 요약입니다
+// 아직 댓글이 없는 포스트(DB에 없는 slug 포함)는 CommentCount가 0이고 LastActivityAt이 nil입니다
+type PostCommentCount struct {
+	// CommentCount는 삭제되지 않은 댓글 수입니다
+	CommentCount int `json:"comment_count"`
+
+	// LastActivityAt은 삭제되지 않은 댓글 중 가장 최근에 작성되거나 수정된 시각입니다
+	LastActivityAt *time.Time `json:"last_activity_at"`
+}
+
+// CommentCountDrift는 저장된 comment_count와 실제 댓글 수가 다른 포스트입니다
+// 댓글 수 재계산(reconciliation) 결과 보고에 사용합니다
+type CommentCountDrift struct {
+	PostID      int64  `json:"post_id"`
+	SiteID      int64  `json:"site_id"`
+	Slug        string `json:"slug"`
+	StoredCount int    `json:"stored_count"` // 재계산 전 posts.comment_count
+	ActualCount int    `json:"actual_count"` // 삭제되지 않은 실제 댓글 수
+}
@@ -0,0 +1,36 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+// TestPost_IsClosed는 잠금과 자동 마감 규칙을 테스트합니다
+func TestPost_IsClosed(t *testing.T) {
+	now := time.Date(2024, 6, 30, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		post          Post
+		autoCloseDays int
+		expected      bool
+	}{
+		{"잠기지 않았고 자동 마감 없음", Post{CreatedAt: now.AddDate(-5, 0, 0)}, 0, false},
+		{"잠긴 포스트", Post{IsLocked: true, CreatedAt: now}, 0, true},
+		{"자동 마감 기간 이내", Post{CreatedAt: now.AddDate(0, 0, -29)}, 30, false},
+		{"자동 마감 기간 경과", Post{CreatedAt: now.AddDate(0, 0, -31)}, 30, true},
+		{"잠긴 포스트는 기간과 관계없이 마감", Post{IsLocked: true, CreatedAt: now}, 30, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// When: 마감 여부 확인
+			result := tt.post.IsClosed(tt.autoCloseDays, now)
+
+			// Then: 기대값과 일치
+			if result != tt.expected {
+				t.Errorf("IsClosed(%d) = %v, want %v", tt.autoCloseDays, result, tt.expected)
+			}
+		})
+	}
+}
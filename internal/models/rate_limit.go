@@ -0,0 +1,64 @@
+package models
+
+// 사이트별로 요청 제한을 설정할 수 있는 공개 API 작업
+const (
+	RateLimitActionCreate = "create" // 댓글 작성
+	RateLimitActionUpdate = "update" // 댓글 수정
+	RateLimitActionDelete = "delete" // 댓글 삭제
+	RateLimitActionList   = "list"   // 댓글 목록, 댓글 수 조회
+)
+
+// RateLimit은 하나의 작업에 대한 요청 제한입니다 (토큰 버킷)
+type RateLimit struct {
+	// PerMinute는 분당 허용되는 요청 수입니다 (토큰 충전 속도)
+	PerMinute int `json:"per_minute"`
+
+	// Burst는 한 번에 허용되는 최대 요청 수입니다 (버킷 크기)
+	Burst int `json:"burst"`
+}
+
+// DefaultRateLimits는 사이트가 설정하지 않은 작업에 적용하는 기본 제한입니다
+var DefaultRateLimits = map[string]RateLimit{
+	RateLimitActionCreate: {PerMinute: 10, Burst: 5},
+	RateLimitActionUpdate: {PerMinute: 20, Burst: 5},
+	RateLimitActionDelete: {PerMinute: 20, Burst: 5},
+	RateLimitActionList:   {PerMinute: 120, Burst: 30},
+}
+
+// SessionSharedIPFactor는 세션 단위 제한을 켠 사이트에서 IP별 제한에 곱하는 배수입니다
+// 세션별로는 설정한 제한을, 같은 IP의 모든 세션을 합쳐서는 이 배수만큼의 제한을 적용합니다
+const SessionSharedIPFactor = 5
+
+// SiteRateLimits는 사이트가 설정한 작업별 요청 제한입니다
+// 설정하지 않은(nil) 작업은 DefaultRateLimits를 적용합니다
+type SiteRateLimits struct {
+	Create *RateLimit `json:"create,omitempty"`
+	Update *RateLimit `json:"update,omitempty"`
+	Delete *RateLimit `json:"delete,omitempty"`
+	List   *RateLimit `json:"list,omitempty"`
+
+	// KeyBySession이 true면 요청에 세션 ID(X-Orbithall-Session 헤더)가 있을 때 IP와 세션 ID 조합별로도 제한합니다
+	// 같은 IP를 공유하는 여러 사용자를 구분하기 위해 IP별 제한은 SessionSharedIPFactor배로 늘어나며,
+	// 세션 ID를 바꿔도 늘어난 IP별 제한은 그대로 적용됩니다
+	KeyBySession bool `json:"key_by_session"`
+}
+
+// For는 작업에 적용할 제한을 반환합니다 (사이트 설정이 없으면 기본 제한)
+func (l SiteRateLimits) For(action string) RateLimit {
+	var configured *RateLimit
+	switch action {
+	case RateLimitActionCreate:
+		configured = l.Create
+	case RateLimitActionUpdate:
+		configured = l.Update
+	case RateLimitActionDelete:
+		configured = l.Delete
+	case RateLimitActionList:
+		configured = l.List
+	}
+
+	if configured != nil {
+		return *configured
+	}
+	return DefaultRateLimits[action]
+}
@@ -0,0 +1,53 @@
+package models
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+)
+
+// RefreshTokenPrefix는 refresh token의 prefix입니다
+const RefreshTokenPrefix = "orb_rt_"
+
+// Session은 Admin 사용자의 로그인 세션입니다
+// 로그인하면 세션이 만들어지고, 짧게 유지되는 access token(JWT)은 refresh token으로 세션을 갱신할 때마다 새로 발급됩니다
+// 세션을 로그아웃(폐기)하면 그 세션의 access token과 refresh token은 더 이상 사용할 수 없습니다
+type Session struct {
+	ID     int64 `json:"id"`
+	UserID int64 `json:"user_id"`
+
+	// UserAgent는 세션을 만들거나 마지막으로 갱신한 클라이언트의 User-Agent입니다
+	UserAgent string `json:"user_agent"`
+
+	// IPAddress는 세션을 만들거나 마지막으로 갱신한 클라이언트의 IP 주소입니다 (마스킹해 저장)
+	IPAddress string `json:"ip_address"`
+
+	// Current는 요청에 사용한 access token의 세션인지 여부입니다 (세션 목록 응답에서만 채워짐)
+	Current bool `json:"current"`
+
+	// RefreshToken은 세션 갱신에 사용하는 토큰입니다
+	// DB에는 해시만 저장하므로 세션을 만들거나 갱신할 때만 채워집니다
+	RefreshToken string `json:"-"`
+
+	CreatedAt  time.Time  `json:"created_at"`
+	LastUsedAt time.Time  `json:"last_used_at"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+}
+
+// GenerateRefreshToken은 새 refresh token을 생성합니다 (orb_rt_ + 64 hex 문자)
+func GenerateRefreshToken() string {
+	bytes := make([]byte, 32)
+	if _, err := rand.Read(bytes); err != nil {
+		// crypto/rand 실패는 시스템 레벨 문제 (GenerateAPIKey와 동일하게 panic)
+		panic("failed to generate random bytes for refresh token: " + err.Error())
+	}
+	return RefreshTokenPrefix + hex.EncodeToString(bytes)
+}
+
+// HashRefreshToken은 DB에 저장하고 조회할 때 사용하는 refresh token의 SHA-256 해시를 반환합니다
+func HashRefreshToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,22 @@
+package models
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestGenerateRefreshToken은 refresh token 생성과 해시를 테스트합니다
+func TestGenerateRefreshToken(t *testing.T) {
+	token := GenerateRefreshToken()
+	other := GenerateRefreshToken()
+
+	if !strings.HasPrefix(token, RefreshTokenPrefix) || len(token) != len(RefreshTokenPrefix)+64 {
+		t.Errorf("unexpected token format: %s", token)
+	}
+	if token == other {
+		t.Error("expected unique tokens")
+	}
+	if HashRefreshToken(token) != HashRefreshToken(token) || HashRefreshToken(token) == HashRefreshToken(other) {
+		t.Error("expected deterministic, distinct hashes")
+	}
+}
@@ -2,7 +2,10 @@ package models
 
 import (
 	"crypto/rand"
+	"crypto/sha256"
 	"encoding/hex"
+	"slices"
+	"strings"
 	"time"
 )
 
@@ -15,9 +18,11 @@ type Site struct {
 	// Domain은 사이트의 도메인입니다 (예: "blog.codeverse.com")
 	Domain string `json:"domain"`
 
-	// APIKey는 API 인증에 사용되는 UUID 키입니다
+	// APIKey는 API 인증에 사용되는 키입니다
 	// 클라이언트는 X-Orbithall-API-Key 헤더로 이 값을 전송합니다
-	APIKey string `json:"api_key"`
+	// 키는 site_api_keys에 저장되며, 이 필드는 사이트 생성 시 발급된 기본 키와
+	// API 키로 조회한 사이트(요청에 사용된 키)에만 채워집니다
+	APIKey string `json:"api_key,omitempty"`
 
 	// CORSOrigins는 CORS 허용 도메인 목록입니다
 	// PostgreSQL의 TEXT[] 타입과 매핑됩니다
@@ -28,12 +33,59 @@ type Site struct {
 	// false인 경우 API 접근이 차단됩니다
 	IsActive bool `json:"is_active"`
 
+	// IsVerified는 도메인 소유권 검증 여부입니다
+	// 검증되지 않은 사이트는 활성화할 수 없으며, 같은 도메인은 검증된 사이트 하나만 가질 수 있습니다
+	IsVerified bool `json:"is_verified"`
+
+	// VerificationToken은 도메인 소유권 검증에 사용하는 토큰입니다
+	// DNS TXT 레코드나 well-known HTTP 파일로 도메인에 게시합니다
+	// API 키로 조회한 사이트와, 사이트 관리 권한이 없는 멤버의 사이트 목록에는 채워지지 않습니다
+	VerificationToken string `json:"verification_token,omitempty"`
+
+	// VerifiedAt은 도메인 소유권 검증에 성공한 시각입니다 (검증 전에는 nil)
+	VerifiedAt *time.Time `json:"verified_at,omitempty"`
+
+	// AutoCloseDays는 포스트 생성 후 댓글을 자동으로 마감하기까지의 일수입니다
+	// 0이면 자동 마감하지 않습니다
+	AutoCloseDays int `json:"auto_close_days"`
+
+	// PersonalDataRetentionDays는 댓글 작성자의 IP 주소와 User-Agent를 보관하는 일수입니다
+	// 기간이 지나면 익명화되며, 0이면 무기한 보관합니다
+	PersonalDataRetentionDays int `json:"personal_data_retention_days"`
+
+	// DeletedContentRetentionDays는 삭제된 댓글의 작성자 이름과 내용을 보관하는 일수입니다
+	// 기간이 지나면 비워지며, 0이면 무기한 보관합니다
+	DeletedContentRetentionDays int `json:"deleted_content_retention_days"`
+
+	// RateLimits는 공개 API 작업별 요청 제한 설정입니다
+	// 설정하지 않은 작업은 기본 제한(DefaultRateLimits)을 적용합니다
+	RateLimits SiteRateLimits `json:"rate_limits"`
+
+	// Role은 사이트를 조회한 사용자의 역할입니다 (owner, manager, moderator, viewer)
+	// 사용자의 사이트 목록(GetUserSites)에만 채워집니다
+	Role string `json:"role,omitempty"`
+
+	// TestMode는 테스트 모드 API 키(orb_test_)로 조회한 사이트인지 여부입니다
+	// true면 포스트/댓글을 실제 데이터와 분리된 샌드박스 영역에서 조회/작성합니다
+	// API 키로 조회한 사이트에만 채워집니다
+	TestMode bool `json:"-"`
+
+	// APIKeyID와 APIKeyScopes는 사이트를 조회한 API 키의 ID와 허용 작업 목록입니다
+	// API 키로 조회한 사이트에만 채워지며, scope는 비밀 키(orb_secret_)에만 있습니다
+	APIKeyID     int64    `json:"-"`
+	APIKeyScopes []string `json:"-"`
+
 	// 메타데이터
 	ID        int64     `json:"id"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
 
+// HasScope는 사이트를 조회한 API 키가 주어진 작업을 허용하는지 확인합니다
+func (s *Site) HasScope(scope string) bool {
+	return slices.Contains(s.APIKeyScopes, scope)
+}
+
 // SiteStats는 사이트의 통계 정보를 나타냅니다
 type SiteStats struct {
 	PostCount           int `json:"post_count"`
@@ -41,6 +93,23 @@ type SiteStats struct {
 	DeletedCommentCount int `json:"deleted_comment_count"`
 }
 
+// API 키 prefix
+const (
+	APIKeyPrefixLive   = "orb_live_"   // 프로덕션 환경용 (실제 데이터)
+	APIKeyPrefixTest   = "orb_test_"   // 테스트 환경용 (샌드박스 데이터)
+	APIKeyPrefixSecret = "orb_secret_" // 서버 전용 비밀 키 (브라우저 요청 거부, scope 제한)
+)
+
+// IsTestAPIKey는 테스트 모드 API 키인지 확인합니다
+func IsTestAPIKey(apiKey string) bool {
+	return strings.HasPrefix(apiKey, APIKeyPrefixTest)
+}
+
+// IsSecretAPIKey는 서버 전용 비밀 API 키인지 확인합니다
+func IsSecretAPIKey(apiKey string) bool {
+	return strings.HasPrefix(apiKey, APIKeyPrefixSecret)
+}
+
 // GenerateAPIKey는 주어진 prefix로 API 키를 생성합니다
 //
 // prefix 종류:
@@ -65,3 +134,10 @@ func GenerateAPIKey(prefix string) string {
 	}
 	return prefix + hex.EncodeToString(bytes)
 }
+
+// HashAPIKey는 API 키의 SHA-256 해시를 hex 문자열로 반환합니다
+// 비밀 키는 원문 대신 이 해시를 저장하고 조회합니다
+func HashAPIKey(apiKey string) string {
+	sum := sha256.Sum256([]byte(apiKey))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,57 @@
+package models
+
+import (
+	"slices"
+	"time"
+)
+
+// 사이트 멤버 역할 (user_sites.role)
+const (
+	SiteRoleOwner     = "owner"     // 멤버 관리, 사이트 삭제를 포함한 모든 권한
+	SiteRoleManager   = "manager"   // 사이트 설정, API 키, 포스트 관리와 댓글 관리
+	SiteRoleModerator = "moderator" // 댓글 조회/관리와 포스트 잠금
+	SiteRoleViewer    = "viewer"    // 사이트 정보, 통계, 포스트 목록 조회
+)
+
+// SiteRoles는 부여할 수 있는 모든 역할입니다 (권한이 많은 순서)
+var SiteRoles = []string{SiteRoleOwner, SiteRoleManager, SiteRoleModerator, SiteRoleViewer}
+
+// 사이트 권한 (Admin API 작업별로 필요한 권한)
+const (
+	PermissionSiteView        = "site:view"        // 사이트 정보, 통계, 포스트 조회
+	PermissionSiteManage      = "site:manage"      // 사이트 설정, 도메인 검증, API 키, 포스트 수정/병합, 가져오기, 감사 로그
+	PermissionCommentModerate = "comment:moderate" // 댓글 조회(IP 포함), 복구, 개인정보 삭제, 포스트 잠금/해제
+	PermissionMemberManage    = "member:manage"    // 멤버 역할 변경, 제거
+	PermissionSiteDelete      = "site:delete"      // 사이트 삭제
+	PermissionSiteTransfer    = "site:transfer"    // 사이트 소유권 이전 요청/취소
+)
+
+// siteRolePermissions는 역할별로 허용하는 권한입니다
+var siteRolePermissions = map[string][]string{
+	SiteRoleOwner:     {PermissionSiteView, PermissionSiteManage, PermissionCommentModerate, PermissionMemberManage, PermissionSiteDelete, PermissionSiteTransfer},
+	SiteRoleManager:   {PermissionSiteView, PermissionSiteManage, PermissionCommentModerate},
+	SiteRoleModerator: {PermissionSiteView, PermissionCommentModerate},
+	SiteRoleViewer:    {PermissionSiteView},
+}
+
+// IsValidSiteRole은 부여할 수 있는 역할인지 확인합니다
+func IsValidSiteRole(role string) bool {
+	return slices.Contains(SiteRoles, role)
+}
+
+// SiteRoleHasPermission은 역할이 권한을 허용하는지 확인합니다
+// 알 수 없는 역할은 어떤 권한도 허용하지 않습니다
+func SiteRoleHasPermission(role, permission string) bool {
+	return slices.Contains(siteRolePermissions[role], permission)
+}
+
+// SiteMember는 사이트에 연결된 사용자와 역할입니다
+type SiteMember struct {
+	User
+
+	// Role은 사이트에서의 역할입니다 (owner, manager, moderator, viewer)
+	Role string `json:"role"`
+
+	// JoinedAt은 사이트에 연결된 시각입니다
+	JoinedAt time.Time `json:"joined_at"`
+}
@@ -0,0 +1,57 @@
+package models
+
+import "testing"
+
+// TestSiteRoleHasPermission은 역할별 권한 판단을 테스트합니다
+func TestSiteRoleHasPermission(t *testing.T) {
+	permissions := []string{
+		PermissionSiteView,
+		PermissionSiteManage,
+		PermissionCommentModerate,
+		PermissionMemberManage,
+		PermissionSiteDelete,
+		PermissionSiteTransfer,
+	}
+
+	tests := []struct {
+		role    string
+		allowed []string
+	}{
+		{role: SiteRoleOwner, allowed: permissions},
+		{role: SiteRoleManager, allowed: []string{PermissionSiteView, PermissionSiteManage, PermissionCommentModerate}},
+		{role: SiteRoleModerator, allowed: []string{PermissionSiteView, PermissionCommentModerate}},
+		{role: SiteRoleViewer, allowed: []string{PermissionSiteView}},
+		{role: "", allowed: nil},
+		{role: "admin", allowed: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.role, func(t *testing.T) {
+			for _, permission := range permissions {
+				want := false
+				for _, allowed := range tt.allowed {
+					if allowed == permission {
+						want = true
+					}
+				}
+				if got := SiteRoleHasPermission(tt.role, permission); got != want {
+					t.Errorf("SiteRoleHasPermission(%q, %q) = %v, want %v", tt.role, permission, got, want)
+				}
+			}
+		})
+	}
+}
+
+// TestIsValidSiteRole은 부여할 수 있는 역할 판별을 테스트합니다
+func TestIsValidSiteRole(t *testing.T) {
+	for _, role := range SiteRoles {
+		if !IsValidSiteRole(role) {
+			t.Errorf("expected %q to be valid", role)
+		}
+	}
+	for _, role := range []string{"", "admin", "Owner"} {
+		if IsValidSiteRole(role) {
+			t.Errorf("expected %q to be invalid", role)
+		}
+	}
+}
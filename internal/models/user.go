@@ -2,21 +2,22 @@ package models
 
 import "time"
 
-// User는 Google OAuth 로그인을 통해 인증된 Admin 사용자 정보를 나타냅니다
+// User는 외부 ID 공급자(Google, OIDC) 로그인을 통해 인증된 Admin 사용자 정보를 나타냅니다
+// 로그인에 사용한 공급자 계정은 UserIdentity로 연결됩니다
 type User struct {
-	// Email은 사용자의 이메일 주소입니다 (Google 계정)
+	// Email은 사용자의 이메일 주소입니다 (처음 로그인한 공급자 계정의 이메일)
 	Email string `json:"email"`
 
-	// Name은 사용자의 이름입니다 (Google 프로필)
+	// EmailVerified는 공급자가 Email을 검증했는지 여부입니다 (ID Token의 email_verified 클레임)
+	// 검증되지 않은 사용자는 초대나 소유권 이전을 수락할 수 없습니다
+	EmailVerified bool `json:"email_verified"`
+
+	// Name은 사용자의 이름입니다 (공급자 프로필)
 	Name string `json:"name"`
 
 	// PictureURL은 사용자의 프로필 이미지 URL입니다
 	PictureURL string `json:"picture_url,omitempty"`
 
-	// GoogleID는 Google OAuth의 고유 식별자입니다 (내부 전용)
-	// API 응답에서는 제외됩니다
-	GoogleID string `json:"-"`
-
 	// 메타데이터
 	ID        int64     `json:"id"`
 	CreatedAt time.Time `json:"created_at"`
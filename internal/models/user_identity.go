@@ -0,0 +1,22 @@
+package models
+
+import "time"
+
+// UserIdentity는 사용자와 외부 ID 공급자 계정의 연결입니다
+// 한 사용자는 여러 공급자 계정으로 로그인할 수 있으며, 공급자 계정은 한 사용자에게만 연결됩니다
+type UserIdentity struct {
+	ID     int64 `json:"id"`
+	UserID int64 `json:"user_id"`
+
+	// Provider는 ID 공급자 이름입니다 (예: google, oidc)
+	Provider string `json:"provider"`
+
+	// Subject는 공급자가 발급한 계정의 고유 식별자입니다 (ID Token의 sub 클레임)
+	Subject string `json:"subject"`
+
+	// Email은 연결할 때 공급자가 알려준 이메일입니다 (참고용)
+	Email string `json:"email"`
+
+	CreatedAt   time.Time `json:"created_at"`
+	LastLoginAt time.Time `json:"last_login_at"`
+}
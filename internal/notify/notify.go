@@ -0,0 +1,189 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log"
+	"mime"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"os"
+	"strings"
+	"time"
+)
+
+// Message는 사용자에게 보내는 알림입니다
+type Message struct {
+	// To는 받는 사람 이메일입니다
+	To string
+
+	// Subject는 알림 제목입니다
+	Subject string
+
+	// Body는 알림 본문입니다 (일반 텍스트)
+	Body string
+}
+
+// Notifier는 사용자에게 알림을 전송합니다
+type Notifier interface {
+	Notify(ctx context.Context, msg Message) error
+}
+
+// LogNotifier는 알림을 전송하지 않고 서버 로그에 기록합니다
+// 메일 서버를 설정하지 않은 개발 환경에서 사용합니다
+type LogNotifier struct{}
+
+// Notify는 알림을 로그에 기록합니다
+func (LogNotifier) Notify(ctx context.Context, msg Message) error {
+	log.Printf("[NOTIFY] to=%s subject=%q\n%s", msg.To, msg.Subject, msg.Body)
+	return nil
+}
+
+// SMTPNotifier는 SMTP 서버로 알림 메일을 전송합니다
+// 서버가 STARTTLS를 지원하면 암호화된 연결로 전환한 뒤 인증합니다
+type SMTPNotifier struct {
+	addr    string
+	host    string
+	from    string
+	auth    smtp.Auth
+	timeout time.Duration
+}
+
+// NewSMTPNotifier는 SMTP 서버 주소(host:port)와 보내는 사람 주소로 SMTPNotifier를 생성합니다
+// username이 비어있으면 인증하지 않습니다
+func NewSMTPNotifier(addr, username, password, from string) (*SMTPNotifier, error) {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid smtp address %q: %w", addr, err)
+	}
+	if _, err := mail.ParseAddress(from); err != nil {
+		return nil, fmt.Errorf("invalid smtp from address %q: %w", from, err)
+	}
+
+	notifier := &SMTPNotifier{
+		addr:    addr,
+		host:    host,
+		from:    from,
+		timeout: 10 * time.Second,
+	}
+	if username != "" {
+		notifier.auth = smtp.PlainAuth("", username, password, host)
+	}
+	return notifier, nil
+}
+
+// LoadFromEnv는 환경변수로 Notifier를 생성합니다
+// SMTP_ADDR이 설정되지 않으면 LogNotifier를 반환합니다
+//
+// 환경변수:
+//   - SMTP_ADDR: SMTP 서버 주소 (예: smtp.example.com:587)
+//   - SMTP_FROM: 보내는 사람 주소 (SMTP_ADDR 설정 시 필수, 예: "Orbithall <no-reply@example.com>")
+//   - SMTP_USERNAME, SMTP_PASSWORD: SMTP 인증 정보 (선택)
+func LoadFromEnv() (Notifier, error) {
+	addr := os.Getenv("SMTP_ADDR")
+	if addr == "" {
+		return LogNotifier{}, nil
+	}
+
+	from := os.Getenv("SMTP_FROM")
+	if from == "" {
+		return nil, errors.New("SMTP_FROM is required when SMTP_ADDR is set")
+	}
+
+	return NewSMTPNotifier(addr, os.Getenv("SMTP_USERNAME"), os.Getenv("SMTP_PASSWORD"), from)
+}
+
+// Notify는 알림 메일을 전송합니다
+func (n *SMTPNotifier) Notify(ctx context.Context, msg Message) error {
+	data, err := buildMessage(n.from, msg)
+	if err != nil {
+		return err
+	}
+	to, err := mail.ParseAddress(msg.To)
+	if err != nil {
+		return fmt.Errorf("invalid recipient %q: %w", msg.To, err)
+	}
+	from, err := mail.ParseAddress(n.from)
+	if err != nil {
+		return fmt.Errorf("invalid sender %q: %w", n.from, err)
+	}
+
+	dialer := &net.Dialer{Timeout: n.timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", n.addr)
+	if err != nil {
+		return fmt.Errorf("failed to connect to smtp server: %w", err)
+	}
+	conn.SetDeadline(time.Now().Add(n.timeout))
+
+	client, err := smtp.NewClient(conn, n.host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to start smtp session: %w", err)
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(nil); err != nil {
+			return fmt.Errorf("failed to start tls: %w", err)
+		}
+	}
+	if n.auth != nil {
+		if err := client.Auth(n.auth); err != nil {
+			return fmt.Errorf("failed to authenticate: %w", err)
+		}
+	}
+
+	if err := client.Mail(from.Address); err != nil {
+		return fmt.Errorf("failed to set sender: %w", err)
+	}
+	if err := client.Rcpt(to.Address); err != nil {
+		return fmt.Errorf("failed to set recipient: %w", err)
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("failed to start message: %w", err)
+	}
+	if _, err := w.Write(data); err != nil {
+		return fmt.Errorf("failed to write message: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("failed to send message: %w", err)
+	}
+
+	return client.Quit()
+}
+
+// buildMessage는 알림을 UTF-8 텍스트 메일 형식으로 변환합니다
+// 헤더에 줄바꿈이 포함된 값은 헤더 삽입을 막기 위해 거부합니다
+func buildMessage(from string, msg Message) ([]byte, error) {
+	for _, value := range []string{from, msg.To, msg.Subject} {
+		if strings.ContainsAny(value, "\r\n") {
+			return nil, errors.New("notification header must not contain line breaks")
+		}
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From: %s\r\n", from)
+	fmt.Fprintf(&buf, "To: %s\r\n", msg.To)
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.BEncoding.Encode("UTF-8", msg.Subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	buf.WriteString("Content-Transfer-Encoding: base64\r\n")
+	buf.WriteString("\r\n")
+
+	// 본문은 76자 단위로 줄바꿈한 base64로 인코딩
+	encoded := base64.StdEncoding.EncodeToString([]byte(msg.Body))
+	for len(encoded) > 76 {
+		buf.WriteString(encoded[:76] + "\r\n")
+		encoded = encoded[76:]
+	}
+	buf.WriteString(encoded + "\r\n")
+
+	return buf.Bytes(), nil
+}
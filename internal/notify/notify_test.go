@@ -0,0 +1,84 @@
+package notify
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+)
+
+// TestBuildMessage는 알림 메일 형식 변환을 테스트합니다
+func TestBuildMessage(t *testing.T) {
+	t.Run("UTF-8 제목과 본문 인코딩", func(t *testing.T) {
+		// Given
+		msg := Message{To: "owner@example.com", Subject: "사이트 소유권 이전 요청", Body: "안녕하세요.\n소유권 이전을 요청했습니다."}
+
+		// When
+		data, err := buildMessage("Orbithall <no-reply@example.com>", msg)
+
+		// Then: 헤더와 base64 본문
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		header, body, ok := strings.Cut(string(data), "\r\n\r\n")
+		if !ok {
+			t.Fatal("Expected header/body separator")
+		}
+		if !strings.Contains(header, "To: owner@example.com\r\n") || !strings.Contains(header, "Subject: =?UTF-8?b?") {
+			t.Errorf("Unexpected header: %s", header)
+		}
+		decoded, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(body, "\r\n", ""))
+		if err != nil {
+			t.Fatalf("Failed to decode body: %v", err)
+		}
+		if string(decoded) != msg.Body {
+			t.Errorf("Unexpected body: %q", decoded)
+		}
+	})
+
+	t.Run("헤더 줄바꿈 거부", func(t *testing.T) {
+		msg := Message{To: "owner@example.com\r\nBcc: attacker@example.com", Subject: "Hi", Body: "Body"}
+
+		if _, err := buildMessage("no-reply@example.com", msg); err == nil {
+			t.Error("Expected error for header with line breaks")
+		}
+	})
+}
+
+// TestLoadFromEnv는 환경변수로 Notifier를 생성하는지 테스트합니다
+func TestLoadFromEnv(t *testing.T) {
+	t.Run("SMTP_ADDR 미설정 시 LogNotifier", func(t *testing.T) {
+		t.Setenv("SMTP_ADDR", "")
+
+		notifier, err := LoadFromEnv()
+
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if _, ok := notifier.(LogNotifier); !ok {
+			t.Errorf("Expected LogNotifier, got %T", notifier)
+		}
+	})
+
+	t.Run("SMTP_FROM 누락 시 오류", func(t *testing.T) {
+		t.Setenv("SMTP_ADDR", "smtp.example.com:587")
+		t.Setenv("SMTP_FROM", "")
+
+		if _, err := LoadFromEnv(); err == nil {
+			t.Error("Expected error without SMTP_FROM")
+		}
+	})
+
+	t.Run("SMTP 설정", func(t *testing.T) {
+		t.Setenv("SMTP_ADDR", "smtp.example.com:587")
+		t.Setenv("SMTP_FROM", "Orbithall <no-reply@example.com>")
+
+		notifier, err := LoadFromEnv()
+
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if _, ok := notifier.(*SMTPNotifier); !ok {
+			t.Errorf("Expected *SMTPNotifier, got %T", notifier)
+		}
+	})
+}
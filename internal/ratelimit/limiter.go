@@ -1,25 +1,62 @@
 package ratelimit
 
 import (
+	"container/list"
+	"context"
 	"sync"
+	"time"
 
 	"golang.org/x/time/rate"
 )
 
-// RateLimiter는 IP별 요청 제한을 관리합니다
-// sync.Map을 사용하여 thread-safe하게 IP별 Limiter를 저장합니다
+// 메모리 RateLimiter의 기본 설정
+const (
+	// DefaultMaxVisitors는 동시에 저장하는 제한 대상별 Limiter의 기본 최대 개수입니다
+	DefaultMaxVisitors = 100000
+
+	// DefaultIdleTimeout은 마지막 요청 이후 Limiter를 제거하기까지의 기본 대기 시간입니다
+	DefaultIdleTimeout = 10 * time.Minute
+)
+
+// visitor는 제한 대상(IP 등)별 Limiter와 마지막 요청 시각입니다
+type visitor struct {
+	key      string
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// RateLimiter는 요청 제한을 인스턴스 메모리에서 관리합니다 (Store 구현)
+// 조작된 X-Forwarded-For 값으로 IP를 무한히 늘려 메모리를 고갈시키지 못하도록,
+// 최대 maxVisitors개의 Limiter만 저장하고 가득 차면 가장 오래 요청이 없던 대상부터 제거합니다 (LRU)
+// StartJanitor로 백그라운드 정리를 시작하면 idleTimeout 동안 요청이 없고 버킷이 가득 찬 대상도 주기적으로 제거합니다
 type RateLimiter struct {
-	// visitors는 IP 주소를 키로, *rate.Limiter를 값으로 저장합니다
-	visitors sync.Map
+	mu sync.Mutex
 
-	// limit는 초당 허용되는 요청 수입니다 (토큰 생성 속도)
+	// visitors는 제한 대상(GetLimiter는 IP 주소, Take는 bucket:key)을 키로, order의 원소(*visitor)를 값으로 저장합니다
+	visitors map[string]*list.Element
+
+	// order는 최근 요청 순서입니다 (앞쪽이 가장 최근)
+	order *list.List
+
+	// limit는 GetLimiter가 적용하는 초당 허용 요청 수입니다 (토큰 생성 속도)
+	// Take는 호출 시 전달된 제한을 적용합니다
 	limit rate.Limit
 
-	// burst는 한 번에 허용되는 최대 요청 수입니다 (버킷 크기)
+	// burst는 GetLimiter가 적용하는 최대 연속 요청 수입니다 (버킷 크기)
 	burst int
+
+	// maxVisitors는 저장하는 Limiter의 최대 개수입니다
+	maxVisitors int
+
+	// idleTimeout은 요청이 없는 Limiter를 정리하기까지의 대기 시간입니다
+	idleTimeout time.Duration
+
+	// stop은 백그라운드 정리를 종료하는 채널입니다 (StartJanitor 호출 시 생성)
+	stop     chan struct{}
+	stopOnce sync.Once
 }
 
-// NewRateLimiter는 새로운 RateLimiter를 생성합니다
+// NewRateLimiter는 기본 최대 개수(DefaultMaxVisitors)와 정리 대기 시간(DefaultIdleTimeout)으로 새로운 RateLimiter를 생성합니다
 //
 // 파라미터:
 //   - limit: 초당 허용되는 요청 수 (예: 10 = 10 req/sec)
@@ -28,15 +65,29 @@ type RateLimiter struct {
 // 예시:
 //   - NewRateLimiter(10, 5): 초당 10개, 최대 5개까지 burst 허용
 func NewRateLimiter(limit rate.Limit, burst int) *RateLimiter {
+	return NewBoundedRateLimiter(limit, burst, DefaultMaxVisitors, DefaultIdleTimeout)
+}
+
+// NewBoundedRateLimiter는 저장하는 Limiter의 최대 개수와 정리 대기 시간을 지정하여 새로운 RateLimiter를 생성합니다
+// maxVisitors가 1보다 작으면 1로 설정합니다
+func NewBoundedRateLimiter(limit rate.Limit, burst int, maxVisitors int, idleTimeout time.Duration) *RateLimiter {
+	if maxVisitors < 1 {
+		maxVisitors = 1
+	}
+
 	return &RateLimiter{
-		visitors: sync.Map{},
-		limit:    limit,
-		burst:    burst,
+		visitors:    make(map[string]*list.Element),
+		order:       list.New(),
+		limit:       limit,
+		burst:       burst,
+		maxVisitors: maxVisitors,
+		idleTimeout: idleTimeout,
 	}
 }
 
-// GetLimiter는 IP 주소에 대한 Limiter를 반환합니다
+// GetLimiter는 IP 주소에 대한 Limiter를 반환하고 마지막 요청 시각을 갱신합니다
 // IP가 처음 요청되면 새 Limiter를 생성하고, 이미 존재하면 기존 Limiter를 반환합니다
+// 새 Limiter를 추가하여 최대 개수를 넘으면 가장 오래 요청이 없던 대상의 Limiter를 제거합니다
 //
 // 토큰 버킷 알고리즘:
 //   - burst만큼의 토큰으로 시작
@@ -44,18 +95,118 @@ func NewRateLimiter(limit rate.Limit, burst int) *RateLimiter {
 //   - limit 속도로 토큰 재충전
 //   - 토큰이 없으면 요청 거부
 func (rl *RateLimiter) GetLimiter(ip string) *rate.Limiter {
-	// IP에 해당하는 Limiter 조회
-	if limiter, exists := rl.visitors.Load(ip); exists {
-		// 이미 존재하면 기존 Limiter 반환
-		return limiter.(*rate.Limiter)
+	return rl.getLimiter(ip, Limit{Rate: rl.limit, Burst: rl.burst})
+}
+
+// Take는 bucket의 key Limiter에 limit을 적용하여 요청 1개의 허용 여부를 판단합니다
+func (rl *RateLimiter) Take(ctx context.Context, bucket, key string, limit Limit) (Result, error) {
+	limiter := rl.getLimiter(bucket+":"+key, limit)
+
+	now := time.Now()
+	allowed := limiter.AllowN(now, 1)
+
+	return newResult(allowed, limiter.TokensAt(now), limit), nil
+}
+
+// getLimiter는 key의 Limiter를 반환하고 마지막 요청 시각을 갱신합니다
+// 기존 Limiter의 제한이 limit과 다르면 (사이트 설정 변경 등) limit으로 바꿉니다
+func (rl *RateLimiter) getLimiter(key string, limit Limit) *rate.Limiter {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	now := time.Now()
+
+	// 이미 존재하면 최근 요청으로 표시하고 기존 Limiter 반환
+	if element, exists := rl.visitors[key]; exists {
+		v := element.Value.(*visitor)
+		v.lastSeen = now
+		rl.order.MoveToFront(element)
+		if v.limiter.Limit() != limit.Rate {
+			v.limiter.SetLimitAt(now, limit.Rate)
+		}
+		if v.limiter.Burst() != limit.Burst {
+			v.limiter.SetBurstAt(now, limit.Burst)
+		}
+		return v.limiter
 	}
 
 	// 새 Limiter 생성
-	limiter := rate.NewLimiter(rl.limit, rl.burst)
+	v := &visitor{key: key, limiter: rate.NewLimiter(limit.Rate, limit.Burst), lastSeen: now}
+	rl.visitors[key] = rl.order.PushFront(v)
+
+	// 최대 개수 초과 시 가장 오래 요청이 없던 대상부터 제거
+	for rl.order.Len() > rl.maxVisitors {
+		rl.removeElement(rl.order.Back())
+	}
+
+	return v.limiter
+}
+
+// Len은 저장된 Limiter 수를 반환합니다
+func (rl *RateLimiter) Len() int {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	return rl.order.Len()
+}
+
+// StartJanitor는 interval마다 idleTimeout 동안 요청이 없던 대상의 Limiter를 제거하는 백그라운드 정리를 시작합니다
+// Stop을 호출하면 종료되며, 한 RateLimiter에서 한 번만 호출해야 합니다
+func (rl *RateLimiter) StartJanitor(interval time.Duration) {
+	rl.stop = make(chan struct{})
+	stop := rl.stop
 
-	// sync.Map에 저장 (thread-safe)
-	// LoadOrStore는 다른 고루틴이 동시에 같은 IP로 요청했을 때를 대비합니다
-	actual, _ := rl.visitors.LoadOrStore(ip, limiter)
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-stop:
+				return
+			case now := <-ticker.C:
+				rl.evictIdle(now)
+			}
+		}
+	}()
+}
+
+// Stop은 StartJanitor로 시작한 백그라운드 정리를 종료합니다
+// 정리를 시작하지 않았거나 이미 종료했으면 아무 동작도 하지 않습니다
+func (rl *RateLimiter) Stop() {
+	rl.stopOnce.Do(func() {
+		if rl.stop != nil {
+			close(rl.stop)
+		}
+	})
+}
+
+// evictIdle은 now 기준으로 idleTimeout 동안 요청이 없던 대상의 Limiter를 제거하고, 제거한 개수를 반환합니다
+// 버킷이 아직 가득 차지 않은 Limiter는 제거하면 제한이 초기화되므로 남겨 둡니다
+// order는 최근 요청 순서이므로 뒤쪽부터 확인하다가 idleTimeout이 지나지 않은 대상을 만나면 멈춥니다
+func (rl *RateLimiter) evictIdle(now time.Time) int {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	evicted := 0
+	for element := rl.order.Back(); element != nil; {
+		prev := element.Prev()
+		v := element.Value.(*visitor)
+		if now.Sub(v.lastSeen) < rl.idleTimeout {
+			break
+		}
+		if v.limiter.TokensAt(now) >= float64(v.limiter.Burst()) {
+			rl.removeElement(element)
+			evicted++
+		}
+		element = prev
+	}
+
+	return evicted
+}
 
-	return actual.(*rate.Limiter)
+// removeElement는 Limiter를 order와 visitors에서 제거합니다 (rl.mu를 잡은 상태에서 호출)
+func (rl *RateLimiter) removeElement(element *list.Element) {
+	v := rl.order.Remove(element).(*visitor)
+	delete(rl.visitors, v.key)
 }
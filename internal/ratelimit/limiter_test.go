@@ -1,6 +1,8 @@
 package ratelimit
 
 import (
+	"fmt"
+	"sync"
 	"testing"
 	"time"
 
@@ -95,3 +97,166 @@ func TestGetLimiter_DifferentIPs(t *testing.T) {
 		t.Fatal("Expected different limiter instances for different IPs")
 	}
 }
+
+// TestGetLimiter_EvictsLeastRecentlySeen은 최대 개수를 넘으면 가장 오래 요청이 없던 IP를 제거하는지 테스트합니다
+func TestGetLimiter_EvictsLeastRecentlySeen(t *testing.T) {
+	// Given: 최대 2개인 RateLimiter에 두 IP 등록 후 첫 IP 재요청
+	rl := NewBoundedRateLimiter(rate.Limit(10), 5, 2, time.Minute)
+	limiter1 := rl.GetLimiter("192.168.1.1")
+	limiter2 := rl.GetLimiter("192.168.1.2")
+	rl.GetLimiter("192.168.1.1")
+
+	// When: 세 번째 IP 요청
+	rl.GetLimiter("192.168.1.3")
+
+	// Then: 최대 개수 유지, 최근 요청한 IP는 유지되고 가장 오래된 IP는 새 Limiter를 받음
+	if rl.Len() != 2 {
+		t.Fatalf("Expected 2 visitors, got %d", rl.Len())
+	}
+	if rl.GetLimiter("192.168.1.1") != limiter1 {
+		t.Error("Expected recently seen IP to keep its limiter")
+	}
+	if rl.GetLimiter("192.168.1.2") == limiter2 {
+		t.Error("Expected least recently seen IP to be evicted")
+	}
+}
+
+// TestEvictIdle은 idleTimeout 동안 요청이 없던 IP만 제거하는지 테스트합니다
+func TestEvictIdle(t *testing.T) {
+	// Given: idleTimeout 1분인 RateLimiter에 두 IP 등록
+	rl := NewBoundedRateLimiter(rate.Limit(10), 5, 100, time.Minute)
+	rl.GetLimiter("192.168.1.1")
+	rl.GetLimiter("192.168.1.2")
+
+	// When: 30초 후 기준으로 정리
+	evicted := rl.evictIdle(time.Now().Add(30 * time.Second))
+
+	// Then: 제거되지 않음
+	if evicted != 0 || rl.Len() != 2 {
+		t.Fatalf("Expected no eviction, got evicted=%d len=%d", evicted, rl.Len())
+	}
+
+	// When: 2분 후 기준으로 정리
+	evicted = rl.evictIdle(time.Now().Add(2 * time.Minute))
+
+	// Then: 모두 제거됨
+	if evicted != 2 || rl.Len() != 0 {
+		t.Fatalf("Expected 2 evictions, got evicted=%d len=%d", evicted, rl.Len())
+	}
+}
+
+// TestEvictIdle_KeepsUnfilledBucket은 버킷이 가득 차기 전에는 정리하지 않는지 테스트합니다
+func TestEvictIdle_KeepsUnfilledBucket(t *testing.T) {
+	// Given: 분당 1개, burst 5 (가득 차는 데 5분)인 Limiter의 토큰을 모두 소비
+	rl := NewBoundedRateLimiter(rate.Every(time.Minute), 5, 100, time.Second)
+	limiter := rl.GetLimiter("192.168.1.1")
+	for i := 0; i < 5; i++ {
+		limiter.Allow()
+	}
+
+	// When: 1분 후 기준으로 정리
+	evicted := rl.evictIdle(time.Now().Add(time.Minute))
+
+	// Then: 버킷이 가득 차지 않았으므로 유지
+	if evicted != 0 {
+		t.Errorf("Expected limiter to be kept until bucket refills, got %d evictions", evicted)
+	}
+
+	// When: 10분 후 기준으로 정리
+	evicted = rl.evictIdle(time.Now().Add(10 * time.Minute))
+
+	// Then: 버킷이 가득 찼으므로 제거
+	if evicted != 1 {
+		t.Errorf("Expected limiter to be evicted after refill, got %d evictions", evicted)
+	}
+}
+
+// TestTake_PerCallLimit은 Take가 호출 시 전달된 제한을 bucket별로 적용하는지 테스트합니다
+func TestTake_PerCallLimit(t *testing.T) {
+	// Given: 기본 제한 burst 1인 RateLimiter
+	rl := NewRateLimiter(rate.Limit(1), 1)
+	ctx := t.Context()
+
+	// When: burst 3 제한으로 4번 요청
+	var allowed []bool
+	for i := 0; i < 4; i++ {
+		result, _ := rl.Take(ctx, "create", "192.168.1.1", Limit{Rate: rate.Limit(1), Burst: 3})
+		allowed = append(allowed, result.Allowed)
+	}
+	other, _ := rl.Take(ctx, "update", "192.168.1.1", Limit{Rate: rate.Limit(1), Burst: 3})
+
+	// Then: 3번만 허용, 다른 bucket은 독립
+	if !allowed[0] || !allowed[1] || !allowed[2] || allowed[3] {
+		t.Errorf("Expected [true true true false], got %v", allowed)
+	}
+	if !other.Allowed {
+		t.Error("Expected other bucket to be allowed")
+	}
+
+	// When: 같은 대상에 더 큰 burst로 변경 후 요청
+	result, _ := rl.Take(ctx, "create", "192.168.1.1", Limit{Rate: rate.Limit(1), Burst: 10})
+
+	// Then: 변경된 한도가 적용됨
+	if result.Limit != 10 {
+		t.Errorf("Expected limit 10, got %d", result.Limit)
+	}
+}
+
+// TestStartJanitor는 백그라운드 정리가 유휴 IP를 제거하고 Stop으로 종료되는지 테스트합니다
+func TestStartJanitor(t *testing.T) {
+	// Given: 빠르게 충전되고 idleTimeout이 짧은 RateLimiter
+	rl := NewBoundedRateLimiter(rate.Limit(1000), 1, 100, 10*time.Millisecond)
+	rl.GetLimiter("192.168.1.1")
+
+	// When: 정리 시작 후 대기
+	rl.StartJanitor(5 * time.Millisecond)
+	deadline := time.Now().Add(time.Second)
+	for rl.Len() > 0 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	rl.Stop()
+	rl.Stop() // 여러 번 호출해도 안전
+
+	// Then: 유휴 IP 제거됨
+	if rl.Len() != 0 {
+		t.Errorf("Expected idle visitor to be evicted, got %d", rl.Len())
+	}
+}
+
+// TestGetLimiter_ConcurrentDuringEviction은 정리 중 동시 접근이 안전하고 최대 개수를 넘지 않는지 테스트합니다
+func TestGetLimiter_ConcurrentDuringEviction(t *testing.T) {
+	// Given: 최대 50개, 백그라운드 정리가 계속 실행되는 RateLimiter
+	rl := NewBoundedRateLimiter(rate.Limit(1000), 1, 50, time.Millisecond)
+	rl.StartJanitor(time.Millisecond)
+	defer rl.Stop()
+
+	// When: 여러 goroutine에서 서로 다른 IP로 동시에 요청
+	var wg sync.WaitGroup
+	for g := 0; g < 10; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 500; i++ {
+				ip := fmt.Sprintf("10.%d.%d.%d", g, i/256, i%256)
+				if rl.GetLimiter(ip) == nil {
+					t.Errorf("Expected limiter for %s", ip)
+					return
+				}
+				if _, err := rl.Take(t.Context(), "test", ip, Limit{Rate: rate.Limit(1000), Burst: 1}); err != nil {
+					t.Errorf("Unexpected error: %v", err)
+					return
+				}
+				if n := rl.Len(); n > 50 {
+					t.Errorf("Expected at most 50 visitors, got %d", n)
+					return
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	// Then: 최대 개수를 넘지 않음
+	if rl.Len() > 50 {
+		t.Errorf("Expected at most 50 visitors, got %d", rl.Len())
+	}
+}
@@ -1,31 +1,87 @@
 package ratelimit
 
 import (
+	"log"
+	"math"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/june20516/orbithall/internal/httputil"
 )
 
-// RateLimitMiddleware는 IP 기반 요청 제한 미들웨어를 반환합니다
-// 제한을 초과하면 429 Too Many Requests 응답을 반환합니다
+// Target은 요청에 적용할 제한입니다
+type Target struct {
+	// Bucket은 제한 종류입니다 (예: "comment-create")
+	Bucket string
+
+	// Key는 제한 대상입니다 (예: IP 주소, 사이트와 IP 조합)
+	Key string
+
+	// Limit은 적용할 제한입니다
+	Limit Limit
+}
+
+// Rule은 요청에 적용할 제한을 정합니다
+// ok가 false면 요청을 제한하지 않습니다
+type Rule func(r *http.Request) (target Target, ok bool)
+
+// ByIP는 모든 요청에 같은 제한을 IP별로 적용하는 Rule을 반환합니다
+func ByIP(bucket string, limit Limit) Rule {
+	return func(r *http.Request) (Target, bool) {
+		return Target{Bucket: bucket, Key: httputil.GetIPAddress(r), Limit: limit}, true
+	}
+}
+
+// RateLimitMiddleware는 rules가 정한 제한을 store로 적용하는 미들웨어를 반환합니다
+// 여러 rule을 지정하면 적용되는 제한을 순서대로 모두 확인하며, 하나라도 초과하면 요청을 거부합니다
+// 제한을 적용한 응답에는 가장 엄격한 제한(남은 요청 수가 가장 적은 제한)의 RateLimit-Limit, RateLimit-Remaining, RateLimit-Reset(초) 헤더를 포함하고,
+// 제한을 초과하면 Retry-After(초) 헤더와 함께 429 Too Many Requests 응답을 반환합니다
+// 저장소 오류로 허용 여부를 판단할 수 없으면 그 제한은 적용하지 않고 오류를 로그로 남깁니다
 //
 // 사용 예시:
 //
 //	rl := NewRateLimiter(rate.Limit(10), 5)
-//	r.Use(RateLimitMiddleware(rl))
-func RateLimitMiddleware(rl *RateLimiter) func(http.Handler) http.Handler {
+//	r.Use(RateLimitMiddleware(rl, ByIP("api", PerMinute(60, 10))))
+func RateLimitMiddleware(store Store, rules ...Rule) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			// IP 주소 추출 (프록시 환경 고려)
-			ip := httputil.GetIPAddress(r)
+			var strictest *Result
+			for _, rule := range rules {
+				// 적용할 제한 결정 (IP 주소는 프록시 환경 고려하여 추출)
+				target, ok := rule(r)
+				if !ok {
+					continue
+				}
 
-			// IP별 Limiter 가져오기
-			limiter := rl.GetLimiter(ip)
+				// 요청 허용 여부 확인
+				result, err := store.Take(r.Context(), target.Bucket, target.Key, target.Limit)
+				if err != nil {
+					log.Printf("[ERROR] Rate limit store failed: %v", err)
+					continue
+				}
 
-			// 요청 허용 여부 확인
-			if !limiter.Allow() {
-				// 제한 초과 - 429 응답
-				w.Header().Set("Retry-After", "1")
+				if strictest == nil || !result.Allowed || result.Remaining < strictest.Remaining {
+					strictest = &result
+				}
+				if !result.Allowed {
+					// 초과한 제한이 있으면 나머지 제한의 토큰은 소비하지 않음
+					break
+				}
+			}
+
+			if strictest == nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			w.Header().Set("RateLimit-Limit", strconv.Itoa(strictest.Limit))
+			w.Header().Set("RateLimit-Remaining", strconv.Itoa(strictest.Remaining))
+			w.Header().Set("RateLimit-Reset", strconv.Itoa(ceilSeconds(strictest.ResetAfter)))
+
+			if !strictest.Allowed {
+				// 제한 초과 - 429 응답 (최소 1초 후 재시도)
+				w.Header().Set("Retry-After", strconv.Itoa(max(ceilSeconds(strictest.RetryAfter), 1)))
 				w.Header().Set("Content-Type", "application/json")
 				w.WriteHeader(http.StatusTooManyRequests)
 				w.Write([]byte(`{"error":"rate_limit_exceeded","message":"Too many requests. Please try again later."}`))
@@ -37,3 +93,8 @@ func RateLimitMiddleware(rl *RateLimiter) func(http.Handler) http.Handler {
 		})
 	}
 }
+
+// ceilSeconds는 시간을 초 단위로 올림합니다
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
@@ -1,11 +1,14 @@
 package ratelimit
 
 import (
+	"context"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"strconv"
 	"testing"
 
+	"github.com/june20516/orbithall/internal/httputil"
 	"golang.org/x/time/rate"
 )
 
@@ -13,7 +16,7 @@ import (
 func TestRateLimitMiddleware_AllowWithinLimit(t *testing.T) {
 	// Given: 10 req/sec, burst 5인 미들웨어
 	rl := NewRateLimiter(rate.Limit(10), 5)
-	middleware := RateLimitMiddleware(rl)
+	middleware := RateLimitMiddleware(rl, ByIP("test", Limit{Rate: rate.Limit(10), Burst: 5}))
 
 	// 테스트용 핸들러 (200 OK 반환)
 	handler := middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -40,7 +43,7 @@ func TestRateLimitMiddleware_AllowWithinLimit(t *testing.T) {
 func TestRateLimitMiddleware_Deny429OnExceed(t *testing.T) {
 	// Given: 1 req/sec, burst 2인 미들웨어 (테스트를 위해 낮은 값)
 	rl := NewRateLimiter(rate.Limit(1), 2)
-	middleware := RateLimitMiddleware(rl)
+	middleware := RateLimitMiddleware(rl, ByIP("test", Limit{Rate: rate.Limit(1), Burst: 2}))
 
 	handler := middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
@@ -77,7 +80,7 @@ func TestRateLimitMiddleware_Deny429OnExceed(t *testing.T) {
 func TestRateLimitMiddleware_RetryAfterHeader(t *testing.T) {
 	// Given: 1 req/sec, burst 1인 미들웨어
 	rl := NewRateLimiter(rate.Limit(1), 1)
-	middleware := RateLimitMiddleware(rl)
+	middleware := RateLimitMiddleware(rl, ByIP("test", Limit{Rate: rate.Limit(1), Burst: 1}))
 
 	handler := middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
@@ -111,7 +114,7 @@ func TestRateLimitMiddleware_RetryAfterHeader(t *testing.T) {
 func TestRateLimitMiddleware_IndependentIPs(t *testing.T) {
 	// Given: 1 req/sec, burst 1인 미들웨어
 	rl := NewRateLimiter(rate.Limit(1), 1)
-	middleware := RateLimitMiddleware(rl)
+	middleware := RateLimitMiddleware(rl, ByIP("test", Limit{Rate: rate.Limit(1), Burst: 1}))
 
 	handler := middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
@@ -149,11 +152,15 @@ func TestRateLimitMiddleware_IndependentIPs(t *testing.T) {
 	}
 }
 
-// TestRateLimitMiddleware_XForwardedFor는 X-Forwarded-For 헤더를 사용하는지 테스트합니다
+// TestRateLimitMiddleware_XForwardedFor는 신뢰하는 프록시를 거친 요청에 X-Forwarded-For 헤더를 사용하는지 테스트합니다
 func TestRateLimitMiddleware_XForwardedFor(t *testing.T) {
-	// Given: 1 req/sec, burst 1인 미들웨어
+	// Given: 1 req/sec, burst 1인 미들웨어, 192.168.0.0/16 대역의 프록시를 신뢰
+	prefixes, _ := httputil.ParseTrustedProxies("192.168.0.0/16")
+	httputil.SetTrustedProxies(prefixes)
+	defer httputil.SetTrustedProxies(nil)
+
 	rl := NewRateLimiter(rate.Limit(1), 1)
-	middleware := RateLimitMiddleware(rl)
+	middleware := RateLimitMiddleware(rl, ByIP("test", Limit{Rate: rate.Limit(1), Burst: 1}))
 
 	handler := middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
@@ -161,8 +168,8 @@ func TestRateLimitMiddleware_XForwardedFor(t *testing.T) {
 
 	// When: X-Forwarded-For 헤더를 포함한 요청 (성공)
 	req1 := httptest.NewRequest(http.MethodGet, "/test", nil)
-	req1.Header.Set("X-Forwarded-For", "10.0.0.1, 10.0.0.2")
-	req1.RemoteAddr = "192.168.1.1:12345" // 이 IP는 무시되어야 함
+	req1.Header.Set("X-Forwarded-For", "10.0.0.1")
+	req1.RemoteAddr = "192.168.1.1:12345" // 신뢰하는 프록시의 IP는 무시되어야 함
 	rec1 := httptest.NewRecorder()
 	handler.ServeHTTP(rec1, req1)
 
@@ -172,13 +179,181 @@ func TestRateLimitMiddleware_XForwardedFor(t *testing.T) {
 
 	// When: 같은 X-Forwarded-For IP로 두 번째 요청 (제한 초과)
 	req2 := httptest.NewRequest(http.MethodGet, "/test", nil)
-	req2.Header.Set("X-Forwarded-For", "10.0.0.1, 10.0.0.2")
+	req2.Header.Set("X-Forwarded-For", "10.0.0.1")
 	req2.RemoteAddr = "192.168.1.99:54321" // RemoteAddr가 달라도 같은 IP로 판단
 	rec2 := httptest.NewRecorder()
 	handler.ServeHTTP(rec2, req2)
 
-	// Then: X-Forwarded-For의 첫 번째 IP(10.0.0.1)를 기준으로 제한되어야 함
+	// Then: X-Forwarded-For의 클라이언트 IP(10.0.0.1)를 기준으로 제한되어야 함
 	if rec2.Code != http.StatusTooManyRequests {
 		t.Fatalf("Second request: expected 429, got %d", rec2.Code)
 	}
 }
+
+// TestRateLimitMiddleware_SpoofedXForwardedFor는 신뢰하지 않는 클라이언트가 X-Forwarded-For로 제한을 우회하지 못하는지 테스트합니다
+func TestRateLimitMiddleware_SpoofedXForwardedFor(t *testing.T) {
+	// Given: 1 req/sec, burst 1인 미들웨어 (신뢰하는 프록시 없음)
+	rl := NewRateLimiter(rate.Limit(1), 1)
+	middleware := RateLimitMiddleware(rl, ByIP("test", Limit{Rate: rate.Limit(1), Burst: 1}))
+
+	handler := middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	// When: 같은 클라이언트가 요청마다 다른 X-Forwarded-For 값으로 요청
+	var codes []int
+	for _, spoofed := range []string{"10.0.0.1", "10.0.0.2"} {
+		req := httptest.NewRequest(http.MethodGet, "/test", nil)
+		req.Header.Set("X-Forwarded-For", spoofed)
+		req.RemoteAddr = "203.0.113.1:12345"
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		codes = append(codes, rec.Code)
+	}
+
+	// Then: 직접 연결된 IP 기준으로 두 번째 요청이 제한되어야 함
+	if codes[0] != http.StatusOK || codes[1] != http.StatusTooManyRequests {
+		t.Fatalf("expected [200 429], got %v", codes)
+	}
+}
+
+// failingStore는 항상 오류를 반환하는 테스트용 저장소입니다
+type failingStore struct{}
+
+func (failingStore) Take(ctx context.Context, bucket, key string, limit Limit) (Result, error) {
+	return Result{}, errors.New("store unavailable")
+}
+
+// TestRateLimitMiddleware_StoreErrorAllows는 저장소 오류 시 요청을 허용하는지 테스트합니다
+func TestRateLimitMiddleware_StoreErrorAllows(t *testing.T) {
+	// Given: 항상 실패하는 저장소를 사용하는 미들웨어
+	handler := RateLimitMiddleware(failingStore{}, ByIP("test", PerMinute(1, 1)))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	// When: 요청
+	req := httptest.NewRequest(http.MethodGet, "/test", nil)
+	req.RemoteAddr = "192.168.1.1:12345"
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	// Then: 200 OK (제한하지 않음)
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected status 200, got %d", rec.Code)
+	}
+}
+
+// TestRateLimitMiddleware_Headers는 RateLimit-* 헤더와 Retry-After 값을 테스트합니다
+func TestRateLimitMiddleware_Headers(t *testing.T) {
+	// Given: 분당 6개(10초당 1개), burst 2인 미들웨어
+	rl := NewRateLimiter(rate.Limit(1), 1)
+	handler := RateLimitMiddleware(rl, ByIP("test", PerMinute(6, 2)))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	send := func() *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/test", nil)
+		req.RemoteAddr = "192.168.1.1:12345"
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	// When: 첫 번째 요청
+	rec := send()
+
+	// Then: 한도 2, 남은 요청 1, 가득 찰 때까지 10초
+	if got := rec.Header().Get("RateLimit-Limit"); got != "2" {
+		t.Errorf("Expected RateLimit-Limit 2, got %q", got)
+	}
+	if got := rec.Header().Get("RateLimit-Remaining"); got != "1" {
+		t.Errorf("Expected RateLimit-Remaining 1, got %q", got)
+	}
+	if got := rec.Header().Get("RateLimit-Reset"); got != "10" {
+		t.Errorf("Expected RateLimit-Reset 10, got %q", got)
+	}
+
+	// When: 한도를 넘는 세 번째 요청
+	send()
+	rec = send()
+
+	// Then: 429, 남은 요청 0, 토큰 1개 충전까지 10초 후 재시도
+	if rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("Expected 429, got %d", rec.Code)
+	}
+	if got := rec.Header().Get("RateLimit-Remaining"); got != "0" {
+		t.Errorf("Expected RateLimit-Remaining 0, got %q", got)
+	}
+	if got := rec.Header().Get("Retry-After"); got != "10" {
+		t.Errorf("Expected Retry-After 10, got %q", got)
+	}
+}
+
+// TestRateLimitMiddleware_RuleSkip은 Rule이 제한하지 않기로 한 요청은 헤더 없이 통과하는지 테스트합니다
+func TestRateLimitMiddleware_RuleSkip(t *testing.T) {
+	// Given: 모든 요청을 제한하지 않는 Rule
+	rl := NewRateLimiter(rate.Limit(1), 1)
+	skip := func(r *http.Request) (Target, bool) { return Target{}, false }
+	handler := RateLimitMiddleware(rl, skip)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	// When: 여러 번 요청
+	var rec *httptest.ResponseRecorder
+	for i := 0; i < 3; i++ {
+		rec = httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/test", nil))
+	}
+
+	// Then: 모두 허용, RateLimit 헤더 없음
+	if rec.Code != http.StatusOK || rec.Header().Get("RateLimit-Limit") != "" {
+		t.Errorf("Expected unlimited request without headers, got %d %v", rec.Code, rec.Header())
+	}
+}
+
+// TestRateLimitMiddleware_MultipleRules는 여러 Rule의 제한을 모두 적용하는지 테스트합니다
+func TestRateLimitMiddleware_MultipleRules(t *testing.T) {
+	// Given: IP별 burst 3, 요청마다 키가 바뀌는 세션별 burst 1 제한
+	rl := NewRateLimiter(rate.Limit(1), 1)
+	session := 0
+	bySession := func(r *http.Request) (Target, bool) {
+		session++
+		return Target{Bucket: "session", Key: strconv.Itoa(session), Limit: PerMinute(1, 1)}, true
+	}
+	handler := RateLimitMiddleware(rl, ByIP("ip", PerMinute(3, 3)), bySession)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	send := func() *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/test", nil)
+		req.RemoteAddr = "192.168.1.1:12345"
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	// When: 첫 번째 요청
+	rec := send()
+
+	// Then: 가장 엄격한 세션별 제한의 헤더
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", rec.Code)
+	}
+	if got := rec.Header().Get("RateLimit-Limit"); got != "1" {
+		t.Errorf("Expected RateLimit-Limit 1, got %q", got)
+	}
+	if got := rec.Header().Get("RateLimit-Remaining"); got != "0" {
+		t.Errorf("Expected RateLimit-Remaining 0, got %q", got)
+	}
+
+	// When: 세션 키를 바꿔가며 IP별 제한을 넘게 요청
+	send()
+	send()
+	rec = send()
+
+	// Then: 세션 키를 바꿔도 IP별 제한으로 429
+	if rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("Expected 429, got %d", rec.Code)
+	}
+	if got := rec.Header().Get("RateLimit-Limit"); got != "3" {
+		t.Errorf("Expected RateLimit-Limit 3, got %q", got)
+	}
+}
@@ -0,0 +1,28 @@
+package ratelimit
+
+import (
+	"context"
+
+	"github.com/june20516/orbithall/internal/database"
+)
+
+// PostgresStore는 요청 제한 상태를 PostgreSQL에 저장합니다 (Store 구현)
+// 모든 API 인스턴스가 같은 토큰 버킷을 사용하므로 인스턴스 수와 관계없이 제한이 유지되고, 재시작해도 초기화되지 않습니다
+type PostgresStore struct {
+	db database.DBTX
+}
+
+// NewPostgresStore는 새로운 PostgresStore를 생성합니다
+func NewPostgresStore(db database.DBTX) *PostgresStore {
+	return &PostgresStore{db: db}
+}
+
+// Take는 bucket의 key 토큰 버킷에 limit을 적용하여 요청 1개의 허용 여부를 판단합니다
+func (s *PostgresStore) Take(ctx context.Context, bucket, key string, limit Limit) (Result, error) {
+	allowed, tokens, err := database.TakeRateLimitToken(ctx, s.db, bucket, key, float64(limit.Rate), limit.Burst)
+	if err != nil {
+		return Result{}, err
+	}
+
+	return newResult(allowed, tokens, limit), nil
+}
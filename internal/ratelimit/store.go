@@ -0,0 +1,74 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// Store는 제한 대상별 요청 제한 상태를 저장하고 요청 허용 여부를 판단하는 저장소입니다
+//
+// 구현체:
+//   - RateLimiter: 인스턴스 메모리에 저장 (인스턴스마다 별도 제한, 재시작 시 초기화)
+//   - PostgresStore: PostgreSQL에 저장 (모든 인스턴스가 제한 공유, 재시작해도 유지)
+type Store interface {
+	// Take는 bucket(제한 종류)의 key(제한 대상)에 limit을 적용하여 요청 1개의 허용 여부를 판단합니다
+	// 허용하면 토큰 1개를 소비하고, 거부하면 상태를 바꾸지 않습니다
+	Take(ctx context.Context, bucket, key string, limit Limit) (Result, error)
+}
+
+// Limit은 요청 제한 설정입니다 (토큰 버킷)
+type Limit struct {
+	// Rate는 초당 충전되는 토큰 수입니다
+	Rate rate.Limit
+
+	// Burst는 버킷 크기(한 번에 허용되는 최대 요청 수)입니다
+	Burst int
+}
+
+// PerMinute는 분당 n개, 최대 burst개까지 연속 요청을 허용하는 Limit을 반환합니다
+func PerMinute(n, burst int) Limit {
+	return Limit{Rate: rate.Limit(float64(n) / 60), Burst: burst}
+}
+
+// Result는 요청 1개에 대한 제한 판단 결과입니다
+type Result struct {
+	// Allowed는 요청 허용 여부입니다
+	Allowed bool
+
+	// Limit은 버킷 크기입니다 (RateLimit-Limit 헤더)
+	Limit int
+
+	// Remaining은 지금 바로 보낼 수 있는 남은 요청 수입니다 (RateLimit-Remaining 헤더)
+	Remaining int
+
+	// ResetAfter는 버킷이 가득 찰 때까지 남은 시간입니다 (RateLimit-Reset 헤더)
+	ResetAfter time.Duration
+
+	// RetryAfter는 거부된 경우 다음 요청이 허용될 때까지 남은 시간입니다 (Retry-After 헤더)
+	RetryAfter time.Duration
+}
+
+// newResult는 처리 후 남은 토큰 수로 Result를 만듭니다
+func newResult(allowed bool, tokens float64, limit Limit) Result {
+	tokens = math.Max(tokens, 0)
+	result := Result{
+		Allowed:   allowed,
+		Limit:     limit.Burst,
+		Remaining: int(math.Floor(tokens)),
+	}
+
+	if limit.Rate > 0 && limit.Rate != rate.Inf {
+		perToken := float64(time.Second) / float64(limit.Rate)
+		if missing := float64(limit.Burst) - tokens; missing > 0 {
+			result.ResetAfter = time.Duration(missing * perToken)
+		}
+		if !allowed && tokens < 1 {
+			result.RetryAfter = time.Duration((1 - tokens) * perToken)
+		}
+	}
+
+	return result
+}
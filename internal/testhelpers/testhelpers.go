@@ -82,30 +82,54 @@ func SetupTxTest(t *testing.T, db *sql.DB) (context.Context, DBTX, func()) {
 }
 
 // CreateTestSite는 테스트용 사이트를 생성하고 API 키를 반환합니다
-// 테스트용 API 키는 "orb_test_" prefix를 사용합니다
+// 사이트는 도메인 소유권이 검증된 상태로 생성됩니다
+// 기본 키는 실제 데이터를 사용하는 "orb_live_" 키로 site_api_keys에 등록됩니다
+// (테스트 모드 키가 필요하면 CreateTestAPIKey를 사용)
 func CreateTestSite(ctx context.Context, t *testing.T, db DBTX, name string, domain string, corsOrigins []string, isActive bool) models.Site {
 	t.Helper()
 
-	// 테스트용 API 키 생성
-	apiKey := models.GenerateAPIKey("orb_test_")
-
 	query := `
-		INSERT INTO sites (name, domain, api_key, cors_origins, is_active)
-		VALUES ($1, $2, $3, $4, $5)
-		RETURNING id, name, domain, api_key, cors_origins, is_active, created_at, updated_at
+		INSERT INTO sites (name, domain, cors_origins, is_active, is_verified, verified_at)
+		VALUES ($1, $2, $3, $4, TRUE, NOW())
+		RETURNING id, name, domain, cors_origins, is_active, is_verified, verification_token, created_at, updated_at
 	`
 
 	var site models.Site
-	err := db.QueryRowContext(ctx, query, name, domain, apiKey, pq.StringArray(corsOrigins), isActive).Scan(
-		&site.ID, &site.Name, &site.Domain, &site.APIKey, pq.Array(&site.CORSOrigins), &site.IsActive, &site.CreatedAt, &site.UpdatedAt,
+	err := db.QueryRowContext(ctx, query, name, domain, pq.StringArray(corsOrigins), isActive).Scan(
+		&site.ID, &site.Name, &site.Domain, pq.Array(&site.CORSOrigins), &site.IsActive, &site.IsVerified, &site.VerificationToken, &site.CreatedAt, &site.UpdatedAt,
 	)
 	if err != nil {
 		t.Fatalf("Failed to create test site: %v", err)
 	}
 
+	// 테스트용 API 키 생성
+	site.APIKey = CreateTestAPIKey(ctx, t, db, site.ID, "default", false)
+
 	return site
 }
 
+// CreateTestAPIKey는 사이트에 테스트용 API 키를 추가하고 키 값을 반환합니다
+// testMode가 true면 샌드박스 데이터를 사용하는 "orb_test_" 키를 생성합니다
+func CreateTestAPIKey(ctx context.Context, t *testing.T, db DBTX, siteID int64, label string, testMode bool) string {
+	t.Helper()
+
+	prefix := models.APIKeyPrefixLive
+	if testMode {
+		prefix = models.APIKeyPrefixTest
+	}
+	apiKey := models.GenerateAPIKey(prefix)
+
+	_, err := db.ExecContext(ctx, `
+		INSERT INTO site_api_keys (site_id, label, api_key)
+		VALUES ($1, $2, $3)
+	`, siteID, label, apiKey)
+	if err != nil {
+		t.Fatalf("Failed to create test api key: %v", err)
+	}
+
+	return apiKey
+}
+
 // CreateTestPost는 테스트용 포스트를 생성하고 포스트 ID를 반환합니다
 func CreateTestPost(ctx context.Context, t *testing.T, db DBTX, siteID int64, slug string, title string) models.Post {
 	t.Helper()
@@ -132,16 +156,13 @@ func CreateTestPost(ctx context.Context, t *testing.T, db DBTX, siteID int64, sl
 func CreateTestSiteWithID(ctx context.Context, t *testing.T, db DBTX, id int64, name string, domain string, corsOrigins []string, isActive bool) {
 	t.Helper()
 
-	// 테스트용 API 키 생성
-	apiKey := models.GenerateAPIKey("orb_test_")
-
 	query := `
-		INSERT INTO sites (id, name, domain, api_key, cors_origins, is_active)
-		VALUES ($1, $2, $3, $4, $5, $6)
+		INSERT INTO sites (id, name, domain, cors_origins, is_active, is_verified, verified_at)
+		VALUES ($1, $2, $3, $4, $5, TRUE, NOW())
 		ON CONFLICT (id) DO NOTHING
 	`
 
-	_, err := db.ExecContext(ctx, query, id, name, domain, apiKey, pq.StringArray(corsOrigins), isActive)
+	_, err := db.ExecContext(ctx, query, id, name, domain, pq.StringArray(corsOrigins), isActive)
 	if err != nil {
 		t.Fatalf("Failed to create test site with ID: %v", err)
 	}
@@ -2,6 +2,7 @@ package validators
 
 import (
 	"fmt"
+	"net"
 	"strings"
 )
 
@@ -25,10 +26,13 @@ type CommentCreateInput struct {
 	Password   string `json:"password"`    // 비밀번호 (수정/삭제 시 사용)
 	Content    string `json:"content"`     // 댓글 내용
 	ParentID   *int   `json:"parent_id"`   // 대댓글인 경우 부모 댓글 ID (선택)
+	PostTitle  string `json:"post_title"`  // 포스트 제목 (선택, 포스트 제목이 아직 없을 때만 사용)
+	PostURL    string `json:"post_url"`    // 포스트 canonical URL (선택, 포스트 URL이 아직 없을 때만 사용)
 }
 
 // Validate는 댓글 생성 입력값을 검증
-// author_name(1-100자), password(4-50자), content(1-10000자), parent_id(양수) 검증
+// author_name(1-100자), password(4-50자), content(1-10000자), parent_id(양수),
+// post_title(선택, 500자 이하), post_url(선택, URL 형식, 2048자 이하) 검증
 func (c *CommentCreateInput) Validate() error {
 	errors := make(ValidationErrors)
 
@@ -59,6 +63,18 @@ func (c *CommentCreateInput) Validate() error {
 		errors["parent_id"] = "Parent ID must be a positive integer"
 	}
 
+	// 포스트 메타데이터 검증: 제공된 경우에만 확인
+	if len(strings.TrimSpace(c.PostTitle)) > 500 {
+		errors["post_title"] = "Post title must be 500 characters or less"
+	}
+	if c.PostURL != "" {
+		if len(c.PostURL) > 2048 {
+			errors["post_url"] = "Post URL must be 2048 characters or less"
+		} else if err := validateURL(c.PostURL); err != nil {
+			errors["post_url"] = "Post URL must be a valid http(s) URL"
+		}
+	}
+
 	if len(errors) > 0 {
 		return errors
 	}
@@ -114,3 +130,58 @@ func (c *CommentDeleteInput) Validate() error {
 	}
 	return nil
 }
+
+// 개인정보 삭제 요청 처리 방식
+const (
+	ErasureModeAnonymize = "anonymize" // 작성자 정보와 내용을 비우고 삭제 상태로 전환 (기본값)
+	ErasureModeDelete    = "delete"    // 댓글 행을 완전히 삭제
+)
+
+// CommentErasureInput은 개인정보 삭제 요청 시 입력 데이터 구조체 (Admin용)
+// 비어있지 않은 조건만 AND로 적용됩니다
+type CommentErasureInput struct {
+	AuthorName string `json:"author_name"` // 작성자 이름 (선택)
+	IPAddress  string `json:"ip_address"`  // 작성자 IP 주소 (선택)
+	Password   string `json:"password"`    // 댓글 수정/삭제용 비밀번호 (선택, author_name 필수)
+	Mode       string `json:"mode"`        // 처리 방식 (선택, anonymize 또는 delete, 기본값 anonymize)
+}
+
+// Validate는 개인정보 삭제 요청 입력값을 검증
+// author_name/ip_address/password 중 하나 이상 필수, ip_address(IP 형식), password(author_name과 함께 사용),
+// mode(anonymize 또는 delete) 검증
+func (c *CommentErasureInput) Validate() error {
+	errors := make(ValidationErrors)
+
+	authorName := strings.TrimSpace(c.AuthorName)
+	ipAddress := strings.TrimSpace(c.IPAddress)
+
+	// 조건 검증: 사이트의 모든 댓글이 삭제되지 않도록 최소 하나 필수
+	if authorName == "" && ipAddress == "" && c.Password == "" {
+		errors["criteria"] = "At least one of author_name, ip_address or password is required"
+	}
+
+	// 작성자 이름 검증: 100자 이하
+	if len(authorName) > 100 {
+		errors["author_name"] = "Author name must be 100 characters or less"
+	}
+
+	// IP 주소 검증: 제공된 경우 IP 형식 확인
+	if ipAddress != "" && net.ParseIP(ipAddress) == nil {
+		errors["ip_address"] = "Invalid IP address"
+	}
+
+	// 비밀번호 검증: bcrypt 비교 대상을 좁히기 위해 작성자 이름과 함께 사용
+	if c.Password != "" && authorName == "" {
+		errors["password"] = "Password requires author_name"
+	}
+
+	// 처리 방식 검증
+	if c.Mode != "" && c.Mode != ErasureModeAnonymize && c.Mode != ErasureModeDelete {
+		errors["mode"] = "Mode must be anonymize or delete"
+	}
+
+	if len(errors) > 0 {
+		return errors
+	}
+	return nil
+}
@@ -124,6 +124,39 @@ func TestValidateCommentCreate(t *testing.T) {
 			expectError:   true,
 			expectedField: "parent_id",
 		},
+		{
+			name: "Valid post metadata",
+			input: CommentCreateInput{
+				AuthorName: "John Doe",
+				Password:   "password123",
+				Content:    "This is a test comment",
+				PostTitle:  "How to use Go",
+				PostURL:    "https://blog.example.com/posts/how-to-use-go",
+			},
+			expectError: false,
+		},
+		{
+			name: "Post title too long",
+			input: CommentCreateInput{
+				AuthorName: "John Doe",
+				Password:   "password123",
+				Content:    "This is a test comment",
+				PostTitle:  strings.Repeat("a", 501),
+			},
+			expectError:   true,
+			expectedField: "post_title",
+		},
+		{
+			name: "Invalid post URL",
+			input: CommentCreateInput{
+				AuthorName: "John Doe",
+				Password:   "password123",
+				Content:    "This is a test comment",
+				PostURL:    "javascript:alert(1)",
+			},
+			expectError:   true,
+			expectedField: "post_url",
+		},
 	}
 
 	for _, tt := range tests {
@@ -269,6 +302,79 @@ func TestValidateCommentDelete(t *testing.T) {
 	}
 }
 
+func TestValidateCommentErasure(t *testing.T) {
+	tests := []struct {
+		name          string
+		input         CommentErasureInput
+		expectError   bool
+		expectedField string
+	}{
+		{
+			name:        "Valid author name only",
+			input:       CommentErasureInput{AuthorName: "홍길동"},
+			expectError: false,
+		},
+		{
+			name:        "Valid IPv6 address with delete mode",
+			input:       CommentErasureInput{IPAddress: "2001:db8::1", Mode: ErasureModeDelete},
+			expectError: false,
+		},
+		{
+			name:        "Valid author name and password",
+			input:       CommentErasureInput{AuthorName: "홍길동", Password: "test1234", Mode: ErasureModeAnonymize},
+			expectError: false,
+		},
+		{
+			name:          "No criteria",
+			input:         CommentErasureInput{AuthorName: "   "},
+			expectError:   true,
+			expectedField: "criteria",
+		},
+		{
+			name:          "Invalid IP address",
+			input:         CommentErasureInput{IPAddress: "999.0.0.1"},
+			expectError:   true,
+			expectedField: "ip_address",
+		},
+		{
+			name:          "Password without author name",
+			input:         CommentErasureInput{Password: "test1234"},
+			expectError:   true,
+			expectedField: "password",
+		},
+		{
+			name:          "Unknown mode",
+			input:         CommentErasureInput{AuthorName: "홍길동", Mode: "purge"},
+			expectError:   true,
+			expectedField: "mode",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.input.Validate()
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("Expected error but got none")
+					return
+				}
+				valErr, ok := err.(ValidationErrors)
+				if !ok {
+					t.Errorf("Expected ValidationErrors but got %T", err)
+					return
+				}
+				if _, exists := valErr[tt.expectedField]; !exists {
+					t.Errorf("Expected error for field %q but got errors: %v", tt.expectedField, valErr)
+				}
+			} else {
+				if err != nil {
+					t.Errorf("Expected no error but got: %v", err)
+				}
+			}
+		})
+	}
+}
+
 // Helper function to create int pointer
 func intPtr(i int) *int {
 	return &i
@@ -0,0 +1,123 @@
+package validators
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PostUpdateInput은 포스트 메타데이터 수정 시 입력 데이터 구조체 (Admin용)
+// 모든 필드가 포인터 타입: nil이면 수정하지 않음
+type PostUpdateInput struct {
+	Title *string `json:"title"` // 포스트 제목 (선택, 1-500자)
+	URL   *string `json:"url"`   // 포스트 canonical URL (선택, URL 형식, 빈 문자열이면 삭제)
+}
+
+// Validate는 포스트 수정 입력값을 검증
+// title(선택, 1-500자), url(선택, 빈 문자열 또는 URL 형식, 2048자 이하) 검증
+func (p *PostUpdateInput) Validate() error {
+	errors := make(ValidationErrors)
+
+	// 제목 검증: 제공된 경우에만 1-500자 확인
+	if p.Title != nil {
+		title := strings.TrimSpace(*p.Title)
+		if title == "" {
+			errors["title"] = "Title cannot be empty"
+		} else if len(title) > 500 {
+			errors["title"] = "Title must be 500 characters or less"
+		}
+	}
+
+	// URL 검증: 빈 문자열은 URL 삭제로 허용
+	if p.URL != nil && *p.URL != "" {
+		if len(*p.URL) > 2048 {
+			errors["url"] = "URL must be 2048 characters or less"
+		} else if err := validateURL(*p.URL); err != nil {
+			errors["url"] = "URL must be a valid http(s) URL"
+		}
+	}
+
+	if len(errors) > 0 {
+		return errors
+	}
+	return nil
+}
+
+// PostAliasInput은 포스트 slug 별칭 추가 시 입력 데이터 구조체 (Admin용)
+type PostAliasInput struct {
+	Slug string `json:"slug"` // 별칭으로 사용할 이전 slug (필수, 1-255자)
+}
+
+// Validate는 별칭 입력값을 검증
+// slug(필수, 1-255자) 검증
+func (p *PostAliasInput) Validate() error {
+	errors := make(ValidationErrors)
+
+	slug := strings.TrimSpace(p.Slug)
+	if slug == "" {
+		errors["slug"] = "Slug is required"
+	} else if len(slug) > 255 {
+		errors["slug"] = "Slug must be 255 characters or less"
+	}
+
+	if len(errors) > 0 {
+		return errors
+	}
+	return nil
+}
+
+// PostMergeInput은 포스트 병합 시 입력 데이터 구조체 (Admin용)
+type PostMergeInput struct {
+	SourcePostID int64 `json:"source_post_id"` // 댓글을 옮겨올 포스트 ID (필수, 병합 후 삭제됨)
+}
+
+// Validate는 병합 입력값을 검증
+// source_post_id(필수, 양수) 검증
+func (p *PostMergeInput) Validate() error {
+	errors := make(ValidationErrors)
+
+	if p.SourcePostID <= 0 {
+		errors["source_post_id"] = "Source post ID is required"
+	}
+
+	if len(errors) > 0 {
+		return errors
+	}
+	return nil
+}
+
+// MaxCommentCountSlugs는 댓글 수 일괄 조회 한 번에 요청할 수 있는 최대 slug 수
+const MaxCommentCountSlugs = 100
+
+// CommentCountsInput은 댓글 수 일괄 조회 시 입력 데이터 구조체
+type CommentCountsInput struct {
+	Slugs []string `json:"slugs"` // 조회할 포스트 slug 목록 (필수, 1-100개, 각 1-255자)
+}
+
+// Validate는 댓글 수 일괄 조회 입력값을 검증
+// slugs(필수, 1-100개, 각 slug 1-255자) 검증
+func (c *CommentCountsInput) Validate() error {
+	errors := make(ValidationErrors)
+
+	if len(c.Slugs) == 0 {
+		errors["slugs"] = "At least one slug is required"
+	} else if len(c.Slugs) > MaxCommentCountSlugs {
+		errors["slugs"] = fmt.Sprintf("At most %d slugs are allowed", MaxCommentCountSlugs)
+	} else {
+		for _, slug := range c.Slugs {
+			slug = strings.TrimSpace(slug)
+			if slug == "" {
+				errors["slugs"] = "Slugs cannot be empty"
+				break
+			}
+			if len(slug) > 255 {
+				errors["slugs"] = "Each slug must be 255 characters or less"
+				break
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return errors
+	}
+	return nil
+}
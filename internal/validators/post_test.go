@@ -0,0 +1,139 @@
+package validators
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestPostUpdateInput_Validate는 포스트 수정 입력값 검증 테스트
+func TestPostUpdateInput_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   PostUpdateInput
+		wantErr bool
+		errMsg  string
+	}{
+		{
+			name: "유효한 입력 - 모든 필드 수정",
+			input: PostUpdateInput{
+				Title: strPtr("How to use Go"),
+				URL:   strPtr("https://blog.example.com/posts/how-to-use-go"),
+			},
+			wantErr: false,
+		},
+		{
+			name:    "유효한 입력 - 모든 필드 nil (수정 없음)",
+			input:   PostUpdateInput{},
+			wantErr: false,
+		},
+		{
+			name: "유효한 입력 - url 빈 문자열 (삭제)",
+			input: PostUpdateInput{
+				URL: strPtr(""),
+			},
+			wantErr: false,
+		},
+		{
+			name: "title 공백만 - 실패",
+			input: PostUpdateInput{
+				Title: strPtr("   "),
+			},
+			wantErr: true,
+			errMsg:  "title",
+		},
+		{
+			name: "title 너무 긺 (500자 초과) - 실패",
+			input: PostUpdateInput{
+				Title: strPtr(strings.Repeat("a", 501)),
+			},
+			wantErr: true,
+			errMsg:  "title",
+		},
+		{
+			name: "url 잘못된 형식 - 실패",
+			input: PostUpdateInput{
+				URL: strPtr("ftp://blog.example.com/file"),
+			},
+			wantErr: true,
+			errMsg:  "url",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.input.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			// 에러 메시지에 특정 필드명이 포함되어야 함
+			if tt.wantErr && err != nil {
+				if !contains(err.Error(), tt.errMsg) {
+					t.Errorf("Validate() error = %v, want error containing %q", err, tt.errMsg)
+				}
+			}
+		})
+	}
+}
+
+// TestPostAliasInput_Validate는 별칭 입력값 검증 테스트
+func TestPostAliasInput_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   PostAliasInput
+		wantErr bool
+	}{
+		{name: "유효한 slug", input: PostAliasInput{Slug: "old-post-slug"}, wantErr: false},
+		{name: "slug 공백만 - 실패", input: PostAliasInput{Slug: "   "}, wantErr: true},
+		{name: "slug 너무 긺 (255자 초과) - 실패", input: PostAliasInput{Slug: strings.Repeat("a", 256)}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.input.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestPostMergeInput_Validate는 병합 입력값 검증 테스트
+func TestPostMergeInput_Validate(t *testing.T) {
+	if err := (&PostMergeInput{SourcePostID: 1}).Validate(); err != nil {
+		t.Errorf("expected no error, got: %v", err)
+	}
+	if err := (&PostMergeInput{}).Validate(); err == nil || !contains(err.Error(), "source_post_id") {
+		t.Errorf("expected source_post_id error, got: %v", err)
+	}
+}
+
+// TestCommentCountsInput_Validate는 댓글 수 일괄 조회 입력값 검증 테스트
+func TestCommentCountsInput_Validate(t *testing.T) {
+	tooMany := make([]string, MaxCommentCountSlugs+1)
+	for i := range tooMany {
+		tooMany[i] = "post"
+	}
+
+	tests := []struct {
+		name    string
+		input   CommentCountsInput
+		wantErr bool
+	}{
+		{name: "유효한 slug 목록", input: CommentCountsInput{Slugs: []string{"a", "b"}}, wantErr: false},
+		{name: "slug 없음 - 실패", input: CommentCountsInput{}, wantErr: true},
+		{name: "빈 slug 포함 - 실패", input: CommentCountsInput{Slugs: []string{"a", " "}}, wantErr: true},
+		{name: "slug 너무 긺 - 실패", input: CommentCountsInput{Slugs: []string{strings.Repeat("a", 256)}}, wantErr: true},
+		{name: "slug 개수 초과 - 실패", input: CommentCountsInput{Slugs: tooMany}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.input.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -1,8 +1,13 @@
 package validators
 
 import (
+	"fmt"
+	"net/mail"
 	"net/url"
 	"strings"
+	"time"
+
+	"github.com/june20516/orbithall/internal/models"
 )
 
 // SiteCreateInput은 사이트 생성 시 입력 데이터 구조체
@@ -54,13 +59,21 @@ func (s *SiteCreateInput) Validate() error {
 // SiteUpdateInput은 사이트 수정 시 입력 데이터 구조체
 // 모든 필드가 포인터 타입: nil이면 수정하지 않음
 type SiteUpdateInput struct {
-	Name        *string   `json:"name"`         // 사이트 이름 (선택, 1-100자)
-	CORSOrigins *[]string `json:"cors_origins"` // CORS 허용 오리진 목록 (선택, URL 형식)
-	IsActive    *bool     `json:"is_active"`    // 활성화 상태 (선택)
+	Name          *string   `json:"name"`            // 사이트 이름 (선택, 1-100자)
+	CORSOrigins   *[]string `json:"cors_origins"`    // CORS 허용 오리진 목록 (선택, URL 형식)
+	IsActive      *bool     `json:"is_active"`       // 활성화 상태 (선택)
+	AutoCloseDays *int      `json:"auto_close_days"` // 댓글 자동 마감 일수 (선택, 0-3650, 0이면 마감 없음)
+
+	PersonalDataRetentionDays   *int `json:"personal_data_retention_days"`   // IP/User-Agent 보관 일수 (선택, 0-3650, 0이면 무기한)
+	DeletedContentRetentionDays *int `json:"deleted_content_retention_days"` // 삭제된 댓글 내용 보관 일수 (선택, 0-3650, 0이면 무기한)
+
+	RateLimits *models.SiteRateLimits `json:"rate_limits"` // 작업별 요청 제한 (선택, 전체 교체, 분당 1-10000회, burst 1-1000)
 }
 
 // Validate는 사이트 수정 입력값을 검증
-// name(선택, 1-100자), cors_origins(선택, URL 형식), is_active(선택) 검증
+// name(선택, 1-100자), cors_origins(선택, URL 형식), is_active(선택), auto_close_days(선택, 0-3650),
+// personal_data_retention_days(선택, 0-3650), deleted_content_retention_days(선택, 0-3650),
+// rate_limits(선택, 작업별 분당 1-10000회, burst 1-1000) 검증
 func (s *SiteUpdateInput) Validate() error {
 	errors := make(ValidationErrors)
 
@@ -90,12 +103,269 @@ func (s *SiteUpdateInput) Validate() error {
 
 	// IsActive는 bool 타입이므로 별도 검증 불필요
 
+	// 자동 마감 일수 검증: 제공된 경우에만 0-3650 확인
+	if s.AutoCloseDays != nil && (*s.AutoCloseDays < 0 || *s.AutoCloseDays > 3650) {
+		errors["auto_close_days"] = "Auto close days must be between 0 and 3650"
+	}
+
+	// 보관 기간 검증: 제공된 경우에만 0-3650 확인
+	if s.PersonalDataRetentionDays != nil && (*s.PersonalDataRetentionDays < 0 || *s.PersonalDataRetentionDays > 3650) {
+		errors["personal_data_retention_days"] = "Personal data retention days must be between 0 and 3650"
+	}
+	if s.DeletedContentRetentionDays != nil && (*s.DeletedContentRetentionDays < 0 || *s.DeletedContentRetentionDays > 3650) {
+		errors["deleted_content_retention_days"] = "Deleted content retention days must be between 0 and 3650"
+	}
+
+	// 요청 제한 검증: 제공된 경우 설정한 작업마다 범위 확인
+	if s.RateLimits != nil {
+		limits := []struct {
+			action string
+			limit  *models.RateLimit
+		}{
+			{models.RateLimitActionCreate, s.RateLimits.Create},
+			{models.RateLimitActionUpdate, s.RateLimits.Update},
+			{models.RateLimitActionDelete, s.RateLimits.Delete},
+			{models.RateLimitActionList, s.RateLimits.List},
+		}
+		for _, l := range limits {
+			if l.limit == nil {
+				continue
+			}
+			if l.limit.PerMinute < 1 || l.limit.PerMinute > 10000 {
+				errors["rate_limits"] = fmt.Sprintf("Rate limit per_minute for %s must be between 1 and 10000", l.action)
+				break
+			}
+			if l.limit.Burst < 1 || l.limit.Burst > 1000 {
+				errors["rate_limits"] = fmt.Sprintf("Rate limit burst for %s must be between 1 and 1000", l.action)
+				break
+			}
+		}
+	}
+
 	if len(errors) > 0 {
 		return errors
 	}
 	return nil
 }
 
+// APIKeyCreateInput은 API 키 발급 시 입력 데이터 구조체
+type APIKeyCreateInput struct {
+	Label          string     `json:"label"`           // 키 라벨 (필수, 1-100자)
+	TestMode       bool       `json:"test_mode"`       // 테스트 모드 키(orb_test_) 발급 여부 (선택, 기본 false, 샌드박스 데이터 사용)
+	Secret         bool       `json:"secret"`          // 서버 전용 비밀 키(orb_secret_) 발급 여부 (선택, 기본 false)
+	Scopes         []string   `json:"scopes"`          // 비밀 키로 허용할 작업 목록 (비밀 키는 필수, 공개 키는 지정 불가)
+	AllowedOrigins []string   `json:"allowed_origins"` // 이 키로 허용할 Origin 목록 (선택, URL 형식, 비어있으면 사이트 설정 사용)
+	ExpiresAt      *time.Time `json:"expires_at"`      // 만료 시각 (선택, 미래 시각)
+}
+
+// Validate는 API 키 발급 입력값을 검증
+// label(필수, 1-100자), allowed_origins(선택, URL 형식), expires_at(선택, 미래 시각) 검증
+// 비밀 키는 scopes(필수, 알려진 scope)를 검증하고, 브라우저용 설정(test_mode, allowed_origins)은 허용하지 않음
+func (a *APIKeyCreateInput) Validate() error {
+	errors := make(ValidationErrors)
+
+	// 라벨 검증: 공백 제거 후 1-100자 확인
+	label := strings.TrimSpace(a.Label)
+	if label == "" {
+		errors["label"] = "Label is required"
+	} else if len(label) > 100 {
+		errors["label"] = "Label must be 100 characters or less"
+	}
+
+	// 허용 Origin 검증: 각 URL 형식 확인
+	for _, origin := range a.AllowedOrigins {
+		if err := validateURL(origin); err != nil {
+			errors["allowed_origins"] = err.Error()
+			break
+		}
+	}
+
+	// 만료 시각 검증: 제공된 경우 미래 시각이어야 함
+	if a.ExpiresAt != nil && !a.ExpiresAt.After(time.Now()) {
+		errors["expires_at"] = "Expiry must be in the future"
+	}
+
+	// 비밀 키 검증: scope 필수, 브라우저용 설정 불가
+	if a.Secret {
+		if len(a.Scopes) == 0 {
+			errors["scopes"] = "At least one scope is required for secret keys"
+		}
+		for _, scope := range a.Scopes {
+			if !models.IsValidAPIKeyScope(scope) {
+				errors["scopes"] = "Unknown scope: " + scope
+				break
+			}
+		}
+		if a.TestMode {
+			errors["test_mode"] = "Secret keys cannot be test mode keys"
+		}
+		if len(a.AllowedOrigins) > 0 {
+			errors["allowed_origins"] = "Secret keys cannot be used from browsers"
+		}
+	} else if len(a.Scopes) > 0 {
+		errors["scopes"] = "Scopes are only allowed for secret keys"
+	}
+
+	if len(errors) > 0 {
+		return errors
+	}
+	return nil
+}
+
+// 키 교체 시 이전 키 유예 기간 (시간 단위)
+const (
+	DefaultAPIKeyGracePeriodHours = 24
+	MaxAPIKeyGracePeriodHours     = 720
+)
+
+// APIKeyRotateInput은 API 키 교체 시 입력 데이터 구조체
+type APIKeyRotateInput struct {
+	GracePeriodHours *int `json:"grace_period_hours"` // 이전 키를 계속 사용할 수 있는 시간 (선택, 0-720, 기본 24, 0이면 즉시 만료)
+}
+
+// Validate는 API 키 교체 입력값을 검증
+// grace_period_hours(선택, 0-720) 검증
+func (a *APIKeyRotateInput) Validate() error {
+	errors := make(ValidationErrors)
+
+	if a.GracePeriodHours != nil && (*a.GracePeriodHours < 0 || *a.GracePeriodHours > MaxAPIKeyGracePeriodHours) {
+		errors["grace_period_hours"] = "Grace period hours must be between 0 and 720"
+	}
+
+	if len(errors) > 0 {
+		return errors
+	}
+	return nil
+}
+
+// GracePeriod는 이전 키의 유예 기간을 반환합니다 (지정하지 않으면 기본 24시간)
+func (a *APIKeyRotateInput) GracePeriod() time.Duration {
+	hours := DefaultAPIKeyGracePeriodHours
+	if a.GracePeriodHours != nil {
+		hours = *a.GracePeriodHours
+	}
+	return time.Duration(hours) * time.Hour
+}
+
+// SiteMemberRoleInput은 사이트 멤버 역할 변경 시 입력 데이터 구조체
+type SiteMemberRoleInput struct {
+	Role string `json:"role"` // 변경할 역할 (필수, owner/manager/moderator/viewer)
+}
+
+// Validate는 사이트 멤버 역할 변경 입력값을 검증
+// role(필수, owner/manager/moderator/viewer) 검증
+func (m *SiteMemberRoleInput) Validate() error {
+	errors := make(ValidationErrors)
+
+	m.Role = strings.TrimSpace(m.Role)
+	if !models.IsValidSiteRole(m.Role) {
+		errors["role"] = "Role must be one of owner, manager, moderator, viewer"
+	}
+
+	if len(errors) > 0 {
+		return errors
+	}
+	return nil
+}
+
+// 초대 유효 기간 (시간 단위)
+const (
+	DefaultInvitationExpiresHours = 168 // 7일
+	MaxInvitationExpiresHours     = 720 // 30일
+)
+
+// SiteInvitationCreateInput은 사이트 멤버 초대 생성 시 입력 데이터 구조체
+type SiteInvitationCreateInput struct {
+	Email        string `json:"email"`         // 초대할 이메일 (필수, 최대 255자)
+	Role         string `json:"role"`          // 수락 시 부여할 역할 (필수, owner/manager/moderator/viewer)
+	ExpiresHours *int   `json:"expires_hours"` // 초대 유효 기간 (선택, 1-720, 기본 168)
+}
+
+// Validate는 사이트 멤버 초대 입력값을 검증
+// email(필수, 이메일 형식), role(필수), expires_hours(선택, 1-720) 검증
+func (i *SiteInvitationCreateInput) Validate() error {
+	errors := make(ValidationErrors)
+
+	i.Email = strings.TrimSpace(i.Email)
+	if i.Email == "" {
+		errors["email"] = "Email is required"
+	} else if !isValidEmail(i.Email) {
+		errors["email"] = "Invalid email format"
+	}
+
+	i.Role = strings.TrimSpace(i.Role)
+	if !models.IsValidSiteRole(i.Role) {
+		errors["role"] = "Role must be one of owner, manager, moderator, viewer"
+	}
+
+	if i.ExpiresHours != nil && (*i.ExpiresHours < 1 || *i.ExpiresHours > MaxInvitationExpiresHours) {
+		errors["expires_hours"] = "Expires hours must be between 1 and 720"
+	}
+
+	if len(errors) > 0 {
+		return errors
+	}
+	return nil
+}
+
+// ExpiresIn은 초대 유효 기간을 반환합니다 (지정하지 않으면 기본 7일)
+func (i *SiteInvitationCreateInput) ExpiresIn() time.Duration {
+	hours := DefaultInvitationExpiresHours
+	if i.ExpiresHours != nil {
+		hours = *i.ExpiresHours
+	}
+	return time.Duration(hours) * time.Hour
+}
+
+// 소유권 이전 요청 유효 기간 (시간 단위)
+const (
+	DefaultTransferExpiresHours = 168 // 7일
+	MaxTransferExpiresHours     = 720 // 30일
+)
+
+// SiteTransferCreateInput은 사이트 소유권 이전 요청 시 입력 데이터 구조체
+type SiteTransferCreateInput struct {
+	Email        string `json:"email"`         // 받는 사람 이메일 (필수, 최대 255자)
+	ExpiresHours *int   `json:"expires_hours"` // 요청 유효 기간 (선택, 1-720, 기본 168)
+}
+
+// Validate는 사이트 소유권 이전 요청 입력값을 검증
+// email(필수, 이메일 형식), expires_hours(선택, 1-720) 검증
+func (t *SiteTransferCreateInput) Validate() error {
+	errors := make(ValidationErrors)
+
+	t.Email = strings.TrimSpace(t.Email)
+	if t.Email == "" {
+		errors["email"] = "Email is required"
+	} else if !isValidEmail(t.Email) {
+		errors["email"] = "Invalid email format"
+	}
+
+	if t.ExpiresHours != nil && (*t.ExpiresHours < 1 || *t.ExpiresHours > MaxTransferExpiresHours) {
+		errors["expires_hours"] = "Expires hours must be between 1 and 720"
+	}
+
+	if len(errors) > 0 {
+		return errors
+	}
+	return nil
+}
+
+// ExpiresIn은 소유권 이전 요청 유효 기간을 반환합니다 (지정하지 않으면 기본 7일)
+func (t *SiteTransferCreateInput) ExpiresIn() time.Duration {
+	hours := DefaultTransferExpiresHours
+	if t.ExpiresHours != nil {
+		hours = *t.ExpiresHours
+	}
+	return time.Duration(hours) * time.Hour
+}
+
+// isValidEmail은 이름 없이 주소만 있는 이메일 형식인지 확인하는 내부 헬퍼 함수 (최대 255자)
+func isValidEmail(email string) bool {
+	address, err := mail.ParseAddress(email)
+	return err == nil && address.Address == email && len(email) <= 255
+}
+
 // validateURL은 URL 형식을 검증하는 내부 헬퍼 함수
 // http:// 또는 https:// 스키마가 있는지 확인
 func validateURL(rawURL string) error {
@@ -2,6 +2,9 @@ package validators
 
 import (
 	"testing"
+	"time"
+
+	"github.com/june20516/orbithall/internal/models"
 )
 
 // TestSiteCreateInput_Validate는 사이트 생성 입력값 검증 테스트
@@ -245,6 +248,78 @@ func TestSiteUpdateInput_Validate(t *testing.T) {
 			wantErr: true,
 			errMsg:  "cors_origins",
 		},
+		{
+			name: "유효한 입력 - auto_close_days 0 (마감 없음)",
+			input: SiteUpdateInput{
+				AutoCloseDays: intPtr(0),
+			},
+			wantErr: false,
+		},
+		{
+			name: "유효한 입력 - auto_close_days 30",
+			input: SiteUpdateInput{
+				AutoCloseDays: intPtr(30),
+			},
+			wantErr: false,
+		},
+		{
+			name: "auto_close_days 음수 - 실패",
+			input: SiteUpdateInput{
+				AutoCloseDays: intPtr(-1),
+			},
+			wantErr: true,
+			errMsg:  "auto_close_days",
+		},
+		{
+			name: "유효한 입력 - 보관 기간 지정",
+			input: SiteUpdateInput{
+				PersonalDataRetentionDays:   intPtr(90),
+				DeletedContentRetentionDays: intPtr(0),
+			},
+			wantErr: false,
+		},
+		{
+			name: "personal_data_retention_days 음수 - 실패",
+			input: SiteUpdateInput{
+				PersonalDataRetentionDays: intPtr(-1),
+			},
+			wantErr: true,
+			errMsg:  "personal_data_retention_days",
+		},
+		{
+			name: "deleted_content_retention_days 범위 초과 - 실패",
+			input: SiteUpdateInput{
+				DeletedContentRetentionDays: intPtr(3651),
+			},
+			wantErr: true,
+			errMsg:  "deleted_content_retention_days",
+		},
+		{
+			name: "유효한 입력 - 요청 제한 지정",
+			input: SiteUpdateInput{
+				RateLimits: &models.SiteRateLimits{
+					Create:       &models.RateLimit{PerMinute: 30, Burst: 10},
+					KeyBySession: true,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "rate_limits per_minute 0 - 실패",
+			input: SiteUpdateInput{
+				RateLimits: &models.SiteRateLimits{List: &models.RateLimit{PerMinute: 0, Burst: 10}},
+			},
+			wantErr: true,
+			errMsg:  "rate_limits",
+		},
+		{
+			name: "rate_limits burst 범위 초과 - 실패",
+			input: SiteUpdateInput{
+				RateLimits: &models.SiteRateLimits{Delete: &models.RateLimit{PerMinute: 10, Burst: 1001}},
+			},
+			wantErr: true,
+			errMsg:  "rate_limits",
+		},
 	}
 
 	for _, tt := range tests {
@@ -265,6 +340,257 @@ func TestSiteUpdateInput_Validate(t *testing.T) {
 	}
 }
 
+// TestAPIKeyCreateInput_Validate는 API 키 발급 입력값 검증 테스트
+func TestAPIKeyCreateInput_Validate(t *testing.T) {
+	future := time.Now().Add(24 * time.Hour)
+	past := time.Now().Add(-time.Hour)
+
+	tests := []struct {
+		name    string
+		input   APIKeyCreateInput
+		wantErr bool
+		errMsg  string
+	}{
+		{
+			name:    "라벨만 지정 - 성공",
+			input:   APIKeyCreateInput{Label: "production"},
+			wantErr: false,
+		},
+		{
+			name: "허용 Origin과 만료 시각 지정 - 성공",
+			input: APIKeyCreateInput{
+				Label:          "staging",
+				AllowedOrigins: []string{"https://staging.example.com"},
+				ExpiresAt:      &future,
+			},
+			wantErr: false,
+		},
+		{
+			name:    "label 누락 - 실패",
+			input:   APIKeyCreateInput{Label: "   "},
+			wantErr: true,
+			errMsg:  "label",
+		},
+		{
+			name:    "allowed_origins 형식 오류 - 실패",
+			input:   APIKeyCreateInput{Label: "staging", AllowedOrigins: []string{"staging.example.com"}},
+			wantErr: true,
+			errMsg:  "allowed_origins",
+		},
+		{
+			name:    "expires_at 과거 시각 - 실패",
+			input:   APIKeyCreateInput{Label: "staging", ExpiresAt: &past},
+			wantErr: true,
+			errMsg:  "expires_at",
+		},
+		{
+			name:    "비밀 키와 scope 지정 - 성공",
+			input:   APIKeyCreateInput{Label: "backend", Secret: true, Scopes: []string{"comments:read_private", "comments:moderate"}},
+			wantErr: false,
+		},
+		{
+			name:    "비밀 키 scope 누락 - 실패",
+			input:   APIKeyCreateInput{Label: "backend", Secret: true},
+			wantErr: true,
+			errMsg:  "scopes",
+		},
+		{
+			name:    "알 수 없는 scope - 실패",
+			input:   APIKeyCreateInput{Label: "backend", Secret: true, Scopes: []string{"sites:delete"}},
+			wantErr: true,
+			errMsg:  "scopes",
+		},
+		{
+			name:    "공개 키에 scope 지정 - 실패",
+			input:   APIKeyCreateInput{Label: "widget", Scopes: []string{"posts:write"}},
+			wantErr: true,
+			errMsg:  "scopes",
+		},
+		{
+			name:    "비밀 키에 허용 Origin 지정 - 실패",
+			input:   APIKeyCreateInput{Label: "backend", Secret: true, Scopes: []string{"posts:write"}, AllowedOrigins: []string{"https://example.com"}},
+			wantErr: true,
+			errMsg:  "allowed_origins",
+		},
+		{
+			name:    "비밀 키를 테스트 모드로 지정 - 실패",
+			input:   APIKeyCreateInput{Label: "backend", Secret: true, Scopes: []string{"posts:write"}, TestMode: true},
+			wantErr: true,
+			errMsg:  "test_mode",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.input.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if tt.wantErr && err != nil {
+				if !contains(err.Error(), tt.errMsg) {
+					t.Errorf("Validate() error = %v, want error containing %q", err, tt.errMsg)
+				}
+			}
+		})
+	}
+}
+
+// TestAPIKeyRotateInput_Validate는 API 키 교체 입력값 검증과 유예 기간 기본값 테스트
+func TestAPIKeyRotateInput_Validate(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       APIKeyRotateInput
+		wantErr     bool
+		gracePeriod time.Duration
+	}{
+		{
+			name:        "미지정 - 기본 24시간",
+			input:       APIKeyRotateInput{},
+			gracePeriod: 24 * time.Hour,
+		},
+		{
+			name:        "0시간 - 즉시 만료",
+			input:       APIKeyRotateInput{GracePeriodHours: intPtr(0)},
+			gracePeriod: 0,
+		},
+		{
+			name:    "범위 초과 - 실패",
+			input:   APIKeyRotateInput{GracePeriodHours: intPtr(721)},
+			wantErr: true,
+		},
+		{
+			name:    "음수 - 실패",
+			input:   APIKeyRotateInput{GracePeriodHours: intPtr(-1)},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.input.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && tt.input.GracePeriod() != tt.gracePeriod {
+				t.Errorf("GracePeriod() = %v, want %v", tt.input.GracePeriod(), tt.gracePeriod)
+			}
+		})
+	}
+}
+
+// TestSiteMemberRoleInput_Validate는 사이트 멤버 역할 변경 입력값 검증 테스트
+func TestSiteMemberRoleInput_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		role    string
+		wantErr bool
+	}{
+		{name: "owner", role: "owner"},
+		{name: "manager", role: "manager"},
+		{name: "moderator - 공백 제거", role: " moderator "},
+		{name: "viewer", role: "viewer"},
+		{name: "빈 역할 - 실패", role: "", wantErr: true},
+		{name: "알 수 없는 역할 - 실패", role: "admin", wantErr: true},
+		{name: "대문자 - 실패", role: "Owner", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := SiteMemberRoleInput{Role: tt.role}
+			err := input.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestSiteInvitationCreateInput_Validate는 사이트 멤버 초대 입력값 검증과 유효 기간 기본값 테스트
+func TestSiteInvitationCreateInput_Validate(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     SiteInvitationCreateInput
+		wantErr   bool
+		expiresIn time.Duration
+	}{
+		{
+			name:      "유효 기간 미지정 - 기본 7일",
+			input:     SiteInvitationCreateInput{Email: " teammate@example.com ", Role: "manager"},
+			expiresIn: 7 * 24 * time.Hour,
+		},
+		{
+			name:      "유효 기간 지정",
+			input:     SiteInvitationCreateInput{Email: "teammate@example.com", Role: "viewer", ExpiresHours: intPtr(48)},
+			expiresIn: 48 * time.Hour,
+		},
+		{
+			name:    "이메일 누락 - 실패",
+			input:   SiteInvitationCreateInput{Role: "viewer"},
+			wantErr: true,
+		},
+		{
+			name:    "이메일 형식 오류 - 실패",
+			input:   SiteInvitationCreateInput{Email: "Teammate <teammate@example.com>", Role: "viewer"},
+			wantErr: true,
+		},
+		{
+			name:    "알 수 없는 역할 - 실패",
+			input:   SiteInvitationCreateInput{Email: "teammate@example.com", Role: "admin"},
+			wantErr: true,
+		},
+		{
+			name:    "유효 기간 0시간 - 실패",
+			input:   SiteInvitationCreateInput{Email: "teammate@example.com", Role: "viewer", ExpiresHours: intPtr(0)},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.input.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && tt.input.ExpiresIn() != tt.expiresIn {
+				t.Errorf("ExpiresIn() = %v, want %v", tt.input.ExpiresIn(), tt.expiresIn)
+			}
+		})
+	}
+}
+
+// TestSiteTransferCreateInput_Validate는 사이트 소유권 이전 요청 입력값 검증 테스트
+func TestSiteTransferCreateInput_Validate(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     SiteTransferCreateInput
+		wantErr   bool
+		expiresIn time.Duration
+	}{
+		{name: "기본 유효 기간", input: SiteTransferCreateInput{Email: "buyer@example.com"}, expiresIn: 7 * 24 * time.Hour},
+		{name: "유효 기간 지정", input: SiteTransferCreateInput{Email: "buyer@example.com", ExpiresHours: intPtr(24)}, expiresIn: 24 * time.Hour},
+		{name: "이메일 누락 - 실패", input: SiteTransferCreateInput{}, wantErr: true},
+		{name: "이메일 형식 오류 - 실패", input: SiteTransferCreateInput{Email: "buyer"}, wantErr: true},
+		{name: "유효 기간 초과 - 실패", input: SiteTransferCreateInput{Email: "buyer@example.com", ExpiresHours: intPtr(721)}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.input.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && tt.input.ExpiresIn() != tt.expiresIn {
+				t.Errorf("ExpiresIn() = %v, want %v", tt.input.ExpiresIn(), tt.expiresIn)
+			}
+		})
+	}
+}
+
 // 헬퍼 함수: 문자열이 특정 부분 문자열을 포함하는지 확인
 func contains(s, substr string) bool {
 	return len(s) >= len(substr) && (s == substr || len(substr) == 0 ||
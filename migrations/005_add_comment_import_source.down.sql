@@ -0,0 +1,10 @@
+-- 댓글 가져오기 출처 컬럼 삭제
+BEGIN;
+
+DROP INDEX IF EXISTS idx_comments_import_source;
+
+ALTER TABLE comments
+DROP COLUMN IF EXISTS import_source_id,
+DROP COLUMN IF EXISTS import_source;
+
+COMMIT;
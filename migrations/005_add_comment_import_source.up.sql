@@ -0,0 +1,16 @@
+-- 외부 댓글 플랫폼(Disqus, WordPress 등)에서 가져온 댓글의 출처 기록
+-- 같은 파일을 다시 가져와도 댓글이 중복 생성되지 않도록 원본 ID를 저장합니다
+BEGIN;
+
+-- import_source: 가져온 플랫폼 이름 (예: 'disqus', 'wordpress'), 직접 작성된 댓글은 NULL
+-- import_source_id: 원본 플랫폼에서의 댓글 ID
+ALTER TABLE comments
+ADD COLUMN import_source VARCHAR(20),
+ADD COLUMN import_source_id VARCHAR(100);
+
+-- 포스트 내에서 (출처, 원본 ID) 조합은 unique
+-- NULL은 서로 다른 값으로 취급되므로 직접 작성된 댓글에는 영향이 없음
+CREATE UNIQUE INDEX idx_comments_import_source
+ON comments(post_id, import_source, import_source_id);
+
+COMMIT;
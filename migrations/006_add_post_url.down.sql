@@ -0,0 +1,7 @@
+-- 포스트 URL 컬럼 삭제
+BEGIN;
+
+ALTER TABLE posts
+DROP COLUMN IF EXISTS url;
+
+COMMIT;
@@ -0,0 +1,9 @@
+-- 포스트의 canonical URL 저장
+-- 위젯이 댓글 작성 시 전달한 URL을 처음 한 번만 저장하며, 이후 변경은 Admin API로만 가능합니다
+BEGIN;
+
+-- url: 포스트의 canonical URL, 아직 모르는 경우 빈 문자열
+ALTER TABLE posts
+ADD COLUMN url VARCHAR(2048) NOT NULL DEFAULT '';
+
+COMMIT;
@@ -0,0 +1,10 @@
+-- 댓글 스레드 잠금 및 자동 마감 컬럼 삭제
+BEGIN;
+
+ALTER TABLE sites
+DROP COLUMN IF EXISTS auto_close_days;
+
+ALTER TABLE posts
+DROP COLUMN IF EXISTS is_locked;
+
+COMMIT;
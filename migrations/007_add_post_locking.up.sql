@@ -0,0 +1,18 @@
+-- 댓글 스레드 잠금 및 자동 마감
+-- 잠긴 포스트와 자동 마감 기간이 지난 포스트에는 새 댓글을 작성할 수 없습니다 (조회는 가능)
+BEGIN;
+
+-- ============================================
+-- posts: 관리자가 수동으로 잠근 포스트
+-- ============================================
+ALTER TABLE posts
+ADD COLUMN is_locked BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- ============================================
+-- sites: 포스트 생성 후 N일이 지나면 댓글 자동 마감 (0이면 마감하지 않음)
+-- ============================================
+ALTER TABLE sites
+ADD COLUMN auto_close_days INTEGER NOT NULL DEFAULT 0
+CHECK (auto_close_days >= 0);
+
+COMMIT;
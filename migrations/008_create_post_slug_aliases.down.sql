@@ -0,0 +1,6 @@
+-- 포스트 slug 별칭 테이블 삭제
+BEGIN;
+
+DROP TABLE IF EXISTS post_slug_aliases;
+
+COMMIT;
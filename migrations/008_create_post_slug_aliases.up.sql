@@ -0,0 +1,24 @@
+-- 포스트 slug 별칭
+-- 블로그 포스트의 URL(slug)이 바뀌어도 이전 slug로 같은 포스트의 댓글을 조회할 수 있도록 합니다
+BEGIN;
+
+-- ============================================
+-- post_slug_aliases 테이블
+-- ============================================
+-- 사이트 내에서 slug는 포스트의 slug 또는 별칭 중 하나로만 사용됩니다
+-- (포스트 slug와의 중복은 애플리케이션에서 검사)
+CREATE TABLE post_slug_aliases (
+    id BIGSERIAL PRIMARY KEY,
+    site_id BIGINT NOT NULL REFERENCES sites(id) ON DELETE CASCADE,
+    post_id BIGINT NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    slug VARCHAR(255) NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+
+    -- 별칭은 사이트 내에서 unique
+    UNIQUE(site_id, slug)
+);
+
+-- post_slug_aliases 테이블 인덱스
+CREATE INDEX idx_post_slug_aliases_post_id ON post_slug_aliases(post_id);
+
+COMMIT;
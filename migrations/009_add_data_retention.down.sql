@@ -0,0 +1,10 @@
+-- 댓글 작성자 개인정보 보관 기간 컬럼 삭제
+BEGIN;
+
+DROP INDEX IF EXISTS idx_comments_personal_data;
+
+ALTER TABLE sites
+DROP COLUMN IF EXISTS deleted_content_retention_days,
+DROP COLUMN IF EXISTS personal_data_retention_days;
+
+COMMIT;
@@ -0,0 +1,20 @@
+-- 댓글 작성자 개인정보 보관 기간
+-- 기간이 지난 IP 주소와 User-Agent는 익명화하고, 삭제된 댓글의 내용은 비웁니다
+BEGIN;
+
+-- ============================================
+-- sites: 사이트별 보관 기간 (0이면 무기한 보관)
+-- ============================================
+-- personal_data_retention_days: 댓글 작성 후 N일이 지나면 ip_address, user_agent 삭제
+-- deleted_content_retention_days: 댓글 삭제 후 N일이 지나면 작성자 이름, 내용, 비밀번호 삭제
+ALTER TABLE sites
+ADD COLUMN personal_data_retention_days INTEGER NOT NULL DEFAULT 0
+CHECK (personal_data_retention_days >= 0),
+ADD COLUMN deleted_content_retention_days INTEGER NOT NULL DEFAULT 0
+CHECK (deleted_content_retention_days >= 0);
+
+-- 익명화 대상 조회용 인덱스 (아직 개인정보가 남아있는 댓글만)
+CREATE INDEX idx_comments_personal_data ON comments(created_at)
+WHERE ip_address IS NOT NULL OR user_agent IS NOT NULL;
+
+COMMIT;
@@ -0,0 +1,17 @@
+-- 댓글 작성자 IP 주소 암호화 컬럼 삭제
+-- 주의: 암호화된 IP는 평문으로 복원되지 않습니다 (복호화에는 애플리케이션 키가 필요)
+BEGIN;
+
+DROP INDEX IF EXISTS idx_comments_personal_data;
+CREATE INDEX idx_comments_personal_data ON comments(created_at)
+WHERE ip_address IS NOT NULL OR user_agent IS NOT NULL;
+
+DROP INDEX IF EXISTS idx_comments_plain_ip_address;
+DROP INDEX IF EXISTS idx_comments_ip_address_hash;
+
+ALTER TABLE comments
+DROP COLUMN IF EXISTS ip_address_masked,
+DROP COLUMN IF EXISTS ip_address_hash,
+DROP COLUMN IF EXISTS ip_address_encrypted;
+
+COMMIT;
@@ -0,0 +1,29 @@
+-- 댓글 작성자 IP 주소 암호화 저장
+-- 평문 INET 대신 애플리케이션 키로 암호화한 값과 조회용 해시, 표시용 마스킹 값을 저장합니다
+-- 기존 평문 ip_address는 서버의 백그라운드 작업이 암호화한 뒤 NULL로 비웁니다
+BEGIN;
+
+-- ============================================
+-- comments: 암호화된 IP 주소 컬럼 추가
+-- ============================================
+-- ip_address_encrypted: "키ID:base64(nonce + 암호문)" (AES-256-GCM, 키 교체 시 키ID로 구분)
+-- ip_address_hash: HMAC-SHA256 hex (같은 IP 검색, 개인정보 삭제 요청 등 동등 비교용)
+-- ip_address_masked: 위젯 표시용 마스킹 값 (예: 192.168.***.***)
+ALTER TABLE comments
+ADD COLUMN ip_address_encrypted TEXT,
+ADD COLUMN ip_address_hash VARCHAR(64),
+ADD COLUMN ip_address_masked VARCHAR(64);
+
+CREATE INDEX idx_comments_ip_address_hash ON comments(ip_address_hash)
+WHERE ip_address_hash IS NOT NULL;
+
+-- 아직 암호화되지 않은 평문 IP 조회용 인덱스 (마이그레이션 작업용)
+CREATE INDEX idx_comments_plain_ip_address ON comments(id)
+WHERE ip_address IS NOT NULL;
+
+-- 익명화 대상 조회용 인덱스를 암호화 컬럼 기준으로 재생성
+DROP INDEX IF EXISTS idx_comments_personal_data;
+CREATE INDEX idx_comments_personal_data ON comments(created_at)
+WHERE ip_address IS NOT NULL OR ip_address_encrypted IS NOT NULL OR user_agent IS NOT NULL;
+
+COMMIT;
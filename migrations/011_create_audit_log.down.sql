@@ -0,0 +1,6 @@
+-- 관리자 작업 감사 로그 테이블 삭제
+BEGIN;
+
+DROP TABLE IF EXISTS audit_log;
+
+COMMIT;
@@ -0,0 +1,30 @@
+-- 관리자 작업 감사 로그
+-- 누가 언제 어떤 사이트 설정을 바꾸거나 댓글/포스트를 관리했는지 기록합니다
+BEGIN;
+
+-- ============================================
+-- audit_log: 관리자 작업 기록
+-- ============================================
+-- site_id: 사이트가 삭제된 뒤에도 삭제 기록을 남기기 위해 외래키를 두지 않음
+-- actor_user_id: 작업한 관리자 (사용자가 삭제되면 NULL)
+-- action: 작업 종류 (예: site.update, post.lock, comment.restore)
+-- target_type/target_id: 작업 대상 (site, post, comment)
+-- before_state/after_state: 변경 전/후 상태 (JSON, 해당 없으면 NULL)
+-- ip_address_encrypted: 요청 IP (댓글 IP와 같은 키로 암호화)
+CREATE TABLE audit_log (
+    id BIGSERIAL PRIMARY KEY,
+    site_id BIGINT NOT NULL,
+    actor_user_id BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    action VARCHAR(50) NOT NULL,
+    target_type VARCHAR(20) NOT NULL,
+    target_id BIGINT NOT NULL,
+    before_state JSONB,
+    after_state JSONB,
+    ip_address_encrypted TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- 사이트별 최신순 조회용 인덱스
+CREATE INDEX idx_audit_log_site_id ON audit_log(site_id, created_at DESC, id DESC);
+
+COMMIT;
@@ -0,0 +1,27 @@
+-- 사이트별 API 키를 sites.api_key로 되돌림
+-- 사이트마다 가장 먼저 발급된 사용 가능한 키 하나만 남습니다
+BEGIN;
+
+ALTER TABLE sites ADD COLUMN api_key VARCHAR(100);
+
+UPDATE sites s
+SET api_key = k.api_key
+FROM (
+    SELECT DISTINCT ON (site_id) site_id, api_key
+    FROM site_api_keys
+    ORDER BY site_id, (revoked_at IS NOT NULL OR (expires_at IS NOT NULL AND expires_at <= NOW())), created_at, id
+) k
+WHERE k.site_id = s.id;
+
+-- 키가 없는 사이트는 새 키 발급
+UPDATE sites
+SET api_key = 'orb_live_' || substr(md5(random()::text || id::text), 1, 24)
+WHERE api_key IS NULL;
+
+ALTER TABLE sites ALTER COLUMN api_key SET NOT NULL;
+ALTER TABLE sites ADD CONSTRAINT sites_api_key_key UNIQUE (api_key);
+CREATE INDEX idx_sites_api_key ON sites(api_key);
+
+DROP TABLE IF EXISTS site_api_keys;
+
+COMMIT;
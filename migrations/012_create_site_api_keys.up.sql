@@ -0,0 +1,35 @@
+-- 사이트별 API 키 분리
+-- 사이트마다 여러 개의 API 키를 발급하고, 교체(rotation)와 폐기(revocation)를 지원합니다
+BEGIN;
+
+-- ============================================
+-- site_api_keys: 사이트 API 키
+-- ============================================
+-- label: 관리자가 구분하기 위한 이름 (예: "production", "staging")
+-- allowed_origins: 이 키로 허용할 Origin 목록 (NULL이면 사이트의 cors_origins 사용)
+-- expires_at: 만료 시각 (NULL이면 만료 없음, 교체 시 유예 기간 종료 시각으로 설정)
+-- revoked_at: 폐기 시각 (NULL이 아니면 즉시 사용 불가)
+-- last_used_at: 마지막 사용 시각 (캐시 미스 시 갱신되므로 대략적인 값)
+CREATE TABLE site_api_keys (
+    id BIGSERIAL PRIMARY KEY,
+    site_id BIGINT NOT NULL REFERENCES sites(id) ON DELETE CASCADE,
+    label VARCHAR(100) NOT NULL,
+    api_key VARCHAR(100) NOT NULL UNIQUE,
+    allowed_origins TEXT[],
+    expires_at TIMESTAMPTZ,
+    revoked_at TIMESTAMPTZ,
+    last_used_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_site_api_keys_site_id ON site_api_keys(site_id);
+
+-- 기존 사이트의 API 키를 기본 키로 이전
+INSERT INTO site_api_keys (site_id, label, api_key, created_at)
+SELECT id, 'default', api_key, created_at
+FROM sites;
+
+-- sites.api_key 제거 (인덱스도 함께 삭제됨)
+ALTER TABLE sites DROP COLUMN api_key;
+
+COMMIT;
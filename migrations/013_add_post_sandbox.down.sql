@@ -0,0 +1,15 @@
+-- 테스트 모드(샌드박스) 데이터 분리 롤백
+-- 샌드박스 데이터는 실제 데이터와 slug가 겹칠 수 있으므로 먼저 삭제합니다 (댓글/별칭은 CASCADE)
+BEGIN;
+
+DELETE FROM posts WHERE is_sandbox = TRUE;
+
+ALTER TABLE post_slug_aliases DROP CONSTRAINT post_slug_aliases_site_id_is_sandbox_slug_key;
+ALTER TABLE post_slug_aliases ADD CONSTRAINT post_slug_aliases_site_id_slug_key UNIQUE (site_id, slug);
+ALTER TABLE post_slug_aliases DROP COLUMN is_sandbox;
+
+ALTER TABLE posts DROP CONSTRAINT posts_site_id_is_sandbox_slug_key;
+ALTER TABLE posts ADD CONSTRAINT posts_site_id_slug_key UNIQUE (site_id, slug);
+ALTER TABLE posts DROP COLUMN is_sandbox;
+
+COMMIT;
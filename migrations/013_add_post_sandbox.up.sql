@@ -0,0 +1,24 @@
+-- 테스트 모드(샌드박스) 데이터 분리
+-- orb_test_ 키로 작성된 포스트/댓글을 같은 사이트의 실제 데이터와 분리해 저장합니다
+BEGIN;
+
+-- ============================================
+-- posts.is_sandbox
+-- ============================================
+-- 테스트 모드 키로 생성된 포스트 (댓글은 포스트를 따라 분리됨)
+-- 같은 slug라도 실제 포스트와 샌드박스 포스트는 서로 다른 포스트입니다
+ALTER TABLE posts ADD COLUMN is_sandbox BOOLEAN NOT NULL DEFAULT FALSE;
+
+ALTER TABLE posts DROP CONSTRAINT posts_site_id_slug_key;
+ALTER TABLE posts ADD CONSTRAINT posts_site_id_is_sandbox_slug_key UNIQUE (site_id, is_sandbox, slug);
+
+-- ============================================
+-- post_slug_aliases.is_sandbox
+-- ============================================
+-- 별칭도 포스트와 같은 영역에서만 unique (포스트의 값을 그대로 따름)
+ALTER TABLE post_slug_aliases ADD COLUMN is_sandbox BOOLEAN NOT NULL DEFAULT FALSE;
+
+ALTER TABLE post_slug_aliases DROP CONSTRAINT post_slug_aliases_site_id_slug_key;
+ALTER TABLE post_slug_aliases ADD CONSTRAINT post_slug_aliases_site_id_is_sandbox_slug_key UNIQUE (site_id, is_sandbox, slug);
+
+COMMIT;
@@ -0,0 +1,10 @@
+-- 서버용 비밀 API 키 롤백
+-- 비밀 키는 공개 API에 사용할 수 없도록 만든 키이므로 함께 삭제합니다
+BEGIN;
+
+ALTER TABLE audit_log DROP COLUMN actor_api_key_id;
+
+DELETE FROM site_api_keys WHERE scopes IS NOT NULL;
+ALTER TABLE site_api_keys DROP COLUMN scopes;
+
+COMMIT;
@@ -0,0 +1,18 @@
+-- 서버용 비밀 API 키
+-- 브라우저에 노출되지 않는 비밀 키(orb_secret_)로 백엔드에서 댓글을 조회하고 관리할 수 있도록 합니다
+BEGIN;
+
+-- ============================================
+-- site_api_keys.scopes
+-- ============================================
+-- 비밀 키가 허용하는 작업 목록 (예: comments:read_private, comments:moderate, posts:write)
+-- 공개 키(orb_live_, orb_test_)는 NULL
+ALTER TABLE site_api_keys ADD COLUMN scopes TEXT[];
+
+-- ============================================
+-- audit_log.actor_api_key_id
+-- ============================================
+-- 비밀 키로 수행한 작업은 관리자 대신 사용한 키를 기록 (키가 삭제되면 NULL)
+ALTER TABLE audit_log ADD COLUMN actor_api_key_id BIGINT REFERENCES site_api_keys(id) ON DELETE SET NULL;
+
+COMMIT;
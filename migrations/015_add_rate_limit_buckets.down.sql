@@ -0,0 +1,6 @@
+-- 인스턴스 간 공유 Rate Limiting 롤백
+BEGIN;
+
+DROP TABLE IF EXISTS rate_limit_buckets;
+
+COMMIT;
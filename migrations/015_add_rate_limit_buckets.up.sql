@@ -0,0 +1,17 @@
+-- 인스턴스 간 공유 Rate Limiting
+-- 여러 API 인스턴스가 같은 제한을 적용하고, 재시작해도 제한 상태가 유지되도록 토큰 버킷을 DB에 저장합니다
+BEGIN;
+
+-- ============================================
+-- rate_limit_buckets 테이블
+-- ============================================
+-- 제한 종류(bucket)와 키(IP 주소)별 토큰 버킷 상태
+CREATE TABLE rate_limit_buckets (
+    bucket VARCHAR(100) NOT NULL,         -- 제한 종류 (예: create-comment)
+    key VARCHAR(255) NOT NULL,            -- 제한 대상 (IP 주소)
+    tokens DOUBLE PRECISION NOT NULL,     -- 마지막 갱신 시점에 남은 토큰 수
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),    -- 마지막 갱신 시각 (이후 경과 시간만큼 토큰 충전)
+    PRIMARY KEY (bucket, key)
+);
+
+COMMIT;
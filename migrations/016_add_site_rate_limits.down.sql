@@ -0,0 +1,6 @@
+-- 사이트별 Rate Limiting 설정 롤백
+BEGIN;
+
+ALTER TABLE sites DROP COLUMN rate_limits;
+
+COMMIT;
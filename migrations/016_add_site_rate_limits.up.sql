@@ -0,0 +1,12 @@
+-- 사이트별 Rate Limiting 설정
+-- 사이트마다 댓글 작성/수정/삭제/조회의 요청 제한을 설정할 수 있도록 합니다
+BEGIN;
+
+-- ============================================
+-- sites.rate_limits
+-- ============================================
+-- 작업별 요청 제한 (예: {"create": {"per_minute": 10, "burst": 5}, "key_by_session": false})
+-- 설정하지 않은 작업은 서버의 기본 제한을 적용
+ALTER TABLE sites ADD COLUMN rate_limits JSONB NOT NULL DEFAULT '{}';
+
+COMMIT;
@@ -0,0 +1,14 @@
+-- 사이트 도메인 소유권 검증 롤백
+-- 같은 도메인의 사이트가 여러 개 있으면 UNIQUE 제약조건을 다시 추가할 수 없으므로,
+-- 롤백 전에 검증되지 않은 중복 사이트를 정리해야 합니다
+BEGIN;
+
+ALTER TABLE sites DROP CONSTRAINT sites_active_requires_verified;
+DROP INDEX idx_sites_domain_verified;
+ALTER TABLE sites ADD CONSTRAINT sites_domain_key UNIQUE (domain);
+
+ALTER TABLE sites DROP COLUMN verified_at;
+ALTER TABLE sites DROP COLUMN verification_token;
+ALTER TABLE sites DROP COLUMN is_verified;
+
+COMMIT;
@@ -0,0 +1,29 @@
+-- 사이트 도메인 소유권 검증
+-- 선착순으로 다른 사람의 도메인을 등록해 실제 소유자를 막지 못하도록,
+-- 도메인 소유권을 검증한 사이트만 활성화할 수 있고 같은 도메인은 검증된 사이트 하나만 가질 수 있도록 합니다
+BEGIN;
+
+-- ============================================
+-- sites 도메인 검증 컬럼
+-- ============================================
+-- is_verified: 도메인 소유권 검증 여부 (DNS TXT 레코드 또는 well-known HTTP 파일)
+-- verification_token: 사이트 관리자가 도메인에 게시할 검증 토큰
+-- verified_at: 검증에 성공한 시각
+ALTER TABLE sites ADD COLUMN is_verified BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE sites ADD COLUMN verification_token VARCHAR(64) NOT NULL DEFAULT replace(gen_random_uuid()::TEXT, '-', '');
+ALTER TABLE sites ADD COLUMN verified_at TIMESTAMPTZ;
+
+-- 기존 사이트는 이미 도메인을 사용 중이므로 검증된 것으로 간주
+UPDATE sites SET is_verified = TRUE, verified_at = NOW();
+
+-- ============================================
+-- 도메인 UNIQUE 제약조건 변경
+-- ============================================
+-- 검증되지 않은 사이트는 같은 도메인을 여러 개 등록할 수 있고, 검증된 사이트만 도메인을 독점 (대소문자 구분 없음)
+ALTER TABLE sites DROP CONSTRAINT sites_domain_key;
+CREATE UNIQUE INDEX idx_sites_domain_verified ON sites(LOWER(domain)) WHERE is_verified = TRUE;
+
+-- 검증되지 않은 사이트는 활성화할 수 없음
+ALTER TABLE sites ADD CONSTRAINT sites_active_requires_verified CHECK (is_verified OR NOT is_active);
+
+COMMIT;
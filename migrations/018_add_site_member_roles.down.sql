@@ -0,0 +1,7 @@
+-- 사이트 멤버 역할 롤백
+-- 역할 구분 전에는 owner만 사이트에 접근할 수 있으므로, owner가 아닌 멤버는 접근 권한을 잃습니다
+BEGIN;
+
+ALTER TABLE user_sites DROP CONSTRAINT user_sites_role_check;
+
+COMMIT;
@@ -0,0 +1,15 @@
+-- 사이트 멤버 역할
+-- user_sites.role에 owner 외의 역할(manager, moderator, viewer)을 허용하고, 정의되지 않은 역할은 저장할 수 없도록 합니다
+BEGIN;
+
+-- ============================================
+-- user_sites.role 제약조건
+-- ============================================
+-- owner: 멤버 관리, 사이트 삭제를 포함한 모든 권한
+-- manager: 사이트 설정, API 키, 포스트 관리와 댓글 관리
+-- moderator: 댓글 조회/관리와 포스트 잠금
+-- viewer: 사이트 정보, 통계, 포스트 목록 조회
+ALTER TABLE user_sites ADD CONSTRAINT user_sites_role_check
+    CHECK (role IN ('owner', 'manager', 'moderator', 'viewer'));
+
+COMMIT;
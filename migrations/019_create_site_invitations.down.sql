@@ -0,0 +1,6 @@
+-- 사이트 멤버 초대 제거
+BEGIN;
+
+DROP TABLE IF EXISTS site_invitations;
+
+COMMIT;
@@ -0,0 +1,35 @@
+-- 사이트 멤버 초대
+-- 아직 가입하지 않은 사용자도 이메일로 초대하고, 로그인 후 초대 토큰으로 수락하면 지정한 역할로 사이트에 연결합니다
+BEGIN;
+
+-- ============================================
+-- site_invitations: 사이트 멤버 초대
+-- ============================================
+-- email: 초대받은 이메일 (수락하는 사용자의 이메일과 대소문자 구분 없이 일치해야 함)
+-- role: 수락 시 부여할 역할
+-- token_hash: 초대 토큰의 SHA-256 해시 (토큰 원문은 생성 응답에서만 반환)
+-- invited_by: 초대한 사용자 (사용자가 삭제되면 NULL)
+-- expires_at: 만료 시각 (이후에는 수락 불가)
+-- accepted_at/accepted_by: 수락 시각과 수락한 사용자 (한 번만 수락 가능)
+-- revoked_at: 취소 시각 (NULL이 아니면 수락 불가)
+CREATE TABLE site_invitations (
+    id BIGSERIAL PRIMARY KEY,
+    site_id BIGINT NOT NULL REFERENCES sites(id) ON DELETE CASCADE,
+    email VARCHAR(255) NOT NULL,
+    role VARCHAR(20) NOT NULL CHECK (role IN ('owner', 'manager', 'moderator', 'viewer')),
+    token_hash VARCHAR(64) NOT NULL UNIQUE,
+    invited_by BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    accepted_at TIMESTAMPTZ,
+    accepted_by BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    revoked_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_site_invitations_site_id ON site_invitations(site_id, created_at DESC);
+
+-- 같은 이메일에 대한 대기 중인 초대는 사이트당 하나 (다시 초대하면 이전 초대를 취소)
+CREATE UNIQUE INDEX idx_site_invitations_pending ON site_invitations(site_id, LOWER(email))
+    WHERE accepted_at IS NULL AND revoked_at IS NULL;
+
+COMMIT;
@@ -0,0 +1,6 @@
+-- 사이트 소유권 이전 요청 제거
+BEGIN;
+
+DROP TABLE IF EXISTS site_ownership_transfers;
+
+COMMIT;
@@ -0,0 +1,35 @@
+-- 사이트 소유권 이전
+-- 사이트를 삭제하지 않고(댓글 유지) 다른 계정으로 넘길 수 있도록, 현재 owner가 이메일로 이전을 요청하고 받는 사람이 수락하는 2단계 이전을 지원합니다
+BEGIN;
+
+-- ============================================
+-- site_ownership_transfers: 사이트 소유권 이전 요청
+-- ============================================
+-- from_user_id: 이전을 요청한 owner (수락 시 받는 사람의 이전 역할, 멤버가 아니었으면 manager로 변경)
+-- to_email: 받는 사람 이메일 (수락하는 사용자의 이메일과 대소문자 구분 없이 일치해야 함)
+-- expires_at: 만료 시각 (이후에는 수락 불가)
+-- accepted_at/accepted_by: 수락 시각과 수락한 사용자
+-- declined_at: 받는 사람이 거절한 시각
+-- cancelled_at: 요청한 owner가 취소한 시각 (새 요청으로 대체된 경우 포함)
+CREATE TABLE site_ownership_transfers (
+    id BIGSERIAL PRIMARY KEY,
+    site_id BIGINT NOT NULL REFERENCES sites(id) ON DELETE CASCADE,
+    from_user_id BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    to_email VARCHAR(255) NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    accepted_at TIMESTAMPTZ,
+    accepted_by BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    declined_at TIMESTAMPTZ,
+    cancelled_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- 대기 중인 이전 요청은 사이트당 하나
+CREATE UNIQUE INDEX idx_site_ownership_transfers_pending ON site_ownership_transfers(site_id)
+    WHERE accepted_at IS NULL AND declined_at IS NULL AND cancelled_at IS NULL;
+
+-- 받는 사람의 대기 중인 이전 요청 조회
+CREATE INDEX idx_site_ownership_transfers_to_email ON site_ownership_transfers(LOWER(to_email))
+    WHERE accepted_at IS NULL AND declined_at IS NULL AND cancelled_at IS NULL;
+
+COMMIT;
@@ -0,0 +1,20 @@
+-- 사용자와 외부 ID 공급자 계정 연결 제거 (users.google_id 복원)
+BEGIN;
+
+ALTER TABLE users ADD COLUMN google_id VARCHAR(255);
+
+UPDATE users u
+SET google_id = i.subject
+FROM user_identities i
+WHERE i.user_id = u.id AND i.provider = 'google';
+
+-- Google 계정이 연결되지 않은 사용자(OIDC로만 가입)는 Google로 로그인할 수 없도록 자리표시 값을 채움
+UPDATE users SET google_id = 'unlinked:' || id WHERE google_id IS NULL;
+
+ALTER TABLE users ALTER COLUMN google_id SET NOT NULL;
+ALTER TABLE users ADD CONSTRAINT users_google_id_key UNIQUE (google_id);
+CREATE INDEX idx_users_google_id ON users(google_id);
+
+DROP TABLE IF EXISTS user_identities;
+
+COMMIT;
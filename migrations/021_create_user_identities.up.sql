@@ -0,0 +1,33 @@
+-- 사용자와 외부 ID 공급자 계정 연결
+-- Google 외의 OIDC 공급자로도 로그인할 수 있도록 users.google_id 대신 user_identities로 계정을 연결합니다
+BEGIN;
+
+-- ============================================
+-- user_identities: 사용자 ↔ ID 공급자 계정
+-- ============================================
+-- provider: ID 공급자 이름 (google, 또는 OIDC_PROVIDER_NAME으로 설정한 이름)
+-- subject: 공급자 계정의 고유 식별자 (ID Token의 sub 클레임)
+-- email: 연결할 때 공급자가 알려준 이메일 (참고용)
+-- last_login_at: 이 계정으로 마지막으로 로그인한 시각
+CREATE TABLE user_identities (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    provider VARCHAR(50) NOT NULL,
+    subject VARCHAR(255) NOT NULL,
+    email VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    last_login_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (provider, subject)
+);
+
+CREATE INDEX idx_user_identities_user_id ON user_identities(user_id);
+
+-- 기존 Google 계정 연결 이전
+INSERT INTO user_identities (user_id, provider, subject, email, created_at, last_login_at)
+SELECT id, 'google', google_id, email, COALESCE(created_at, NOW()), COALESCE(updated_at, NOW())
+FROM users;
+
+DROP INDEX IF EXISTS idx_users_google_id;
+ALTER TABLE users DROP COLUMN google_id;
+
+COMMIT;
@@ -0,0 +1,6 @@
+-- Admin 로그인 세션 제거
+BEGIN;
+
+DROP TABLE IF EXISTS sessions;
+
+COMMIT;
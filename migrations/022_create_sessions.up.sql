@@ -0,0 +1,30 @@
+-- Admin 로그인 세션
+-- 짧게 유지되는 access token(JWT)과 세션마다 교체되는 refresh token으로 로그인을 유지하고, 세션 단위로 로그아웃할 수 있도록 합니다
+BEGIN;
+
+-- ============================================
+-- sessions: Admin 사용자 로그인 세션
+-- ============================================
+-- refresh_token_hash: 현재 refresh token의 SHA-256 해시 (갱신할 때마다 교체)
+-- previous_token_hash: 직전 refresh token의 해시 (이미 교체된 토큰이 다시 사용되면 탈취로 보고 세션을 폐기)
+-- user_agent, ip_address_masked: 세션을 만들거나 마지막으로 갱신한 클라이언트 정보 (IP는 마스킹해 저장)
+-- last_used_at: 마지막으로 로그인/갱신한 시각
+-- expires_at: refresh token 만료 시각 (갱신할 때마다 연장)
+-- revoked_at: 로그아웃 시각 (NULL이 아니면 access token과 refresh token 모두 거부)
+CREATE TABLE sessions (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    refresh_token_hash VARCHAR(64) NOT NULL UNIQUE,
+    previous_token_hash VARCHAR(64),
+    user_agent TEXT NOT NULL DEFAULT '',
+    ip_address_masked VARCHAR(64) NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    last_used_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMPTZ NOT NULL,
+    revoked_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_sessions_user_id ON sessions(user_id, last_used_at DESC);
+CREATE INDEX idx_sessions_previous_token_hash ON sessions(previous_token_hash) WHERE previous_token_hash IS NOT NULL;
+
+COMMIT;
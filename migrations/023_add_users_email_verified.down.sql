@@ -0,0 +1,6 @@
+-- 사용자 이메일 검증 여부 제거
+BEGIN;
+
+ALTER TABLE users DROP COLUMN IF EXISTS email_verified;
+
+COMMIT;
@@ -0,0 +1,9 @@
+-- 사용자 이메일 검증 여부
+-- ID 공급자가 검증한 이메일(email_verified 클레임)로 로그인한 사용자만 계정 연결, 초대 수락, 소유권 이전 수락을 할 수 있도록 검증 여부를 저장합니다
+BEGIN;
+
+-- 기존 사용자는 요청 본문의 이메일로 가입했을 수 있으므로 검증되지 않은 상태로 시작하고,
+-- 연결된 공급자 계정으로 검증된 같은 이메일로 다시 로그인하면 검증됩니다
+ALTER TABLE users ADD COLUMN email_verified BOOLEAN NOT NULL DEFAULT FALSE;
+
+COMMIT;
@@ -0,0 +1,6 @@
+-- 사이트 소유권 이전 토큰 제거
+BEGIN;
+
+ALTER TABLE site_ownership_transfers DROP COLUMN IF EXISTS token_hash;
+
+COMMIT;
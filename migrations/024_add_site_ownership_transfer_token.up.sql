@@ -0,0 +1,13 @@
+-- 사이트 소유권 이전 토큰
+-- 받는 사람 이메일로만 전송한 일회용 토큰을 제시해야 이전 요청을 수락하거나 거절할 수 있도록 토큰 해시를 저장합니다
+BEGIN;
+
+-- token_hash: 이전 토큰의 SHA-256 해시 (토큰 원문은 받는 사람 알림으로만 전송)
+ALTER TABLE site_ownership_transfers ADD COLUMN token_hash VARCHAR(64) UNIQUE;
+
+-- 토큰 없이 만든 대기 중인 요청은 수락할 수 없으므로 취소 (owner가 다시 요청)
+UPDATE site_ownership_transfers
+SET cancelled_at = NOW()
+WHERE token_hash IS NULL AND accepted_at IS NULL AND declined_at IS NULL AND cancelled_at IS NULL;
+
+COMMIT;
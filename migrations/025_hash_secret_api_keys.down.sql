@@ -0,0 +1,14 @@
+-- 비밀 API 키 해시 저장 롤백
+-- 해시에서 비밀 키 원문을 복구할 수 없으므로 비밀 키는 삭제합니다
+BEGIN;
+
+DELETE FROM site_api_keys WHERE api_key IS NULL;
+
+ALTER TABLE site_api_keys
+    DROP CONSTRAINT site_api_keys_key_check,
+    DROP COLUMN key_hash,
+    DROP COLUMN key_prefix,
+    DROP COLUMN key_last4,
+    ALTER COLUMN api_key SET NOT NULL;
+
+COMMIT;
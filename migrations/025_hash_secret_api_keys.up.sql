@@ -0,0 +1,26 @@
+-- 비밀 API 키 해시 저장
+-- 비밀 키(orb_secret_)는 원문 대신 SHA-256 해시와 표시용 prefix, 마지막 4자만 저장합니다
+BEGIN;
+
+-- key_hash: 비밀 키의 SHA-256 해시 (hex, 키 조회에 사용)
+-- key_prefix, key_last4: 마스킹된 비밀 키를 표시하기 위한 prefix와 마지막 4자
+-- 공개 키(orb_live_, orb_test_)는 브라우저에 노출되는 값이므로 api_key에 원문을 저장하고 세 컬럼은 NULL
+ALTER TABLE site_api_keys
+    ADD COLUMN key_hash VARCHAR(64) UNIQUE,
+    ADD COLUMN key_prefix VARCHAR(20),
+    ADD COLUMN key_last4 VARCHAR(4),
+    ALTER COLUMN api_key DROP NOT NULL;
+
+-- 이미 저장된 비밀 키를 해시로 바꾸고 원문 제거
+UPDATE site_api_keys
+SET key_hash = encode(sha256(convert_to(api_key, 'UTF8')), 'hex'),
+    key_prefix = 'orb_secret_',
+    key_last4 = right(api_key, 4),
+    api_key = NULL
+WHERE api_key LIKE 'orb\_secret\_%';
+
+-- 모든 키는 원문(공개 키)과 해시(비밀 키) 중 하나만 가짐
+ALTER TABLE site_api_keys
+    ADD CONSTRAINT site_api_keys_key_check CHECK ((api_key IS NULL) <> (key_hash IS NULL));
+
+COMMIT;
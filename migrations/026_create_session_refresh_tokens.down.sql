@@ -0,0 +1,20 @@
+-- 교체된 refresh token 기록 롤백
+-- 세션마다 가장 최근에 교체된 토큰만 previous_token_hash로 되돌립니다
+BEGIN;
+
+ALTER TABLE sessions ADD COLUMN previous_token_hash VARCHAR(64);
+
+UPDATE sessions s
+SET previous_token_hash = (
+    SELECT token_hash
+    FROM session_refresh_tokens t
+    WHERE t.session_id = s.id
+    ORDER BY rotated_at DESC
+    LIMIT 1
+);
+
+CREATE INDEX idx_sessions_previous_token_hash ON sessions(previous_token_hash) WHERE previous_token_hash IS NOT NULL;
+
+DROP TABLE session_refresh_tokens;
+
+COMMIT;
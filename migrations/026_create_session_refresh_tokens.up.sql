@@ -0,0 +1,27 @@
+-- 교체된 refresh token 기록
+-- 직전 토큰뿐 아니라 세션에서 교체된 모든 refresh token의 재사용을 감지해 세션을 폐기할 수 있도록 합니다
+BEGIN;
+
+-- ============================================
+-- session_refresh_tokens: 세션에서 이미 교체된 refresh token
+-- ============================================
+-- token_hash: 교체된 refresh token의 SHA-256 해시 (다시 사용되면 탈취로 보고 세션을 폐기)
+-- 세션이 삭제되면 함께 삭제됩니다
+CREATE TABLE session_refresh_tokens (
+    token_hash VARCHAR(64) PRIMARY KEY,
+    session_id BIGINT NOT NULL REFERENCES sessions(id) ON DELETE CASCADE,
+    rotated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_session_refresh_tokens_session_id ON session_refresh_tokens(session_id);
+
+-- 기존 직전 토큰 이전
+INSERT INTO session_refresh_tokens (token_hash, session_id)
+SELECT previous_token_hash, id
+FROM sessions
+WHERE previous_token_hash IS NOT NULL;
+
+-- sessions.previous_token_hash 제거 (인덱스도 함께 삭제됨)
+ALTER TABLE sessions DROP COLUMN previous_token_hash;
+
+COMMIT;